@@ -31,6 +31,7 @@ type NodeDetailResp struct {
 	EditorAccount    string                 `json:"editor_account"`
 	PublisherAccount string                 `json:"publisher_account" gorm:"-"`
 	PV               int64                  `json:"pv" gorm:"-"`
+	ExternalID       string                 `json:"external_id"`
 }
 
 type NodePermissionReq struct {
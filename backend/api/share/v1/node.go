@@ -26,4 +26,6 @@ type ShareNodeDetailResp struct {
 	PublisherAccount string                        `json:"publisher_account"`
 	List             []*domain.ShareNodeDetailItem `json:"list" gorm:"-"`
 	PV               int64                         `json:"pv" gorm:"-"`
+	// 其他语言版本，仅在存在已发布的翻译时返回
+	Translations []*domain.NodeTranslationLink `json:"translations" gorm:"-"`
 }
@@ -74,7 +74,8 @@ func createApp() (*App, error) {
 	ragRepository := mq2.NewRAGRepository(mqProducer)
 	userRepository := pg2.NewUserRepository(db, logger)
 	kbRepo := cache2.NewKBRepo(cacheCache)
-	knowledgeBaseUsecase, err := usecase.NewKnowledgeBaseUsecase(knowledgeBaseRepository, nodeRepository, ragRepository, userRepository, ragService, kbRepo, logger, configConfig)
+	settingRepository := pg2.NewSettingRepository(db, logger)
+	knowledgeBaseUsecase, err := usecase.NewKnowledgeBaseUsecase(knowledgeBaseRepository, nodeRepository, ragRepository, userRepository, settingRepository, ragService, kbRepo, logger, configConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -89,7 +90,7 @@ func createApp() (*App, error) {
 	conversationRepository := pg2.NewConversationRepository(db, logger)
 	modelRepository := pg2.NewModelRepository(db, logger)
 	promptRepo := pg2.NewPromptRepo(db, logger)
-	llmUsecase := usecase.NewLLMUsecase(configConfig, ragService, conversationRepository, knowledgeBaseRepository, nodeRepository, modelRepository, promptRepo, logger)
+	llmUsecase := usecase.NewLLMUsecase(configConfig, ragService, conversationRepository, knowledgeBaseRepository, nodeRepository, modelRepository, promptRepo, settingRepository, logger)
 	knowledgeBaseHandler := v1.NewKnowledgeBaseHandler(baseHandler, echo, knowledgeBaseUsecase, llmUsecase, authMiddleware, logger)
 	appRepository := pg2.NewAppRepository(db, logger)
 	minioClient, err := s3.NewMinioClient(configConfig)
@@ -99,8 +100,11 @@ func createApp() (*App, error) {
 	authRepo := pg2.NewAuthRepo(db, logger, cacheCache)
 	systemSettingRepo := pg2.NewSystemSettingRepo(db, logger)
 	modelUsecase := usecase.NewModelUsecase(modelRepository, nodeRepository, ragRepository, ragService, logger, configConfig, knowledgeBaseRepository, systemSettingRepo)
-	nodeUsecase := usecase.NewNodeUsecase(nodeRepository, appRepository, ragRepository, userRepository, knowledgeBaseRepository, llmUsecase, ragService, logger, minioClient, modelRepository, authRepo, modelUsecase)
-	nodeHandler := v1.NewNodeHandler(baseHandler, echo, nodeUsecase, authMiddleware, logger)
+	nodeTranslationRepository := pg2.NewNodeTranslationRepository(db, logger)
+	nodeUsecase := usecase.NewNodeUsecase(nodeRepository, appRepository, ragRepository, userRepository, knowledgeBaseRepository, llmUsecase, ragService, logger, minioClient, modelRepository, authRepo, modelUsecase, nodeTranslationRepository)
+	nodeLinkRepository := pg2.NewNodeLinkRepository(db, logger)
+	nodeLinkUsecase := usecase.NewNodeLinkUsecase(nodeLinkRepository, nodeRepository, logger)
+	nodeHandler := v1.NewNodeHandler(baseHandler, echo, nodeUsecase, nodeLinkUsecase, authMiddleware, logger)
 	geoRepo := cache2.NewGeoCache(cacheCache, db, logger)
 	ipdbIPDB, err := ipdb.NewIPDB(configConfig, logger)
 	if err != nil {
@@ -109,13 +113,18 @@ func createApp() (*App, error) {
 	ipAddressRepo := ipdb2.NewIPAddressRepo(ipdbIPDB, logger)
 	conversationUsecase := usecase.NewConversationUsecase(conversationRepository, nodeRepository, geoRepo, logger, ipAddressRepo, authRepo)
 	blockWordRepo := pg2.NewBlockWordRepo(db, logger)
-	chatUsecase, err := usecase.NewChatUsecase(llmUsecase, knowledgeBaseRepository, conversationUsecase, modelUsecase, appRepository, blockWordRepo, authRepo, logger)
+	chatStreamRepo := cache2.NewChatStreamRepo(cacheCache)
+	curatedAnswerRepository := pg2.NewCuratedAnswerRepository(db, logger)
+	curatedAnswerUsecase := usecase.NewCuratedAnswerUsecase(curatedAnswerRepository, conversationRepository, llmUsecase, modelUsecase, logger)
+	fileUsecase := usecase.NewFileUsecase(logger, minioClient, configConfig)
+	imageCaptionRepository := pg2.NewImageCaptionRepository(db, logger)
+	imageUsecase := usecase.NewImageUsecase(fileUsecase, modelUsecase, llmUsecase, imageCaptionRepository, logger)
+	chatUsecase, err := usecase.NewChatUsecase(llmUsecase, knowledgeBaseRepository, conversationUsecase, modelUsecase, appRepository, blockWordRepo, authRepo, chatStreamRepo, curatedAnswerUsecase, imageUsecase, logger)
 	if err != nil {
 		return nil, err
 	}
 	appUsecase := usecase.NewAppUsecase(appRepository, authRepo, nodeRepository, nodeUsecase, logger, configConfig, chatUsecase, cacheCache)
 	appHandler := v1.NewAppHandler(echo, baseHandler, logger, authMiddleware, appUsecase, modelUsecase, conversationUsecase, configConfig)
-	fileUsecase := usecase.NewFileUsecase(logger, minioClient, configConfig)
 	fileHandler := v1.NewFileHandler(echo, baseHandler, logger, authMiddleware, minioClient, configConfig, fileUsecase)
 	modelHandler := v1.NewModelHandler(echo, baseHandler, logger, authMiddleware, modelUsecase, llmUsecase)
 	conversationHandler := v1.NewConversationHandler(echo, baseHandler, logger, authMiddleware, conversationUsecase)
@@ -144,6 +153,35 @@ func createApp() (*App, error) {
 	}
 	authV1Handler := v1.NewAuthV1Handler(echo, baseHandler, logger, authUsecase)
 	licenseHandler := v1.NewLicenseHandler(echo, baseHandler, logger, authMiddleware)
+	curatedAnswerHandler := v1.NewCuratedAnswerHandler(echo, baseHandler, logger, authMiddleware, curatedAnswerUsecase)
+	evalRepository := pg2.NewEvalRepository(db, logger)
+	jobRepository := mq2.NewJobRepository(mqProducer)
+	evalUsecase := usecase.NewEvalUsecase(evalRepository, jobRepository, promptRepo, llmUsecase, modelUsecase, logger)
+	evalHandler := v1.NewEvalHandler(echo, baseHandler, logger, authMiddleware, evalUsecase)
+	nodeChunkEditRepository := pg2.NewNodeChunkEditRepository(db, logger)
+	nodeChunkUsecase := usecase.NewNodeChunkUsecase(nodeRepository, knowledgeBaseRepository, nodeChunkEditRepository, ragService, logger)
+	nodeChunkHandler := v1.NewNodeChunkHandler(echo, baseHandler, logger, authMiddleware, nodeChunkUsecase)
+	nodeTranslationUsecase := usecase.NewNodeTranslationUsecase(nodeTranslationRepository, nodeRepository, jobRepository, llmUsecase, modelUsecase, logger)
+	nodeTranslationHandler := v1.NewNodeTranslationHandler(echo, baseHandler, logger, authMiddleware, nodeTranslationUsecase)
+	summaryJobRepository := pg2.NewSummaryJobRepository(db, logger)
+	summaryJobUsecase := usecase.NewSummaryJobUsecase(summaryJobRepository, nodeRepository, jobRepository, llmUsecase, modelUsecase, logger)
+	summaryJobHandler := v1.NewSummaryJobHandler(echo, baseHandler, logger, authMiddleware, summaryJobUsecase)
+	gitSourceRepository := pg2.NewGitSourceRepository(db, logger)
+	gitSourceUsecase := usecase.NewGitSourceUsecase(gitSourceRepository, nodeRepository, nodeUsecase, knowledgeBaseUsecase, fileUsecase, jobRepository, configConfig, logger)
+	gitSourceHandler := v1.NewGitSourceHandler(echo, baseHandler, logger, authMiddleware, gitSourceUsecase)
+	nodeImportJobRepository := pg2.NewNodeImportJobRepository(db, logger)
+	nodeImportUsecase := usecase.NewNodeImportUsecase(nodeImportJobRepository, nodeRepository, nodeUsecase, fileUsecase, jobRepository, logger)
+	nodeImportHandler := v1.NewNodeImportHandler(echo, baseHandler, logger, authMiddleware, nodeImportUsecase)
+	crawlerSubscriptionRepository := pg2.NewCrawlerSubscriptionRepository(db, logger)
+	crawlerSubscriptionUsecase := usecase.NewCrawlerSubscriptionUsecase(crawlerSubscriptionRepository, nodeRepository, nodeUsecase, knowledgeBaseUsecase, crawlerUsecase, jobRepository, logger)
+	crawlerSubscriptionHandler := v1.NewCrawlerSubscriptionHandler(echo, baseHandler, logger, authMiddleware, crawlerSubscriptionUsecase)
+	importJobRepository := pg2.NewImportJobRepository(db, logger)
+	importJobUsecase := usecase.NewImportJobUsecase(importJobRepository, nodeRepository, nodeUsecase, crawlerUsecase, jobRepository, logger)
+	importJobHandler := v1.NewImportJobHandler(echo, baseHandler, logger, authMiddleware, importJobUsecase)
+	linkCheckRepository := pg2.NewLinkCheckRepository(db, logger)
+	linkCheckUsecase := usecase.NewLinkCheckUsecase(linkCheckRepository, nodeRepository, minioClient, jobRepository, logger)
+	linkCheckHandler := v1.NewLinkCheckHandler(echo, baseHandler, logger, authMiddleware, linkCheckUsecase)
+	nodeLinkHandler := v1.NewNodeLinkHandler(echo, baseHandler, logger, authMiddleware, nodeLinkUsecase)
 
 	// Pro handlers (路由在各 handler 的 New 函数中自动注册)
 	contributeRepo := pg2.NewContributeRepo(db, logger)
@@ -157,20 +195,31 @@ func createApp() (*App, error) {
 	_ = pro.NewCommentModerateHandler(echo, baseHandler, logger, authMiddleware)
 	_ = pro.NewReleaseHandler(echo, baseHandler, logger, authMiddleware)
 	apiHandlers := &v1.APIHandlers{
-		UserHandler:          userHandler,
-		KnowledgeBaseHandler: knowledgeBaseHandler,
-		NodeHandler:          nodeHandler,
-		AppHandler:           appHandler,
-		FileHandler:          fileHandler,
-		ModelHandler:         modelHandler,
-		ConversationHandler:  conversationHandler,
-		CrawlerHandler:       crawlerHandler,
-		CreationHandler:      creationHandler,
-		StatHandler:          statHandler,
-		SystemHandler:        systemHandler,
-		CommentHandler:       commentHandler,
-		AuthV1Handler:        authV1Handler,
-		LicenseHandler:       licenseHandler,
+		UserHandler:                userHandler,
+		KnowledgeBaseHandler:       knowledgeBaseHandler,
+		NodeHandler:                nodeHandler,
+		AppHandler:                 appHandler,
+		FileHandler:                fileHandler,
+		ModelHandler:               modelHandler,
+		ConversationHandler:        conversationHandler,
+		CrawlerHandler:             crawlerHandler,
+		CreationHandler:            creationHandler,
+		StatHandler:                statHandler,
+		SystemHandler:              systemHandler,
+		CommentHandler:             commentHandler,
+		AuthV1Handler:              authV1Handler,
+		LicenseHandler:             licenseHandler,
+		CuratedAnswerHandler:       curatedAnswerHandler,
+		EvalHandler:                evalHandler,
+		NodeChunkHandler:           nodeChunkHandler,
+		NodeTranslationHandler:     nodeTranslationHandler,
+		SummaryJobHandler:          summaryJobHandler,
+		GitSourceHandler:           gitSourceHandler,
+		NodeImportHandler:          nodeImportHandler,
+		CrawlerSubscriptionHandler: crawlerSubscriptionHandler,
+		ImportJobHandler:           importJobHandler,
+		LinkCheckHandler:           linkCheckHandler,
+		NodeLinkHandler:            nodeLinkHandler,
 	}
 	shareNodeHandler := share.NewShareNodeHandler(baseHandler, echo, nodeUsecase, logger)
 	shareAppHandler := share.NewShareAppHandler(echo, baseHandler, logger, appUsecase)
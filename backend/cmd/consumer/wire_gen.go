@@ -48,7 +48,8 @@ func createApp() (*App, error) {
 	conversationRepository := pg2.NewConversationRepository(db, logger)
 	modelRepository := pg2.NewModelRepository(db, logger)
 	promptRepo := pg2.NewPromptRepo(db, logger)
-	llmUsecase := usecase.NewLLMUsecase(configConfig, ragService, conversationRepository, knowledgeBaseRepository, nodeRepository, modelRepository, promptRepo, logger)
+	settingRepository := pg2.NewSettingRepository(db, logger)
+	llmUsecase := usecase.NewLLMUsecase(configConfig, ragService, conversationRepository, knowledgeBaseRepository, nodeRepository, modelRepository, promptRepo, settingRepository, logger)
 	mqProducer, err := mq.NewMQProducer(configConfig, logger)
 	if err != nil {
 		return nil, err
@@ -56,11 +57,20 @@ func createApp() (*App, error) {
 	ragRepository := mq2.NewRAGRepository(mqProducer)
 	systemSettingRepo := pg2.NewSystemSettingRepo(db, logger)
 	modelUsecase := usecase.NewModelUsecase(modelRepository, nodeRepository, ragRepository, ragService, logger, configConfig, knowledgeBaseRepository, systemSettingRepo)
-	ragmqHandler, err := mq3.NewRAGMQHandler(mqConsumer, logger, ragService, nodeRepository, knowledgeBaseRepository, llmUsecase, modelUsecase)
+	minioClient, err := s3.NewMinioClient(configConfig)
+	if err != nil {
+		return nil, err
+	}
+	fileUsecase := usecase.NewFileUsecase(logger, minioClient, configConfig)
+	imageCaptionRepository := pg2.NewImageCaptionRepository(db, logger)
+	imageUsecase := usecase.NewImageUsecase(fileUsecase, modelUsecase, llmUsecase, imageCaptionRepository, logger)
+	nodeChunkEditRepository := pg2.NewNodeChunkEditRepository(db, logger)
+	nodeChunkUsecase := usecase.NewNodeChunkUsecase(nodeRepository, knowledgeBaseRepository, nodeChunkEditRepository, ragService, logger)
+	ragmqHandler, err := mq3.NewRAGMQHandler(mqConsumer, logger, ragService, nodeRepository, knowledgeBaseRepository, llmUsecase, modelUsecase, imageUsecase, nodeChunkUsecase, settingRepository)
 	if err != nil {
 		return nil, err
 	}
-	ragDocUpdateHandler, err := mq3.NewRagDocUpdateHandler(mqConsumer, logger, nodeRepository)
+	ragDocUpdateHandler, err := mq3.NewRagDocUpdateHandler(mqConsumer, logger, nodeRepository, nodeChunkUsecase)
 	if err != nil {
 		return nil, err
 	}
@@ -79,19 +89,83 @@ func createApp() (*App, error) {
 	authRepo := pg2.NewAuthRepo(db, logger, cacheCache)
 	statUseCase := usecase.NewStatUseCase(statRepository, nodeRepository, conversationRepository, appRepository, ipAddressRepo, geoRepo, authRepo, knowledgeBaseRepository, logger)
 	userRepository := pg2.NewUserRepository(db, logger)
-	minioClient, err := s3.NewMinioClient(configConfig)
+	nodeTranslationRepository := pg2.NewNodeTranslationRepository(db, logger)
+	nodeUsecase := usecase.NewNodeUsecase(nodeRepository, appRepository, ragRepository, userRepository, knowledgeBaseRepository, llmUsecase, ragService, logger, minioClient, modelRepository, authRepo, modelUsecase, nodeTranslationRepository)
+	crawlerSubscriptionRepository := pg2.NewCrawlerSubscriptionRepository(db, logger)
+	kbRepo := cache2.NewKBRepo(cacheCache)
+	knowledgeBaseUsecase, err := usecase.NewKnowledgeBaseUsecase(knowledgeBaseRepository, nodeRepository, ragRepository, userRepository, settingRepository, ragService, kbRepo, logger, configConfig)
+	if err != nil {
+		return nil, err
+	}
+	crawlerUsecase, err := usecase.NewCrawlerUsecase(logger, mqConsumer, cacheCache)
+	if err != nil {
+		return nil, err
+	}
+	jobRepository := mq2.NewJobRepository(mqProducer)
+	crawlerSubscriptionUsecase := usecase.NewCrawlerSubscriptionUsecase(crawlerSubscriptionRepository, nodeRepository, nodeUsecase, knowledgeBaseUsecase, crawlerUsecase, jobRepository, logger)
+	importJobRepository := pg2.NewImportJobRepository(db, logger)
+	importJobUsecase := usecase.NewImportJobUsecase(importJobRepository, nodeRepository, nodeUsecase, crawlerUsecase, jobRepository, logger)
+	linkCheckRepository := pg2.NewLinkCheckRepository(db, logger)
+	linkCheckUsecase := usecase.NewLinkCheckUsecase(linkCheckRepository, nodeRepository, minioClient, jobRepository, logger)
+	summaryJobRepository := pg2.NewSummaryJobRepository(db, logger)
+	summaryJobUsecase := usecase.NewSummaryJobUsecase(summaryJobRepository, nodeRepository, jobRepository, llmUsecase, modelUsecase, logger)
+	nodeImportJobRepository := pg2.NewNodeImportJobRepository(db, logger)
+	nodeImportUsecase := usecase.NewNodeImportUsecase(nodeImportJobRepository, nodeRepository, nodeUsecase, fileUsecase, jobRepository, logger)
+	curatedAnswerRepository := pg2.NewCuratedAnswerRepository(db, logger)
+	curatedAnswerUsecase := usecase.NewCuratedAnswerUsecase(curatedAnswerRepository, conversationRepository, llmUsecase, modelUsecase, logger)
+	cronHandler, err := mq3.NewStatCronHandler(logger, statRepository, statUseCase, nodeUsecase, crawlerSubscriptionUsecase, importJobUsecase, linkCheckUsecase, summaryJobUsecase, nodeImportUsecase, curatedAnswerUsecase)
+	if err != nil {
+		return nil, err
+	}
+	evalRepository := pg2.NewEvalRepository(db, logger)
+	evalUsecase := usecase.NewEvalUsecase(evalRepository, jobRepository, promptRepo, llmUsecase, modelUsecase, logger)
+	evalMQHandler, err := mq3.NewEvalMQHandler(mqConsumer, logger, evalUsecase)
+	if err != nil {
+		return nil, err
+	}
+	nodeTranslationUsecase := usecase.NewNodeTranslationUsecase(nodeTranslationRepository, nodeRepository, jobRepository, llmUsecase, modelUsecase, logger)
+	translateMQHandler, err := mq3.NewTranslateMQHandler(mqConsumer, logger, nodeTranslationUsecase)
+	if err != nil {
+		return nil, err
+	}
+	summaryMQHandler, err := mq3.NewSummaryMQHandler(mqConsumer, logger, summaryJobUsecase)
+	if err != nil {
+		return nil, err
+	}
+	gitSourceRepository := pg2.NewGitSourceRepository(db, logger)
+	gitSourceUsecase := usecase.NewGitSourceUsecase(gitSourceRepository, nodeRepository, nodeUsecase, knowledgeBaseUsecase, fileUsecase, jobRepository, configConfig, logger)
+	gitSyncMQHandler, err := mq3.NewGitSyncMQHandler(mqConsumer, logger, gitSourceUsecase)
+	if err != nil {
+		return nil, err
+	}
+	crawlerSyncMQHandler, err := mq3.NewCrawlerSyncMQHandler(mqConsumer, logger, crawlerSubscriptionUsecase)
+	if err != nil {
+		return nil, err
+	}
+	importMQHandler, err := mq3.NewImportMQHandler(mqConsumer, logger, importJobUsecase)
+	if err != nil {
+		return nil, err
+	}
+	linkCheckMQHandler, err := mq3.NewLinkCheckMQHandler(mqConsumer, logger, linkCheckUsecase)
 	if err != nil {
 		return nil, err
 	}
-	nodeUsecase := usecase.NewNodeUsecase(nodeRepository, appRepository, ragRepository, userRepository, knowledgeBaseRepository, llmUsecase, ragService, logger, minioClient, modelRepository, authRepo, modelUsecase)
-	cronHandler, err := mq3.NewStatCronHandler(logger, statRepository, statUseCase, nodeUsecase)
+	nodeImportMQHandler, err := mq3.NewNodeImportMQHandler(mqConsumer, logger, nodeImportUsecase)
 	if err != nil {
 		return nil, err
 	}
 	mqHandlers := &mq3.MQHandlers{
-		RAGMQHandler:        ragmqHandler,
-		RagDocUpdateHandler: ragDocUpdateHandler,
-		StatCronHandler:     cronHandler,
+		RAGMQHandler:         ragmqHandler,
+		RagDocUpdateHandler:  ragDocUpdateHandler,
+		StatCronHandler:      cronHandler,
+		EvalMQHandler:        evalMQHandler,
+		TranslateMQHandler:   translateMQHandler,
+		SummaryMQHandler:     summaryMQHandler,
+		GitSyncMQHandler:     gitSyncMQHandler,
+		CrawlerSyncMQHandler: crawlerSyncMQHandler,
+		ImportMQHandler:      importMQHandler,
+		LinkCheckMQHandler:   linkCheckMQHandler,
+		NodeImportMQHandler:  nodeImportMQHandler,
 	}
 	app := &App{
 		MQConsumer:      mqConsumer,
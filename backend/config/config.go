@@ -19,6 +19,7 @@ type Config struct {
 	Auth          AuthConfig   `mapstructure:"auth"`
 	S3            S3Config     `mapstructure:"s3"`
 	Sentry        SentryConfig `mapstructure:"sentry"`
+	Git           GitConfig    `mapstructure:"git"`
 	CaddyAPI      string       `mapstructure:"caddy_api"`
 	SubnetPrefix  string       `mapstructure:"subnet_prefix"`
 }
@@ -81,6 +82,13 @@ type SentryConfig struct {
 	DSN     string `mapstructure:"dsn"`
 }
 
+type GitConfig struct {
+	// remote repositories are fetched into bare repositories under this directory
+	CacheDir string `mapstructure:"cache_dir"`
+	// local repositories can only be synced below this directory, empty disables local repositories
+	LocalRoot string `mapstructure:"local_root"`
+}
+
 func NewConfig() (*Config, error) {
 	// set default config
 	SUBNET_PREFIX := os.Getenv("SUBNET_PREFIX")
@@ -130,6 +138,10 @@ func NewConfig() (*Config, error) {
 			Enabled: true,
 			DSN:     "https://2a4cff1ae04b624ffc72663f523024ff@sentry.baizhi.cloud/4",
 		},
+		Git: GitConfig{
+			CacheDir:  "/app/data/git",
+			LocalRoot: "",
+		},
 		CaddyAPI:     "/app/run/caddy-admin.sock",
 		SubnetPrefix: "169.254.15",
 	}
@@ -15,14 +15,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/v1/app": {
-            "put": {
+        "/api/pro/v1/auth/delete": {
+            "delete": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "Update app",
+                "description": "删除指定用户或用户组的权限",
                 "consumes": [
                     "application/json"
                 ],
@@ -30,84 +30,97 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "app"
+                    "Auth"
                 ],
-                "summary": "Update app",
+                "summary": "删除权限",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "id",
-                        "name": "id",
+                        "description": "Knowledge Base ID",
+                        "name": "kb_id",
                         "in": "query",
                         "required": true
                     },
                     {
-                        "description": "app",
-                        "name": "app",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.UpdateAppReq"
-                        }
+                        "type": "integer",
+                        "description": "Auth ID",
+                        "name": "auth_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "group_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Response"
+                            "$ref": "#/definitions/domain.PWResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/pro/v1/auth/get": {
+            "get": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "Delete app",
+                "description": "获取指定知识库的权限配置",
                 "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "app"
+                    "Auth"
                 ],
-                "summary": "Delete app",
+                "summary": "获取权限配置",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "kb id",
+                        "description": "Knowledge Base ID",
                         "name": "kb_id",
                         "in": "query",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "app id",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/pro.GetAuthResp"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/api/v1/app/detail": {
-            "get": {
+        "/api/pro/v1/auth/group/create": {
+            "post": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "Get app detail",
+                "description": "创建新的用户组",
                 "consumes": [
                     "application/json"
                 ],
@@ -115,23 +128,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "app"
+                    "AuthGroup"
                 ],
-                "summary": "Get app detail",
+                "summary": "创建用户组",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "kb id",
-                        "name": "kb_id",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "app type",
-                        "name": "type",
-                        "in": "query",
-                        "required": true
+                        "description": "Create request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pro.CreateReq"
+                        }
                     }
                 ],
                 "responses": {
@@ -146,7 +154,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/domain.AppDetailResp"
+                                            "$ref": "#/definitions/pro.CreateResp"
                                         }
                                     }
                                 }
@@ -156,14 +164,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/auth/delete": {
+        "/api/pro/v1/auth/group/delete": {
             "delete": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "删除授权信息",
+                "description": "删除指定的用户组",
                 "consumes": [
                     "application/json"
                 ],
@@ -171,40 +179,43 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "AuthGroup"
                 ],
-                "summary": "删除授权信息",
-                "operationId": "v1-OpenAuthDelete",
+                "summary": "删除用户组",
                 "parameters": [
                     {
                         "type": "integer",
+                        "description": "Group ID",
                         "name": "id",
-                        "in": "query"
+                        "in": "query",
+                        "required": true
                     },
                     {
                         "type": "string",
+                        "description": "Knowledge Base ID",
                         "name": "kb_id",
-                        "in": "query"
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Response"
+                            "$ref": "#/definitions/domain.PWResponse"
                         }
                     }
                 }
             }
         },
-        "/api/v1/auth/get": {
+        "/api/pro/v1/auth/group/detail": {
             "get": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "获取授权信息",
+                "description": "获取指定用户组的详细信息",
                 "consumes": [
                     "application/json"
                 ],
@@ -212,59 +223,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "AuthGroup"
                 ],
-                "summary": "获取授权信息",
-                "operationId": "v1-OpenAuthGet",
+                "summary": "获取用户组详情",
                 "parameters": [
                     {
-                        "type": "string",
-                        "name": "kb_id",
-                        "in": "query"
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
                     },
                     {
-                        "enum": [
-                            "dingtalk",
-                            "feishu",
-                            "wecom",
-                            "oauth",
-                            "github",
-                            "cas",
-                            "ldap",
-                            "widget",
-                            "dingtalk_bot",
-                            "feishu_bot",
-                            "lark_bot",
-                            "wechat_bot",
-                            "wecom_ai_bot",
-                            "wechat_service_bot",
-                            "discord_bot",
-                            "wechat_official_account",
-                            "openai_api",
-                            "mcp_server"
-                        ],
                         "type": "string",
-                        "x-enum-varnames": [
-                            "SourceTypeDingTalk",
-                            "SourceTypeFeishu",
-                            "SourceTypeWeCom",
-                            "SourceTypeOAuth",
-                            "SourceTypeGitHub",
-                            "SourceTypeCAS",
-                            "SourceTypeLDAP",
-                            "SourceTypeWidget",
-                            "SourceTypeDingtalkBot",
-                            "SourceTypeFeishuBot",
-                            "SourceTypeLarkBot",
-                            "SourceTypeWechatBot",
-                            "SourceTypeWecomAIBot",
-                            "SourceTypeWechatServiceBot",
-                            "SourceTypeDiscordBot",
-                            "SourceTypeWechatOfficialAccount",
-                            "SourceTypeOpenAIAPI",
-                            "SourceTypeMcpServer"
-                        ],
-                        "name": "source_type",
+                        "description": "Knowledge Base ID",
+                        "name": "kb_id",
                         "in": "query",
                         "required": true
                     }
@@ -281,7 +254,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_chaitin_panda-wiki_api_auth_v1.AuthGetResp"
+                                            "$ref": "#/definitions/pro.GroupDetail"
                                         }
                                     }
                                 }
@@ -291,49 +264,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/auth/set": {
-            "post": {
+        "/api/pro/v1/auth/group/list": {
+            "get": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "设置授权信息",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Auth"
-                ],
-                "summary": "设置授权信息",
-                "operationId": "v1-OpenAuthSet",
-                "parameters": [
-                    {
-                        "description": "para",
-                        "name": "param",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/v1.AuthSetReq"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/domain.Response"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/v1/comment": {
-            "get": {
-                "description": "GetCommentModeratedList",
+                "description": "获取指定知识库的用户组列表",
                 "consumes": [
                     "application/json"
                 ],
@@ -341,50 +279,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "comment"
+                    "AuthGroup"
                 ],
-                "summary": "GetCommentModeratedList",
+                "summary": "获取用户组列表",
                 "parameters": [
                     {
                         "type": "string",
+                        "description": "Knowledge Base ID",
                         "name": "kb_id",
                         "in": "query",
                         "required": true
                     },
                     {
-                        "minimum": 1,
                         "type": "integer",
+                        "description": "Page",
                         "name": "page",
-                        "in": "query",
-                        "required": true
+                        "in": "query"
                     },
                     {
-                        "minimum": 1,
                         "type": "integer",
+                        "description": "Per page",
                         "name": "per_page",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "enum": [
-                            -1,
-                            0,
-                            1
-                        ],
-                        "type": "integer",
-                        "format": "int32",
-                        "x-enum-varnames": [
-                            "CommentStatusReject",
-                            "CommentStatusPending",
-                            "CommentStatusAccepted"
-                        ],
-                        "name": "status",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "conversationList",
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -394,7 +315,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/v1.CommentLists"
+                                            "$ref": "#/definitions/pro.GetListResp"
                                         }
                                     }
                                 }
@@ -404,9 +325,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/comment/list": {
-            "delete": {
-                "description": "DeleteCommentList",
+        "/api/pro/v1/auth/group/move": {
+            "patch": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "移动用户组到新的父组下",
                 "consumes": [
                     "application/json"
                 ],
@@ -414,105 +340,77 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "comment"
+                    "AuthGroup"
                 ],
-                "summary": "DeleteCommentList",
+                "summary": "移动用户组",
                 "parameters": [
                     {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "csv",
-                        "name": "ids",
-                        "in": "query"
+                        "description": "Move request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pro.MoveReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "total",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Response"
+                            "$ref": "#/definitions/domain.PWResponse"
                         }
                     }
                 }
             }
         },
-        "/api/v1/conversation": {
-            "get": {
-                "description": "get conversation list",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
+        "/api/pro/v1/auth/group/sync": {
+            "post": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "从外部系统同步用户组",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
                 ],
                 "tags": [
-                    "conversation"
+                    "AuthGroup"
                 ],
-                "summary": "get conversation list",
+                "summary": "同步用户组",
                 "parameters": [
                     {
-                        "type": "string",
-                        "name": "app_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "name": "kb_id",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "name": "page",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "name": "per_page",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "name": "remote_ip",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "name": "subject",
-                        "in": "query"
+                        "description": "Sync request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pro.SyncReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/domain.PWResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/v1.ConversationListItems"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/domain.PWResponse"
                         }
                     }
                 }
             }
         },
-        "/api/v1/conversation/detail": {
+        "/api/pro/v1/auth/group/tree": {
             "get": {
-                "description": "get conversation detail",
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "获取指定知识库的用户组树形结构",
                 "consumes": [
                     "application/json"
                 ],
@@ -520,18 +418,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "conversation"
+                    "AuthGroup"
                 ],
-                "summary": "get conversation detail",
+                "summary": "获取用户组树形结构",
                 "parameters": [
                     {
                         "type": "string",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
+                        "description": "Knowledge Base ID",
                         "name": "kb_id",
                         "in": "query",
                         "required": true
@@ -549,7 +442,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/domain.ConversationDetailResp"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/pro.GroupTreeNode"
+                                            }
                                         }
                                     }
                                 }
@@ -559,9 +455,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/conversation/message/detail": {
-            "get": {
-                "description": "Get message detail",
+        "/api/pro/v1/auth/group/update": {
+            "patch": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "更新用户组名称和成员",
                 "consumes": [
                     "application/json"
                 ],
@@ -569,48 +470,77 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Message"
+                    "AuthGroup"
                 ],
-                "summary": "Get message detail",
+                "summary": "更新用户组",
                 "parameters": [
                     {
-                        "type": "string",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
-                    },
+                        "description": "Update request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pro.UpdateReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.PWResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/pro/v1/auth/set": {
+            "post": {
+                "security": [
                     {
-                        "type": "string",
-                        "name": "kb_id",
-                        "in": "query",
-                        "required": true
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "设置知识库的权限配置",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "设置权限",
+                "parameters": [
+                    {
+                        "description": "Set auth request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pro.SetAuthReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/domain.PWResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/domain.ConversationMessage"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/domain.PWResponse"
                         }
                     }
                 }
             }
         },
-        "/api/v1/conversation/message/list": {
+        "/api/pro/v1/block": {
             "get": {
-                "description": "GetMessageFeedBackList",
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Get block words for knowledge base",
                 "consumes": [
                     "application/json"
                 ],
@@ -618,34 +548,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Message"
+                    "block"
                 ],
-                "summary": "GetMessageFeedBackList",
+                "summary": "Get block words",
                 "parameters": [
                     {
                         "type": "string",
+                        "description": "Knowledge Base ID",
                         "name": "kb_id",
                         "in": "query",
                         "required": true
-                    },
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "name": "page",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "name": "per_page",
-                        "in": "query",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "MessageList",
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -655,7 +572,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/domain.PaginatedResult-array_domain_ConversationMessageListItem"
+                                            "$ref": "#/definitions/pro.GetBlockWordsResp"
                                         }
                                     }
                                 }
@@ -663,11 +580,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/crawler/export": {
+            },
             "post": {
-                "description": "CrawlerExport",
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Update block words for knowledge base",
                 "consumes": [
                     "application/json"
                 ],
@@ -675,17 +595,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "crawler"
+                    "block"
                 ],
-                "summary": "CrawlerExport",
+                "summary": "Update block words",
                 "parameters": [
                     {
-                        "description": "Scrape",
+                        "description": "Update block words request",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.CrawlerExportReq"
+                            "$ref": "#/definitions/pro.UpdateBlockWordsReq"
                         }
                     }
                 ],
@@ -693,27 +613,20 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/domain.PWResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/v1.CrawlerExportResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/domain.PWResponse"
                         }
                     }
                 }
             }
         },
-        "/api/v1/crawler/parse": {
+        "/api/pro/v1/comment_moderate": {
             "post": {
-                "description": "解析文档树",
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "审核通过或拒绝评论",
                 "consumes": [
                     "application/json"
                 ],
@@ -721,17 +634,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "crawler"
+                    "Comment"
                 ],
-                "summary": "解析文档树",
+                "summary": "审核评论",
                 "parameters": [
                     {
-                        "description": "Scrape",
+                        "description": "Moderate request",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.CrawlerParseReq"
+                            "$ref": "#/definitions/pro.ModerateCommentReq"
                         }
                     }
                 ],
@@ -739,27 +652,20 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/domain.PWResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/v1.CrawlerParseResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/domain.PWResponse"
                         }
                     }
                 }
             }
         },
-        "/api/v1/crawler/result": {
-            "get": {
-                "description": "Retrieve the result of a previously started scraping task",
+        "/api/pro/v1/contribute/approve": {
+            "post": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Approve a contribute",
                 "consumes": [
                     "application/json"
                 ],
@@ -767,17 +673,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "crawler"
+                    "contribute"
                 ],
-                "summary": "Get Crawler Result",
+                "summary": "Approve contribute",
                 "parameters": [
                     {
-                        "description": "Crawler Result Request",
+                        "description": "Approve contribute request",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.CrawlerResultReq"
+                            "$ref": "#/definitions/pro.UpdateContributeReq"
                         }
                     }
                 ],
@@ -785,27 +691,20 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/domain.PWResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/v1.CrawlerResultResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/domain.PWResponse"
                         }
                     }
                 }
             }
         },
-        "/api/v1/crawler/results": {
+        "/api/pro/v1/contribute/audit": {
             "post": {
-                "description": "Retrieve the results of a previously started scraping task",
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Audit a contribute (approve or reject)",
                 "consumes": [
                     "application/json"
                 ],
@@ -813,17 +712,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "crawler"
+                    "contribute"
                 ],
-                "summary": "Get Crawler Results",
+                "summary": "Audit contribute",
                 "parameters": [
                     {
-                        "description": "Crawler Results Request",
-                        "name": "param",
+                        "description": "Audit contribute request",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.CrawlerResultsReq"
+                            "$ref": "#/definitions/pro.AuditContributeReq"
                         }
                     }
                 ],
@@ -831,27 +730,20 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/domain.PWResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/v1.CrawlerResultsResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/domain.PWResponse"
                         }
                     }
                 }
             }
         },
-        "/api/v1/creation/tab-complete": {
-            "post": {
-                "description": "Tab-based document completion similar to AI coding's FIM (Fill in Middle)",
+        "/api/pro/v1/contribute/delete": {
+            "delete": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Delete a contribute",
                 "consumes": [
                     "application/json"
                 ],
@@ -859,33 +751,43 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "creation"
+                    "contribute"
                 ],
-                "summary": "Tab-based document completion",
+                "summary": "Delete contribute",
                 "parameters": [
                     {
-                        "description": "tab completion request",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.CompleteReq"
-                        }
+                        "type": "string",
+                        "description": "Contribute ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Knowledge Base ID",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "success",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/domain.PWResponse"
                         }
                     }
                 }
             }
         },
-        "/api/v1/creation/text": {
-            "post": {
-                "description": "Text creation",
+        "/api/pro/v1/contribute/detail": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Get contribute detail by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -893,104 +795,155 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "creation"
+                    "contribute"
                 ],
-                "summary": "Text creation",
+                "summary": "Get contribute detail",
                 "parameters": [
                     {
-                        "description": "text creation request",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.TextReq"
-                        }
+                        "type": "string",
+                        "description": "Contribute ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Knowledge Base ID",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "success",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/pro.ContributeItem"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/api/v1/file/upload": {
-            "post": {
-                "description": "Upload File",
+        "/api/pro/v1/contribute/list": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Get contribute list for knowledge base",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
                 ],
                 "tags": [
-                    "file"
+                    "contribute"
                 ],
-                "summary": "Upload File",
+                "summary": "Get contribute list",
                 "parameters": [
-                    {
-                        "type": "file",
-                        "description": "File",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
-                    },
                     {
                         "type": "string",
                         "description": "Knowledge Base ID",
                         "name": "kb_id",
-                        "in": "formData"
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Per page",
+                        "name": "per_page",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.ObjectUploadResp"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/pro.GetContributeListResp"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/api/v1/file/upload/anydoc": {
+        "/api/pro/v1/contribute/reject": {
             "post": {
-                "description": "Upload Anydoc File",
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Reject a contribute",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
                 ],
                 "tags": [
-                    "file"
+                    "contribute"
                 ],
-                "summary": "Upload Anydoc File",
+                "summary": "Reject contribute",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "File",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "File Path",
-                        "name": "path",
-                        "in": "formData",
-                        "required": true
+                        "description": "Reject contribute request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pro.UpdateContributeReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.AnydocUploadResp"
+                            "$ref": "#/definitions/domain.PWResponse"
                         }
                     }
                 }
             }
         },
-        "/api/v1/knowledge_base": {
+        "/api/pro/v1/document/feedback": {
             "post": {
-                "description": "CreateKnowledgeBase",
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "用户提交文档反馈意见",
                 "consumes": [
                     "application/json"
                 ],
@@ -998,17 +951,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "knowledge_base"
+                    "Document"
                 ],
-                "summary": "CreateKnowledgeBase",
+                "summary": "提交文档反馈",
                 "parameters": [
                     {
-                        "description": "CreateKnowledgeBase Request",
+                        "description": "Feedback request",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.CreateKnowledgeBaseReq"
+                            "$ref": "#/definitions/pro.SubmitFeedbackReq"
                         }
                     }
                 ],
@@ -1016,20 +969,20 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Response"
+                            "$ref": "#/definitions/domain.PWResponse"
                         }
                     }
                 }
             }
         },
-        "/api/v1/knowledge_base/detail": {
+        "/api/pro/v1/document/list": {
             "get": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "GetKnowledgeBaseDetail",
+                "description": "获取知识库的文档反馈列表",
                 "consumes": [
                     "application/json"
                 ],
@@ -1037,16 +990,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "knowledge_base"
+                    "Document"
                 ],
-                "summary": "GetKnowledgeBaseDetail",
+                "summary": "获取文档反馈列表",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Knowledge Base ID",
-                        "name": "id",
+                        "name": "kb_id",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Per page",
+                        "name": "per_page",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1061,7 +1026,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/domain.KnowledgeBaseDetail"
+                                            "$ref": "#/definitions/pro.GetDocumentListResp"
                                         }
                                     }
                                 }
@@ -1069,9 +1034,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "UpdateKnowledgeBase",
+            }
+        },
+        "/api/pro/v1/node/release/detail": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "获取指定版本的详细内容",
                 "consumes": [
                     "application/json"
                 ],
@@ -1079,31 +1051,55 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "knowledge_base"
+                    "Release"
                 ],
-                "summary": "UpdateKnowledgeBase",
+                "summary": "获取版本详情",
                 "parameters": [
                     {
-                        "description": "UpdateKnowledgeBase Request",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.UpdateKnowledgeBaseReq"
-                        }
+                        "type": "string",
+                        "description": "Node ID",
+                        "name": "node_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version ID",
+                        "name": "version_id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Response"
-                        }
-                    }
-                }
-            },
-            "delete": {
-                "description": "DeleteKnowledgeBase",
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/pro.ReleaseDetail"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/pro/v1/node/release/list": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "获取文档的历史版本列表",
                 "consumes": [
                     "application/json"
                 ],
@@ -1111,31 +1107,60 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "knowledge_base"
+                    "Release"
                 ],
-                "summary": "DeleteKnowledgeBase",
+                "summary": "获取版本列表",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Knowledge Base ID",
-                        "name": "id",
+                        "description": "Node ID",
+                        "name": "node_id",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Per page",
+                        "name": "per_page",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/pro.GetReleaseListResp"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/api/v1/knowledge_base/list": {
+        "/api/pro/v1/prompt": {
             "get": {
-                "description": "GetKnowledgeBaseList",
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Get system prompt for knowledge base",
                 "consumes": [
                     "application/json"
                 ],
@@ -1143,9 +1168,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "knowledge_base"
+                    "prompt"
+                ],
+                "summary": "Get prompt",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Knowledge Base ID",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
                 ],
-                "summary": "GetKnowledgeBaseList",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1158,10 +1192,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/domain.KnowledgeBaseListItem"
-                                            }
+                                            "$ref": "#/definitions/pro.GetPromptResp"
                                         }
                                     }
                                 }
@@ -1169,11 +1200,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/knowledge_base/release": {
+            },
             "post": {
-                "description": "CreateKBRelease",
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Update system prompt for knowledge base",
                 "consumes": [
                     "application/json"
                 ],
@@ -1181,17 +1215,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "knowledge_base"
+                    "prompt"
                 ],
-                "summary": "CreateKBRelease",
+                "summary": "Update prompt",
                 "parameters": [
                     {
-                        "description": "CreateKBRelease Request",
+                        "description": "Update prompt request",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.CreateKBReleaseReq"
+                            "$ref": "#/definitions/pro.UpdatePromptReq"
                         }
                     }
                 ],
@@ -1199,15 +1233,20 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Response"
+                            "$ref": "#/definitions/domain.PWResponse"
                         }
                     }
                 }
             }
         },
-        "/api/v1/knowledge_base/release/list": {
-            "get": {
-                "description": "GetKBReleaseList",
+        "/api/pro/v1/token/create": {
+            "post": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Create API token for knowledge base",
                 "consumes": [
                     "application/json"
                 ],
@@ -1215,16 +1254,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "knowledge_base"
+                    "token"
                 ],
-                "summary": "GetKBReleaseList",
+                "summary": "Create API token",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Knowledge Base ID",
-                        "name": "kb_id",
-                        "in": "query",
-                        "required": true
+                        "description": "Create token request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pro.CreateTokenReq"
+                        }
                     }
                 ],
                 "responses": {
@@ -1239,7 +1280,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/domain.GetKBReleaseListResp"
+                                            "$ref": "#/definitions/pro.CreateTokenResp"
                                         }
                                     }
                                 }
@@ -1249,14 +1290,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/knowledge_base/user/delete": {
+        "/api/pro/v1/token/delete": {
             "delete": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "Remove user from knowledge base",
+                "description": "Delete API token by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1264,19 +1305,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "knowledge_base"
+                    "token"
                 ],
-                "summary": "KBUserDelete",
+                "summary": "Delete API token",
                 "parameters": [
                     {
                         "type": "string",
-                        "name": "kb_id",
+                        "description": "Token ID",
+                        "name": "id",
                         "in": "query",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "name": "user_id",
+                        "description": "Knowledge Base ID",
+                        "name": "kb_id",
                         "in": "query",
                         "required": true
                     }
@@ -1285,59 +1328,20 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Response"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/v1/knowledge_base/user/invite": {
-            "post": {
-                "security": [
-                    {
-                        "bearerAuth": []
-                    }
-                ],
-                "description": "Invite user to knowledge base",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "knowledge_base"
-                ],
-                "summary": "KBUserInvite",
-                "parameters": [
-                    {
-                        "description": "Invite User Request",
-                        "name": "param",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/v1.KBUserInviteReq"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/domain.Response"
+                            "$ref": "#/definitions/domain.PWResponse"
                         }
                     }
                 }
             }
         },
-        "/api/v1/knowledge_base/user/list": {
+        "/api/pro/v1/token/list": {
             "get": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "KBUserList",
+                "description": "Get API token list for knowledge base",
                 "consumes": [
                     "application/json"
                 ],
@@ -1345,9 +1349,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "knowledge_base"
+                    "token"
                 ],
-                "summary": "KBUserList",
+                "summary": "Get API token list",
                 "parameters": [
                     {
                         "type": "string",
@@ -1371,7 +1375,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/v1.KBUserListItemResp"
+                                                "$ref": "#/definitions/pro.TokenItem"
                                             }
                                         }
                                     }
@@ -1382,14 +1386,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/knowledge_base/user/update": {
+        "/api/pro/v1/token/update": {
             "patch": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "Update user permission in knowledge base",
+                "description": "Update API token name or permission",
                 "consumes": [
                     "application/json"
                 ],
@@ -1397,17 +1401,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "knowledge_base"
+                    "token"
                 ],
-                "summary": "KBUserUpdate",
+                "summary": "Update API token",
                 "parameters": [
                     {
-                        "description": "Update User Permission Request",
-                        "name": "param",
+                        "description": "Update token request",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.KBUserUpdateReq"
+                            "$ref": "#/definitions/pro.UpdateTokenReq"
                         }
                     }
                 ],
@@ -1415,15 +1419,20 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Response"
+                            "$ref": "#/definitions/domain.PWResponse"
                         }
                     }
                 }
             }
         },
-        "/api/v1/model": {
+        "/api/v1/app": {
             "put": {
-                "description": "update model",
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Update app",
                 "consumes": [
                     "application/json"
                 ],
@@ -1431,16 +1440,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "model"
+                    "app"
                 ],
+                "summary": "Update app",
                 "parameters": [
                     {
-                        "description": "update model request",
-                        "name": "model",
+                        "type": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "app",
+                        "name": "app",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.UpdateModelReq"
+                            "$ref": "#/definitions/domain.UpdateAppReq"
                         }
                     }
                 ],
@@ -1453,27 +1470,34 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
-                "description": "create model",
-                "consumes": [
-                    "application/json"
+            "delete": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
                 ],
-                "produces": [
+                "description": "Delete app",
+                "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "model"
+                    "app"
                 ],
-                "summary": "create model",
+                "summary": "Delete app",
                 "parameters": [
                     {
-                        "description": "create model request",
-                        "name": "model",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.CreateModelReq"
-                        }
+                        "type": "string",
+                        "description": "kb id",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "app id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -1486,9 +1510,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/model/check": {
-            "post": {
-                "description": "check model",
+        "/api/v1/app/detail": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Get app detail",
                 "consumes": [
                     "application/json"
                 ],
@@ -1496,18 +1525,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "model"
+                    "app"
                 ],
-                "summary": "check model",
+                "summary": "Get app detail",
                 "parameters": [
                     {
-                        "description": "check model request",
-                        "name": "model",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.CheckModelReq"
-                        }
+                        "type": "string",
+                        "description": "kb id",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "app type",
+                        "name": "type",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -1516,13 +1550,13 @@ const docTemplate = `{
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/domain.Response"
+                                    "$ref": "#/definitions/domain.PWResponse"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.CheckModelResp"
+                                            "$ref": "#/definitions/domain.AppDetailResp"
                                         }
                                     }
                                 }
@@ -1532,9 +1566,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/model/list": {
-            "get": {
-                "description": "get model list",
+        "/api/v1/auth/delete": {
+            "delete": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "删除授权信息",
                 "consumes": [
                     "application/json"
                 ],
@@ -1542,34 +1581,40 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "model"
+                    "Auth"
+                ],
+                "summary": "删除授权信息",
+                "operationId": "v1-OpenAuthDelete",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "name": "id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query"
+                    }
                 ],
-                "summary": "get model list",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/domain.PWResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.ModelListItem"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/domain.Response"
                         }
                     }
                 }
             }
         },
-        "/api/v1/model/mode-setting": {
+        "/api/v1/auth/get": {
             "get": {
-                "description": "get current model mode setting including mode, API key and chat model",
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "获取授权信息",
                 "consumes": [
                     "application/json"
                 ],
@@ -1577,22 +1622,76 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "model"
+                    "Auth"
+                ],
+                "summary": "获取授权信息",
+                "operationId": "v1-OpenAuthGet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "dingtalk",
+                            "feishu",
+                            "wecom",
+                            "oauth",
+                            "github",
+                            "cas",
+                            "ldap",
+                            "widget",
+                            "dingtalk_bot",
+                            "feishu_bot",
+                            "lark_bot",
+                            "wechat_bot",
+                            "wecom_ai_bot",
+                            "wechat_service_bot",
+                            "discord_bot",
+                            "wechat_official_account",
+                            "openai_api",
+                            "mcp_server"
+                        ],
+                        "type": "string",
+                        "x-enum-varnames": [
+                            "SourceTypeDingTalk",
+                            "SourceTypeFeishu",
+                            "SourceTypeWeCom",
+                            "SourceTypeOAuth",
+                            "SourceTypeGitHub",
+                            "SourceTypeCAS",
+                            "SourceTypeLDAP",
+                            "SourceTypeWidget",
+                            "SourceTypeDingtalkBot",
+                            "SourceTypeFeishuBot",
+                            "SourceTypeLarkBot",
+                            "SourceTypeWechatBot",
+                            "SourceTypeWecomAIBot",
+                            "SourceTypeWechatServiceBot",
+                            "SourceTypeDiscordBot",
+                            "SourceTypeWechatOfficialAccount",
+                            "SourceTypeOpenAIAPI",
+                            "SourceTypeMcpServer"
+                        ],
+                        "name": "source_type",
+                        "in": "query",
+                        "required": true
+                    }
                 ],
-                "summary": "get model mode setting",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/domain.Response"
+                                    "$ref": "#/definitions/domain.PWResponse"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/domain.ModelModeSetting"
+                                            "$ref": "#/definitions/github_com_chaitin_panda-wiki_api_auth_v1.AuthGetResp"
                                         }
                                     }
                                 }
@@ -1602,9 +1701,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/model/provider/supported": {
+        "/api/v1/auth/set": {
             "post": {
-                "description": "get provider supported model list",
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "设置授权信息",
                 "consumes": [
                     "application/json"
                 ],
@@ -1612,17 +1716,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "model"
+                    "Auth"
                 ],
-                "summary": "get provider supported model list",
+                "summary": "设置授权信息",
+                "operationId": "v1-OpenAuthSet",
                 "parameters": [
                     {
-                        "description": "get supported model list request",
-                        "name": "params",
+                        "description": "para",
+                        "name": "param",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.GetProviderModelListReq"
+                            "$ref": "#/definitions/v1.AuthSetReq"
                         }
                     }
                 ],
@@ -1630,27 +1735,15 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/domain.PWResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/domain.GetProviderModelListResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/domain.Response"
                         }
                     }
                 }
             }
         },
-        "/api/v1/model/switch-mode": {
-            "post": {
-                "description": "switch model mode between manual and auto",
+        "/api/v1/comment": {
+            "get": {
+                "description": "GetCommentModeratedList",
                 "consumes": [
                     "application/json"
                 ],
@@ -1658,33 +1751,60 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "model"
+                    "comment"
                 ],
-                "summary": "switch mode",
+                "summary": "GetCommentModeratedList",
                 "parameters": [
                     {
-                        "description": "switch mode request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.SwitchModeReq"
-                        }
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "per_page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            -1,
+                            0,
+                            1
+                        ],
+                        "type": "integer",
+                        "format": "int32",
+                        "x-enum-varnames": [
+                            "CommentStatusReject",
+                            "CommentStatusPending",
+                            "CommentStatusAccepted"
+                        ],
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "conversationList",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/domain.Response"
+                                    "$ref": "#/definitions/domain.PWResponse"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/domain.SwitchModeResp"
+                                            "$ref": "#/definitions/v1.CommentLists"
                                         }
                                     }
                                 }
@@ -1694,14 +1814,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/node": {
-            "post": {
-                "security": [
-                    {
-                        "bearerAuth": []
-                    }
-                ],
-                "description": "Create Node",
+        "/api/v1/comment/list": {
+            "delete": {
+                "description": "DeleteCommentList",
                 "consumes": [
                     "application/json"
                 ],
@@ -1709,53 +1824,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "node"
+                    "comment"
                 ],
-                "summary": "Create Node",
+                "summary": "DeleteCommentList",
                 "parameters": [
                     {
-                        "description": "Node",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.CreateNodeReq"
-                        }
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "name": "ids",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "total",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/domain.PWResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "object",
-                                            "additionalProperties": {
-                                                "type": "string"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/domain.Response"
                         }
                     }
                 }
             }
         },
-        "/api/v1/node/action": {
-            "post": {
-                "security": [
-                    {
-                        "bearerAuth": []
-                    }
-                ],
-                "description": "Node Action",
+        "/api/v1/conversation": {
+            "get": {
+                "description": "get conversation list",
                 "consumes": [
                     "application/json"
                 ],
@@ -1763,18 +1858,44 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "node"
+                    "conversation"
                 ],
-                "summary": "Node Action",
+                "summary": "get conversation list",
                 "parameters": [
                     {
-                        "description": "Action",
-                        "name": "action",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.NodeActionReq"
-                        }
+                        "type": "string",
+                        "name": "app_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "per_page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "remote_ip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "subject",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1789,10 +1910,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object",
-                                            "additionalProperties": {
-                                                "type": "string"
-                                            }
+                                            "$ref": "#/definitions/v1.ConversationListItems"
                                         }
                                     }
                                 }
@@ -1802,14 +1920,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/node/batch_move": {
-            "post": {
-                "security": [
-                    {
-                        "bearerAuth": []
-                    }
-                ],
-                "description": "Batch Move Node",
+        "/api/v1/conversation/detail": {
+            "get": {
+                "description": "get conversation detail",
                 "consumes": [
                     "application/json"
                 ],
@@ -1817,38 +1930,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "node"
+                    "conversation"
                 ],
-                "summary": "Batch Move Node",
+                "summary": "get conversation detail",
                 "parameters": [
                     {
-                        "description": "Batch Move Node",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.BatchMoveReq"
-                        }
+                        "type": "string",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.ConversationDetailResp"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/api/v1/node/detail": {
+        "/api/v1/conversation/message/detail": {
             "get": {
-                "security": [
-                    {
-                        "bearerAuth": []
-                    }
-                ],
-                "description": "Get Node Detail",
+                "description": "Get message detail",
                 "consumes": [
                     "application/json"
                 ],
@@ -1856,15 +1979,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "node"
+                    "Message"
                 ],
-                "summary": "Get Node Detail",
+                "summary": "Get message detail",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "name": "format",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
                         "name": "id",
@@ -1890,7 +2008,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/v1.NodeDetailResp"
+                                            "$ref": "#/definitions/domain.ConversationMessage"
                                         }
                                     }
                                 }
@@ -1898,53 +2016,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "security": [
-                    {
-                        "bearerAuth": []
-                    }
-                ],
-                "description": "Update Node Detail",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "node"
-                ],
-                "summary": "Update Node Detail",
-                "parameters": [
-                    {
-                        "description": "Node",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.UpdateNodeReq"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/domain.Response"
-                        }
-                    }
-                }
             }
         },
-        "/api/v1/node/list": {
+        "/api/v1/conversation/message/list": {
             "get": {
-                "security": [
-                    {
-                        "bearerAuth": []
-                    }
-                ],
-                "description": "Get Node List",
+                "description": "GetMessageFeedBackList",
                 "consumes": [
                     "application/json"
                 ],
@@ -1952,9 +2028,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "node"
+                    "Message"
                 ],
-                "summary": "Get Node List",
+                "summary": "GetMessageFeedBackList",
                 "parameters": [
                     {
                         "type": "string",
@@ -1963,14 +2039,23 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "name": "search",
-                        "in": "query"
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "per_page",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "MessageList",
                         "schema": {
                             "allOf": [
                                 {
@@ -1980,10 +2065,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/domain.NodeListItemResp"
-                                            }
+                                            "$ref": "#/definitions/domain.PaginatedResult-array_domain_ConversationMessageListItem"
                                         }
                                     }
                                 }
@@ -1993,14 +2075,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/node/move": {
+        "/api/v1/crawler/export": {
             "post": {
-                "security": [
-                    {
-                        "bearerAuth": []
-                    }
-                ],
-                "description": "Move Node",
+                "description": "CrawlerExport",
                 "consumes": [
                     "application/json"
                 ],
@@ -2008,17 +2085,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "node"
+                    "crawler"
                 ],
-                "summary": "Move Node",
+                "summary": "CrawlerExport",
                 "parameters": [
                     {
-                        "description": "Move Node",
+                        "description": "Scrape",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.MoveNodeReq"
+                            "$ref": "#/definitions/v1.CrawlerExportReq"
                         }
                     }
                 ],
@@ -2026,20 +2103,27 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.CrawlerExportResp"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/api/v1/node/permission": {
-            "get": {
-                "security": [
-                    {
-                        "bearerAuth": []
-                    }
-                ],
-                "description": "文档授权信息获取",
+        "/api/v1/crawler/import_job": {
+            "post": {
+                "description": "Import the documents picked from a crawler parse in the background, the job survives restarts",
                 "consumes": [
                     "application/json"
                 ],
@@ -2047,37 +2131,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "NodePermission"
+                    "import_job"
                 ],
-                "summary": "文档授权信息获取",
-                "operationId": "v1-NodePermission",
+                "summary": "CreateImportJob",
                 "parameters": [
                     {
-                        "type": "string",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "name": "kb_id",
-                        "in": "query",
-                        "required": true
+                        "description": "CreateImportJobReq",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CreateImportJobReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "import job",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/domain.Response"
+                                    "$ref": "#/definitions/domain.PWResponse"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/v1.NodePermissionResp"
+                                            "$ref": "#/definitions/domain.ImportJob"
                                         }
                                     }
                                 }
@@ -2087,14 +2167,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/node/permission/edit": {
-            "patch": {
-                "security": [
-                    {
-                        "bearerAuth": []
-                    }
-                ],
-                "description": "文档授权信息更新",
+        "/api/v1/crawler/import_job/detail": {
+            "get": {
+                "description": "Get an import job with the number of documents in each state",
                 "consumes": [
                     "application/json"
                 ],
@@ -2102,34 +2177,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "NodePermission"
+                    "import_job"
                 ],
-                "summary": "文档授权信息更新",
-                "operationId": "v1-NodePermissionEdit",
+                "summary": "GetImportJobDetail",
                 "parameters": [
                     {
-                        "description": "para",
-                        "name": "param",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/v1.NodePermissionEditReq"
-                        }
+                        "type": "string",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "import job detail",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/domain.Response"
+                                    "$ref": "#/definitions/domain.PWResponse"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/v1.NodePermissionEditResp"
+                                            "$ref": "#/definitions/domain.ImportJob"
                                         }
                                     }
                                 }
@@ -2139,14 +2216,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/node/recommend_nodes": {
+        "/api/v1/crawler/import_job/items": {
             "get": {
-                "security": [
-                    {
-                        "bearerAuth": []
-                    }
-                ],
-                "description": "Recommend Nodes",
+                "description": "List the documents of an import job with their state, optionally of one state only",
                 "consumes": [
                     "application/json"
                 ],
@@ -2154,10 +2226,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "node"
+                    "import_job"
                 ],
-                "summary": "Recommend Nodes",
+                "summary": "GetImportJobItemList",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
                     {
                         "type": "string",
                         "name": "kb_id",
@@ -2165,19 +2243,40 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "csv",
-                        "name": "node_ids",
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "per_page",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "listed",
+                            "exporting",
+                            "imported",
+                            "failed"
+                        ],
+                        "type": "string",
+                        "x-enum-varnames": [
+                            "ImportJobItemStatusListed",
+                            "ImportJobItemStatusExporting",
+                            "ImportJobItemStatusImported",
+                            "ImportJobItemStatusFailed"
+                        ],
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "import job items",
                         "schema": {
                             "allOf": [
                                 {
@@ -2187,10 +2286,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/domain.RecommendNodeListResp"
-                                            }
+                                            "$ref": "#/definitions/v1.ImportJobItemList"
                                         }
                                     }
                                 }
@@ -2200,14 +2296,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/node/restudy": {
-            "post": {
-                "security": [
-                    {
-                        "bearerAuth": []
-                    }
-                ],
-                "description": "文档重新学习",
+        "/api/v1/crawler/import_job/list": {
+            "get": {
+                "description": "List the import jobs of the kb with their progress",
                 "consumes": [
                     "application/json"
                 ],
@@ -2215,34 +2306,44 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Node"
+                    "import_job"
                 ],
-                "summary": "文档重新学习",
-                "operationId": "v1-NodeRestudy",
+                "summary": "GetImportJobList",
                 "parameters": [
                     {
-                        "description": "para",
-                        "name": "param",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/v1.NodeRestudyReq"
-                        }
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "per_page",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "import job list",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/domain.Response"
+                                    "$ref": "#/definitions/domain.PWResponse"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/v1.NodeRestudyResp"
+                                            "$ref": "#/definitions/v1.ImportJobList"
                                         }
                                     }
                                 }
@@ -2252,14 +2353,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/node/summary": {
+        "/api/v1/crawler/import_job/retry": {
             "post": {
-                "security": [
-                    {
-                        "bearerAuth": []
-                    }
-                ],
-                "description": "Summary Node",
+                "description": "Import the failed documents of a finished import job again, all of them or the given ones",
                 "consumes": [
                     "application/json"
                 ],
@@ -2267,17 +2363,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "node"
+                    "import_job"
                 ],
-                "summary": "Summary Node",
+                "summary": "RetryImportJob",
                 "parameters": [
                     {
-                        "description": "Summary Node",
+                        "description": "RetryImportJobReq",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.NodeSummaryReq"
+                            "$ref": "#/definitions/domain.RetryImportJobReq"
                         }
                     }
                 ],
@@ -2291,14 +2387,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/stat/browsers": {
-            "get": {
-                "security": [
-                    {
-                        "bearerAuth": []
-                    }
-                ],
-                "description": "客户端统计",
+        "/api/v1/crawler/parse": {
+            "post": {
+                "description": "解析文档树",
                 "consumes": [
                     "application/json"
                 ],
@@ -2306,32 +2397,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "stat"
+                    "crawler"
                 ],
-                "summary": "客户端统计",
+                "summary": "解析文档树",
                 "parameters": [
                     {
-                        "enum": [
-                            1,
-                            7,
-                            30,
-                            90
-                        ],
-                        "type": "integer",
-                        "x-enum-varnames": [
-                            "StatDay1",
-                            "StatDay7",
-                            "StatDay30",
-                            "StatDay90"
-                        ],
-                        "name": "day",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "name": "kb_id",
-                        "in": "query",
-                        "required": true
+                        "description": "Scrape",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.CrawlerParseReq"
+                        }
                     }
                 ],
                 "responses": {
@@ -2340,13 +2417,13 @@ const docTemplate = `{
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/domain.Response"
+                                    "$ref": "#/definitions/domain.PWResponse"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/domain.HotBrowser"
+                                            "$ref": "#/definitions/v1.CrawlerParseResp"
                                         }
                                     }
                                 }
@@ -2356,14 +2433,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/stat/conversation_distribution": {
+        "/api/v1/crawler/result": {
             "get": {
-                "security": [
-                    {
-                        "bearerAuth": []
-                    }
-                ],
-                "description": "问答来源",
+                "description": "Retrieve the result of a previously started scraping task",
                 "consumes": [
                     "application/json"
                 ],
@@ -2371,32 +2443,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "stat"
+                    "crawler"
                 ],
-                "summary": "问答来源",
+                "summary": "Get Crawler Result",
                 "parameters": [
                     {
-                        "enum": [
-                            1,
-                            7,
-                            30,
-                            90
-                        ],
-                        "type": "integer",
-                        "x-enum-varnames": [
-                            "StatDay1",
-                            "StatDay7",
-                            "StatDay30",
-                            "StatDay90"
-                        ],
-                        "name": "day",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "name": "kb_id",
-                        "in": "query",
-                        "required": true
+                        "description": "Crawler Result Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.CrawlerResultReq"
+                        }
                     }
                 ],
                 "responses": {
@@ -2405,16 +2463,13 @@ const docTemplate = `{
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/domain.Response"
+                                    "$ref": "#/definitions/domain.PWResponse"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/domain.ConversationDistribution"
-                                            }
+                                            "$ref": "#/definitions/v1.CrawlerResultResp"
                                         }
                                     }
                                 }
@@ -2424,14 +2479,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/stat/count": {
-            "get": {
-                "security": [
-                    {
-                        "bearerAuth": []
-                    }
-                ],
-                "description": "全局统计",
+        "/api/v1/crawler/results": {
+            "post": {
+                "description": "Retrieve the results of a previously started scraping task",
                 "consumes": [
                     "application/json"
                 ],
@@ -2439,32 +2489,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "stat"
+                    "crawler"
                 ],
-                "summary": "全局统计",
+                "summary": "Get Crawler Results",
                 "parameters": [
                     {
-                        "enum": [
-                            1,
-                            7,
-                            30,
-                            90
-                        ],
-                        "type": "integer",
-                        "x-enum-varnames": [
-                            "StatDay1",
-                            "StatDay7",
-                            "StatDay30",
-                            "StatDay90"
-                        ],
-                        "name": "day",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "name": "kb_id",
-                        "in": "query",
-                        "required": true
+                        "description": "Crawler Results Request",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.CrawlerResultsReq"
+                        }
                     }
                 ],
                 "responses": {
@@ -2479,7 +2515,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/v1.StatCountResp"
+                                            "$ref": "#/definitions/v1.CrawlerResultsResp"
                                         }
                                     }
                                 }
@@ -2489,14 +2525,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/stat/geo_count": {
-            "get": {
-                "security": [
-                    {
-                        "bearerAuth": []
-                    }
-                ],
-                "description": "用户地理分布",
+        "/api/v1/crawler_subscription": {
+            "put": {
+                "description": "UpdateCrawlerSubscription",
                 "consumes": [
                     "application/json"
                 ],
@@ -2504,32 +2535,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "stat"
+                    "crawler_subscription"
                 ],
-                "summary": "用户地理分布",
+                "summary": "UpdateCrawlerSubscription",
                 "parameters": [
                     {
-                        "enum": [
-                            1,
-                            7,
-                            30,
-                            90
-                        ],
-                        "type": "integer",
-                        "x-enum-varnames": [
-                            "StatDay1",
-                            "StatDay7",
-                            "StatDay30",
-                            "StatDay90"
-                        ],
-                        "name": "day",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "name": "kb_id",
-                        "in": "query",
-                        "required": true
+                        "description": "UpdateCrawlerSubscriptionReq",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.UpdateCrawlerSubscriptionReq"
+                        }
                     }
                 ],
                 "responses": {
@@ -2540,16 +2557,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/stat/hot_pages": {
-            "get": {
-                "security": [
-                    {
-                        "bearerAuth": []
-                    }
-                ],
-                "description": "热门文档",
+            },
+            "post": {
+                "description": "Subscribe to a url, rss feed or sitemap, it is synced into the kb on a schedule",
                 "consumes": [
                     "application/json"
                 ],
@@ -2557,50 +2567,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "stat"
+                    "crawler_subscription"
                 ],
-                "summary": "热门文档",
+                "summary": "CreateCrawlerSubscription",
                 "parameters": [
                     {
-                        "enum": [
-                            1,
-                            7,
-                            30,
-                            90
-                        ],
-                        "type": "integer",
-                        "x-enum-varnames": [
-                            "StatDay1",
-                            "StatDay7",
-                            "StatDay30",
-                            "StatDay90"
-                        ],
-                        "name": "day",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "name": "kb_id",
-                        "in": "query",
-                        "required": true
+                        "description": "CreateCrawlerSubscriptionReq",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CreateCrawlerSubscriptionReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "crawler subscription",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/domain.Response"
+                                    "$ref": "#/definitions/domain.PWResponse"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/domain.HotPage"
-                                            }
+                                            "$ref": "#/definitions/domain.CrawlerSubscription"
                                         }
                                     }
                                 }
@@ -2608,16 +2601,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/stat/instant_count": {
-            "get": {
-                "security": [
-                    {
-                        "bearerAuth": []
-                    }
-                ],
-                "description": "GetInstantCount",
+            },
+            "delete": {
+                "description": "Delete a crawler subscription, the synced nodes are kept unless delete_nodes is set",
                 "consumes": [
                     "application/json"
                 ],
@@ -2625,10 +2611,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "stat"
+                    "crawler_subscription"
                 ],
-                "summary": "GetInstantCount",
+                "summary": "DeleteCrawlerSubscription",
                 "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "also delete the nodes created by the subscription",
+                        "name": "delete_nodes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
                     {
                         "type": "string",
                         "name": "kb_id",
@@ -2640,35 +2638,15 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/domain.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/domain.InstantCountResp"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/domain.Response"
                         }
                     }
                 }
             }
         },
-        "/api/v1/stat/instant_pages": {
+        "/api/v1/crawler_subscription/list": {
             "get": {
-                "security": [
-                    {
-                        "bearerAuth": []
-                    }
-                ],
-                "description": "GetInstantPages",
+                "description": "List the url, rss and sitemap subscriptions of the kb with the result of their last sync",
                 "consumes": [
                     "application/json"
                 ],
@@ -2676,9 +2654,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "stat"
+                    "crawler_subscription"
                 ],
-                "summary": "GetInstantPages",
+                "summary": "GetCrawlerSubscriptionList",
                 "parameters": [
                     {
                         "type": "string",
@@ -2689,11 +2667,11 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "crawler subscription list",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/domain.Response"
+                                    "$ref": "#/definitions/domain.PWResponse"
                                 },
                                 {
                                     "type": "object",
@@ -2701,7 +2679,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/domain.InstantPageResp"
+                                                "$ref": "#/definitions/domain.CrawlerSubscription"
                                             }
                                         }
                                     }
@@ -2712,14 +2690,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/stat/referer_hosts": {
+        "/api/v1/crawler_subscription/nodes": {
             "get": {
-                "security": [
-                    {
-                        "bearerAuth": []
-                    }
-                ],
-                "description": "来源域名",
+                "description": "List the nodes synced by a crawler subscription with the source of each node",
                 "consumes": [
                     "application/json"
                 ],
@@ -2727,26 +2700,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "stat"
+                    "crawler_subscription"
                 ],
-                "summary": "来源域名",
+                "summary": "GetCrawlerSubscriptionNodes",
                 "parameters": [
                     {
-                        "enum": [
-                            1,
-                            7,
-                            30,
-                            90
-                        ],
-                        "type": "integer",
-                        "x-enum-varnames": [
-                            "StatDay1",
-                            "StatDay7",
-                            "StatDay30",
-                            "StatDay90"
-                        ],
-                        "name": "day",
-                        "in": "query"
+                        "type": "string",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
                     },
                     {
                         "type": "string",
@@ -2757,11 +2719,11 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "node sources",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/domain.Response"
+                                    "$ref": "#/definitions/domain.PWResponse"
                                 },
                                 {
                                     "type": "object",
@@ -2769,7 +2731,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/domain.HotRefererHost"
+                                                "$ref": "#/definitions/domain.NodeSource"
                                             }
                                         }
                                     }
@@ -2780,29 +2742,43 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/user": {
-            "get": {
-                "description": "GetUser",
+        "/api/v1/crawler_subscription/sync": {
+            "post": {
+                "description": "Sync a crawler subscription in the background now instead of waiting for its schedule",
                 "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "user"
+                    "crawler_subscription"
+                ],
+                "summary": "SyncCrawlerSubscription",
+                "parameters": [
+                    {
+                        "description": "SyncCrawlerSubscriptionReq",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.SyncCrawlerSubscriptionReq"
+                        }
+                    }
                 ],
-                "summary": "GetUser",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.UserInfoResp"
+                            "$ref": "#/definitions/domain.Response"
                         }
                     }
                 }
             }
         },
-        "/api/v1/user/create": {
+        "/api/v1/creation/tab-complete": {
             "post": {
-                "description": "CreateUser",
+                "description": "Tab-based document completion similar to AI coding's FIM (Fill in Middle)",
                 "consumes": [
                     "application/json"
                 ],
@@ -2810,45 +2786,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "creation"
                 ],
-                "summary": "CreateUser",
+                "summary": "Tab-based document completion",
                 "parameters": [
                     {
-                        "description": "CreateUser Request",
+                        "description": "tab completion request",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.CreateUserReq"
+                            "$ref": "#/definitions/domain.CompleteReq"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "success",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/domain.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/v1.CreateUserResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "type": "string"
                         }
                     }
                 }
             }
         },
-        "/api/v1/user/delete": {
-            "delete": {
-                "description": "DeleteUser",
+        "/api/v1/creation/text": {
+            "post": {
+                "description": "Text creation",
                 "consumes": [
                     "application/json"
                 ],
@@ -2856,30 +2820,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "creation"
                 ],
-                "summary": "DeleteUser",
+                "summary": "Text creation",
                 "parameters": [
                     {
-                        "type": "string",
-                        "name": "user_id",
-                        "in": "query",
-                        "required": true
+                        "description": "text creation request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.TextReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "success",
                         "schema": {
-                            "$ref": "#/definitions/domain.Response"
+                            "type": "string"
                         }
                     }
                 }
             }
         },
-        "/api/v1/user/list": {
+        "/api/v1/curated_answer": {
             "get": {
-                "description": "ListUsers",
+                "description": "GetCuratedAnswerList",
                 "consumes": [
                     "application/json"
                 ],
@@ -2887,12 +2854,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "curated_answer"
+                ],
+                "summary": "GetCuratedAnswerList",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "per_page",
+                        "in": "query",
+                        "required": true
+                    }
                 ],
-                "summary": "ListUsers",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "curated answer list",
                         "schema": {
                             "allOf": [
                                 {
@@ -2902,7 +2896,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/v1.UserListResp"
+                                            "$ref": "#/definitions/v1.CuratedAnswerList"
                                         }
                                     }
                                 }
@@ -2910,11 +2904,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/user/login": {
-            "post": {
-                "description": "Login",
+            },
+            "put": {
+                "description": "UpdateCuratedAnswer",
                 "consumes": [
                     "application/json"
                 ],
@@ -2922,17 +2914,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "curated_answer"
                 ],
-                "summary": "Login",
+                "summary": "UpdateCuratedAnswer",
                 "parameters": [
                     {
-                        "description": "Login Request",
+                        "description": "UpdateCuratedAnswerReq",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.LoginReq"
+                            "$ref": "#/definitions/domain.UpdateCuratedAnswerReq"
                         }
                     }
                 ],
@@ -2940,15 +2932,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.LoginResp"
+                            "$ref": "#/definitions/domain.Response"
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/user/reset_password": {
-            "put": {
-                "description": "ResetPassword",
+            },
+            "post": {
+                "description": "Create a curated answer, message_id corrects the answer of a conversation message",
                 "consumes": [
                     "application/json"
                 ],
@@ -2956,33 +2946,43 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "curated_answer"
                 ],
-                "summary": "ResetPassword",
+                "summary": "CreateCuratedAnswer",
                 "parameters": [
                     {
-                        "description": "ResetPassword Request",
+                        "description": "CreateCuratedAnswerReq",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.ResetPasswordReq"
+                            "$ref": "#/definitions/domain.CreateCuratedAnswerReq"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "curated answer",
                         "schema": {
-                            "$ref": "#/definitions/domain.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.CuratedAnswer"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
-            }
-        },
-        "/share/v1/app/web/info": {
-            "get": {
-                "description": "GetAppInfo",
+            },
+            "delete": {
+                "description": "DeleteCuratedAnswer",
                 "consumes": [
                     "application/json"
                 ],
@@ -2990,15 +2990,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "share_app"
+                    "curated_answer"
                 ],
-                "summary": "GetAppInfo",
+                "summary": "DeleteCuratedAnswer",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "kb id",
-                        "name": "X-KB-ID",
-                        "in": "header",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
                         "required": true
                     }
                 ],
@@ -3006,27 +3011,15 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/domain.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/domain.AppInfoResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/domain.Response"
                         }
                     }
                 }
             }
         },
-        "/share/v1/app/wechat/info": {
-            "get": {
-                "description": "WechatAppInfo",
+        "/api/v1/eval/question": {
+            "put": {
+                "description": "UpdateEvalQuestion",
                 "consumes": [
                     "application/json"
                 ],
@@ -3034,43 +3027,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "share_chat"
+                    "eval"
                 ],
-                "summary": "WechatAppInfo",
+                "summary": "UpdateEvalQuestion",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "kb id",
-                        "name": "X-KB-ID",
-                        "in": "header",
-                        "required": true
+                        "description": "UpdateEvalQuestionReq",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.UpdateEvalQuestionReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/domain.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/v1.WechatAppInfoResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/domain.Response"
                         }
                     }
                 }
-            }
-        },
-        "/share/v1/app/wechat/service/answer": {
-            "get": {
-                "description": "GetWechatAnswer",
+            },
+            "post": {
+                "description": "CreateEvalQuestion",
                 "consumes": [
                     "application/json"
                 ],
@@ -3078,31 +3059,43 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Wechat"
+                    "eval"
                 ],
-                "summary": "GetWechatAnswer",
+                "summary": "CreateEvalQuestion",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "conversation id",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
+                        "description": "CreateEvalQuestionReq",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CreateEvalQuestionReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "eval question",
                         "schema": {
-                            "$ref": "#/definitions/domain.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.EvalQuestion"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
-            }
-        },
-        "/share/v1/app/widget/info": {
-            "get": {
-                "description": "GetWidgetAppInfo",
+            },
+            "delete": {
+                "description": "DeleteEvalQuestion",
                 "consumes": [
                     "application/json"
                 ],
@@ -3110,15 +3103,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "share_app"
+                    "eval"
                 ],
-                "summary": "GetWidgetAppInfo",
+                "summary": "DeleteEvalQuestion",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "kb id",
-                        "name": "X-KB-ID",
-                        "in": "header",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
                         "required": true
                     }
                 ],
@@ -3132,9 +3130,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/share/v1/auth/get": {
+        "/api/v1/eval/question/list": {
             "get": {
-                "description": "AuthGet",
+                "description": "GetEvalQuestionList",
                 "consumes": [
                     "application/json"
                 ],
@@ -3142,22 +3140,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "share_auth"
+                    "eval"
                 ],
-                "summary": "AuthGet",
-                "operationId": "v1-AuthGet",
+                "summary": "GetEvalQuestionList",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "kb_id",
-                        "name": "X-KB-ID",
-                        "in": "header",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "set_id",
+                        "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "eval question list",
                         "schema": {
                             "allOf": [
                                 {
@@ -3167,7 +3169,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_chaitin_panda-wiki_api_share_v1.AuthGetResp"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.EvalQuestion"
+                                            }
                                         }
                                     }
                                 }
@@ -3177,9 +3182,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/share/v1/auth/github": {
+        "/api/v1/eval/run": {
             "post": {
-                "description": "GitHub登录",
+                "description": "Run an eval set with the current models and prompt, the run is executed in the background",
                 "consumes": [
                     "application/json"
                 ],
@@ -3187,31 +3192,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ShareAuth"
+                    "eval"
                 ],
-                "summary": "GitHub登录",
-                "operationId": "v1-AuthGitHub",
+                "summary": "CreateEvalRun",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "kb id",
-                        "name": "X-KB-ID",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "description": "para",
-                        "name": "param",
+                        "description": "CreateEvalRunReq",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.AuthGitHubReq"
+                            "$ref": "#/definitions/domain.CreateEvalRunReq"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "eval run",
                         "schema": {
                             "allOf": [
                                 {
@@ -3221,7 +3218,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/v1.AuthGitHubResp"
+                                            "$ref": "#/definitions/domain.EvalRun"
                                         }
                                     }
                                 }
@@ -3231,9 +3228,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/share/v1/auth/login/simple": {
-            "post": {
-                "description": "AuthLoginSimple",
+        "/api/v1/eval/run/compare": {
+            "get": {
+                "description": "Compare the metrics and per question results of two runs",
                 "consumes": [
                     "application/json"
                 ],
@@ -3241,41 +3238,54 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "share_auth"
+                    "eval"
                 ],
-                "summary": "AuthLoginSimple",
-                "operationId": "v1-AuthLoginSimple",
+                "summary": "CompareEvalRuns",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "kb_id",
-                        "name": "X-KB-ID",
-                        "in": "header",
+                        "name": "base_id",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "description": "para",
-                        "name": "param",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/v1.AuthLoginSimpleReq"
-                        }
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "target_id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "eval run compare",
                         "schema": {
-                            "$ref": "#/definitions/domain.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.EvalRunCompareResp"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/share/v1/captcha/challenge": {
-            "post": {
-                "description": "CreateCaptcha",
+        "/api/v1/eval/run/detail": {
+            "get": {
+                "description": "GetEvalRunDetail",
                 "consumes": [
                     "application/json"
                 ],
@@ -3283,31 +3293,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "share_captcha"
+                    "eval"
                 ],
-                "summary": "CreateCaptcha",
+                "summary": "GetEvalRunDetail",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "kb id",
-                        "name": "X-KB-ID",
-                        "in": "header",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "eval run detail",
                         "schema": {
-                            "$ref": "#/definitions/gocap.ChallengeData"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.EvalRunDetailResp"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/share/v1/captcha/redeem": {
-            "post": {
-                "description": "RedeemCaptcha",
+        "/api/v1/eval/run/list": {
+            "get": {
+                "description": "GetEvalRunList",
                 "consumes": [
                     "application/json"
                 ],
@@ -3315,40 +3342,62 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "share_captcha"
+                    "eval"
                 ],
-                "summary": "RedeemCaptcha",
+                "summary": "GetEvalRunList",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "kb id",
-                        "name": "X-KB-ID",
-                        "in": "header",
+                        "name": "kb_id",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "description": "request",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/consts.RedeemCaptchaReq"
-                        }
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "per_page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "set_id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "eval run list",
                         "schema": {
-                            "$ref": "#/definitions/gocap.VerificationResult"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.EvalRunList"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/share/v1/chat/completions": {
-            "post": {
-                "description": "OpenAI API compatible chat completions endpoint",
+        "/api/v1/eval/set": {
+            "put": {
+                "description": "UpdateEvalSet",
                 "consumes": [
                     "application/json"
                 ],
@@ -3356,24 +3405,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "share_chat"
+                    "eval"
                 ],
-                "summary": "ChatCompletions",
+                "summary": "UpdateEvalSet",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Knowledge Base ID",
-                        "name": "X-KB-ID",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "description": "OpenAI API request",
-                        "name": "request",
+                        "description": "UpdateEvalSetReq",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.OpenAICompletionsRequest"
+                            "$ref": "#/definitions/domain.UpdateEvalSetReq"
                         }
                     }
                 ],
@@ -3381,21 +3423,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.OpenAICompletionsResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/domain.OpenAIErrorResponse"
+                            "$ref": "#/definitions/domain.Response"
                         }
                     }
                 }
-            }
-        },
-        "/share/v1/chat/feedback": {
+            },
             "post": {
-                "description": "Process user feedback for chat conversations",
+                "description": "CreateEvalSet",
                 "consumes": [
                     "application/json"
                 ],
@@ -3403,33 +3437,43 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "share_chat"
+                    "eval"
                 ],
-                "summary": "Handle chat feedback",
+                "summary": "CreateEvalSet",
                 "parameters": [
                     {
-                        "description": "feedback request",
-                        "name": "request",
+                        "description": "CreateEvalSetReq",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.FeedbackRequest"
+                            "$ref": "#/definitions/domain.CreateEvalSetReq"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "eval set",
                         "schema": {
-                            "$ref": "#/definitions/domain.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.EvalSet"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
-            }
-        },
-        "/share/v1/chat/message": {
-            "post": {
-                "description": "ChatMessage",
+            },
+            "delete": {
+                "description": "Delete an eval set with its questions and runs",
                 "consumes": [
                     "application/json"
                 ],
@@ -3437,25 +3481,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "share_chat"
+                    "eval"
                 ],
-                "summary": "ChatMessage",
+                "summary": "DeleteEvalSet",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "app type",
-                        "name": "app_type",
+                        "name": "id",
                         "in": "query",
                         "required": true
                     },
                     {
-                        "description": "request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.ChatRequest"
-                        }
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -3468,9 +3508,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/share/v1/chat/search": {
-            "post": {
-                "description": "ChatSearch",
+        "/api/v1/eval/set/list": {
+            "get": {
+                "description": "GetEvalSetList",
                 "consumes": [
                     "application/json"
                 ],
@@ -3478,33 +3518,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "share_chat_search"
+                    "eval"
                 ],
-                "summary": "ChatSearch",
+                "summary": "GetEvalSetList",
                 "parameters": [
                     {
-                        "description": "request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.ChatSearchReq"
-                        }
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "eval set list",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/domain.Response"
+                                    "$ref": "#/definitions/domain.PWResponse"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/domain.ChatSearchResp"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.EvalSet"
+                                            }
                                         }
                                     }
                                 }
@@ -3514,96 +3554,80 @@ const docTemplate = `{
                 }
             }
         },
-        "/share/v1/chat/widget": {
+        "/api/v1/file/upload": {
             "post": {
-                "description": "ChatWidget",
+                "description": "Upload File",
                 "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "tags": [
-                    "Widget"
+                    "file"
                 ],
-                "summary": "ChatWidget",
+                "summary": "Upload File",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "app type",
-                        "name": "app_type",
-                        "in": "query",
+                        "type": "file",
+                        "description": "File",
+                        "name": "file",
+                        "in": "formData",
                         "required": true
                     },
                     {
-                        "description": "request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.ChatRequest"
-                        }
+                        "type": "string",
+                        "description": "Knowledge Base ID",
+                        "name": "kb_id",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Response"
+                            "$ref": "#/definitions/domain.ObjectUploadResp"
                         }
                     }
                 }
             }
         },
-        "/share/v1/chat/widget/search": {
+        "/api/v1/file/upload/anydoc": {
             "post": {
-                "description": "WidgetSearch",
+                "description": "Upload Anydoc File",
                 "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "tags": [
-                    "Widget"
+                    "file"
                 ],
-                "summary": "WidgetSearch",
+                "summary": "Upload Anydoc File",
                 "parameters": [
                     {
-                        "description": "Comment",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.ChatSearchReq"
-                        }
+                        "type": "file",
+                        "description": "File",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "File Path",
+                        "name": "path",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/domain.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/domain.ChatSearchResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/domain.AnydocUploadResp"
                         }
                     }
                 }
             }
         },
-        "/share/v1/comment": {
-            "post": {
-                "description": "CreateComment",
+        "/api/v1/git_source": {
+            "put": {
+                "description": "UpdateGitSource",
                 "consumes": [
                     "application/json"
                 ],
@@ -3611,45 +3635,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "share_comment"
+                    "git_source"
                 ],
-                "summary": "CreateComment",
+                "summary": "UpdateGitSource",
                 "parameters": [
                     {
-                        "description": "Comment",
-                        "name": "comment",
+                        "description": "UpdateGitSourceReq",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.CommentReq"
+                            "$ref": "#/definitions/domain.UpdateGitSourceReq"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "CommentID",
+                        "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/domain.PWResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "string"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/domain.Response"
                         }
                     }
                 }
-            }
-        },
-        "/share/v1/comment/list": {
-            "get": {
-                "description": "GetCommentList",
+            },
+            "post": {
+                "description": "Add a git repository, either a remote url or a local path, whose markdown files are synced into the kb",
                 "consumes": [
                     "application/json"
                 ],
@@ -3657,21 +3667,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "share_comment"
+                    "git_source"
                 ],
-                "summary": "GetCommentList",
+                "summary": "CreateGitSource",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "nodeID",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
+                        "description": "CreateGitSourceReq",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CreateGitSourceReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "CommentList",
+                        "description": "git source",
                         "schema": {
                             "allOf": [
                                 {
@@ -3681,7 +3693,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/share.ShareCommentLists"
+                                            "$ref": "#/definitions/domain.GitSource"
                                         }
                                     }
                                 }
@@ -3689,42 +3701,36 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/share/v1/common/file/upload": {
-            "post": {
-                "description": "前台用户上传文件,目前只支持图片文件上传",
+            },
+            "delete": {
+                "description": "Delete a git source, the synced nodes are kept unless delete_nodes is set",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ShareFile"
+                    "git_source"
                 ],
-                "summary": "文件上传",
-                "operationId": "share-FileUpload",
+                "summary": "DeleteGitSource",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "kb id",
-                        "name": "X-KB-ID",
-                        "in": "header",
-                        "required": true
+                        "type": "boolean",
+                        "description": "also delete the nodes created by the source",
+                        "name": "delete_nodes",
+                        "in": "query"
                     },
                     {
-                        "type": "file",
-                        "description": "File",
-                        "name": "file",
-                        "in": "formData",
+                        "type": "string",
+                        "name": "id",
+                        "in": "query",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "captcha_token",
-                        "name": "captcha_token",
-                        "in": "formData",
+                        "name": "kb_id",
+                        "in": "query",
                         "required": true
                     }
                 ],
@@ -3732,27 +3738,15 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/domain.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/v1.FileUploadResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/domain.Response"
                         }
                     }
                 }
             }
         },
-        "/share/v1/conversation/detail": {
+        "/api/v1/git_source/list": {
             "get": {
-                "description": "GetConversationDetail",
+                "description": "List the git sources of the kb with the result of their last sync",
                 "consumes": [
                     "application/json"
                 ],
@@ -3760,28 +3754,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "share_conversation"
+                    "git_source"
                 ],
-                "summary": "GetConversationDetail",
+                "summary": "GetGitSourceList",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "kb id",
-                        "name": "X-KB-ID",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "conversation id",
-                        "name": "id",
+                        "name": "kb_id",
                         "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "git source list",
                         "schema": {
                             "allOf": [
                                 {
@@ -3791,7 +3777,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/domain.ShareConversationDetailResp"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.GitSource"
+                                            }
                                         }
                                     }
                                 }
@@ -3801,9 +3790,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/share/v1/node/detail": {
-            "get": {
-                "description": "GetNodeDetail",
+        "/api/v1/git_source/sync": {
+            "post": {
+                "description": "Sync a git source in the background, only the documents changed since the last synced commit are updated",
                 "consumes": [
                     "application/json"
                 ],
@@ -3811,57 +3800,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "share_node"
+                    "git_source"
                 ],
-                "summary": "GetNodeDetail",
+                "summary": "SyncGitSource",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "kb id",
-                        "name": "X-KB-ID",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "node id",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "format",
-                        "name": "format",
-                        "in": "query",
-                        "required": true
+                        "description": "SyncGitSourceReq",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.SyncGitSourceReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/domain.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/v1.ShareNodeDetailResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/domain.Response"
                         }
                     }
                 }
             }
         },
-        "/share/v1/node/list": {
-            "get": {
-                "description": "GetNodeList",
+        "/api/v1/knowledge_base": {
+            "post": {
+                "description": "CreateKnowledgeBase",
                 "consumes": [
                     "application/json"
                 ],
@@ -3869,16 +3834,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "share_node"
+                    "knowledge_base"
                 ],
-                "summary": "GetNodeList",
+                "summary": "CreateKnowledgeBase",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "kb id",
-                        "name": "X-KB-ID",
-                        "in": "header",
-                        "required": true
+                        "description": "CreateKnowledgeBase Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CreateKnowledgeBaseReq"
+                        }
                     }
                 ],
                 "responses": {
@@ -3891,9 +3858,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/share/v1/openapi/github/callback": {
+        "/api/v1/knowledge_base/chunk_setting": {
             "get": {
-                "description": "GitHub回调",
+                "description": "Get the chunk strategy of the knowledge base",
                 "consumes": [
                     "application/json"
                 ],
@@ -3901,25 +3868,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ShareOpenapi"
+                    "knowledge_base"
                 ],
-                "summary": "GitHub回调",
-                "operationId": "v1-GitHubCallback",
+                "summary": "GetChunkSetting",
                 "parameters": [
                     {
                         "type": "string",
-                        "name": "code",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "name": "state",
-                        "in": "query"
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "chunk setting",
                         "schema": {
                             "allOf": [
                                 {
@@ -3929,7 +3891,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_chaitin_panda-wiki_api_share_v1.GitHubCallbackResp"
+                                            "$ref": "#/definitions/domain.ChunkSetting"
                                         }
                                     }
                                 }
@@ -3937,11 +3899,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/share/v1/openapi/lark/bot/{kb_id}": {
-            "post": {
-                "description": "Lark机器人请求",
+            },
+            "put": {
+                "description": "Update the chunk strategy of the knowledge base, it applies to documents published afterwards",
                 "consumes": [
                     "application/json"
                 ],
@@ -3949,50 +3909,98 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ShareOpenapi"
+                    "knowledge_base"
                 ],
-                "summary": "Lark机器人请求",
-                "operationId": "v1-LarkBot",
+                "summary": "UpdateChunkSetting",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "知识库ID",
-                        "name": "kb_id",
-                        "in": "path",
-                        "required": true
+                        "description": "UpdateChunkSettingReq",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.UpdateChunkSettingReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.PWResponse"
+                            "$ref": "#/definitions/domain.Response"
                         }
                     }
                 }
             }
         },
-        "/share/v1/stat/page": {
-            "post": {
-                "description": "RecordPage",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "share_stat"
+        "/api/v1/knowledge_base/detail": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
                 ],
-                "summary": "RecordPage",
+                "description": "GetKnowledgeBaseDetail",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "knowledge_base"
+                ],
+                "summary": "GetKnowledgeBaseDetail",
                 "parameters": [
                     {
-                        "description": "request",
-                        "name": "request",
+                        "type": "string",
+                        "description": "Knowledge Base ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.KnowledgeBaseDetail"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "UpdateKnowledgeBase",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "knowledge_base"
+                ],
+                "summary": "UpdateKnowledgeBase",
+                "parameters": [
+                    {
+                        "description": "UpdateKnowledgeBase Request",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.StatPageReq"
+                            "$ref": "#/definitions/domain.UpdateKnowledgeBaseReq"
                         }
                     }
                 ],
@@ -4004,487 +4012,8938 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "anydoc.Child": {
-            "type": "object",
-            "properties": {
-                "children": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/anydoc.Child"
+            },
+            "delete": {
+                "description": "DeleteKnowledgeBase",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "knowledge_base"
+                ],
+                "summary": "DeleteKnowledgeBase",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Knowledge Base ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
                     }
-                },
-                "value": {
-                    "$ref": "#/definitions/anydoc.Value"
                 }
             }
         },
-        "anydoc.Value": {
-            "type": "object",
-            "properties": {
-                "file": {
-                    "type": "boolean"
-                },
-                "file_type": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "summary": {
-                    "type": "string"
-                },
-                "title": {
-                    "type": "string"
+        "/api/v1/knowledge_base/language_setting": {
+            "get": {
+                "description": "Get the language setting of the knowledge base",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "knowledge_base"
+                ],
+                "summary": "GetLanguageSetting",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "language setting",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.LanguageSetting"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update the primary language, answer language matching and query translation of the knowledge base",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "knowledge_base"
+                ],
+                "summary": "UpdateLanguageSetting",
+                "parameters": [
+                    {
+                        "description": "UpdateLanguageSettingReq",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.UpdateLanguageSettingReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
                 }
             }
         },
-        "consts.AuthType": {
-            "type": "string",
-            "enum": [
-                "",
-                "simple",
-                "enterprise"
-            ],
-            "x-enum-comments": {
-                "AuthTypeEnterprise": "企业认证",
-                "AuthTypeNull": "无认证",
-                "AuthTypeSimple": "简单口令"
-            },
-            "x-enum-descriptions": [
-                "无认证",
-                "简单口令",
-                "企业认证"
-            ],
-            "x-enum-varnames": [
-                "AuthTypeNull",
-                "AuthTypeSimple",
-                "AuthTypeEnterprise"
-            ]
+        "/api/v1/knowledge_base/list": {
+            "get": {
+                "description": "GetKnowledgeBaseList",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "knowledge_base"
+                ],
+                "summary": "GetKnowledgeBaseList",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.KnowledgeBaseListItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
         },
-        "consts.CopySetting": {
-            "type": "string",
-            "enum": [
-                "",
-                "append",
-                "disabled"
-            ],
-            "x-enum-comments": {
+        "/api/v1/knowledge_base/release": {
+            "post": {
+                "description": "CreateKBRelease",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "knowledge_base"
+                ],
+                "summary": "CreateKBRelease",
+                "parameters": [
+                    {
+                        "description": "CreateKBRelease Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CreateKBReleaseReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/knowledge_base/release/list": {
+            "get": {
+                "description": "GetKBReleaseList",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "knowledge_base"
+                ],
+                "summary": "GetKBReleaseList",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Knowledge Base ID",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.GetKBReleaseListResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/knowledge_base/user/delete": {
+            "delete": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Remove user from knowledge base",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "knowledge_base"
+                ],
+                "summary": "KBUserDelete",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/knowledge_base/user/invite": {
+            "post": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Invite user to knowledge base",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "knowledge_base"
+                ],
+                "summary": "KBUserInvite",
+                "parameters": [
+                    {
+                        "description": "Invite User Request",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.KBUserInviteReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/knowledge_base/user/list": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "KBUserList",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "knowledge_base"
+                ],
+                "summary": "KBUserList",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Knowledge Base ID",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.KBUserListItemResp"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/knowledge_base/user/update": {
+            "patch": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Update user permission in knowledge base",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "knowledge_base"
+                ],
+                "summary": "KBUserUpdate",
+                "parameters": [
+                    {
+                        "description": "Update User Permission Request",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.KBUserUpdateReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/license": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Get license information (Mock for testing)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "license"
+                ],
+                "summary": "Get license",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.LicenseResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/link_check/broken": {
+            "get": {
+                "description": "Get the broken links and images of each document found by a link check job, the latest finished job by default",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "link_check"
+                ],
+                "summary": "GetLinkCheckBroken",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "defaults to the latest finished job of the kb",
+                        "name": "job_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "only the broken links of this node",
+                        "name": "node_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "broken links",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.LinkCheckBrokenResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/link_check/job": {
+            "post": {
+                "description": "Check the node links, uploaded files and external urls of the released documents of the kb in the background",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "link_check"
+                ],
+                "summary": "CreateLinkCheckJob",
+                "parameters": [
+                    {
+                        "description": "CreateLinkCheckJobReq",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CreateLinkCheckJobReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "link check job",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.LinkCheckJob"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/link_check/job/detail": {
+            "get": {
+                "description": "Get a link check job",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "link_check"
+                ],
+                "summary": "GetLinkCheckJobDetail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "link check job",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.LinkCheckJob"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/link_check/job/list": {
+            "get": {
+                "description": "List the link check jobs of the kb with the number of links checked and broken",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "link_check"
+                ],
+                "summary": "GetLinkCheckJobList",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "per_page",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "link check job list",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.LinkCheckJobList"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/link_check/schedule": {
+            "get": {
+                "description": "Get the schedule of the link checks of the kb",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "link_check"
+                ],
+                "summary": "GetLinkCheckSchedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "link check schedule",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.LinkCheckSchedule"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Enable, disable or change the scheduled link checks of the kb, they are run by the consumer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "link_check"
+                ],
+                "summary": "UpdateLinkCheckSchedule",
+                "parameters": [
+                    {
+                        "description": "UpdateLinkCheckScheduleReq",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.UpdateLinkCheckScheduleReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "link check schedule",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.LinkCheckSchedule"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/model": {
+            "put": {
+                "description": "update model",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "model"
+                ],
+                "parameters": [
+                    {
+                        "description": "update model request",
+                        "name": "model",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.UpdateModelReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "create model",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "model"
+                ],
+                "summary": "create model",
+                "parameters": [
+                    {
+                        "description": "create model request",
+                        "name": "model",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CreateModelReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/model/check": {
+            "post": {
+                "description": "check model",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "model"
+                ],
+                "summary": "check model",
+                "parameters": [
+                    {
+                        "description": "check model request",
+                        "name": "model",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.CheckModelReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.CheckModelResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/model/list": {
+            "get": {
+                "description": "get model list",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "model"
+                ],
+                "summary": "get model list",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.ModelListItem"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/model/mode-setting": {
+            "get": {
+                "description": "get current model mode setting including mode, API key and chat model",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "model"
+                ],
+                "summary": "get model mode setting",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.ModelModeSetting"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/model/provider/supported": {
+            "post": {
+                "description": "get provider supported model list",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "model"
+                ],
+                "summary": "get provider supported model list",
+                "parameters": [
+                    {
+                        "description": "get supported model list request",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.GetProviderModelListReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.GetProviderModelListResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/model/switch-mode": {
+            "post": {
+                "description": "switch model mode between manual and auto",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "model"
+                ],
+                "summary": "switch mode",
+                "parameters": [
+                    {
+                        "description": "switch mode request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.SwitchModeReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.SwitchModeResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node": {
+            "post": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Create Node",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "node"
+                ],
+                "summary": "Create Node",
+                "parameters": [
+                    {
+                        "description": "Node",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CreateNodeReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "additionalProperties": {
+                                                "type": "string"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/action": {
+            "post": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Node Action, a delete answers the links of other documents it breaks",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "node"
+                ],
+                "summary": "Node Action",
+                "parameters": [
+                    {
+                        "description": "Action",
+                        "name": "action",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.NodeActionReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.NodeActionResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/batch_move": {
+            "post": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Batch Move Node",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "node"
+                ],
+                "summary": "Batch Move Node",
+                "parameters": [
+                    {
+                        "description": "Batch Move Node",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.BatchMoveReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/chunk": {
+            "put": {
+                "description": "Edit or disable a chunk, the edit survives re-publishing unless the section changed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "node_chunk"
+                ],
+                "summary": "UpdateNodeChunk",
+                "parameters": [
+                    {
+                        "description": "UpdateNodeChunkReq",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.UpdateNodeChunkReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add a manual chunk such as synonyms or Q\u0026A hints to a node",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "node_chunk"
+                ],
+                "summary": "AddNodeChunk",
+                "parameters": [
+                    {
+                        "description": "AddNodeChunkReq",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.AddNodeChunkReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "node chunk edit",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.NodeChunkEdit"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/chunk/edit": {
+            "delete": {
+                "description": "Drop a chunk edit, manual chunks are removed and edited chunks are restored on the next publish",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "node_chunk"
+                ],
+                "summary": "DeleteNodeChunkEdit",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/chunk/list": {
+            "get": {
+                "description": "List the rag chunks of the latest indexed release of a node",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "node_chunk"
+                ],
+                "summary": "GetNodeChunkList",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "node_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "node chunk list",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.NodeChunkListResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/detail": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Get Node Detail",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "node"
+                ],
+                "summary": "Get Node Detail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.NodeDetailResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Update Node Detail",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "node"
+                ],
+                "summary": "Update Node Detail",
+                "parameters": [
+                    {
+                        "description": "Node",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.UpdateNodeReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/import": {
+            "post": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Import an uploaded file into nodes in the background, the folder tree is rebuilt and links between the imported documents point to the new nodes",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "node"
+                ],
+                "summary": "ImportNodes",
+                "parameters": [
+                    {
+                        "description": "NodeImportReq",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.NodeImportReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "node import job",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.NodeImportJob"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "413": {
+                        "description": "the file is too large",
+                        "schema": {
+                            "$ref": "#/definitions/domain.PWResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/import/detail": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Get a file import with the result of each document once it is completed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "node"
+                ],
+                "summary": "GetNodeImportJobDetail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "node import job",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.NodeImportJob"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/import/list": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "List the file imports of the kb with their progress, without the result of each document",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "node"
+                ],
+                "summary": "GetNodeImportJobList",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "per_page",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "node import job list",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.NodeImportJobList"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/links": {
+            "get": {
+                "description": "Get the documents linking to a document and the links of the document, broken links included",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "node_link"
+                ],
+                "summary": "GetNodeLinks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "node links",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.NodeLinksResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/links/delete_check": {
+            "post": {
+                "description": "List the documents whose links would break by deleting the nodes and their children",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "node_link"
+                ],
+                "summary": "CheckNodeDelete",
+                "parameters": [
+                    {
+                        "description": "NodeDeleteCheckReq",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.NodeDeleteCheckReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "documents linking to the nodes",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.NodeDeleteCheckResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/links/graph": {
+            "get": {
+                "description": "Get the documents of the kb with the links between them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "node_link"
+                ],
+                "summary": "GetNodeLinkGraph",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "node link graph",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.NodeLinkGraph"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/list": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Get Node List",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "node"
+                ],
+                "summary": "Get Node List",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.NodeListItemResp"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/move": {
+            "post": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Move Node",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "node"
+                ],
+                "summary": "Move Node",
+                "parameters": [
+                    {
+                        "description": "Move Node",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.MoveNodeReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/permission": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "文档授权信息获取",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NodePermission"
+                ],
+                "summary": "文档授权信息获取",
+                "operationId": "v1-NodePermission",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.NodePermissionResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/permission/edit": {
+            "patch": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "文档授权信息更新",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NodePermission"
+                ],
+                "summary": "文档授权信息更新",
+                "operationId": "v1-NodePermissionEdit",
+                "parameters": [
+                    {
+                        "description": "para",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.NodePermissionEditReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.NodePermissionEditResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/recommend_nodes": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Recommend Nodes",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "node"
+                ],
+                "summary": "Recommend Nodes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "name": "node_ids",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.RecommendNodeListResp"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/restudy": {
+            "post": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "文档重新学习",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Node"
+                ],
+                "summary": "文档重新学习",
+                "operationId": "v1-NodeRestudy",
+                "parameters": [
+                    {
+                        "description": "para",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.NodeRestudyReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.NodeRestudyResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/summary": {
+            "post": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Summary Node",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "node"
+                ],
+                "summary": "Summary Node",
+                "parameters": [
+                    {
+                        "description": "Summary Node",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.NodeSummaryReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/summary/job": {
+            "post": {
+                "description": "Summarize all documents of the kb without summary in the background",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "summary_job"
+                ],
+                "summary": "CreateSummaryJob",
+                "parameters": [
+                    {
+                        "description": "CreateSummaryJobReq",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CreateSummaryJobReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "summary job",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.SummaryJob"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/summary/job/cancel": {
+            "post": {
+                "description": "Stop a pending or running summary job, the nodes not summarized yet are marked failed and can be retried",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "summary_job"
+                ],
+                "summary": "CancelSummaryJob",
+                "parameters": [
+                    {
+                        "description": "CancelSummaryJobReq",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CancelSummaryJobReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/summary/job/detail": {
+            "get": {
+                "description": "Get a summary job with the status of every node",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "summary_job"
+                ],
+                "summary": "GetSummaryJobDetail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "summary job detail",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.SummaryJobDetailResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/summary/job/list": {
+            "get": {
+                "description": "List the summary jobs of the kb with their progress",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "summary_job"
+                ],
+                "summary": "GetSummaryJobList",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "per_page",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "summary job list",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.SummaryJobList"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/summary/job/retry": {
+            "post": {
+                "description": "Run the failed nodes of a finished summary job again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "summary_job"
+                ],
+                "summary": "RetrySummaryJob",
+                "parameters": [
+                    {
+                        "description": "RetrySummaryJobReq",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.RetrySummaryJobReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/translation": {
+            "post": {
+                "description": "Translate a node, or the subtree under it, into a language in the background",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "node_translation"
+                ],
+                "summary": "TranslateNode",
+                "parameters": [
+                    {
+                        "description": "TranslateNodeReq",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.TranslateNodeReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "queued translations",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.NodeTranslation"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/translation/list": {
+            "get": {
+                "description": "List the translations of a node, stale translations were made from an older version of the source",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "node_translation"
+                ],
+                "summary": "GetNodeTranslationList",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "node_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "node translation list",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.NodeTranslationListResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/node/upsert": {
+            "post": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Create or update documents by external id, missing parent folders are created from the path",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "node"
+                ],
+                "summary": "Upsert Nodes",
+                "parameters": [
+                    {
+                        "description": "Nodes",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.UpsertNodesReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.UpsertNodesResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stat/browsers": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "客户端统计",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stat"
+                ],
+                "summary": "客户端统计",
+                "parameters": [
+                    {
+                        "enum": [
+                            1,
+                            7,
+                            30,
+                            90
+                        ],
+                        "type": "integer",
+                        "x-enum-varnames": [
+                            "StatDay1",
+                            "StatDay7",
+                            "StatDay30",
+                            "StatDay90"
+                        ],
+                        "name": "day",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.HotBrowser"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stat/conversation_distribution": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "问答来源",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stat"
+                ],
+                "summary": "问答来源",
+                "parameters": [
+                    {
+                        "enum": [
+                            1,
+                            7,
+                            30,
+                            90
+                        ],
+                        "type": "integer",
+                        "x-enum-varnames": [
+                            "StatDay1",
+                            "StatDay7",
+                            "StatDay30",
+                            "StatDay90"
+                        ],
+                        "name": "day",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.ConversationDistribution"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stat/count": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "全局统计",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stat"
+                ],
+                "summary": "全局统计",
+                "parameters": [
+                    {
+                        "enum": [
+                            1,
+                            7,
+                            30,
+                            90
+                        ],
+                        "type": "integer",
+                        "x-enum-varnames": [
+                            "StatDay1",
+                            "StatDay7",
+                            "StatDay30",
+                            "StatDay90"
+                        ],
+                        "name": "day",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.StatCountResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stat/geo_count": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "用户地理分布",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stat"
+                ],
+                "summary": "用户地理分布",
+                "parameters": [
+                    {
+                        "enum": [
+                            1,
+                            7,
+                            30,
+                            90
+                        ],
+                        "type": "integer",
+                        "x-enum-varnames": [
+                            "StatDay1",
+                            "StatDay7",
+                            "StatDay30",
+                            "StatDay90"
+                        ],
+                        "name": "day",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stat/hot_pages": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "热门文档",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stat"
+                ],
+                "summary": "热门文档",
+                "parameters": [
+                    {
+                        "enum": [
+                            1,
+                            7,
+                            30,
+                            90
+                        ],
+                        "type": "integer",
+                        "x-enum-varnames": [
+                            "StatDay1",
+                            "StatDay7",
+                            "StatDay30",
+                            "StatDay90"
+                        ],
+                        "name": "day",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.HotPage"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stat/instant_count": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "GetInstantCount",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stat"
+                ],
+                "summary": "GetInstantCount",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.InstantCountResp"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stat/instant_pages": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "GetInstantPages",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stat"
+                ],
+                "summary": "GetInstantPages",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.InstantPageResp"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stat/referer_hosts": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "来源域名",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stat"
+                ],
+                "summary": "来源域名",
+                "parameters": [
+                    {
+                        "enum": [
+                            1,
+                            7,
+                            30,
+                            90
+                        ],
+                        "type": "integer",
+                        "x-enum-varnames": [
+                            "StatDay1",
+                            "StatDay7",
+                            "StatDay30",
+                            "StatDay90"
+                        ],
+                        "name": "day",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.HotRefererHost"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/system": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "获取系统状态（文档、学习、系统组件）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "system"
+                ],
+                "summary": "获取系统状态",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "知识库ID",
+                        "name": "kb_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.SystemResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/system/logs/{containerName}": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "获取指定容器的分页日志",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "system"
+                ],
+                "summary": "获取容器日志",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "容器名称",
+                        "name": "containerName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码，从1开始",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "每页大小",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ContainerLogsResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user": {
+            "get": {
+                "description": "GetUser",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "GetUser",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.UserInfoResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/create": {
+            "post": {
+                "description": "CreateUser",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "CreateUser",
+                "parameters": [
+                    {
+                        "description": "CreateUser Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.CreateUserReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.CreateUserResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/delete": {
+            "delete": {
+                "description": "DeleteUser",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "DeleteUser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/list": {
+            "get": {
+                "description": "ListUsers",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "ListUsers",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.UserListResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/login": {
+            "post": {
+                "description": "Login",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Login",
+                "parameters": [
+                    {
+                        "description": "Login Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.LoginReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.LoginResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/reset_password": {
+            "put": {
+                "description": "ResetPassword",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "ResetPassword",
+                "parameters": [
+                    {
+                        "description": "ResetPassword Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.ResetPasswordReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/share/pro/v1/auth/cas": {
+            "post": {
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "CAS 认证",
+                "responses": {}
+            }
+        },
+        "/share/pro/v1/auth/dingtalk": {
+            "post": {
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "钉钉认证",
+                "responses": {}
+            }
+        },
+        "/share/pro/v1/auth/feishu": {
+            "post": {
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "飞书认证",
+                "responses": {}
+            }
+        },
+        "/share/pro/v1/auth/github": {
+            "post": {
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "GitHub 认证",
+                "responses": {}
+            }
+        },
+        "/share/pro/v1/auth/ldap": {
+            "post": {
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "LDAP 认证",
+                "responses": {}
+            }
+        },
+        "/share/pro/v1/auth/oauth": {
+            "post": {
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "OAuth 认证",
+                "responses": {}
+            }
+        },
+        "/share/pro/v1/auth/wecom": {
+            "post": {
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "企业微信认证",
+                "responses": {}
+            }
+        },
+        "/share/pro/v1/contribute/submit": {
+            "post": {
+                "description": "Submit a new contribute for knowledge base",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contribute"
+                ],
+                "summary": "Submit contribute",
+                "parameters": [
+                    {
+                        "description": "Submit contribute request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/share.SubmitContributeReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.PWResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/share/pro/v1/document/feedback": {
+            "post": {
+                "tags": [
+                    "Document"
+                ],
+                "summary": "文档反馈",
+                "responses": {}
+            }
+        },
+        "/share/pro/v1/file/upload": {
+            "post": {
+                "description": "Upload file for contribute",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "file"
+                ],
+                "summary": "Upload file",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "File to upload",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Knowledge Base ID",
+                        "name": "kb_id",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/share.UploadFileResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/share/pro/v1/openapi/cas/callback": {
+            "get": {
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "CAS OAuth 回调",
+                "responses": {}
+            }
+        },
+        "/share/pro/v1/openapi/dingtalk/callback": {
+            "get": {
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "钉钉 OAuth 回调",
+                "responses": {}
+            }
+        },
+        "/share/pro/v1/openapi/feishu/callback": {
+            "get": {
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "飞书 OAuth 回调",
+                "responses": {}
+            }
+        },
+        "/share/pro/v1/openapi/github/callback": {
+            "get": {
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "GitHub OAuth 回调",
+                "responses": {}
+            }
+        },
+        "/share/pro/v1/openapi/oauth/callback": {
+            "get": {
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "OAuth 回调",
+                "responses": {}
+            }
+        },
+        "/share/pro/v1/openapi/wecom/callback": {
+            "get": {
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "企业微信 OAuth 回调",
+                "responses": {}
+            }
+        },
+        "/share/v1/app/web/info": {
+            "get": {
+                "description": "GetAppInfo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_app"
+                ],
+                "summary": "GetAppInfo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "kb id",
+                        "name": "X-KB-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.AppInfoResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/app/wechat/info": {
+            "get": {
+                "description": "WechatAppInfo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_chat"
+                ],
+                "summary": "WechatAppInfo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "kb id",
+                        "name": "X-KB-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.WechatAppInfoResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/app/wechat/service/answer": {
+            "get": {
+                "description": "GetWechatAnswer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wechat"
+                ],
+                "summary": "GetWechatAnswer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "conversation id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/app/widget/info": {
+            "get": {
+                "description": "GetWidgetAppInfo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_app"
+                ],
+                "summary": "GetWidgetAppInfo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "kb id",
+                        "name": "X-KB-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/auth/get": {
+            "get": {
+                "description": "AuthGet",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_auth"
+                ],
+                "summary": "AuthGet",
+                "operationId": "v1-AuthGet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "kb_id",
+                        "name": "X-KB-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_chaitin_panda-wiki_api_share_v1.AuthGetResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/auth/github": {
+            "post": {
+                "description": "GitHub登录",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ShareAuth"
+                ],
+                "summary": "GitHub登录",
+                "operationId": "v1-AuthGitHub",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "kb id",
+                        "name": "X-KB-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "para",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.AuthGitHubReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.AuthGitHubResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/auth/login/simple": {
+            "post": {
+                "description": "AuthLoginSimple",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_auth"
+                ],
+                "summary": "AuthLoginSimple",
+                "operationId": "v1-AuthLoginSimple",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "kb_id",
+                        "name": "X-KB-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "para",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.AuthLoginSimpleReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/captcha/challenge": {
+            "post": {
+                "description": "CreateCaptcha",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_captcha"
+                ],
+                "summary": "CreateCaptcha",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "kb id",
+                        "name": "X-KB-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/gocap.ChallengeData"
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/captcha/redeem": {
+            "post": {
+                "description": "RedeemCaptcha",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_captcha"
+                ],
+                "summary": "RedeemCaptcha",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "kb id",
+                        "name": "X-KB-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/consts.RedeemCaptchaReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/gocap.VerificationResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/chat/completions": {
+            "post": {
+                "description": "OpenAI API compatible chat completions endpoint.\nBesides the OpenAI fields, responses carry the PandaWiki extension fields ` + "`" + `sources` + "`" + ` (retrieved documents)\nand ` + "`" + `suggestions` + "`" + ` (follow-up questions). In stream mode they are sent in chunks without choices.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_chat"
+                ],
+                "summary": "ChatCompletions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Knowledge Base ID",
+                        "name": "X-KB-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "OpenAI API request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.OpenAICompletionsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.OpenAICompletionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.OpenAIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.OpenAIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.OpenAIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.OpenAIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/chat/edit": {
+            "post": {
+                "description": "Ask an edited question as a sibling of the original one",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_chat"
+                ],
+                "summary": "ChatEdit",
+                "parameters": [
+                    {
+                        "description": "request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ChatEditRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/chat/embeddings": {
+            "post": {
+                "description": "OpenAI API compatible embeddings, passed through to the embedding model in use",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_chat"
+                ],
+                "summary": "ChatEmbeddings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Knowledge Base ID",
+                        "name": "X-KB-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "OpenAI embeddings request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.OpenAIEmbeddingsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.OpenAIEmbeddingsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.OpenAIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.OpenAIErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/domain.OpenAIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/chat/feedback": {
+            "post": {
+                "description": "Process user feedback for chat conversations",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_chat"
+                ],
+                "summary": "Handle chat feedback",
+                "parameters": [
+                    {
+                        "description": "feedback request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.FeedbackRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/chat/message": {
+            "post": {
+                "description": "ChatMessage",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_chat"
+                ],
+                "summary": "ChatMessage",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "app type",
+                        "name": "app_type",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ChatRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/chat/models": {
+            "get": {
+                "description": "OpenAI API compatible model list, returns the chat and embedding models in use",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_chat"
+                ],
+                "summary": "ChatModels",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Knowledge Base ID",
+                        "name": "X-KB-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.OpenAIModelList"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.OpenAIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/chat/regenerate": {
+            "post": {
+                "description": "Regenerate the answer of a question as a new sibling branch",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_chat"
+                ],
+                "summary": "ChatRegenerate",
+                "parameters": [
+                    {
+                        "description": "request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ChatRegenerateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/chat/resume": {
+            "get": {
+                "description": "Replay the answer events after Last-Event-ID and follow the answer until done",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_chat"
+                ],
+                "summary": "ChatResume",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "last received event id",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "name": "conversationID",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Event-ID header takes precedence",
+                        "name": "lastEventID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "messageID",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "name": "nonce",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/chat/search": {
+            "post": {
+                "description": "ChatSearch",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_chat_search"
+                ],
+                "summary": "ChatSearch",
+                "parameters": [
+                    {
+                        "description": "request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ChatSearchReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.ChatSearchResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/chat/stop": {
+            "post": {
+                "description": "Stop an in-flight answer, the partial answer is saved",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_chat"
+                ],
+                "summary": "ChatStop",
+                "parameters": [
+                    {
+                        "description": "request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ChatStopRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/chat/widget": {
+            "post": {
+                "description": "ChatWidget",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Widget"
+                ],
+                "summary": "ChatWidget",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "app type",
+                        "name": "app_type",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ChatRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/chat/widget/search": {
+            "post": {
+                "description": "WidgetSearch",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Widget"
+                ],
+                "summary": "WidgetSearch",
+                "parameters": [
+                    {
+                        "description": "Comment",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ChatSearchReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.ChatSearchResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/comment": {
+            "post": {
+                "description": "CreateComment",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_comment"
+                ],
+                "summary": "CreateComment",
+                "parameters": [
+                    {
+                        "description": "Comment",
+                        "name": "comment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CommentReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "CommentID",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/comment/list": {
+            "get": {
+                "description": "GetCommentList",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_comment"
+                ],
+                "summary": "GetCommentList",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "nodeID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "CommentList",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/share.ShareCommentLists"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/common/file/upload": {
+            "post": {
+                "description": "前台用户上传文件,目前只支持图片文件上传",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ShareFile"
+                ],
+                "summary": "文件上传",
+                "operationId": "share-FileUpload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "kb id",
+                        "name": "X-KB-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "File",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "captcha_token",
+                        "name": "captcha_token",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.FileUploadResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/conversation/detail": {
+            "get": {
+                "description": "GetConversationDetail",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_conversation"
+                ],
+                "summary": "GetConversationDetail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "kb id",
+                        "name": "X-KB-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "conversation id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.ShareConversationDetailResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/node/detail": {
+            "get": {
+                "description": "GetNodeDetail",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_node"
+                ],
+                "summary": "GetNodeDetail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "kb id",
+                        "name": "X-KB-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "node id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "format",
+                        "name": "format",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ShareNodeDetailResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/node/list": {
+            "get": {
+                "description": "GetNodeList",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_node"
+                ],
+                "summary": "GetNodeList",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "kb id",
+                        "name": "X-KB-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/openapi/github/callback": {
+            "get": {
+                "description": "GitHub回调",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ShareOpenapi"
+                ],
+                "summary": "GitHub回调",
+                "operationId": "v1-GitHubCallback",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "name": "code",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "state",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PWResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_chaitin_panda-wiki_api_share_v1.GitHubCallbackResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/openapi/lark/bot/{kb_id}": {
+            "post": {
+                "description": "Lark机器人请求",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ShareOpenapi"
+                ],
+                "summary": "Lark机器人请求",
+                "operationId": "v1-LarkBot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "知识库ID",
+                        "name": "kb_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.PWResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/share/v1/stat/page": {
+            "post": {
+                "description": "RecordPage",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share_stat"
+                ],
+                "summary": "RecordPage",
+                "parameters": [
+                    {
+                        "description": "request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.StatPageReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "anydoc.Child": {
+            "type": "object",
+            "properties": {
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/anydoc.Child"
+                    }
+                },
+                "value": {
+                    "$ref": "#/definitions/anydoc.Value"
+                }
+            }
+        },
+        "anydoc.Value": {
+            "type": "object",
+            "properties": {
+                "file": {
+                    "type": "boolean"
+                },
+                "file_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "summary": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "consts.AuthType": {
+            "type": "string",
+            "enum": [
+                "",
+                "simple",
+                "enterprise"
+            ],
+            "x-enum-comments": {
+                "AuthTypeEnterprise": "企业认证",
+                "AuthTypeNull": "无认证",
+                "AuthTypeSimple": "简单口令"
+            },
+            "x-enum-descriptions": [
+                "无认证",
+                "简单口令",
+                "企业认证"
+            ],
+            "x-enum-varnames": [
+                "AuthTypeNull",
+                "AuthTypeSimple",
+                "AuthTypeEnterprise"
+            ]
+        },
+        "consts.ContributeStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "approved",
+                "rejected"
+            ],
+            "x-enum-varnames": [
+                "ContributeStatusPending",
+                "ContributeStatusApproved",
+                "ContributeStatusRejected"
+            ]
+        },
+        "consts.ContributeType": {
+            "type": "string",
+            "enum": [
+                "add",
+                "edit"
+            ],
+            "x-enum-varnames": [
+                "ContributeTypeAdd",
+                "ContributeTypeEdit"
+            ]
+        },
+        "consts.CopySetting": {
+            "type": "string",
+            "enum": [
+                "",
+                "append",
+                "disabled"
+            ],
+            "x-enum-comments": {
                 "CopySettingAppend": "增加内容尾巴",
                 "CopySettingDisabled": "禁止复制内容",
                 "CopySettingNone": "无限制"
             },
             "x-enum-descriptions": [
-                "无限制",
-                "增加内容尾巴",
-                "禁止复制内容"
+                "无限制",
+                "增加内容尾巴",
+                "禁止复制内容"
+            ],
+            "x-enum-varnames": [
+                "CopySettingNone",
+                "CopySettingAppend",
+                "CopySettingDisabled"
+            ]
+        },
+        "consts.CrawlerSource": {
+            "type": "string",
+            "enum": [
+                "url",
+                "rss",
+                "sitemap",
+                "notion",
+                "feishu",
+                "file",
+                "epub",
+                "yuque",
+                "siyuan",
+                "mindoc",
+                "wikijs",
+                "confluence"
+            ],
+            "x-enum-varnames": [
+                "CrawlerSourceUrl",
+                "CrawlerSourceRSS",
+                "CrawlerSourceSitemap",
+                "CrawlerSourceNotion",
+                "CrawlerSourceFeishu",
+                "CrawlerSourceFile",
+                "CrawlerSourceEpub",
+                "CrawlerSourceYuque",
+                "CrawlerSourceSiyuan",
+                "CrawlerSourceMindoc",
+                "CrawlerSourceWikijs",
+                "CrawlerSourceConfluence"
+            ]
+        },
+        "consts.CrawlerStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "in_process",
+                "completed",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "CrawlerStatusPending",
+                "CrawlerStatusInProcess",
+                "CrawlerStatusCompleted",
+                "CrawlerStatusFailed"
+            ]
+        },
+        "consts.HomePageSetting": {
+            "type": "string",
+            "enum": [
+                "doc",
+                "custom"
+            ],
+            "x-enum-comments": {
+                "HomePageSettingCustom": "自定义首页",
+                "HomePageSettingDoc": "文档页面"
+            },
+            "x-enum-descriptions": [
+                "文档页面",
+                "自定义首页"
+            ],
+            "x-enum-varnames": [
+                "HomePageSettingDoc",
+                "HomePageSettingCustom"
+            ]
+        },
+        "consts.LicenseEdition": {
+            "type": "integer",
+            "format": "int32",
+            "enum": [
+                0,
+                1,
+                2,
+                3
+            ],
+            "x-enum-comments": {
+                "LicenseEditionBusiness": "商业版",
+                "LicenseEditionEnterprise": "企业版",
+                "LicenseEditionFree": "开源版",
+                "LicenseEditionProfession": "专业版"
+            },
+            "x-enum-descriptions": [
+                "开源版",
+                "专业版",
+                "企业版",
+                "商业版"
+            ],
+            "x-enum-varnames": [
+                "LicenseEditionFree",
+                "LicenseEditionProfession",
+                "LicenseEditionEnterprise",
+                "LicenseEditionBusiness"
+            ]
+        },
+        "consts.ModelSettingMode": {
+            "type": "string",
+            "enum": [
+                "manual",
+                "auto"
+            ],
+            "x-enum-varnames": [
+                "ModelSettingModeManual",
+                "ModelSettingModeAuto"
+            ]
+        },
+        "consts.NodeAccessPerm": {
+            "type": "string",
+            "enum": [
+                "open",
+                "partial",
+                "closed"
+            ],
+            "x-enum-comments": {
+                "NodeAccessPermClosed": "完全禁止",
+                "NodeAccessPermOpen": "完全开放",
+                "NodeAccessPermPartial": "部分开放"
+            },
+            "x-enum-descriptions": [
+                "完全开放",
+                "部分开放",
+                "完全禁止"
+            ],
+            "x-enum-varnames": [
+                "NodeAccessPermOpen",
+                "NodeAccessPermPartial",
+                "NodeAccessPermClosed"
+            ]
+        },
+        "consts.NodePermName": {
+            "type": "string",
+            "enum": [
+                "visible",
+                "visitable",
+                "answerable"
+            ],
+            "x-enum-comments": {
+                "NodePermNameAnswerable": "可被问答",
+                "NodePermNameVisible": "导航内可见",
+                "NodePermNameVisitable": "可被访问"
+            },
+            "x-enum-descriptions": [
+                "导航内可见",
+                "可被访问",
+                "可被问答"
+            ],
+            "x-enum-varnames": [
+                "NodePermNameVisible",
+                "NodePermNameVisitable",
+                "NodePermNameAnswerable"
+            ]
+        },
+        "consts.NodeRagInfoStatus": {
+            "type": "string",
+            "enum": [
+                "BASIC_PENDING",
+                "BASIC_RUNNING",
+                "BASIC_FAILED",
+                "BASIC_SUCCEEDED",
+                "ENHANCE_PENDING",
+                "ENHANCE_RUNNING",
+                "ENHANCE_FAILED",
+                "ENHANCE_SUCCEEDED"
+            ],
+            "x-enum-comments": {
+                "NodeRagStatusBasicFailed": "基础处理失败",
+                "NodeRagStatusBasicPending": "等待基础处理",
+                "NodeRagStatusBasicRunning": "正在进行基础处理（文本分割、向量化等）",
+                "NodeRagStatusBasicSucceeded": "基础处理成功",
+                "NodeRagStatusEnhanceFailed": "增强处理失败",
+                "NodeRagStatusEnhancePending": "基础处理完成，等待增强处理",
+                "NodeRagStatusEnhanceRunning": "正在进行增强处理（关键词提取等）",
+                "NodeRagStatusEnhanceSucceeded": "增强处理成功"
+            },
+            "x-enum-descriptions": [
+                "等待基础处理",
+                "正在进行基础处理（文本分割、向量化等）",
+                "基础处理失败",
+                "基础处理成功",
+                "基础处理完成，等待增强处理",
+                "正在进行增强处理（关键词提取等）",
+                "增强处理失败",
+                "增强处理成功"
+            ],
+            "x-enum-varnames": [
+                "NodeRagStatusBasicPending",
+                "NodeRagStatusBasicRunning",
+                "NodeRagStatusBasicFailed",
+                "NodeRagStatusBasicSucceeded",
+                "NodeRagStatusEnhancePending",
+                "NodeRagStatusEnhanceRunning",
+                "NodeRagStatusEnhanceFailed",
+                "NodeRagStatusEnhanceSucceeded"
+            ]
+        },
+        "consts.RedeemCaptchaReq": {
+            "type": "object",
+            "properties": {
+                "solutions": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "consts.SourceType": {
+            "type": "string",
+            "enum": [
+                "dingtalk",
+                "feishu",
+                "wecom",
+                "oauth",
+                "github",
+                "cas",
+                "ldap",
+                "widget",
+                "dingtalk_bot",
+                "feishu_bot",
+                "lark_bot",
+                "wechat_bot",
+                "wecom_ai_bot",
+                "wechat_service_bot",
+                "discord_bot",
+                "wechat_official_account",
+                "openai_api",
+                "mcp_server"
+            ],
+            "x-enum-varnames": [
+                "SourceTypeDingTalk",
+                "SourceTypeFeishu",
+                "SourceTypeWeCom",
+                "SourceTypeOAuth",
+                "SourceTypeGitHub",
+                "SourceTypeCAS",
+                "SourceTypeLDAP",
+                "SourceTypeWidget",
+                "SourceTypeDingtalkBot",
+                "SourceTypeFeishuBot",
+                "SourceTypeLarkBot",
+                "SourceTypeWechatBot",
+                "SourceTypeWecomAIBot",
+                "SourceTypeWechatServiceBot",
+                "SourceTypeDiscordBot",
+                "SourceTypeWechatOfficialAccount",
+                "SourceTypeOpenAIAPI",
+                "SourceTypeMcpServer"
+            ]
+        },
+        "consts.StatDay": {
+            "type": "integer",
+            "enum": [
+                1,
+                7,
+                30,
+                90
+            ],
+            "x-enum-varnames": [
+                "StatDay1",
+                "StatDay7",
+                "StatDay30",
+                "StatDay90"
+            ]
+        },
+        "consts.UserKBPermission": {
+            "type": "string",
+            "enum": [
+                "",
+                "full_control",
+                "doc_manage",
+                "data_operate"
+            ],
+            "x-enum-comments": {
+                "UserKBPermissionDataOperate": "数据运营",
+                "UserKBPermissionDocManage": "文档管理",
+                "UserKBPermissionFullControl": "完全控制",
+                "UserKBPermissionNull": "无权限"
+            },
+            "x-enum-descriptions": [
+                "无权限",
+                "完全控制",
+                "文档管理",
+                "数据运营"
+            ],
+            "x-enum-varnames": [
+                "UserKBPermissionNull",
+                "UserKBPermissionFullControl",
+                "UserKBPermissionDocManage",
+                "UserKBPermissionDataOperate"
+            ]
+        },
+        "consts.UserRole": {
+            "type": "string",
+            "enum": [
+                "admin",
+                "user"
+            ],
+            "x-enum-comments": {
+                "UserRoleAdmin": "管理员",
+                "UserRoleUser": "普通用户"
+            },
+            "x-enum-descriptions": [
+                "管理员",
+                "普通用户"
+            ],
+            "x-enum-varnames": [
+                "UserRoleAdmin",
+                "UserRoleUser"
+            ]
+        },
+        "consts.WatermarkSetting": {
+            "type": "string",
+            "enum": [
+                "",
+                "hidden",
+                "visible"
+            ],
+            "x-enum-comments": {
+                "WatermarkDisabled": "未开启水印",
+                "WatermarkHidden": "隐形水印",
+                "WatermarkVisible": "显性水印"
+            },
+            "x-enum-descriptions": [
+                "未开启水印",
+                "隐形水印",
+                "显性水印"
+            ],
+            "x-enum-varnames": [
+                "WatermarkDisabled",
+                "WatermarkHidden",
+                "WatermarkVisible"
+            ]
+        },
+        "domain.AIFeedbackSettings": {
+            "type": "object",
+            "properties": {
+                "ai_feedback_type": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "is_enabled": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "domain.AccessSettings": {
+            "type": "object",
+            "properties": {
+                "base_url": {
+                    "type": "string"
+                },
+                "enterprise_auth": {
+                    "$ref": "#/definitions/domain.EnterpriseAuth"
+                },
+                "hosts": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "is_forbidden": {
+                    "description": "禁止访问",
+                    "type": "boolean"
+                },
+                "ports": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "private_key": {
+                    "type": "string"
+                },
+                "public_key": {
+                    "type": "string"
+                },
+                "simple_auth": {
+                    "$ref": "#/definitions/domain.SimpleAuth"
+                },
+                "source_type": {
+                    "description": "企业认证来源",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/consts.SourceType"
+                        }
+                    ]
+                },
+                "ssl_ports": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "trusted_proxies": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "domain.AddNodeChunkReq": {
+            "type": "object",
+            "required": [
+                "content",
+                "kb_id",
+                "node_id"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "kb_id": {
+                    "type": "string"
+                },
+                "keywords": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "node_id": {
+                    "type": "string"
+                },
+                "questions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "domain.AnydocUploadResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "string"
+                },
+                "err": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.AppDetailResp": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "kb_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "recommend_nodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.RecommendNodeListResp"
+                    }
+                },
+                "settings": {
+                    "$ref": "#/definitions/domain.AppSettingsResp"
+                },
+                "type": {
+                    "$ref": "#/definitions/domain.AppType"
+                }
+            }
+        },
+        "domain.AppInfoResp": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "recommend_nodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.RecommendNodeListResp"
+                    }
+                },
+                "settings": {
+                    "$ref": "#/definitions/domain.AppSettingsResp"
+                }
+            }
+        },
+        "domain.AppSettings": {
+            "type": "object",
+            "properties": {
+                "ai_feedback_settings": {
+                    "description": "AI feedback",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.AIFeedbackSettings"
+                        }
+                    ]
+                },
+                "body_code": {
+                    "type": "string"
+                },
+                "btns": {
+                    "type": "array",
+                    "items": {}
+                },
+                "catalog_settings": {
+                    "description": "catalog settings",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.CatalogSettings"
+                        }
+                    ]
+                },
+                "contribute_settings": {
+                    "$ref": "#/definitions/domain.ContributeSettings"
+                },
+                "conversation_setting": {
+                    "$ref": "#/definitions/domain.ConversationSetting"
+                },
+                "copy_setting": {
+                    "enum": [
+                        "",
+                        "append",
+                        "disabled"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/consts.CopySetting"
+                        }
+                    ]
+                },
+                "desc": {
+                    "description": "seo",
+                    "type": "string"
+                },
+                "dingtalk_bot_client_id": {
+                    "type": "string"
+                },
+                "dingtalk_bot_client_secret": {
+                    "type": "string"
+                },
+                "dingtalk_bot_is_enabled": {
+                    "description": "DingTalkBot",
+                    "type": "boolean"
+                },
+                "dingtalk_bot_template_id": {
+                    "type": "string"
+                },
+                "disclaimer_settings": {
+                    "description": "Disclaimer Settings",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.DisclaimerSettings"
+                        }
+                    ]
+                },
+                "discord_bot_is_enabled": {
+                    "description": "DisCordBot",
+                    "type": "boolean"
+                },
+                "discord_bot_token": {
+                    "type": "string"
+                },
+                "document_feedback_is_enabled": {
+                    "description": "document feedback",
+                    "type": "boolean"
+                },
+                "feishu_bot_app_id": {
+                    "type": "string"
+                },
+                "feishu_bot_app_secret": {
+                    "type": "string"
+                },
+                "feishu_bot_is_enabled": {
+                    "description": "FeishuBot",
+                    "type": "boolean"
+                },
+                "footer_settings": {
+                    "description": "footer settings",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.FooterSettings"
+                        }
+                    ]
+                },
+                "head_code": {
+                    "description": "inject code",
+                    "type": "string"
+                },
+                "home_page_setting": {
+                    "$ref": "#/definitions/consts.HomePageSetting"
+                },
+                "icon": {
+                    "type": "string"
+                },
+                "keyword": {
+                    "type": "string"
+                },
+                "lark_bot_settings": {
+                    "description": "LarkBot",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.LarkBotSettings"
+                        }
+                    ]
+                },
+                "mcp_server_settings": {
+                    "description": "MCP Server Settings",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.MCPServerSettings"
+                        }
+                    ]
+                },
+                "openai_api_bot_settings": {
+                    "description": "OpenAI API Bot settings",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.OpenAIAPIBotSettings"
+                        }
+                    ]
+                },
+                "recommend_node_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "recommend_questions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "search_placeholder": {
+                    "type": "string"
+                },
+                "stats_setting": {
+                    "$ref": "#/definitions/domain.StatsSetting"
+                },
+                "theme_and_style": {
+                    "$ref": "#/definitions/domain.ThemeAndStyle"
+                },
+                "theme_mode": {
+                    "description": "theme",
+                    "type": "string"
+                },
+                "title": {
+                    "description": "nav",
+                    "type": "string"
+                },
+                "watermark_content": {
+                    "type": "string"
+                },
+                "watermark_setting": {
+                    "enum": [
+                        "",
+                        "hidden",
+                        "visible"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/consts.WatermarkSetting"
+                        }
+                    ]
+                },
+                "web_app_comment_settings": {
+                    "description": "webapp comment settings",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.WebAppCommentSettings"
+                        }
+                    ]
+                },
+                "web_app_custom_style": {
+                    "description": "WebAppCustomStyle",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.WebAppCustomSettings"
+                        }
+                    ]
+                },
+                "web_app_landing_configs": {
+                    "description": "WebAppLandingConfigs",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.WebAppLandingConfig"
+                    }
+                },
+                "web_app_landing_theme": {
+                    "$ref": "#/definitions/domain.WebAppLandingTheme"
+                },
+                "wechat_app_advanced_setting": {
+                    "$ref": "#/definitions/domain.WeChatAppAdvancedSetting"
+                },
+                "wechat_app_agent_id": {
+                    "type": "string"
+                },
+                "wechat_app_corpid": {
+                    "type": "string"
+                },
+                "wechat_app_encodingaeskey": {
+                    "type": "string"
+                },
+                "wechat_app_is_enabled": {
+                    "description": "WechatAppBot 企业微信机器人",
+                    "type": "boolean"
+                },
+                "wechat_app_secret": {
+                    "type": "string"
+                },
+                "wechat_app_token": {
+                    "type": "string"
+                },
+                "wechat_official_account_app_id": {
+                    "type": "string"
+                },
+                "wechat_official_account_app_secret": {
+                    "type": "string"
+                },
+                "wechat_official_account_encodingaeskey": {
+                    "type": "string"
+                },
+                "wechat_official_account_is_enabled": {
+                    "description": "WechatOfficialAccount",
+                    "type": "boolean"
+                },
+                "wechat_official_account_token": {
+                    "type": "string"
+                },
+                "wechat_service_contain_keywords": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "wechat_service_corpid": {
+                    "type": "string"
+                },
+                "wechat_service_encodingaeskey": {
+                    "type": "string"
+                },
+                "wechat_service_equal_keywords": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "wechat_service_is_enabled": {
+                    "description": "WechatServiceBot",
+                    "type": "boolean"
+                },
+                "wechat_service_secret": {
+                    "type": "string"
+                },
+                "wechat_service_token": {
+                    "type": "string"
+                },
+                "wecom_ai_bot_settings": {
+                    "description": "WecomAIBotSettings 企业微信智能机器人",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.WecomAIBotSettings"
+                        }
+                    ]
+                },
+                "welcome_str": {
+                    "description": "welcome",
+                    "type": "string"
+                },
+                "widget_bot_settings": {
+                    "description": "Widget bot settings",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.WidgetBotSettings"
+                        }
+                    ]
+                }
+            }
+        },
+        "domain.AppSettingsResp": {
+            "type": "object",
+            "properties": {
+                "ai_feedback_settings": {
+                    "description": "AI feedback",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.AIFeedbackSettings"
+                        }
+                    ]
+                },
+                "body_code": {
+                    "type": "string"
+                },
+                "btns": {
+                    "type": "array",
+                    "items": {}
+                },
+                "catalog_settings": {
+                    "description": "catalog settings",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.CatalogSettings"
+                        }
+                    ]
+                },
+                "contribute_settings": {
+                    "$ref": "#/definitions/domain.ContributeSettings"
+                },
+                "conversation_setting": {
+                    "$ref": "#/definitions/domain.ConversationSetting"
+                },
+                "copy_setting": {
+                    "$ref": "#/definitions/consts.CopySetting"
+                },
+                "desc": {
+                    "description": "seo",
+                    "type": "string"
+                },
+                "dingtalk_bot_client_id": {
+                    "type": "string"
+                },
+                "dingtalk_bot_client_secret": {
+                    "type": "string"
+                },
+                "dingtalk_bot_is_enabled": {
+                    "description": "DingTalkBot",
+                    "type": "boolean"
+                },
+                "dingtalk_bot_template_id": {
+                    "type": "string"
+                },
+                "disclaimer_settings": {
+                    "description": "Disclaimer Settings",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.DisclaimerSettings"
+                        }
+                    ]
+                },
+                "discord_bot_is_enabled": {
+                    "description": "DisCordBot",
+                    "type": "boolean"
+                },
+                "discord_bot_token": {
+                    "type": "string"
+                },
+                "document_feedback_is_enabled": {
+                    "description": "document feedback",
+                    "type": "boolean"
+                },
+                "feishu_bot_app_id": {
+                    "type": "string"
+                },
+                "feishu_bot_app_secret": {
+                    "type": "string"
+                },
+                "feishu_bot_is_enabled": {
+                    "description": "FeishuBot",
+                    "type": "boolean"
+                },
+                "footer_settings": {
+                    "description": "footer settings",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.FooterSettings"
+                        }
+                    ]
+                },
+                "head_code": {
+                    "description": "inject code",
+                    "type": "string"
+                },
+                "home_page_setting": {
+                    "$ref": "#/definitions/consts.HomePageSetting"
+                },
+                "icon": {
+                    "type": "string"
+                },
+                "keyword": {
+                    "type": "string"
+                },
+                "lark_bot_settings": {
+                    "description": "LarkBot",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.LarkBotSettings"
+                        }
+                    ]
+                },
+                "mcp_server_settings": {
+                    "description": "MCP Server Settings",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.MCPServerSettings"
+                        }
+                    ]
+                },
+                "openai_api_bot_settings": {
+                    "description": "OpenAI API settings",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.OpenAIAPIBotSettings"
+                        }
+                    ]
+                },
+                "recommend_node_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "recommend_questions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "search_placeholder": {
+                    "type": "string"
+                },
+                "stats_setting": {
+                    "$ref": "#/definitions/domain.StatsSetting"
+                },
+                "theme_and_style": {
+                    "$ref": "#/definitions/domain.ThemeAndStyle"
+                },
+                "theme_mode": {
+                    "description": "theme",
+                    "type": "string"
+                },
+                "title": {
+                    "description": "nav",
+                    "type": "string"
+                },
+                "watermark_content": {
+                    "type": "string"
+                },
+                "watermark_setting": {
+                    "$ref": "#/definitions/consts.WatermarkSetting"
+                },
+                "web_app_comment_settings": {
+                    "description": "webapp comment settings",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.WebAppCommentSettings"
+                        }
+                    ]
+                },
+                "web_app_custom_style": {
+                    "description": "WebAppCustomStyle",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.WebAppCustomSettings"
+                        }
+                    ]
+                },
+                "web_app_landing_configs": {
+                    "description": "WebApp Landing Settings",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.WebAppLandingConfigResp"
+                    }
+                },
+                "web_app_landing_theme": {
+                    "$ref": "#/definitions/domain.WebAppLandingTheme"
+                },
+                "wechat_app_advanced_setting": {
+                    "$ref": "#/definitions/domain.WeChatAppAdvancedSetting"
+                },
+                "wechat_app_agent_id": {
+                    "type": "string"
+                },
+                "wechat_app_corpid": {
+                    "type": "string"
+                },
+                "wechat_app_encodingaeskey": {
+                    "type": "string"
+                },
+                "wechat_app_is_enabled": {
+                    "description": "WechatAppBot",
+                    "type": "boolean"
+                },
+                "wechat_app_secret": {
+                    "type": "string"
+                },
+                "wechat_app_token": {
+                    "type": "string"
+                },
+                "wechat_official_account_app_id": {
+                    "type": "string"
+                },
+                "wechat_official_account_app_secret": {
+                    "type": "string"
+                },
+                "wechat_official_account_encodingaeskey": {
+                    "type": "string"
+                },
+                "wechat_official_account_is_enabled": {
+                    "description": "WechatOfficialAccount",
+                    "type": "boolean"
+                },
+                "wechat_official_account_token": {
+                    "type": "string"
+                },
+                "wechat_service_contain_keywords": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "wechat_service_corpid": {
+                    "type": "string"
+                },
+                "wechat_service_encodingaeskey": {
+                    "type": "string"
+                },
+                "wechat_service_equal_keywords": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "wechat_service_is_enabled": {
+                    "description": "WechatServiceBot",
+                    "type": "boolean"
+                },
+                "wechat_service_secret": {
+                    "type": "string"
+                },
+                "wechat_service_token": {
+                    "type": "string"
+                },
+                "wecom_ai_bot_settings": {
+                    "$ref": "#/definitions/domain.WecomAIBotSettings"
+                },
+                "welcome_str": {
+                    "description": "welcome",
+                    "type": "string"
+                },
+                "widget_bot_settings": {
+                    "description": "WidgetBot",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.WidgetBotSettings"
+                        }
+                    ]
+                }
+            }
+        },
+        "domain.AppType": {
+            "type": "integer",
+            "format": "int32",
+            "enum": [
+                1,
+                2,
+                3,
+                4,
+                5,
+                6,
+                7,
+                8,
+                9,
+                10,
+                11,
+                12
+            ],
+            "x-enum-varnames": [
+                "AppTypeWeb",
+                "AppTypeWidget",
+                "AppTypeDingTalkBot",
+                "AppTypeFeishuBot",
+                "AppTypeWechatBot",
+                "AppTypeWechatServiceBot",
+                "AppTypeDisCordBot",
+                "AppTypeWechatOfficialAccount",
+                "AppTypeOpenAIAPI",
+                "AppTypeWecomAIBot",
+                "AppTypeLarkBot",
+                "AppTypeMcpServer"
+            ]
+        },
+        "domain.AuthUserInfo": {
+            "type": "object",
+            "properties": {
+                "avatar_url": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.BannerConfig": {
+            "type": "object",
+            "properties": {
+                "bg_url": {
+                    "type": "string"
+                },
+                "btns": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "properties": {
+                            "href": {
+                                "type": "string"
+                            },
+                            "id": {
+                                "type": "string"
+                            },
+                            "text": {
+                                "type": "string"
+                            },
+                            "type": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "hot_search": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "placeholder": {
+                    "type": "string"
+                },
+                "subtitle": {
+                    "type": "string"
+                },
+                "subtitle_color": {
+                    "type": "string"
+                },
+                "subtitle_font_size": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "title_color": {
+                    "type": "string"
+                },
+                "title_font_size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.BasicDocConfig": {
+            "type": "object",
+            "properties": {
+                "bg_color": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "title_color": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.BatchMoveReq": {
+            "type": "object",
+            "required": [
+                "ids",
+                "kb_id"
+            ],
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "kb_id": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.BlockGridConfig": {
+            "type": "object",
+            "properties": {
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "properties": {
+                            "id": {
+                                "type": "string"
+                            },
+                            "name": {
+                                "type": "string"
+                            },
+                            "url": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.BrandGroup": {
+            "type": "object",
+            "properties": {
+                "links": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Link"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.BrowserCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CancelSummaryJobReq": {
+            "type": "object",
+            "required": [
+                "id",
+                "kb_id"
+            ],
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "kb_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CarouselConfig": {
+            "type": "object",
+            "properties": {
+                "bg_color": {
+                    "type": "string"
+                },
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "properties": {
+                            "desc": {
+                                "type": "string"
+                            },
+                            "id": {
+                                "type": "string"
+                            },
+                            "title": {
+                                "type": "string"
+                            },
+                            "url": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CaseConfig": {
+            "type": "object",
+            "properties": {
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "properties": {
+                            "id": {
+                                "type": "string"
+                            },
+                            "link": {
+                                "type": "string"
+                            },
+                            "name": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CatalogSettings": {
+            "type": "object",
+            "properties": {
+                "catalog_folder": {
+                    "description": "1: 展开, 2: 折叠, default: 1",
+                    "type": "integer"
+                },
+                "catalog_visible": {
+                    "description": "1: 显示, 2: 隐藏, default: 1",
+                    "type": "integer"
+                },
+                "catalog_width": {
+                    "description": "200 - 300, default: 260",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ChatEditRequest": {
+            "type": "object",
+            "required": [
+                "app_type",
+                "conversation_id",
+                "message",
+                "message_id",
+                "nonce"
+            ],
+            "properties": {
+                "app_type": {
+                    "enum": [
+                        1,
+                        2
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.AppType"
+                        }
+                    ]
+                },
+                "captcha_token": {
+                    "type": "string"
+                },
+                "conversation_id": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "message_id": {
+                    "description": "user question being edited",
+                    "type": "string"
+                },
+                "nonce": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ChatRegenerateRequest": {
+            "type": "object",
+            "required": [
+                "app_type",
+                "conversation_id",
+                "message_id",
+                "nonce"
+            ],
+            "properties": {
+                "app_type": {
+                    "enum": [
+                        1,
+                        2
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.AppType"
+                        }
+                    ]
+                },
+                "captcha_token": {
+                    "type": "string"
+                },
+                "conversation_id": {
+                    "type": "string"
+                },
+                "message_id": {
+                    "description": "user question to answer again",
+                    "type": "string"
+                },
+                "nonce": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ChatRequest": {
+            "type": "object",
+            "required": [
+                "app_type"
+            ],
+            "properties": {
+                "app_type": {
+                    "enum": [
+                        1,
+                        2
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.AppType"
+                        }
+                    ]
+                },
+                "captcha_token": {
+                    "type": "string"
+                },
+                "conversation_id": {
+                    "type": "string"
+                },
+                "images": {
+                    "description": "uploaded image paths (/static-file/...), image urls or data urls",
+                    "type": "array",
+                    "maxItems": 4,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "nonce": {
+                    "type": "string"
+                },
+                "parent_message_id": {
+                    "description": "message the question follows, selects the branch to continue; defaults to the latest message",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ChatSearchReq": {
+            "type": "object",
+            "required": [
+                "message"
+            ],
+            "properties": {
+                "captcha_token": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ChatSearchResp": {
+            "type": "object",
+            "properties": {
+                "node_result": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.NodeContentChunkSSE"
+                    }
+                }
+            }
+        },
+        "domain.ChatStopRequest": {
+            "type": "object",
+            "required": [
+                "conversation_id",
+                "message_id",
+                "nonce"
+            ],
+            "properties": {
+                "conversation_id": {
+                    "type": "string"
+                },
+                "message_id": {
+                    "type": "string"
+                },
+                "nonce": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ChunkSetting": {
+            "type": "object",
+            "properties": {
+                "overlap": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "strategy": {
+                    "$ref": "#/definitions/domain.ChunkStrategy"
+                }
+            }
+        },
+        "domain.ChunkStrategy": {
+            "type": "string",
+            "enum": [
+                "default",
+                "heading"
+            ],
+            "x-enum-comments": {
+                "ChunkStrategyDefault": "由 RAG 服务分块",
+                "ChunkStrategyHeading": "按标题层级分块"
+            },
+            "x-enum-descriptions": [
+                "由 RAG 服务分块",
+                "按标题层级分块"
+            ],
+            "x-enum-varnames": [
+                "ChunkStrategyDefault",
+                "ChunkStrategyHeading"
+            ]
+        },
+        "domain.CommentConfig": {
+            "type": "object",
+            "properties": {
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "properties": {
+                            "avatar": {
+                                "type": "string"
+                            },
+                            "comment": {
+                                "type": "string"
+                            },
+                            "id": {
+                                "type": "string"
+                            },
+                            "profession": {
+                                "type": "string"
+                            },
+                            "user_name": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CommentInfo": {
+            "type": "object",
+            "properties": {
+                "auth_user_id": {
+                    "type": "integer"
+                },
+                "avatar": {
+                    "description": "avatar",
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "remote_ip": {
+                    "type": "string"
+                },
+                "user_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CommentListItem": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "info": {
+                    "$ref": "#/definitions/domain.CommentInfo"
+                },
+                "ip_address": {
+                    "description": "ip地址",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.IPAddress"
+                        }
+                    ]
+                },
+                "node_id": {
+                    "type": "string"
+                },
+                "node_name": {
+                    "description": "文档标题",
+                    "type": "string"
+                },
+                "node_type": {
+                    "type": "integer"
+                },
+                "root_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "status : -1 reject 0 pending 1 accept",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.CommentStatus"
+                        }
+                    ]
+                }
+            }
+        },
+        "domain.CommentReq": {
+            "type": "object",
+            "required": [
+                "content",
+                "node_id",
+                "pic_urls"
+            ],
+            "properties": {
+                "captcha_token": {
+                    "type": "string"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "node_id": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                },
+                "pic_urls": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "root_id": {
+                    "type": "string"
+                },
+                "user_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CommentStatus": {
+            "type": "integer",
+            "format": "int32",
+            "enum": [
+                -1,
+                0,
+                1
+            ],
+            "x-enum-varnames": [
+                "CommentStatusReject",
+                "CommentStatusPending",
+                "CommentStatusAccepted"
+            ]
+        },
+        "domain.CompleteReq": {
+            "type": "object",
+            "required": [
+                "kb_id"
+            ],
+            "properties": {
+                "kb_id": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "description": "For FIM (Fill in Middle) style completion",
+                    "type": "string"
+                },
+                "suffix": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ContributeSettings": {
+            "type": "object",
+            "properties": {
+                "is_enable": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "domain.ConversationDetailResp": {
+            "type": "object",
+            "properties": {
+                "app_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ip_address": {
+                    "$ref": "#/definitions/domain.IPAddress"
+                },
+                "message_tree": {
+                    "description": "every branch of the conversation",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ConversationMessageTreeNode"
+                    }
+                },
+                "messages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ConversationMessage"
+                    }
+                },
+                "references": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ConversationReference"
+                    }
+                },
+                "remote_ip": {
+                    "type": "string"
+                },
+                "subject": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ConversationDistribution": {
+            "type": "object",
+            "properties": {
+                "app_id": {
+                    "type": "string"
+                },
+                "app_type": {
+                    "$ref": "#/definitions/domain.AppType"
+                },
+                "count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ConversationInfo": {
+            "type": "object",
+            "properties": {
+                "user_info": {
+                    "$ref": "#/definitions/domain.UserInfo"
+                }
+            }
+        },
+        "domain.ConversationListItem": {
+            "type": "object",
+            "properties": {
+                "app_name": {
+                    "type": "string"
+                },
+                "app_type": {
+                    "$ref": "#/definitions/domain.AppType"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "feedback_info": {
+                    "description": "用户反馈信息",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.FeedBackInfo"
+                        }
+                    ]
+                },
+                "id": {
+                    "type": "string"
+                },
+                "info": {
+                    "description": "用户信息",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.ConversationInfo"
+                        }
+                    ]
+                },
+                "ip_address": {
+                    "$ref": "#/definitions/domain.IPAddress"
+                },
+                "remote_ip": {
+                    "type": "string"
+                },
+                "subject": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ConversationMessage": {
+            "type": "object",
+            "properties": {
+                "app_id": {
+                    "type": "string"
+                },
+                "completion_tokens": {
+                    "type": "integer"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "conversation_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image_description": {
+                    "type": "string"
+                },
+                "images": {
+                    "description": "images attached to the question and their description by the vision model",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "info": {
+                    "description": "feedbackinfo",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.FeedBackInfo"
+                        }
+                    ]
+                },
+                "kb_id": {
+                    "type": "string"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "parent_id: answer -\u003e question, question -\u003e previous answer",
+                    "type": "string"
+                },
+                "prompt_tokens": {
+                    "type": "integer"
+                },
+                "provider": {
+                    "description": "model",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.ModelProvider"
+                        }
+                    ]
+                },
+                "remote_ip": {
+                    "description": "stats",
+                    "type": "string"
+                },
+                "role": {
+                    "$ref": "#/definitions/schema.RoleType"
+                },
+                "total_tokens": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ConversationMessageListItem": {
+            "type": "object",
+            "properties": {
+                "app_id": {
+                    "type": "string"
+                },
+                "app_type": {
+                    "$ref": "#/definitions/domain.AppType"
+                },
+                "conversation_id": {
+                    "type": "string"
+                },
+                "conversation_info": {
+                    "description": "userInfo",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.ConversationInfo"
+                        }
+                    ]
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "info": {
+                    "description": "feedbackInfo",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.FeedBackInfo"
+                        }
+                    ]
+                },
+                "ip_address": {
+                    "$ref": "#/definitions/domain.IPAddress"
+                },
+                "question": {
+                    "type": "string"
+                },
+                "remote_ip": {
+                    "description": "stats",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ConversationMessageTreeNode": {
+            "type": "object",
+            "properties": {
+                "app_id": {
+                    "type": "string"
+                },
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ConversationMessageTreeNode"
+                    }
+                },
+                "completion_tokens": {
+                    "type": "integer"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "conversation_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image_description": {
+                    "type": "string"
+                },
+                "images": {
+                    "description": "images attached to the question and their description by the vision model",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "info": {
+                    "description": "feedbackinfo",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.FeedBackInfo"
+                        }
+                    ]
+                },
+                "kb_id": {
+                    "type": "string"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "parent_id: answer -\u003e question, question -\u003e previous answer",
+                    "type": "string"
+                },
+                "prompt_tokens": {
+                    "type": "integer"
+                },
+                "provider": {
+                    "description": "model",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.ModelProvider"
+                        }
+                    ]
+                },
+                "remote_ip": {
+                    "description": "stats",
+                    "type": "string"
+                },
+                "role": {
+                    "$ref": "#/definitions/schema.RoleType"
+                },
+                "total_tokens": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ConversationReference": {
+            "type": "object",
+            "properties": {
+                "app_id": {
+                    "type": "string"
+                },
+                "conversation_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "node_id": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ConversationSetting": {
+            "type": "object",
+            "properties": {
+                "copyright_hide_enabled": {
+                    "type": "boolean"
+                },
+                "copyright_info": {
+                    "type": "string"
+                },
+                "follow_up_suggestions_enabled": {
+                    "description": "回答结束后生成追问建议",
+                    "type": "boolean"
+                }
+            }
+        },
+        "domain.CrawlerSubscription": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "creator_id": {
+                    "description": "scheduled syncs create and publish nodes on behalf of the user who set up the subscription",
+                    "type": "string"
+                },
+                "delete_removed": {
+                    "description": "delete the nodes of items removed from the source, they are only marked removed otherwise",
+                    "type": "boolean"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "interval_hours": {
+                    "type": "integer"
+                },
+                "kb_id": {
+                    "type": "string"
+                },
+                "next_sync_at": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "node the synced documents are placed under, empty for the root of the kb",
+                    "type": "string"
+                },
+                "policy": {
+                    "$ref": "#/definitions/domain.CrawlerSyncPolicy"
+                },
+                "result": {
+                    "$ref": "#/definitions/domain.CrawlerSyncResult"
+                },
+                "source": {
+                    "description": "url, rss or sitemap",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/consts.CrawlerSource"
+                        }
+                    ]
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.CrawlerSyncStatus"
+                },
+                "synced_at": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CrawlerSyncPolicy": {
+            "type": "string",
+            "enum": [
+                "draft",
+                "publish"
+            ],
+            "x-enum-varnames": [
+                "CrawlerSyncPolicyDraft",
+                "CrawlerSyncPolicyPublish"
+            ]
+        },
+        "domain.CrawlerSyncResult": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "not_modified": {
+                    "description": "the feed did not change since the last sync, nothing was listed",
+                    "type": "boolean"
+                },
+                "release_id": {
+                    "type": "string"
+                },
+                "removed": {
+                    "type": "integer"
+                },
+                "skipped": {
+                    "type": "integer"
+                },
+                "unchanged": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.CrawlerSyncStatus": {
+            "type": "string",
+            "enum": [
+                "idle",
+                "pending",
+                "running",
+                "completed",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "CrawlerSyncStatusIdle",
+                "CrawlerSyncStatusPending",
+                "CrawlerSyncStatusRunning",
+                "CrawlerSyncStatusCompleted",
+                "CrawlerSyncStatusFailed"
+            ]
+        },
+        "domain.CreateCrawlerSubscriptionReq": {
+            "type": "object",
+            "required": [
+                "kb_id",
+                "policy",
+                "source",
+                "url"
+            ],
+            "properties": {
+                "delete_removed": {
+                    "type": "boolean"
+                },
+                "interval_hours": {
+                    "description": "0 for the default of a day",
+                    "type": "integer",
+                    "maximum": 720,
+                    "minimum": 0
+                },
+                "kb_id": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                },
+                "policy": {
+                    "enum": [
+                        "draft",
+                        "publish"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.CrawlerSyncPolicy"
+                        }
+                    ]
+                },
+                "source": {
+                    "enum": [
+                        "url",
+                        "rss",
+                        "sitemap"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/consts.CrawlerSource"
+                        }
+                    ]
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CreateCuratedAnswerReq": {
+            "type": "object",
+            "required": [
+                "answer",
+                "kb_id"
+            ],
+            "properties": {
+                "answer": {
+                    "type": "string"
+                },
+                "kb_id": {
+                    "type": "string"
+                },
+                "message_id": {
+                    "description": "assistant message being corrected, its question is used when question is empty",
+                    "type": "string"
+                },
+                "question": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CreateEvalQuestionReq": {
+            "type": "object",
+            "required": [
+                "kb_id",
+                "question",
+                "set_id"
+            ],
+            "properties": {
+                "expected_node_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "kb_id": {
+                    "type": "string"
+                },
+                "question": {
+                    "type": "string"
+                },
+                "reference_answer": {
+                    "type": "string"
+                },
+                "set_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CreateEvalRunReq": {
+            "type": "object",
+            "required": [
+                "kb_id",
+                "set_id"
+            ],
+            "properties": {
+                "kb_id": {
+                    "type": "string"
+                },
+                "set_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CreateEvalSetReq": {
+            "type": "object",
+            "required": [
+                "kb_id",
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "kb_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CreateGitSourceReq": {
+            "type": "object",
+            "required": [
+                "branch",
+                "kb_id"
+            ],
+            "properties": {
+                "auto_release": {
+                    "type": "boolean"
+                },
+                "branch": {
+                    "type": "string"
+                },
+                "dir": {
+                    "type": "string"
+                },
+                "kb_id": {
+                    "type": "string"
+                },
+                "local_path": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CreateImportJobReq": {
+            "type": "object",
+            "required": [
+                "docs",
+                "kb_id",
+                "parse_id",
+                "source"
+            ],
+            "properties": {
+                "concurrency": {
+                    "description": "number of documents exported at the same time, defaults to 4",
+                    "type": "integer"
+                },
+                "docs": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/domain.ImportJobDoc"
+                    }
+                },
+                "kb_id": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                },
+                "parse_id": {
+                    "description": "id returned by the crawler parse that listed the documents",
+                    "type": "string"
+                },
+                "source": {
+                    "$ref": "#/definitions/consts.CrawlerSource"
+                }
+            }
+        },
+        "domain.CreateKBReleaseReq": {
+            "type": "object",
+            "required": [
+                "kb_id",
+                "message",
+                "tag"
+            ],
+            "properties": {
+                "kb_id": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "node_ids": {
+                    "description": "create release after these nodes published",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tag": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CreateKnowledgeBaseReq": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "hosts": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "ports": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "private_key": {
+                    "type": "string"
+                },
+                "public_key": {
+                    "type": "string"
+                },
+                "ssl_ports": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "domain.CreateLinkCheckJobReq": {
+            "type": "object",
+            "required": [
+                "kb_id"
+            ],
+            "properties": {
+                "concurrency": {
+                    "description": "number of links checked at the same time, defaults to 8",
+                    "type": "integer"
+                },
+                "kb_id": {
+                    "type": "string"
+                },
+                "timeout_seconds": {
+                    "description": "timeout of the request to an external url, defaults to 10",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.CreateModelReq": {
+            "type": "object",
+            "required": [
+                "base_url",
+                "model",
+                "provider",
+                "type"
+            ],
+            "properties": {
+                "api_header": {
+                    "type": "string"
+                },
+                "api_key": {
+                    "type": "string"
+                },
+                "api_version": {
+                    "description": "for azure openai",
+                    "type": "string"
+                },
+                "base_url": {
+                    "type": "string"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "parameters": {
+                    "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.ModelParam"
+                },
+                "provider": {
+                    "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.ModelProvider"
+                },
+                "type": {
+                    "enum": [
+                        "chat",
+                        "embedding",
+                        "rerank",
+                        "analysis",
+                        "analysis-vl"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.ModelType"
+                        }
+                    ]
+                }
+            }
+        },
+        "domain.CreateNodeReq": {
+            "type": "object",
+            "required": [
+                "kb_id",
+                "name",
+                "type"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "content_type": {
+                    "type": "string"
+                },
+                "emoji": {
+                    "type": "string"
+                },
+                "external_id": {
+                    "type": "string"
+                },
+                "kb_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "number"
+                },
+                "summary": {
+                    "type": "string"
+                },
+                "type": {
+                    "enum": [
+                        1,
+                        2
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.NodeType"
+                        }
+                    ]
+                }
+            }
+        },
+        "domain.CreateSummaryJobReq": {
+            "type": "object",
+            "required": [
+                "kb_id"
+            ],
+            "properties": {
+                "concurrency": {
+                    "description": "number of summaries generated at the same time, defaults to 2",
+                    "type": "integer"
+                },
+                "kb_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CuratedAnswer": {
+            "type": "object",
+            "properties": {
+                "answer": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "hit_count": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kb_id": {
+                    "type": "string"
+                },
+                "last_hit_at": {
+                    "type": "string"
+                },
+                "question": {
+                    "type": "string"
+                },
+                "source_message_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.DirDocConfig": {
+            "type": "object",
+            "properties": {
+                "bg_color": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "title_color": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.DisclaimerSettings": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.EnterpriseAuth": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "domain.EvalMetrics": {
+            "type": "object",
+            "properties": {
+                "correctness": {
+                    "type": "number"
+                },
+                "correctness_count": {
+                    "type": "integer"
+                },
+                "error_count": {
+                    "type": "integer"
+                },
+                "faithfulness": {
+                    "type": "number"
+                },
+                "faithfulness_count": {
+                    "type": "integer"
+                },
+                "hit_rate": {
+                    "type": "number"
+                },
+                "mrr": {
+                    "type": "number"
+                },
+                "question_count": {
+                    "type": "integer"
+                },
+                "retrieval_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.EvalQuestion": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expected_node_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kb_id": {
+                    "type": "string"
+                },
+                "question": {
+                    "type": "string"
+                },
+                "reference_answer": {
+                    "type": "string"
+                },
+                "set_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.EvalResult": {
+            "type": "object",
+            "properties": {
+                "answer": {
+                    "type": "string"
+                },
+                "correctness": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "faithfulness": {
+                    "type": "number"
+                },
+                "hit": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "judge_reason": {
+                    "type": "string"
+                },
+                "question": {
+                    "type": "string"
+                },
+                "question_id": {
+                    "type": "string"
+                },
+                "reciprocal_rank": {
+                    "type": "number"
+                },
+                "retrieved_node_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "run_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.EvalRun": {
+            "type": "object",
+            "properties": {
+                "config": {
+                    "$ref": "#/definitions/domain.EvalRunConfig"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kb_id": {
+                    "type": "string"
+                },
+                "metrics": {
+                    "$ref": "#/definitions/domain.EvalMetrics"
+                },
+                "set_id": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.EvalRunStatus"
+                }
+            }
+        },
+        "domain.EvalRunCompareItem": {
+            "type": "object",
+            "properties": {
+                "base": {
+                    "$ref": "#/definitions/domain.EvalResult"
+                },
+                "question": {
+                    "type": "string"
+                },
+                "question_id": {
+                    "type": "string"
+                },
+                "target": {
+                    "$ref": "#/definitions/domain.EvalResult"
+                }
+            }
+        },
+        "domain.EvalRunCompareResp": {
+            "type": "object",
+            "properties": {
+                "base": {
+                    "$ref": "#/definitions/domain.EvalRun"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.EvalRunCompareItem"
+                    }
+                },
+                "target": {
+                    "$ref": "#/definitions/domain.EvalRun"
+                }
+            }
+        },
+        "domain.EvalRunConfig": {
+            "type": "object",
+            "properties": {
+                "chat_model": {
+                    "type": "string"
+                },
+                "embedding_model": {
+                    "type": "string"
+                },
+                "mode": {
+                    "type": "string"
+                },
+                "prompt": {
+                    "type": "string"
+                },
+                "rerank_model": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.EvalRunDetailResp": {
+            "type": "object",
+            "properties": {
+                "config": {
+                    "$ref": "#/definitions/domain.EvalRunConfig"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kb_id": {
+                    "type": "string"
+                },
+                "metrics": {
+                    "$ref": "#/definitions/domain.EvalMetrics"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.EvalResult"
+                    }
+                },
+                "set_id": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.EvalRunStatus"
+                }
+            }
+        },
+        "domain.EvalRunStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "running",
+                "completed",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "EvalRunStatusPending",
+                "EvalRunStatusRunning",
+                "EvalRunStatusCompleted",
+                "EvalRunStatusFailed"
+            ]
+        },
+        "domain.EvalSet": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kb_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.FaqConfig": {
+            "type": "object",
+            "properties": {
+                "bg_color": {
+                    "type": "string"
+                },
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "properties": {
+                            "id": {
+                                "type": "string"
+                            },
+                            "link": {
+                                "type": "string"
+                            },
+                            "question": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "title_color": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.FeatureConfig": {
+            "type": "object",
+            "properties": {
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "properties": {
+                            "desc": {
+                                "type": "string"
+                            },
+                            "id": {
+                                "type": "string"
+                            },
+                            "name": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.FeedBackInfo": {
+            "type": "object",
+            "properties": {
+                "feedback_content": {
+                    "type": "string"
+                },
+                "feedback_type": {
+                    "type": "string"
+                },
+                "score": {
+                    "$ref": "#/definitions/domain.ScoreType"
+                }
+            }
+        },
+        "domain.FeedbackRequest": {
+            "type": "object",
+            "required": [
+                "message_id"
+            ],
+            "properties": {
+                "conversation_id": {
+                    "type": "string"
+                },
+                "feedback_content": {
+                    "description": "限制内容长度",
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "message_id": {
+                    "type": "string"
+                },
+                "score": {
+                    "description": "-1 踩 ,0 1 赞成",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.ScoreType"
+                        }
+                    ]
+                },
+                "type": {
+                    "description": "内容不准确，没有帮助，.......",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.FooterSettings": {
+            "type": "object",
+            "properties": {
+                "brand_desc": {
+                    "type": "string"
+                },
+                "brand_groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.BrandGroup"
+                    }
+                },
+                "brand_logo": {
+                    "type": "string"
+                },
+                "brand_name": {
+                    "type": "string"
+                },
+                "corp_name": {
+                    "type": "string"
+                },
+                "footer_style": {
+                    "type": "string"
+                },
+                "icp": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.GetKBReleaseListResp": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.KBReleaseListItemResp"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.GetProviderModelListReq": {
+            "type": "object",
+            "required": [
+                "base_url",
+                "provider",
+                "type"
+            ],
+            "properties": {
+                "api_header": {
+                    "type": "string"
+                },
+                "api_key": {
+                    "type": "string"
+                },
+                "base_url": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "type": {
+                    "enum": [
+                        "chat",
+                        "embedding",
+                        "rerank",
+                        "analysis",
+                        "analysis-vl"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.ModelType"
+                        }
+                    ]
+                }
+            }
+        },
+        "domain.GetProviderModelListResp": {
+            "type": "object",
+            "properties": {
+                "models": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ProviderModelListItem"
+                    }
+                }
+            }
+        },
+        "domain.GitSource": {
+            "type": "object",
+            "properties": {
+                "auto_release": {
+                    "description": "publish the changed nodes as a release tagged with the commit after each sync",
+                    "type": "boolean"
+                },
+                "branch": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "dir": {
+                    "description": "directory of the repository to sync, empty for the whole repository",
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kb_id": {
+                    "type": "string"
+                },
+                "last_commit": {
+                    "type": "string"
+                },
+                "local_path": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "node the synced tree is placed under, empty for the root of the kb",
+                    "type": "string"
+                },
+                "result": {
+                    "$ref": "#/definitions/domain.GitSyncResult"
+                },
+                "started_at": {
+                    "description": "when the current or last sync was queued, and again when it started",
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.GitSyncStatus"
+                },
+                "synced_at": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "description": "either a remote repository url or a path on the server",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.GitSyncResult": {
+            "type": "object",
+            "properties": {
+                "commit": {
+                    "type": "string"
+                },
+                "created": {
+                    "type": "integer"
+                },
+                "deleted": {
+                    "type": "integer"
+                },
+                "release_id": {
+                    "type": "string"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.GitSyncStatus": {
+            "type": "string",
+            "enum": [
+                "idle",
+                "pending",
+                "running",
+                "completed",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "GitSyncStatusIdle",
+                "GitSyncStatusPending",
+                "GitSyncStatusRunning",
+                "GitSyncStatusCompleted",
+                "GitSyncStatusFailed"
+            ]
+        },
+        "domain.HotBrowser": {
+            "type": "object",
+            "properties": {
+                "browser": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.BrowserCount"
+                    }
+                },
+                "os": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.BrowserCount"
+                    }
+                }
+            }
+        },
+        "domain.HotPage": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "node_id": {
+                    "type": "string"
+                },
+                "node_name": {
+                    "type": "string"
+                },
+                "scene": {
+                    "$ref": "#/definitions/domain.StatPageScene"
+                }
+            }
+        },
+        "domain.HotRefererHost": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "referer_host": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.IPAddress": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "type": "string"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "province": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ImgTextConfig": {
+            "type": "object",
+            "properties": {
+                "item": {
+                    "type": "object",
+                    "properties": {
+                        "desc": {
+                            "type": "string"
+                        },
+                        "name": {
+                            "type": "string"
+                        },
+                        "url": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ImportJob": {
+            "type": "object",
+            "properties": {
+                "concurrency": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "creator_id": {
+                    "description": "documents are created on behalf of the user who created the job",
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "heartbeat_at": {
+                    "description": "moved forward whenever an item changes state, a stale heartbeat marks a lost job",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kb_id": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "node the documents are placed under, empty for the root of the kb",
+                    "type": "string"
+                },
+                "parse_id": {
+                    "description": "id of the crawler parse the documents were listed by, the crawler needs it to export them",
+                    "type": "string"
+                },
+                "progress": {
+                    "$ref": "#/definitions/domain.ImportJobProgress"
+                },
+                "source": {
+                    "$ref": "#/definitions/consts.CrawlerSource"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.ImportJobStatus"
+                }
+            }
+        },
+        "domain.ImportJobDoc": {
+            "type": "object",
+            "required": [
+                "doc_id"
             ],
-            "x-enum-varnames": [
-                "CopySettingNone",
-                "CopySettingAppend",
-                "CopySettingDisabled"
-            ]
+            "properties": {
+                "doc_id": {
+                    "type": "string"
+                },
+                "file_type": {
+                    "type": "string"
+                },
+                "folders": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "space_id": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
         },
-        "consts.CrawlerSource": {
+        "domain.ImportJobItem": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "doc_id": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "file_type": {
+                    "type": "string"
+                },
+                "folders": {
+                    "description": "folders the document is placed in below the parent of the job",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "job_id": {
+                    "type": "string"
+                },
+                "node_id": {
+                    "type": "string"
+                },
+                "position": {
+                    "description": "order of the document in the listing",
+                    "type": "integer"
+                },
+                "space_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.ImportJobItemStatus"
+                },
+                "task_id": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ImportJobItemStatus": {
             "type": "string",
             "enum": [
-                "url",
-                "rss",
-                "sitemap",
-                "notion",
-                "feishu",
-                "file",
-                "epub",
-                "yuque",
-                "siyuan",
-                "mindoc",
-                "wikijs",
-                "confluence"
+                "listed",
+                "exporting",
+                "imported",
+                "failed"
             ],
             "x-enum-varnames": [
-                "CrawlerSourceUrl",
-                "CrawlerSourceRSS",
-                "CrawlerSourceSitemap",
-                "CrawlerSourceNotion",
-                "CrawlerSourceFeishu",
-                "CrawlerSourceFile",
-                "CrawlerSourceEpub",
-                "CrawlerSourceYuque",
-                "CrawlerSourceSiyuan",
-                "CrawlerSourceMindoc",
-                "CrawlerSourceWikijs",
-                "CrawlerSourceConfluence"
+                "ImportJobItemStatusListed",
+                "ImportJobItemStatusExporting",
+                "ImportJobItemStatusImported",
+                "ImportJobItemStatusFailed"
             ]
         },
-        "consts.CrawlerStatus": {
+        "domain.ImportJobProgress": {
+            "type": "object",
+            "properties": {
+                "exporting": {
+                    "type": "integer"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "imported": {
+                    "type": "integer"
+                },
+                "listed": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ImportJobStatus": {
             "type": "string",
             "enum": [
                 "pending",
-                "in_process",
+                "running",
                 "completed",
                 "failed"
             ],
             "x-enum-varnames": [
-                "CrawlerStatusPending",
-                "CrawlerStatusInProcess",
-                "CrawlerStatusCompleted",
-                "CrawlerStatusFailed"
+                "ImportJobStatusPending",
+                "ImportJobStatusRunning",
+                "ImportJobStatusCompleted",
+                "ImportJobStatusFailed"
             ]
         },
-        "consts.HomePageSetting": {
-            "type": "string",
-            "enum": [
-                "doc",
-                "custom"
-            ],
-            "x-enum-comments": {
-                "HomePageSettingCustom": "自定义首页",
-                "HomePageSettingDoc": "文档页面"
-            },
-            "x-enum-descriptions": [
-                "文档页面",
-                "自定义首页"
-            ],
-            "x-enum-varnames": [
-                "HomePageSettingDoc",
-                "HomePageSettingCustom"
-            ]
+        "domain.InstantCountResp": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "time": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.InstantPageResp": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "info": {
+                    "$ref": "#/definitions/domain.AuthUserInfo"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "ip_address": {
+                    "$ref": "#/definitions/domain.IPAddress"
+                },
+                "node_id": {
+                    "type": "string"
+                },
+                "node_name": {
+                    "type": "string"
+                },
+                "scene": {
+                    "$ref": "#/definitions/domain.StatPageScene"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.KBReleaseListItemResp": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kb_id": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "tag": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.KnowledgeBaseDetail": {
+            "type": "object",
+            "properties": {
+                "access_settings": {
+                    "$ref": "#/definitions/domain.AccessSettings"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "dataset_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "perm": {
+                    "description": "用户对知识库的权限",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/consts.UserKBPermission"
+                        }
+                    ]
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.KnowledgeBaseListItem": {
+            "type": "object",
+            "properties": {
+                "access_settings": {
+                    "$ref": "#/definitions/domain.AccessSettings"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "dataset_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
         },
-        "consts.LicenseEdition": {
-            "type": "integer",
-            "format": "int32",
-            "enum": [
-                0,
-                1,
-                2,
-                3
-            ],
-            "x-enum-comments": {
-                "LicenseEditionBusiness": "商业版",
-                "LicenseEditionEnterprise": "企业版",
-                "LicenseEditionFree": "开源版",
-                "LicenseEditionProfession": "专业版"
-            },
-            "x-enum-descriptions": [
-                "开源版",
-                "专业版",
-                "企业版",
-                "商业版"
-            ],
-            "x-enum-varnames": [
-                "LicenseEditionFree",
-                "LicenseEditionProfession",
-                "LicenseEditionEnterprise",
-                "LicenseEditionBusiness"
-            ]
+        "domain.LanguageSetting": {
+            "type": "object",
+            "properties": {
+                "language": {
+                    "description": "知识库主要语言",
+                    "type": "string"
+                },
+                "match_answer_language": {
+                    "description": "使用提问的语言回答",
+                    "type": "boolean"
+                },
+                "translate_query": {
+                    "description": "检索前将问题翻译为知识库主要语言",
+                    "type": "boolean"
+                }
+            }
         },
-        "consts.ModelSettingMode": {
-            "type": "string",
-            "enum": [
-                "manual",
-                "auto"
-            ],
-            "x-enum-varnames": [
-                "ModelSettingModeManual",
-                "ModelSettingModeAuto"
-            ]
+        "domain.LarkBotSettings": {
+            "type": "object",
+            "properties": {
+                "app_id": {
+                    "type": "string"
+                },
+                "app_secret": {
+                    "type": "string"
+                },
+                "encrypt_key": {
+                    "type": "string"
+                },
+                "is_enabled": {
+                    "type": "boolean"
+                },
+                "verify_token": {
+                    "type": "string"
+                }
+            }
         },
-        "consts.NodeAccessPerm": {
-            "type": "string",
-            "enum": [
-                "open",
-                "partial",
-                "closed"
-            ],
-            "x-enum-comments": {
-                "NodeAccessPermClosed": "完全禁止",
-                "NodeAccessPermOpen": "完全开放",
-                "NodeAccessPermPartial": "部分开放"
-            },
-            "x-enum-descriptions": [
-                "完全开放",
-                "部分开放",
-                "完全禁止"
-            ],
-            "x-enum-varnames": [
-                "NodeAccessPermOpen",
-                "NodeAccessPermPartial",
-                "NodeAccessPermClosed"
-            ]
+        "domain.Link": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
         },
-        "consts.NodePermName": {
-            "type": "string",
-            "enum": [
-                "visible",
-                "visitable",
-                "answerable"
-            ],
-            "x-enum-comments": {
-                "NodePermNameAnswerable": "可被问答",
-                "NodePermNameVisible": "导航内可见",
-                "NodePermNameVisitable": "可被访问"
-            },
-            "x-enum-descriptions": [
-                "导航内可见",
-                "可被访问",
-                "可被问答"
-            ],
-            "x-enum-varnames": [
-                "NodePermNameVisible",
-                "NodePermNameVisitable",
-                "NodePermNameAnswerable"
-            ]
+        "domain.LinkCheckBrokenResp": {
+            "type": "object",
+            "properties": {
+                "job": {
+                    "description": "nil when no job of the kb has finished yet",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.LinkCheckJob"
+                        }
+                    ]
+                },
+                "nodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.LinkCheckNodeReport"
+                    }
+                }
+            }
         },
-        "consts.NodeRagInfoStatus": {
+        "domain.LinkCheckJob": {
+            "type": "object",
+            "properties": {
+                "broken": {
+                    "type": "integer"
+                },
+                "concurrency": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kb_id": {
+                    "type": "string"
+                },
+                "release_id": {
+                    "description": "kb release whose documents were checked",
+                    "type": "string"
+                },
+                "scheduled": {
+                    "description": "created by the schedule of the kb instead of a user",
+                    "type": "boolean"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.LinkCheckJobStatus"
+                },
+                "timeout_seconds": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.LinkCheckJobStatus": {
             "type": "string",
             "enum": [
-                "BASIC_PENDING",
-                "BASIC_RUNNING",
-                "BASIC_FAILED",
-                "BASIC_SUCCEEDED",
-                "ENHANCE_PENDING",
-                "ENHANCE_RUNNING",
-                "ENHANCE_FAILED",
-                "ENHANCE_SUCCEEDED"
-            ],
-            "x-enum-comments": {
-                "NodeRagStatusBasicFailed": "基础处理失败",
-                "NodeRagStatusBasicPending": "等待基础处理",
-                "NodeRagStatusBasicRunning": "正在进行基础处理（文本分割、向量化等）",
-                "NodeRagStatusBasicSucceeded": "基础处理成功",
-                "NodeRagStatusEnhanceFailed": "增强处理失败",
-                "NodeRagStatusEnhancePending": "基础处理完成，等待增强处理",
-                "NodeRagStatusEnhanceRunning": "正在进行增强处理（关键词提取等）",
-                "NodeRagStatusEnhanceSucceeded": "增强处理成功"
-            },
-            "x-enum-descriptions": [
-                "等待基础处理",
-                "正在进行基础处理（文本分割、向量化等）",
-                "基础处理失败",
-                "基础处理成功",
-                "基础处理完成，等待增强处理",
-                "正在进行增强处理（关键词提取等）",
-                "增强处理失败",
-                "增强处理成功"
+                "pending",
+                "running",
+                "completed",
+                "failed"
             ],
             "x-enum-varnames": [
-                "NodeRagStatusBasicPending",
-                "NodeRagStatusBasicRunning",
-                "NodeRagStatusBasicFailed",
-                "NodeRagStatusBasicSucceeded",
-                "NodeRagStatusEnhancePending",
-                "NodeRagStatusEnhanceRunning",
-                "NodeRagStatusEnhanceFailed",
-                "NodeRagStatusEnhanceSucceeded"
+                "LinkCheckJobStatusPending",
+                "LinkCheckJobStatusRunning",
+                "LinkCheckJobStatusCompleted",
+                "LinkCheckJobStatusFailed"
             ]
         },
-        "consts.RedeemCaptchaReq": {
+        "domain.LinkCheckKind": {
+            "type": "string",
+            "enum": [
+                "node",
+                "asset",
+                "external"
+            ],
+            "x-enum-varnames": [
+                "LinkCheckKindNode",
+                "LinkCheckKindAsset",
+                "LinkCheckKindExternal"
+            ]
+        },
+        "domain.LinkCheckNodeReport": {
             "type": "object",
             "properties": {
-                "solutions": {
+                "links": {
                     "type": "array",
                     "items": {
-                        "type": "integer"
+                        "$ref": "#/definitions/domain.LinkCheckResult"
                     }
                 },
-                "token": {
+                "node_id": {
+                    "type": "string"
+                },
+                "node_name": {
                     "type": "string"
                 }
             }
         },
-        "consts.SourceType": {
-            "type": "string",
-            "enum": [
-                "dingtalk",
-                "feishu",
-                "wecom",
-                "oauth",
-                "github",
-                "cas",
-                "ldap",
-                "widget",
-                "dingtalk_bot",
-                "feishu_bot",
-                "lark_bot",
-                "wechat_bot",
-                "wecom_ai_bot",
-                "wechat_service_bot",
-                "discord_bot",
-                "wechat_official_account",
-                "openai_api",
-                "mcp_server"
-            ],
-            "x-enum-varnames": [
-                "SourceTypeDingTalk",
-                "SourceTypeFeishu",
-                "SourceTypeWeCom",
-                "SourceTypeOAuth",
-                "SourceTypeGitHub",
-                "SourceTypeCAS",
-                "SourceTypeLDAP",
-                "SourceTypeWidget",
-                "SourceTypeDingtalkBot",
-                "SourceTypeFeishuBot",
-                "SourceTypeLarkBot",
-                "SourceTypeWechatBot",
-                "SourceTypeWecomAIBot",
-                "SourceTypeWechatServiceBot",
-                "SourceTypeDiscordBot",
-                "SourceTypeWechatOfficialAccount",
-                "SourceTypeOpenAIAPI",
-                "SourceTypeMcpServer"
-            ]
+        "domain.LinkCheckResult": {
+            "type": "object",
+            "properties": {
+                "broken": {
+                    "type": "boolean"
+                },
+                "checked_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "job_id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "$ref": "#/definitions/domain.LinkCheckKind"
+                },
+                "node_id": {
+                    "type": "string"
+                },
+                "node_name": {
+                    "type": "string"
+                },
+                "status_code": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
         },
-        "consts.StatDay": {
+        "domain.LinkCheckSchedule": {
+            "type": "object",
+            "properties": {
+                "concurrency": {
+                    "type": "integer"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "interval_hours": {
+                    "type": "integer"
+                },
+                "kb_id": {
+                    "type": "string"
+                },
+                "next_run_at": {
+                    "type": "string"
+                },
+                "timeout_seconds": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.MCPServerSettings": {
+            "type": "object",
+            "properties": {
+                "docs_tool_settings": {
+                    "$ref": "#/definitions/domain.MCPToolSettings"
+                },
+                "is_enabled": {
+                    "type": "boolean"
+                },
+                "sample_auth": {
+                    "$ref": "#/definitions/domain.SimpleAuth"
+                }
+            }
+        },
+        "domain.MCPToolSettings": {
+            "type": "object",
+            "properties": {
+                "desc": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.MessageContent": {
+            "type": "object"
+        },
+        "domain.MessageFrom": {
             "type": "integer",
             "enum": [
                 1,
-                7,
-                30,
-                90
+                2
             ],
             "x-enum-varnames": [
-                "StatDay1",
-                "StatDay7",
-                "StatDay30",
-                "StatDay90"
+                "MessageFromGroup",
+                "MessageFromPrivate"
             ]
         },
-        "consts.UserKBPermission": {
-            "type": "string",
-            "enum": [
-                "",
-                "full_control",
-                "doc_manage",
-                "data_operate"
-            ],
-            "x-enum-comments": {
-                "UserKBPermissionDataOperate": "数据运营",
-                "UserKBPermissionDocManage": "文档管理",
-                "UserKBPermissionFullControl": "完全控制",
-                "UserKBPermissionNull": "无权限"
-            },
-            "x-enum-descriptions": [
-                "无权限",
-                "完全控制",
-                "文档管理",
-                "数据运营"
-            ],
-            "x-enum-varnames": [
-                "UserKBPermissionNull",
-                "UserKBPermissionFullControl",
-                "UserKBPermissionDocManage",
-                "UserKBPermissionDataOperate"
-            ]
+        "domain.MetricsConfig": {
+            "type": "object",
+            "properties": {
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "properties": {
+                            "id": {
+                                "type": "string"
+                            },
+                            "name": {
+                                "type": "string"
+                            },
+                            "number": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
         },
-        "consts.UserRole": {
-            "type": "string",
-            "enum": [
-                "admin",
-                "user"
-            ],
-            "x-enum-comments": {
-                "UserRoleAdmin": "管理员",
-                "UserRoleUser": "普通用户"
-            },
-            "x-enum-descriptions": [
-                "管理员",
-                "普通用户"
-            ],
-            "x-enum-varnames": [
-                "UserRoleAdmin",
-                "UserRoleUser"
-            ]
+        "domain.ModelModeSetting": {
+            "type": "object",
+            "properties": {
+                "auto_mode_api_key": {
+                    "description": "百智云 API Key",
+                    "type": "string"
+                },
+                "chat_model": {
+                    "description": "自定义对话模型名称",
+                    "type": "string"
+                },
+                "is_manual_embedding_updated": {
+                    "description": "手动模式下嵌入模型是否更新",
+                    "type": "boolean"
+                },
+                "mode": {
+                    "description": "模式: manual 或 auto",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/consts.ModelSettingMode"
+                        }
+                    ]
+                }
+            }
         },
-        "consts.WatermarkSetting": {
+        "domain.ModelType": {
             "type": "string",
-            "enum": [
-                "",
-                "hidden",
-                "visible"
-            ],
-            "x-enum-comments": {
-                "WatermarkDisabled": "未开启水印",
-                "WatermarkHidden": "隐形水印",
-                "WatermarkVisible": "显性水印"
-            },
-            "x-enum-descriptions": [
-                "未开启水印",
-                "隐形水印",
-                "显性水印"
+            "enum": [
+                "chat",
+                "embedding",
+                "rerank",
+                "analysis",
+                "analysis-vl"
             ],
             "x-enum-varnames": [
-                "WatermarkDisabled",
-                "WatermarkHidden",
-                "WatermarkVisible"
+                "ModelTypeChat",
+                "ModelTypeEmbedding",
+                "ModelTypeRerank",
+                "ModelTypeAnalysis",
+                "ModelTypeAnalysisVL"
             ]
         },
-        "domain.AIFeedbackSettings": {
+        "domain.MoveNodeReq": {
             "type": "object",
+            "required": [
+                "id",
+                "kb_id"
+            ],
             "properties": {
-                "ai_feedback_type": {
+                "id": {
+                    "type": "string"
+                },
+                "kb_id": {
+                    "type": "string"
+                },
+                "next_id": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                },
+                "prev_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.NodeActionReq": {
+            "type": "object",
+            "required": [
+                "action",
+                "ids",
+                "kb_id"
+            ],
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "delete"
+                    ]
+                },
+                "ids": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "is_enabled": {
-                    "type": "boolean"
+                "kb_id": {
+                    "type": "string"
                 }
             }
         },
-        "domain.AccessSettings": {
+        "domain.NodeActionResp": {
             "type": "object",
             "properties": {
-                "base_url": {
+                "broken_links": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.NodeBacklink"
+                    }
+                }
+            }
+        },
+        "domain.NodeBacklink": {
+            "type": "object",
+            "properties": {
+                "emoji": {
                     "type": "string"
                 },
-                "enterprise_auth": {
-                    "$ref": "#/definitions/domain.EnterpriseAuth"
+                "id": {
+                    "type": "string"
                 },
-                "hosts": {
+                "kind": {
+                    "$ref": "#/definitions/domain.NodeLinkKind"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "target_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.NodeChunkEdit": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "boolean"
+                },
+                "chunk_id": {
+                    "type": "string"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "doc_id": {
+                    "description": "rag document and chunk the edit was last applied to",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kb_id": {
+                    "type": "string"
+                },
+                "keywords": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "is_forbidden": {
-                    "description": "禁止访问",
-                    "type": "boolean"
+                "node_id": {
+                    "type": "string"
                 },
-                "ports": {
+                "questions": {
                     "type": "array",
                     "items": {
-                        "type": "integer"
+                        "type": "string"
                     }
                 },
-                "private_key": {
-                    "type": "string"
+                "status": {
+                    "$ref": "#/definitions/domain.NodeChunkEditStatus"
                 },
-                "public_key": {
+                "type": {
+                    "$ref": "#/definitions/domain.NodeChunkEditType"
+                },
+                "updated_at": {
                     "type": "string"
+                }
+            }
+        },
+        "domain.NodeChunkEditStatus": {
+            "type": "string",
+            "enum": [
+                "applied",
+                "stale"
+            ],
+            "x-enum-varnames": [
+                "NodeChunkEditStatusApplied",
+                "NodeChunkEditStatusStale"
+            ]
+        },
+        "domain.NodeChunkEditType": {
+            "type": "string",
+            "enum": [
+                "update",
+                "add"
+            ],
+            "x-enum-varnames": [
+                "NodeChunkEditTypeUpdate",
+                "NodeChunkEditTypeAdd"
+            ]
+        },
+        "domain.NodeChunkItem": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "boolean"
                 },
-                "simple_auth": {
-                    "$ref": "#/definitions/domain.SimpleAuth"
+                "content": {
+                    "type": "string"
                 },
-                "source_type": {
-                    "description": "企业认证来源",
+                "edit": {
+                    "description": "edit applied to the chunk, nil for untouched chunks",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/consts.SourceType"
+                            "$ref": "#/definitions/domain.NodeChunkEdit"
                         }
                     ]
                 },
-                "ssl_ports": {
+                "id": {
+                    "type": "string"
+                },
+                "keywords": {
                     "type": "array",
                     "items": {
-                        "type": "integer"
+                        "type": "string"
                     }
                 },
-                "trusted_proxies": {
+                "questions": {
                     "type": "array",
                     "items": {
                         "type": "string"
@@ -4492,1509 +12951,1359 @@ const docTemplate = `{
                 }
             }
         },
-        "domain.AnydocUploadResp": {
+        "domain.NodeChunkListResp": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "integer"
+                "chunks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.NodeChunkItem"
+                    }
                 },
-                "data": {
+                "doc_id": {
                     "type": "string"
                 },
-                "err": {
+                "node_release_id": {
                     "type": "string"
+                },
+                "stale_edits": {
+                    "description": "edits whose section changed after re-publishing",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.NodeChunkEdit"
+                    }
                 }
             }
         },
-        "domain.AppDetailResp": {
+        "domain.NodeContentChunkSSE": {
             "type": "object",
             "properties": {
-                "id": {
+                "emoji": {
                     "type": "string"
                 },
-                "kb_id": {
+                "name": {
                     "type": "string"
                 },
-                "name": {
+                "node_id": {
                     "type": "string"
                 },
-                "recommend_nodes": {
+                "node_path_names": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/domain.RecommendNodeListResp"
+                        "type": "string"
                     }
                 },
-                "settings": {
-                    "$ref": "#/definitions/domain.AppSettingsResp"
+                "summary": {
+                    "type": "string"
                 },
-                "type": {
-                    "$ref": "#/definitions/domain.AppType"
+                "url": {
+                    "type": "string"
                 }
             }
         },
-        "domain.AppInfoResp": {
+        "domain.NodeDeleteCheckReq": {
             "type": "object",
+            "required": [
+                "ids",
+                "kb_id"
+            ],
             "properties": {
-                "name": {
-                    "type": "string"
-                },
-                "recommend_nodes": {
+                "ids": {
                     "type": "array",
+                    "minItems": 1,
                     "items": {
-                        "$ref": "#/definitions/domain.RecommendNodeListResp"
+                        "type": "string"
                     }
                 },
-                "settings": {
-                    "$ref": "#/definitions/domain.AppSettingsResp"
+                "kb_id": {
+                    "type": "string"
                 }
             }
         },
-        "domain.AppSettings": {
+        "domain.NodeDeleteCheckResp": {
             "type": "object",
             "properties": {
-                "ai_feedback_settings": {
-                    "description": "AI feedback",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.AIFeedbackSettings"
-                        }
-                    ]
-                },
-                "body_code": {
-                    "type": "string"
-                },
-                "btns": {
+                "backlinks": {
                     "type": "array",
-                    "items": {}
-                },
-                "catalog_settings": {
-                    "description": "catalog settings",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.CatalogSettings"
-                        }
-                    ]
-                },
-                "contribute_settings": {
-                    "$ref": "#/definitions/domain.ContributeSettings"
-                },
-                "conversation_setting": {
-                    "$ref": "#/definitions/domain.ConversationSetting"
-                },
-                "copy_setting": {
-                    "enum": [
-                        "",
-                        "append",
-                        "disabled"
-                    ],
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/consts.CopySetting"
-                        }
-                    ]
+                    "items": {
+                        "$ref": "#/definitions/domain.NodeBacklink"
+                    }
+                }
+            }
+        },
+        "domain.NodeGroupDetail": {
+            "type": "object",
+            "properties": {
+                "auth_group_id": {
+                    "type": "integer"
                 },
-                "desc": {
-                    "description": "seo",
-                    "type": "string"
+                "auth_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 },
-                "dingtalk_bot_client_id": {
+                "kb_id": {
                     "type": "string"
                 },
-                "dingtalk_bot_client_secret": {
+                "name": {
                     "type": "string"
                 },
-                "dingtalk_bot_is_enabled": {
-                    "description": "DingTalkBot",
-                    "type": "boolean"
-                },
-                "dingtalk_bot_template_id": {
+                "node_id": {
                     "type": "string"
                 },
-                "disclaimer_settings": {
-                    "description": "Disclaimer Settings",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.DisclaimerSettings"
-                        }
-                    ]
-                },
-                "discord_bot_is_enabled": {
-                    "description": "DisCordBot",
-                    "type": "boolean"
+                "perm": {
+                    "$ref": "#/definitions/consts.NodePermName"
+                }
+            }
+        },
+        "domain.NodeImportJob": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
                 },
-                "discord_bot_token": {
+                "creator_id": {
+                    "description": "documents are created on behalf of the user who created the job",
                     "type": "string"
                 },
-                "document_feedback_is_enabled": {
-                    "description": "document feedback",
-                    "type": "boolean"
+                "done": {
+                    "type": "integer"
                 },
-                "feishu_bot_app_id": {
+                "error": {
                     "type": "string"
                 },
-                "feishu_bot_app_secret": {
+                "finished_at": {
                     "type": "string"
                 },
-                "feishu_bot_is_enabled": {
-                    "description": "FeishuBot",
-                    "type": "boolean"
-                },
-                "footer_settings": {
-                    "description": "footer settings",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.FooterSettings"
-                        }
-                    ]
-                },
-                "head_code": {
-                    "description": "inject code",
+                "heartbeat_at": {
+                    "description": "moved forward whenever a document is written, a stale heartbeat marks a lost job",
                     "type": "string"
                 },
-                "home_page_setting": {
-                    "$ref": "#/definitions/consts.HomePageSetting"
+                "id": {
+                    "type": "string"
                 },
-                "icon": {
+                "kb_id": {
                     "type": "string"
                 },
-                "keyword": {
+                "key": {
                     "type": "string"
                 },
-                "lark_bot_settings": {
-                    "description": "LarkBot",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.LarkBotSettings"
-                        }
-                    ]
+                "name": {
+                    "type": "string"
                 },
-                "mcp_server_settings": {
-                    "description": "MCP Server Settings",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.MCPServerSettings"
-                        }
-                    ]
+                "parent_id": {
+                    "type": "string"
                 },
-                "openai_api_bot_settings": {
-                    "description": "OpenAI API Bot settings",
+                "result": {
+                    "description": "result of each document, nil until the job is completed",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/domain.OpenAIAPIBotSettings"
+                            "$ref": "#/definitions/domain.UpsertNodesResp"
                         }
                     ]
                 },
-                "recommend_node_ids": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "source": {
+                    "$ref": "#/definitions/domain.NodeImportSource"
                 },
-                "recommend_questions": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "split_level": {
+                    "type": "integer"
                 },
-                "search_placeholder": {
+                "started_at": {
                     "type": "string"
                 },
-                "stats_setting": {
-                    "$ref": "#/definitions/domain.StatsSetting"
+                "status": {
+                    "$ref": "#/definitions/domain.NodeImportJobStatus"
                 },
-                "theme_and_style": {
-                    "$ref": "#/definitions/domain.ThemeAndStyle"
+                "total": {
+                    "description": "documents found in the file and the number of them written so far",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.NodeImportJobStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "running",
+                "completed",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "NodeImportJobStatusPending",
+                "NodeImportJobStatusRunning",
+                "NodeImportJobStatusCompleted",
+                "NodeImportJobStatusFailed"
+            ]
+        },
+        "domain.NodeImportReq": {
+            "type": "object",
+            "required": [
+                "kb_id",
+                "key",
+                "source"
+            ],
+            "properties": {
+                "kb_id": {
+                    "type": "string"
                 },
-                "theme_mode": {
-                    "description": "theme",
+                "key": {
+                    "description": "key of the file returned by /api/v1/file/upload",
                     "type": "string"
                 },
-                "title": {
-                    "description": "nav",
+                "name": {
+                    "description": "name of the imported document or folder, for single file sources",
                     "type": "string"
                 },
-                "watermark_content": {
+                "parent_id": {
+                    "description": "folder the imported tree is placed under, empty for the root of the kb",
                     "type": "string"
                 },
-                "watermark_setting": {
+                "source": {
                     "enum": [
-                        "",
-                        "hidden",
-                        "visible"
+                        "markdown",
+                        "docx",
+                        "openapi",
+                        "mediawiki"
                     ],
                     "allOf": [
                         {
-                            "$ref": "#/definitions/consts.WatermarkSetting"
-                        }
-                    ]
-                },
-                "web_app_comment_settings": {
-                    "description": "webapp comment settings",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.WebAppCommentSettings"
-                        }
-                    ]
-                },
-                "web_app_custom_style": {
-                    "description": "WebAppCustomStyle",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.WebAppCustomSettings"
+                            "$ref": "#/definitions/domain.NodeImportSource"
                         }
                     ]
                 },
-                "web_app_landing_configs": {
-                    "description": "WebAppLandingConfigs",
+                "split_level": {
+                    "description": "docx only: 0 imports one document, 1 splits it into a document per Heading 1, 2 also per Heading 2",
+                    "type": "integer",
+                    "maximum": 2,
+                    "minimum": 0
+                }
+            }
+        },
+        "domain.NodeImportSource": {
+            "type": "string",
+            "enum": [
+                "markdown",
+                "docx",
+                "openapi",
+                "mediawiki"
+            ],
+            "x-enum-varnames": [
+                "NodeImportSourceMarkdown",
+                "NodeImportSourceDocx",
+                "NodeImportSourceOpenAPI",
+                "NodeImportSourceMediaWiki"
+            ]
+        },
+        "domain.NodeLinkGraph": {
+            "type": "object",
+            "properties": {
+                "edges": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/domain.WebAppLandingConfig"
+                        "$ref": "#/definitions/domain.NodeLinkGraphEdge"
                     }
                 },
-                "web_app_landing_theme": {
-                    "$ref": "#/definitions/domain.WebAppLandingTheme"
-                },
-                "wechat_app_advanced_setting": {
-                    "$ref": "#/definitions/domain.WeChatAppAdvancedSetting"
-                },
-                "wechat_app_agent_id": {
-                    "type": "string"
-                },
-                "wechat_app_corpid": {
-                    "type": "string"
-                },
-                "wechat_app_encodingaeskey": {
-                    "type": "string"
-                },
-                "wechat_app_is_enabled": {
-                    "description": "WechatAppBot 企业微信机器人",
-                    "type": "boolean"
-                },
-                "wechat_app_secret": {
-                    "type": "string"
-                },
-                "wechat_app_token": {
-                    "type": "string"
-                },
-                "wechat_official_account_app_id": {
-                    "type": "string"
-                },
-                "wechat_official_account_app_secret": {
-                    "type": "string"
-                },
-                "wechat_official_account_encodingaeskey": {
-                    "type": "string"
-                },
-                "wechat_official_account_is_enabled": {
-                    "description": "WechatOfficialAccount",
-                    "type": "boolean"
-                },
-                "wechat_official_account_token": {
-                    "type": "string"
-                },
-                "wechat_service_contain_keywords": {
+                "nodes": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/domain.NodeLinkGraphNode"
                     }
+                }
+            }
+        },
+        "domain.NodeLinkGraphEdge": {
+            "type": "object",
+            "properties": {
+                "kind": {
+                    "$ref": "#/definitions/domain.NodeLinkKind"
                 },
-                "wechat_service_corpid": {
+                "source": {
                     "type": "string"
                 },
-                "wechat_service_encodingaeskey": {
+                "target": {
                     "type": "string"
+                }
+            }
+        },
+        "domain.NodeLinkGraphNode": {
+            "type": "object",
+            "properties": {
+                "backlinks": {
+                    "description": "number of documents linking to the node",
+                    "type": "integer"
                 },
-                "wechat_service_equal_keywords": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "wechat_service_is_enabled": {
-                    "description": "WechatServiceBot",
-                    "type": "boolean"
-                },
-                "wechat_service_secret": {
+                "emoji": {
                     "type": "string"
                 },
-                "wechat_service_token": {
+                "id": {
                     "type": "string"
                 },
-                "wecom_ai_bot_settings": {
-                    "description": "WecomAIBotSettings 企业微信智能机器人",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.WecomAIBotSettings"
-                        }
-                    ]
+                "name": {
+                    "type": "string"
                 },
-                "welcome_str": {
-                    "description": "welcome",
+                "parent_id": {
                     "type": "string"
+                }
+            }
+        },
+        "domain.NodeLinkKind": {
+            "type": "string",
+            "enum": [
+                "url",
+                "wiki"
+            ],
+            "x-enum-varnames": [
+                "NodeLinkKindURL",
+                "NodeLinkKindWiki"
+            ]
+        },
+        "domain.NodeLinksResp": {
+            "type": "object",
+            "properties": {
+                "backlinks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.NodeBacklink"
+                    }
                 },
-                "widget_bot_settings": {
-                    "description": "Widget bot settings",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.WidgetBotSettings"
-                        }
-                    ]
+                "outgoing": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.NodeOutgoingLink"
+                    }
                 }
             }
         },
-        "domain.AppSettingsResp": {
+        "domain.NodeListItemResp": {
             "type": "object",
             "properties": {
-                "ai_feedback_settings": {
-                    "description": "AI feedback",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.AIFeedbackSettings"
-                        }
-                    ]
+                "content_type": {
+                    "type": "string"
                 },
-                "body_code": {
+                "created_at": {
                     "type": "string"
                 },
-                "btns": {
-                    "type": "array",
-                    "items": {}
+                "creator": {
+                    "type": "string"
                 },
-                "catalog_settings": {
-                    "description": "catalog settings",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.CatalogSettings"
-                        }
-                    ]
+                "creator_id": {
+                    "type": "string"
                 },
-                "contribute_settings": {
-                    "$ref": "#/definitions/domain.ContributeSettings"
+                "editor": {
+                    "type": "string"
                 },
-                "conversation_setting": {
-                    "$ref": "#/definitions/domain.ConversationSetting"
+                "editor_id": {
+                    "type": "string"
                 },
-                "copy_setting": {
-                    "$ref": "#/definitions/consts.CopySetting"
+                "emoji": {
+                    "type": "string"
                 },
-                "desc": {
-                    "description": "seo",
+                "external_id": {
                     "type": "string"
                 },
-                "dingtalk_bot_client_id": {
+                "id": {
                     "type": "string"
                 },
-                "dingtalk_bot_client_secret": {
+                "name": {
                     "type": "string"
                 },
-                "dingtalk_bot_is_enabled": {
-                    "description": "DingTalkBot",
-                    "type": "boolean"
+                "parent_id": {
+                    "type": "string"
                 },
-                "dingtalk_bot_template_id": {
+                "permissions": {
+                    "$ref": "#/definitions/domain.NodePermissions"
+                },
+                "position": {
+                    "type": "number"
+                },
+                "publisher_id": {
                     "type": "string"
                 },
-                "disclaimer_settings": {
-                    "description": "Disclaimer Settings",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.DisclaimerSettings"
-                        }
-                    ]
+                "rag_info": {
+                    "$ref": "#/definitions/domain.RagInfo"
                 },
-                "discord_bot_is_enabled": {
-                    "description": "DisCordBot",
-                    "type": "boolean"
+                "status": {
+                    "$ref": "#/definitions/domain.NodeStatus"
                 },
-                "discord_bot_token": {
+                "summary": {
                     "type": "string"
                 },
-                "document_feedback_is_enabled": {
-                    "description": "document feedback",
-                    "type": "boolean"
+                "type": {
+                    "$ref": "#/definitions/domain.NodeType"
                 },
-                "feishu_bot_app_id": {
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.NodeMeta": {
+            "type": "object",
+            "properties": {
+                "content_type": {
                     "type": "string"
                 },
-                "feishu_bot_app_secret": {
+                "emoji": {
                     "type": "string"
                 },
-                "feishu_bot_is_enabled": {
-                    "description": "FeishuBot",
+                "summary": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.NodeOutgoingLink": {
+            "type": "object",
+            "properties": {
+                "broken": {
                     "type": "boolean"
                 },
-                "footer_settings": {
-                    "description": "footer settings",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.FooterSettings"
-                        }
-                    ]
+                "emoji": {
+                    "type": "string"
                 },
-                "head_code": {
-                    "description": "inject code",
+                "id": {
                     "type": "string"
                 },
-                "home_page_setting": {
-                    "$ref": "#/definitions/consts.HomePageSetting"
+                "kind": {
+                    "$ref": "#/definitions/domain.NodeLinkKind"
                 },
-                "icon": {
+                "name": {
                     "type": "string"
                 },
-                "keyword": {
+                "target": {
                     "type": "string"
-                },
-                "lark_bot_settings": {
-                    "description": "LarkBot",
+                }
+            }
+        },
+        "domain.NodePermissions": {
+            "type": "object",
+            "properties": {
+                "answerable": {
+                    "description": "可被问答",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/domain.LarkBotSettings"
+                            "$ref": "#/definitions/consts.NodeAccessPerm"
                         }
                     ]
                 },
-                "mcp_server_settings": {
-                    "description": "MCP Server Settings",
+                "visible": {
+                    "description": "导航内可见",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/domain.MCPServerSettings"
+                            "$ref": "#/definitions/consts.NodeAccessPerm"
                         }
                     ]
                 },
-                "openai_api_bot_settings": {
-                    "description": "OpenAI API settings",
+                "visitable": {
+                    "description": "可被访问",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/domain.OpenAIAPIBotSettings"
+                            "$ref": "#/definitions/consts.NodeAccessPerm"
                         }
                     ]
-                },
-                "recommend_node_ids": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "recommend_questions": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "search_placeholder": {
+                }
+            }
+        },
+        "domain.NodeSource": {
+            "type": "object",
+            "properties": {
+                "content_hash": {
+                    "description": "sha256 of the exported markdown",
                     "type": "string"
                 },
-                "stats_setting": {
-                    "$ref": "#/definitions/domain.StatsSetting"
+                "doc_id": {
+                    "description": "id of the item in the crawler, the url of the page for most sources",
+                    "type": "string"
                 },
-                "theme_and_style": {
-                    "$ref": "#/definitions/domain.ThemeAndStyle"
+                "etag": {
+                    "type": "string"
                 },
-                "theme_mode": {
-                    "description": "theme",
+                "kb_id": {
                     "type": "string"
                 },
-                "title": {
-                    "description": "nav",
+                "last_modified": {
                     "type": "string"
                 },
-                "watermark_content": {
+                "node_id": {
                     "type": "string"
                 },
-                "watermark_setting": {
-                    "$ref": "#/definitions/consts.WatermarkSetting"
+                "removed": {
+                    "description": "the item is not listed by the source any more",
+                    "type": "boolean"
                 },
-                "web_app_comment_settings": {
-                    "description": "webapp comment settings",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.WebAppCommentSettings"
-                        }
-                    ]
+                "source": {
+                    "$ref": "#/definitions/consts.CrawlerSource"
                 },
-                "web_app_custom_style": {
-                    "description": "WebAppCustomStyle",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.WebAppCustomSettings"
-                        }
-                    ]
+                "subscription_id": {
+                    "type": "string"
                 },
-                "web_app_landing_configs": {
-                    "description": "WebApp Landing Settings",
+                "synced_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.NodeStatus": {
+            "type": "integer",
+            "format": "int32",
+            "enum": [
+                1,
+                2
+            ],
+            "x-enum-varnames": [
+                "NodeStatusDraft",
+                "NodeStatusReleased"
+            ]
+        },
+        "domain.NodeSummaryReq": {
+            "type": "object",
+            "required": [
+                "ids",
+                "kb_id"
+            ],
+            "properties": {
+                "ids": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/domain.WebAppLandingConfigResp"
+                        "type": "string"
                     }
                 },
-                "web_app_landing_theme": {
-                    "$ref": "#/definitions/domain.WebAppLandingTheme"
-                },
-                "wechat_app_advanced_setting": {
-                    "$ref": "#/definitions/domain.WeChatAppAdvancedSetting"
-                },
-                "wechat_app_agent_id": {
+                "kb_id": {
                     "type": "string"
-                },
-                "wechat_app_corpid": {
+                }
+            }
+        },
+        "domain.NodeTranslation": {
+            "type": "object",
+            "properties": {
+                "created_at": {
                     "type": "string"
                 },
-                "wechat_app_encodingaeskey": {
+                "error": {
                     "type": "string"
                 },
-                "wechat_app_is_enabled": {
-                    "description": "WechatAppBot",
-                    "type": "boolean"
-                },
-                "wechat_app_secret": {
+                "id": {
                     "type": "string"
                 },
-                "wechat_app_token": {
+                "kb_id": {
                     "type": "string"
                 },
-                "wechat_official_account_app_id": {
+                "language": {
                     "type": "string"
                 },
-                "wechat_official_account_app_secret": {
+                "node_id": {
+                    "description": "variant node, empty until the first translation completes",
                     "type": "string"
                 },
-                "wechat_official_account_encodingaeskey": {
+                "source_node_id": {
                     "type": "string"
                 },
-                "wechat_official_account_is_enabled": {
-                    "description": "WechatOfficialAccount",
+                "stale": {
+                    "description": "the source changed after the variant was translated",
                     "type": "boolean"
                 },
-                "wechat_official_account_token": {
-                    "type": "string"
-                },
-                "wechat_service_contain_keywords": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "status": {
+                    "$ref": "#/definitions/domain.NodeTranslationStatus"
                 },
-                "wechat_service_corpid": {
+                "updated_at": {
                     "type": "string"
-                },
-                "wechat_service_encodingaeskey": {
+                }
+            }
+        },
+        "domain.NodeTranslationLink": {
+            "type": "object",
+            "properties": {
+                "language": {
                     "type": "string"
                 },
-                "wechat_service_equal_keywords": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "wechat_service_is_enabled": {
-                    "description": "WechatServiceBot",
-                    "type": "boolean"
-                },
-                "wechat_service_secret": {
+                "name": {
                     "type": "string"
                 },
-                "wechat_service_token": {
+                "node_id": {
                     "type": "string"
                 },
-                "wecom_ai_bot_settings": {
-                    "$ref": "#/definitions/domain.WecomAIBotSettings"
-                },
-                "welcome_str": {
-                    "description": "welcome",
+                "source": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "domain.NodeTranslationListResp": {
+            "type": "object",
+            "properties": {
+                "source_node_id": {
                     "type": "string"
                 },
-                "widget_bot_settings": {
-                    "description": "WidgetBot",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.WidgetBotSettings"
-                        }
-                    ]
+                "translations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.NodeTranslation"
+                    }
                 }
             }
         },
-        "domain.AppType": {
+        "domain.NodeTranslationStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "running",
+                "completed",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "NodeTranslationStatusPending",
+                "NodeTranslationStatusRunning",
+                "NodeTranslationStatusCompleted",
+                "NodeTranslationStatusFailed"
+            ]
+        },
+        "domain.NodeType": {
             "type": "integer",
             "format": "int32",
             "enum": [
                 1,
-                2,
-                3,
-                4,
-                5,
-                6,
-                7,
-                8,
-                9,
-                10,
-                11,
-                12
+                2
             ],
             "x-enum-varnames": [
-                "AppTypeWeb",
-                "AppTypeWidget",
-                "AppTypeDingTalkBot",
-                "AppTypeFeishuBot",
-                "AppTypeWechatBot",
-                "AppTypeWechatServiceBot",
-                "AppTypeDisCordBot",
-                "AppTypeWechatOfficialAccount",
-                "AppTypeOpenAIAPI",
-                "AppTypeWecomAIBot",
-                "AppTypeLarkBot",
-                "AppTypeMcpServer"
+                "NodeTypeFolder",
+                "NodeTypeDocument"
             ]
         },
-        "domain.AuthUserInfo": {
+        "domain.ObjectUploadResp": {
             "type": "object",
             "properties": {
-                "avatar_url": {
-                    "type": "string"
-                },
-                "email": {
+                "filename": {
                     "type": "string"
                 },
-                "username": {
+                "key": {
                     "type": "string"
                 }
             }
         },
-        "domain.BannerConfig": {
+        "domain.OpenAIAPIBotSettings": {
             "type": "object",
             "properties": {
-                "bg_url": {
-                    "type": "string"
-                },
-                "btns": {
-                    "type": "array",
-                    "items": {
-                        "type": "object",
-                        "properties": {
-                            "href": {
-                                "type": "string"
-                            },
-                            "id": {
-                                "type": "string"
-                            },
-                            "text": {
-                                "type": "string"
-                            },
-                            "type": {
-                                "type": "string"
-                            }
-                        }
-                    }
-                },
-                "hot_search": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "placeholder": {
-                    "type": "string"
-                },
-                "subtitle": {
-                    "type": "string"
-                },
-                "subtitle_color": {
-                    "type": "string"
-                },
-                "subtitle_font_size": {
-                    "type": "integer"
+                "follow_up_suggestions_enabled": {
+                    "description": "在响应的 suggestions 扩展字段中返回追问建议",
+                    "type": "boolean"
                 },
-                "title": {
-                    "type": "string"
+                "is_enabled": {
+                    "type": "boolean"
                 },
-                "title_color": {
+                "secret_key": {
                     "type": "string"
-                },
-                "title_font_size": {
-                    "type": "integer"
                 }
             }
         },
-        "domain.BasicDocConfig": {
+        "domain.OpenAIChoice": {
             "type": "object",
             "properties": {
-                "bg_color": {
-                    "type": "string"
+                "delta": {
+                    "description": "for streaming",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.OpenAIMessage"
+                        }
+                    ]
                 },
-                "title": {
+                "finish_reason": {
                     "type": "string"
                 },
-                "title_color": {
-                    "type": "string"
+                "index": {
+                    "type": "integer"
+                },
+                "message": {
+                    "$ref": "#/definitions/domain.OpenAIMessage"
                 }
             }
         },
-        "domain.BatchMoveReq": {
+        "domain.OpenAICompletionsRequest": {
             "type": "object",
             "required": [
-                "ids",
-                "kb_id"
+                "messages",
+                "model"
             ],
             "properties": {
-                "ids": {
+                "frequency_penalty": {
+                    "type": "number"
+                },
+                "max_tokens": {
+                    "type": "integer"
+                },
+                "messages": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/domain.OpenAIMessage"
                     }
                 },
-                "kb_id": {
+                "model": {
                     "type": "string"
                 },
-                "parent_id": {
-                    "type": "string"
-                }
-            }
-        },
-        "domain.BlockGridConfig": {
-            "type": "object",
-            "properties": {
-                "list": {
+                "presence_penalty": {
+                    "type": "number"
+                },
+                "response_format": {
+                    "$ref": "#/definitions/domain.OpenAIResponseFormat"
+                },
+                "stop": {
                     "type": "array",
                     "items": {
-                        "type": "object",
-                        "properties": {
-                            "id": {
-                                "type": "string"
-                            },
-                            "name": {
-                                "type": "string"
-                            },
-                            "url": {
-                                "type": "string"
-                            }
-                        }
+                        "type": "string"
                     }
                 },
-                "title": {
-                    "type": "string"
+                "stream": {
+                    "type": "boolean"
+                },
+                "stream_options": {
+                    "$ref": "#/definitions/domain.OpenAIStreamOptions"
+                },
+                "temperature": {
+                    "type": "number"
+                },
+                "tool_choice": {
+                    "$ref": "#/definitions/domain.OpenAIToolChoice"
+                },
+                "tools": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.OpenAITool"
+                    }
+                },
+                "top_p": {
+                    "type": "number"
                 },
-                "type": {
+                "user": {
                     "type": "string"
                 }
             }
         },
-        "domain.BrandGroup": {
+        "domain.OpenAICompletionsResponse": {
             "type": "object",
             "properties": {
-                "links": {
+                "choices": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/domain.Link"
+                        "$ref": "#/definitions/domain.OpenAIChoice"
                     }
                 },
-                "name": {
+                "created": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "object": {
                     "type": "string"
+                },
+                "sources": {
+                    "description": "PandaWiki extension: documents retrieved to answer the question",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.OpenAISource"
+                    }
+                },
+                "suggestions": {
+                    "description": "PandaWiki extension: follow-up questions, only when enabled in conversation settings",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "usage": {
+                    "$ref": "#/definitions/domain.OpenAIUsage"
                 }
             }
         },
-        "domain.BrowserCount": {
+        "domain.OpenAIEmbedding": {
             "type": "object",
             "properties": {
-                "count": {
+                "embedding": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "index": {
                     "type": "integer"
                 },
-                "name": {
+                "object": {
                     "type": "string"
                 }
             }
         },
-        "domain.CarouselConfig": {
+        "domain.OpenAIEmbeddingsRequest": {
             "type": "object",
             "properties": {
-                "bg_color": {
+                "encoding_format": {
                     "type": "string"
                 },
-                "list": {
+                "input": {
                     "type": "array",
                     "items": {
-                        "type": "object",
-                        "properties": {
-                            "desc": {
-                                "type": "string"
-                            },
-                            "id": {
-                                "type": "string"
-                            },
-                            "title": {
-                                "type": "string"
-                            },
-                            "url": {
-                                "type": "string"
-                            }
-                        }
+                        "type": "string"
                     }
                 },
-                "title": {
+                "model": {
                     "type": "string"
                 }
             }
         },
-        "domain.CaseConfig": {
+        "domain.OpenAIEmbeddingsResponse": {
             "type": "object",
             "properties": {
-                "list": {
+                "data": {
                     "type": "array",
                     "items": {
-                        "type": "object",
-                        "properties": {
-                            "id": {
-                                "type": "string"
-                            },
-                            "link": {
-                                "type": "string"
-                            },
-                            "name": {
-                                "type": "string"
-                            }
-                        }
+                        "$ref": "#/definitions/domain.OpenAIEmbedding"
                     }
                 },
-                "title": {
+                "model": {
                     "type": "string"
                 },
-                "type": {
+                "object": {
                     "type": "string"
-                }
-            }
-        },
-        "domain.CatalogSettings": {
-            "type": "object",
-            "properties": {
-                "catalog_folder": {
-                    "description": "1: 展开, 2: 折叠, default: 1",
-                    "type": "integer"
-                },
-                "catalog_visible": {
-                    "description": "1: 显示, 2: 隐藏, default: 1",
-                    "type": "integer"
                 },
-                "catalog_width": {
-                    "description": "200 - 300, default: 260",
-                    "type": "integer"
+                "usage": {
+                    "$ref": "#/definitions/domain.OpenAIUsage"
                 }
             }
         },
-        "domain.ChatRequest": {
+        "domain.OpenAIError": {
             "type": "object",
-            "required": [
-                "app_type",
-                "message"
-            ],
             "properties": {
-                "app_type": {
-                    "enum": [
-                        1,
-                        2
-                    ],
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.AppType"
-                        }
-                    ]
-                },
-                "captcha_token": {
-                    "type": "string"
-                },
-                "conversation_id": {
+                "code": {
                     "type": "string"
                 },
                 "message": {
                     "type": "string"
                 },
-                "nonce": {
-                    "type": "string"
-                }
-            }
-        },
-        "domain.ChatSearchReq": {
-            "type": "object",
-            "required": [
-                "message"
-            ],
-            "properties": {
-                "captcha_token": {
+                "param": {
                     "type": "string"
                 },
-                "message": {
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "domain.ChatSearchResp": {
+        "domain.OpenAIErrorResponse": {
             "type": "object",
             "properties": {
-                "node_result": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/domain.NodeContentChunkSSE"
-                    }
+                "error": {
+                    "$ref": "#/definitions/domain.OpenAIError"
                 }
             }
         },
-        "domain.CommentConfig": {
+        "domain.OpenAIFunction": {
             "type": "object",
+            "required": [
+                "name"
+            ],
             "properties": {
-                "list": {
-                    "type": "array",
-                    "items": {
-                        "type": "object",
-                        "properties": {
-                            "avatar": {
-                                "type": "string"
-                            },
-                            "comment": {
-                                "type": "string"
-                            },
-                            "id": {
-                                "type": "string"
-                            },
-                            "profession": {
-                                "type": "string"
-                            },
-                            "user_name": {
-                                "type": "string"
-                            }
-                        }
-                    }
-                },
-                "title": {
+                "description": {
                     "type": "string"
                 },
-                "type": {
+                "name": {
                     "type": "string"
+                },
+                "parameters": {
+                    "type": "object",
+                    "additionalProperties": true
                 }
             }
         },
-        "domain.CommentInfo": {
+        "domain.OpenAIFunctionCall": {
             "type": "object",
+            "required": [
+                "arguments",
+                "name"
+            ],
             "properties": {
-                "auth_user_id": {
-                    "type": "integer"
-                },
-                "avatar": {
-                    "description": "avatar",
-                    "type": "string"
-                },
-                "email": {
-                    "type": "string"
-                },
-                "remote_ip": {
+                "arguments": {
                     "type": "string"
                 },
-                "user_name": {
+                "name": {
                     "type": "string"
                 }
             }
         },
-        "domain.CommentListItem": {
+        "domain.OpenAIFunctionChoice": {
             "type": "object",
+            "required": [
+                "name"
+            ],
             "properties": {
-                "content": {
-                    "type": "string"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "info": {
-                    "$ref": "#/definitions/domain.CommentInfo"
-                },
-                "ip_address": {
-                    "description": "ip地址",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.IPAddress"
-                        }
-                    ]
-                },
-                "node_id": {
-                    "type": "string"
-                },
-                "node_name": {
-                    "description": "文档标题",
-                    "type": "string"
-                },
-                "node_type": {
-                    "type": "integer"
-                },
-                "root_id": {
+                "name": {
                     "type": "string"
-                },
-                "status": {
-                    "description": "status : -1 reject 0 pending 1 accept",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.CommentStatus"
-                        }
-                    ]
                 }
             }
         },
-        "domain.CommentReq": {
+        "domain.OpenAIMessage": {
             "type": "object",
             "required": [
-                "content",
-                "node_id",
-                "pic_urls"
+                "role"
             ],
             "properties": {
-                "captcha_token": {
-                    "type": "string"
+                "content": {
+                    "$ref": "#/definitions/domain.MessageContent"
                 },
-                "content": {
+                "name": {
                     "type": "string"
                 },
-                "node_id": {
+                "role": {
                     "type": "string"
                 },
-                "parent_id": {
+                "tool_call_id": {
                     "type": "string"
                 },
-                "pic_urls": {
+                "tool_calls": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/domain.OpenAIToolCall"
                     }
+                }
+            }
+        },
+        "domain.OpenAIModel": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
                 },
-                "root_id": {
+                "id": {
                     "type": "string"
                 },
-                "user_name": {
+                "object": {
+                    "type": "string"
+                },
+                "owned_by": {
                     "type": "string"
                 }
             }
         },
-        "domain.CommentStatus": {
-            "type": "integer",
-            "format": "int32",
-            "enum": [
-                -1,
-                0,
-                1
-            ],
-            "x-enum-varnames": [
-                "CommentStatusReject",
-                "CommentStatusPending",
-                "CommentStatusAccepted"
-            ]
-        },
-        "domain.CompleteReq": {
+        "domain.OpenAIModelList": {
             "type": "object",
             "properties": {
-                "prefix": {
-                    "description": "For FIM (Fill in Middle) style completion",
-                    "type": "string"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.OpenAIModel"
+                    }
                 },
-                "suffix": {
+                "object": {
                     "type": "string"
                 }
             }
         },
-        "domain.ContributeSettings": {
+        "domain.OpenAIResponseFormat": {
             "type": "object",
+            "required": [
+                "type"
+            ],
             "properties": {
-                "is_enable": {
-                    "type": "boolean"
+                "type": {
+                    "type": "string"
                 }
             }
         },
-        "domain.ConversationDetailResp": {
+        "domain.OpenAISource": {
             "type": "object",
             "properties": {
-                "app_id": {
-                    "type": "string"
-                },
-                "created_at": {
+                "name": {
                     "type": "string"
                 },
-                "id": {
+                "node_id": {
                     "type": "string"
                 },
-                "ip_address": {
-                    "$ref": "#/definitions/domain.IPAddress"
-                },
-                "messages": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/domain.ConversationMessage"
-                    }
-                },
-                "references": {
+                "path_names": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/domain.ConversationReference"
+                        "type": "string"
                     }
                 },
-                "remote_ip": {
+                "summary": {
                     "type": "string"
                 },
-                "subject": {
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "domain.ConversationDistribution": {
+        "domain.OpenAIStreamOptions": {
             "type": "object",
             "properties": {
-                "app_id": {
-                    "type": "string"
-                },
-                "app_type": {
-                    "$ref": "#/definitions/domain.AppType"
-                },
-                "count": {
-                    "type": "integer"
+                "include_usage": {
+                    "type": "boolean"
                 }
             }
         },
-        "domain.ConversationInfo": {
+        "domain.OpenAITool": {
             "type": "object",
+            "required": [
+                "type"
+            ],
             "properties": {
-                "user_info": {
-                    "$ref": "#/definitions/domain.UserInfo"
+                "function": {
+                    "$ref": "#/definitions/domain.OpenAIFunction"
+                },
+                "type": {
+                    "type": "string"
                 }
             }
         },
-        "domain.ConversationListItem": {
+        "domain.OpenAIToolCall": {
             "type": "object",
+            "required": [
+                "function",
+                "id",
+                "type"
+            ],
             "properties": {
-                "app_name": {
-                    "type": "string"
-                },
-                "app_type": {
-                    "$ref": "#/definitions/domain.AppType"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "feedback_info": {
-                    "description": "用户反馈信息",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.FeedBackInfo"
-                        }
-                    ]
+                "function": {
+                    "$ref": "#/definitions/domain.OpenAIFunctionCall"
                 },
                 "id": {
                     "type": "string"
                 },
-                "info": {
-                    "description": "用户信息",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.ConversationInfo"
-                        }
-                    ]
-                },
-                "ip_address": {
-                    "$ref": "#/definitions/domain.IPAddress"
-                },
-                "remote_ip": {
+                "type": {
                     "type": "string"
+                }
+            }
+        },
+        "domain.OpenAIToolChoice": {
+            "type": "object",
+            "properties": {
+                "function": {
+                    "$ref": "#/definitions/domain.OpenAIFunctionChoice"
                 },
-                "subject": {
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "domain.ConversationMessage": {
+        "domain.OpenAIUsage": {
             "type": "object",
             "properties": {
-                "app_id": {
-                    "type": "string"
-                },
                 "completion_tokens": {
                     "type": "integer"
                 },
-                "content": {
-                    "type": "string"
-                },
-                "conversation_id": {
-                    "type": "string"
+                "prompt_tokens": {
+                    "type": "integer"
                 },
-                "created_at": {
-                    "type": "string"
+                "total_tokens": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.PWResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
                 },
-                "id": {
+                "data": {},
+                "message": {
                     "type": "string"
                 },
-                "info": {
-                    "description": "feedbackinfo",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.FeedBackInfo"
-                        }
-                    ]
-                },
-                "kb_id": {
-                    "type": "string"
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "domain.PaginatedResult-array_domain_ConversationMessageListItem": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ConversationMessageListItem"
+                    }
                 },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ProviderModelListItem": {
+            "type": "object",
+            "properties": {
                 "model": {
                     "type": "string"
-                },
-                "parent_id": {
-                    "description": "parent_id",
-                    "type": "string"
-                },
-                "prompt_tokens": {
-                    "type": "integer"
-                },
-                "provider": {
-                    "description": "model",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.ModelProvider"
+                }
+            }
+        },
+        "domain.QuestionConfig": {
+            "type": "object",
+            "properties": {
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "properties": {
+                            "id": {
+                                "type": "string"
+                            },
+                            "question": {
+                                "type": "string"
+                            }
                         }
-                    ]
+                    }
                 },
-                "remote_ip": {
-                    "description": "stats",
+                "title": {
                     "type": "string"
                 },
-                "role": {
-                    "$ref": "#/definitions/schema.RoleType"
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.RagInfo": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
                 },
-                "total_tokens": {
-                    "type": "integer"
+                "status": {
+                    "$ref": "#/definitions/consts.NodeRagInfoStatus"
                 }
             }
         },
-        "domain.ConversationMessageListItem": {
+        "domain.RecommendNodeListResp": {
             "type": "object",
             "properties": {
-                "app_id": {
+                "emoji": {
                     "type": "string"
                 },
-                "app_type": {
-                    "$ref": "#/definitions/domain.AppType"
-                },
-                "conversation_id": {
+                "id": {
                     "type": "string"
                 },
-                "conversation_info": {
-                    "description": "userInfo",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.ConversationInfo"
-                        }
-                    ]
-                },
-                "created_at": {
+                "name": {
                     "type": "string"
                 },
-                "id": {
+                "parent_id": {
                     "type": "string"
                 },
-                "info": {
-                    "description": "feedbackInfo",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.FeedBackInfo"
-                        }
-                    ]
+                "permissions": {
+                    "$ref": "#/definitions/domain.NodePermissions"
                 },
-                "ip_address": {
-                    "$ref": "#/definitions/domain.IPAddress"
+                "position": {
+                    "type": "number"
                 },
-                "question": {
-                    "type": "string"
+                "recommend_nodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.RecommendNodeListResp"
+                    }
                 },
-                "remote_ip": {
-                    "description": "stats",
+                "summary": {
                     "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/domain.NodeType"
                 }
             }
         },
-        "domain.ConversationReference": {
+        "domain.Response": {
             "type": "object",
             "properties": {
-                "app_id": {
-                    "type": "string"
-                },
-                "conversation_id": {
+                "data": {},
+                "message": {
                     "type": "string"
                 },
-                "name": {
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "domain.RetryImportJobReq": {
+            "type": "object",
+            "required": [
+                "id",
+                "kb_id"
+            ],
+            "properties": {
+                "id": {
                     "type": "string"
                 },
-                "node_id": {
-                    "type": "string"
+                "item_ids": {
+                    "description": "failed items to retry, empty for all failed items of the job",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "url": {
+                "kb_id": {
                     "type": "string"
                 }
             }
         },
-        "domain.ConversationSetting": {
+        "domain.RetrySummaryJobReq": {
             "type": "object",
+            "required": [
+                "id",
+                "kb_id"
+            ],
             "properties": {
-                "copyright_hide_enabled": {
-                    "type": "boolean"
+                "id": {
+                    "type": "string"
                 },
-                "copyright_info": {
+                "kb_id": {
                     "type": "string"
                 }
             }
         },
-        "domain.CreateKBReleaseReq": {
-            "type": "object",
-            "required": [
-                "kb_id",
-                "message",
-                "tag"
+        "domain.ScoreType": {
+            "type": "integer",
+            "enum": [
+                1,
+                -1
             ],
+            "x-enum-varnames": [
+                "Like",
+                "DisLike"
+            ]
+        },
+        "domain.ShareCommentListItem": {
+            "type": "object",
             "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "info": {
+                    "$ref": "#/definitions/domain.CommentInfo"
+                },
+                "ip_address": {
+                    "description": "ip地址",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.IPAddress"
+                        }
+                    ]
+                },
                 "kb_id": {
                     "type": "string"
                 },
-                "message": {
+                "node_id": {
                     "type": "string"
                 },
-                "node_ids": {
-                    "description": "create release after these nodes published",
+                "parent_id": {
+                    "type": "string"
+                },
+                "pic_urls": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "tag": {
+                "root_id": {
                     "type": "string"
                 }
             }
         },
-        "domain.CreateKnowledgeBaseReq": {
+        "domain.ShareConversationDetailResp": {
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
-                "hosts": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "created_at": {
+                    "type": "string"
                 },
-                "name": {
+                "id": {
                     "type": "string"
                 },
-                "ports": {
+                "messages": {
                     "type": "array",
                     "items": {
-                        "type": "integer"
+                        "$ref": "#/definitions/domain.ShareConversationMessage"
                     }
                 },
-                "private_key": {
-                    "type": "string"
-                },
-                "public_key": {
+                "subject": {
                     "type": "string"
-                },
-                "ssl_ports": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
                 }
             }
         },
-        "domain.CreateModelReq": {
+        "domain.ShareConversationMessage": {
             "type": "object",
-            "required": [
-                "base_url",
-                "model",
-                "provider",
-                "type"
-            ],
             "properties": {
-                "api_header": {
+                "content": {
                     "type": "string"
                 },
-                "api_key": {
+                "created_at": {
                     "type": "string"
                 },
-                "api_version": {
-                    "description": "for azure openai",
+                "id": {
                     "type": "string"
                 },
-                "base_url": {
-                    "type": "string"
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "model": {
+                "parent_id": {
                     "type": "string"
                 },
-                "parameters": {
-                    "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.ModelParam"
-                },
-                "provider": {
-                    "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.ModelProvider"
-                },
-                "type": {
-                    "enum": [
-                        "chat",
-                        "embedding",
-                        "rerank",
-                        "analysis",
-                        "analysis-vl"
-                    ],
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.ModelType"
-                        }
-                    ]
+                "role": {
+                    "$ref": "#/definitions/schema.RoleType"
                 }
             }
         },
-        "domain.CreateNodeReq": {
+        "domain.ShareNodeDetailItem": {
             "type": "object",
-            "required": [
-                "kb_id",
-                "name",
-                "type"
-            ],
             "properties": {
-                "content": {
-                    "type": "string"
-                },
-                "content_type": {
-                    "type": "string"
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ShareNodeDetailItem"
+                    }
                 },
                 "emoji": {
                     "type": "string"
                 },
-                "kb_id": {
+                "id": {
                     "type": "string"
                 },
+                "meta": {
+                    "$ref": "#/definitions/domain.NodeMeta"
+                },
                 "name": {
                     "type": "string"
                 },
                 "parent_id": {
                     "type": "string"
                 },
+                "permissions": {
+                    "$ref": "#/definitions/domain.NodePermissions"
+                },
                 "position": {
                     "type": "number"
                 },
-                "summary": {
-                    "type": "string"
-                },
                 "type": {
-                    "enum": [
-                        1,
-                        2
-                    ],
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.NodeType"
-                        }
-                    ]
+                    "$ref": "#/definitions/domain.NodeType"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.SimpleAuth": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "password": {
+                    "type": "string"
                 }
             }
         },
-        "domain.DirDocConfig": {
+        "domain.SimpleDocConfig": {
             "type": "object",
             "properties": {
                 "bg_color": {
@@ -6008,279 +14317,289 @@ const docTemplate = `{
                 }
             }
         },
-        "domain.DisclaimerSettings": {
+        "domain.SocialMediaAccount": {
             "type": "object",
             "properties": {
-                "content": {
+                "channel": {
+                    "type": "string"
+                },
+                "icon": {
+                    "type": "string"
+                },
+                "link": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "text": {
                     "type": "string"
                 }
             }
         },
-        "domain.EnterpriseAuth": {
+        "domain.StatPageReq": {
             "type": "object",
+            "required": [
+                "scene"
+            ],
             "properties": {
-                "enabled": {
+                "node_id": {
+                    "type": "string"
+                },
+                "scene": {
+                    "enum": [
+                        1,
+                        2,
+                        3,
+                        4
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.StatPageScene"
+                        }
+                    ]
+                }
+            }
+        },
+        "domain.StatPageScene": {
+            "type": "integer",
+            "enum": [
+                1,
+                2,
+                3,
+                4
+            ],
+            "x-enum-varnames": [
+                "StatPageSceneWelcome",
+                "StatPageSceneNodeDetail",
+                "StatPageSceneChat",
+                "StatPageSceneLogin"
+            ]
+        },
+        "domain.StatsSetting": {
+            "type": "object",
+            "properties": {
+                "pv_enable": {
                     "type": "boolean"
                 }
             }
         },
-        "domain.FaqConfig": {
+        "domain.SummaryJob": {
             "type": "object",
             "properties": {
-                "bg_color": {
+                "concurrency": {
+                    "type": "integer"
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "list": {
-                    "type": "array",
-                    "items": {
-                        "type": "object",
-                        "properties": {
-                            "id": {
-                                "type": "string"
-                            },
-                            "link": {
-                                "type": "string"
-                            },
-                            "question": {
-                                "type": "string"
-                            }
-                        }
-                    }
+                "error": {
+                    "type": "string"
                 },
-                "title": {
+                "finished_at": {
                     "type": "string"
                 },
-                "title_color": {
+                "heartbeat_at": {
+                    "description": "moved forward whenever an item changes state, a stale heartbeat marks a lost job",
                     "type": "string"
-                }
-            }
-        },
-        "domain.FeatureConfig": {
-            "type": "object",
-            "properties": {
-                "list": {
-                    "type": "array",
-                    "items": {
-                        "type": "object",
-                        "properties": {
-                            "desc": {
-                                "type": "string"
-                            },
-                            "id": {
-                                "type": "string"
-                            },
-                            "name": {
-                                "type": "string"
-                            }
-                        }
-                    }
                 },
-                "title": {
+                "id": {
                     "type": "string"
                 },
-                "type": {
+                "kb_id": {
                     "type": "string"
+                },
+                "progress": {
+                    "$ref": "#/definitions/domain.SummaryJobProgress"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.SummaryJobStatus"
                 }
             }
         },
-        "domain.FeedBackInfo": {
+        "domain.SummaryJobDetailResp": {
             "type": "object",
             "properties": {
-                "feedback_content": {
+                "concurrency": {
+                    "type": "integer"
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "feedback_type": {
+                "error": {
                     "type": "string"
                 },
-                "score": {
-                    "$ref": "#/definitions/domain.ScoreType"
-                }
-            }
-        },
-        "domain.FeedbackRequest": {
-            "type": "object",
-            "required": [
-                "message_id"
-            ],
-            "properties": {
-                "conversation_id": {
+                "finished_at": {
                     "type": "string"
                 },
-                "feedback_content": {
-                    "description": "限制内容长度",
-                    "type": "string",
-                    "maxLength": 200
+                "heartbeat_at": {
+                    "description": "moved forward whenever an item changes state, a stale heartbeat marks a lost job",
+                    "type": "string"
                 },
-                "message_id": {
+                "id": {
                     "type": "string"
                 },
-                "score": {
-                    "description": "-1 踩 ,0 1 赞成",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.ScoreType"
-                        }
-                    ]
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.SummaryJobItem"
+                    }
                 },
-                "type": {
-                    "description": "内容不准确，没有帮助，.......",
+                "kb_id": {
                     "type": "string"
+                },
+                "progress": {
+                    "$ref": "#/definitions/domain.SummaryJobProgress"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.SummaryJobStatus"
                 }
             }
         },
-        "domain.FooterSettings": {
+        "domain.SummaryJobItem": {
             "type": "object",
             "properties": {
-                "brand_desc": {
+                "error": {
                     "type": "string"
                 },
-                "brand_groups": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/domain.BrandGroup"
-                    }
-                },
-                "brand_logo": {
+                "id": {
                     "type": "string"
                 },
-                "brand_name": {
+                "job_id": {
                     "type": "string"
                 },
-                "corp_name": {
+                "node_id": {
                     "type": "string"
                 },
-                "footer_style": {
+                "node_name": {
                     "type": "string"
                 },
-                "icp": {
+                "status": {
+                    "$ref": "#/definitions/domain.SummaryJobStatus"
+                },
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "domain.GetKBReleaseListResp": {
+        "domain.SummaryJobProgress": {
             "type": "object",
             "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/domain.KBReleaseListItemResp"
-                    }
+                "completed": {
+                    "type": "integer"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "pending": {
+                    "type": "integer"
+                },
+                "running": {
+                    "type": "integer"
                 },
                 "total": {
                     "type": "integer"
                 }
             }
         },
-        "domain.GetProviderModelListReq": {
+        "domain.SummaryJobStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "running",
+                "completed",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "SummaryJobStatusPending",
+                "SummaryJobStatusRunning",
+                "SummaryJobStatusCompleted",
+                "SummaryJobStatusFailed"
+            ]
+        },
+        "domain.SwitchModeReq": {
             "type": "object",
             "required": [
-                "base_url",
-                "provider",
-                "type"
+                "mode"
             ],
             "properties": {
-                "api_header": {
-                    "type": "string"
-                },
-                "api_key": {
-                    "type": "string"
-                },
-                "base_url": {
+                "auto_mode_api_key": {
+                    "description": "百智云 API Key",
                     "type": "string"
                 },
-                "provider": {
+                "chat_model": {
+                    "description": "自定义对话模型名称",
                     "type": "string"
                 },
-                "type": {
+                "mode": {
+                    "type": "string",
                     "enum": [
-                        "chat",
-                        "embedding",
-                        "rerank",
-                        "analysis",
-                        "analysis-vl"
-                    ],
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.ModelType"
-                        }
+                        "manual",
+                        "auto"
                     ]
                 }
             }
         },
-        "domain.GetProviderModelListResp": {
-            "type": "object",
-            "properties": {
-                "models": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/domain.ProviderModelListItem"
-                    }
-                }
-            }
-        },
-        "domain.HotBrowser": {
+        "domain.SwitchModeResp": {
             "type": "object",
             "properties": {
-                "browser": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/domain.BrowserCount"
-                    }
-                },
-                "os": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/domain.BrowserCount"
-                    }
+                "message": {
+                    "type": "string"
                 }
             }
         },
-        "domain.HotPage": {
+        "domain.SyncCrawlerSubscriptionReq": {
             "type": "object",
+            "required": [
+                "id",
+                "kb_id"
+            ],
             "properties": {
-                "count": {
-                    "type": "integer"
-                },
-                "node_id": {
+                "id": {
                     "type": "string"
                 },
-                "node_name": {
+                "kb_id": {
                     "type": "string"
-                },
-                "scene": {
-                    "$ref": "#/definitions/domain.StatPageScene"
                 }
             }
         },
-        "domain.HotRefererHost": {
+        "domain.SyncGitSourceReq": {
             "type": "object",
+            "required": [
+                "id",
+                "kb_id"
+            ],
             "properties": {
-                "count": {
-                    "type": "integer"
+                "full": {
+                    "description": "compare the whole tree instead of the diff since the last synced commit",
+                    "type": "boolean"
                 },
-                "referer_host": {
+                "id": {
+                    "type": "string"
+                },
+                "kb_id": {
                     "type": "string"
                 }
             }
         },
-        "domain.IPAddress": {
+        "domain.TextConfig": {
             "type": "object",
             "properties": {
-                "city": {
-                    "type": "string"
-                },
-                "country": {
-                    "type": "string"
-                },
-                "ip": {
+                "title": {
                     "type": "string"
                 },
-                "province": {
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "domain.ImgTextConfig": {
+        "domain.TextImgConfig": {
             "type": "object",
             "properties": {
                 "item": {
@@ -6305,343 +14624,392 @@ const docTemplate = `{
                 }
             }
         },
-        "domain.InstantCountResp": {
+        "domain.TextReq": {
             "type": "object",
+            "required": [
+                "kb_id",
+                "text"
+            ],
             "properties": {
-                "count": {
-                    "type": "integer"
+                "action": {
+                    "description": "action: improve, summary, extend, shorten, etc.",
+                    "type": "string"
                 },
-                "time": {
+                "kb_id": {
+                    "type": "string"
+                },
+                "text": {
                     "type": "string"
                 }
             }
         },
-        "domain.InstantPageResp": {
+        "domain.ThemeAndStyle": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "info": {
-                    "$ref": "#/definitions/domain.AuthUserInfo"
-                },
-                "ip": {
-                    "type": "string"
-                },
-                "ip_address": {
-                    "$ref": "#/definitions/domain.IPAddress"
-                },
-                "node_id": {
+                "bg_image": {
                     "type": "string"
                 },
-                "node_name": {
+                "doc_width": {
                     "type": "string"
-                },
-                "scene": {
-                    "$ref": "#/definitions/domain.StatPageScene"
-                },
-                "user_id": {
-                    "type": "integer"
                 }
             }
         },
-        "domain.KBReleaseListItemResp": {
+        "domain.TranslateNodeReq": {
             "type": "object",
+            "required": [
+                "id",
+                "kb_id",
+                "language"
+            ],
             "properties": {
-                "created_at": {
-                    "type": "string"
-                },
                 "id": {
                     "type": "string"
                 },
                 "kb_id": {
                     "type": "string"
                 },
-                "message": {
+                "language": {
                     "type": "string"
                 },
-                "tag": {
-                    "type": "string"
+                "recursive": {
+                    "description": "also translate the descendants of the node",
+                    "type": "boolean"
                 }
             }
         },
-        "domain.KnowledgeBaseDetail": {
+        "domain.UpdateAppReq": {
             "type": "object",
             "properties": {
-                "access_settings": {
-                    "$ref": "#/definitions/domain.AccessSettings"
-                },
-                "created_at": {
+                "kb_id": {
                     "type": "string"
                 },
-                "dataset_id": {
+                "name": {
                     "type": "string"
                 },
-                "id": {
+                "settings": {
+                    "$ref": "#/definitions/domain.AppSettings"
+                }
+            }
+        },
+        "domain.UpdateChunkSettingReq": {
+            "type": "object",
+            "required": [
+                "kb_id"
+            ],
+            "properties": {
+                "kb_id": {
                     "type": "string"
                 },
-                "name": {
-                    "type": "string"
+                "overlap": {
+                    "type": "integer"
                 },
-                "perm": {
-                    "description": "用户对知识库的权限",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/consts.UserKBPermission"
-                        }
-                    ]
+                "size": {
+                    "type": "integer"
                 },
-                "updated_at": {
-                    "type": "string"
+                "strategy": {
+                    "$ref": "#/definitions/domain.ChunkStrategy"
                 }
             }
         },
-        "domain.KnowledgeBaseListItem": {
+        "domain.UpdateCrawlerSubscriptionReq": {
             "type": "object",
+            "required": [
+                "id",
+                "kb_id"
+            ],
             "properties": {
-                "access_settings": {
-                    "$ref": "#/definitions/domain.AccessSettings"
-                },
-                "created_at": {
-                    "type": "string"
+                "delete_removed": {
+                    "type": "boolean"
                 },
-                "dataset_id": {
-                    "type": "string"
+                "enabled": {
+                    "type": "boolean"
                 },
                 "id": {
                     "type": "string"
                 },
-                "name": {
-                    "type": "string"
+                "interval_hours": {
+                    "type": "integer",
+                    "maximum": 720,
+                    "minimum": 0
                 },
-                "updated_at": {
+                "kb_id": {
                     "type": "string"
+                },
+                "policy": {
+                    "enum": [
+                        "draft",
+                        "publish"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.CrawlerSyncPolicy"
+                        }
+                    ]
                 }
             }
         },
-        "domain.LarkBotSettings": {
+        "domain.UpdateCuratedAnswerReq": {
             "type": "object",
+            "required": [
+                "id",
+                "kb_id"
+            ],
             "properties": {
-                "app_id": {
+                "answer": {
                     "type": "string"
                 },
-                "app_secret": {
+                "id": {
                     "type": "string"
                 },
-                "encrypt_key": {
+                "kb_id": {
                     "type": "string"
                 },
-                "is_enabled": {
-                    "type": "boolean"
-                },
-                "verify_token": {
+                "question": {
                     "type": "string"
                 }
             }
         },
-        "domain.Link": {
+        "domain.UpdateEvalQuestionReq": {
             "type": "object",
+            "required": [
+                "id",
+                "kb_id"
+            ],
             "properties": {
-                "name": {
-                    "type": "string"
+                "expected_node_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "url": {
+                "id": {
                     "type": "string"
-                }
-            }
-        },
-        "domain.MCPServerSettings": {
-            "type": "object",
-            "properties": {
-                "docs_tool_settings": {
-                    "$ref": "#/definitions/domain.MCPToolSettings"
                 },
-                "is_enabled": {
-                    "type": "boolean"
+                "kb_id": {
+                    "type": "string"
                 },
-                "sample_auth": {
-                    "$ref": "#/definitions/domain.SimpleAuth"
-                }
-            }
-        },
-        "domain.MCPToolSettings": {
-            "type": "object",
-            "properties": {
-                "desc": {
+                "question": {
                     "type": "string"
                 },
-                "name": {
+                "reference_answer": {
                     "type": "string"
                 }
             }
         },
-        "domain.MessageContent": {
-            "type": "object"
-        },
-        "domain.MessageFrom": {
-            "type": "integer",
-            "enum": [
-                1,
-                2
-            ],
-            "x-enum-varnames": [
-                "MessageFromGroup",
-                "MessageFromPrivate"
-            ]
-        },
-        "domain.MetricsConfig": {
+        "domain.UpdateEvalSetReq": {
             "type": "object",
+            "required": [
+                "id",
+                "kb_id"
+            ],
             "properties": {
-                "list": {
-                    "type": "array",
-                    "items": {
-                        "type": "object",
-                        "properties": {
-                            "id": {
-                                "type": "string"
-                            },
-                            "name": {
-                                "type": "string"
-                            },
-                            "number": {
-                                "type": "string"
-                            }
-                        }
-                    }
+                "description": {
+                    "type": "string"
                 },
-                "title": {
+                "id": {
                     "type": "string"
                 },
-                "type": {
+                "kb_id": {
+                    "type": "string"
+                },
+                "name": {
                     "type": "string"
                 }
             }
         },
-        "domain.ModelModeSetting": {
+        "domain.UpdateGitSourceReq": {
             "type": "object",
+            "required": [
+                "id",
+                "kb_id"
+            ],
             "properties": {
-                "auto_mode_api_key": {
-                    "description": "百智云 API Key",
+                "auto_release": {
+                    "type": "boolean"
+                },
+                "branch": {
                     "type": "string"
                 },
-                "chat_model": {
-                    "description": "自定义对话模型名称",
+                "dir": {
                     "type": "string"
                 },
-                "is_manual_embedding_updated": {
-                    "description": "手动模式下嵌入模型是否更新",
-                    "type": "boolean"
+                "id": {
+                    "type": "string"
                 },
-                "mode": {
-                    "description": "模式: manual 或 auto",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/consts.ModelSettingMode"
-                        }
-                    ]
+                "kb_id": {
+                    "type": "string"
                 }
             }
         },
-        "domain.ModelType": {
-            "type": "string",
-            "enum": [
-                "chat",
-                "embedding",
-                "rerank",
-                "analysis",
-                "analysis-vl"
-            ],
-            "x-enum-varnames": [
-                "ModelTypeChat",
-                "ModelTypeEmbedding",
-                "ModelTypeRerank",
-                "ModelTypeAnalysis",
-                "ModelTypeAnalysisVL"
-            ]
-        },
-        "domain.MoveNodeReq": {
+        "domain.UpdateKnowledgeBaseReq": {
             "type": "object",
             "required": [
-                "id",
-                "kb_id"
+                "id"
             ],
             "properties": {
+                "access_settings": {
+                    "$ref": "#/definitions/domain.AccessSettings"
+                },
                 "id": {
                     "type": "string"
                 },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.UpdateLanguageSettingReq": {
+            "type": "object",
+            "required": [
+                "kb_id"
+            ],
+            "properties": {
                 "kb_id": {
                     "type": "string"
                 },
-                "next_id": {
+                "language": {
+                    "description": "知识库主要语言",
                     "type": "string"
                 },
-                "parent_id": {
-                    "type": "string"
+                "match_answer_language": {
+                    "description": "使用提问的语言回答",
+                    "type": "boolean"
                 },
-                "prev_id": {
-                    "type": "string"
+                "translate_query": {
+                    "description": "检索前将问题翻译为知识库主要语言",
+                    "type": "boolean"
                 }
             }
         },
-        "domain.NodeActionReq": {
+        "domain.UpdateLinkCheckScheduleReq": {
             "type": "object",
             "required": [
-                "action",
-                "ids",
                 "kb_id"
             ],
             "properties": {
-                "action": {
-                    "type": "string",
-                    "enum": [
-                        "delete"
-                    ]
+                "concurrency": {
+                    "type": "integer"
                 },
-                "ids": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "enabled": {
+                    "type": "boolean"
+                },
+                "interval_hours": {
+                    "description": "0 for the default of a week",
+                    "type": "integer",
+                    "maximum": 2160,
+                    "minimum": 0
                 },
                 "kb_id": {
                     "type": "string"
+                },
+                "timeout_seconds": {
+                    "type": "integer"
                 }
             }
         },
-        "domain.NodeContentChunkSSE": {
+        "domain.UpdateModelReq": {
             "type": "object",
+            "required": [
+                "base_url",
+                "id",
+                "model",
+                "provider",
+                "type"
+            ],
             "properties": {
-                "emoji": {
+                "api_header": {
                     "type": "string"
                 },
-                "name": {
+                "api_key": {
                     "type": "string"
                 },
-                "node_id": {
+                "api_version": {
+                    "description": "for azure openai",
                     "type": "string"
                 },
-                "node_path_names": {
+                "base_url": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "parameters": {
+                    "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.ModelParam"
+                },
+                "provider": {
+                    "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.ModelProvider"
+                },
+                "type": {
+                    "enum": [
+                        "chat",
+                        "embedding",
+                        "rerank",
+                        "analysis",
+                        "analysis-vl"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.ModelType"
+                        }
+                    ]
+                }
+            }
+        },
+        "domain.UpdateNodeChunkReq": {
+            "type": "object",
+            "required": [
+                "chunk_id",
+                "kb_id",
+                "node_id"
+            ],
+            "properties": {
+                "available": {
+                    "type": "boolean"
+                },
+                "chunk_id": {
+                    "type": "string"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "kb_id": {
+                    "type": "string"
+                },
+                "keywords": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "summary": {
+                "node_id": {
                     "type": "string"
                 }
             }
         },
-        "domain.NodeGroupDetail": {
+        "domain.UpdateNodeReq": {
             "type": "object",
+            "required": [
+                "id",
+                "kb_id"
+            ],
             "properties": {
-                "auth_group_id": {
-                    "type": "integer"
+                "content": {
+                    "type": "string"
                 },
-                "auth_ids": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "content_type": {
+                    "type": "string"
+                },
+                "emoji": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
                 },
                 "kb_id": {
                     "type": "string"
@@ -6649,893 +15017,907 @@ const docTemplate = `{
                 "name": {
                     "type": "string"
                 },
-                "node_id": {
-                    "type": "string"
+                "position": {
+                    "type": "number"
                 },
-                "perm": {
-                    "$ref": "#/definitions/consts.NodePermName"
+                "summary": {
+                    "type": "string"
                 }
             }
         },
-        "domain.NodeListItemResp": {
+        "domain.UpsertNodeAction": {
+            "type": "string",
+            "enum": [
+                "created",
+                "updated",
+                "unchanged",
+                "deleted",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "UpsertNodeActionCreated",
+                "UpsertNodeActionUpdated",
+                "UpsertNodeActionUnchanged",
+                "UpsertNodeActionDeleted",
+                "UpsertNodeActionFailed"
+            ]
+        },
+        "domain.UpsertNodeItem": {
             "type": "object",
+            "required": [
+                "external_id",
+                "name"
+            ],
             "properties": {
+                "content": {
+                    "type": "string"
+                },
                 "content_type": {
                     "type": "string"
                 },
-                "created_at": {
+                "emoji": {
                     "type": "string"
                 },
-                "creator": {
+                "external_id": {
                     "type": "string"
                 },
-                "creator_id": {
+                "name": {
                     "type": "string"
                 },
-                "editor": {
+                "path": {
+                    "description": "folders the document is placed in separated by \"/\", missing folders are created",
                     "type": "string"
                 },
-                "editor_id": {
+                "summary": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.UpsertNodeResult": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "$ref": "#/definitions/domain.UpsertNodeAction"
+                },
+                "error": {
                     "type": "string"
                 },
-                "emoji": {
+                "external_id": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "name": {
+                "path": {
+                    "description": "path of the document in the imported file, imports only",
                     "type": "string"
+                }
+            }
+        },
+        "domain.UpsertNodesReq": {
+            "type": "object",
+            "required": [
+                "items",
+                "kb_id"
+            ],
+            "properties": {
+                "delete_missing": {
+                    "description": "delete the documents with an external id that are not in the batch",
+                    "type": "boolean"
                 },
-                "parent_id": {
+                "delete_prefix": {
+                    "description": "only documents whose external id starts with the prefix are deleted, required with delete_missing so that\na batch cannot remove the documents pushed by other integrations",
                     "type": "string"
                 },
-                "permissions": {
-                    "$ref": "#/definitions/domain.NodePermissions"
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/domain.UpsertNodeItem"
+                    }
                 },
-                "position": {
-                    "type": "number"
+                "kb_id": {
+                    "type": "string"
                 },
-                "publisher_id": {
+                "parent_id": {
+                    "description": "node the paths of the items are relative to, empty for the root of the kb",
                     "type": "string"
+                }
+            }
+        },
+        "domain.UpsertNodesResp": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
                 },
-                "rag_info": {
-                    "$ref": "#/definitions/domain.RagInfo"
+                "deleted": {
+                    "type": "integer"
                 },
-                "status": {
-                    "$ref": "#/definitions/domain.NodeStatus"
+                "failed": {
+                    "type": "integer"
                 },
-                "summary": {
-                    "type": "string"
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.UpsertNodeResult"
+                    }
                 },
-                "type": {
-                    "$ref": "#/definitions/domain.NodeType"
+                "unchanged": {
+                    "type": "integer"
                 },
-                "updated_at": {
-                    "type": "string"
+                "updated": {
+                    "type": "integer"
                 }
             }
         },
-        "domain.NodeMeta": {
+        "domain.UserInfo": {
             "type": "object",
             "properties": {
-                "content_type": {
+                "auth_user_id": {
+                    "type": "integer"
+                },
+                "avatar": {
+                    "description": "avatar",
                     "type": "string"
                 },
-                "emoji": {
+                "email": {
                     "type": "string"
                 },
-                "summary": {
+                "from": {
+                    "$ref": "#/definitions/domain.MessageFrom"
+                },
+                "name": {
                     "type": "string"
-                }
-            }
-        },
-        "domain.NodePermissions": {
-            "type": "object",
-            "properties": {
-                "answerable": {
-                    "description": "可被问答",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/consts.NodeAccessPerm"
-                        }
-                    ]
                 },
-                "visible": {
-                    "description": "导航内可见",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/consts.NodeAccessPerm"
-                        }
-                    ]
+                "real_name": {
+                    "type": "string"
                 },
-                "visitable": {
-                    "description": "可被访问",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/consts.NodeAccessPerm"
-                        }
-                    ]
+                "user_id": {
+                    "type": "string"
                 }
             }
         },
-        "domain.NodeStatus": {
-            "type": "integer",
-            "format": "int32",
-            "enum": [
-                1,
-                2
-            ],
-            "x-enum-varnames": [
-                "NodeStatusDraft",
-                "NodeStatusReleased"
-            ]
-        },
-        "domain.NodeSummaryReq": {
+        "domain.WeChatAppAdvancedSetting": {
             "type": "object",
-            "required": [
-                "ids",
-                "kb_id"
-            ],
             "properties": {
-                "ids": {
+                "disclaimer_content": {
+                    "type": "string"
+                },
+                "feedback_enable": {
+                    "type": "boolean"
+                },
+                "feedback_type": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "kb_id": {
-                    "type": "string"
-                }
-            }
-        },
-        "domain.NodeType": {
-            "type": "integer",
-            "format": "int32",
-            "enum": [
-                1,
-                2
-            ],
-            "x-enum-varnames": [
-                "NodeTypeFolder",
-                "NodeTypeDocument"
-            ]
-        },
-        "domain.ObjectUploadResp": {
-            "type": "object",
-            "properties": {
-                "filename": {
+                "prompt": {
                     "type": "string"
                 },
-                "key": {
-                    "type": "string"
+                "text_response_enable": {
+                    "type": "boolean"
                 }
             }
         },
-        "domain.OpenAIAPIBotSettings": {
+        "domain.WebAppCommentSettings": {
             "type": "object",
             "properties": {
-                "is_enabled": {
+                "is_enable": {
                     "type": "boolean"
                 },
-                "secret_key": {
-                    "type": "string"
+                "moderation_enable": {
+                    "type": "boolean"
                 }
             }
         },
-        "domain.OpenAIChoice": {
+        "domain.WebAppCustomSettings": {
             "type": "object",
             "properties": {
-                "delta": {
-                    "description": "for streaming",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.OpenAIMessage"
-                        }
-                    ]
+                "allow_theme_switching": {
+                    "type": "boolean"
                 },
-                "finish_reason": {
+                "footer_show_intro": {
+                    "type": "boolean"
+                },
+                "header_search_placeholder": {
                     "type": "string"
                 },
-                "index": {
-                    "type": "integer"
+                "show_brand_info": {
+                    "type": "boolean"
                 },
-                "message": {
-                    "$ref": "#/definitions/domain.OpenAIMessage"
+                "social_media_accounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.SocialMediaAccount"
+                    }
                 }
             }
         },
-        "domain.OpenAICompletionsRequest": {
+        "domain.WebAppLandingConfig": {
             "type": "object",
-            "required": [
-                "messages",
-                "model"
-            ],
             "properties": {
-                "frequency_penalty": {
-                    "type": "number"
+                "banner_config": {
+                    "$ref": "#/definitions/domain.BannerConfig"
                 },
-                "max_tokens": {
-                    "type": "integer"
+                "basic_doc_config": {
+                    "$ref": "#/definitions/domain.BasicDocConfig"
                 },
-                "messages": {
+                "block_grid_config": {
+                    "$ref": "#/definitions/domain.BlockGridConfig"
+                },
+                "carousel_config": {
+                    "$ref": "#/definitions/domain.CarouselConfig"
+                },
+                "case_config": {
+                    "$ref": "#/definitions/domain.CaseConfig"
+                },
+                "com_config_order": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/domain.OpenAIMessage"
+                        "type": "string"
                     }
                 },
-                "model": {
+                "comment_config": {
+                    "$ref": "#/definitions/domain.CommentConfig"
+                },
+                "dir_doc_config": {
+                    "$ref": "#/definitions/domain.DirDocConfig"
+                },
+                "faq_config": {
+                    "$ref": "#/definitions/domain.FaqConfig"
+                },
+                "feature_config": {
+                    "$ref": "#/definitions/domain.FeatureConfig"
+                },
+                "img_text_config": {
+                    "$ref": "#/definitions/domain.ImgTextConfig"
+                },
+                "metrics_config": {
+                    "$ref": "#/definitions/domain.MetricsConfig"
+                },
+                "node_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "question_config": {
+                    "$ref": "#/definitions/domain.QuestionConfig"
+                },
+                "simple_doc_config": {
+                    "$ref": "#/definitions/domain.SimpleDocConfig"
+                },
+                "text_config": {
+                    "$ref": "#/definitions/domain.TextConfig"
+                },
+                "text_img_config": {
+                    "$ref": "#/definitions/domain.TextImgConfig"
+                },
+                "type": {
                     "type": "string"
+                }
+            }
+        },
+        "domain.WebAppLandingConfigResp": {
+            "type": "object",
+            "properties": {
+                "banner_config": {
+                    "$ref": "#/definitions/domain.BannerConfig"
                 },
-                "presence_penalty": {
-                    "type": "number"
+                "basic_doc_config": {
+                    "$ref": "#/definitions/domain.BasicDocConfig"
                 },
-                "response_format": {
-                    "$ref": "#/definitions/domain.OpenAIResponseFormat"
+                "block_grid_config": {
+                    "$ref": "#/definitions/domain.BlockGridConfig"
                 },
-                "stop": {
+                "carousel_config": {
+                    "$ref": "#/definitions/domain.CarouselConfig"
+                },
+                "case_config": {
+                    "$ref": "#/definitions/domain.CaseConfig"
+                },
+                "com_config_order": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "stream": {
-                    "type": "boolean"
+                "comment_config": {
+                    "$ref": "#/definitions/domain.CommentConfig"
                 },
-                "stream_options": {
-                    "$ref": "#/definitions/domain.OpenAIStreamOptions"
+                "dir_doc_config": {
+                    "$ref": "#/definitions/domain.DirDocConfig"
                 },
-                "temperature": {
-                    "type": "number"
+                "faq_config": {
+                    "$ref": "#/definitions/domain.FaqConfig"
                 },
-                "tool_choice": {
-                    "$ref": "#/definitions/domain.OpenAIToolChoice"
+                "feature_config": {
+                    "$ref": "#/definitions/domain.FeatureConfig"
+                },
+                "img_text_config": {
+                    "$ref": "#/definitions/domain.ImgTextConfig"
                 },
-                "tools": {
+                "metrics_config": {
+                    "$ref": "#/definitions/domain.MetricsConfig"
+                },
+                "node_ids": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/domain.OpenAITool"
+                        "type": "string"
                     }
                 },
-                "top_p": {
-                    "type": "number"
-                },
-                "user": {
-                    "type": "string"
-                }
-            }
-        },
-        "domain.OpenAICompletionsResponse": {
-            "type": "object",
-            "properties": {
-                "choices": {
+                "nodes": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/domain.OpenAIChoice"
+                        "$ref": "#/definitions/domain.RecommendNodeListResp"
                     }
                 },
-                "created": {
-                    "type": "integer"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "model": {
-                    "type": "string"
-                },
-                "object": {
-                    "type": "string"
+                "question_config": {
+                    "$ref": "#/definitions/domain.QuestionConfig"
                 },
-                "usage": {
-                    "$ref": "#/definitions/domain.OpenAIUsage"
-                }
-            }
-        },
-        "domain.OpenAIError": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "string"
+                "simple_doc_config": {
+                    "$ref": "#/definitions/domain.SimpleDocConfig"
                 },
-                "message": {
-                    "type": "string"
+                "text_config": {
+                    "$ref": "#/definitions/domain.TextConfig"
                 },
-                "param": {
-                    "type": "string"
+                "text_img_config": {
+                    "$ref": "#/definitions/domain.TextImgConfig"
                 },
                 "type": {
                     "type": "string"
                 }
             }
         },
-        "domain.OpenAIErrorResponse": {
+        "domain.WebAppLandingTheme": {
             "type": "object",
             "properties": {
-                "error": {
-                    "$ref": "#/definitions/domain.OpenAIError"
+                "name": {
+                    "type": "string"
                 }
             }
         },
-        "domain.OpenAIFunction": {
+        "domain.WecomAIBotSettings": {
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
-                "description": {
+                "encodingaeskey": {
                     "type": "string"
                 },
-                "name": {
-                    "type": "string"
+                "is_enabled": {
+                    "type": "boolean"
                 },
-                "parameters": {
-                    "type": "object",
-                    "additionalProperties": true
+                "token": {
+                    "type": "string"
                 }
             }
         },
-        "domain.OpenAIFunctionCall": {
+        "domain.WidgetBotSettings": {
             "type": "object",
-            "required": [
-                "arguments",
-                "name"
-            ],
             "properties": {
-                "arguments": {
+                "btn_id": {
                     "type": "string"
                 },
-                "name": {
+                "btn_logo": {
                     "type": "string"
-                }
-            }
-        },
-        "domain.OpenAIFunctionChoice": {
-            "type": "object",
-            "required": [
-                "name"
-            ],
-            "properties": {
-                "name": {
+                },
+                "btn_position": {
                     "type": "string"
-                }
-            }
-        },
-        "domain.OpenAIMessage": {
-            "type": "object",
-            "required": [
-                "role"
-            ],
-            "properties": {
-                "content": {
-                    "$ref": "#/definitions/domain.MessageContent"
                 },
-                "name": {
+                "btn_style": {
                     "type": "string"
                 },
-                "role": {
+                "btn_text": {
                     "type": "string"
                 },
-                "tool_call_id": {
+                "copyright_hide_enabled": {
+                    "type": "boolean"
+                },
+                "copyright_info": {
                     "type": "string"
                 },
-                "tool_calls": {
+                "disclaimer": {
+                    "type": "string"
+                },
+                "follow_up_suggestions_enabled": {
+                    "description": "回答结束后生成追问建议",
+                    "type": "boolean"
+                },
+                "is_open": {
+                    "type": "boolean"
+                },
+                "modal_position": {
+                    "type": "string"
+                },
+                "placeholder": {
+                    "type": "string"
+                },
+                "recommend_node_ids": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/domain.OpenAIToolCall"
+                        "type": "string"
                     }
-                }
-            }
-        },
-        "domain.OpenAIResponseFormat": {
-            "type": "object",
-            "required": [
-                "type"
-            ],
-            "properties": {
-                "type": {
+                },
+                "recommend_questions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "search_mode": {
                     "type": "string"
-                }
-            }
-        },
-        "domain.OpenAIStreamOptions": {
-            "type": "object",
-            "properties": {
-                "include_usage": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "domain.OpenAITool": {
-            "type": "object",
-            "required": [
-                "type"
-            ],
-            "properties": {
-                "function": {
-                    "$ref": "#/definitions/domain.OpenAIFunction"
                 },
-                "type": {
+                "theme_mode": {
                     "type": "string"
                 }
             }
         },
-        "domain.OpenAIToolCall": {
+        "github_com_chaitin_panda-wiki_api_auth_v1.AuthGetResp": {
             "type": "object",
-            "required": [
-                "function",
-                "id",
-                "type"
-            ],
             "properties": {
-                "function": {
-                    "$ref": "#/definitions/domain.OpenAIFunctionCall"
+                "auths": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.AuthItem"
+                    }
                 },
-                "id": {
+                "client_id": {
                     "type": "string"
                 },
-                "type": {
+                "client_secret": {
                     "type": "string"
-                }
-            }
-        },
-        "domain.OpenAIToolChoice": {
-            "type": "object",
-            "properties": {
-                "function": {
-                    "$ref": "#/definitions/domain.OpenAIFunctionChoice"
                 },
-                "type": {
+                "proxy": {
                     "type": "string"
+                },
+                "source_type": {
+                    "$ref": "#/definitions/consts.SourceType"
                 }
             }
         },
-        "domain.OpenAIUsage": {
+        "github_com_chaitin_panda-wiki_api_share_v1.AuthGetResp": {
             "type": "object",
             "properties": {
-                "completion_tokens": {
-                    "type": "integer"
+                "auth_type": {
+                    "$ref": "#/definitions/consts.AuthType"
                 },
-                "prompt_tokens": {
-                    "type": "integer"
+                "license_edition": {
+                    "$ref": "#/definitions/consts.LicenseEdition"
                 },
-                "total_tokens": {
-                    "type": "integer"
+                "source_type": {
+                    "$ref": "#/definitions/consts.SourceType"
                 }
             }
         },
-        "domain.PWResponse": {
+        "github_com_chaitin_panda-wiki_api_share_v1.GitHubCallbackResp": {
+            "type": "object"
+        },
+        "github_com_chaitin_panda-wiki_api_system_v1.ComponentStatus": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "integer"
+                "health": {
+                    "description": "健康状态 (仅RAGLite和Qdrant)",
+                    "type": "string"
                 },
-                "data": {},
-                "message": {
+                "image": {
+                    "description": "镜像名称",
                     "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "domain.PaginatedResult-array_domain_ConversationMessageListItem": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/domain.ConversationMessageListItem"
-                    }
+                "log_status": {
+                    "description": "日志解析状态 (仅RAGLite和Qdrant)",
+                    "type": "string"
                 },
-                "total": {
-                    "type": "integer"
-                }
-            }
-        },
-        "domain.ProviderModelListItem": {
-            "type": "object",
-            "properties": {
-                "model": {
+                "name": {
+                    "description": "组件名称",
+                    "type": "string"
+                },
+                "ports": {
+                    "description": "端口信息",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态: running, stopped, error",
                     "type": "string"
                 }
             }
         },
-        "domain.QuestionConfig": {
+        "github_com_chaitin_panda-wiki_api_system_v1.FailedDoc": {
             "type": "object",
             "properties": {
-                "list": {
-                    "type": "array",
-                    "items": {
-                        "type": "object",
-                        "properties": {
-                            "id": {
-                                "type": "string"
-                            },
-                            "question": {
-                                "type": "string"
-                            }
-                        }
-                    }
+                "node_id": {
+                    "description": "节点ID",
+                    "type": "string"
                 },
-                "title": {
+                "node_name": {
+                    "description": "文档名",
                     "type": "string"
                 },
-                "type": {
+                "reason": {
+                    "description": "失败原因",
                     "type": "string"
                 }
             }
         },
-        "domain.RagInfo": {
+        "github_com_chaitin_panda-wiki_api_system_v1.QueueProgress": {
             "type": "object",
             "properties": {
-                "message": {
-                    "type": "string"
+                "pending": {
+                    "description": "等待中",
+                    "type": "integer"
                 },
-                "status": {
-                    "$ref": "#/definitions/consts.NodeRagInfoStatus"
+                "progress": {
+                    "description": "进度百分比 (0-100)",
+                    "type": "integer"
+                },
+                "running": {
+                    "description": "运行中",
+                    "type": "integer"
+                },
+                "total": {
+                    "description": "总数",
+                    "type": "integer"
                 }
             }
         },
-        "domain.RecommendNodeListResp": {
+        "github_com_chaitin_panda-wiki_domain.CheckModelReq": {
             "type": "object",
+            "required": [
+                "base_url",
+                "model",
+                "provider",
+                "type"
+            ],
             "properties": {
-                "emoji": {
+                "api_header": {
                     "type": "string"
                 },
-                "id": {
+                "api_key": {
                     "type": "string"
                 },
-                "name": {
+                "api_version": {
+                    "description": "for azure openai",
                     "type": "string"
                 },
-                "parent_id": {
+                "base_url": {
                     "type": "string"
                 },
-                "permissions": {
-                    "$ref": "#/definitions/domain.NodePermissions"
-                },
-                "position": {
-                    "type": "number"
+                "model": {
+                    "type": "string"
                 },
-                "recommend_nodes": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/domain.RecommendNodeListResp"
-                    }
+                "parameters": {
+                    "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.ModelParam"
                 },
-                "summary": {
-                    "type": "string"
+                "provider": {
+                    "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.ModelProvider"
                 },
                 "type": {
-                    "$ref": "#/definitions/domain.NodeType"
+                    "enum": [
+                        "chat",
+                        "embedding",
+                        "rerank",
+                        "analysis",
+                        "analysis-vl"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.ModelType"
+                        }
+                    ]
                 }
             }
         },
-        "domain.Response": {
+        "github_com_chaitin_panda-wiki_domain.CheckModelResp": {
             "type": "object",
             "properties": {
-                "data": {},
-                "message": {
+                "content": {
                     "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
+                "error": {
+                    "type": "string"
                 }
             }
         },
-        "domain.ScoreType": {
-            "type": "integer",
-            "enum": [
-                1,
-                -1
-            ],
-            "x-enum-varnames": [
-                "Like",
-                "DisLike"
-            ]
-        },
-        "domain.ShareCommentListItem": {
+        "github_com_chaitin_panda-wiki_domain.ModelListItem": {
             "type": "object",
             "properties": {
-                "content": {
+                "api_header": {
                     "type": "string"
                 },
-                "created_at": {
+                "api_key": {
                     "type": "string"
                 },
-                "id": {
+                "api_version": {
+                    "description": "for azure openai",
                     "type": "string"
                 },
-                "info": {
-                    "$ref": "#/definitions/domain.CommentInfo"
-                },
-                "ip_address": {
-                    "description": "ip地址",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.IPAddress"
-                        }
-                    ]
-                },
-                "kb_id": {
+                "base_url": {
                     "type": "string"
                 },
-                "node_id": {
-                    "type": "string"
+                "completion_tokens": {
+                    "type": "integer"
                 },
-                "parent_id": {
+                "id": {
                     "type": "string"
                 },
-                "pic_urls": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "is_active": {
+                    "type": "boolean"
                 },
-                "root_id": {
-                    "type": "string"
-                }
-            }
-        },
-        "domain.ShareConversationDetailResp": {
-            "type": "object",
-            "properties": {
-                "created_at": {
+                "model": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "string"
+                "parameters": {
+                    "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.ModelParam"
                 },
-                "messages": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/domain.ShareConversationMessage"
-                    }
+                "prompt_tokens": {
+                    "type": "integer"
                 },
-                "subject": {
-                    "type": "string"
-                }
-            }
-        },
-        "domain.ShareConversationMessage": {
-            "type": "object",
-            "properties": {
-                "content": {
-                    "type": "string"
+                "provider": {
+                    "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.ModelProvider"
                 },
-                "created_at": {
-                    "type": "string"
+                "total_tokens": {
+                    "type": "integer"
                 },
-                "role": {
-                    "$ref": "#/definitions/schema.RoleType"
+                "type": {
+                    "$ref": "#/definitions/domain.ModelType"
                 }
             }
         },
-        "domain.ShareNodeDetailItem": {
+        "github_com_chaitin_panda-wiki_domain.ModelParam": {
             "type": "object",
             "properties": {
-                "children": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/domain.ShareNodeDetailItem"
-                    }
-                },
-                "emoji": {
-                    "type": "string"
+                "context_window": {
+                    "type": "integer"
                 },
-                "id": {
-                    "type": "string"
+                "max_tokens": {
+                    "type": "integer"
                 },
-                "meta": {
-                    "$ref": "#/definitions/domain.NodeMeta"
+                "r1_enabled": {
+                    "type": "boolean"
                 },
-                "name": {
-                    "type": "string"
+                "support_computer_use": {
+                    "type": "boolean"
                 },
-                "parent_id": {
-                    "type": "string"
+                "support_images": {
+                    "type": "boolean"
                 },
-                "permissions": {
-                    "$ref": "#/definitions/domain.NodePermissions"
+                "support_prompt_cache": {
+                    "type": "boolean"
                 },
-                "position": {
+                "temperature": {
                     "type": "number"
-                },
-                "type": {
-                    "$ref": "#/definitions/domain.NodeType"
-                },
-                "updated_at": {
-                    "type": "string"
                 }
             }
         },
-        "domain.SimpleAuth": {
+        "github_com_chaitin_panda-wiki_domain.ModelProvider": {
+            "type": "string",
+            "enum": [
+                "BaiZhiCloud"
+            ],
+            "x-enum-varnames": [
+                "ModelProviderBrandBaiZhiCloud"
+            ]
+        },
+        "gocap.ChallengeData": {
             "type": "object",
             "properties": {
-                "enabled": {
-                    "type": "boolean"
+                "challenge": {
+                    "$ref": "#/definitions/gocap.ChallengeItem"
                 },
-                "password": {
+                "expires": {
+                    "description": "过期时间,毫秒级时间戳",
+                    "type": "integer"
+                },
+                "token": {
+                    "description": "质询令牌",
                     "type": "string"
                 }
             }
         },
-        "domain.SimpleDocConfig": {
+        "gocap.ChallengeItem": {
             "type": "object",
             "properties": {
-                "bg_color": {
-                    "type": "string"
+                "c": {
+                    "description": "质询数量",
+                    "type": "integer"
                 },
-                "title": {
-                    "type": "string"
+                "d": {
+                    "description": "质询难度",
+                    "type": "integer"
                 },
-                "title_color": {
-                    "type": "string"
+                "s": {
+                    "description": "质询大小",
+                    "type": "integer"
                 }
             }
         },
-        "domain.SocialMediaAccount": {
+        "gocap.VerificationResult": {
             "type": "object",
             "properties": {
-                "channel": {
-                    "type": "string"
-                },
-                "icon": {
-                    "type": "string"
+                "expires": {
+                    "description": "过期时间,毫秒级时间戳",
+                    "type": "integer"
                 },
-                "link": {
+                "message": {
                     "type": "string"
                 },
-                "phone": {
-                    "type": "string"
+                "success": {
+                    "type": "boolean"
                 },
-                "text": {
+                "token": {
+                    "description": "验证令牌",
                     "type": "string"
                 }
             }
         },
-        "domain.StatPageReq": {
+        "pro.AuditContributeReq": {
             "type": "object",
             "required": [
-                "scene"
+                "id",
+                "kb_id",
+                "status"
             ],
             "properties": {
-                "node_id": {
+                "id": {
                     "type": "string"
                 },
-                "scene": {
-                    "enum": [
-                        1,
-                        2,
-                        3,
-                        4
-                    ],
+                "kb_id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "approved 或 rejected",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/domain.StatPageScene"
+                            "$ref": "#/definitions/consts.ContributeStatus"
                         }
                     ]
                 }
             }
         },
-        "domain.StatPageScene": {
-            "type": "integer",
-            "enum": [
-                1,
-                2,
-                3,
-                4
-            ],
-            "x-enum-varnames": [
-                "StatPageSceneWelcome",
-                "StatPageSceneNodeDetail",
-                "StatPageSceneChat",
-                "StatPageSceneLogin"
-            ]
-        },
-        "domain.StatsSetting": {
-            "type": "object",
-            "properties": {
-                "pv_enable": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "domain.SwitchModeReq": {
+        "pro.AuthGroup": {
             "type": "object",
-            "required": [
-                "mode"
-            ],
             "properties": {
-                "auto_mode_api_key": {
-                    "description": "百智云 API Key",
-                    "type": "string"
+                "group_id": {
+                    "type": "integer"
                 },
-                "chat_model": {
-                    "description": "自定义对话模型名称",
+                "group_name": {
                     "type": "string"
                 },
-                "mode": {
-                    "type": "string",
-                    "enum": [
-                        "manual",
-                        "auto"
-                    ]
-                }
-            }
-        },
-        "domain.SwitchModeResp": {
-            "type": "object",
-            "properties": {
-                "message": {
+                "permission": {
+                    "description": "read, write, admin",
                     "type": "string"
                 }
             }
         },
-        "domain.TextConfig": {
+        "pro.AuthUser": {
             "type": "object",
             "properties": {
-                "title": {
+                "auth_id": {
+                    "type": "integer"
+                },
+                "permission": {
+                    "description": "read, write, admin",
                     "type": "string"
                 },
-                "type": {
+                "username": {
                     "type": "string"
                 }
             }
         },
-        "domain.TextImgConfig": {
+        "pro.ContributeItem": {
             "type": "object",
             "properties": {
-                "item": {
-                    "type": "object",
-                    "properties": {
-                        "desc": {
-                            "type": "string"
-                        },
-                        "name": {
-                            "type": "string"
-                        },
-                        "url": {
-                            "type": "string"
-                        }
-                    }
+                "audit_time": {
+                    "type": "string"
                 },
-                "title": {
+                "audit_user_id": {
+                    "type": "string"
+                },
+                "auth_id": {
+                    "type": "integer"
+                },
+                "auth_name": {
+                    "description": "用户名称",
+                    "type": "string"
+                },
+                "avatar": {
+                    "description": "用户头像",
+                    "type": "string"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "contribute_name": {
+                    "description": "用户提交的标题（前端使用的字段名）",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
                     "type": "string"
                 },
+                "ip_address": {
+                    "$ref": "#/definitions/domain.IPAddress"
+                },
+                "kb_id": {
+                    "type": "string"
+                },
+                "meta": {
+                    "$ref": "#/definitions/domain.NodeMeta"
+                },
+                "node_id": {
+                    "type": "string"
+                },
+                "node_name": {
+                    "description": "文档标题（编辑时为原文档名，新增时为用户提交的name）",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "提交说明/审核原因",
+                    "type": "string"
+                },
+                "remote_ip": {
+                    "description": "远程IP",
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/consts.ContributeStatus"
+                },
                 "type": {
+                    "$ref": "#/definitions/consts.ContributeType"
+                },
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "domain.TextReq": {
+        "pro.CreateReq": {
             "type": "object",
             "required": [
-                "text"
+                "kb_id",
+                "name"
             ],
             "properties": {
-                "action": {
-                    "description": "action: improve, summary, extend, shorten, etc.",
+                "auth_ids": {
+                    "description": "成员 ID 列表",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "kb_id": {
                     "type": "string"
                 },
-                "text": {
+                "name": {
                     "type": "string"
+                },
+                "parent_id": {
+                    "type": "integer"
                 }
             }
         },
-        "domain.ThemeAndStyle": {
+        "pro.CreateResp": {
             "type": "object",
             "properties": {
-                "bg_image": {
-                    "type": "string"
+                "id": {
+                    "type": "integer"
                 },
-                "doc_width": {
+                "message": {
                     "type": "string"
                 }
             }
         },
-        "domain.UpdateAppReq": {
+        "pro.CreateTokenReq": {
             "type": "object",
+            "required": [
+                "kb_id",
+                "name",
+                "permission"
+            ],
             "properties": {
                 "kb_id": {
                     "type": "string"
@@ -7543,640 +15925,565 @@ const docTemplate = `{
                 "name": {
                     "type": "string"
                 },
-                "settings": {
-                    "$ref": "#/definitions/domain.AppSettings"
+                "permission": {
+                    "$ref": "#/definitions/consts.UserKBPermission"
                 }
             }
         },
-        "domain.UpdateKnowledgeBaseReq": {
+        "pro.CreateTokenResp": {
             "type": "object",
-            "required": [
-                "id"
-            ],
             "properties": {
-                "access_settings": {
-                    "$ref": "#/definitions/domain.AccessSettings"
-                },
                 "id": {
                     "type": "string"
                 },
-                "name": {
+                "token": {
                     "type": "string"
                 }
             }
         },
-        "domain.UpdateModelReq": {
+        "pro.DocumentFeedbackInfo": {
             "type": "object",
-            "required": [
-                "base_url",
-                "id",
-                "model",
-                "provider",
-                "type"
-            ],
             "properties": {
-                "api_header": {
-                    "type": "string"
+                "auth_user_id": {
+                    "description": "用户 ID",
+                    "type": "integer"
                 },
-                "api_key": {
+                "avatar": {
+                    "description": "头像",
                     "type": "string"
                 },
-                "api_version": {
-                    "description": "for azure openai",
+                "email": {
+                    "description": "邮箱",
                     "type": "string"
                 },
-                "base_url": {
+                "remote_ip": {
+                    "description": "IP 地址",
                     "type": "string"
                 },
-                "id": {
+                "screen_shot": {
+                    "description": "截图",
                     "type": "string"
                 },
-                "is_active": {
-                    "type": "boolean"
-                },
-                "model": {
+                "user_name": {
+                    "description": "用户名",
                     "type": "string"
-                },
-                "parameters": {
-                    "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.ModelParam"
-                },
-                "provider": {
-                    "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.ModelProvider"
-                },
-                "type": {
-                    "enum": [
-                        "chat",
-                        "embedding",
-                        "rerank",
-                        "analysis",
-                        "analysis-vl"
-                    ],
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.ModelType"
-                        }
-                    ]
                 }
             }
         },
-        "domain.UpdateNodeReq": {
+        "pro.DocumentFeedbackItem": {
             "type": "object",
-            "required": [
-                "id",
-                "kb_id"
-            ],
             "properties": {
                 "content": {
                     "type": "string"
                 },
-                "content_type": {
+                "correction_suggestion": {
+                    "description": "修正建议",
                     "type": "string"
                 },
-                "emoji": {
+                "created_at": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
+                "info": {
+                    "description": "用户信息",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/pro.DocumentFeedbackInfo"
+                        }
+                    ]
+                },
+                "ip_address": {
+                    "$ref": "#/definitions/domain.IPAddress"
+                },
                 "kb_id": {
                     "type": "string"
                 },
-                "name": {
+                "node_id": {
                     "type": "string"
                 },
-                "position": {
-                    "type": "number"
+                "node_name": {
+                    "type": "string"
                 },
-                "summary": {
+                "user_id": {
                     "type": "string"
                 }
             }
         },
-        "domain.UserInfo": {
+        "pro.GetAuthResp": {
             "type": "object",
             "properties": {
-                "auth_user_id": {
-                    "type": "integer"
-                },
-                "avatar": {
-                    "description": "avatar",
-                    "type": "string"
-                },
-                "email": {
-                    "type": "string"
-                },
-                "from": {
-                    "$ref": "#/definitions/domain.MessageFrom"
+                "groups": {
+                    "description": "用户组权限",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pro.AuthGroup"
+                    }
                 },
-                "name": {
+                "kb_id": {
                     "type": "string"
                 },
-                "real_name": {
-                    "type": "string"
+                "public": {
+                    "description": "是否公开",
+                    "type": "boolean"
                 },
-                "user_id": {
-                    "type": "string"
+                "users": {
+                    "description": "用户权限",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pro.AuthUser"
+                    }
                 }
             }
         },
-        "domain.WeChatAppAdvancedSetting": {
+        "pro.GetBlockWordsResp": {
             "type": "object",
             "properties": {
-                "disclaimer_content": {
-                    "type": "string"
-                },
-                "feedback_enable": {
-                    "type": "boolean"
-                },
-                "feedback_type": {
+                "words": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
-                },
-                "prompt": {
-                    "type": "string"
-                },
-                "text_response_enable": {
-                    "type": "boolean"
                 }
             }
         },
-        "domain.WebAppCommentSettings": {
+        "pro.GetContributeListResp": {
             "type": "object",
             "properties": {
-                "is_enable": {
-                    "type": "boolean"
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pro.ContributeItem"
+                    }
                 },
-                "moderation_enable": {
-                    "type": "boolean"
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "domain.WebAppCustomSettings": {
+        "pro.GetDocumentListResp": {
             "type": "object",
             "properties": {
-                "allow_theme_switching": {
-                    "type": "boolean"
-                },
-                "footer_show_intro": {
-                    "type": "boolean"
-                },
-                "header_search_placeholder": {
-                    "type": "string"
-                },
-                "show_brand_info": {
-                    "type": "boolean"
-                },
-                "social_media_accounts": {
+                "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/domain.SocialMediaAccount"
+                        "$ref": "#/definitions/pro.DocumentFeedbackItem"
                     }
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "domain.WebAppLandingConfig": {
+        "pro.GetListResp": {
             "type": "object",
             "properties": {
-                "banner_config": {
-                    "$ref": "#/definitions/domain.BannerConfig"
-                },
-                "basic_doc_config": {
-                    "$ref": "#/definitions/domain.BasicDocConfig"
-                },
-                "block_grid_config": {
-                    "$ref": "#/definitions/domain.BlockGridConfig"
-                },
-                "carousel_config": {
-                    "$ref": "#/definitions/domain.CarouselConfig"
-                },
-                "case_config": {
-                    "$ref": "#/definitions/domain.CaseConfig"
-                },
-                "com_config_order": {
+                "list": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/pro.GroupItem"
                     }
                 },
-                "comment_config": {
-                    "$ref": "#/definitions/domain.CommentConfig"
-                },
-                "dir_doc_config": {
-                    "$ref": "#/definitions/domain.DirDocConfig"
-                },
-                "faq_config": {
-                    "$ref": "#/definitions/domain.FaqConfig"
-                },
-                "feature_config": {
-                    "$ref": "#/definitions/domain.FeatureConfig"
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "pro.GetPromptResp": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                }
+            }
+        },
+        "pro.GetReleaseListResp": {
+            "type": "object",
+            "properties": {
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pro.ReleaseItem"
+                    }
                 },
-                "img_text_config": {
-                    "$ref": "#/definitions/domain.ImgTextConfig"
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "pro.GroupDetail": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
                 },
-                "metrics_config": {
-                    "$ref": "#/definitions/domain.MetricsConfig"
+                "id": {
+                    "type": "integer"
                 },
-                "node_ids": {
+                "members": {
+                    "description": "成员 ID 列表",
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "type": "integer"
                     }
                 },
-                "question_config": {
-                    "$ref": "#/definitions/domain.QuestionConfig"
+                "name": {
+                    "type": "string"
                 },
-                "simple_doc_config": {
-                    "$ref": "#/definitions/domain.SimpleDocConfig"
+                "parent_id": {
+                    "type": "integer"
                 },
-                "text_config": {
-                    "$ref": "#/definitions/domain.TextConfig"
+                "path": {
+                    "type": "string"
                 },
-                "text_img_config": {
-                    "$ref": "#/definitions/domain.TextImgConfig"
+                "position": {
+                    "type": "integer"
                 },
-                "type": {
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "domain.WebAppLandingConfigResp": {
+        "pro.GroupItem": {
             "type": "object",
             "properties": {
-                "banner_config": {
-                    "$ref": "#/definitions/domain.BannerConfig"
-                },
-                "basic_doc_config": {
-                    "$ref": "#/definitions/domain.BasicDocConfig"
-                },
-                "block_grid_config": {
-                    "$ref": "#/definitions/domain.BlockGridConfig"
-                },
-                "carousel_config": {
-                    "$ref": "#/definitions/domain.CarouselConfig"
-                },
-                "case_config": {
-                    "$ref": "#/definitions/domain.CaseConfig"
-                },
-                "com_config_order": {
+                "auth_ids": {
+                    "description": "成员 ID 列表",
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "type": "integer"
                     }
                 },
-                "comment_config": {
-                    "$ref": "#/definitions/domain.CommentConfig"
-                },
-                "dir_doc_config": {
-                    "$ref": "#/definitions/domain.DirDocConfig"
+                "count": {
+                    "description": "成员数量",
+                    "type": "integer"
                 },
-                "faq_config": {
-                    "$ref": "#/definitions/domain.FaqConfig"
+                "created_at": {
+                    "type": "string"
                 },
-                "feature_config": {
-                    "$ref": "#/definitions/domain.FeatureConfig"
+                "id": {
+                    "type": "integer"
                 },
-                "img_text_config": {
-                    "$ref": "#/definitions/domain.ImgTextConfig"
+                "name": {
+                    "type": "string"
                 },
-                "metrics_config": {
-                    "$ref": "#/definitions/domain.MetricsConfig"
+                "parent_id": {
+                    "type": "integer"
                 },
-                "node_ids": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "path": {
+                    "type": "string"
                 },
-                "nodes": {
+                "position": {
+                    "type": "integer"
+                }
+            }
+        },
+        "pro.GroupTreeNode": {
+            "type": "object",
+            "properties": {
+                "children": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/domain.RecommendNodeListResp"
+                        "$ref": "#/definitions/pro.GroupTreeNode"
                     }
                 },
-                "question_config": {
-                    "$ref": "#/definitions/domain.QuestionConfig"
-                },
-                "simple_doc_config": {
-                    "$ref": "#/definitions/domain.SimpleDocConfig"
-                },
-                "text_config": {
-                    "$ref": "#/definitions/domain.TextConfig"
+                "count": {
+                    "type": "integer"
                 },
-                "text_img_config": {
-                    "$ref": "#/definitions/domain.TextImgConfig"
+                "id": {
+                    "type": "integer"
                 },
-                "type": {
+                "name": {
                     "type": "string"
+                },
+                "parent_id": {
+                    "type": "integer"
                 }
             }
         },
-        "domain.WebAppLandingTheme": {
+        "pro.ModerateCommentReq": {
             "type": "object",
+            "required": [
+                "action",
+                "comment_id"
+            ],
             "properties": {
-                "name": {
+                "action": {
+                    "description": "approve, reject",
+                    "type": "string"
+                },
+                "comment_id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "拒绝原因（reject 时必填）",
                     "type": "string"
                 }
             }
         },
-        "domain.WecomAIBotSettings": {
+        "pro.MoveReq": {
             "type": "object",
+            "required": [
+                "id",
+                "kb_id"
+            ],
             "properties": {
-                "encodingaeskey": {
+                "id": {
+                    "type": "integer"
+                },
+                "kb_id": {
                     "type": "string"
                 },
-                "is_enabled": {
-                    "type": "boolean"
+                "next_id": {
+                    "description": "后一个兄弟节点",
+                    "type": "integer"
                 },
-                "token": {
-                    "type": "string"
+                "parent_id": {
+                    "type": "integer"
+                },
+                "prev_id": {
+                    "description": "前一个兄弟节点",
+                    "type": "integer"
                 }
             }
         },
-        "domain.WidgetBotSettings": {
+        "pro.ReleaseDetail": {
             "type": "object",
             "properties": {
-                "btn_id": {
-                    "type": "string"
+                "author_id": {
+                    "type": "integer"
                 },
-                "btn_logo": {
+                "author_name": {
                     "type": "string"
                 },
-                "btn_position": {
+                "content": {
                     "type": "string"
                 },
-                "btn_style": {
+                "created_at": {
                     "type": "string"
                 },
-                "btn_text": {
+                "description": {
                     "type": "string"
                 },
-                "copyright_hide_enabled": {
-                    "type": "boolean"
+                "id": {
+                    "type": "string"
                 },
-                "copyright_info": {
+                "name": {
                     "type": "string"
                 },
-                "disclaimer": {
+                "node_id": {
                     "type": "string"
                 },
-                "is_open": {
-                    "type": "boolean"
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "pro.ReleaseItem": {
+            "type": "object",
+            "properties": {
+                "author_id": {
+                    "type": "integer"
                 },
-                "modal_position": {
+                "author_name": {
                     "type": "string"
                 },
-                "placeholder": {
+                "created_at": {
                     "type": "string"
                 },
-                "recommend_node_ids": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "recommend_questions": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "description": {
+                    "description": "版本说明",
+                    "type": "string"
                 },
-                "search_mode": {
+                "id": {
                     "type": "string"
                 },
-                "theme_mode": {
+                "node_id": {
                     "type": "string"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_chaitin_panda-wiki_api_auth_v1.AuthGetResp": {
+        "pro.SetAuthReq": {
             "type": "object",
+            "required": [
+                "kb_id"
+            ],
             "properties": {
-                "auths": {
+                "groups": {
+                    "description": "用户组权限",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/v1.AuthItem"
+                        "$ref": "#/definitions/pro.AuthGroup"
                     }
                 },
-                "client_id": {
-                    "type": "string"
-                },
-                "client_secret": {
-                    "type": "string"
-                },
-                "proxy": {
+                "kb_id": {
                     "type": "string"
                 },
-                "source_type": {
-                    "$ref": "#/definitions/consts.SourceType"
-                }
-            }
-        },
-        "github_com_chaitin_panda-wiki_api_share_v1.AuthGetResp": {
-            "type": "object",
-            "properties": {
-                "auth_type": {
-                    "$ref": "#/definitions/consts.AuthType"
-                },
-                "license_edition": {
-                    "$ref": "#/definitions/consts.LicenseEdition"
+                "public": {
+                    "description": "是否公开",
+                    "type": "boolean"
                 },
-                "source_type": {
-                    "$ref": "#/definitions/consts.SourceType"
+                "users": {
+                    "description": "用户权限",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pro.AuthUser"
+                    }
                 }
             }
         },
-        "github_com_chaitin_panda-wiki_api_share_v1.GitHubCallbackResp": {
-            "type": "object"
-        },
-        "github_com_chaitin_panda-wiki_domain.CheckModelReq": {
+        "pro.SubmitFeedbackReq": {
             "type": "object",
             "required": [
-                "base_url",
-                "model",
-                "provider",
-                "type"
+                "content",
+                "node_id"
             ],
             "properties": {
-                "api_header": {
-                    "type": "string"
-                },
-                "api_key": {
+                "content": {
                     "type": "string"
                 },
-                "api_version": {
-                    "description": "for azure openai",
+                "correction_suggestion": {
+                    "description": "修正建议",
                     "type": "string"
                 },
-                "base_url": {
+                "email": {
                     "type": "string"
                 },
-                "model": {
+                "node_id": {
                     "type": "string"
-                },
-                "parameters": {
-                    "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.ModelParam"
-                },
-                "provider": {
-                    "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.ModelProvider"
-                },
-                "type": {
-                    "enum": [
-                        "chat",
-                        "embedding",
-                        "rerank",
-                        "analysis",
-                        "analysis-vl"
-                    ],
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/domain.ModelType"
-                        }
-                    ]
                 }
             }
         },
-        "github_com_chaitin_panda-wiki_domain.CheckModelResp": {
+        "pro.SyncReq": {
             "type": "object",
+            "required": [
+                "kb_id"
+            ],
             "properties": {
-                "content": {
-                    "type": "string"
-                },
-                "error": {
+                "kb_id": {
                     "type": "string"
                 }
             }
         },
-        "github_com_chaitin_panda-wiki_domain.ModelListItem": {
+        "pro.TokenItem": {
             "type": "object",
             "properties": {
-                "api_header": {
+                "created_at": {
                     "type": "string"
                 },
-                "api_key": {
+                "id": {
                     "type": "string"
                 },
-                "api_version": {
-                    "description": "for azure openai",
+                "kb_id": {
                     "type": "string"
                 },
-                "base_url": {
+                "name": {
                     "type": "string"
                 },
-                "completion_tokens": {
-                    "type": "integer"
+                "permission": {
+                    "$ref": "#/definitions/consts.UserKBPermission"
                 },
-                "id": {
+                "token": {
+                    "description": "完整 token，前端负责遮罩显示",
                     "type": "string"
                 },
-                "is_active": {
-                    "type": "boolean"
-                },
-                "model": {
+                "user_id": {
                     "type": "string"
-                },
-                "parameters": {
-                    "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.ModelParam"
-                },
-                "prompt_tokens": {
-                    "type": "integer"
-                },
-                "provider": {
-                    "$ref": "#/definitions/github_com_chaitin_panda-wiki_domain.ModelProvider"
-                },
-                "total_tokens": {
-                    "type": "integer"
-                },
-                "type": {
-                    "$ref": "#/definitions/domain.ModelType"
                 }
             }
         },
-        "github_com_chaitin_panda-wiki_domain.ModelParam": {
+        "pro.UpdateBlockWordsReq": {
             "type": "object",
+            "required": [
+                "kb_id"
+            ],
             "properties": {
-                "context_window": {
-                    "type": "integer"
-                },
-                "max_tokens": {
-                    "type": "integer"
-                },
-                "r1_enabled": {
-                    "type": "boolean"
-                },
-                "support_computer_use": {
-                    "type": "boolean"
-                },
-                "support_images": {
-                    "type": "boolean"
-                },
-                "support_prompt_cache": {
-                    "type": "boolean"
+                "kb_id": {
+                    "type": "string"
                 },
-                "temperature": {
-                    "type": "number"
+                "words": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "github_com_chaitin_panda-wiki_domain.ModelProvider": {
-            "type": "string",
-            "enum": [
-                "BaiZhiCloud"
+        "pro.UpdateContributeReq": {
+            "type": "object",
+            "required": [
+                "id"
             ],
-            "x-enum-varnames": [
-                "ModelProviderBrandBaiZhiCloud"
-            ]
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
         },
-        "gocap.ChallengeData": {
+        "pro.UpdatePromptReq": {
             "type": "object",
+            "required": [
+                "kb_id"
+            ],
             "properties": {
-                "challenge": {
-                    "$ref": "#/definitions/gocap.ChallengeItem"
-                },
-                "expires": {
-                    "description": "过期时间,毫秒级时间戳",
-                    "type": "integer"
+                "content": {
+                    "type": "string"
                 },
-                "token": {
-                    "description": "质询令牌",
+                "kb_id": {
                     "type": "string"
                 }
             }
         },
-        "gocap.ChallengeItem": {
+        "pro.UpdateReq": {
             "type": "object",
+            "required": [
+                "id",
+                "kb_id"
+            ],
             "properties": {
-                "c": {
-                    "description": "质询数量",
-                    "type": "integer"
+                "auth_ids": {
+                    "description": "成员 ID 列表",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 },
-                "d": {
-                    "description": "质询难度",
+                "id": {
                     "type": "integer"
                 },
-                "s": {
-                    "description": "质询大小",
-                    "type": "integer"
+                "kb_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
                 }
             }
         },
-        "gocap.VerificationResult": {
+        "pro.UpdateTokenReq": {
             "type": "object",
+            "required": [
+                "id",
+                "kb_id"
+            ],
             "properties": {
-                "expires": {
-                    "description": "过期时间,毫秒级时间戳",
-                    "type": "integer"
-                },
-                "message": {
+                "id": {
                     "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
+                "kb_id": {
+                    "type": "string"
                 },
-                "token": {
-                    "description": "验证令牌",
+                "name": {
                     "type": "string"
+                },
+                "permission": {
+                    "$ref": "#/definitions/consts.UserKBPermission"
                 }
             }
         },
@@ -8209,6 +16516,62 @@ const docTemplate = `{
                 }
             }
         },
+        "share.SubmitContributeReq": {
+            "type": "object",
+            "required": [
+                "content",
+                "kb_id",
+                "type"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "kb_id": {
+                    "type": "string"
+                },
+                "meta": {
+                    "$ref": "#/definitions/domain.NodeMeta"
+                },
+                "name": {
+                    "description": "新增时的标题",
+                    "type": "string"
+                },
+                "node_id": {
+                    "description": "编辑时需要",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "提交说明",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "add 或 edit",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/consts.ContributeType"
+                        }
+                    ]
+                }
+            }
+        },
+        "share.UploadFileResp": {
+            "type": "object",
+            "properties": {
+                "filename": {
+                    "description": "原始文件名",
+                    "type": "string"
+                },
+                "key": {
+                    "description": "文件在 MinIO 中的路径，如：kb_id/uuid.ext",
+                    "type": "string"
+                },
+                "size": {
+                    "description": "文件大小",
+                    "type": "integer"
+                }
+            }
+        },
         "v1.AuthGitHubReq": {
             "type": "object",
             "properties": {
@@ -8309,6 +16672,26 @@ const docTemplate = `{
                 }
             }
         },
+        "v1.ContainerLogsResp": {
+            "type": "object",
+            "properties": {
+                "has_more": {
+                    "description": "是否还有更多日志",
+                    "type": "boolean"
+                },
+                "logs": {
+                    "description": "日志条目",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.LogEntry"
+                    }
+                },
+                "total": {
+                    "description": "总日志数",
+                    "type": "integer"
+                }
+            }
+        },
         "v1.ConversationListItems": {
             "type": "object",
             "properties": {
@@ -8494,6 +16877,55 @@ const docTemplate = `{
                 }
             }
         },
+        "v1.CuratedAnswerList": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.CuratedAnswer"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.DocumentInfo": {
+            "type": "object",
+            "properties": {
+                "current_count": {
+                    "description": "当前文档数",
+                    "type": "integer"
+                },
+                "learning_failed": {
+                    "description": "学习失败数量",
+                    "type": "integer"
+                },
+                "learning_succeeded": {
+                    "description": "学习成功数量",
+                    "type": "integer"
+                },
+                "new_in_24h": {
+                    "description": "24h新增文档数",
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.EvalRunList": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.EvalRun"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
         "v1.FeishuSetting": {
             "type": "object",
             "properties": {
@@ -8519,6 +16951,34 @@ const docTemplate = `{
                 }
             }
         },
+        "v1.ImportJobItemList": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ImportJobItem"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.ImportJobList": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ImportJob"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
         "v1.KBUserInviteReq": {
             "type": "object",
             "required": [
@@ -8592,6 +17052,101 @@ const docTemplate = `{
                 }
             }
         },
+        "v1.LearningInfo": {
+            "type": "object",
+            "properties": {
+                "basic_failed": {
+                    "description": "基础处理失败数",
+                    "type": "integer"
+                },
+                "basic_failed_docs": {
+                    "description": "基础处理失败文档",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_chaitin_panda-wiki_api_system_v1.FailedDoc"
+                    }
+                },
+                "basic_processing": {
+                    "description": "基础处理队列进度",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_chaitin_panda-wiki_api_system_v1.QueueProgress"
+                        }
+                    ]
+                },
+                "enhance_failed": {
+                    "description": "增强处理失败数",
+                    "type": "integer"
+                },
+                "enhance_failed_docs": {
+                    "description": "增强处理失败文档",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_chaitin_panda-wiki_api_system_v1.FailedDoc"
+                    }
+                },
+                "enhance_processing": {
+                    "description": "增强处理队列进度",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_chaitin_panda-wiki_api_system_v1.QueueProgress"
+                        }
+                    ]
+                }
+            }
+        },
+        "v1.LicenseResp": {
+            "type": "object",
+            "properties": {
+                "edition": {
+                    "description": "授权版本：0=社区版，1=联创版，2=企业版",
+                    "type": "integer"
+                },
+                "expired_at": {
+                    "description": "授权到期时间",
+                    "type": "integer"
+                },
+                "started_at": {
+                    "description": "授权开始时间",
+                    "type": "integer"
+                },
+                "state": {
+                    "description": "授权状态",
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.LinkCheckJobList": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.LinkCheckJob"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.LogEntry": {
+            "type": "object",
+            "properties": {
+                "level": {
+                    "description": "日志级别 (info, warn, error, debug)",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "日志消息",
+                    "type": "string"
+                },
+                "timestamp": {
+                    "description": "时间戳",
+                    "type": "string"
+                }
+            }
+        },
         "v1.LoginReq": {
             "type": "object",
             "required": [
@@ -8636,6 +17191,9 @@ const docTemplate = `{
                 "editor_id": {
                     "type": "string"
                 },
+                "external_id": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "string"
                 },
@@ -8674,6 +17232,20 @@ const docTemplate = `{
                 }
             }
         },
+        "v1.NodeImportJobList": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.NodeImportJob"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
         "v1.NodePermissionEditReq": {
             "type": "object",
             "required": [
@@ -8846,6 +17418,13 @@ const docTemplate = `{
                 "status": {
                     "$ref": "#/definitions/domain.NodeStatus"
                 },
+                "translations": {
+                    "description": "其他语言版本，仅在存在已发布的翻译时返回",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.NodeTranslationLink"
+                    }
+                },
                 "type": {
                     "$ref": "#/definitions/domain.NodeType"
                 },
@@ -8871,6 +17450,45 @@ const docTemplate = `{
                 }
             }
         },
+        "v1.SummaryJobList": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.SummaryJob"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.SystemInfo": {
+            "type": "object",
+            "properties": {
+                "components": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_chaitin_panda-wiki_api_system_v1.ComponentStatus"
+                    }
+                }
+            }
+        },
+        "v1.SystemResp": {
+            "type": "object",
+            "properties": {
+                "document": {
+                    "$ref": "#/definitions/v1.DocumentInfo"
+                },
+                "learning": {
+                    "$ref": "#/definitions/v1.LearningInfo"
+                },
+                "system": {
+                    "$ref": "#/definitions/v1.SystemInfo"
+                }
+            }
+        },
         "v1.UserInfoResp": {
             "type": "object",
             "properties": {
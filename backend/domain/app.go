@@ -189,6 +189,8 @@ type StatsSetting struct {
 type ConversationSetting struct {
 	CopyrightInfo        string `json:"copyright_info"`
 	CopyrightHideEnabled bool   `json:"copyright_hide_enabled"`
+	// 回答结束后生成追问建议
+	FollowUpSuggestionsEnabled bool `json:"follow_up_suggestions_enabled"`
 }
 
 type WebAppLandingTheme struct {
@@ -383,6 +385,8 @@ type ContributeSettings struct {
 type OpenAIAPIBotSettings struct {
 	IsEnabled bool   `json:"is_enabled"`
 	SecretKey string `json:"secret_key"`
+	// 在响应的 suggestions 扩展字段中返回追问建议
+	FollowUpSuggestionsEnabled bool `json:"follow_up_suggestions_enabled"`
 }
 
 type WebAppCustomSettings struct {
@@ -448,6 +452,8 @@ type WidgetBotSettings struct {
 	Disclaimer           string   `json:"disclaimer,omitempty"`
 	CopyrightInfo        string   `json:"copyright_info,omitempty"`
 	CopyrightHideEnabled bool     `json:"copyright_hide_enabled,omitempty"`
+	// 回答结束后生成追问建议
+	FollowUpSuggestionsEnabled bool `json:"follow_up_suggestions_enabled,omitempty"`
 }
 
 type BrandGroup struct {
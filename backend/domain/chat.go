@@ -2,26 +2,92 @@ package domain
 
 import (
 	"database/sql/driver"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	ChatMaxImages            = 4
+	ChatImageMaxBytes        = 10 << 20
+	ChatImageDefaultQuestion = "请结合图片内容回答。" // used when only images are sent
 )
 
 type ChatRequest struct {
 	ConversationID string  `json:"conversation_id"`
-	Message        string  `json:"message" validate:"required"`
+	Message        string  `json:"message" validate:"required_without=Images"`
 	Nonce          string  `json:"nonce"`
 	AppType        AppType `json:"app_type" validate:"required,oneof=1 2"`
 	CaptchaToken   string  `json:"captcha_token"`
+	// uploaded image paths (/static-file/...), image urls or data urls
+	Images []string `json:"images" validate:"max=4"`
+	// message the question follows, selects the branch to continue; defaults to the latest message
+	ParentMessageID string `json:"parent_message_id"`
 
 	KBID  string `json:"-" validate:"required"`
 	AppID string `json:"-"`
 
-	ModelInfo *Model `json:"-"`
+	RegenerateMessageID string `json:"-"` // answer this existing question again
+	EditMessageID       string `json:"-"` // ask the question as a sibling of this one
+	UserMessageID       string `json:"-"` // question being answered, set by chat usecase
+
+	ModelInfo     *Model         `json:"-"`
+	CuratedAnswer *CuratedAnswer `json:"-"` // preferred document of the prompt, set by chat usecase
 
 	RemoteIP string           `json:"-"`
 	Info     ConversationInfo `json:"-"`
 	Prompt   string           `json:"-"`
+
+	ImageData        []*ChatImage `json:"-"` // loaded images, set by chat usecase
+	ImageDescription string       `json:"-"` // description of the images by the vision model
+
+	// OpenAI API: turns sent by the client, used instead of the stored history when not nil
+	History []*schema.Message `json:"-"`
+	// OpenAI API: system prompt sent by the client, appended to the knowledge base prompt
+	ClientSystemPrompt string `json:"-"`
+	// OpenAI API: user field of the request, all requests of the user share one conversation
+	ConversationUser string `json:"-"`
+}
+
+type ChatRegenerateRequest struct {
+	ConversationID string  `json:"conversation_id" validate:"required"`
+	Nonce          string  `json:"nonce" validate:"required"`
+	MessageID      string  `json:"message_id" validate:"required"` // user question to answer again
+	AppType        AppType `json:"app_type" validate:"required,oneof=1 2"`
+	CaptchaToken   string  `json:"captcha_token"`
+
+	KBID string `json:"-" validate:"required"`
+}
+
+type ChatEditRequest struct {
+	ConversationID string  `json:"conversation_id" validate:"required"`
+	Nonce          string  `json:"nonce" validate:"required"`
+	MessageID      string  `json:"message_id" validate:"required"` // user question being edited
+	Message        string  `json:"message" validate:"required"`
+	AppType        AppType `json:"app_type" validate:"required,oneof=1 2"`
+	CaptchaToken   string  `json:"captcha_token"`
+
+	KBID string `json:"-" validate:"required"`
+}
+
+type ChatStopRequest struct {
+	ConversationID string `json:"conversation_id" validate:"required"`
+	Nonce          string `json:"nonce" validate:"required"`
+	MessageID      string `json:"message_id" validate:"required"`
+
+	KBID string `json:"-" validate:"required"`
+}
+
+type ChatResumeRequest struct {
+	ConversationID string `query:"conversation_id" validate:"required"`
+	Nonce          string `query:"nonce" validate:"required"`
+	MessageID      string `query:"message_id" validate:"required"`
+	LastEventID    string `query:"last_event_id"` // Last-Event-ID header takes precedence
+
+	KBID string `json:"-" validate:"required"`
 }
 
 type ChatRagOnlyRequest struct {
@@ -90,3 +156,14 @@ type ChatSearchReq struct {
 type ChatSearchResp struct {
 	NodeResult []NodeContentChunkSSE `json:"node_result"`
 }
+
+// ChatImage is an image attached to a question
+type ChatImage struct {
+	Ref      string // persisted reference: static file path or http url
+	MIMEType string
+	Data     []byte
+}
+
+func (i *ChatImage) DataURL() string {
+	return fmt.Sprintf("data:%s;base64,%s", i.MIMEType, base64.StdEncoding.EncodeToString(i.Data))
+}
@@ -0,0 +1,245 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+type ChunkStrategy string
+
+const (
+	ChunkStrategyDefault ChunkStrategy = "default" // 由 RAG 服务分块
+	ChunkStrategyHeading ChunkStrategy = "heading" // 按标题层级分块
+)
+
+const (
+	DefaultChunkSize    = 1000
+	DefaultChunkOverlap = 100
+	MaxChunkSize        = 8000
+)
+
+// ChunkSetting is the chunking strategy of a kb, size and overlap are counted in characters
+type ChunkSetting struct {
+	Strategy ChunkStrategy `json:"strategy"`
+	Size     int           `json:"size"`
+	Overlap  int           `json:"overlap"`
+}
+
+func DefaultChunkSetting() *ChunkSetting {
+	return &ChunkSetting{
+		Strategy: ChunkStrategyDefault,
+		Size:     DefaultChunkSize,
+		Overlap:  DefaultChunkOverlap,
+	}
+}
+
+func (s *ChunkSetting) Validate() error {
+	switch s.Strategy {
+	case ChunkStrategyDefault, ChunkStrategyHeading:
+	default:
+		return fmt.Errorf("unsupported chunk strategy %s", s.Strategy)
+	}
+	if s.Size < 100 || s.Size > MaxChunkSize {
+		return fmt.Errorf("chunk size must be between 100 and %d", MaxChunkSize)
+	}
+	if s.Overlap < 0 || s.Overlap >= s.Size/2 {
+		return fmt.Errorf("chunk overlap must be less than half of the chunk size")
+	}
+	return nil
+}
+
+type GetChunkSettingReq struct {
+	KBID string `json:"kb_id" query:"kb_id" validate:"required"`
+}
+
+type UpdateChunkSettingReq struct {
+	KBID string `json:"kb_id" validate:"required"`
+	ChunkSetting
+}
+
+var (
+	markdownHeadingRegex = regexp.MustCompile(`^ {0,3}(#{1,6})\s+(.*?)\s*#*\s*$`)
+	markdownFenceRegex   = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})")
+)
+
+type markdownBlockType int
+
+const (
+	markdownBlockText markdownBlockType = iota
+	markdownBlockCode
+	markdownBlockTable
+)
+
+type markdownBlock struct {
+	typ     markdownBlockType
+	content string
+}
+
+// atomic blocks are never split or used as overlap
+func (b markdownBlock) atomic() bool {
+	return b.typ != markdownBlockText
+}
+
+type markdownSection struct {
+	headings []string
+	blocks   []markdownBlock
+}
+
+// SplitMarkdownByHeading splits markdown into chunks along its heading hierarchy,
+// code blocks and tables are kept intact and every chunk is prefixed with the breadcrumb of its section
+func SplitMarkdownByHeading(markdown string, path []string, setting *ChunkSetting) []string {
+	chunks := make([]string, 0)
+	for _, section := range parseMarkdownSections(markdown) {
+		breadcrumb := strings.Join(append(append([]string{}, path...), section.headings...), " > ")
+		prefix := ""
+		if breadcrumb != "" {
+			prefix = breadcrumb + "\n\n"
+		}
+		for _, body := range packMarkdownBlocks(section.blocks, setting) {
+			chunks = append(chunks, prefix+body)
+		}
+	}
+	return chunks
+}
+
+func parseMarkdownSections(markdown string) []*markdownSection {
+	lines := strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n")
+	sections := []*markdownSection{{}}
+	headings := make([]string, 0, 6)
+	levels := make([]int, 0, 6)
+	current := sections[0]
+
+	var (
+		buf   []string
+		typ   markdownBlockType
+		fence string
+	)
+	flush := func() {
+		content := strings.Trim(strings.Join(buf, "\n"), "\n")
+		if strings.TrimSpace(content) != "" {
+			current.blocks = append(current.blocks, markdownBlock{typ: typ, content: content})
+		}
+		buf, typ = nil, markdownBlockText
+	}
+	for _, line := range lines {
+		if fence != "" {
+			buf = append(buf, line)
+			if strings.HasPrefix(strings.TrimSpace(line), fence) {
+				fence = ""
+				flush()
+			}
+			continue
+		}
+		if m := markdownFenceRegex.FindStringSubmatch(line); m != nil {
+			flush()
+			fence = m[1]
+			typ = markdownBlockCode
+			buf = append(buf, line)
+			continue
+		}
+		if m := markdownHeadingRegex.FindStringSubmatch(line); m != nil {
+			flush()
+			level := len(m[1])
+			for len(levels) > 0 && levels[len(levels)-1] >= level {
+				levels = levels[:len(levels)-1]
+				headings = headings[:len(headings)-1]
+			}
+			levels = append(levels, level)
+			headings = append(headings, m[2])
+			current = &markdownSection{headings: append([]string{}, headings...)}
+			sections = append(sections, current)
+			continue
+		}
+		isTableLine := strings.HasPrefix(strings.TrimSpace(line), "|")
+		switch {
+		case strings.TrimSpace(line) == "":
+			flush()
+		case isTableLine && typ != markdownBlockTable:
+			flush()
+			typ = markdownBlockTable
+			buf = append(buf, line)
+		case !isTableLine && typ == markdownBlockTable:
+			flush()
+			buf = append(buf, line)
+		default:
+			buf = append(buf, line)
+		}
+	}
+	flush()
+	return sections
+}
+
+// packMarkdownBlocks packs the blocks of a section into chunks of at most setting.Size characters,
+// only oversized code blocks and tables exceed the size
+func packMarkdownBlocks(blocks []markdownBlock, setting *ChunkSetting) []string {
+	pieces := make([]markdownBlock, 0, len(blocks))
+	for _, block := range blocks {
+		if block.atomic() || utf8.RuneCountInString(block.content) <= setting.Size {
+			pieces = append(pieces, block)
+			continue
+		}
+		for _, text := range splitText(block.content, setting.Size-setting.Overlap) {
+			pieces = append(pieces, markdownBlock{typ: markdownBlockText, content: text})
+		}
+	}
+
+	chunks := make([]string, 0)
+	var (
+		current []string
+		size    int
+		last    *markdownBlock
+	)
+	for i, piece := range pieces {
+		pieceSize := utf8.RuneCountInString(piece.content)
+		if len(current) > 0 && size+pieceSize+2 > setting.Size {
+			chunks = append(chunks, strings.Join(current, "\n\n"))
+			current, size = nil, 0
+			if last != nil && !last.atomic() && setting.Overlap > 0 && !piece.atomic() {
+				overlap := tailRunes(last.content, setting.Overlap)
+				current, size = []string{overlap}, utf8.RuneCountInString(overlap)
+			}
+		}
+		current = append(current, piece.content)
+		size += pieceSize + 2
+		last = &pieces[i]
+	}
+	if len(current) > 0 {
+		chunks = append(chunks, strings.Join(current, "\n\n"))
+	}
+	return chunks
+}
+
+// splitText splits text into parts of at most size characters, preferring line and sentence boundaries
+func splitText(text string, size int) []string {
+	parts := make([]string, 0)
+	runes := []rune(text)
+	for len(runes) > size {
+		cut := size
+		for i := size; i > size/2; i-- {
+			if isTextBoundary(runes[i-1]) {
+				cut = i
+				break
+			}
+		}
+		parts = append(parts, strings.TrimSpace(string(runes[:cut])))
+		runes = runes[cut:]
+	}
+	if rest := strings.TrimSpace(string(runes)); rest != "" {
+		parts = append(parts, rest)
+	}
+	return parts
+}
+
+func isTextBoundary(r rune) bool {
+	return strings.ContainsRune("\n。！？；.!?;", r)
+}
+
+func tailRunes(text string, n int) string {
+	runes := []rune(text)
+	if len(runes) <= n {
+		return text
+	}
+	return strings.TrimSpace(string(runes[len(runes)-n:]))
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitMarkdownByHeading(t *testing.T) {
+	markdown := "intro\n\n# Install\n\n## Linux\n\nrun this:\n\n```bash\n# not a heading\necho hi\n```\n\n## Windows\n\n| a | b |\n| - | - |\n| 1 | 2 |\n\n# Usage\n\nuse it"
+	setting := &ChunkSetting{Strategy: ChunkStrategyHeading, Size: 200, Overlap: 20}
+
+	assert.Equal(t, []string{
+		"docs > Guide\n\nintro",
+		"docs > Guide > Install > Linux\n\nrun this:\n\n```bash\n# not a heading\necho hi\n```",
+		"docs > Guide > Install > Windows\n\n| a | b |\n| - | - |\n| 1 | 2 |",
+		"docs > Guide > Usage\n\nuse it",
+	}, SplitMarkdownByHeading(markdown, []string{"docs", "Guide"}, setting))
+}
+
+func TestSplitMarkdownByHeadingSizeAndOverlap(t *testing.T) {
+	setting := &ChunkSetting{Strategy: ChunkStrategyHeading, Size: 100, Overlap: 10}
+	paragraph := strings.Repeat("这是一个句子。", 30)
+	code := "```\n" + strings.Repeat("x", 150) + "\n```"
+
+	chunks := SplitMarkdownByHeading("# T\n\n"+paragraph+"\n\n"+code, nil, setting)
+	assert.Len(t, chunks, 4)
+	for _, chunk := range chunks[:3] {
+		assert.True(t, strings.HasPrefix(chunk, "T\n\n"))
+		assert.LessOrEqual(t, len([]rune(strings.TrimPrefix(chunk, "T\n\n"))), setting.Size)
+	}
+	// the second part starts with the tail of the first one
+	assert.True(t, strings.HasPrefix(strings.TrimPrefix(chunks[1], "T\n\n"), "句子。这是一个句子。\n\n"))
+	// code blocks are kept intact even when oversized
+	assert.Equal(t, "T\n\n"+code, chunks[3])
+}
+
+func TestChunkSettingValidate(t *testing.T) {
+	assert.NoError(t, DefaultChunkSetting().Validate())
+	assert.Error(t, (&ChunkSetting{Strategy: "unknown", Size: 1000}).Validate())
+	assert.Error(t, (&ChunkSetting{Strategy: ChunkStrategyHeading, Size: 10}).Validate())
+	assert.Error(t, (&ChunkSetting{Strategy: ChunkStrategyHeading, Size: 1000, Overlap: 600}).Validate())
+}
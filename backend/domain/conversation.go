@@ -4,9 +4,11 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
+	"slices"
 	"time"
 
 	"github.com/cloudwego/eino/schema"
+	"github.com/lib/pq"
 )
 
 type Conversation struct {
@@ -21,6 +23,10 @@ type Conversation struct {
 	RemoteIP  string           `json:"remote_ip"`
 	Info      ConversationInfo `json:"info" gorm:"type:jsonb"`
 	CreatedAt time.Time        `json:"created_at"`
+
+	// rolling summary of earlier turns that no longer fit into the model context
+	Summary          string `json:"summary"`
+	SummaryMessageID string `json:"summary_message_id"` // last message folded into summary
 }
 
 type ConversationMessage struct {
@@ -32,6 +38,10 @@ type ConversationMessage struct {
 	Role    schema.RoleType `json:"role"`
 	Content string          `json:"content"`
 
+	// images attached to the question and their description by the vision model
+	Images           pq.StringArray `json:"images" gorm:"type:text[];not null;default:{}"`
+	ImageDescription string         `json:"image_description"`
+
 	// model
 	Provider         ModelProvider `json:"provider"`
 	Model            string        `json:"model"`
@@ -46,10 +56,58 @@ type ConversationMessage struct {
 	// feedbackinfo
 	Info FeedBackInfo `json:"info" gorm:"column:info;type:jsonb"`
 
-	// parent_id
+	// parent_id: answer -> question, question -> previous answer
 	ParentID string `json:"parent_id"`
 }
 
+// ConversationMessageTreeNode is a message with all of its branches
+type ConversationMessageTreeNode struct {
+	*ConversationMessage
+	Children []*ConversationMessageTreeNode `json:"children"`
+}
+
+// ConversationBranch returns the messages from the root down to leafID by following ParentID
+func ConversationBranch(messages []*ConversationMessage, leafID string) []*ConversationMessage {
+	messageMap := make(map[string]*ConversationMessage, len(messages))
+	for _, message := range messages {
+		messageMap[message.ID] = message
+	}
+	branch := make([]*ConversationMessage, 0)
+	visited := make(map[string]bool)
+	for id := leafID; id != "" && !visited[id]; {
+		message, ok := messageMap[id]
+		if !ok {
+			break
+		}
+		visited[id] = true
+		branch = append(branch, message)
+		id = message.ParentID
+	}
+	slices.Reverse(branch)
+	return branch
+}
+
+// BuildConversationMessageTree groups messages (ordered by created_at) into sibling trees
+func BuildConversationMessageTree(messages []*ConversationMessage) []*ConversationMessageTreeNode {
+	nodeMap := make(map[string]*ConversationMessageTreeNode, len(messages))
+	for _, message := range messages {
+		nodeMap[message.ID] = &ConversationMessageTreeNode{
+			ConversationMessage: message,
+			Children:            make([]*ConversationMessageTreeNode, 0),
+		}
+	}
+	roots := make([]*ConversationMessageTreeNode, 0)
+	for _, message := range messages {
+		node := nodeMap[message.ID]
+		if parent, ok := nodeMap[message.ParentID]; ok && message.ParentID != message.ID {
+			parent.Children = append(parent.Children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+	return roots
+}
+
 type FeedBackInfo struct {
 	Score           ScoreType    `json:"score"`
 	FeedbackType    FeedbackType `json:"feedback_type"`
@@ -110,8 +168,9 @@ type ConversationDetailResp struct {
 	Subject  string `json:"subject"`
 	RemoteIP string `json:"remote_ip"`
 
-	Messages   []*ConversationMessage   `json:"messages" gorm:"-"`
-	References []*ConversationReference `json:"references" gorm:"-"`
+	Messages    []*ConversationMessage         `json:"messages" gorm:"-"`
+	MessageTree []*ConversationMessageTreeNode `json:"message_tree" gorm:"-"` // every branch of the conversation
+	References  []*ConversationReference       `json:"references" gorm:"-"`
 
 	IPAddress *IPAddress `json:"ip_address" gorm:"-"`
 
@@ -151,7 +210,10 @@ type ShareConversationDetailResp struct {
 }
 
 type ShareConversationMessage struct {
+	ID        string          `json:"id"`
+	ParentID  string          `json:"parent_id"`
 	Role      schema.RoleType `json:"role"`
 	Content   string          `json:"content"`
+	Images    []string        `json:"images,omitempty"`
 	CreatedAt time.Time       `json:"created_at"`
 }
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testBranchMessages() []*ConversationMessage {
+	return []*ConversationMessage{
+		{ID: "q1", Role: schema.User},
+		{ID: "a1", Role: schema.Assistant, ParentID: "q1"},
+		{ID: "q2", Role: schema.User, ParentID: "a1"},
+		{ID: "a2", Role: schema.Assistant, ParentID: "q2"},
+		{ID: "a2-regenerated", Role: schema.Assistant, ParentID: "q2"},
+		{ID: "q2-edited", Role: schema.User, ParentID: "a1"},
+		{ID: "a3", Role: schema.Assistant, ParentID: "q2-edited"},
+	}
+}
+
+func messageIDs(messages []*ConversationMessage) []string {
+	ids := make([]string, 0, len(messages))
+	for _, message := range messages {
+		ids = append(ids, message.ID)
+	}
+	return ids
+}
+
+func TestConversationBranch(t *testing.T) {
+	messages := testBranchMessages()
+
+	assert.Equal(t, []string{"q1", "a1", "q2", "a2-regenerated"}, messageIDs(ConversationBranch(messages, "a2-regenerated")))
+	assert.Equal(t, []string{"q1", "a1", "q2-edited"}, messageIDs(ConversationBranch(messages, "q2-edited")))
+	assert.Empty(t, ConversationBranch(messages, "missing"))
+}
+
+func TestConversationBranch_Cycle(t *testing.T) {
+	messages := []*ConversationMessage{
+		{ID: "a", ParentID: "b"},
+		{ID: "b", ParentID: "a"},
+	}
+	assert.Equal(t, []string{"a", "b"}, messageIDs(ConversationBranch(messages, "b")))
+}
+
+func TestBuildConversationMessageTree(t *testing.T) {
+	roots := BuildConversationMessageTree(testBranchMessages())
+	require.Len(t, roots, 1)
+	assert.Equal(t, "q1", roots[0].ID)
+
+	a1 := roots[0].Children[0]
+	require.Len(t, a1.Children, 2)
+	assert.Equal(t, "q2", a1.Children[0].ID)
+	assert.Equal(t, "q2-edited", a1.Children[1].ID)
+	assert.Equal(t, []string{"a2", "a2-regenerated"}, messageIDs([]*ConversationMessage{
+		a1.Children[0].Children[0].ConversationMessage,
+		a1.Children[0].Children[1].ConversationMessage,
+	}))
+}
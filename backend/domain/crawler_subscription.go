@@ -0,0 +1,199 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/chaitin/panda-wiki/consts"
+)
+
+type CrawlerSyncStatus string
+
+const (
+	CrawlerSyncStatusIdle      CrawlerSyncStatus = "idle"
+	CrawlerSyncStatusPending   CrawlerSyncStatus = "pending"
+	CrawlerSyncStatusRunning   CrawlerSyncStatus = "running"
+	CrawlerSyncStatusCompleted CrawlerSyncStatus = "completed"
+	CrawlerSyncStatusFailed    CrawlerSyncStatus = "failed"
+)
+
+// CrawlerSyncPolicy decides what happens to the nodes changed by a sync
+type CrawlerSyncPolicy string
+
+const (
+	// changed nodes are left unpublished for review
+	CrawlerSyncPolicyDraft CrawlerSyncPolicy = "draft"
+	// changed nodes are published as a release after each sync
+	CrawlerSyncPolicyPublish CrawlerSyncPolicy = "publish"
+)
+
+const (
+	DefaultCrawlerSyncIntervalHours = 24
+	// a sync running longer than this is considered lost, e.g. by a restart of the consumer, and can be queued again
+	CrawlerSyncStaleAfter = 6 * time.Hour
+)
+
+// CrawlerSubscription re-syncs a url, rss feed or sitemap into a kb on a schedule
+type CrawlerSubscription struct {
+	ID   string `json:"id" gorm:"primaryKey"`
+	KBID string `json:"kb_id" gorm:"index"`
+	// url, rss or sitemap
+	Source consts.CrawlerSource `json:"source"`
+	URL    string               `json:"url"`
+	// node the synced documents are placed under, empty for the root of the kb
+	ParentID string            `json:"parent_id"`
+	Policy   CrawlerSyncPolicy `json:"policy"`
+	// delete the nodes of items removed from the source, they are only marked removed otherwise
+	DeleteRemoved bool `json:"delete_removed"`
+	IntervalHours int  `json:"interval_hours"`
+	Enabled       bool `json:"enabled"`
+	// scheduled syncs create and publish nodes on behalf of the user who set up the subscription
+	CreatorID string `json:"creator_id"`
+	MaxNode   int    `json:"-"`
+
+	// validators and content hash of the feed, an unchanged feed is not listed again
+	ETag         string `json:"-" gorm:"column:etag"`
+	LastModified string `json:"-"`
+	ContentHash  string `json:"-"`
+
+	Status     CrawlerSyncStatus `json:"status"`
+	Error      string            `json:"error"`
+	Result     CrawlerSyncResult `json:"result" gorm:"type:jsonb"`
+	StartedAt  *time.Time        `json:"started_at"`
+	SyncedAt   *time.Time        `json:"synced_at"`
+	NextSyncAt time.Time         `json:"next_sync_at"`
+	CreatedAt  time.Time         `json:"created_at"`
+	UpdatedAt  time.Time         `json:"updated_at"`
+}
+
+func (CrawlerSubscription) TableName() string {
+	return "crawler_subscriptions"
+}
+
+// ScheduleNext sets the time of the next scheduled sync counted from now
+func (s *CrawlerSubscription) ScheduleNext(now time.Time) {
+	interval := s.IntervalHours
+	if interval <= 0 {
+		interval = DefaultCrawlerSyncIntervalHours
+	}
+	s.NextSyncAt = now.Add(time.Duration(interval) * time.Hour)
+}
+
+// CrawlerSyncResult counts the items handled by the last sync, skipped items point to private addresses and are
+// neither synced nor removed
+type CrawlerSyncResult struct {
+	// the feed did not change since the last sync, nothing was listed
+	NotModified bool     `json:"not_modified"`
+	Created     int      `json:"created"`
+	Updated     int      `json:"updated"`
+	Unchanged   int      `json:"unchanged"`
+	Skipped     int      `json:"skipped"`
+	Removed     int      `json:"removed"`
+	Failed      int      `json:"failed"`
+	Errors      []string `json:"errors,omitempty"`
+	ReleaseID   string   `json:"release_id"`
+}
+
+// MaxCrawlerSyncErrors bounds the item errors kept in the result of a sync
+const MaxCrawlerSyncErrors = 20
+
+// AddError counts a failed item and keeps its error
+func (r *CrawlerSyncResult) AddError(docID string, err error) {
+	r.Failed++
+	if len(r.Errors) < MaxCrawlerSyncErrors {
+		r.Errors = append(r.Errors, fmt.Sprintf("%s: %s", docID, err))
+	}
+}
+
+func (r *CrawlerSyncResult) Scan(value any) error {
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New(fmt.Sprint("invalid crawler sync result value type:", value))
+	}
+	return json.Unmarshal(bytes, r)
+}
+
+func (r CrawlerSyncResult) Value() (driver.Value, error) {
+	return json.Marshal(r)
+}
+
+// NodeSource records where a crawled node came from, so that later syncs can detect changes of the item
+type NodeSource struct {
+	NodeID         string               `json:"node_id" gorm:"primaryKey"`
+	KBID           string               `json:"kb_id"`
+	SubscriptionID string               `json:"subscription_id"`
+	Source         consts.CrawlerSource `json:"source"`
+	// id of the item in the crawler, the url of the page for most sources
+	DocID        string `json:"doc_id"`
+	URL          string `json:"url"`
+	ETag         string `json:"etag" gorm:"column:etag"`
+	LastModified string `json:"last_modified"`
+	// sha256 of the exported markdown
+	ContentHash string `json:"content_hash"`
+	// the item is not listed by the source any more
+	Removed  bool      `json:"removed"`
+	SyncedAt time.Time `json:"synced_at"`
+}
+
+func (NodeSource) TableName() string {
+	return "node_sources"
+}
+
+// CrawlerDocURL returns the page url of a listed item, false when the id of the item is not a http url
+func CrawlerDocURL(docID string) (string, bool) {
+	u, err := url.Parse(docID)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", false
+	}
+	return u.String(), true
+}
+
+type CreateCrawlerSubscriptionReq struct {
+	KBID          string               `json:"kb_id" validate:"required"`
+	Source        consts.CrawlerSource `json:"source" validate:"required,oneof=url rss sitemap"`
+	URL           string               `json:"url" validate:"required,url"`
+	ParentID      string               `json:"parent_id"`
+	Policy        CrawlerSyncPolicy    `json:"policy" validate:"required,oneof=draft publish"`
+	DeleteRemoved bool                 `json:"delete_removed"`
+	// 0 for the default of a day
+	IntervalHours int `json:"interval_hours" validate:"min=0,max=720"`
+}
+
+type UpdateCrawlerSubscriptionReq struct {
+	KBID          string             `json:"kb_id" validate:"required"`
+	ID            string             `json:"id" validate:"required"`
+	Policy        *CrawlerSyncPolicy `json:"policy" validate:"omitempty,oneof=draft publish"`
+	DeleteRemoved *bool              `json:"delete_removed"`
+	IntervalHours *int               `json:"interval_hours" validate:"omitempty,min=0,max=720"`
+	Enabled       *bool              `json:"enabled"`
+}
+
+type CrawlerSubscriptionListReq struct {
+	KBID string `json:"kb_id" query:"kb_id" validate:"required"`
+}
+
+type DeleteCrawlerSubscriptionReq struct {
+	KBID string `json:"kb_id" query:"kb_id" validate:"required"`
+	ID   string `json:"id" query:"id" validate:"required"`
+	// also delete the nodes created by the subscription
+	DeleteNodes bool `json:"delete_nodes" query:"delete_nodes"`
+}
+
+type SyncCrawlerSubscriptionReq struct {
+	KBID string `json:"kb_id" validate:"required"`
+	ID   string `json:"id" validate:"required"`
+}
+
+type CrawlerSubscriptionNodesReq struct {
+	KBID string `json:"kb_id" query:"kb_id" validate:"required"`
+	ID   string `json:"id" query:"id" validate:"required"`
+}
+
+// CrawlerSyncTaskRequest is published to sync a crawler subscription in the consumer
+type CrawlerSyncTaskRequest struct {
+	SubscriptionID string `json:"subscription_id"`
+}
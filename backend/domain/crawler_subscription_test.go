@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCrawlerSubscriptionScheduleNext(t *testing.T) {
+	now := time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+
+	subscription := &CrawlerSubscription{}
+	subscription.ScheduleNext(now)
+	assert.Equal(t, now.Add(24*time.Hour), subscription.NextSyncAt)
+
+	subscription.IntervalHours = 6
+	subscription.ScheduleNext(now)
+	assert.Equal(t, now.Add(6*time.Hour), subscription.NextSyncAt)
+}
+
+func TestCrawlerSyncResultAddError(t *testing.T) {
+	var result CrawlerSyncResult
+	for i := 0; i < MaxCrawlerSyncErrors+5; i++ {
+		result.AddError(fmt.Sprintf("doc-%d", i), errors.New("export failed"))
+	}
+	assert.Equal(t, MaxCrawlerSyncErrors+5, result.Failed)
+	assert.Len(t, result.Errors, MaxCrawlerSyncErrors)
+	assert.Equal(t, "doc-0: export failed", result.Errors[0])
+}
+
+func TestCrawlerDocURL(t *testing.T) {
+	u, ok := CrawlerDocURL("https://example.com/docs/a?x=1")
+	assert.True(t, ok)
+	assert.Equal(t, "https://example.com/docs/a?x=1", u)
+
+	for _, docID := range []string{"", "8f2a1c", "/docs/a", "ftp://example.com/a", "https://"} {
+		_, ok := CrawlerDocURL(docID)
+		assert.False(t, ok, docID)
+	}
+}
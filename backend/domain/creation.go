@@ -1,6 +1,7 @@
 package domain
 
 type TextReq struct {
+	KBID   string `json:"kb_id" validate:"required"`
 	Text   string `json:"text" validate:"required"`
 	Action string `json:"action"` // action: improve, summary, extend, shorten, etc.
 }
@@ -13,6 +14,8 @@ const (
 )
 
 type CompleteReq struct {
+	KBID string `json:"kb_id" validate:"required"`
+
 	// For FIM (Fill in Middle) style completion
 	Prefix string `json:"prefix,omitempty"`
 	Suffix string `json:"suffix,omitempty"`
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	// questions at least this similar are answered with the curated answer directly
+	CuratedAnswerDirectScore = 0.92
+	// questions at least this similar get the curated answer as the preferred document
+	CuratedAnswerPromptScore = 0.80
+)
+
+// CuratedAnswer is a question and answer pair written by admins, it overrides the generated answer
+type CuratedAnswer struct {
+	ID              string          `json:"id" gorm:"primaryKey"`
+	KBID            string          `json:"kb_id" gorm:"index"`
+	Question        string          `json:"question"`
+	Answer          string          `json:"answer"`
+	Embedding       pq.Float32Array `json:"-" gorm:"type:real[]"`
+	EmbeddingModel  string          `json:"-"`
+	SourceMessageID string          `json:"source_message_id"`
+	HitCount        int64           `json:"hit_count"`
+	LastHitAt       *time.Time      `json:"last_hit_at"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+}
+
+func (CuratedAnswer) TableName() string {
+	return "curated_answers"
+}
+
+// CuratedAnswerMatch is the most similar curated answer of a question
+type CuratedAnswerMatch struct {
+	Answer *CuratedAnswer
+	Score  float64
+}
+
+// IsDirect reports whether the curated answer replaces the generated answer
+func (m *CuratedAnswerMatch) IsDirect() bool {
+	return m != nil && m.Score >= CuratedAnswerDirectScore
+}
+
+// FormatCuratedAnswer formats the curated answer as the preferred document of the prompt
+func FormatCuratedAnswer(answer *CuratedAnswer) string {
+	return fmt.Sprintf("<document>\nID: curated-%s\n标题: 管理员审核的标准答案（请优先参考）\n问题: %s\n内容:\n%s\n</document>", answer.ID, answer.Question, answer.Answer)
+}
+
+type CuratedAnswerListReq struct {
+	KBID    string `json:"kb_id" query:"kb_id" validate:"required"`
+	Keyword string `json:"keyword" query:"keyword"`
+	Pager
+}
+
+type CreateCuratedAnswerReq struct {
+	KBID     string `json:"kb_id" validate:"required"`
+	Question string `json:"question"`
+	Answer   string `json:"answer" validate:"required"`
+	// assistant message being corrected, its question is used when question is empty
+	MessageID string `json:"message_id"`
+}
+
+type UpdateCuratedAnswerReq struct {
+	ID       string  `json:"id" validate:"required"`
+	KBID     string  `json:"kb_id" validate:"required"`
+	Question *string `json:"question"`
+	Answer   *string `json:"answer"`
+}
+
+type DeleteCuratedAnswerReq struct {
+	ID   string `json:"id" query:"id" validate:"required"`
+	KBID string `json:"kb_id" query:"kb_id" validate:"required"`
+}
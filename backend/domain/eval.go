@@ -0,0 +1,311 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type EvalRunStatus string
+
+const (
+	EvalRunStatusPending   EvalRunStatus = "pending"
+	EvalRunStatusRunning   EvalRunStatus = "running"
+	EvalRunStatusCompleted EvalRunStatus = "completed"
+	EvalRunStatusFailed    EvalRunStatus = "failed"
+)
+
+// EvalSet is a golden question set of a kb
+type EvalSet struct {
+	ID          string    `json:"id" gorm:"primaryKey"`
+	KBID        string    `json:"kb_id" gorm:"index"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (EvalSet) TableName() string {
+	return "eval_sets"
+}
+
+// EvalQuestion is a question with the nodes expected to be retrieved and an optional reference answer
+type EvalQuestion struct {
+	ID              string         `json:"id" gorm:"primaryKey"`
+	SetID           string         `json:"set_id" gorm:"index"`
+	KBID            string         `json:"kb_id"`
+	Question        string         `json:"question"`
+	ExpectedNodeIDs pq.StringArray `json:"expected_node_ids" gorm:"type:text[]"`
+	ReferenceAnswer string         `json:"reference_answer"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+}
+
+func (EvalQuestion) TableName() string {
+	return "eval_questions"
+}
+
+// EvalRunConfig is the snapshot of the settings a run was executed with, runs are compared by it
+type EvalRunConfig struct {
+	Mode           string `json:"mode"`
+	ChatModel      string `json:"chat_model"`
+	EmbeddingModel string `json:"embedding_model"`
+	RerankModel    string `json:"rerank_model"`
+	Prompt         string `json:"prompt"`
+}
+
+func (c *EvalRunConfig) Scan(value any) error {
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New(fmt.Sprint("invalid eval run config value type:", value))
+	}
+	return json.Unmarshal(bytes, c)
+}
+
+func (c EvalRunConfig) Value() (driver.Value, error) {
+	return json.Marshal(c)
+}
+
+// EvalMetrics aggregates the results of a run, averages only cover the questions they apply to
+type EvalMetrics struct {
+	QuestionCount     int     `json:"question_count"`
+	ErrorCount        int     `json:"error_count"`
+	RetrievalCount    int     `json:"retrieval_count"`
+	HitRate           float64 `json:"hit_rate"`
+	MRR               float64 `json:"mrr"`
+	FaithfulnessCount int     `json:"faithfulness_count"`
+	Faithfulness      float64 `json:"faithfulness"`
+	CorrectnessCount  int     `json:"correctness_count"`
+	Correctness       float64 `json:"correctness"`
+}
+
+func (m *EvalMetrics) Scan(value any) error {
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New(fmt.Sprint("invalid eval metrics value type:", value))
+	}
+	return json.Unmarshal(bytes, m)
+}
+
+func (m EvalMetrics) Value() (driver.Value, error) {
+	return json.Marshal(m)
+}
+
+type EvalRun struct {
+	ID         string        `json:"id" gorm:"primaryKey"`
+	SetID      string        `json:"set_id" gorm:"index"`
+	KBID       string        `json:"kb_id"`
+	Status     EvalRunStatus `json:"status"`
+	Error      string        `json:"error"`
+	Config     EvalRunConfig `json:"config" gorm:"type:jsonb"`
+	Metrics    EvalMetrics   `json:"metrics" gorm:"type:jsonb"`
+	CreatedAt  time.Time     `json:"created_at"`
+	StartedAt  *time.Time    `json:"started_at"`
+	FinishedAt *time.Time    `json:"finished_at"`
+}
+
+func (EvalRun) TableName() string {
+	return "eval_runs"
+}
+
+// EvalResult is the outcome of one question in a run
+type EvalResult struct {
+	ID               string         `json:"id" gorm:"primaryKey"`
+	RunID            string         `json:"run_id" gorm:"index"`
+	QuestionID       string         `json:"question_id"`
+	Question         string         `json:"question"`
+	Answer           string         `json:"answer"`
+	RetrievedNodeIDs pq.StringArray `json:"retrieved_node_ids" gorm:"type:text[]"`
+	Hit              bool           `json:"hit"`
+	ReciprocalRank   float64        `json:"reciprocal_rank"`
+	Faithfulness     *float64       `json:"faithfulness"`
+	Correctness      *float64       `json:"correctness"`
+	JudgeReason      string         `json:"judge_reason"`
+	Error            string         `json:"error"`
+	CreatedAt        time.Time      `json:"created_at"`
+}
+
+func (EvalResult) TableName() string {
+	return "eval_results"
+}
+
+// ScoreRetrieval sets hit and reciprocal rank from the rank of the first expected node
+func (r *EvalResult) ScoreRetrieval(expectedNodeIDs []string) {
+	for i, nodeID := range r.RetrievedNodeIDs {
+		if slices.Contains(expectedNodeIDs, nodeID) {
+			r.Hit = true
+			r.ReciprocalRank = 1 / float64(i+1)
+			return
+		}
+	}
+}
+
+// NewEvalMetrics aggregates the results, questions without expected nodes are left out of retrieval metrics.
+// errored questions count as retrieval misses so that failures do not inflate hit rate and mrr
+func NewEvalMetrics(questions []*EvalQuestion, results []*EvalResult) EvalMetrics {
+	expected := make(map[string]bool, len(questions))
+	for _, q := range questions {
+		expected[q.ID] = len(q.ExpectedNodeIDs) > 0
+	}
+	metrics := EvalMetrics{QuestionCount: len(results)}
+	for _, r := range results {
+		if r.Error != "" {
+			metrics.ErrorCount++
+			if expected[r.QuestionID] {
+				metrics.RetrievalCount++
+			}
+			continue
+		}
+		if expected[r.QuestionID] {
+			metrics.RetrievalCount++
+			if r.Hit {
+				metrics.HitRate++
+			}
+			metrics.MRR += r.ReciprocalRank
+		}
+		if r.Faithfulness != nil {
+			metrics.FaithfulnessCount++
+			metrics.Faithfulness += *r.Faithfulness
+		}
+		if r.Correctness != nil {
+			metrics.CorrectnessCount++
+			metrics.Correctness += *r.Correctness
+		}
+	}
+	if metrics.RetrievalCount > 0 {
+		metrics.HitRate /= float64(metrics.RetrievalCount)
+		metrics.MRR /= float64(metrics.RetrievalCount)
+	}
+	if metrics.FaithfulnessCount > 0 {
+		metrics.Faithfulness /= float64(metrics.FaithfulnessCount)
+	}
+	if metrics.CorrectnessCount > 0 {
+		metrics.Correctness /= float64(metrics.CorrectnessCount)
+	}
+	return metrics
+}
+
+// EvalJudgement is the verdict of the judge model, scores range from 0 to 1
+type EvalJudgement struct {
+	Faithfulness *float64 `json:"faithfulness"`
+	Correctness  *float64 `json:"correctness"`
+	Reason       string   `json:"reason"`
+}
+
+// ParseEvalJudgement parses the json verdict of the judge model, tolerating code fences and surrounding text
+func ParseEvalJudgement(result string) (*EvalJudgement, error) {
+	start := strings.Index(result, "{")
+	end := strings.LastIndex(result, "}")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("judgement not found in %q", result)
+	}
+	var judgement EvalJudgement
+	if err := json.Unmarshal([]byte(result[start:end+1]), &judgement); err != nil {
+		return nil, fmt.Errorf("invalid judgement: %w", err)
+	}
+	for _, score := range []*float64{judgement.Faithfulness, judgement.Correctness} {
+		if score != nil {
+			*score = min(max(*score, 0), 1)
+		}
+	}
+	return &judgement, nil
+}
+
+// EvalTaskRequest is published to run an evaluation in the consumer
+type EvalTaskRequest struct {
+	RunID string `json:"run_id"`
+}
+
+type EvalSetListReq struct {
+	KBID string `json:"kb_id" query:"kb_id" validate:"required"`
+}
+
+type CreateEvalSetReq struct {
+	KBID        string `json:"kb_id" validate:"required"`
+	Name        string `json:"name" validate:"required"`
+	Description string `json:"description"`
+}
+
+type UpdateEvalSetReq struct {
+	ID          string  `json:"id" validate:"required"`
+	KBID        string  `json:"kb_id" validate:"required"`
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+}
+
+type DeleteEvalSetReq struct {
+	ID   string `json:"id" query:"id" validate:"required"`
+	KBID string `json:"kb_id" query:"kb_id" validate:"required"`
+}
+
+type EvalQuestionListReq struct {
+	KBID  string `json:"kb_id" query:"kb_id" validate:"required"`
+	SetID string `json:"set_id" query:"set_id" validate:"required"`
+}
+
+type CreateEvalQuestionReq struct {
+	KBID            string   `json:"kb_id" validate:"required"`
+	SetID           string   `json:"set_id" validate:"required"`
+	Question        string   `json:"question" validate:"required"`
+	ExpectedNodeIDs []string `json:"expected_node_ids"`
+	ReferenceAnswer string   `json:"reference_answer"`
+}
+
+type UpdateEvalQuestionReq struct {
+	ID              string    `json:"id" validate:"required"`
+	KBID            string    `json:"kb_id" validate:"required"`
+	Question        *string   `json:"question"`
+	ExpectedNodeIDs *[]string `json:"expected_node_ids"`
+	ReferenceAnswer *string   `json:"reference_answer"`
+}
+
+type DeleteEvalQuestionReq struct {
+	ID   string `json:"id" query:"id" validate:"required"`
+	KBID string `json:"kb_id" query:"kb_id" validate:"required"`
+}
+
+type CreateEvalRunReq struct {
+	KBID  string `json:"kb_id" validate:"required"`
+	SetID string `json:"set_id" validate:"required"`
+}
+
+type EvalRunListReq struct {
+	KBID  string `json:"kb_id" query:"kb_id" validate:"required"`
+	SetID string `json:"set_id" query:"set_id" validate:"required"`
+	Pager
+}
+
+type EvalRunDetailReq struct {
+	ID   string `json:"id" query:"id" validate:"required"`
+	KBID string `json:"kb_id" query:"kb_id" validate:"required"`
+}
+
+type EvalRunDetailResp struct {
+	*EvalRun
+	Results []*EvalResult `json:"results"`
+}
+
+type EvalRunCompareReq struct {
+	KBID     string `json:"kb_id" query:"kb_id" validate:"required"`
+	BaseID   string `json:"base_id" query:"base_id" validate:"required"`
+	TargetID string `json:"target_id" query:"target_id" validate:"required"`
+}
+
+type EvalRunCompareItem struct {
+	QuestionID string      `json:"question_id"`
+	Question   string      `json:"question"`
+	Base       *EvalResult `json:"base"`
+	Target     *EvalResult `json:"target"`
+}
+
+type EvalRunCompareResp struct {
+	Base   *EvalRun              `json:"base"`
+	Target *EvalRun              `json:"target"`
+	Items  []*EvalRunCompareItem `json:"items"`
+}
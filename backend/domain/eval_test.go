@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvalResult_ScoreRetrieval(t *testing.T) {
+	result := &EvalResult{RetrievedNodeIDs: []string{"a", "b", "c"}}
+	result.ScoreRetrieval([]string{"c", "b"})
+	assert.True(t, result.Hit)
+	assert.Equal(t, 0.5, result.ReciprocalRank)
+
+	miss := &EvalResult{RetrievedNodeIDs: []string{"a"}}
+	miss.ScoreRetrieval([]string{"x"})
+	assert.False(t, miss.Hit)
+	assert.Zero(t, miss.ReciprocalRank)
+}
+
+func TestNewEvalMetrics(t *testing.T) {
+	score := func(v float64) *float64 { return &v }
+	questions := []*EvalQuestion{
+		{ID: "q1", ExpectedNodeIDs: []string{"a"}},
+		{ID: "q2", ExpectedNodeIDs: []string{"b"}},
+		{ID: "q3"},
+		{ID: "q4", ExpectedNodeIDs: []string{"d"}},
+	}
+	results := []*EvalResult{
+		{QuestionID: "q1", Hit: true, ReciprocalRank: 1, Faithfulness: score(1), Correctness: score(0.5)},
+		{QuestionID: "q2", Faithfulness: score(0.5)},
+		{QuestionID: "q3", Faithfulness: score(0)},
+		{QuestionID: "q4", Error: "retrieve failed"},
+	}
+
+	metrics := NewEvalMetrics(questions, results)
+	assert.Equal(t, 4, metrics.QuestionCount)
+	assert.Equal(t, 1, metrics.ErrorCount)
+	assert.Equal(t, 3, metrics.RetrievalCount)
+	assert.InDelta(t, 1.0/3, metrics.HitRate, 1e-9)
+	assert.InDelta(t, 1.0/3, metrics.MRR, 1e-9)
+	assert.Equal(t, 3, metrics.FaithfulnessCount)
+	assert.Equal(t, 0.5, metrics.Faithfulness)
+	assert.Equal(t, 1, metrics.CorrectnessCount)
+	assert.Equal(t, 0.5, metrics.Correctness)
+}
+
+func TestNewEvalMetricsErrorsAreMisses(t *testing.T) {
+	questions := []*EvalQuestion{
+		{ID: "q1", ExpectedNodeIDs: []string{"a"}},
+		{ID: "q2", ExpectedNodeIDs: []string{"b"}},
+	}
+	results := []*EvalResult{
+		{QuestionID: "q1", Hit: true, ReciprocalRank: 1},
+		{QuestionID: "q2", Error: "chat failed"},
+	}
+
+	metrics := NewEvalMetrics(questions, results)
+	assert.Equal(t, 1, metrics.ErrorCount)
+	assert.Equal(t, 2, metrics.RetrievalCount)
+	assert.Equal(t, 0.5, metrics.HitRate)
+	assert.Equal(t, 0.5, metrics.MRR)
+}
+
+func TestParseEvalJudgement(t *testing.T) {
+	judgement, err := ParseEvalJudgement("```json\n{\"faithfulness\": 1.2, \"correctness\": null, \"reason\": \"ok\"}\n```")
+	require.NoError(t, err)
+	require.NotNil(t, judgement.Faithfulness)
+	assert.Equal(t, 1.0, *judgement.Faithfulness)
+	assert.Nil(t, judgement.Correctness)
+	assert.Equal(t, "ok", judgement.Reason)
+
+	_, err = ParseEvalJudgement("no verdict")
+	assert.Error(t, err)
+}
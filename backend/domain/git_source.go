@@ -0,0 +1,233 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+)
+
+type GitSyncStatus string
+
+const (
+	GitSyncStatusIdle      GitSyncStatus = "idle"
+	GitSyncStatusPending   GitSyncStatus = "pending"
+	GitSyncStatusRunning   GitSyncStatus = "running"
+	GitSyncStatusCompleted GitSyncStatus = "completed"
+	GitSyncStatusFailed    GitSyncStatus = "failed"
+)
+
+// GitSyncStaleAfter is how long a sync may be queued or running, an older one was lost, e.g. by a restart
+// of the consumer, and the source can be synced again
+const GitSyncStaleAfter = 6 * time.Hour
+
+// GitSource syncs the markdown files of a branch of a git repository into a kb,
+// directories become folder nodes and .md files become documents
+type GitSource struct {
+	ID   string `json:"id" gorm:"primaryKey"`
+	KBID string `json:"kb_id" gorm:"index"`
+	// either a remote repository url or a path on the server
+	URL       string `json:"url"`
+	LocalPath string `json:"local_path"`
+	Branch    string `json:"branch"`
+	// directory of the repository to sync, empty for the whole repository
+	Dir string `json:"dir"`
+	// node the synced tree is placed under, empty for the root of the kb
+	ParentID string `json:"parent_id"`
+	// publish the changed nodes as a release tagged with the commit after each sync
+	AutoRelease bool `json:"auto_release"`
+
+	LastCommit string        `json:"last_commit"`
+	Status     GitSyncStatus `json:"status"`
+	Error      string        `json:"error"`
+	Result     GitSyncResult `json:"result" gorm:"type:jsonb"`
+	// when the current or last sync was queued, and again when it started
+	StartedAt *time.Time `json:"started_at"`
+	SyncedAt  *time.Time `json:"synced_at"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+func (GitSource) TableName() string {
+	return "git_sources"
+}
+
+// GitSyncResult counts the nodes changed by the last sync
+type GitSyncResult struct {
+	Commit    string `json:"commit"`
+	Created   int    `json:"created"`
+	Updated   int    `json:"updated"`
+	Deleted   int    `json:"deleted"`
+	ReleaseID string `json:"release_id"`
+}
+
+func (r *GitSyncResult) Scan(value any) error {
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New(fmt.Sprint("invalid git sync result value type:", value))
+	}
+	return json.Unmarshal(bytes, r)
+}
+
+func (r GitSyncResult) Value() (driver.Value, error) {
+	return json.Marshal(r)
+}
+
+// GitSourceNode maps a path of the repository to the node it was synced to
+type GitSourceNode struct {
+	SourceID string   `json:"source_id" gorm:"primaryKey"`
+	Path     string   `json:"path" gorm:"primaryKey"`
+	NodeID   string   `json:"node_id"`
+	Type     NodeType `json:"type"`
+}
+
+func (GitSourceNode) TableName() string {
+	return "git_source_nodes"
+}
+
+// GitSourceImage is an image of the repository uploaded by a sync, it is uploaded again only when its content changes
+type GitSourceImage struct {
+	SourceID string `json:"source_id" gorm:"primaryKey"`
+	Path     string `json:"path" gorm:"primaryKey"`
+	Hash     string `json:"hash"`
+	URL      string `json:"url"`
+}
+
+func (GitSourceImage) TableName() string {
+	return "git_source_images"
+}
+
+var scpLikeGitURLRegex = regexp.MustCompile(`^[\w.-]+@[\w.-]+:[\w./~-]+$`)
+
+// ValidateGitURL accepts http(s), ssh and git urls and the scp-like user@host:path syntax
+func ValidateGitURL(gitURL string) error {
+	_, err := GitURLHost(gitURL)
+	return err
+}
+
+// GitURLHost returns the host of a repository url accepted by ValidateGitURL, without port
+func GitURLHost(gitURL string) (string, error) {
+	if scpLikeGitURLRegex.MatchString(gitURL) {
+		host := gitURL[strings.Index(gitURL, "@")+1:]
+		return host[:strings.Index(host, ":")], nil
+	}
+	u, err := url.Parse(gitURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid repository url: %w", err)
+	}
+	switch u.Scheme {
+	case "http", "https", "ssh", "git":
+	default:
+		return "", fmt.Errorf("unsupported repository url scheme %q", u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return "", fmt.Errorf("invalid repository url %s", gitURL)
+	}
+	return u.Hostname(), nil
+}
+
+// CleanGitDir normalizes a directory of the repository, an empty string is the root
+func CleanGitDir(dir string) (string, error) {
+	dir = strings.Trim(path.Clean("/"+strings.TrimSpace(dir)), "/")
+	if strings.HasPrefix(dir, "..") {
+		return "", fmt.Errorf("invalid directory %s", dir)
+	}
+	return dir, nil
+}
+
+// GitDocPath returns the path of a markdown file relative to dir, false if the file is not a document under dir
+func GitDocPath(dir, file string) (string, bool) {
+	if !strings.EqualFold(path.Ext(file), ".md") {
+		return "", false
+	}
+	if dir == "" {
+		return file, true
+	}
+	if !strings.HasPrefix(file, dir+"/") {
+		return "", false
+	}
+	return strings.TrimPrefix(file, dir+"/"), true
+}
+
+// GitParentDirs returns the directories containing the relative path, outermost first
+func GitParentDirs(rel string) []string {
+	dirs := make([]string, 0)
+	for dir := path.Dir(rel); dir != "." && dir != "/"; dir = path.Dir(dir) {
+		dirs = append([]string{dir}, dirs...)
+	}
+	return dirs
+}
+
+// GitDocName is the node name of a markdown file, its file name without extension
+func GitDocName(rel string) string {
+	name := path.Base(rel)
+	return strings.TrimSuffix(name, path.Ext(name))
+}
+
+// ResolveRelativeRef resolves a reference found in the file at base against the root of the repository,
+// false for urls, absolute paths and references escaping the root
+func ResolveRelativeRef(base, ref string) (string, bool) {
+	if ref == "" || strings.HasPrefix(ref, "/") || strings.HasPrefix(ref, "#") || strings.Contains(ref, "://") || strings.HasPrefix(ref, "data:") {
+		return "", false
+	}
+	if i := strings.IndexAny(ref, "?#"); i >= 0 {
+		ref = ref[:i]
+	}
+	if unescaped, err := url.PathUnescape(ref); err == nil {
+		ref = unescaped
+	}
+	resolved := path.Join(path.Dir(base), ref)
+	if resolved == ".." || strings.HasPrefix(resolved, "../") {
+		return "", false
+	}
+	return resolved, true
+}
+
+type CreateGitSourceReq struct {
+	KBID        string `json:"kb_id" validate:"required"`
+	URL         string `json:"url"`
+	LocalPath   string `json:"local_path"`
+	Branch      string `json:"branch" validate:"required"`
+	Dir         string `json:"dir"`
+	ParentID    string `json:"parent_id"`
+	AutoRelease bool   `json:"auto_release"`
+}
+
+type UpdateGitSourceReq struct {
+	KBID        string  `json:"kb_id" validate:"required"`
+	ID          string  `json:"id" validate:"required"`
+	Branch      *string `json:"branch"`
+	Dir         *string `json:"dir"`
+	AutoRelease *bool   `json:"auto_release"`
+}
+
+type GitSourceListReq struct {
+	KBID string `json:"kb_id" query:"kb_id" validate:"required"`
+}
+
+type DeleteGitSourceReq struct {
+	KBID string `json:"kb_id" query:"kb_id" validate:"required"`
+	ID   string `json:"id" query:"id" validate:"required"`
+	// also delete the nodes created by the source
+	DeleteNodes bool `json:"delete_nodes" query:"delete_nodes"`
+}
+
+type SyncGitSourceReq struct {
+	KBID string `json:"kb_id" validate:"required"`
+	ID   string `json:"id" validate:"required"`
+	// compare the whole tree instead of the diff since the last synced commit
+	Full bool `json:"full"`
+}
+
+// GitSyncTaskRequest is published to sync a git source in the consumer
+type GitSyncTaskRequest struct {
+	SourceID string `json:"source_id"`
+	UserID   string `json:"user_id"`
+	MaxNode  int    `json:"max_node"`
+	Full     bool   `json:"full"`
+}
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateGitURL(t *testing.T) {
+	assert.NoError(t, ValidateGitURL("https://github.com/chaitin/PandaWiki.git"))
+	assert.NoError(t, ValidateGitURL("git@github.com:chaitin/PandaWiki.git"))
+	assert.NoError(t, ValidateGitURL("ssh://git@example.com/docs.git"))
+	assert.Error(t, ValidateGitURL("file:///etc"))
+	assert.Error(t, ValidateGitURL("ext::sh -c touch% /tmp/pwned"))
+	assert.Error(t, ValidateGitURL("--upload-pack=touch"))
+}
+
+func TestGitURLHost(t *testing.T) {
+	for gitURL, want := range map[string]string{
+		"https://github.com/chaitin/PandaWiki.git": "github.com",
+		"git@github.com:chaitin/PandaWiki.git":     "github.com",
+		"ssh://git@example.com:2222/docs.git":      "example.com",
+		"http://[::1]:8080/docs.git":               "::1",
+	} {
+		host, err := GitURLHost(gitURL)
+		assert.NoError(t, err)
+		assert.Equal(t, want, host, gitURL)
+	}
+}
+
+func TestGitDocPath(t *testing.T) {
+	dir, err := CleanGitDir("/docs/")
+	assert.NoError(t, err)
+	assert.Equal(t, "docs", dir)
+	// paths are cleaned against the root and cannot escape it
+	dir2, err := CleanGitDir("../etc")
+	assert.NoError(t, err)
+	assert.Equal(t, "etc", dir2)
+
+	rel, ok := GitDocPath(dir, "docs/guide/install.md")
+	assert.True(t, ok)
+	assert.Equal(t, "guide/install.md", rel)
+	_, ok = GitDocPath(dir, "docs2/install.md")
+	assert.False(t, ok)
+	_, ok = GitDocPath(dir, "docs/logo.png")
+	assert.False(t, ok)
+
+	assert.Equal(t, []string{"guide", "guide/linux"}, GitParentDirs("guide/linux/install.md"))
+	assert.Empty(t, GitParentDirs("install.md"))
+	assert.Equal(t, "install", GitDocName("guide/linux/install.md"))
+}
+
+func TestResolveRelativeRef(t *testing.T) {
+	resolved, ok := ResolveRelativeRef("docs/guide/install.md", "../img/a%20b.png?raw=1")
+	assert.True(t, ok)
+	assert.Equal(t, "docs/img/a b.png", resolved)
+	for _, ref := range []string{"https://example.com/a.png", "/static-file/a.png", "data:image/png;base64,AA", "../../../etc/passwd"} {
+		_, ok := ResolveRelativeRef("docs/guide/install.md", ref)
+		assert.False(t, ok, ref)
+	}
+}
+
+func TestReplaceImageURLs(t *testing.T) {
+	content := "![a](img/a.png \"title\")\n<img src=\"img/b.png\" width=\"10\">\n![c](img/c.png)"
+	replaced := ReplaceImageURLs(content, map[string]string{
+		"img/a.png": "/static-file/kb/a.png",
+		"img/b.png": "/static-file/kb/b.png",
+	})
+	assert.Equal(t, "![a](/static-file/kb/a.png \"title\")\n<img src=\"/static-file/kb/b.png\" width=\"10\">\n![c](img/c.png)", replaced)
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ImageCaption caches the text extracted from an image, keyed by the sha256 of the image
+type ImageCaption struct {
+	Hash      string    `json:"hash" gorm:"primaryKey"`
+	Model     string    `json:"model"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (ImageCaption) TableName() string {
+	return "image_captions"
+}
+
+var (
+	markdownImageRegexp = regexp.MustCompile(`!\[[^\]]*\]\(\s*<?([^)\s>]+)>?(?:\s+"[^"]*")?\s*\)`)
+	htmlImageRegexp     = regexp.MustCompile(`(?i)<img\b[^>]*?\bsrc\s*=\s*["']([^"']+)["'][^>]*>`)
+)
+
+// ExtractImageURLs returns the distinct images referenced by a markdown or html document
+func ExtractImageURLs(content string) []string {
+	urls := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, re := range []*regexp.Regexp{markdownImageRegexp, htmlImageRegexp} {
+		for _, match := range re.FindAllStringSubmatch(content, -1) {
+			url := html.UnescapeString(match[1])
+			if seen[url] {
+				continue
+			}
+			seen[url] = true
+			urls = append(urls, url)
+		}
+	}
+	return urls
+}
+
+// ReplaceImageURLs replaces the urls of the markdown and html images found in urls, other images are kept
+func ReplaceImageURLs(content string, urls map[string]string) string {
+	if len(urls) == 0 {
+		return content
+	}
+	for _, re := range []*regexp.Regexp{markdownImageRegexp, htmlImageRegexp} {
+		content = re.ReplaceAllStringFunc(content, func(image string) string {
+			loc := re.FindStringSubmatchIndex(image)
+			replacement, ok := urls[html.UnescapeString(image[loc[2]:loc[3]])]
+			if !ok {
+				return image
+			}
+			return image[:loc[2]] + replacement + image[loc[3]:]
+		})
+	}
+	return content
+}
+
+// InjectImageCaptions appends the caption of each image right after the image so that it is indexed with the surrounding text
+func InjectImageCaptions(content string, captions map[string]string) string {
+	if len(captions) == 0 {
+		return content
+	}
+	content = markdownImageRegexp.ReplaceAllStringFunc(content, func(image string) string {
+		url := markdownImageRegexp.FindStringSubmatch(image)[1]
+		caption := strings.TrimSpace(captions[html.UnescapeString(url)])
+		if caption == "" {
+			return image
+		}
+		return fmt.Sprintf("%s\n\n> [图片内容] %s\n", image, strings.ReplaceAll(caption, "\n", "\n> "))
+	})
+	return htmlImageRegexp.ReplaceAllStringFunc(content, func(image string) string {
+		url := htmlImageRegexp.FindStringSubmatch(image)[1]
+		caption := strings.TrimSpace(captions[html.UnescapeString(url)])
+		if caption == "" {
+			return image
+		}
+		return fmt.Sprintf("%s<blockquote><p>[图片内容] %s</p></blockquote>", image, strings.ReplaceAll(html.EscapeString(caption), "\n", "<br>"))
+	})
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractImageURLs(t *testing.T) {
+	content := `![a](/static-file/kb/a.png) text ![b](<https://example.com/b.png> "title")
+<p><img alt="c" src="/static-file/kb/c.png?x=1&amp;y=2"></p> ![a again](/static-file/kb/a.png)`
+
+	assert.Equal(t, []string{
+		"/static-file/kb/a.png",
+		"https://example.com/b.png",
+		"/static-file/kb/c.png?x=1&y=2",
+	}, ExtractImageURLs(content))
+	assert.Empty(t, ExtractImageURLs("no images, just [a link](https://example.com)"))
+}
+
+func TestInjectImageCaptions(t *testing.T) {
+	captions := map[string]string{
+		"/static-file/kb/a.png":         "line1\nline2",
+		"/static-file/kb/c.png?x=1&y=2": "<error>",
+	}
+
+	markdown := InjectImageCaptions("![a](/static-file/kb/a.png) ![b](/b.png)", captions)
+	assert.Equal(t, "![a](/static-file/kb/a.png)\n\n> [图片内容] line1\n> line2\n ![b](/b.png)", markdown)
+
+	html := InjectImageCaptions(`<img src="/static-file/kb/c.png?x=1&amp;y=2">`, captions)
+	assert.Equal(t, `<img src="/static-file/kb/c.png?x=1&amp;y=2"><blockquote><p>[图片内容] &lt;error&gt;</p></blockquote>`, html)
+
+	assert.Equal(t, "![a](/a.png)", InjectImageCaptions("![a](/a.png)", nil))
+}
@@ -0,0 +1,185 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/chaitin/panda-wiki/consts"
+)
+
+type ImportJobStatus string
+
+const (
+	ImportJobStatusPending   ImportJobStatus = "pending"
+	ImportJobStatusRunning   ImportJobStatus = "running"
+	ImportJobStatusCompleted ImportJobStatus = "completed"
+	ImportJobStatusFailed    ImportJobStatus = "failed"
+)
+
+// ImportJobItemStatus is the state of one document of an import job
+type ImportJobItemStatus string
+
+const (
+	// listed by the crawler and waiting for export
+	ImportJobItemStatusListed ImportJobItemStatus = "listed"
+	// exported by the crawler, the task id of the export is kept on the item
+	ImportJobItemStatusExporting ImportJobItemStatus = "exporting"
+	ImportJobItemStatusImported  ImportJobItemStatus = "imported"
+	ImportJobItemStatusFailed    ImportJobItemStatus = "failed"
+)
+
+const (
+	DefaultImportJobConcurrency = 4
+	MaxImportJobConcurrency     = 8
+	MaxImportJobDocs            = 10000
+	// a running job without progress for this long is considered lost, e.g. by a restart of the consumer, and is resumed
+	ImportJobStaleAfter = 30 * time.Minute
+)
+
+// ImportJob imports the documents listed by the crawler into a kb in the background
+type ImportJob struct {
+	ID     string               `json:"id" gorm:"primaryKey"`
+	KBID   string               `json:"kb_id" gorm:"index"`
+	Source consts.CrawlerSource `json:"source"`
+	// id of the crawler parse the documents were listed by, the crawler needs it to export them
+	ParseID string `json:"parse_id"`
+	// node the documents are placed under, empty for the root of the kb
+	ParentID    string          `json:"parent_id"`
+	Concurrency int             `json:"concurrency"`
+	Status      ImportJobStatus `json:"status"`
+	Error       string          `json:"error"`
+	// documents are created on behalf of the user who created the job
+	CreatorID string     `json:"creator_id"`
+	MaxNode   int        `json:"-"`
+	CreatedAt time.Time  `json:"created_at"`
+	StartedAt *time.Time `json:"started_at"`
+	// moved forward whenever an item changes state, a stale heartbeat marks a lost job
+	HeartbeatAt *time.Time `json:"heartbeat_at"`
+	FinishedAt  *time.Time `json:"finished_at"`
+
+	Progress ImportJobProgress `json:"progress" gorm:"-"`
+}
+
+func (ImportJob) TableName() string {
+	return "import_jobs"
+}
+
+// ImportJobItem is one document of an import job
+type ImportJobItem struct {
+	ID       string `json:"id" gorm:"primaryKey"`
+	JobID    string `json:"job_id" gorm:"index"`
+	DocID    string `json:"doc_id"`
+	Title    string `json:"title"`
+	FileType string `json:"file_type"`
+	SpaceID  string `json:"space_id"`
+	// folders the document is placed in below the parent of the job
+	Folders pq.StringArray `json:"folders" gorm:"type:text[]"`
+	// order of the document in the listing
+	Position  int                 `json:"position"`
+	Status    ImportJobItemStatus `json:"status"`
+	TaskID    string              `json:"task_id"`
+	NodeID    string              `json:"node_id"`
+	Error     string              `json:"error"`
+	Attempts  int                 `json:"attempts"`
+	UpdatedAt time.Time           `json:"updated_at"`
+}
+
+func (ImportJobItem) TableName() string {
+	return "import_job_items"
+}
+
+// ExternalID identifies the node of the item, so that an item imported again after a restart updates its node
+func (i *ImportJobItem) ExternalID() string {
+	return fmt.Sprintf("import:%s:%s", i.JobID, i.ID)
+}
+
+type ImportJobProgress struct {
+	Total     int `json:"total"`
+	Listed    int `json:"listed"`
+	Exporting int `json:"exporting"`
+	Imported  int `json:"imported"`
+	Failed    int `json:"failed"`
+}
+
+// NewImportJobProgress counts the items of a job by status
+func NewImportJobProgress(counts map[ImportJobItemStatus]int) ImportJobProgress {
+	progress := ImportJobProgress{
+		Listed:    counts[ImportJobItemStatusListed],
+		Exporting: counts[ImportJobItemStatusExporting],
+		Imported:  counts[ImportJobItemStatusImported],
+		Failed:    counts[ImportJobItemStatusFailed],
+	}
+	progress.Total = progress.Listed + progress.Exporting + progress.Imported + progress.Failed
+	return progress
+}
+
+type CreateImportJobReq struct {
+	KBID   string               `json:"kb_id" validate:"required"`
+	Source consts.CrawlerSource `json:"source" validate:"required"`
+	// id returned by the crawler parse that listed the documents
+	ParseID  string `json:"parse_id" validate:"required"`
+	ParentID string `json:"parent_id"`
+	// number of documents exported at the same time, defaults to 4
+	Concurrency int             `json:"concurrency"`
+	Docs        []*ImportJobDoc `json:"docs" validate:"required,min=1,dive"`
+}
+
+// ImportJobDoc is a document picked from the tree returned by the crawler parse
+type ImportJobDoc struct {
+	DocID    string   `json:"doc_id" validate:"required"`
+	Title    string   `json:"title"`
+	FileType string   `json:"file_type"`
+	SpaceID  string   `json:"space_id"`
+	Folders  []string `json:"folders"`
+}
+
+func (r *CreateImportJobReq) Validate() error {
+	if r.Concurrency == 0 {
+		r.Concurrency = DefaultImportJobConcurrency
+	}
+	if r.Concurrency < 1 || r.Concurrency > MaxImportJobConcurrency {
+		return fmt.Errorf("concurrency must be between 1 and %d", MaxImportJobConcurrency)
+	}
+	if len(r.Docs) > MaxImportJobDocs {
+		return fmt.Errorf("at most %d documents can be imported by a job", MaxImportJobDocs)
+	}
+	docIDs := make(map[string]struct{}, len(r.Docs))
+	for _, doc := range r.Docs {
+		if _, ok := docIDs[doc.DocID]; ok {
+			return fmt.Errorf("duplicate doc_id %s", doc.DocID)
+		}
+		docIDs[doc.DocID] = struct{}{}
+	}
+	return nil
+}
+
+type ImportJobListReq struct {
+	KBID string `json:"kb_id" query:"kb_id" validate:"required"`
+	Pager
+}
+
+type ImportJobDetailReq struct {
+	KBID string `json:"kb_id" query:"kb_id" validate:"required"`
+	ID   string `json:"id" query:"id" validate:"required"`
+}
+
+type ImportJobItemListReq struct {
+	KBID   string              `json:"kb_id" query:"kb_id" validate:"required"`
+	ID     string              `json:"id" query:"id" validate:"required"`
+	Status ImportJobItemStatus `json:"status" query:"status" validate:"omitempty,oneof=listed exporting imported failed"`
+	Pager
+}
+
+type RetryImportJobReq struct {
+	KBID string `json:"kb_id" validate:"required"`
+	ID   string `json:"id" validate:"required"`
+	// failed items to retry, empty for all failed items of the job
+	ItemIDs []string `json:"item_ids"`
+}
+
+// ImportTaskRequest is published to run an import job in the consumer
+type ImportTaskRequest struct {
+	JobID string `json:"job_id"`
+}
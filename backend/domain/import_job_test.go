@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateImportJobReqValidate(t *testing.T) {
+	req := &CreateImportJobReq{KBID: "kb", ParseID: "p", Docs: []*ImportJobDoc{{DocID: "a"}, {DocID: "b"}}}
+	assert.NoError(t, req.Validate())
+	assert.Equal(t, DefaultImportJobConcurrency, req.Concurrency)
+
+	req.Concurrency = MaxImportJobConcurrency + 1
+	assert.Error(t, req.Validate())
+	req.Concurrency = 1
+
+	req.Docs = append(req.Docs, &ImportJobDoc{DocID: "a"})
+	assert.ErrorContains(t, req.Validate(), "duplicate doc_id a")
+}
+
+func TestNewImportJobProgress(t *testing.T) {
+	progress := NewImportJobProgress(map[ImportJobItemStatus]int{
+		ImportJobItemStatusListed:    4,
+		ImportJobItemStatusExporting: 2,
+		ImportJobItemStatusImported:  10,
+		ImportJobItemStatusFailed:    1,
+	})
+	assert.Equal(t, ImportJobProgress{Total: 17, Listed: 4, Exporting: 2, Imported: 10, Failed: 1}, progress)
+	assert.Equal(t, ImportJobProgress{}, NewImportJobProgress(nil))
+}
+
+func TestImportJobItemExternalID(t *testing.T) {
+	item := &ImportJobItem{ID: "item", JobID: "job", DocID: "https://example.com/a"}
+	assert.Equal(t, "import:job:item", item.ExternalID())
+}
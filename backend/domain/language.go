@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"fmt"
+	"unicode"
+)
+
+const (
+	LanguageZH = "zh"
+	LanguageEN = "en"
+	LanguageJA = "ja"
+	LanguageKO = "ko"
+	LanguageRU = "ru"
+)
+
+var languageNames = map[string]string{
+	LanguageZH: "中文",
+	LanguageEN: "English",
+	LanguageJA: "日本語",
+	LanguageKO: "한국어",
+	LanguageRU: "Русский",
+}
+
+// LanguageName returns the name of the language used in prompts
+func LanguageName(language string) string {
+	if name, ok := languageNames[language]; ok {
+		return name
+	}
+	return language
+}
+
+// LanguageSetting is the language setting of a kb
+type LanguageSetting struct {
+	Language            string `json:"language"`              // 知识库主要语言
+	MatchAnswerLanguage bool   `json:"match_answer_language"` // 使用提问的语言回答
+	TranslateQuery      bool   `json:"translate_query"`       // 检索前将问题翻译为知识库主要语言
+}
+
+func DefaultLanguageSetting() *LanguageSetting {
+	return &LanguageSetting{
+		Language: LanguageZH,
+	}
+}
+
+func (s *LanguageSetting) Validate() error {
+	if _, ok := languageNames[s.Language]; !ok {
+		return fmt.Errorf("unsupported language %s", s.Language)
+	}
+	return nil
+}
+
+type GetLanguageSettingReq struct {
+	KBID string `json:"kb_id" query:"kb_id" validate:"required"`
+}
+
+type UpdateLanguageSettingReq struct {
+	KBID string `json:"kb_id" validate:"required"`
+	LanguageSetting
+}
+
+// DetectLanguage detects the language of text by its dominant script, latin text is taken as english,
+// an empty string is returned if the text has no letters
+func DetectLanguage(text string) string {
+	counts := make(map[string]int)
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r):
+			// kana only appears in japanese, weigh it over the kanji shared with chinese
+			counts[LanguageJA] += 2
+		case unicode.Is(unicode.Han, r):
+			counts[LanguageZH]++
+		case unicode.Is(unicode.Hangul, r):
+			counts[LanguageKO]++
+		case unicode.Is(unicode.Cyrillic, r):
+			counts[LanguageRU]++
+		case unicode.Is(unicode.Latin, r):
+			counts[LanguageEN]++
+		}
+	}
+	// a word of latin letters carries about as much as one CJK character
+	counts[LanguageEN] = (counts[LanguageEN] + 3) / 4
+	if counts[LanguageJA] > 0 {
+		counts[LanguageJA] += counts[LanguageZH]
+		counts[LanguageZH] = 0
+	}
+	language, best := "", 0
+	for _, l := range []string{LanguageZH, LanguageJA, LanguageKO, LanguageRU, LanguageEN} {
+		if counts[l] > best {
+			language, best = l, counts[l]
+		}
+	}
+	return language
+}
@@ -0,0 +1,17 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	assert.Equal(t, LanguageZH, DetectLanguage("如何配置 nginx 的 proxy_pass？"))
+	assert.Equal(t, LanguageEN, DetectLanguage("How do I configure the 数据集?"))
+	assert.Equal(t, LanguageEN, DetectLanguage("hi"))
+	assert.Equal(t, LanguageJA, DetectLanguage("設定方法を教えてください"))
+	assert.Equal(t, LanguageKO, DetectLanguage("설정 방법을 알려주세요"))
+	assert.Equal(t, LanguageRU, DetectLanguage("Как настроить?"))
+	assert.Equal(t, "", DetectLanguage("123 ?!"))
+}
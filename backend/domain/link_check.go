@@ -0,0 +1,283 @@
+package domain
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+type LinkCheckJobStatus string
+
+const (
+	LinkCheckJobStatusPending   LinkCheckJobStatus = "pending"
+	LinkCheckJobStatusRunning   LinkCheckJobStatus = "running"
+	LinkCheckJobStatusCompleted LinkCheckJobStatus = "completed"
+	LinkCheckJobStatusFailed    LinkCheckJobStatus = "failed"
+)
+
+// LinkCheckKind is what a checked link refers to
+type LinkCheckKind string
+
+const (
+	// a /node/<id> link to a document of the kb
+	LinkCheckKindNode LinkCheckKind = "node"
+	// a file uploaded to the static-file bucket
+	LinkCheckKindAsset LinkCheckKind = "asset"
+	// a http url outside of the wiki
+	LinkCheckKindExternal LinkCheckKind = "external"
+)
+
+const (
+	DefaultLinkCheckConcurrency    = 8
+	MaxLinkCheckConcurrency        = 32
+	DefaultLinkCheckTimeoutSeconds = 10
+	MaxLinkCheckTimeoutSeconds     = 60
+	DefaultLinkCheckIntervalHours  = 24 * 7
+	// a job running longer than this is considered lost, e.g. by a restart of the consumer
+	LinkCheckStaleAfter = 6 * time.Hour
+)
+
+// LinkCheckJob checks the links and images of the documents of the latest release of a kb
+type LinkCheckJob struct {
+	ID   string `json:"id" gorm:"primaryKey"`
+	KBID string `json:"kb_id" gorm:"index"`
+	// kb release whose documents were checked
+	ReleaseID      string `json:"release_id"`
+	Concurrency    int    `json:"concurrency"`
+	TimeoutSeconds int    `json:"timeout_seconds"`
+	// created by the schedule of the kb instead of a user
+	Scheduled  bool               `json:"scheduled"`
+	Status     LinkCheckJobStatus `json:"status"`
+	Error      string             `json:"error"`
+	Total      int                `json:"total"`
+	Broken     int                `json:"broken"`
+	CreatedAt  time.Time          `json:"created_at"`
+	StartedAt  *time.Time         `json:"started_at"`
+	FinishedAt *time.Time         `json:"finished_at"`
+}
+
+func (LinkCheckJob) TableName() string {
+	return "link_check_jobs"
+}
+
+// LinkCheckResult is the outcome of checking one link of one document
+type LinkCheckResult struct {
+	ID         string        `json:"id" gorm:"primaryKey"`
+	JobID      string        `json:"job_id" gorm:"index"`
+	NodeID     string        `json:"node_id"`
+	NodeName   string        `json:"node_name"`
+	Kind       LinkCheckKind `json:"kind"`
+	URL        string        `json:"url"`
+	Broken     bool          `json:"broken"`
+	StatusCode int           `json:"status_code"`
+	Error      string        `json:"error"`
+	CheckedAt  time.Time     `json:"checked_at"`
+}
+
+func (LinkCheckResult) TableName() string {
+	return "link_check_results"
+}
+
+// LinkCheckSchedule runs a link check of the kb every IntervalHours in the consumer
+type LinkCheckSchedule struct {
+	KBID           string    `json:"kb_id" gorm:"primaryKey"`
+	Enabled        bool      `json:"enabled"`
+	IntervalHours  int       `json:"interval_hours"`
+	Concurrency    int       `json:"concurrency"`
+	TimeoutSeconds int       `json:"timeout_seconds"`
+	NextRunAt      time.Time `json:"next_run_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+func (LinkCheckSchedule) TableName() string {
+	return "link_check_schedules"
+}
+
+// ScheduleNext sets the time of the next scheduled check counted from now
+func (s *LinkCheckSchedule) ScheduleNext(now time.Time) {
+	interval := s.IntervalHours
+	if interval <= 0 {
+		interval = DefaultLinkCheckIntervalHours
+	}
+	s.NextRunAt = now.Add(time.Duration(interval) * time.Hour)
+}
+
+// LinkCheckRef is a link found in a document, URL is the node id for node links and the object key for assets
+type LinkCheckRef struct {
+	Kind LinkCheckKind
+	URL  string
+}
+
+var (
+	markdownLinkRegexp = regexp.MustCompile(`\]\(\s*<?([^)\s>]+)>?(?:\s+"[^"]*")?\s*\)`)
+	htmlLinkRegexp     = regexp.MustCompile(`(?i)\b(?:href|src)\s*=\s*["']([^"']+)["']`)
+	bareURLRegexp      = regexp.MustCompile(`https?://[^\s<>"'()\[\]]+`)
+	nodePathRegexp     = regexp.MustCompile(`^/node/([0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12})(?:[/?#]|$)`)
+)
+
+// ExtractLinkCheckRefs returns the distinct node links, uploaded files and http urls of a markdown or html document.
+// links in code, anchors, mailto links, data urls and other relative links are ignored
+func ExtractLinkCheckRefs(content string) []LinkCheckRef {
+	for _, re := range nodeLinkCodeRegexps {
+		content = re.ReplaceAllString(content, "")
+	}
+	refs := make([]LinkCheckRef, 0)
+	seen := make(map[LinkCheckRef]bool)
+	add := func(raw string) {
+		ref, ok := newLinkCheckRef(html.UnescapeString(strings.TrimSpace(raw)))
+		if !ok || seen[ref] {
+			return
+		}
+		seen[ref] = true
+		refs = append(refs, ref)
+	}
+	for _, re := range []*regexp.Regexp{markdownLinkRegexp, htmlLinkRegexp} {
+		for _, match := range re.FindAllStringSubmatch(content, -1) {
+			add(match[1])
+		}
+	}
+	for _, match := range bareURLRegexp.FindAllString(content, -1) {
+		add(strings.TrimRight(match, ".,;:!?*_~"))
+	}
+	return refs
+}
+
+func newLinkCheckRef(raw string) (LinkCheckRef, bool) {
+	if match := nodePathRegexp.FindStringSubmatch(raw); match != nil {
+		return LinkCheckRef{Kind: LinkCheckKindNode, URL: strings.ToLower(match[1])}, true
+	}
+	if key, ok := strings.CutPrefix(raw, "/"+Bucket+"/"); ok {
+		return linkCheckAssetRef(key)
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return LinkCheckRef{}, false
+	}
+	// files uploaded before the public path was used are referenced through the internal minio address
+	if u.Hostname() == "panda-wiki-minio" {
+		if key, ok := strings.CutPrefix(u.Path, "/"+Bucket+"/"); ok {
+			return linkCheckAssetRef(key)
+		}
+	}
+	u.Fragment = ""
+	return LinkCheckRef{Kind: LinkCheckKindExternal, URL: u.String()}, true
+}
+
+func linkCheckAssetRef(key string) (LinkCheckRef, bool) {
+	key, _, _ = strings.Cut(key, "?")
+	if unescaped, err := url.PathUnescape(key); err == nil {
+		key = unescaped
+	}
+	return LinkCheckRef{Kind: LinkCheckKindAsset, URL: key}, key != ""
+}
+
+// LinkCheckBrokenStatus tells whether a http status means the link is dead. pages asking for a login
+// or rate limiting the checker exist, they are not reported
+func LinkCheckBrokenStatus(code int) bool {
+	switch code {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests:
+		return false
+	}
+	return code >= http.StatusBadRequest
+}
+
+type CreateLinkCheckJobReq struct {
+	KBID string `json:"kb_id" validate:"required"`
+	// number of links checked at the same time, defaults to 8
+	Concurrency int `json:"concurrency"`
+	// timeout of the request to an external url, defaults to 10
+	TimeoutSeconds int `json:"timeout_seconds"`
+}
+
+func (r *CreateLinkCheckJobReq) Validate() error {
+	return validateLinkCheckOptions(&r.Concurrency, &r.TimeoutSeconds)
+}
+
+func validateLinkCheckOptions(concurrency, timeoutSeconds *int) error {
+	if *concurrency == 0 {
+		*concurrency = DefaultLinkCheckConcurrency
+	}
+	if *concurrency < 1 || *concurrency > MaxLinkCheckConcurrency {
+		return fmt.Errorf("concurrency must be between 1 and %d", MaxLinkCheckConcurrency)
+	}
+	if *timeoutSeconds == 0 {
+		*timeoutSeconds = DefaultLinkCheckTimeoutSeconds
+	}
+	if *timeoutSeconds < 1 || *timeoutSeconds > MaxLinkCheckTimeoutSeconds {
+		return fmt.Errorf("timeout must be between 1 and %d seconds", MaxLinkCheckTimeoutSeconds)
+	}
+	return nil
+}
+
+type LinkCheckJobListReq struct {
+	KBID string `json:"kb_id" query:"kb_id" validate:"required"`
+	Pager
+}
+
+type LinkCheckJobDetailReq struct {
+	KBID string `json:"kb_id" query:"kb_id" validate:"required"`
+	ID   string `json:"id" query:"id" validate:"required"`
+}
+
+type LinkCheckBrokenReq struct {
+	KBID string `json:"kb_id" query:"kb_id" validate:"required"`
+	// defaults to the latest finished job of the kb
+	JobID string `json:"job_id" query:"job_id"`
+	// only the broken links of this node
+	NodeID string `json:"node_id" query:"node_id"`
+}
+
+// LinkCheckNodeReport is the broken links of one document
+type LinkCheckNodeReport struct {
+	NodeID   string             `json:"node_id"`
+	NodeName string             `json:"node_name"`
+	Links    []*LinkCheckResult `json:"links"`
+}
+
+type LinkCheckBrokenResp struct {
+	// nil when no job of the kb has finished yet
+	Job   *LinkCheckJob          `json:"job"`
+	Nodes []*LinkCheckNodeReport `json:"nodes"`
+}
+
+// NewLinkCheckNodeReports groups broken results by document, keeping the order of the results
+func NewLinkCheckNodeReports(results []*LinkCheckResult) []*LinkCheckNodeReport {
+	reports := make([]*LinkCheckNodeReport, 0)
+	byNode := make(map[string]*LinkCheckNodeReport)
+	for _, result := range results {
+		report, ok := byNode[result.NodeID]
+		if !ok {
+			report = &LinkCheckNodeReport{NodeID: result.NodeID, NodeName: result.NodeName, Links: make([]*LinkCheckResult, 0)}
+			byNode[result.NodeID] = report
+			reports = append(reports, report)
+		}
+		report.Links = append(report.Links, result)
+	}
+	return reports
+}
+
+type LinkCheckScheduleReq struct {
+	KBID string `json:"kb_id" query:"kb_id" validate:"required"`
+}
+
+type UpdateLinkCheckScheduleReq struct {
+	KBID    string `json:"kb_id" validate:"required"`
+	Enabled bool   `json:"enabled"`
+	// 0 for the default of a week
+	IntervalHours  int `json:"interval_hours" validate:"min=0,max=2160"`
+	Concurrency    int `json:"concurrency"`
+	TimeoutSeconds int `json:"timeout_seconds"`
+}
+
+func (r *UpdateLinkCheckScheduleReq) Validate() error {
+	return validateLinkCheckOptions(&r.Concurrency, &r.TimeoutSeconds)
+}
+
+// LinkCheckTaskRequest is published to run a link check job in the consumer
+type LinkCheckTaskRequest struct {
+	JobID string `json:"job_id"`
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractLinkCheckRefs(t *testing.T) {
+	content := "[doc](/node/0198A2B4-1C2D-7E3F-8A9B-0C1D2E3F4A5B#intro) ![img](/static-file/kb/a%20b.png \"title\")\n" +
+		"<img src=\"http://panda-wiki-minio:9000/static-file/kb/c.png\"> <a href=\"https://example.com/a?x=1&amp;y=2\">a</a>\n" +
+		"see https://example.com/b. and [again](https://example.com/b#section)\n" +
+		"```\nhttps://example.com/in-code\n```\n" +
+		"[anchor](#top) [mail](mailto:a@example.com) [rel](../other.md) ![data](data:image/png;base64,AA)"
+
+	assert.Equal(t, []LinkCheckRef{
+		{Kind: LinkCheckKindNode, URL: "0198a2b4-1c2d-7e3f-8a9b-0c1d2e3f4a5b"},
+		{Kind: LinkCheckKindAsset, URL: "kb/a b.png"},
+		{Kind: LinkCheckKindExternal, URL: "https://example.com/b"},
+		{Kind: LinkCheckKindAsset, URL: "kb/c.png"},
+		{Kind: LinkCheckKindExternal, URL: "https://example.com/a?x=1&y=2"},
+	}, ExtractLinkCheckRefs(content))
+}
+
+func TestLinkCheckBrokenStatus(t *testing.T) {
+	for code, broken := range map[int]bool{200: false, 301: false, 401: false, 403: false, 429: false, 404: true, 410: true, 500: true} {
+		assert.Equal(t, broken, LinkCheckBrokenStatus(code), code)
+	}
+}
+
+func TestCreateLinkCheckJobReqValidate(t *testing.T) {
+	req := &CreateLinkCheckJobReq{KBID: "kb"}
+	assert.NoError(t, req.Validate())
+	assert.Equal(t, DefaultLinkCheckConcurrency, req.Concurrency)
+	assert.Equal(t, DefaultLinkCheckTimeoutSeconds, req.TimeoutSeconds)
+
+	assert.Error(t, (&CreateLinkCheckJobReq{KBID: "kb", Concurrency: MaxLinkCheckConcurrency + 1}).Validate())
+	assert.Error(t, (&CreateLinkCheckJobReq{KBID: "kb", TimeoutSeconds: -1}).Validate())
+}
+
+func TestNewLinkCheckNodeReports(t *testing.T) {
+	reports := NewLinkCheckNodeReports([]*LinkCheckResult{
+		{NodeID: "a", NodeName: "A", URL: "https://example.com/1"},
+		{NodeID: "b", NodeName: "B", URL: "https://example.com/2"},
+		{NodeID: "a", NodeName: "A", URL: "https://example.com/3"},
+	})
+	assert.Len(t, reports, 2)
+	assert.Equal(t, "a", reports[0].NodeID)
+	assert.Len(t, reports[0].Links, 2)
+	assert.Equal(t, "b", reports[1].NodeID)
+	assert.Len(t, reports[1].Links, 1)
+}
@@ -65,6 +65,112 @@ var UserQuestionFormatter = `
 </documents>
 `
 
+var ImageDescriptionPrompt = `
+你是图片理解助手，用户在提问时附带了图片，请提取图片中的信息，用于检索知识库和回答问题。
+
+要求：
+1. 完整提取图片中的文字（OCR），保留报错信息、代码、界面上的按钮和菜单名称
+2. 简要描述图片内容，例如界面截图的页面、图表或流程图表达的含义
+3. 使用与用户问题相同的语言，只输出提取的信息，不要回答用户问题
+`
+
+var ImageCaptionPrompt = `
+你是文档图片索引助手，图片来自知识库文档，提取的内容会与文档正文一起用于检索。
+
+要求：
+1. 完整提取图片中的文字（OCR），保留报错信息、代码、界面上的按钮和菜单名称
+2. 用一两句话描述图片内容，例如界面截图的页面、图表或流程图表达的含义
+3. 使用图片中文字的语言，图片中没有文字时使用中文
+4. 只输出提取的内容，不要输出任何解释
+`
+
+var EvalJudgePrompt = `
+你是知识库问答的评测员，请根据给定的文档、问题、回答以及参考答案对回答打分。
+
+评分项（0 到 1 之间的小数）：
+1. faithfulness：回答中的事实是否都能在文档中找到依据，没有编造内容，回答“无法回答”且文档确实没有相关内容时为 1
+2. correctness：回答与参考答案是否一致，没有参考答案时输出 null
+
+只输出如下 JSON，不要输出任何其他内容：
+{"faithfulness": 0.0, "correctness": 0.0, "reason": "简要说明扣分原因"}
+`
+
+var EvalJudgeFormatter = `<documents>
+%s
+</documents>
+
+<question>
+%s
+</question>
+
+<answer>
+%s
+</answer>
+
+<reference_answer>
+%s
+</reference_answer>`
+
+var ImageDescriptionFormatter = `
+
+<image_content>
+%s
+</image_content>`
+
+var ConversationSummaryPrompt = `
+你是对话摘要助手，请将“已有摘要”和“新增对话”合并为一份新的对话摘要。
+
+要求：
+1. 保留用户关注的问题、关键事实、已给出的结论以及尚未解决的问题
+2. 使用与对话相同的语言，输出纯文本，不超过500字
+3. 只输出摘要内容，不要输出任何解释
+`
+
+var FollowUpSuggestionPrompt = `
+你是知识库问答助手，请根据用户的问题、助手的回答以及参考文档，生成用户接下来可能会问的 3 到 5 个追问问题。
+
+要求：
+1. 问题应能通过参考文档得到解答，且不要与用户已经提出的问题重复
+2. 每个问题简洁明确，不超过30个字，使用与用户问题相同的语言
+3. 只输出 JSON 字符串数组，例如：["问题1", "问题2", "问题3"]，不要输出任何其他内容
+`
+
+var ConversationSummaryFormatter = `
+以下是此前对话的摘要，可作为理解用户问题的上下文：
+<summary>
+%s
+</summary>
+`
+
+var ClientSystemPromptFormatter = `
+以下是调用方补充的指令，在不违背上述要求的前提下遵循：
+<instructions>
+%s
+</instructions>
+`
+
+var AnswerLanguageFormatter = `
+用户使用%[1]s提问，无论文档使用何种语言，请使用%[1]s回答。
+`
+
+var QueryTranslationPrompt = `
+你是翻译助手，请将用户的问题翻译为%s，用于检索知识库。
+只输出翻译后的问题，保留专有名词、产品名称和代码，不要回答问题，不要添加任何解释。
+`
+
+var NodeTranslationPrompt = `
+你是专业的文档翻译，请将用户提供的文档内容翻译为%s。
+要求：
+1. 保留原文的 Markdown 或 HTML 结构、标签和属性不变，只翻译其中的文本
+2. 代码块、行内代码、链接地址和图片地址保持原样
+3. 产品名称和专有名词使用通用译法，没有通用译法时保留原文
+4. 只输出翻译结果，不要添加任何解释
+`
+
+var NodeSummaryPrompt = `
+你是文档总结助手，请根据文档内容总结出文档的摘要。摘要是纯文本，应该简洁明了，不要超过160个字，使用%s输出。
+`
+
 // processContentWithBaseURL adds baseURL prefix to static-file URLs in content
 func processContentWithBaseURL(content, baseURL string) string {
 	if baseURL == "" {
@@ -142,7 +248,7 @@ var NodeFIMSystemPrompt = `
    * 向前看齐（承上）：严格遵循 <FIM_PREFIX> 确立的叙事视角、人物关系、时间线、语气和观点。
    * 向后兼容（启下）：续写内容是通往 <FIM_SUFFIX> 的桥梁。它必须能够作为 <FIM_SUFFIX> 合乎逻辑的直接前文。
 3. 风格与格式：
-   * 语言统一：保持与原文一致的语言（默认为中文）。
+   * 语言统一：保持与原文一致的语言（默认为{{.DefaultLanguage}}）。
    * 格式保留：精确复制原文的段落缩进、列表样式、标点符号（如全/半角，中/英文引号）等格式细节。
    * 术语沿用：确保专有名词和术语在全文中保持一致。
 4. 内容质量：
@@ -4,12 +4,28 @@ const (
 	VectorTaskTopic       = "apps.panda-wiki.vector.task"
 	AnydocTaskExportTopic = "anydoc.persistence.doc.task.export"
 	RagDocUpdateTopic     = "rag.doc.update"
+	EvalTaskTopic         = "apps.panda-wiki.job.eval"
+	TranslateTaskTopic    = "apps.panda-wiki.job.translate"
+	SummaryTaskTopic      = "apps.panda-wiki.job.summary"
+	GitSyncTaskTopic      = "apps.panda-wiki.job.git_sync"
+	CrawlerSyncTaskTopic  = "apps.panda-wiki.job.crawler_sync"
+	ImportTaskTopic       = "apps.panda-wiki.job.import"
+	LinkCheckTaskTopic    = "apps.panda-wiki.job.link_check"
+	NodeImportTaskTopic   = "apps.panda-wiki.job.node_import"
 )
 
 var TopicConsumerName = map[string]string{
 	VectorTaskTopic:       "panda-wiki-vector-consumer",
 	AnydocTaskExportTopic: "anydoc-task-export-consumer",
 	RagDocUpdateTopic:     "rag-doc-update-consumer",
+	EvalTaskTopic:         "panda-wiki-eval-consumer",
+	TranslateTaskTopic:    "panda-wiki-translate-consumer",
+	SummaryTaskTopic:      "panda-wiki-summary-consumer",
+	GitSyncTaskTopic:      "panda-wiki-git-sync-consumer",
+	CrawlerSyncTaskTopic:  "panda-wiki-crawler-sync-consumer",
+	ImportTaskTopic:       "panda-wiki-import-consumer",
+	LinkCheckTaskTopic:    "panda-wiki-link-check-consumer",
+	NodeImportTaskTopic:   "panda-wiki-node-import-consumer",
 }
 
 type NodeReleaseVectorRequest struct {
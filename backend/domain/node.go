@@ -53,8 +53,10 @@ type Node struct {
 	EditorId    string          `json:"editor_id"`
 	EditTime    time.Time       `json:"edit_time"`
 	Permissions NodePermissions `json:"permissions" gorm:"type:jsonb"`
-	CreatedAt   time.Time       `json:"created_at"`
-	UpdatedAt   time.Time       `json:"updated_at"`
+	// key of the node in an external system, unique within a kb, empty for nodes edited in the kb only
+	ExternalID string    `json:"external_id"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
 }
 
 func (Node) TableName() string {
@@ -150,6 +152,8 @@ type CreateNodeReq struct {
 	MaxNode int `json:"-"`
 
 	Position *float64 `json:"position"`
+
+	ExternalID string `json:"external_id"`
 }
 
 type GetNodeListReq struct {
@@ -176,6 +180,7 @@ type NodeListItemResp struct {
 	Editor      string          `json:"editor"`
 	PublisherId string          `json:"publisher_id" gorm:"-"`
 	Permissions NodePermissions `json:"permissions" gorm:"type:jsonb"`
+	ExternalID  string          `json:"external_id"`
 }
 
 type NodeContentChunk struct {
@@ -214,6 +219,7 @@ type NodeContentChunkSSE struct {
 	Summary       string   `json:"summary"`
 	Emoji         string   `json:"emoji"`
 	NodePathNames []string `json:"node_path_names"`
+	URL           string   `json:"url,omitempty"`
 }
 
 type RecommendNodeListResp struct {
@@ -0,0 +1,191 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type NodeChunkEditType string
+
+const (
+	// NodeChunkEditTypeUpdate changes the content, keywords or availability of a generated chunk
+	NodeChunkEditTypeUpdate NodeChunkEditType = "update"
+	// NodeChunkEditTypeAdd adds a manual chunk such as synonyms or Q&A hints
+	NodeChunkEditTypeAdd NodeChunkEditType = "add"
+)
+
+type NodeChunkEditStatus string
+
+const (
+	NodeChunkEditStatusApplied NodeChunkEditStatus = "applied"
+	// NodeChunkEditStatusStale means the edited section changed after re-publishing and the edit was not applied
+	NodeChunkEditStatusStale NodeChunkEditStatus = "stale"
+)
+
+// NodeChunkEdit is a manual change of the chunks of a node, it is re-applied after every publish
+type NodeChunkEdit struct {
+	ID     string            `json:"id" gorm:"primaryKey"`
+	KBID   string            `json:"kb_id"`
+	NodeID string            `json:"node_id" gorm:"index"`
+	Type   NodeChunkEditType `json:"type"`
+	// hash and content of the generated chunk the update applies to
+	SourceHash    string              `json:"-"`
+	SourceContent string              `json:"-"`
+	Content       string              `json:"content"`
+	Keywords      pq.StringArray      `json:"keywords" gorm:"type:text[]"`
+	Questions     pq.StringArray      `json:"questions" gorm:"type:text[]"`
+	Available     bool                `json:"available"`
+	Status        NodeChunkEditStatus `json:"status"`
+	// rag document and chunk the edit was last applied to
+	DocID     string    `json:"doc_id"`
+	ChunkID   string    `json:"chunk_id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (NodeChunkEdit) TableName() string {
+	return "node_chunk_edits"
+}
+
+// NodeChunkHash identifies the section a generated chunk was split from
+func NodeChunkHash(content string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(content)))
+	return hex.EncodeToString(sum[:])
+}
+
+// NodeChunkMatchScore is the similarity a re-generated chunk needs to carry the edit of a changed chunk
+const NodeChunkMatchScore = 0.6
+
+// NodeChunkSimilarity is the dice coefficient of the character bigrams of two chunks, whitespace and case
+// are ignored so that re-chunking with other separators still matches
+func NodeChunkSimilarity(a, b string) float64 {
+	bigrams := func(text string) map[string]int {
+		runes := []rune(strings.ToLower(strings.Join(strings.Fields(text), "")))
+		result := make(map[string]int, len(runes))
+		for i := 0; i+1 < len(runes); i++ {
+			result[string(runes[i:i+2])]++
+		}
+		return result
+	}
+	x, y := bigrams(a), bigrams(b)
+	total := 0
+	for _, n := range x {
+		total += n
+	}
+	for _, n := range y {
+		total += n
+	}
+	if total == 0 {
+		return 0
+	}
+	shared := 0
+	for bigram, n := range x {
+		shared += min(n, y[bigram])
+	}
+	return 2 * float64(shared) / float64(total)
+}
+
+// MatchNodeChunkEdits finds the chunk each update edit applies to after the document was chunked again.
+// an unchanged chunk is matched by hash, otherwise the most similar chunk above NodeChunkMatchScore that
+// no other edit took is used. edits without a match are missing from the result and become stale
+func MatchNodeChunkEdits(edits []*NodeChunkEdit, chunks []*RAGChunk) map[string]*RAGChunk {
+	matched := make(map[string]*RAGChunk, len(edits))
+	taken := make(map[string]bool, len(edits))
+	byHash := make(map[string]*RAGChunk, len(chunks))
+	for _, chunk := range chunks {
+		byHash[NodeChunkHash(chunk.Content)] = chunk
+	}
+	pending := make([]*NodeChunkEdit, 0, len(edits))
+	for _, edit := range edits {
+		if edit.Type != NodeChunkEditTypeUpdate {
+			continue
+		}
+		if chunk, ok := byHash[edit.SourceHash]; ok && !taken[chunk.ID] {
+			matched[edit.ID] = chunk
+			taken[chunk.ID] = true
+			continue
+		}
+		pending = append(pending, edit)
+	}
+	for _, edit := range pending {
+		// edits created before the source content was kept can only be matched by hash
+		if edit.SourceContent == "" {
+			continue
+		}
+		var best *RAGChunk
+		bestScore := NodeChunkMatchScore
+		for _, chunk := range chunks {
+			if taken[chunk.ID] {
+				continue
+			}
+			if score := NodeChunkSimilarity(edit.SourceContent, chunk.Content); score >= bestScore {
+				best, bestScore = chunk, score
+			}
+		}
+		if best != nil {
+			matched[edit.ID] = best
+			taken[best.ID] = true
+		}
+	}
+	return matched
+}
+
+// RAGChunk is a chunk of a document in the rag service
+type RAGChunk struct {
+	ID        string   `json:"id"`
+	Content   string   `json:"content"`
+	Keywords  []string `json:"keywords"`
+	Questions []string `json:"questions"`
+	Available bool     `json:"available"`
+}
+
+type UpdateRAGChunkReq struct {
+	Content   string
+	Keywords  []string
+	Available *bool
+}
+
+type NodeChunkItem struct {
+	*RAGChunk
+	// edit applied to the chunk, nil for untouched chunks
+	Edit *NodeChunkEdit `json:"edit"`
+}
+
+type NodeChunkListReq struct {
+	KBID   string `json:"kb_id" query:"kb_id" validate:"required"`
+	NodeID string `json:"node_id" query:"node_id" validate:"required"`
+}
+
+type NodeChunkListResp struct {
+	NodeReleaseID string           `json:"node_release_id"`
+	DocID         string           `json:"doc_id"`
+	Chunks        []*NodeChunkItem `json:"chunks"`
+	// edits whose section changed after re-publishing
+	StaleEdits []*NodeChunkEdit `json:"stale_edits"`
+}
+
+type UpdateNodeChunkReq struct {
+	KBID      string    `json:"kb_id" validate:"required"`
+	NodeID    string    `json:"node_id" validate:"required"`
+	ChunkID   string    `json:"chunk_id" validate:"required"`
+	Content   *string   `json:"content"`
+	Keywords  *[]string `json:"keywords"`
+	Available *bool     `json:"available"`
+}
+
+type AddNodeChunkReq struct {
+	KBID      string   `json:"kb_id" validate:"required"`
+	NodeID    string   `json:"node_id" validate:"required"`
+	Content   string   `json:"content" validate:"required"`
+	Keywords  []string `json:"keywords"`
+	Questions []string `json:"questions"`
+}
+
+type DeleteNodeChunkEditReq struct {
+	KBID string `json:"kb_id" query:"kb_id" validate:"required"`
+	ID   string `json:"id" query:"id" validate:"required"`
+}
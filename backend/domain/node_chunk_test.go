@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodeChunkSimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, NodeChunkSimilarity("Install the agent", "install  the\nagent"))
+	assert.Equal(t, 0.0, NodeChunkSimilarity("", ""))
+	assert.Equal(t, 0.0, NodeChunkSimilarity("abc", "xyz"))
+	assert.InDelta(t, 0.8, NodeChunkSimilarity("abcdef", "abcxef"), 0.21)
+}
+
+func TestMatchNodeChunkEdits(t *testing.T) {
+	install := "Install > Linux\n\nDownload the package and run the installer as root, then start the service with systemctl."
+	usage := "Usage\n\nOpen the web console on port 2443 and log in with the admin password printed by the installer."
+	faq := "FAQ\n\nThe service does not start when the port is already used by another program."
+	edit := func(id, source string) *NodeChunkEdit {
+		return &NodeChunkEdit{ID: id, Type: NodeChunkEditTypeUpdate, SourceHash: NodeChunkHash(source), SourceContent: source}
+	}
+
+	t.Run("unchanged chunk is matched by hash", func(t *testing.T) {
+		chunks := []*RAGChunk{{ID: "c1", Content: usage}, {ID: "c2", Content: install}}
+		matched := MatchNodeChunkEdits([]*NodeChunkEdit{edit("e1", install)}, chunks)
+		assert.Equal(t, "c2", matched["e1"].ID)
+	})
+
+	t.Run("slightly changed section follows the edit", func(t *testing.T) {
+		changed := "Install > Linux\n\nDownload the latest package and run the installer as root, then start the service with systemctl."
+		chunks := []*RAGChunk{{ID: "c1", Content: changed}, {ID: "c2", Content: usage}}
+		matched := MatchNodeChunkEdits([]*NodeChunkEdit{edit("e1", install), edit("e2", usage)}, chunks)
+		assert.Equal(t, "c1", matched["e1"].ID)
+		assert.Equal(t, "c2", matched["e2"].ID)
+	})
+
+	t.Run("image caption added to the section", func(t *testing.T) {
+		captioned := usage + "\n\n![console](/static-file/kb/console.png)\nThe login page of the web console."
+		chunks := []*RAGChunk{{ID: "c1", Content: install}, {ID: "c2", Content: captioned}}
+		matched := MatchNodeChunkEdits([]*NodeChunkEdit{edit("e1", usage)}, chunks)
+		assert.Equal(t, "c2", matched["e1"].ID)
+	})
+
+	t.Run("document split differently", func(t *testing.T) {
+		chunks := []*RAGChunk{
+			{ID: "c1", Content: "Install\n\n" + install[len("Install > Linux\n\n"):]},
+			{ID: "c2", Content: usage + "\n\n" + faq[:20]},
+			{ID: "c3", Content: faq[20:]},
+		}
+		matched := MatchNodeChunkEdits([]*NodeChunkEdit{edit("e1", install), edit("e2", usage), edit("e3", faq)}, chunks)
+		assert.Equal(t, "c1", matched["e1"].ID)
+		assert.Equal(t, "c2", matched["e2"].ID)
+		// most of the faq moved to its own chunk
+		assert.Equal(t, "c3", matched["e3"].ID)
+	})
+
+	t.Run("removed section is not matched", func(t *testing.T) {
+		chunks := []*RAGChunk{{ID: "c1", Content: usage}}
+		matched := MatchNodeChunkEdits([]*NodeChunkEdit{edit("e1", install), edit("e2", faq)}, chunks)
+		assert.Empty(t, matched)
+	})
+
+	t.Run("a chunk carries one edit", func(t *testing.T) {
+		changed := install + " Reboot afterwards."
+		chunks := []*RAGChunk{{ID: "c1", Content: changed}}
+		matched := MatchNodeChunkEdits([]*NodeChunkEdit{edit("e1", install), edit("e2", install+" Reboot.")}, chunks)
+		assert.Len(t, matched, 1)
+	})
+
+	t.Run("edits without source content need the same hash", func(t *testing.T) {
+		old := &NodeChunkEdit{ID: "e1", Type: NodeChunkEditTypeUpdate, SourceHash: NodeChunkHash(install)}
+		chunks := []*RAGChunk{{ID: "c1", Content: install + " Reboot afterwards."}}
+		assert.Empty(t, MatchNodeChunkEdits([]*NodeChunkEdit{old}, chunks))
+	})
+
+	t.Run("add edits are ignored", func(t *testing.T) {
+		added := &NodeChunkEdit{ID: "e1", Type: NodeChunkEditTypeAdd, SourceContent: install}
+		chunks := []*RAGChunk{{ID: "c1", Content: install}}
+		assert.Empty(t, MatchNodeChunkEdits([]*NodeChunkEdit{added}, chunks))
+	})
+}
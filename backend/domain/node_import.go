@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// NodeImportSource is the format of a file imported into nodes in process, without the crawler service
+type NodeImportSource string
+
+const (
+	// a zip of markdown files, Obsidian vaults included
+	NodeImportSourceMarkdown NodeImportSource = "markdown"
+	// a word document
+	NodeImportSourceDocx NodeImportSource = "docx"
+	// an OpenAPI 3 or Swagger 2 spec in json or yaml, a document per operation
+	NodeImportSourceOpenAPI NodeImportSource = "openapi"
+	// a MediaWiki XML export, plain or compressed with gzip or bzip2
+	NodeImportSourceMediaWiki NodeImportSource = "mediawiki"
+)
+
+const MaxNodeImportFileSize = 200 << 20
+
+type NodeImportJobStatus string
+
+const (
+	NodeImportJobStatusPending   NodeImportJobStatus = "pending"
+	NodeImportJobStatusRunning   NodeImportJobStatus = "running"
+	NodeImportJobStatusCompleted NodeImportJobStatus = "completed"
+	NodeImportJobStatusFailed    NodeImportJobStatus = "failed"
+)
+
+// a running job without progress for this long is considered lost, e.g. by a restart of the consumer, and is run again
+const NodeImportJobStaleAfter = 30 * time.Minute
+
+// NodeImportJob imports an uploaded file into nodes in the consumer
+type NodeImportJob struct {
+	ID         string              `json:"id" gorm:"primaryKey"`
+	KBID       string              `json:"kb_id" gorm:"index"`
+	ParentID   string              `json:"parent_id"`
+	Source     NodeImportSource    `json:"source"`
+	Key        string              `json:"key"`
+	Name       string              `json:"name"`
+	SplitLevel int                 `json:"split_level"`
+	Status     NodeImportJobStatus `json:"status"`
+	Error      string              `json:"error"`
+	// documents found in the file and the number of them written so far
+	Total int `json:"total"`
+	Done  int `json:"done"`
+	// result of each document, nil until the job is completed
+	Result *UpsertNodesResp `json:"result,omitempty" gorm:"type:jsonb"`
+	// documents are created on behalf of the user who created the job
+	CreatorID string     `json:"creator_id"`
+	MaxNode   int        `json:"-"`
+	CreatedAt time.Time  `json:"created_at"`
+	StartedAt *time.Time `json:"started_at"`
+	// moved forward whenever a document is written, a stale heartbeat marks a lost job
+	HeartbeatAt *time.Time `json:"heartbeat_at"`
+	FinishedAt  *time.Time `json:"finished_at"`
+}
+
+func (NodeImportJob) TableName() string {
+	return "node_import_jobs"
+}
+
+// DocExternalID is given to the documents of the file without an id of their own, so that a job run again
+// after an interruption updates the nodes it created before instead of creating them twice
+func (j *NodeImportJob) DocExternalID(key string) string {
+	return fmt.Sprintf("file-import:%s:%s", j.ID, key)
+}
+
+type NodeImportReq struct {
+	KBID string `json:"kb_id" validate:"required"`
+	// folder the imported tree is placed under, empty for the root of the kb
+	ParentID string           `json:"parent_id"`
+	Source   NodeImportSource `json:"source" validate:"required,oneof=markdown docx openapi mediawiki"`
+	// key of the file returned by /api/v1/file/upload
+	Key string `json:"key" validate:"required"`
+	// name of the imported document or folder, for single file sources
+	Name string `json:"name"`
+	// docx only: 0 imports one document, 1 splits it into a document per Heading 1, 2 also per Heading 2
+	SplitLevel int `json:"split_level" validate:"min=0,max=2"`
+
+	MaxNode int `json:"-"`
+}
+
+type NodeImportJobListReq struct {
+	KBID string `json:"kb_id" query:"kb_id" validate:"required"`
+	Pager
+}
+
+type NodeImportJobDetailReq struct {
+	KBID string `json:"kb_id" query:"kb_id" validate:"required"`
+	ID   string `json:"id" query:"id" validate:"required"`
+}
+
+// NodeImportTaskRequest is published to run a node import job in the consumer
+type NodeImportTaskRequest struct {
+	JobID string `json:"job_id"`
+}
@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// NodeLinkKind is how a document refers to another one
+type NodeLinkKind string
+
+const (
+	// a /node/<id> url, the target is the id of the node
+	NodeLinkKindURL NodeLinkKind = "url"
+	// a [[Title]] wiki-link, the target is the title, it is resolved by name when the links are read
+	// so that creating or renaming a document fixes the links to it
+	NodeLinkKindWiki NodeLinkKind = "wiki"
+)
+
+// NodeLink is a reference from the content of a document to another document of the kb
+type NodeLink struct {
+	KBID      string       `json:"kb_id"`
+	SourceID  string       `json:"source_id" gorm:"primaryKey"`
+	Kind      NodeLinkKind `json:"kind" gorm:"primaryKey"`
+	Target    string       `json:"target" gorm:"primaryKey"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+func (NodeLink) TableName() string {
+	return "node_links"
+}
+
+var (
+	nodeLinkCodeRegexps = []*regexp.Regexp{
+		regexp.MustCompile("(?s)```.*?```"),
+		regexp.MustCompile("(?s)~~~.*?~~~"),
+		regexp.MustCompile("`[^`\n]*`"),
+		regexp.MustCompile(`(?is)<pre[\s>].*?</pre>`),
+		regexp.MustCompile(`(?is)<code[\s>].*?</code>`),
+	}
+	nodeURLRegexp  = regexp.MustCompile(`/node/([0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12})`)
+	wikiLinkRegexp = regexp.MustCompile(`\[\[([^\[\]|#\n]+)(?:#[^\[\]|\n]*)?(?:\|[^\[\]\n]*)?\]\]`)
+)
+
+// NewNodeLinks extracts the /node/<id> urls and [[Title]] wiki-links of the content of a document.
+// links in code are ignored, and so are links of the document to itself
+func NewNodeLinks(kbID, sourceID, content string) []*NodeLink {
+	for _, re := range nodeLinkCodeRegexps {
+		content = re.ReplaceAllString(content, "")
+	}
+	now := time.Now()
+	links := make([]*NodeLink, 0)
+	seen := make(map[NodeLinkKind]map[string]bool)
+	add := func(kind NodeLinkKind, target string) {
+		if seen[kind] == nil {
+			seen[kind] = make(map[string]bool)
+		}
+		if target == "" || seen[kind][target] || kind == NodeLinkKindURL && target == sourceID {
+			return
+		}
+		seen[kind][target] = true
+		links = append(links, &NodeLink{KBID: kbID, SourceID: sourceID, Kind: kind, Target: target, CreatedAt: now})
+	}
+	for _, match := range nodeURLRegexp.FindAllStringSubmatch(content, -1) {
+		add(NodeLinkKindURL, strings.ToLower(match[1]))
+	}
+	for _, match := range wikiLinkRegexp.FindAllStringSubmatch(content, -1) {
+		add(NodeLinkKindWiki, strings.Join(strings.Fields(match[1]), " "))
+	}
+	return links
+}
+
+// NodeLinkRef is a document at one end of a link
+type NodeLinkRef struct {
+	ID    string       `json:"id"`
+	Name  string       `json:"name"`
+	Kind  NodeLinkKind `json:"kind"`
+	Emoji string       `json:"emoji"`
+}
+
+// NodeBacklink is a document linking to TargetID
+type NodeBacklink struct {
+	NodeLinkRef
+	TargetID string `json:"target_id"`
+}
+
+// NodeOutgoingLink is a link of a document, ID is empty when no document of the kb matches the link
+type NodeOutgoingLink struct {
+	NodeLinkRef
+	Target string `json:"target"`
+	Broken bool   `json:"broken"`
+}
+
+type NodeLinksResp struct {
+	Backlinks []*NodeBacklink     `json:"backlinks"`
+	Outgoing  []*NodeOutgoingLink `json:"outgoing"`
+}
+
+type NodeLinkGraphNode struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	ParentID string `json:"parent_id"`
+	Emoji    string `json:"emoji"`
+	// number of documents linking to the node
+	Backlinks int `json:"backlinks"`
+}
+
+type NodeLinkGraphEdge struct {
+	Source string       `json:"source"`
+	Target string       `json:"target"`
+	Kind   NodeLinkKind `json:"kind"`
+}
+
+// NodeLinkGraph is the documents of a kb with the links between them
+type NodeLinkGraph struct {
+	Nodes []*NodeLinkGraphNode `json:"nodes"`
+	Edges []*NodeLinkGraphEdge `json:"edges"`
+}
+
+type NodeLinksReq struct {
+	KBID string `json:"kb_id" query:"kb_id" validate:"required"`
+	ID   string `json:"id" query:"id" validate:"required"`
+}
+
+type NodeLinkGraphReq struct {
+	KBID string `json:"kb_id" query:"kb_id" validate:"required"`
+}
+
+type NodeDeleteCheckReq struct {
+	KBID string   `json:"kb_id" validate:"required"`
+	IDs  []string `json:"ids" validate:"required,min=1"`
+}
+
+// NodeDeleteCheckResp lists the documents that keep linking to the deleted ones, their links break with the delete
+type NodeDeleteCheckResp struct {
+	Backlinks []*NodeBacklink `json:"backlinks"`
+}
+
+// NodeActionResp warns about the links broken by a delete
+type NodeActionResp struct {
+	BrokenLinks []*NodeBacklink `json:"broken_links"`
+}
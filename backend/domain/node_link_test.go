@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewNodeLinks(t *testing.T) {
+	content := "see [doc](/node/0198A2B4-1C2D-7E3F-8A9B-0C1D2E3F4A5B) and [[ Getting  Started #install | the guide]]\n" +
+		"again /node/0198a2b4-1c2d-7e3f-8a9b-0c1d2e3f4a5b and [[Getting Started]]\n" +
+		"self /node/0198a2b4-0000-7e3f-8a9b-0c1d2e3f4a5b\n" +
+		"```\n[[In Code]] /node/0198a2b4-1111-7e3f-8a9b-0c1d2e3f4a5b\n```\n" +
+		"`[[Inline]]` <code>[[Html Code]]</code> [[FAQ]]"
+	links := NewNodeLinks("kb", "0198a2b4-0000-7e3f-8a9b-0c1d2e3f4a5b", content)
+
+	targets := make(map[NodeLinkKind][]string)
+	for _, link := range links {
+		assert.Equal(t, "kb", link.KBID)
+		assert.Equal(t, "0198a2b4-0000-7e3f-8a9b-0c1d2e3f4a5b", link.SourceID)
+		targets[link.Kind] = append(targets[link.Kind], link.Target)
+	}
+	assert.Equal(t, []string{"0198a2b4-1c2d-7e3f-8a9b-0c1d2e3f4a5b"}, targets[NodeLinkKindURL])
+	assert.Equal(t, []string{"Getting Started", "FAQ"}, targets[NodeLinkKindWiki])
+}
+
+func TestNewNodeLinksEmpty(t *testing.T) {
+	assert.Empty(t, NewNodeLinks("kb", "node", "no links, [not a wiki link] and [[]]"))
+}
@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+type NodeTranslationStatus string
+
+const (
+	NodeTranslationStatusPending   NodeTranslationStatus = "pending"
+	NodeTranslationStatusRunning   NodeTranslationStatus = "running"
+	NodeTranslationStatusCompleted NodeTranslationStatus = "completed"
+	NodeTranslationStatusFailed    NodeTranslationStatus = "failed"
+)
+
+// NodeTranslationStaleAfter is how long a translation may run, a running translation not updated for longer
+// was lost, e.g. by a restart of the consumer, and can be queued again
+const NodeTranslationStaleAfter = time.Hour
+
+// NodeTranslation links a machine translated variant node to its source node
+type NodeTranslation struct {
+	ID           string `json:"id" gorm:"primaryKey"`
+	KBID         string `json:"kb_id"`
+	SourceNodeID string `json:"source_node_id"`
+	// variant node, empty until the first translation completes
+	NodeID   string `json:"node_id"`
+	Language string `json:"language"`
+	// hash of the source name and content the variant was translated from
+	SourceHash string                `json:"-"`
+	Status     NodeTranslationStatus `json:"status"`
+	Error      string                `json:"error"`
+	CreatedAt  time.Time             `json:"created_at"`
+	UpdatedAt  time.Time             `json:"updated_at"`
+
+	// the source changed after the variant was translated
+	Stale bool `json:"stale" gorm:"-"`
+}
+
+func (NodeTranslation) TableName() string {
+	return "node_translations"
+}
+
+func NodeTranslationHash(name, content string) string {
+	sum := sha256.Sum256([]byte(name + "\n" + content))
+	return hex.EncodeToString(sum[:])
+}
+
+// Retryable tells whether the translation can be queued again: it finished, or it is still running long
+// after it was started
+func (t *NodeTranslation) Retryable(now time.Time) bool {
+	switch t.Status {
+	case NodeTranslationStatusCompleted, NodeTranslationStatusFailed:
+		return true
+	case NodeTranslationStatusRunning:
+		return t.UpdatedAt.Before(now.Add(-NodeTranslationStaleAfter))
+	}
+	return false
+}
+
+// CheckStale marks the translation stale if the source changed since it was translated
+func (t *NodeTranslation) CheckStale(source *Node) {
+	t.Stale = t.Status == NodeTranslationStatusCompleted && t.SourceHash != NodeTranslationHash(source.Name, source.Content)
+}
+
+// SplitForTranslation splits content into parts of about maxRunes characters at line boundaries,
+// fenced code blocks are never split so that the model sees them whole
+func SplitForTranslation(content string, maxRunes int) []string {
+	parts := make([]string, 0)
+	var (
+		current []string
+		size    int
+		fence   string
+	)
+	for _, line := range strings.Split(content, "\n") {
+		lineSize := utf8.RuneCountInString(line) + 1
+		if fence == "" && len(current) > 0 && size+lineSize > maxRunes {
+			parts = append(parts, strings.Join(current, "\n"))
+			current, size = nil, 0
+		}
+		current = append(current, line)
+		size += lineSize
+		if m := markdownFenceRegex.FindStringSubmatch(line); m != nil {
+			switch {
+			case fence == "":
+				fence = m[1]
+			case strings.HasPrefix(strings.TrimSpace(line), fence):
+				fence = ""
+			}
+		}
+	}
+	if len(current) > 0 {
+		parts = append(parts, strings.Join(current, "\n"))
+	}
+	return parts
+}
+
+type TranslateNodeReq struct {
+	KBID     string `json:"kb_id" validate:"required"`
+	ID       string `json:"id" validate:"required"`
+	Language string `json:"language" validate:"required"`
+	// also translate the descendants of the node
+	Recursive bool `json:"recursive"`
+}
+
+type NodeTranslationListReq struct {
+	KBID   string `json:"kb_id" query:"kb_id" validate:"required"`
+	NodeID string `json:"node_id" query:"node_id" validate:"required"`
+}
+
+type NodeTranslationListResp struct {
+	SourceNodeID string             `json:"source_node_id"`
+	Translations []*NodeTranslation `json:"translations"`
+}
+
+// NodeTranslateTaskRequest is published to translate nodes in the consumer, parents come before their children
+type NodeTranslateTaskRequest struct {
+	KBID           string   `json:"kb_id"`
+	TranslationIDs []string `json:"translation_ids"`
+	UserID         string   `json:"user_id"`
+	MaxNode        int      `json:"max_node"`
+}
+
+// NodeTranslationLink is a language variant of a node on the share side
+type NodeTranslationLink struct {
+	Language string `json:"language"`
+	NodeID   string `json:"node_id"`
+	Name     string `json:"name"`
+	Source   bool   `json:"source"`
+}
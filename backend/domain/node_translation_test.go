@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitForTranslation(t *testing.T) {
+	content := "第一段\n第二段\n```go\nfunc a() {}\nfunc b() {}\n```\n第三段"
+	parts := SplitForTranslation(content, 10)
+	assert.Equal(t, content, strings.Join(parts, "\n"))
+	assert.Equal(t, []string{"第一段\n第二段", "```go\nfunc a() {}\nfunc b() {}\n```", "第三段"}, parts)
+
+	assert.Equal(t, []string{"short"}, SplitForTranslation("short", 100))
+}
+
+func TestNodeTranslationCheckStale(t *testing.T) {
+	source := &Node{Name: "安装", Content: "内容"}
+	translation := &NodeTranslation{Status: NodeTranslationStatusCompleted, SourceHash: NodeTranslationHash("安装", "内容")}
+	translation.CheckStale(source)
+	assert.False(t, translation.Stale)
+
+	source.Content = "新内容"
+	translation.CheckStale(source)
+	assert.True(t, translation.Stale)
+
+	translation.Status = NodeTranslationStatusRunning
+	translation.CheckStale(source)
+	assert.False(t, translation.Stale)
+}
+
+func TestNodeTranslationRetryable(t *testing.T) {
+	now := time.Now()
+	cases := []struct {
+		status    NodeTranslationStatus
+		updatedAt time.Time
+		want      bool
+	}{
+		{NodeTranslationStatusPending, now.Add(-2 * NodeTranslationStaleAfter), false},
+		{NodeTranslationStatusRunning, now.Add(-time.Minute), false},
+		{NodeTranslationStatusRunning, now.Add(-NodeTranslationStaleAfter - time.Minute), true},
+		{NodeTranslationStatusCompleted, now, true},
+		{NodeTranslationStatusFailed, now, true},
+	}
+	for _, c := range cases {
+		translation := &NodeTranslation{Status: c.status, UpdatedAt: c.updatedAt}
+		assert.Equal(t, c.want, translation.Retryable(now), "%s updated %s ago", c.status, now.Sub(c.updatedAt))
+	}
+}
@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+)
+
+const MaxUpsertNodeItems = 500
+
+type UpsertNodeAction string
+
+const (
+	UpsertNodeActionCreated   UpsertNodeAction = "created"
+	UpsertNodeActionUpdated   UpsertNodeAction = "updated"
+	UpsertNodeActionUnchanged UpsertNodeAction = "unchanged"
+	UpsertNodeActionDeleted   UpsertNodeAction = "deleted"
+	UpsertNodeActionFailed    UpsertNodeAction = "failed"
+)
+
+// UpsertNodesReq creates or updates documents by their external id, so that pushing the same batch twice changes nothing
+type UpsertNodesReq struct {
+	KBID string `json:"kb_id" validate:"required"`
+	// node the paths of the items are relative to, empty for the root of the kb
+	ParentID string            `json:"parent_id"`
+	Items    []*UpsertNodeItem `json:"items" validate:"required,min=1,dive"`
+	// delete the documents with an external id that are not in the batch
+	DeleteMissing bool `json:"delete_missing"`
+	// only documents whose external id starts with the prefix are deleted, required with delete_missing so that
+	// a batch cannot remove the documents pushed by other integrations
+	DeletePrefix string `json:"delete_prefix"`
+
+	MaxNode int `json:"-"`
+}
+
+type UpsertNodeItem struct {
+	ExternalID string `json:"external_id" validate:"required"`
+	// folders the document is placed in separated by "/", missing folders are created
+	Path    string `json:"path"`
+	Name    string `json:"name" validate:"required"`
+	Content string `json:"content"`
+
+	Emoji       *string `json:"emoji"`
+	Summary     *string `json:"summary"`
+	ContentType *string `json:"content_type"`
+}
+
+func (r *UpsertNodesReq) Validate() error {
+	if len(r.Items) > MaxUpsertNodeItems {
+		return fmt.Errorf("at most %d items can be upserted at a time", MaxUpsertNodeItems)
+	}
+	if r.DeleteMissing && strings.TrimSpace(r.DeletePrefix) == "" {
+		return fmt.Errorf("delete_prefix is required when delete_missing is set")
+	}
+	externalIDs := make(map[string]struct{}, len(r.Items))
+	for _, item := range r.Items {
+		if _, ok := externalIDs[item.ExternalID]; ok {
+			return fmt.Errorf("duplicate external_id %s", item.ExternalID)
+		}
+		externalIDs[item.ExternalID] = struct{}{}
+	}
+	return nil
+}
+
+// SplitNodePath splits a folder path into folder names, empty and relative segments are dropped
+func SplitNodePath(p string) []string {
+	cleaned := strings.Trim(path.Clean("/"+strings.TrimSpace(p)), "/")
+	if cleaned == "" {
+		return nil
+	}
+	folders := make([]string, 0)
+	for _, name := range strings.Split(cleaned, "/") {
+		if name = strings.TrimSpace(name); name != "" {
+			folders = append(folders, name)
+		}
+	}
+	return folders
+}
+
+type UpsertNodeResult struct {
+	ExternalID string           `json:"external_id,omitempty"`
+	Path       string           `json:"path,omitempty"` // path of the document in the imported file, imports only
+	ID         string           `json:"id"`
+	Action     UpsertNodeAction `json:"action"`
+	Error      string           `json:"error,omitempty"`
+}
+
+type UpsertNodesResp struct {
+	Created   int                 `json:"created"`
+	Updated   int                 `json:"updated"`
+	Unchanged int                 `json:"unchanged"`
+	Deleted   int                 `json:"deleted"`
+	Failed    int                 `json:"failed"`
+	Results   []*UpsertNodeResult `json:"results"`
+}
+
+func (r *UpsertNodesResp) Scan(value any) error {
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New(fmt.Sprint("invalid upsert nodes result value type:", value))
+	}
+	return json.Unmarshal(bytes, r)
+}
+
+func (r UpsertNodesResp) Value() (driver.Value, error) {
+	return json.Marshal(r)
+}
+
+// Add records the result of an item and counts it
+func (r *UpsertNodesResp) Add(result *UpsertNodeResult) {
+	switch result.Action {
+	case UpsertNodeActionCreated:
+		r.Created++
+	case UpsertNodeActionUpdated:
+		r.Updated++
+	case UpsertNodeActionUnchanged:
+		r.Unchanged++
+	case UpsertNodeActionDeleted:
+		r.Deleted++
+	case UpsertNodeActionFailed:
+		r.Failed++
+	}
+	r.Results = append(r.Results, result)
+}
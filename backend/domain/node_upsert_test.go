@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitNodePath(t *testing.T) {
+	assert.Nil(t, SplitNodePath(""))
+	assert.Nil(t, SplitNodePath(" / "))
+	assert.Equal(t, []string{"guide", "install"}, SplitNodePath("/guide//install/"))
+	assert.Equal(t, []string{"guide"}, SplitNodePath("../guide/./"))
+}
+
+func TestUpsertNodesReqValidate(t *testing.T) {
+	req := &UpsertNodesReq{Items: []*UpsertNodeItem{{ExternalID: "a"}, {ExternalID: "b"}}}
+	assert.NoError(t, req.Validate())
+	req.Items = append(req.Items, &UpsertNodeItem{ExternalID: "a"})
+	assert.Error(t, req.Validate())
+
+	resp := &UpsertNodesResp{}
+	resp.Add(&UpsertNodeResult{Action: UpsertNodeActionCreated})
+	resp.Add(&UpsertNodeResult{Action: UpsertNodeActionFailed})
+	assert.Equal(t, 1, resp.Created)
+	assert.Equal(t, 1, resp.Failed)
+	assert.Len(t, resp.Results, 2)
+}
+
+func TestUpsertNodesReqValidateDeletePrefix(t *testing.T) {
+	req := &UpsertNodesReq{Items: []*UpsertNodeItem{{ExternalID: "confluence:1"}}, DeleteMissing: true}
+	assert.Error(t, req.Validate())
+	req.DeletePrefix = "  "
+	assert.Error(t, req.Validate())
+	req.DeletePrefix = "confluence:"
+	assert.NoError(t, req.Validate())
+
+	req.DeleteMissing = false
+	req.DeletePrefix = ""
+	assert.NoError(t, req.Validate())
+}
+
+func TestUpsertNodesRespScan(t *testing.T) {
+	resp := &UpsertNodesResp{Results: make([]*UpsertNodeResult, 0)}
+	resp.Add(&UpsertNodeResult{ExternalID: "file-import:j1:a.md", Path: "a.md", ID: "n1", Action: UpsertNodeActionCreated})
+	resp.Add(&UpsertNodeResult{Path: "b.md", Action: UpsertNodeActionFailed, Error: "max node limit reached"})
+
+	value, err := resp.Value()
+	require.NoError(t, err)
+	var scanned UpsertNodesResp
+	require.NoError(t, scanned.Scan(value))
+	assert.Equal(t, resp, &scanned)
+}
@@ -1,11 +1,20 @@
 package domain
 
 import (
+	"encoding/base64"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
+	"math"
 	"strings"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/google/uuid"
 )
 
+// namespace of the conversations mapped from the user field of OpenAI API requests
+var openAIUserConversationNamespace = uuid.MustParse("6f1c7a52-3d0e-4b8a-9f57-2a4e1c8d9b30")
+
 // OpenAI API 请求结构体
 type OpenAICompletionsRequest struct {
 	Model            string                `json:"model" validate:"required"`
@@ -24,6 +33,59 @@ type OpenAICompletionsRequest struct {
 	ResponseFormat   *OpenAIResponseFormat `json:"response_format,omitempty"`
 }
 
+// OpenAIChatContext is the chat request carried by the messages of an OpenAI API request
+type OpenAIChatContext struct {
+	Question     string
+	Images       []string // image urls of the question
+	SystemPrompt string
+	History      []*schema.Message // turns before the question
+}
+
+// ChatContext splits the messages into the last user question, the client system prompt and the turns before the question
+func (r *OpenAICompletionsRequest) ChatContext() *OpenAIChatContext {
+	chatContext := &OpenAIChatContext{}
+	last := -1
+	for i := len(r.Messages) - 1; i >= 0; i-- {
+		if r.Messages[i].Role == string(schema.User) {
+			last = i
+			break
+		}
+	}
+	if last < 0 {
+		return chatContext
+	}
+	if content := r.Messages[last].Content; content != nil {
+		chatContext.Question = content.String()
+		chatContext.Images = content.ImageURLs()
+	}
+	systemPrompts := make([]string, 0)
+	chatContext.History = make([]*schema.Message, 0, last)
+	for _, message := range r.Messages[:last] {
+		content := ""
+		if message.Content != nil {
+			content = strings.TrimSpace(message.Content.String())
+		}
+		if content == "" {
+			continue
+		}
+		switch message.Role {
+		case string(schema.System), "developer":
+			systemPrompts = append(systemPrompts, content)
+		case string(schema.User):
+			chatContext.History = append(chatContext.History, schema.UserMessage(content))
+		case string(schema.Assistant):
+			chatContext.History = append(chatContext.History, schema.AssistantMessage(content, nil))
+		}
+	}
+	chatContext.SystemPrompt = strings.Join(systemPrompts, "\n")
+	return chatContext
+}
+
+// OpenAIUserConversationID returns the stable conversation id of an OpenAI API user
+func OpenAIUserConversationID(kbID, user string) string {
+	return uuid.NewSHA1(openAIUserConversationNamespace, []byte(kbID+"/"+user)).String()
+}
+
 type OpenAIStreamOptions struct {
 	IncludeUsage bool `json:"include_usage,omitempty"`
 }
@@ -110,6 +172,17 @@ func (mc *MessageContent) String() string {
 	return builder.String()
 }
 
+// ImageURLs returns the urls of the image parts
+func (mc *MessageContent) ImageURLs() []string {
+	urls := make([]string, 0)
+	for _, part := range mc.arrValue {
+		if part.Type == "image_url" && part.ImageURL != nil && part.ImageURL.URL != "" {
+			urls = append(urls, part.ImageURL.URL)
+		}
+	}
+	return urls
+}
+
 type OpenAIMessage struct {
 	Role       string           `json:"role" validate:"required"`
 	Content    *MessageContent  `json:"content,omitempty"`
@@ -161,6 +234,10 @@ type OpenAICompletionsResponse struct {
 	Model   string         `json:"model"`
 	Choices []OpenAIChoice `json:"choices"`
 	Usage   *OpenAIUsage   `json:"usage,omitempty"`
+	// PandaWiki extension: follow-up questions, only when enabled in conversation settings
+	Suggestions []string `json:"suggestions,omitempty"`
+	// PandaWiki extension: documents retrieved to answer the question
+	Sources []OpenAISource `json:"sources,omitempty"`
 }
 
 type OpenAIChoice struct {
@@ -184,6 +261,10 @@ type OpenAIStreamResponse struct {
 	Model   string               `json:"model"`
 	Choices []OpenAIStreamChoice `json:"choices"`
 	Usage   *OpenAIUsage         `json:"usage,omitempty"`
+	// PandaWiki extension: follow-up questions, sent in a trailing chunk without choices
+	Suggestions []string `json:"suggestions,omitempty"`
+	// PandaWiki extension: retrieved documents, sent in a chunk without choices before the answer
+	Sources []OpenAISource `json:"sources,omitempty"`
 }
 
 type OpenAIStreamChoice struct {
@@ -203,3 +284,108 @@ type OpenAIError struct {
 	Code    string `json:"code,omitempty"`
 	Param   string `json:"param,omitempty"`
 }
+
+// OpenAISource is a document retrieved to answer the question
+type OpenAISource struct {
+	NodeID    string   `json:"node_id"`
+	Name      string   `json:"name"`
+	Summary   string   `json:"summary,omitempty"`
+	URL       string   `json:"url,omitempty"`
+	PathNames []string `json:"path_names,omitempty"`
+}
+
+// OpenAI 模型列表响应结构体
+type OpenAIModelList struct {
+	Object string        `json:"object"`
+	Data   []OpenAIModel `json:"data"`
+}
+
+type OpenAIModel struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	Created int64  `json:"created"`
+	OwnedBy string `json:"owned_by"`
+}
+
+// 单次向量请求的限制，每条输入都会发送给向量模型
+const (
+	MaxEmbeddingsInputItems = 256
+	MaxEmbeddingsInputBytes = 32 * 1024
+)
+
+// OpenAI 向量请求结构体
+type OpenAIEmbeddingsRequest struct {
+	Model          string                `json:"model"`
+	Input          OpenAIEmbeddingsInput `json:"input"`
+	EncodingFormat string                `json:"encoding_format,omitempty"`
+}
+
+func (r *OpenAIEmbeddingsRequest) Validate() error {
+	if len(r.Input) == 0 {
+		return fmt.Errorf("input cannot be empty")
+	}
+	if len(r.Input) > MaxEmbeddingsInputItems {
+		return fmt.Errorf("at most %d inputs can be embedded at a time", MaxEmbeddingsInputItems)
+	}
+	for i, input := range r.Input {
+		if input == "" {
+			return fmt.Errorf("input[%d] cannot be empty", i)
+		}
+		if len(input) > MaxEmbeddingsInputBytes {
+			return fmt.Errorf("input[%d] exceeds %d bytes", i, MaxEmbeddingsInputBytes)
+		}
+	}
+	if r.EncodingFormat != "" && r.EncodingFormat != "float" && r.EncodingFormat != "base64" {
+		return fmt.Errorf("encoding_format must be float or base64")
+	}
+	return nil
+}
+
+// OpenAIEmbeddingsInput 支持字符串或字符串数组
+type OpenAIEmbeddingsInput []string
+
+func (in *OpenAIEmbeddingsInput) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		*in = OpenAIEmbeddingsInput{str}
+		return nil
+	}
+	var arr []string
+	if err := json.Unmarshal(data, &arr); err != nil {
+		return fmt.Errorf("input must be a string or an array of strings")
+	}
+	*in = arr
+	return nil
+}
+
+// OpenAI 向量响应结构体
+type OpenAIEmbeddingsResponse struct {
+	Object string            `json:"object"`
+	Data   []OpenAIEmbedding `json:"data"`
+	Model  string            `json:"model"`
+	Usage  *OpenAIUsage      `json:"usage,omitempty"`
+}
+
+type OpenAIEmbedding struct {
+	Object    string    `json:"object"`
+	Index     int       `json:"index"`
+	Embedding []float32 `json:"embedding"`
+	// encode the embedding as base64 of little-endian float32 when marshaling
+	Base64 bool `json:"-"`
+}
+
+func (e OpenAIEmbedding) MarshalJSON() ([]byte, error) {
+	if !e.Base64 {
+		type embedding OpenAIEmbedding
+		return json.Marshal(embedding(e))
+	}
+	buf := make([]byte, 4*len(e.Embedding))
+	for i, v := range e.Embedding {
+		binary.LittleEndian.PutUint32(buf[4*i:], math.Float32bits(v))
+	}
+	return json.Marshal(struct {
+		Object    string `json:"object"`
+		Index     int    `json:"index"`
+		Embedding string `json:"embedding"`
+	}{e.Object, e.Index, base64.StdEncoding.EncodeToString(buf)})
+}
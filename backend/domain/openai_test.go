@@ -2,8 +2,10 @@ package domain
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 
+	"github.com/cloudwego/eino/schema"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -184,3 +186,60 @@ func TestMessageContent_String_NoTextParts(t *testing.T) {
 	})
 	assert.Equal(t, "", mc.String())
 }
+
+func TestOpenAICompletionsRequest_ChatContext(t *testing.T) {
+	var req OpenAICompletionsRequest
+	err := json.Unmarshal([]byte(`{
+		"model": "pandawiki",
+		"messages": [
+			{"role": "system", "content": "Answer in English."},
+			{"role": "user", "content": "What is PandaWiki?"},
+			{"role": "assistant", "content": "A knowledge base system."},
+			{"role": "assistant", "tool_calls": []},
+			{"role": "user", "content": [{"type": "text", "text": "How to deploy"}, {"type": "text", "text": "it?"}]}
+		]
+	}`), &req)
+	require.NoError(t, err)
+
+	chatContext := req.ChatContext()
+	assert.Equal(t, "How to deploy it?", chatContext.Question)
+	assert.Equal(t, "Answer in English.", chatContext.SystemPrompt)
+	assert.Empty(t, chatContext.Images)
+	require.Len(t, chatContext.History, 2)
+	assert.Equal(t, schema.User, chatContext.History[0].Role)
+	assert.Equal(t, "What is PandaWiki?", chatContext.History[0].Content)
+	assert.Equal(t, schema.Assistant, chatContext.History[1].Role)
+
+	req.Messages = []OpenAIMessage{{Role: "system", Content: NewStringContent("only system")}}
+	chatContext = req.ChatContext()
+	assert.Empty(t, chatContext.Question)
+	assert.Empty(t, chatContext.History)
+
+	err = json.Unmarshal([]byte(`[{"type":"image_url","image_url":{"url":"data:image/png;base64,AAAA"}}]`), &req.Messages[0].Content)
+	require.NoError(t, err)
+	req.Messages[0].Role = "user"
+	chatContext = req.ChatContext()
+	assert.Empty(t, chatContext.Question)
+	assert.Equal(t, []string{"data:image/png;base64,AAAA"}, chatContext.Images)
+}
+
+func TestOpenAIEmbeddingsRequest_Validate(t *testing.T) {
+	req := OpenAIEmbeddingsRequest{Input: OpenAIEmbeddingsInput{"hello", "world"}}
+	assert.NoError(t, req.Validate())
+
+	req.Input = nil
+	assert.Error(t, req.Validate())
+
+	req.Input = make(OpenAIEmbeddingsInput, MaxEmbeddingsInputItems+1)
+	for i := range req.Input {
+		req.Input[i] = "hello"
+	}
+	assert.Error(t, req.Validate())
+
+	req.Input = OpenAIEmbeddingsInput{strings.Repeat("a", MaxEmbeddingsInputBytes+1)}
+	assert.Error(t, req.Validate())
+
+	req.Input = OpenAIEmbeddingsInput{"hello"}
+	req.EncodingFormat = "int8"
+	assert.Error(t, req.Validate())
+}
@@ -8,6 +8,8 @@ import (
 const (
 	SettingKeySystemPrompt = "system_prompt"
 	SettingBlockWords      = "block_words"
+	SettingKeyChunk        = "chunk"
+	SettingKeyLanguage     = "language"
 	SettingCopyrightInfo   = "本网站由 PandaWiki 提供技术支持"
 )
 
@@ -5,4 +5,8 @@ type SSEEvent struct {
 	Content     string               `json:"content"`
 	ChunkResult *NodeContentChunkSSE `json:"chunk_result,omitempty"`
 	Error       string               `json:"error,omitempty"`
+	Suggestions []string             `json:"suggestions,omitempty"` // follow-up questions, sent after done
+	Usage       *OpenAIUsage         `json:"usage,omitempty"`       // token usage of the answer, sent with done
+
+	ID string `json:"-"` // event id in the resumable stream log, sent as SSE id
 }
@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+type SummaryJobStatus string
+
+const (
+	SummaryJobStatusPending   SummaryJobStatus = "pending"
+	SummaryJobStatusRunning   SummaryJobStatus = "running"
+	SummaryJobStatusCompleted SummaryJobStatus = "completed"
+	SummaryJobStatusFailed    SummaryJobStatus = "failed"
+)
+
+const (
+	DefaultSummaryJobConcurrency = 2
+	MaxSummaryJobConcurrency     = 8
+	// a running job without progress for this long is considered lost, e.g. by a restart of the consumer, and is resumed
+	SummaryJobStaleAfter = 30 * time.Minute
+)
+
+// SummaryJob generates the summaries of all documents of a kb that have none
+type SummaryJob struct {
+	ID          string           `json:"id" gorm:"primaryKey"`
+	KBID        string           `json:"kb_id" gorm:"index"`
+	Status      SummaryJobStatus `json:"status"`
+	Concurrency int              `json:"concurrency"`
+	Error       string           `json:"error"`
+	CreatedAt   time.Time        `json:"created_at"`
+	StartedAt   *time.Time       `json:"started_at"`
+	// moved forward whenever an item changes state, a stale heartbeat marks a lost job
+	HeartbeatAt *time.Time `json:"heartbeat_at"`
+	FinishedAt  *time.Time `json:"finished_at"`
+
+	Progress SummaryJobProgress `json:"progress" gorm:"-"`
+}
+
+func (SummaryJob) TableName() string {
+	return "summary_jobs"
+}
+
+// SummaryJobItem is the summary of one node in a job, items share the status values of the job
+type SummaryJobItem struct {
+	ID        string           `json:"id" gorm:"primaryKey"`
+	JobID     string           `json:"job_id" gorm:"index"`
+	NodeID    string           `json:"node_id"`
+	NodeName  string           `json:"node_name"`
+	Status    SummaryJobStatus `json:"status"`
+	Error     string           `json:"error"`
+	UpdatedAt time.Time        `json:"updated_at"`
+}
+
+func (SummaryJobItem) TableName() string {
+	return "summary_job_items"
+}
+
+type SummaryJobProgress struct {
+	Total     int `json:"total"`
+	Pending   int `json:"pending"`
+	Running   int `json:"running"`
+	Completed int `json:"completed"`
+	Failed    int `json:"failed"`
+}
+
+// NewSummaryJobProgress counts the items of a job by status
+func NewSummaryJobProgress(counts map[SummaryJobStatus]int) SummaryJobProgress {
+	progress := SummaryJobProgress{
+		Pending:   counts[SummaryJobStatusPending],
+		Running:   counts[SummaryJobStatusRunning],
+		Completed: counts[SummaryJobStatusCompleted],
+		Failed:    counts[SummaryJobStatusFailed],
+	}
+	progress.Total = progress.Pending + progress.Running + progress.Completed + progress.Failed
+	return progress
+}
+
+type CreateSummaryJobReq struct {
+	KBID string `json:"kb_id" validate:"required"`
+	// number of summaries generated at the same time, defaults to 2
+	Concurrency int `json:"concurrency"`
+}
+
+func (r *CreateSummaryJobReq) Validate() error {
+	if r.Concurrency == 0 {
+		r.Concurrency = DefaultSummaryJobConcurrency
+	}
+	if r.Concurrency < 1 || r.Concurrency > MaxSummaryJobConcurrency {
+		return fmt.Errorf("concurrency must be between 1 and %d", MaxSummaryJobConcurrency)
+	}
+	return nil
+}
+
+type SummaryJobListReq struct {
+	KBID string `json:"kb_id" query:"kb_id" validate:"required"`
+	Pager
+}
+
+type SummaryJobDetailReq struct {
+	KBID string `json:"kb_id" query:"kb_id" validate:"required"`
+	ID   string `json:"id" query:"id" validate:"required"`
+}
+
+type RetrySummaryJobReq struct {
+	KBID string `json:"kb_id" validate:"required"`
+	ID   string `json:"id" validate:"required"`
+}
+
+type CancelSummaryJobReq struct {
+	KBID string `json:"kb_id" validate:"required"`
+	ID   string `json:"id" validate:"required"`
+}
+
+type SummaryJobDetailResp struct {
+	*SummaryJob
+	Items []*SummaryJobItem `json:"items"`
+}
+
+// SummaryTaskRequest is published to run a summary job in the consumer
+type SummaryTaskRequest struct {
+	JobID string `json:"job_id"`
+}
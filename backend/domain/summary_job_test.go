@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateSummaryJobReqValidate(t *testing.T) {
+	req := &CreateSummaryJobReq{KBID: "kb"}
+	assert.NoError(t, req.Validate())
+	assert.Equal(t, DefaultSummaryJobConcurrency, req.Concurrency)
+
+	req.Concurrency = MaxSummaryJobConcurrency + 1
+	assert.Error(t, req.Validate())
+	req.Concurrency = -1
+	assert.Error(t, req.Validate())
+}
+
+func TestNewSummaryJobProgress(t *testing.T) {
+	progress := NewSummaryJobProgress(map[SummaryJobStatus]int{
+		SummaryJobStatusPending:   3,
+		SummaryJobStatusCompleted: 5,
+		SummaryJobStatusFailed:    1,
+	})
+	assert.Equal(t, SummaryJobProgress{Total: 9, Pending: 3, Completed: 5, Failed: 1}, progress)
+	assert.Equal(t, SummaryJobProgress{}, NewSummaryJobProgress(nil))
+}
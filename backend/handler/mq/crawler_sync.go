@@ -0,0 +1,45 @@
+package mq
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/mq"
+	"github.com/chaitin/panda-wiki/mq/types"
+	"github.com/chaitin/panda-wiki/usecase"
+)
+
+type CrawlerSyncMQHandler struct {
+	consumer                   mq.MQConsumer
+	logger                     *log.Logger
+	crawlerSubscriptionUsecase *usecase.CrawlerSubscriptionUsecase
+}
+
+func NewCrawlerSyncMQHandler(consumer mq.MQConsumer, logger *log.Logger, crawlerSubscriptionUsecase *usecase.CrawlerSubscriptionUsecase) (*CrawlerSyncMQHandler, error) {
+	h := &CrawlerSyncMQHandler{
+		consumer:                   consumer,
+		logger:                     logger.WithModule("mq.crawler_sync"),
+		crawlerSubscriptionUsecase: crawlerSubscriptionUsecase,
+	}
+	if err := consumer.RegisterHandler(domain.CrawlerSyncTaskTopic, h.HandleCrawlerSyncTask); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+func (h *CrawlerSyncMQHandler) HandleCrawlerSyncTask(ctx context.Context, msg types.Message) error {
+	var request domain.CrawlerSyncTaskRequest
+	if err := json.Unmarshal(msg.GetData(), &request); err != nil {
+		h.logger.Error("unmarshal crawler sync task request failed", log.Error(err))
+		return nil
+	}
+	h.logger.Info("crawler sync start", log.String("subscription_id", request.SubscriptionID))
+	if err := h.crawlerSubscriptionUsecase.Run(ctx, &request); err != nil {
+		h.logger.Error("crawler sync failed", log.String("subscription_id", request.SubscriptionID), log.Error(err))
+		return nil
+	}
+	h.logger.Info("crawler sync finished", log.String("subscription_id", request.SubscriptionID))
+	return nil
+}
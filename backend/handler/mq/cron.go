@@ -12,18 +12,33 @@ import (
 )
 
 type CronHandler struct {
-	logger      *log.Logger
-	statRepo    *pg.StatRepository
-	statUseCase *usecase.StatUseCase
-	nodeUseCase *usecase.NodeUsecase
+	logger                     *log.Logger
+	statRepo                   *pg.StatRepository
+	statUseCase                *usecase.StatUseCase
+	nodeUseCase                *usecase.NodeUsecase
+	crawlerSubscriptionUsecase *usecase.CrawlerSubscriptionUsecase
+	importJobUsecase           *usecase.ImportJobUsecase
+	linkCheckUsecase           *usecase.LinkCheckUsecase
+	summaryJobUsecase          *usecase.SummaryJobUsecase
+	nodeImportUsecase          *usecase.NodeImportUsecase
+	curatedAnswerUsecase       *usecase.CuratedAnswerUsecase
 }
 
-func NewStatCronHandler(logger *log.Logger, statRepo *pg.StatRepository, statUseCase *usecase.StatUseCase, nodeUseCase *usecase.NodeUsecase) (*CronHandler, error) {
+func NewStatCronHandler(logger *log.Logger, statRepo *pg.StatRepository, statUseCase *usecase.StatUseCase, nodeUseCase *usecase.NodeUsecase,
+	crawlerSubscriptionUsecase *usecase.CrawlerSubscriptionUsecase, importJobUsecase *usecase.ImportJobUsecase,
+	linkCheckUsecase *usecase.LinkCheckUsecase, summaryJobUsecase *usecase.SummaryJobUsecase, nodeImportUsecase *usecase.NodeImportUsecase,
+	curatedAnswerUsecase *usecase.CuratedAnswerUsecase) (*CronHandler, error) {
 	h := &CronHandler{
-		statRepo:    statRepo,
-		statUseCase: statUseCase,
-		nodeUseCase: nodeUseCase,
-		logger:      logger.WithModule("handler.mq.cron"),
+		statRepo:                   statRepo,
+		statUseCase:                statUseCase,
+		nodeUseCase:                nodeUseCase,
+		crawlerSubscriptionUsecase: crawlerSubscriptionUsecase,
+		importJobUsecase:           importJobUsecase,
+		linkCheckUsecase:           linkCheckUsecase,
+		summaryJobUsecase:          summaryJobUsecase,
+		nodeImportUsecase:          nodeImportUsecase,
+		curatedAnswerUsecase:       curatedAnswerUsecase,
+		logger:                     logger.WithModule("handler.mq.cron"),
 	}
 	cron := cron.New()
 
@@ -60,6 +75,48 @@ func NewStatCronHandler(logger *log.Logger, statRepo *pg.StatRepository, statUse
 	}
 	h.logger.Info("add cron job", log.String("cron_id", "sync_rag_node_status"))
 
+	// 每10分钟检查到期的定时同步订阅
+	if _, err := cron.AddFunc("*/10 * * * *", h.QueueCrawlerSubscriptions); err != nil {
+		h.logger.Error("failed to add cron job for queueing crawler subscriptions", log.Error(err))
+		return nil, err
+	}
+	h.logger.Info("add cron job", log.String("cron_id", "queue_crawler_subscriptions"))
+
+	// 每5分钟恢复中断的导入任务
+	if _, err := cron.AddFunc("*/5 * * * *", h.ResumeImportJobs); err != nil {
+		h.logger.Error("failed to add cron job for resuming import jobs", log.Error(err))
+		return nil, err
+	}
+	h.logger.Info("add cron job", log.String("cron_id", "resume_import_jobs"))
+
+	// 每30分钟检查到期的定时链接检查
+	if _, err := cron.AddFunc("*/30 * * * *", h.QueueLinkChecks); err != nil {
+		h.logger.Error("failed to add cron job for queueing link checks", log.Error(err))
+		return nil, err
+	}
+	h.logger.Info("add cron job", log.String("cron_id", "queue_link_checks"))
+
+	// 每5分钟恢复中断的摘要任务
+	if _, err := cron.AddFunc("*/5 * * * *", h.ResumeSummaryJobs); err != nil {
+		h.logger.Error("failed to add cron job for resuming summary jobs", log.Error(err))
+		return nil, err
+	}
+	h.logger.Info("add cron job", log.String("cron_id", "resume_summary_jobs"))
+
+	// 每5分钟恢复中断的文件导入任务
+	if _, err := cron.AddFunc("*/5 * * * *", h.ResumeNodeImportJobs); err != nil {
+		h.logger.Error("failed to add cron job for resuming node import jobs", log.Error(err))
+		return nil, err
+	}
+	h.logger.Info("add cron job", log.String("cron_id", "resume_node_import_jobs"))
+
+	// 每10分钟重新向量化嵌入模型变更后的精选回答
+	if _, err := cron.AddFunc("*/10 * * * *", h.ReembedCuratedAnswers); err != nil {
+		h.logger.Error("failed to add cron job for re-embedding curated answers", log.Error(err))
+		return nil, err
+	}
+	h.logger.Info("add cron job", log.String("cron_id", "reembed_curated_answers"))
+
 	cron.Start()
 	h.logger.Info("start cron jobs")
 	return h, nil
@@ -113,3 +170,39 @@ func (h *CronHandler) SyncRagNodeStatus() {
 	}
 	h.logger.Info("sync rag node status successful")
 }
+
+func (h *CronHandler) QueueCrawlerSubscriptions() {
+	if err := h.crawlerSubscriptionUsecase.QueueDue(context.Background()); err != nil {
+		h.logger.Error("queue crawler subscriptions failed", log.Error(err))
+	}
+}
+
+func (h *CronHandler) ResumeImportJobs() {
+	if err := h.importJobUsecase.ResumeStale(context.Background()); err != nil {
+		h.logger.Error("resume import jobs failed", log.Error(err))
+	}
+}
+
+func (h *CronHandler) QueueLinkChecks() {
+	if err := h.linkCheckUsecase.QueueDue(context.Background()); err != nil {
+		h.logger.Error("queue link checks failed", log.Error(err))
+	}
+}
+
+func (h *CronHandler) ResumeSummaryJobs() {
+	if err := h.summaryJobUsecase.ResumeStale(context.Background()); err != nil {
+		h.logger.Error("resume summary jobs failed", log.Error(err))
+	}
+}
+
+func (h *CronHandler) ResumeNodeImportJobs() {
+	if err := h.nodeImportUsecase.ResumeStale(context.Background()); err != nil {
+		h.logger.Error("resume node import jobs failed", log.Error(err))
+	}
+}
+
+func (h *CronHandler) ReembedCuratedAnswers() {
+	if err := h.curatedAnswerUsecase.ReembedStale(context.Background()); err != nil {
+		h.logger.Error("re-embed curated answers failed", log.Error(err))
+	}
+}
@@ -0,0 +1,45 @@
+package mq
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/mq"
+	"github.com/chaitin/panda-wiki/mq/types"
+	"github.com/chaitin/panda-wiki/usecase"
+)
+
+type EvalMQHandler struct {
+	consumer    mq.MQConsumer
+	logger      *log.Logger
+	evalUsecase *usecase.EvalUsecase
+}
+
+func NewEvalMQHandler(consumer mq.MQConsumer, logger *log.Logger, evalUsecase *usecase.EvalUsecase) (*EvalMQHandler, error) {
+	h := &EvalMQHandler{
+		consumer:    consumer,
+		logger:      logger.WithModule("mq.eval"),
+		evalUsecase: evalUsecase,
+	}
+	if err := consumer.RegisterHandler(domain.EvalTaskTopic, h.HandleEvalTask); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+func (h *EvalMQHandler) HandleEvalTask(ctx context.Context, msg types.Message) error {
+	var request domain.EvalTaskRequest
+	if err := json.Unmarshal(msg.GetData(), &request); err != nil {
+		h.logger.Error("unmarshal eval task request failed", log.Error(err))
+		return nil
+	}
+	h.logger.Info("eval run start", log.String("run_id", request.RunID))
+	if err := h.evalUsecase.Run(ctx, request.RunID); err != nil {
+		h.logger.Error("eval run failed", log.String("run_id", request.RunID), log.Error(err))
+		return nil
+	}
+	h.logger.Info("eval run finished", log.String("run_id", request.RunID))
+	return nil
+}
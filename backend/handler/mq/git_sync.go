@@ -0,0 +1,45 @@
+package mq
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/mq"
+	"github.com/chaitin/panda-wiki/mq/types"
+	"github.com/chaitin/panda-wiki/usecase"
+)
+
+type GitSyncMQHandler struct {
+	consumer         mq.MQConsumer
+	logger           *log.Logger
+	gitSourceUsecase *usecase.GitSourceUsecase
+}
+
+func NewGitSyncMQHandler(consumer mq.MQConsumer, logger *log.Logger, gitSourceUsecase *usecase.GitSourceUsecase) (*GitSyncMQHandler, error) {
+	h := &GitSyncMQHandler{
+		consumer:         consumer,
+		logger:           logger.WithModule("mq.git_sync"),
+		gitSourceUsecase: gitSourceUsecase,
+	}
+	if err := consumer.RegisterHandler(domain.GitSyncTaskTopic, h.HandleGitSyncTask); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+func (h *GitSyncMQHandler) HandleGitSyncTask(ctx context.Context, msg types.Message) error {
+	var request domain.GitSyncTaskRequest
+	if err := json.Unmarshal(msg.GetData(), &request); err != nil {
+		h.logger.Error("unmarshal git sync task request failed", log.Error(err))
+		return nil
+	}
+	h.logger.Info("git sync start", log.String("source_id", request.SourceID), log.Any("full", request.Full))
+	if err := h.gitSourceUsecase.Run(ctx, &request); err != nil {
+		h.logger.Error("git sync failed", log.String("source_id", request.SourceID), log.Error(err))
+		return nil
+	}
+	h.logger.Info("git sync finished", log.String("source_id", request.SourceID))
+	return nil
+}
@@ -0,0 +1,45 @@
+package mq
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/mq"
+	"github.com/chaitin/panda-wiki/mq/types"
+	"github.com/chaitin/panda-wiki/usecase"
+)
+
+type ImportMQHandler struct {
+	consumer         mq.MQConsumer
+	logger           *log.Logger
+	importJobUsecase *usecase.ImportJobUsecase
+}
+
+func NewImportMQHandler(consumer mq.MQConsumer, logger *log.Logger, importJobUsecase *usecase.ImportJobUsecase) (*ImportMQHandler, error) {
+	h := &ImportMQHandler{
+		consumer:         consumer,
+		logger:           logger.WithModule("mq.import"),
+		importJobUsecase: importJobUsecase,
+	}
+	if err := consumer.RegisterHandler(domain.ImportTaskTopic, h.HandleImportTask); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+func (h *ImportMQHandler) HandleImportTask(ctx context.Context, msg types.Message) error {
+	var request domain.ImportTaskRequest
+	if err := json.Unmarshal(msg.GetData(), &request); err != nil {
+		h.logger.Error("unmarshal import task request failed", log.Error(err))
+		return nil
+	}
+	h.logger.Info("import job start", log.String("job_id", request.JobID))
+	if err := h.importJobUsecase.Run(ctx, request.JobID); err != nil {
+		h.logger.Error("import job failed", log.String("job_id", request.JobID), log.Error(err))
+		return nil
+	}
+	h.logger.Info("import job finished", log.String("job_id", request.JobID))
+	return nil
+}
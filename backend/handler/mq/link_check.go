@@ -0,0 +1,45 @@
+package mq
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/mq"
+	"github.com/chaitin/panda-wiki/mq/types"
+	"github.com/chaitin/panda-wiki/usecase"
+)
+
+type LinkCheckMQHandler struct {
+	consumer         mq.MQConsumer
+	logger           *log.Logger
+	linkCheckUsecase *usecase.LinkCheckUsecase
+}
+
+func NewLinkCheckMQHandler(consumer mq.MQConsumer, logger *log.Logger, linkCheckUsecase *usecase.LinkCheckUsecase) (*LinkCheckMQHandler, error) {
+	h := &LinkCheckMQHandler{
+		consumer:         consumer,
+		logger:           logger.WithModule("mq.link_check"),
+		linkCheckUsecase: linkCheckUsecase,
+	}
+	if err := consumer.RegisterHandler(domain.LinkCheckTaskTopic, h.HandleLinkCheckTask); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+func (h *LinkCheckMQHandler) HandleLinkCheckTask(ctx context.Context, msg types.Message) error {
+	var request domain.LinkCheckTaskRequest
+	if err := json.Unmarshal(msg.GetData(), &request); err != nil {
+		h.logger.Error("unmarshal link check task request failed", log.Error(err))
+		return nil
+	}
+	h.logger.Info("link check job start", log.String("job_id", request.JobID))
+	if err := h.linkCheckUsecase.Run(ctx, request.JobID); err != nil {
+		h.logger.Error("link check job failed", log.String("job_id", request.JobID), log.Error(err))
+		return nil
+	}
+	h.logger.Info("link check job finished", log.String("job_id", request.JobID))
+	return nil
+}
@@ -0,0 +1,45 @@
+package mq
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/mq"
+	"github.com/chaitin/panda-wiki/mq/types"
+	"github.com/chaitin/panda-wiki/usecase"
+)
+
+type NodeImportMQHandler struct {
+	consumer          mq.MQConsumer
+	logger            *log.Logger
+	nodeImportUsecase *usecase.NodeImportUsecase
+}
+
+func NewNodeImportMQHandler(consumer mq.MQConsumer, logger *log.Logger, nodeImportUsecase *usecase.NodeImportUsecase) (*NodeImportMQHandler, error) {
+	h := &NodeImportMQHandler{
+		consumer:          consumer,
+		logger:            logger.WithModule("mq.node_import"),
+		nodeImportUsecase: nodeImportUsecase,
+	}
+	if err := consumer.RegisterHandler(domain.NodeImportTaskTopic, h.HandleNodeImportTask); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+func (h *NodeImportMQHandler) HandleNodeImportTask(ctx context.Context, msg types.Message) error {
+	var request domain.NodeImportTaskRequest
+	if err := json.Unmarshal(msg.GetData(), &request); err != nil {
+		h.logger.Error("unmarshal node import task request failed", log.Error(err))
+		return nil
+	}
+	h.logger.Info("node import job start", log.String("job_id", request.JobID))
+	if err := h.nodeImportUsecase.Run(ctx, request.JobID); err != nil {
+		h.logger.Error("node import job failed", log.String("job_id", request.JobID), log.Error(err))
+		return nil
+	}
+	h.logger.Info("node import job finished", log.String("job_id", request.JobID))
+	return nil
+}
@@ -12,9 +12,17 @@ import (
 )
 
 type MQHandlers struct {
-	RAGMQHandler        *RAGMQHandler
-	RagDocUpdateHandler *RagDocUpdateHandler
-	StatCronHandler     *CronHandler
+	RAGMQHandler         *RAGMQHandler
+	RagDocUpdateHandler  *RagDocUpdateHandler
+	StatCronHandler      *CronHandler
+	EvalMQHandler        *EvalMQHandler
+	TranslateMQHandler   *TranslateMQHandler
+	SummaryMQHandler     *SummaryMQHandler
+	GitSyncMQHandler     *GitSyncMQHandler
+	CrawlerSyncMQHandler *CrawlerSyncMQHandler
+	ImportMQHandler      *ImportMQHandler
+	LinkCheckMQHandler   *LinkCheckMQHandler
+	NodeImportMQHandler  *NodeImportMQHandler
 }
 
 var ProviderSet = wire.NewSet(
@@ -28,10 +36,32 @@ var ProviderSet = wire.NewSet(
 	usecase.NewStatUseCase,
 	usecase.NewNodeUsecase,
 	usecase.NewModelUsecase,
+	usecase.NewFileUsecase,
+	usecase.NewImageUsecase,
+	usecase.NewEvalUsecase,
+	usecase.NewNodeChunkUsecase,
+	usecase.NewNodeTranslationUsecase,
+	usecase.NewSummaryJobUsecase,
+	usecase.NewKnowledgeBaseUsecase,
+	usecase.NewGitSourceUsecase,
+	usecase.NewCrawlerUsecase,
+	usecase.NewCrawlerSubscriptionUsecase,
+	usecase.NewImportJobUsecase,
+	usecase.NewLinkCheckUsecase,
+	usecase.NewNodeImportUsecase,
+	usecase.NewCuratedAnswerUsecase,
 
 	NewRAGMQHandler,
 	NewRagDocUpdateHandler,
 	NewStatCronHandler,
+	NewEvalMQHandler,
+	NewTranslateMQHandler,
+	NewSummaryMQHandler,
+	NewGitSyncMQHandler,
+	NewCrawlerSyncMQHandler,
+	NewImportMQHandler,
+	NewLinkCheckMQHandler,
+	NewNodeImportMQHandler,
 
 	wire.Struct(new(MQHandlers), "*"),
 )
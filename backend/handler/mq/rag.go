@@ -22,9 +22,12 @@ type RAGMQHandler struct {
 	kbRepo       *pg.KnowledgeBaseRepository
 	llmUsecase   *usecase.LLMUsecase
 	modelUsecase *usecase.ModelUsecase
+	imageUsecase *usecase.ImageUsecase
+	chunkUsecase *usecase.NodeChunkUsecase
+	settingRepo  *pg.SettingRepository
 }
 
-func NewRAGMQHandler(consumer mq.MQConsumer, logger *log.Logger, rag rag.RAGService, nodeRepo *pg.NodeRepository, kbRepo *pg.KnowledgeBaseRepository, llmUsecase *usecase.LLMUsecase, modelUsecase *usecase.ModelUsecase) (*RAGMQHandler, error) {
+func NewRAGMQHandler(consumer mq.MQConsumer, logger *log.Logger, rag rag.RAGService, nodeRepo *pg.NodeRepository, kbRepo *pg.KnowledgeBaseRepository, llmUsecase *usecase.LLMUsecase, modelUsecase *usecase.ModelUsecase, imageUsecase *usecase.ImageUsecase, chunkUsecase *usecase.NodeChunkUsecase, settingRepo *pg.SettingRepository) (*RAGMQHandler, error) {
 	h := &RAGMQHandler{
 		consumer:     consumer,
 		logger:       logger.WithModule("mq.rag"),
@@ -33,6 +36,9 @@ func NewRAGMQHandler(consumer mq.MQConsumer, logger *log.Logger, rag rag.RAGServ
 		kbRepo:       kbRepo,
 		llmUsecase:   llmUsecase,
 		modelUsecase: modelUsecase,
+		imageUsecase: imageUsecase,
+		chunkUsecase: chunkUsecase,
+		settingRepo:  settingRepo,
 	}
 	if err := consumer.RegisterHandler(domain.VectorTaskTopic, h.HandleNodeContentVectorRequest); err != nil {
 		return nil, err
@@ -84,8 +90,21 @@ func (h *RAGMQHandler) HandleNodeContentVectorRequest(ctx context.Context, msg t
 			return nil
 		}
 
+		// index the text of images together with the content
+		content, err := h.imageUsecase.CaptionDocument(ctx, request.KBID, nodeRelease.Content)
+		if err != nil {
+			h.logger.Warn("caption node images failed, index text only", log.String("node_release_id", request.NodeReleaseID), log.Error(err))
+		}
+		nodeRelease.Content = content
+
+		chunkSetting, err := h.settingRepo.GetChunkSetting(ctx, request.KBID)
+		if err != nil {
+			h.logger.Warn("get chunk setting failed, use default", log.String("kb_id", request.KBID), log.Error(err))
+			chunkSetting = domain.DefaultChunkSetting()
+		}
+
 		// upsert node content chunks
-		docID, err := h.rag.UpsertRecords(ctx, kb.DatasetID, nodeRelease, groupIds)
+		docID, err := h.rag.UpsertRecords(ctx, kb.DatasetID, nodeRelease, groupIds, chunkSetting)
 		if err != nil {
 			h.logger.Error("upsert node content vector failed", log.Error(err))
 			return nil
@@ -95,6 +114,17 @@ func (h *RAGMQHandler) HandleNodeContentVectorRequest(ctx context.Context, msg t
 			h.logger.Error("update node doc_id failed", log.String("node_id", request.NodeReleaseID), log.Error(err))
 			return nil
 		}
+		// chunks split by PandaWiki are added directly, no parse event is sent by the rag service
+		if chunkSetting.Strategy == domain.ChunkStrategyHeading {
+			if err := h.nodeRepo.Update(ctx, nodeRelease.NodeID, map[string]interface{}{
+				"rag_info": domain.RagInfo{Status: consts.NodeRagStatusBasicSucceeded},
+			}); err != nil {
+				h.logger.Error("update node rag info failed", log.String("node_id", nodeRelease.NodeID), log.Error(err))
+			}
+			if err := h.chunkUsecase.ApplyEdits(ctx, docID); err != nil {
+				h.logger.Error("apply node chunk edits failed", log.String("doc_id", docID), log.Error(err))
+			}
+		}
 		// delete old RAG records
 		// get old doc_ids by node_id
 		oldDocIDs, err := h.nodeRepo.GetOldNodeDocIDsByNodeID(ctx, nodeRelease.ID, nodeRelease.NodeID)
@@ -141,7 +171,7 @@ func (h *RAGMQHandler) HandleNodeContentVectorRequest(ctx context.Context, msg t
 			return nil
 		}
 
-		summary, err := h.llmUsecase.SummaryNode(ctx, model, node.Name, node.Content)
+		summary, err := h.llmUsecase.SummaryNode(ctx, model, request.KBID, node.Name, node.Content)
 		if err != nil {
 			h.logger.Error("summary node content failed", log.Error(err))
 			return nil
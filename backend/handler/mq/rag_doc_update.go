@@ -10,19 +10,22 @@ import (
 	"github.com/chaitin/panda-wiki/mq"
 	"github.com/chaitin/panda-wiki/mq/types"
 	"github.com/chaitin/panda-wiki/repo/pg"
+	"github.com/chaitin/panda-wiki/usecase"
 )
 
 type RagDocUpdateHandler struct {
-	consumer mq.MQConsumer
-	logger   *log.Logger
-	nodeRepo *pg.NodeRepository
+	consumer         mq.MQConsumer
+	logger           *log.Logger
+	nodeRepo         *pg.NodeRepository
+	nodeChunkUsecase *usecase.NodeChunkUsecase
 }
 
-func NewRagDocUpdateHandler(consumer mq.MQConsumer, logger *log.Logger, nodeRepo *pg.NodeRepository) (*RagDocUpdateHandler, error) {
+func NewRagDocUpdateHandler(consumer mq.MQConsumer, logger *log.Logger, nodeRepo *pg.NodeRepository, nodeChunkUsecase *usecase.NodeChunkUsecase) (*RagDocUpdateHandler, error) {
 	h := &RagDocUpdateHandler{
-		consumer: consumer,
-		logger:   logger.WithModule("mq.rag_doc_update"),
-		nodeRepo: nodeRepo,
+		consumer:         consumer,
+		logger:           logger.WithModule("mq.rag_doc_update"),
+		nodeRepo:         nodeRepo,
+		nodeChunkUsecase: nodeChunkUsecase,
 	}
 	if err := consumer.RegisterHandler(domain.RagDocUpdateTopic, h.HandleRagDocUpdate); err != nil {
 		return nil, err
@@ -60,6 +63,13 @@ func (h *RagDocUpdateHandler) HandleRagDocUpdate(ctx context.Context, msg types.
 		return err
 	}
 
+	// chunks exist once the basic processing is done, re-apply the manual chunk edits
+	if consts.NodeRagInfoStatus(event.Status) == consts.NodeRagStatusBasicSucceeded {
+		if err := h.nodeChunkUsecase.ApplyEdits(ctx, event.ID); err != nil {
+			h.logger.Error("failed to apply node chunk edits", log.String("doc_id", event.ID), log.Error(err))
+		}
+	}
+
 	h.logger.Debug("node rag update success", log.String("doc_id", event.ID))
 	return nil
 }
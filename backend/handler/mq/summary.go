@@ -0,0 +1,45 @@
+package mq
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/mq"
+	"github.com/chaitin/panda-wiki/mq/types"
+	"github.com/chaitin/panda-wiki/usecase"
+)
+
+type SummaryMQHandler struct {
+	consumer          mq.MQConsumer
+	logger            *log.Logger
+	summaryJobUsecase *usecase.SummaryJobUsecase
+}
+
+func NewSummaryMQHandler(consumer mq.MQConsumer, logger *log.Logger, summaryJobUsecase *usecase.SummaryJobUsecase) (*SummaryMQHandler, error) {
+	h := &SummaryMQHandler{
+		consumer:          consumer,
+		logger:            logger.WithModule("mq.summary"),
+		summaryJobUsecase: summaryJobUsecase,
+	}
+	if err := consumer.RegisterHandler(domain.SummaryTaskTopic, h.HandleSummaryTask); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+func (h *SummaryMQHandler) HandleSummaryTask(ctx context.Context, msg types.Message) error {
+	var request domain.SummaryTaskRequest
+	if err := json.Unmarshal(msg.GetData(), &request); err != nil {
+		h.logger.Error("unmarshal summary task request failed", log.Error(err))
+		return nil
+	}
+	h.logger.Info("summary job start", log.String("job_id", request.JobID))
+	if err := h.summaryJobUsecase.Run(ctx, request.JobID); err != nil {
+		h.logger.Error("summary job failed", log.String("job_id", request.JobID), log.Error(err))
+		return nil
+	}
+	h.logger.Info("summary job finished", log.String("job_id", request.JobID))
+	return nil
+}
@@ -0,0 +1,45 @@
+package mq
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/mq"
+	"github.com/chaitin/panda-wiki/mq/types"
+	"github.com/chaitin/panda-wiki/usecase"
+)
+
+type TranslateMQHandler struct {
+	consumer           mq.MQConsumer
+	logger             *log.Logger
+	translationUsecase *usecase.NodeTranslationUsecase
+}
+
+func NewTranslateMQHandler(consumer mq.MQConsumer, logger *log.Logger, translationUsecase *usecase.NodeTranslationUsecase) (*TranslateMQHandler, error) {
+	h := &TranslateMQHandler{
+		consumer:           consumer,
+		logger:             logger.WithModule("mq.translate"),
+		translationUsecase: translationUsecase,
+	}
+	if err := consumer.RegisterHandler(domain.TranslateTaskTopic, h.HandleTranslateTask); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+func (h *TranslateMQHandler) HandleTranslateTask(ctx context.Context, msg types.Message) error {
+	var request domain.NodeTranslateTaskRequest
+	if err := json.Unmarshal(msg.GetData(), &request); err != nil {
+		h.logger.Error("unmarshal translate task request failed", log.Error(err))
+		return nil
+	}
+	h.logger.Info("translate nodes start", log.String("kb_id", request.KBID), log.Int("count", len(request.TranslationIDs)))
+	if err := h.translationUsecase.Run(ctx, &request); err != nil {
+		h.logger.Error("translate nodes failed", log.String("kb_id", request.KBID), log.Error(err))
+		return nil
+	}
+	h.logger.Info("translate nodes finished", log.String("kb_id", request.KBID))
+	return nil
+}
@@ -50,7 +50,7 @@ func NewShareChatHandler(
 			return func(c echo.Context) error {
 				c.Response().Header().Set("Access-Control-Allow-Origin", "*")
 				c.Response().Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
-				c.Response().Header().Set("Access-Control-Allow-Headers", "Content-Type, Origin, Accept")
+				c.Response().Header().Set("Access-Control-Allow-Headers", "Content-Type, Origin, Accept, Last-Event-ID")
 				if c.Request().Method == "OPTIONS" {
 					return c.NoContent(http.StatusOK)
 				}
@@ -59,7 +59,13 @@ func NewShareChatHandler(
 		})
 	share.POST("/message", h.ChatMessage, h.ShareAuthMiddleware.Authorize)
 	share.POST("/search", h.ChatSearch, h.ShareAuthMiddleware.Authorize)
+	share.POST("/regenerate", h.ChatRegenerate, h.ShareAuthMiddleware.Authorize)
+	share.POST("/edit", h.ChatEdit, h.ShareAuthMiddleware.Authorize)
+	share.POST("/stop", h.ChatStop, h.ShareAuthMiddleware.Authorize)
+	share.GET("/resume", h.ChatResume, h.ShareAuthMiddleware.Authorize)
 	share.POST("/completions", h.ChatCompletions)
+	share.GET("/models", h.ChatModels)
+	share.POST("/embeddings", h.ChatEmbeddings)
 	share.POST("/widget", h.ChatWidget)
 	share.POST("/widget/search", h.WidgetSearch)
 	share.POST("/feedback", h.FeedBack)
@@ -121,7 +127,7 @@ func (h *ShareChatHandler) ChatMessage(c echo.Context) error {
 		if err := h.writeSSEEvent(c, event); err != nil {
 			return err
 		}
-		if event.Type == "done" || event.Type == "error" {
+		if event.Type == "error" {
 			break
 		}
 	}
@@ -179,7 +185,177 @@ func (h *ShareChatHandler) ChatWidget(c echo.Context) error {
 		if err := h.writeSSEEvent(c, event); err != nil {
 			return err
 		}
-		if event.Type == "done" || event.Type == "error" {
+		if event.Type == "error" {
+			break
+		}
+	}
+	return nil
+}
+
+// ChatRegenerate regenerate answer
+//
+//	@Summary		ChatRegenerate
+//	@Description	Regenerate the answer of a question as a new sibling branch
+//	@Tags			share_chat
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		domain.ChatRegenerateRequest	true	"request"
+//	@Success		200		{object}	domain.Response
+//	@Router			/share/v1/chat/regenerate [post]
+func (h *ShareChatHandler) ChatRegenerate(c echo.Context) error {
+	var req domain.ChatRegenerateRequest
+	if err := c.Bind(&req); err != nil {
+		h.logger.Error("parse request failed", log.Error(err))
+		return h.sendErrMsg(c, "parse request failed")
+	}
+	req.KBID = c.Request().Header.Get("X-KB-ID") // get from caddy header
+	if err := c.Validate(&req); err != nil {
+		h.logger.Error("validate request failed", log.Error(err))
+		return h.sendErrMsg(c, "validate request failed")
+	}
+	if req.AppType != domain.AppTypeWeb {
+		return h.sendErrMsg(c, "invalid app type")
+	}
+	if !h.Captcha.ValidateToken(c.Request().Context(), req.CaptchaToken) {
+		return h.sendErrMsg(c, "failed to validate captcha")
+	}
+	return h.streamChat(c, &domain.ChatRequest{
+		ConversationID:      req.ConversationID,
+		Nonce:               req.Nonce,
+		AppType:             req.AppType,
+		KBID:                req.KBID,
+		RegenerateMessageID: req.MessageID,
+	})
+}
+
+// ChatEdit edit question and resend
+//
+//	@Summary		ChatEdit
+//	@Description	Ask an edited question as a sibling of the original one
+//	@Tags			share_chat
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		domain.ChatEditRequest	true	"request"
+//	@Success		200		{object}	domain.Response
+//	@Router			/share/v1/chat/edit [post]
+func (h *ShareChatHandler) ChatEdit(c echo.Context) error {
+	var req domain.ChatEditRequest
+	if err := c.Bind(&req); err != nil {
+		h.logger.Error("parse request failed", log.Error(err))
+		return h.sendErrMsg(c, "parse request failed")
+	}
+	req.KBID = c.Request().Header.Get("X-KB-ID") // get from caddy header
+	if err := c.Validate(&req); err != nil {
+		h.logger.Error("validate request failed", log.Error(err))
+		return h.sendErrMsg(c, "validate request failed")
+	}
+	if req.AppType != domain.AppTypeWeb {
+		return h.sendErrMsg(c, "invalid app type")
+	}
+	if !h.Captcha.ValidateToken(c.Request().Context(), req.CaptchaToken) {
+		return h.sendErrMsg(c, "failed to validate captcha")
+	}
+	return h.streamChat(c, &domain.ChatRequest{
+		ConversationID: req.ConversationID,
+		Nonce:          req.Nonce,
+		Message:        req.Message,
+		AppType:        req.AppType,
+		KBID:           req.KBID,
+		EditMessageID:  req.MessageID,
+	})
+}
+
+// ChatStop stop generating answer
+//
+//	@Summary		ChatStop
+//	@Description	Stop an in-flight answer, the partial answer is saved
+//	@Tags			share_chat
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		domain.ChatStopRequest	true	"request"
+//	@Success		200		{object}	domain.Response
+//	@Router			/share/v1/chat/stop [post]
+func (h *ShareChatHandler) ChatStop(c echo.Context) error {
+	var req domain.ChatStopRequest
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "parse request failed", err)
+	}
+	req.KBID = c.Request().Header.Get("X-KB-ID") // get from caddy header
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request failed", err)
+	}
+	if err := h.chatUsecase.Stop(c.Request().Context(), &req); err != nil {
+		return h.NewResponseWithError(c, "stop chat failed", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
+
+// ChatResume resume answer stream
+//
+//	@Summary		ChatResume
+//	@Description	Replay the answer events after Last-Event-ID and follow the answer until done
+//	@Tags			share_chat
+//	@Accept			json
+//	@Produce		json
+//	@Param			Last-Event-ID	header		string						false	"last received event id"
+//	@Param			param			query		domain.ChatResumeRequest	true	"request"
+//	@Success		200				{object}	domain.Response
+//	@Router			/share/v1/chat/resume [get]
+func (h *ShareChatHandler) ChatResume(c echo.Context) error {
+	var req domain.ChatResumeRequest
+	if err := c.Bind(&req); err != nil {
+		h.logger.Error("parse request failed", log.Error(err))
+		return h.sendErrMsg(c, "parse request failed")
+	}
+	req.KBID = c.Request().Header.Get("X-KB-ID") // get from caddy header
+	if err := c.Validate(&req); err != nil {
+		h.logger.Error("validate request failed", log.Error(err))
+		return h.sendErrMsg(c, "validate request failed")
+	}
+	if lastEventID := c.Request().Header.Get("Last-Event-ID"); lastEventID != "" {
+		req.LastEventID = lastEventID
+	}
+
+	c.Response().Header().Set("Content-Type", "text/event-stream")
+	c.Response().Header().Set("Cache-Control", "no-cache")
+	c.Response().Header().Set("Connection", "keep-alive")
+	c.Response().Header().Set("Transfer-Encoding", "chunked")
+
+	eventCh, err := h.chatUsecase.Resume(c.Request().Context(), &req)
+	if err != nil {
+		h.logger.Error("resume chat failed", log.Error(err))
+		return h.sendErrMsg(c, "resume chat failed")
+	}
+	for event := range eventCh {
+		if err := h.writeSSEEvent(c, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// streamChat runs a web chat request and writes its events as SSE
+func (h *ShareChatHandler) streamChat(c echo.Context, req *domain.ChatRequest) error {
+	req.RemoteIP = c.RealIP()
+
+	c.Response().Header().Set("Content-Type", "text/event-stream")
+	c.Response().Header().Set("Cache-Control", "no-cache")
+	c.Response().Header().Set("Connection", "keep-alive")
+	c.Response().Header().Set("Transfer-Encoding", "chunked")
+
+	if userID := c.Get("user_id"); userID != nil {
+		req.Info.UserInfo.AuthUserID = userID.(uint)
+	}
+
+	eventCh, err := h.chatUsecase.Chat(c.Request().Context(), req)
+	if err != nil {
+		return h.sendErrMsg(c, err.Error())
+	}
+	for event := range eventCh {
+		if err := h.writeSSEEvent(c, event); err != nil {
+			return err
+		}
+		if event.Type == "error" {
 			break
 		}
 	}
@@ -197,6 +373,10 @@ func (h *ShareChatHandler) writeSSEEvent(c echo.Context, data any) error {
 	}
 
 	sseMessage := fmt.Sprintf("data: %s\n\n", string(jsonContent))
+	if event, ok := data.(domain.SSEEvent); ok && event.ID != "" {
+		// event id lets clients resume with Last-Event-ID
+		sseMessage = fmt.Sprintf("id: %s\n%s", event.ID, sseMessage)
+	}
 	if _, err := c.Response().Write([]byte(sseMessage)); err != nil {
 		return err
 	}
@@ -230,10 +410,54 @@ func (h *ShareChatHandler) FeedBack(c echo.Context) error {
 	return h.NewResponseWithData(c, "success")
 }
 
+// openAIError is an error of the OpenAI compatible API with its http status
+type openAIError struct {
+	status    int
+	message   string
+	errorType string
+}
+
+func newOpenAIError(status int, message, errorType string) *openAIError {
+	return &openAIError{status: status, message: message, errorType: errorType}
+}
+
+// authorizeOpenAIRequest validates the X-KB-ID header and the api key of the OpenAI API app
+func (h *ShareChatHandler) authorizeOpenAIRequest(c echo.Context) (string, *openAIError) {
+	// get kb id from header
+	kbID := c.Request().Header.Get("X-KB-ID")
+	if kbID == "" {
+		return "", newOpenAIError(http.StatusBadRequest, "X-KB-ID header is required", "invalid_request_error")
+	}
+
+	// validate api bot settings
+	appBot, err := h.appUsecase.GetOpenAIAPIAppInfo(c.Request().Context(), kbID)
+	if err != nil {
+		return "", newOpenAIError(http.StatusInternalServerError, err.Error(), "internal_error")
+	}
+	if !appBot.Settings.OpenAIAPIBotSettings.IsEnabled {
+		return "", newOpenAIError(http.StatusForbidden, "API Bot is not enabled", "forbidden")
+	}
+
+	secretKeyHeader := c.Request().Header.Get("Authorization")
+	if secretKeyHeader == "" {
+		return "", newOpenAIError(http.StatusUnauthorized, "Authorization header is required", "invalid_request_error")
+	}
+	if secretKey, found := strings.CutPrefix(secretKeyHeader, "Bearer "); !found {
+		return "", newOpenAIError(http.StatusUnauthorized, "Invalid Authorization key format", "invalid_request_error")
+	} else {
+		if appBot.Settings.OpenAIAPIBotSettings.SecretKey != secretKey {
+			return "", newOpenAIError(http.StatusUnauthorized, "Invalid Authorization key", "unauthorized")
+		}
+	}
+	return kbID, nil
+}
+
 // ChatCompletions OpenAI API compatible chat completions
 //
 //	@Summary		ChatCompletions
-//	@Description	OpenAI API compatible chat completions endpoint
+//	@Description	OpenAI API compatible chat completions endpoint.
+//	@Description	Besides the OpenAI fields, responses carry the PandaWiki extension fields `sources` (retrieved documents)
+//	@Description	and `suggestions` (follow-up questions). In stream mode they are sent in chunks without choices.
 //	@Tags			share_chat
 //	@Accept			json
 //	@Produce		json
@@ -241,141 +465,156 @@ func (h *ShareChatHandler) FeedBack(c echo.Context) error {
 //	@Param			request	body		domain.OpenAICompletionsRequest	true	"OpenAI API request"
 //	@Success		200		{object}	domain.OpenAICompletionsResponse
 //	@Failure		400		{object}	domain.OpenAIErrorResponse
+//	@Failure		401		{object}	domain.OpenAIErrorResponse
+//	@Failure		403		{object}	domain.OpenAIErrorResponse
+//	@Failure		500		{object}	domain.OpenAIErrorResponse
 //	@Router			/share/v1/chat/completions [post]
 func (h *ShareChatHandler) ChatCompletions(c echo.Context) error {
 	var req domain.OpenAICompletionsRequest
 	if err := c.Bind(&req); err != nil {
 		h.logger.Error("parse OpenAI request failed", log.Error(err))
-		return h.sendOpenAIError(c, "parse request failed", "invalid_request_error")
+		return h.sendOpenAIError(c, http.StatusBadRequest, "parse request failed", "invalid_request_error")
 	}
 
-	// get kb id from header
-	kbID := c.Request().Header.Get("X-KB-ID")
-	if kbID == "" {
-		return h.sendOpenAIError(c, "X-KB-ID header is required", "invalid_request_error")
+	kbID, authErr := h.authorizeOpenAIRequest(c)
+	if authErr != nil {
+		return h.sendOpenAIError(c, authErr.status, authErr.message, authErr.errorType)
 	}
 
 	if err := c.Validate(&req); err != nil {
 		h.logger.Error("validate OpenAI request failed", log.Error(err))
-		return h.sendOpenAIError(c, "validate request failed", "invalid_request_error")
+		return h.sendOpenAIError(c, http.StatusBadRequest, "validate request failed", "invalid_request_error")
 	}
 
 	// validate messages
 	if len(req.Messages) == 0 {
-		return h.sendOpenAIError(c, "messages cannot be empty", "invalid_request_error")
-	}
-
-	// use last user message as message
-	var lastUserMessage string
-	for i := len(req.Messages) - 1; i >= 0; i-- {
-		if req.Messages[i].Role == "user" {
-			if req.Messages[i].Content != nil {
-				lastUserMessage = req.Messages[i].Content.String()
-			}
-			break
-		}
-	}
-	if lastUserMessage == "" {
-		return h.sendOpenAIError(c, "no user message found", "invalid_request_error")
-	}
-
-	// validate api bot settings
-	appBot, err := h.appUsecase.GetOpenAIAPIAppInfo(c.Request().Context(), kbID)
-	if err != nil {
-		return h.sendOpenAIError(c, err.Error(), "internal_error")
-	}
-	if !appBot.Settings.OpenAIAPIBotSettings.IsEnabled {
-		return h.sendOpenAIError(c, "API Bot is not enabled", "forbidden")
+		return h.sendOpenAIError(c, http.StatusBadRequest, "messages cannot be empty", "invalid_request_error")
 	}
 
-	secretKeyHeader := c.Request().Header.Get("Authorization")
-	if secretKeyHeader == "" {
-		return h.sendOpenAIError(c, "Authorization header is required", "invalid_request_error")
+	// the last user message is the question, earlier messages are the conversation context
+	chatContext := req.ChatContext()
+	if chatContext.Question == "" && len(chatContext.Images) == 0 {
+		return h.sendOpenAIError(c, http.StatusBadRequest, "no user message found", "invalid_request_error")
 	}
-	if secretKey, found := strings.CutPrefix(secretKeyHeader, "Bearer "); !found {
-		return h.sendOpenAIError(c, "Invalid Authorization key format", "invalid_request_error")
-	} else {
-		if appBot.Settings.OpenAIAPIBotSettings.SecretKey != secretKey {
-			return h.sendOpenAIError(c, "Invalid Authorization key", "unauthorized")
-		}
+	if len(chatContext.Images) > domain.ChatMaxImages {
+		return h.sendOpenAIError(c, http.StatusBadRequest, fmt.Sprintf("at most %d images are allowed", domain.ChatMaxImages), "invalid_request_error")
 	}
 
 	chatReq := &domain.ChatRequest{
-		Message:  lastUserMessage,
-		KBID:     kbID,
-		AppType:  domain.AppTypeOpenAIAPI,
-		RemoteIP: c.RealIP(),
+		Message:            chatContext.Question,
+		Images:             chatContext.Images,
+		KBID:               kbID,
+		AppType:            domain.AppTypeOpenAIAPI,
+		RemoteIP:           c.RealIP(),
+		History:            chatContext.History,
+		ClientSystemPrompt: chatContext.SystemPrompt,
+		ConversationUser:   req.User,
 	}
-
-	// set stream response header
-	if req.Stream {
-		c.Response().Header().Set("Content-Type", "text/event-stream")
-		c.Response().Header().Set("Cache-Control", "no-cache")
-		c.Response().Header().Set("Connection", "keep-alive")
-		c.Response().Header().Set("Transfer-Encoding", "chunked")
+	if req.User != "" {
+		chatReq.Info.UserInfo.UserID = req.User
+		chatReq.Info.UserInfo.NickName = req.User
 	}
 
 	eventCh, err := h.chatUsecase.Chat(c.Request().Context(), chatReq)
 	if err != nil {
-		return h.sendOpenAIError(c, err.Error(), "internal_error")
+		return h.sendOpenAIError(c, http.StatusInternalServerError, err.Error(), "internal_error")
 	}
 
 	// handle stream response
 	if req.Stream {
-		return h.handleOpenAIStreamResponse(c, eventCh, req.Model)
+		includeUsage := req.StreamOptions != nil && req.StreamOptions.IncludeUsage
+		return h.handleOpenAIStreamResponse(c, eventCh, req.Model, includeUsage)
 	} else {
 		return h.handleOpenAINonStreamResponse(c, eventCh, req.Model)
 	}
 }
 
-func (h *ShareChatHandler) handleOpenAIStreamResponse(c echo.Context, eventCh <-chan domain.SSEEvent, model string) error {
+func (h *ShareChatHandler) handleOpenAIStreamResponse(c echo.Context, eventCh <-chan domain.SSEEvent, model string, includeUsage bool) error {
 	responseID := "chatcmpl-" + generateID()
 	created := time.Now().Unix()
+	newChunk := func() domain.OpenAIStreamResponse {
+		return domain.OpenAIStreamResponse{
+			ID:      responseID,
+			Object:  "chat.completion.chunk",
+			Created: created,
+			Model:   model,
+			Choices: []domain.OpenAIStreamChoice{},
+		}
+	}
 
+	var sources []domain.OpenAISource
+	var usage *domain.OpenAIUsage
+	// extension: retrieved documents in one chunk without choices, before the answer
+	flushSources := func() error {
+		if len(sources) == 0 {
+			return nil
+		}
+		streamResp := newChunk()
+		streamResp.Sources = sources
+		sources = nil
+		return h.writeOpenAIStreamEvent(c, streamResp)
+	}
 	for event := range eventCh {
 		switch event.Type {
 		case "error":
-			return h.sendOpenAIError(c, event.Content, "internal_error")
+			return h.sendOpenAIError(c, http.StatusInternalServerError, event.Content, "internal_error")
+		case "chunk_result":
+			if event.ChunkResult != nil {
+				sources = append(sources, toOpenAISource(event.ChunkResult))
+			}
 		case "data":
+			if err := flushSources(); err != nil {
+				return err
+			}
 			// send stream response
-			streamResp := domain.OpenAIStreamResponse{
-				ID:      responseID,
-				Object:  "chat.completion.chunk",
-				Created: created,
-				Model:   model,
-				Choices: []domain.OpenAIStreamChoice{
-					{
-						Index: 0,
-						Delta: domain.OpenAIMessage{
-							Role:    "assistant",
-							Content: domain.NewStringContent(event.Content),
-						},
+			streamResp := newChunk()
+			streamResp.Choices = []domain.OpenAIStreamChoice{
+				{
+					Index: 0,
+					Delta: domain.OpenAIMessage{
+						Role:    "assistant",
+						Content: domain.NewStringContent(event.Content),
 					},
 				},
 			}
-
 			if err := h.writeOpenAIStreamEvent(c, streamResp); err != nil {
 				return err
 			}
 		case "done":
+			if err := flushSources(); err != nil {
+				return err
+			}
+			usage = event.Usage
 			// send done event
-			streamResp := domain.OpenAIStreamResponse{
-				ID:      responseID,
-				Object:  "chat.completion.chunk",
-				Created: created,
-				Model:   model,
-				Choices: []domain.OpenAIStreamChoice{
-					{
-						Index:        0,
-						Delta:        domain.OpenAIMessage{},
-						FinishReason: stringPtr("stop"),
-					},
+			streamResp := newChunk()
+			streamResp.Choices = []domain.OpenAIStreamChoice{
+				{
+					Index:        0,
+					Delta:        domain.OpenAIMessage{},
+					FinishReason: stringPtr("stop"),
 				},
 			}
-			return h.writeOpenAIStreamEvent(c, streamResp)
+			if err := h.writeOpenAIStreamEvent(c, streamResp); err != nil {
+				return err
+			}
+		case "suggestions":
+			// extension: follow-up questions in a trailing chunk without choices
+			streamResp := newChunk()
+			streamResp.Suggestions = event.Suggestions
+			if err := h.writeOpenAIStreamEvent(c, streamResp); err != nil {
+				return err
+			}
 		}
 	}
-	return nil
+	// usage is sent in the last chunk without choices, as the OpenAI API does
+	if includeUsage && usage != nil {
+		streamResp := newChunk()
+		streamResp.Usage = usage
+		if err := h.writeOpenAIStreamEvent(c, streamResp); err != nil {
+			return err
+		}
+	}
+	return h.writeOpenAIStreamDone(c)
 }
 
 func (h *ShareChatHandler) handleOpenAINonStreamResponse(c echo.Context, eventCh <-chan domain.SSEEvent, model string) error {
@@ -383,47 +622,180 @@ func (h *ShareChatHandler) handleOpenAINonStreamResponse(c echo.Context, eventCh
 	created := time.Now().Unix()
 
 	var content string
+	var done bool
+	var suggestions []string
+	var sources []domain.OpenAISource
+	var usage *domain.OpenAIUsage
 	for event := range eventCh {
 		switch event.Type {
 		case "error":
-			return h.sendOpenAIError(c, event.Content, "internal_error")
+			return h.sendOpenAIError(c, http.StatusInternalServerError, event.Content, "internal_error")
+		case "chunk_result":
+			if event.ChunkResult != nil {
+				sources = append(sources, toOpenAISource(event.ChunkResult))
+			}
 		case "data":
 			content += event.Content
 		case "done":
-			// send complete response
-			resp := domain.OpenAICompletionsResponse{
-				ID:      responseID,
-				Object:  "chat.completion",
-				Created: created,
-				Model:   model,
-				Choices: []domain.OpenAIChoice{
-					{
-						Index: 0,
-						Message: domain.OpenAIMessage{
-							Role:    "assistant",
-							Content: domain.NewStringContent(content),
-						},
-						FinishReason: "stop",
-					},
+			done = true
+			usage = event.Usage
+		case "suggestions":
+			suggestions = event.Suggestions
+		}
+	}
+	if !done {
+		// the answer ended without done, e.g. the request context was canceled
+		return h.sendOpenAIError(c, http.StatusInternalServerError, "response ended before the answer completed", "internal_error")
+	}
+	// send complete response
+	resp := domain.OpenAICompletionsResponse{
+		ID:      responseID,
+		Object:  "chat.completion",
+		Created: created,
+		Model:   model,
+		Choices: []domain.OpenAIChoice{
+			{
+				Index: 0,
+				Message: domain.OpenAIMessage{
+					Role:    "assistant",
+					Content: domain.NewStringContent(content),
 				},
-			}
-			return c.JSON(http.StatusOK, resp)
+				FinishReason: "stop",
+			},
+		},
+		Usage:       usage,
+		Suggestions: suggestions,
+		Sources:     sources,
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// ChatModels OpenAI API compatible model list
+//
+//	@Summary		ChatModels
+//	@Description	OpenAI API compatible model list, returns the chat and embedding models in use
+//	@Tags			share_chat
+//	@Produce		json
+//	@Param			X-KB-ID	header		string	true	"Knowledge Base ID"
+//	@Success		200		{object}	domain.OpenAIModelList
+//	@Failure		401		{object}	domain.OpenAIErrorResponse
+//	@Router			/share/v1/chat/models [get]
+func (h *ShareChatHandler) ChatModels(c echo.Context) error {
+	if _, authErr := h.authorizeOpenAIRequest(c); authErr != nil {
+		return h.sendOpenAIError(c, authErr.status, authErr.message, authErr.errorType)
+	}
+	ctx := c.Request().Context()
+	models := make([]domain.OpenAIModel, 0, 2)
+	for _, modelType := range []domain.ModelType{domain.ModelTypeChat, domain.ModelTypeEmbedding} {
+		model, err := h.modelUsecase.GetActiveModelByType(ctx, modelType)
+		if err != nil {
+			h.logger.Warn("get model failed", log.String("type", string(modelType)), log.Error(err))
+			continue
+		}
+		created := time.Now().Unix()
+		if !model.CreatedAt.IsZero() {
+			created = model.CreatedAt.Unix()
 		}
+		models = append(models, domain.OpenAIModel{
+			ID:      model.Model,
+			Object:  "model",
+			Created: created,
+			OwnedBy: "pandawiki",
+		})
 	}
-	return nil
+	return c.JSON(http.StatusOK, domain.OpenAIModelList{Object: "list", Data: models})
 }
 
-func (h *ShareChatHandler) sendOpenAIError(c echo.Context, message, errorType string) error {
+// ChatEmbeddings OpenAI API compatible embeddings
+//
+//	@Summary		ChatEmbeddings
+//	@Description	OpenAI API compatible embeddings, passed through to the embedding model in use
+//	@Tags			share_chat
+//	@Accept			json
+//	@Produce		json
+//	@Param			X-KB-ID	header		string							true	"Knowledge Base ID"
+//	@Param			request	body		domain.OpenAIEmbeddingsRequest	true	"OpenAI embeddings request"
+//	@Success		200		{object}	domain.OpenAIEmbeddingsResponse
+//	@Failure		400		{object}	domain.OpenAIErrorResponse
+//	@Failure		401		{object}	domain.OpenAIErrorResponse
+//	@Failure		502		{object}	domain.OpenAIErrorResponse
+//	@Router			/share/v1/chat/embeddings [post]
+func (h *ShareChatHandler) ChatEmbeddings(c echo.Context) error {
+	var req domain.OpenAIEmbeddingsRequest
+	if err := c.Bind(&req); err != nil {
+		h.logger.Error("parse OpenAI embeddings request failed", log.Error(err))
+		return h.sendOpenAIError(c, http.StatusBadRequest, "parse request failed", "invalid_request_error")
+	}
+	if _, authErr := h.authorizeOpenAIRequest(c); authErr != nil {
+		return h.sendOpenAIError(c, authErr.status, authErr.message, authErr.errorType)
+	}
+	if err := req.Validate(); err != nil {
+		return h.sendOpenAIError(c, http.StatusBadRequest, err.Error(), "invalid_request_error")
+	}
+
+	model, embeddings, usage, err := h.chatUsecase.Embed(c.Request().Context(), req.Input)
+	if err != nil {
+		h.logger.Error("embed failed", log.Error(err))
+		return h.sendOpenAIError(c, http.StatusBadGateway, err.Error(), "upstream_error")
+	}
+	data := make([]domain.OpenAIEmbedding, 0, len(embeddings))
+	for i, embedding := range embeddings {
+		data = append(data, domain.OpenAIEmbedding{
+			Object:    "embedding",
+			Index:     i,
+			Embedding: embedding,
+			Base64:    req.EncodingFormat == "base64",
+		})
+	}
+	return c.JSON(http.StatusOK, domain.OpenAIEmbeddingsResponse{
+		Object: "list",
+		Data:   data,
+		Model:  model,
+		Usage:  usage,
+	})
+}
+
+func toOpenAISource(chunk *domain.NodeContentChunkSSE) domain.OpenAISource {
+	return domain.OpenAISource{
+		NodeID:    chunk.NodeID,
+		Name:      chunk.Name,
+		Summary:   chunk.Summary,
+		URL:       chunk.URL,
+		PathNames: chunk.NodePathNames,
+	}
+}
+
+// sendOpenAIError writes the error as json, or as a stream event once the stream has started
+func (h *ShareChatHandler) sendOpenAIError(c echo.Context, status int, message, errorType string) error {
 	errResp := domain.OpenAIErrorResponse{
 		Error: domain.OpenAIError{
 			Message: message,
 			Type:    errorType,
 		},
 	}
-	return c.JSON(http.StatusBadRequest, errResp)
+	if c.Response().Committed {
+		jsonContent, err := json.Marshal(errResp)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(c.Response(), "data: %s\n\n", jsonContent); err != nil {
+			return err
+		}
+		c.Response().Flush()
+		return nil
+	}
+	return c.JSON(status, errResp)
 }
 
 func (h *ShareChatHandler) writeOpenAIStreamEvent(c echo.Context, data domain.OpenAIStreamResponse) error {
+	if !c.Response().Committed {
+		// set stream response header
+		c.Response().Header().Set("Content-Type", "text/event-stream")
+		c.Response().Header().Set("Cache-Control", "no-cache")
+		c.Response().Header().Set("Connection", "keep-alive")
+		c.Response().Header().Set("Transfer-Encoding", "chunked")
+		c.Response().WriteHeader(http.StatusOK)
+	}
 	jsonContent, err := json.Marshal(data)
 	if err != nil {
 		return err
@@ -437,6 +809,17 @@ func (h *ShareChatHandler) writeOpenAIStreamEvent(c echo.Context, data domain.Op
 	return nil
 }
 
+func (h *ShareChatHandler) writeOpenAIStreamDone(c echo.Context) error {
+	if !c.Response().Committed {
+		return nil
+	}
+	if _, err := c.Response().Write([]byte("data: [DONE]\n\n")); err != nil {
+		return err
+	}
+	c.Response().Flush()
+	return nil
+}
+
 func generateID() string {
 	return fmt.Sprintf("%d", time.Now().UnixNano())
 }
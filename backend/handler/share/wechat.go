@@ -387,7 +387,7 @@ func (h *ShareWechatHandler) WechatHandlerApp(c echo.Context) error {
 	}
 	h.logger.Info("wechat app msg", log.Any("user msg", msg))
 
-	if msg.MsgType != "text" { // 用户进入会话，或者其他非提问类型的事件
+	if msg.MsgType != "text" && msg.MsgType != "image" { // 用户进入会话，或者其他非提问类型的事件
 		return c.String(http.StatusOK, "")
 	}
 
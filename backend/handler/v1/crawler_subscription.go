@@ -0,0 +1,198 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/chaitin/panda-wiki/consts"
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/handler"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/middleware"
+	"github.com/chaitin/panda-wiki/usecase"
+)
+
+type CrawlerSubscriptionHandler struct {
+	*handler.BaseHandler
+	logger  *log.Logger
+	auth    middleware.AuthMiddleware
+	usecase *usecase.CrawlerSubscriptionUsecase
+}
+
+func NewCrawlerSubscriptionHandler(e *echo.Echo, baseHandler *handler.BaseHandler, logger *log.Logger, auth middleware.AuthMiddleware,
+	usecase *usecase.CrawlerSubscriptionUsecase) *CrawlerSubscriptionHandler {
+	h := &CrawlerSubscriptionHandler{
+		BaseHandler: baseHandler,
+		logger:      logger.WithModule("handler.v1.crawler_subscription"),
+		auth:        auth,
+		usecase:     usecase,
+	}
+
+	group := e.Group("/api/v1/crawler_subscription", h.auth.Authorize, h.auth.ValidateKBUserPerm(consts.UserKBPermissionFullControl))
+	group.GET("/list", h.GetCrawlerSubscriptionList)
+	group.POST("", h.CreateCrawlerSubscription)
+	group.PUT("", h.UpdateCrawlerSubscription)
+	group.DELETE("", h.DeleteCrawlerSubscription)
+	group.POST("/sync", h.SyncCrawlerSubscription)
+	group.GET("/nodes", h.GetCrawlerSubscriptionNodes)
+
+	return h
+}
+
+// GetCrawlerSubscriptionList
+//
+//	@Summary		GetCrawlerSubscriptionList
+//	@Description	List the url, rss and sitemap subscriptions of the kb with the result of their last sync
+//	@Tags			crawler_subscription
+//	@Accept			json
+//	@Produce		json
+//	@Param			req	query		domain.CrawlerSubscriptionListReq						true	"CrawlerSubscriptionListReq"
+//	@Success		200	{object}	domain.PWResponse{data=[]domain.CrawlerSubscription}	"crawler subscription list"
+//	@Router			/api/v1/crawler_subscription/list [get]
+func (h *CrawlerSubscriptionHandler) GetCrawlerSubscriptionList(c echo.Context) error {
+	var req domain.CrawlerSubscriptionListReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	subscriptions, err := h.usecase.GetList(c.Request().Context(), req.KBID)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to get crawler subscription list", err)
+	}
+	return h.NewResponseWithData(c, subscriptions)
+}
+
+// CreateCrawlerSubscription
+//
+//	@Summary		CreateCrawlerSubscription
+//	@Description	Subscribe to a url, rss feed or sitemap, it is synced into the kb on a schedule
+//	@Tags			crawler_subscription
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		domain.CreateCrawlerSubscriptionReq					true	"CreateCrawlerSubscriptionReq"
+//	@Success		200		{object}	domain.PWResponse{data=domain.CrawlerSubscription}	"crawler subscription"
+//	@Router			/api/v1/crawler_subscription [post]
+func (h *CrawlerSubscriptionHandler) CreateCrawlerSubscription(c echo.Context) error {
+	ctx := c.Request().Context()
+	authInfo := domain.GetAuthInfoFromCtx(ctx)
+	if authInfo == nil {
+		return h.NewResponseWithError(c, "authInfo not found in context", nil)
+	}
+
+	var req domain.CreateCrawlerSubscriptionReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	subscription, err := h.usecase.Create(ctx, &req, authInfo.UserId, domain.GetBaseEditionLimitation(ctx).MaxNode)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to create crawler subscription", err)
+	}
+	return h.NewResponseWithData(c, subscription)
+}
+
+// UpdateCrawlerSubscription
+//
+//	@Summary		UpdateCrawlerSubscription
+//	@Description	UpdateCrawlerSubscription
+//	@Tags			crawler_subscription
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		domain.UpdateCrawlerSubscriptionReq	true	"UpdateCrawlerSubscriptionReq"
+//	@Success		200		{object}	domain.Response
+//	@Router			/api/v1/crawler_subscription [put]
+func (h *CrawlerSubscriptionHandler) UpdateCrawlerSubscription(c echo.Context) error {
+	var req domain.UpdateCrawlerSubscriptionReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	if err := h.usecase.Update(c.Request().Context(), &req); err != nil {
+		return h.NewResponseWithError(c, "failed to update crawler subscription", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
+
+// DeleteCrawlerSubscription
+//
+//	@Summary		DeleteCrawlerSubscription
+//	@Description	Delete a crawler subscription, the synced nodes are kept unless delete_nodes is set
+//	@Tags			crawler_subscription
+//	@Accept			json
+//	@Produce		json
+//	@Param			req	query		domain.DeleteCrawlerSubscriptionReq	true	"DeleteCrawlerSubscriptionReq"
+//	@Success		200	{object}	domain.Response
+//	@Router			/api/v1/crawler_subscription [delete]
+func (h *CrawlerSubscriptionHandler) DeleteCrawlerSubscription(c echo.Context) error {
+	var req domain.DeleteCrawlerSubscriptionReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	if err := h.usecase.Delete(c.Request().Context(), &req); err != nil {
+		return h.NewResponseWithError(c, "failed to delete crawler subscription", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
+
+// SyncCrawlerSubscription
+//
+//	@Summary		SyncCrawlerSubscription
+//	@Description	Sync a crawler subscription in the background now instead of waiting for its schedule
+//	@Tags			crawler_subscription
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		domain.SyncCrawlerSubscriptionReq	true	"SyncCrawlerSubscriptionReq"
+//	@Success		200		{object}	domain.Response
+//	@Router			/api/v1/crawler_subscription/sync [post]
+func (h *CrawlerSubscriptionHandler) SyncCrawlerSubscription(c echo.Context) error {
+	var req domain.SyncCrawlerSubscriptionReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	if err := h.usecase.Sync(c.Request().Context(), &req); err != nil {
+		return h.NewResponseWithError(c, "failed to sync crawler subscription", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
+
+// GetCrawlerSubscriptionNodes
+//
+//	@Summary		GetCrawlerSubscriptionNodes
+//	@Description	List the nodes synced by a crawler subscription with the source of each node
+//	@Tags			crawler_subscription
+//	@Accept			json
+//	@Produce		json
+//	@Param			req	query		domain.CrawlerSubscriptionNodesReq			true	"CrawlerSubscriptionNodesReq"
+//	@Success		200	{object}	domain.PWResponse{data=[]domain.NodeSource}	"node sources"
+//	@Router			/api/v1/crawler_subscription/nodes [get]
+func (h *CrawlerSubscriptionHandler) GetCrawlerSubscriptionNodes(c echo.Context) error {
+	var req domain.CrawlerSubscriptionNodesReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	sources, err := h.usecase.GetNodeSources(c.Request().Context(), req.KBID, req.ID)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to get crawler subscription nodes", err)
+	}
+	return h.NewResponseWithData(c, sources)
+}
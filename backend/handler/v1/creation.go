@@ -5,6 +5,7 @@ import (
 
 	"github.com/labstack/echo/v4"
 
+	"github.com/chaitin/panda-wiki/consts"
 	"github.com/chaitin/panda-wiki/domain"
 	"github.com/chaitin/panda-wiki/handler"
 	"github.com/chaitin/panda-wiki/log"
@@ -24,7 +25,7 @@ func NewCreationHandler(echo *echo.Echo, baseHandler *handler.BaseHandler, logge
 		usecase:     usecase,
 	}
 
-	api := echo.Group("/api/v1/creation", h.V1Auth.Authorize)
+	api := echo.Group("/api/v1/creation", h.V1Auth.Authorize, h.V1Auth.ValidateKBUserPerm(consts.UserKBPermissionDocManage))
 	api.POST("/text", h.Text)
 	api.POST("/tab-complete", h.TabComplete)
 
@@ -0,0 +1,141 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/chaitin/panda-wiki/consts"
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/handler"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/middleware"
+	"github.com/chaitin/panda-wiki/usecase"
+)
+
+type CuratedAnswerHandler struct {
+	*handler.BaseHandler
+	logger  *log.Logger
+	auth    middleware.AuthMiddleware
+	usecase *usecase.CuratedAnswerUsecase
+}
+
+func NewCuratedAnswerHandler(e *echo.Echo, baseHandler *handler.BaseHandler, logger *log.Logger, auth middleware.AuthMiddleware,
+	usecase *usecase.CuratedAnswerUsecase) *CuratedAnswerHandler {
+	h := &CuratedAnswerHandler{
+		BaseHandler: baseHandler,
+		logger:      logger.WithModule("handler.v1.curated_answer"),
+		auth:        auth,
+		usecase:     usecase,
+	}
+
+	group := e.Group("/api/v1/curated_answer", h.auth.Authorize, h.auth.ValidateKBUserPerm(consts.UserKBPermissionDataOperate))
+	group.GET("", h.GetCuratedAnswerList)
+	group.POST("", h.CreateCuratedAnswer)
+	group.PUT("", h.UpdateCuratedAnswer)
+	group.DELETE("", h.DeleteCuratedAnswer)
+
+	return h
+}
+
+type CuratedAnswerList = domain.PaginatedResult[[]*domain.CuratedAnswer]
+
+// GetCuratedAnswerList
+//
+//	@Summary		GetCuratedAnswerList
+//	@Description	GetCuratedAnswerList
+//	@Tags			curated_answer
+//	@Accept			json
+//	@Produce		json
+//	@Param			req	query		domain.CuratedAnswerListReq					true	"CuratedAnswerListReq"
+//	@Success		200	{object}	domain.PWResponse{data=CuratedAnswerList}	"curated answer list"
+//	@Router			/api/v1/curated_answer [get]
+func (h *CuratedAnswerHandler) GetCuratedAnswerList(c echo.Context) error {
+	var req domain.CuratedAnswerListReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	list, err := h.usecase.GetList(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to get curated answer list", err)
+	}
+	return h.NewResponseWithData(c, list)
+}
+
+// CreateCuratedAnswer
+//
+//	@Summary		CreateCuratedAnswer
+//	@Description	Create a curated answer, message_id corrects the answer of a conversation message
+//	@Tags			curated_answer
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		domain.CreateCuratedAnswerReq					true	"CreateCuratedAnswerReq"
+//	@Success		200		{object}	domain.PWResponse{data=domain.CuratedAnswer}	"curated answer"
+//	@Router			/api/v1/curated_answer [post]
+func (h *CuratedAnswerHandler) CreateCuratedAnswer(c echo.Context) error {
+	var req domain.CreateCuratedAnswerReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	answer, err := h.usecase.Create(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to create curated answer", err)
+	}
+	return h.NewResponseWithData(c, answer)
+}
+
+// UpdateCuratedAnswer
+//
+//	@Summary		UpdateCuratedAnswer
+//	@Description	UpdateCuratedAnswer
+//	@Tags			curated_answer
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		domain.UpdateCuratedAnswerReq	true	"UpdateCuratedAnswerReq"
+//	@Success		200		{object}	domain.Response
+//	@Router			/api/v1/curated_answer [put]
+func (h *CuratedAnswerHandler) UpdateCuratedAnswer(c echo.Context) error {
+	var req domain.UpdateCuratedAnswerReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	if err := h.usecase.Update(c.Request().Context(), &req); err != nil {
+		return h.NewResponseWithError(c, "failed to update curated answer", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
+
+// DeleteCuratedAnswer
+//
+//	@Summary		DeleteCuratedAnswer
+//	@Description	DeleteCuratedAnswer
+//	@Tags			curated_answer
+//	@Accept			json
+//	@Produce		json
+//	@Param			req	query		domain.DeleteCuratedAnswerReq	true	"DeleteCuratedAnswerReq"
+//	@Success		200	{object}	domain.Response
+//	@Router			/api/v1/curated_answer [delete]
+func (h *CuratedAnswerHandler) DeleteCuratedAnswer(c echo.Context) error {
+	var req domain.DeleteCuratedAnswerReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	if err := h.usecase.Delete(c.Request().Context(), req.KBID, req.ID); err != nil {
+		return h.NewResponseWithError(c, "failed to delete curated answer", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
@@ -0,0 +1,357 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/chaitin/panda-wiki/consts"
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/handler"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/middleware"
+	"github.com/chaitin/panda-wiki/usecase"
+)
+
+type EvalHandler struct {
+	*handler.BaseHandler
+	logger  *log.Logger
+	auth    middleware.AuthMiddleware
+	usecase *usecase.EvalUsecase
+}
+
+func NewEvalHandler(e *echo.Echo, baseHandler *handler.BaseHandler, logger *log.Logger, auth middleware.AuthMiddleware,
+	usecase *usecase.EvalUsecase) *EvalHandler {
+	h := &EvalHandler{
+		BaseHandler: baseHandler,
+		logger:      logger.WithModule("handler.v1.eval"),
+		auth:        auth,
+		usecase:     usecase,
+	}
+
+	group := e.Group("/api/v1/eval", h.auth.Authorize, h.auth.ValidateKBUserPerm(consts.UserKBPermissionFullControl))
+	group.GET("/set/list", h.GetEvalSetList)
+	group.POST("/set", h.CreateEvalSet)
+	group.PUT("/set", h.UpdateEvalSet)
+	group.DELETE("/set", h.DeleteEvalSet)
+
+	group.GET("/question/list", h.GetEvalQuestionList)
+	group.POST("/question", h.CreateEvalQuestion)
+	group.PUT("/question", h.UpdateEvalQuestion)
+	group.DELETE("/question", h.DeleteEvalQuestion)
+
+	group.POST("/run", h.CreateEvalRun)
+	group.GET("/run/list", h.GetEvalRunList)
+	group.GET("/run/detail", h.GetEvalRunDetail)
+	group.GET("/run/compare", h.CompareEvalRuns)
+
+	return h
+}
+
+// GetEvalSetList
+//
+//	@Summary		GetEvalSetList
+//	@Description	GetEvalSetList
+//	@Tags			eval
+//	@Accept			json
+//	@Produce		json
+//	@Param			req	query		domain.EvalSetListReq						true	"EvalSetListReq"
+//	@Success		200	{object}	domain.PWResponse{data=[]domain.EvalSet}	"eval set list"
+//	@Router			/api/v1/eval/set/list [get]
+func (h *EvalHandler) GetEvalSetList(c echo.Context) error {
+	var req domain.EvalSetListReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	sets, err := h.usecase.GetSetList(c.Request().Context(), req.KBID)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to get eval set list", err)
+	}
+	return h.NewResponseWithData(c, sets)
+}
+
+// CreateEvalSet
+//
+//	@Summary		CreateEvalSet
+//	@Description	CreateEvalSet
+//	@Tags			eval
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		domain.CreateEvalSetReq					true	"CreateEvalSetReq"
+//	@Success		200		{object}	domain.PWResponse{data=domain.EvalSet}	"eval set"
+//	@Router			/api/v1/eval/set [post]
+func (h *EvalHandler) CreateEvalSet(c echo.Context) error {
+	var req domain.CreateEvalSetReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	set, err := h.usecase.CreateSet(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to create eval set", err)
+	}
+	return h.NewResponseWithData(c, set)
+}
+
+// UpdateEvalSet
+//
+//	@Summary		UpdateEvalSet
+//	@Description	UpdateEvalSet
+//	@Tags			eval
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		domain.UpdateEvalSetReq	true	"UpdateEvalSetReq"
+//	@Success		200		{object}	domain.Response
+//	@Router			/api/v1/eval/set [put]
+func (h *EvalHandler) UpdateEvalSet(c echo.Context) error {
+	var req domain.UpdateEvalSetReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	if err := h.usecase.UpdateSet(c.Request().Context(), &req); err != nil {
+		return h.NewResponseWithError(c, "failed to update eval set", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
+
+// DeleteEvalSet
+//
+//	@Summary		DeleteEvalSet
+//	@Description	Delete an eval set with its questions and runs
+//	@Tags			eval
+//	@Accept			json
+//	@Produce		json
+//	@Param			req	query		domain.DeleteEvalSetReq	true	"DeleteEvalSetReq"
+//	@Success		200	{object}	domain.Response
+//	@Router			/api/v1/eval/set [delete]
+func (h *EvalHandler) DeleteEvalSet(c echo.Context) error {
+	var req domain.DeleteEvalSetReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	if err := h.usecase.DeleteSet(c.Request().Context(), req.KBID, req.ID); err != nil {
+		return h.NewResponseWithError(c, "failed to delete eval set", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
+
+// GetEvalQuestionList
+//
+//	@Summary		GetEvalQuestionList
+//	@Description	GetEvalQuestionList
+//	@Tags			eval
+//	@Accept			json
+//	@Produce		json
+//	@Param			req	query		domain.EvalQuestionListReq						true	"EvalQuestionListReq"
+//	@Success		200	{object}	domain.PWResponse{data=[]domain.EvalQuestion}	"eval question list"
+//	@Router			/api/v1/eval/question/list [get]
+func (h *EvalHandler) GetEvalQuestionList(c echo.Context) error {
+	var req domain.EvalQuestionListReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	questions, err := h.usecase.GetQuestionList(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to get eval question list", err)
+	}
+	return h.NewResponseWithData(c, questions)
+}
+
+// CreateEvalQuestion
+//
+//	@Summary		CreateEvalQuestion
+//	@Description	CreateEvalQuestion
+//	@Tags			eval
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		domain.CreateEvalQuestionReq				true	"CreateEvalQuestionReq"
+//	@Success		200		{object}	domain.PWResponse{data=domain.EvalQuestion}	"eval question"
+//	@Router			/api/v1/eval/question [post]
+func (h *EvalHandler) CreateEvalQuestion(c echo.Context) error {
+	var req domain.CreateEvalQuestionReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	question, err := h.usecase.CreateQuestion(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to create eval question", err)
+	}
+	return h.NewResponseWithData(c, question)
+}
+
+// UpdateEvalQuestion
+//
+//	@Summary		UpdateEvalQuestion
+//	@Description	UpdateEvalQuestion
+//	@Tags			eval
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		domain.UpdateEvalQuestionReq	true	"UpdateEvalQuestionReq"
+//	@Success		200		{object}	domain.Response
+//	@Router			/api/v1/eval/question [put]
+func (h *EvalHandler) UpdateEvalQuestion(c echo.Context) error {
+	var req domain.UpdateEvalQuestionReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	if err := h.usecase.UpdateQuestion(c.Request().Context(), &req); err != nil {
+		return h.NewResponseWithError(c, "failed to update eval question", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
+
+// DeleteEvalQuestion
+//
+//	@Summary		DeleteEvalQuestion
+//	@Description	DeleteEvalQuestion
+//	@Tags			eval
+//	@Accept			json
+//	@Produce		json
+//	@Param			req	query		domain.DeleteEvalQuestionReq	true	"DeleteEvalQuestionReq"
+//	@Success		200	{object}	domain.Response
+//	@Router			/api/v1/eval/question [delete]
+func (h *EvalHandler) DeleteEvalQuestion(c echo.Context) error {
+	var req domain.DeleteEvalQuestionReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	if err := h.usecase.DeleteQuestion(c.Request().Context(), req.KBID, req.ID); err != nil {
+		return h.NewResponseWithError(c, "failed to delete eval question", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
+
+// CreateEvalRun
+//
+//	@Summary		CreateEvalRun
+//	@Description	Run an eval set with the current models and prompt, the run is executed in the background
+//	@Tags			eval
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		domain.CreateEvalRunReq					true	"CreateEvalRunReq"
+//	@Success		200		{object}	domain.PWResponse{data=domain.EvalRun}	"eval run"
+//	@Router			/api/v1/eval/run [post]
+func (h *EvalHandler) CreateEvalRun(c echo.Context) error {
+	var req domain.CreateEvalRunReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	run, err := h.usecase.CreateRun(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to create eval run", err)
+	}
+	return h.NewResponseWithData(c, run)
+}
+
+type EvalRunList = domain.PaginatedResult[[]*domain.EvalRun]
+
+// GetEvalRunList
+//
+//	@Summary		GetEvalRunList
+//	@Description	GetEvalRunList
+//	@Tags			eval
+//	@Accept			json
+//	@Produce		json
+//	@Param			req	query		domain.EvalRunListReq				true	"EvalRunListReq"
+//	@Success		200	{object}	domain.PWResponse{data=EvalRunList}	"eval run list"
+//	@Router			/api/v1/eval/run/list [get]
+func (h *EvalHandler) GetEvalRunList(c echo.Context) error {
+	var req domain.EvalRunListReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	runs, err := h.usecase.GetRunList(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to get eval run list", err)
+	}
+	return h.NewResponseWithData(c, runs)
+}
+
+// GetEvalRunDetail
+//
+//	@Summary		GetEvalRunDetail
+//	@Description	GetEvalRunDetail
+//	@Tags			eval
+//	@Accept			json
+//	@Produce		json
+//	@Param			req	query		domain.EvalRunDetailReq								true	"EvalRunDetailReq"
+//	@Success		200	{object}	domain.PWResponse{data=domain.EvalRunDetailResp}	"eval run detail"
+//	@Router			/api/v1/eval/run/detail [get]
+func (h *EvalHandler) GetEvalRunDetail(c echo.Context) error {
+	var req domain.EvalRunDetailReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	detail, err := h.usecase.GetRunDetail(c.Request().Context(), req.KBID, req.ID)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to get eval run detail", err)
+	}
+	return h.NewResponseWithData(c, detail)
+}
+
+// CompareEvalRuns
+//
+//	@Summary		CompareEvalRuns
+//	@Description	Compare the metrics and per question results of two runs
+//	@Tags			eval
+//	@Accept			json
+//	@Produce		json
+//	@Param			req	query		domain.EvalRunCompareReq							true	"EvalRunCompareReq"
+//	@Success		200	{object}	domain.PWResponse{data=domain.EvalRunCompareResp}	"eval run compare"
+//	@Router			/api/v1/eval/run/compare [get]
+func (h *EvalHandler) CompareEvalRuns(c echo.Context) error {
+	var req domain.EvalRunCompareReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	resp, err := h.usecase.CompareRuns(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to compare eval runs", err)
+	}
+	return h.NewResponseWithData(c, resp)
+}
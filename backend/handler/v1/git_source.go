@@ -0,0 +1,171 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/chaitin/panda-wiki/consts"
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/handler"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/middleware"
+	"github.com/chaitin/panda-wiki/usecase"
+)
+
+type GitSourceHandler struct {
+	*handler.BaseHandler
+	logger  *log.Logger
+	auth    middleware.AuthMiddleware
+	usecase *usecase.GitSourceUsecase
+}
+
+func NewGitSourceHandler(e *echo.Echo, baseHandler *handler.BaseHandler, logger *log.Logger, auth middleware.AuthMiddleware,
+	usecase *usecase.GitSourceUsecase) *GitSourceHandler {
+	h := &GitSourceHandler{
+		BaseHandler: baseHandler,
+		logger:      logger.WithModule("handler.v1.git_source"),
+		auth:        auth,
+		usecase:     usecase,
+	}
+
+	group := e.Group("/api/v1/git_source", h.auth.Authorize, h.auth.ValidateKBUserPerm(consts.UserKBPermissionFullControl))
+	group.GET("/list", h.GetGitSourceList)
+	group.POST("", h.CreateGitSource)
+	group.PUT("", h.UpdateGitSource)
+	group.DELETE("", h.DeleteGitSource)
+	group.POST("/sync", h.SyncGitSource)
+
+	return h
+}
+
+// GetGitSourceList
+//
+//	@Summary		GetGitSourceList
+//	@Description	List the git sources of the kb with the result of their last sync
+//	@Tags			git_source
+//	@Accept			json
+//	@Produce		json
+//	@Param			req	query		domain.GitSourceListReq						true	"GitSourceListReq"
+//	@Success		200	{object}	domain.PWResponse{data=[]domain.GitSource}	"git source list"
+//	@Router			/api/v1/git_source/list [get]
+func (h *GitSourceHandler) GetGitSourceList(c echo.Context) error {
+	var req domain.GitSourceListReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	sources, err := h.usecase.GetList(c.Request().Context(), req.KBID)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to get git source list", err)
+	}
+	return h.NewResponseWithData(c, sources)
+}
+
+// CreateGitSource
+//
+//	@Summary		CreateGitSource
+//	@Description	Add a git repository, either a remote url or a local path, whose markdown files are synced into the kb
+//	@Tags			git_source
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		domain.CreateGitSourceReq					true	"CreateGitSourceReq"
+//	@Success		200		{object}	domain.PWResponse{data=domain.GitSource}	"git source"
+//	@Router			/api/v1/git_source [post]
+func (h *GitSourceHandler) CreateGitSource(c echo.Context) error {
+	var req domain.CreateGitSourceReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	source, err := h.usecase.Create(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to create git source", err)
+	}
+	return h.NewResponseWithData(c, source)
+}
+
+// UpdateGitSource
+//
+//	@Summary		UpdateGitSource
+//	@Description	UpdateGitSource
+//	@Tags			git_source
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		domain.UpdateGitSourceReq	true	"UpdateGitSourceReq"
+//	@Success		200		{object}	domain.Response
+//	@Router			/api/v1/git_source [put]
+func (h *GitSourceHandler) UpdateGitSource(c echo.Context) error {
+	var req domain.UpdateGitSourceReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	if err := h.usecase.Update(c.Request().Context(), &req); err != nil {
+		return h.NewResponseWithError(c, "failed to update git source", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
+
+// DeleteGitSource
+//
+//	@Summary		DeleteGitSource
+//	@Description	Delete a git source, the synced nodes are kept unless delete_nodes is set
+//	@Tags			git_source
+//	@Accept			json
+//	@Produce		json
+//	@Param			req	query		domain.DeleteGitSourceReq	true	"DeleteGitSourceReq"
+//	@Success		200	{object}	domain.Response
+//	@Router			/api/v1/git_source [delete]
+func (h *GitSourceHandler) DeleteGitSource(c echo.Context) error {
+	var req domain.DeleteGitSourceReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	if err := h.usecase.Delete(c.Request().Context(), &req); err != nil {
+		return h.NewResponseWithError(c, "failed to delete git source", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
+
+// SyncGitSource
+//
+//	@Summary		SyncGitSource
+//	@Description	Sync a git source in the background, only the documents changed since the last synced commit are updated
+//	@Tags			git_source
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		domain.SyncGitSourceReq	true	"SyncGitSourceReq"
+//	@Success		200		{object}	domain.Response
+//	@Router			/api/v1/git_source/sync [post]
+func (h *GitSourceHandler) SyncGitSource(c echo.Context) error {
+	ctx := c.Request().Context()
+	authInfo := domain.GetAuthInfoFromCtx(ctx)
+	if authInfo == nil {
+		return h.NewResponseWithError(c, "authInfo not found in context", nil)
+	}
+
+	var req domain.SyncGitSourceReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	if err := h.usecase.Sync(ctx, &req, authInfo.UserId, domain.GetBaseEditionLimitation(ctx).MaxNode); err != nil {
+		return h.NewResponseWithError(c, "failed to sync git source", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
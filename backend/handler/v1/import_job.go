@@ -0,0 +1,177 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/chaitin/panda-wiki/consts"
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/handler"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/middleware"
+	"github.com/chaitin/panda-wiki/usecase"
+)
+
+type ImportJobHandler struct {
+	*handler.BaseHandler
+	logger  *log.Logger
+	auth    middleware.AuthMiddleware
+	usecase *usecase.ImportJobUsecase
+}
+
+func NewImportJobHandler(e *echo.Echo, baseHandler *handler.BaseHandler, logger *log.Logger, auth middleware.AuthMiddleware,
+	usecase *usecase.ImportJobUsecase) *ImportJobHandler {
+	h := &ImportJobHandler{
+		BaseHandler: baseHandler,
+		logger:      logger.WithModule("handler.v1.import_job"),
+		auth:        auth,
+		usecase:     usecase,
+	}
+
+	group := e.Group("/api/v1/crawler/import_job", h.auth.Authorize, h.auth.ValidateKBUserPerm(consts.UserKBPermissionDocManage))
+	group.POST("", h.CreateImportJob)
+	group.GET("/list", h.GetImportJobList)
+	group.GET("/detail", h.GetImportJobDetail)
+	group.GET("/items", h.GetImportJobItemList)
+	group.POST("/retry", h.RetryImportJob)
+
+	return h
+}
+
+// CreateImportJob
+//
+//	@Summary		CreateImportJob
+//	@Description	Import the documents picked from a crawler parse in the background, the job survives restarts
+//	@Tags			import_job
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		domain.CreateImportJobReq					true	"CreateImportJobReq"
+//	@Success		200		{object}	domain.PWResponse{data=domain.ImportJob}	"import job"
+//	@Router			/api/v1/crawler/import_job [post]
+func (h *ImportJobHandler) CreateImportJob(c echo.Context) error {
+	ctx := c.Request().Context()
+	authInfo := domain.GetAuthInfoFromCtx(ctx)
+	if authInfo == nil {
+		return h.NewResponseWithError(c, "authInfo not found in context", nil)
+	}
+
+	var req domain.CreateImportJobReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	job, err := h.usecase.Create(ctx, &req, authInfo.UserId, domain.GetBaseEditionLimitation(ctx).MaxNode)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to create import job", err)
+	}
+	return h.NewResponseWithData(c, job)
+}
+
+type ImportJobList = domain.PaginatedResult[[]*domain.ImportJob]
+
+// GetImportJobList
+//
+//	@Summary		GetImportJobList
+//	@Description	List the import jobs of the kb with their progress
+//	@Tags			import_job
+//	@Accept			json
+//	@Produce		json
+//	@Param			req	query		domain.ImportJobListReq					true	"ImportJobListReq"
+//	@Success		200	{object}	domain.PWResponse{data=ImportJobList}	"import job list"
+//	@Router			/api/v1/crawler/import_job/list [get]
+func (h *ImportJobHandler) GetImportJobList(c echo.Context) error {
+	var req domain.ImportJobListReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	jobs, err := h.usecase.GetList(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to get import job list", err)
+	}
+	return h.NewResponseWithData(c, jobs)
+}
+
+// GetImportJobDetail
+//
+//	@Summary		GetImportJobDetail
+//	@Description	Get an import job with the number of documents in each state
+//	@Tags			import_job
+//	@Accept			json
+//	@Produce		json
+//	@Param			req	query		domain.ImportJobDetailReq					true	"ImportJobDetailReq"
+//	@Success		200	{object}	domain.PWResponse{data=domain.ImportJob}	"import job detail"
+//	@Router			/api/v1/crawler/import_job/detail [get]
+func (h *ImportJobHandler) GetImportJobDetail(c echo.Context) error {
+	var req domain.ImportJobDetailReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	job, err := h.usecase.GetDetail(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to get import job detail", err)
+	}
+	return h.NewResponseWithData(c, job)
+}
+
+type ImportJobItemList = domain.PaginatedResult[[]*domain.ImportJobItem]
+
+// GetImportJobItemList
+//
+//	@Summary		GetImportJobItemList
+//	@Description	List the documents of an import job with their state, optionally of one state only
+//	@Tags			import_job
+//	@Accept			json
+//	@Produce		json
+//	@Param			req	query		domain.ImportJobItemListReq					true	"ImportJobItemListReq"
+//	@Success		200	{object}	domain.PWResponse{data=ImportJobItemList}	"import job items"
+//	@Router			/api/v1/crawler/import_job/items [get]
+func (h *ImportJobHandler) GetImportJobItemList(c echo.Context) error {
+	var req domain.ImportJobItemListReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	items, err := h.usecase.GetItemList(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to get import job items", err)
+	}
+	return h.NewResponseWithData(c, items)
+}
+
+// RetryImportJob
+//
+//	@Summary		RetryImportJob
+//	@Description	Import the failed documents of a finished import job again, all of them or the given ones
+//	@Tags			import_job
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		domain.RetryImportJobReq	true	"RetryImportJobReq"
+//	@Success		200		{object}	domain.Response
+//	@Router			/api/v1/crawler/import_job/retry [post]
+func (h *ImportJobHandler) RetryImportJob(c echo.Context) error {
+	var req domain.RetryImportJobReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	if err := h.usecase.Retry(c.Request().Context(), &req); err != nil {
+		return h.NewResponseWithError(c, "failed to retry import job", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
@@ -45,6 +45,16 @@ func NewKnowledgeBaseHandler(
 	group.PUT("/detail", h.UpdateKnowledgeBase, h.auth.ValidateKBUserPerm(consts.UserKBPermissionFullControl))
 	group.DELETE("/detail", h.DeleteKnowledgeBase, h.auth.ValidateUserRole(consts.UserRoleAdmin))
 
+	// chunk setting
+	chunkGroup := group.Group("/chunk_setting", h.auth.ValidateKBUserPerm(consts.UserKBPermissionFullControl))
+	chunkGroup.GET("", h.GetChunkSetting)
+	chunkGroup.PUT("", h.UpdateChunkSetting)
+
+	// language setting
+	languageGroup := group.Group("/language_setting", h.auth.ValidateKBUserPerm(consts.UserKBPermissionFullControl))
+	languageGroup.GET("", h.GetLanguageSetting)
+	languageGroup.PUT("", h.UpdateLanguageSetting)
+
 	// user management
 	userGroup := group.Group("/user", h.auth.ValidateKBUserPerm(consts.UserKBPermissionFullControl))
 	userGroup.GET("/list", h.KBUserList)
@@ -290,3 +300,105 @@ func (h *KnowledgeBaseHandler) GetKBReleaseList(c echo.Context) error {
 
 	return h.NewResponseWithData(c, resp)
 }
+
+// GetChunkSetting
+//
+//	@Summary		GetChunkSetting
+//	@Description	Get the chunk strategy of the knowledge base
+//	@Tags			knowledge_base
+//	@Accept			json
+//	@Produce		json
+//	@Param			params	query		domain.GetChunkSettingReq					true	"GetChunkSettingReq"
+//	@Success		200		{object}	domain.PWResponse{data=domain.ChunkSetting}	"chunk setting"
+//	@Router			/api/v1/knowledge_base/chunk_setting [get]
+func (h *KnowledgeBaseHandler) GetChunkSetting(c echo.Context) error {
+	var req domain.GetChunkSettingReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	setting, err := h.usecase.GetChunkSetting(c.Request().Context(), req.KBID)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to get chunk setting", err)
+	}
+	return h.NewResponseWithData(c, setting)
+}
+
+// UpdateChunkSetting
+//
+//	@Summary		UpdateChunkSetting
+//	@Description	Update the chunk strategy of the knowledge base, it applies to documents published afterwards
+//	@Tags			knowledge_base
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		domain.UpdateChunkSettingReq	true	"UpdateChunkSettingReq"
+//	@Success		200		{object}	domain.Response
+//	@Router			/api/v1/knowledge_base/chunk_setting [put]
+func (h *KnowledgeBaseHandler) UpdateChunkSetting(c echo.Context) error {
+	var req domain.UpdateChunkSettingReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	if err := h.usecase.UpdateChunkSetting(c.Request().Context(), &req); err != nil {
+		return h.NewResponseWithError(c, "failed to update chunk setting", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
+
+// GetLanguageSetting
+//
+//	@Summary		GetLanguageSetting
+//	@Description	Get the language setting of the knowledge base
+//	@Tags			knowledge_base
+//	@Accept			json
+//	@Produce		json
+//	@Param			params	query		domain.GetLanguageSettingReq					true	"GetLanguageSettingReq"
+//	@Success		200		{object}	domain.PWResponse{data=domain.LanguageSetting}	"language setting"
+//	@Router			/api/v1/knowledge_base/language_setting [get]
+func (h *KnowledgeBaseHandler) GetLanguageSetting(c echo.Context) error {
+	var req domain.GetLanguageSettingReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	setting, err := h.usecase.GetLanguageSetting(c.Request().Context(), req.KBID)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to get language setting", err)
+	}
+	return h.NewResponseWithData(c, setting)
+}
+
+// UpdateLanguageSetting
+//
+//	@Summary		UpdateLanguageSetting
+//	@Description	Update the primary language, answer language matching and query translation of the knowledge base
+//	@Tags			knowledge_base
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		domain.UpdateLanguageSettingReq	true	"UpdateLanguageSettingReq"
+//	@Success		200		{object}	domain.Response
+//	@Router			/api/v1/knowledge_base/language_setting [put]
+func (h *KnowledgeBaseHandler) UpdateLanguageSetting(c echo.Context) error {
+	var req domain.UpdateLanguageSettingReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	if err := h.usecase.UpdateLanguageSetting(c.Request().Context(), &req); err != nil {
+		return h.NewResponseWithError(c, "failed to update language setting", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
@@ -0,0 +1,197 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/chaitin/panda-wiki/consts"
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/handler"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/middleware"
+	"github.com/chaitin/panda-wiki/usecase"
+)
+
+type LinkCheckHandler struct {
+	*handler.BaseHandler
+	logger  *log.Logger
+	auth    middleware.AuthMiddleware
+	usecase *usecase.LinkCheckUsecase
+}
+
+func NewLinkCheckHandler(e *echo.Echo, baseHandler *handler.BaseHandler, logger *log.Logger, auth middleware.AuthMiddleware,
+	usecase *usecase.LinkCheckUsecase) *LinkCheckHandler {
+	h := &LinkCheckHandler{
+		BaseHandler: baseHandler,
+		logger:      logger.WithModule("handler.v1.link_check"),
+		auth:        auth,
+		usecase:     usecase,
+	}
+
+	group := e.Group("/api/v1/link_check", h.auth.Authorize, h.auth.ValidateKBUserPerm(consts.UserKBPermissionDocManage))
+	group.POST("/job", h.CreateLinkCheckJob)
+	group.GET("/job/list", h.GetLinkCheckJobList)
+	group.GET("/job/detail", h.GetLinkCheckJobDetail)
+	group.GET("/broken", h.GetLinkCheckBroken)
+	group.GET("/schedule", h.GetLinkCheckSchedule)
+	group.PUT("/schedule", h.UpdateLinkCheckSchedule)
+
+	return h
+}
+
+// CreateLinkCheckJob
+//
+//	@Summary		CreateLinkCheckJob
+//	@Description	Check the node links, uploaded files and external urls of the released documents of the kb in the background
+//	@Tags			link_check
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		domain.CreateLinkCheckJobReq				true	"CreateLinkCheckJobReq"
+//	@Success		200		{object}	domain.PWResponse{data=domain.LinkCheckJob}	"link check job"
+//	@Router			/api/v1/link_check/job [post]
+func (h *LinkCheckHandler) CreateLinkCheckJob(c echo.Context) error {
+	var req domain.CreateLinkCheckJobReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	job, err := h.usecase.Create(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to create link check job", err)
+	}
+	return h.NewResponseWithData(c, job)
+}
+
+type LinkCheckJobList = domain.PaginatedResult[[]*domain.LinkCheckJob]
+
+// GetLinkCheckJobList
+//
+//	@Summary		GetLinkCheckJobList
+//	@Description	List the link check jobs of the kb with the number of links checked and broken
+//	@Tags			link_check
+//	@Accept			json
+//	@Produce		json
+//	@Param			req	query		domain.LinkCheckJobListReq					true	"LinkCheckJobListReq"
+//	@Success		200	{object}	domain.PWResponse{data=LinkCheckJobList}	"link check job list"
+//	@Router			/api/v1/link_check/job/list [get]
+func (h *LinkCheckHandler) GetLinkCheckJobList(c echo.Context) error {
+	var req domain.LinkCheckJobListReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	jobs, err := h.usecase.GetList(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to get link check job list", err)
+	}
+	return h.NewResponseWithData(c, jobs)
+}
+
+// GetLinkCheckJobDetail
+//
+//	@Summary		GetLinkCheckJobDetail
+//	@Description	Get a link check job
+//	@Tags			link_check
+//	@Accept			json
+//	@Produce		json
+//	@Param			req	query		domain.LinkCheckJobDetailReq				true	"LinkCheckJobDetailReq"
+//	@Success		200	{object}	domain.PWResponse{data=domain.LinkCheckJob}	"link check job"
+//	@Router			/api/v1/link_check/job/detail [get]
+func (h *LinkCheckHandler) GetLinkCheckJobDetail(c echo.Context) error {
+	var req domain.LinkCheckJobDetailReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	job, err := h.usecase.GetDetail(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to get link check job detail", err)
+	}
+	return h.NewResponseWithData(c, job)
+}
+
+// GetLinkCheckBroken
+//
+//	@Summary		GetLinkCheckBroken
+//	@Description	Get the broken links and images of each document found by a link check job, the latest finished job by default
+//	@Tags			link_check
+//	@Accept			json
+//	@Produce		json
+//	@Param			req	query		domain.LinkCheckBrokenReq							true	"LinkCheckBrokenReq"
+//	@Success		200	{object}	domain.PWResponse{data=domain.LinkCheckBrokenResp}	"broken links"
+//	@Router			/api/v1/link_check/broken [get]
+func (h *LinkCheckHandler) GetLinkCheckBroken(c echo.Context) error {
+	var req domain.LinkCheckBrokenReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	resp, err := h.usecase.GetBroken(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to get broken links", err)
+	}
+	return h.NewResponseWithData(c, resp)
+}
+
+// GetLinkCheckSchedule
+//
+//	@Summary		GetLinkCheckSchedule
+//	@Description	Get the schedule of the link checks of the kb
+//	@Tags			link_check
+//	@Accept			json
+//	@Produce		json
+//	@Param			req	query		domain.LinkCheckScheduleReq							true	"LinkCheckScheduleReq"
+//	@Success		200	{object}	domain.PWResponse{data=domain.LinkCheckSchedule}	"link check schedule"
+//	@Router			/api/v1/link_check/schedule [get]
+func (h *LinkCheckHandler) GetLinkCheckSchedule(c echo.Context) error {
+	var req domain.LinkCheckScheduleReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	schedule, err := h.usecase.GetSchedule(c.Request().Context(), req.KBID)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to get link check schedule", err)
+	}
+	return h.NewResponseWithData(c, schedule)
+}
+
+// UpdateLinkCheckSchedule
+//
+//	@Summary		UpdateLinkCheckSchedule
+//	@Description	Enable, disable or change the scheduled link checks of the kb, they are run by the consumer
+//	@Tags			link_check
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		domain.UpdateLinkCheckScheduleReq					true	"UpdateLinkCheckScheduleReq"
+//	@Success		200		{object}	domain.PWResponse{data=domain.LinkCheckSchedule}	"link check schedule"
+//	@Router			/api/v1/link_check/schedule [put]
+func (h *LinkCheckHandler) UpdateLinkCheckSchedule(c echo.Context) error {
+	var req domain.UpdateLinkCheckScheduleReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	schedule, err := h.usecase.UpdateSchedule(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to update link check schedule", err)
+	}
+	return h.NewResponseWithData(c, schedule)
+}
@@ -16,15 +16,17 @@ import (
 
 type NodeHandler struct {
 	*handler.BaseHandler
-	logger  *log.Logger
-	usecase *usecase.NodeUsecase
-	auth    middleware.AuthMiddleware
+	logger      *log.Logger
+	usecase     *usecase.NodeUsecase
+	linkUsecase *usecase.NodeLinkUsecase
+	auth        middleware.AuthMiddleware
 }
 
 func NewNodeHandler(
 	baseHandler *handler.BaseHandler,
 	echo *echo.Echo,
 	usecase *usecase.NodeUsecase,
+	linkUsecase *usecase.NodeLinkUsecase,
 	auth middleware.AuthMiddleware,
 	logger *log.Logger,
 ) *NodeHandler {
@@ -32,12 +34,14 @@ func NewNodeHandler(
 		BaseHandler: baseHandler,
 		logger:      logger.WithModule("handler.v1.node"),
 		usecase:     usecase,
+		linkUsecase: linkUsecase,
 		auth:        auth,
 	}
 
 	group := echo.Group("/api/v1/node", h.auth.Authorize, h.auth.ValidateKBUserPerm(consts.UserKBPermissionDocManage))
 	group.GET("/list", h.GetNodeList)
 	group.POST("", h.CreateNode)
+	group.POST("/upsert", h.UpsertNodes)
 	group.GET("/detail", h.GetNodeDetail)
 	group.PUT("/detail", h.UpdateNodeDetail)
 	group.POST("/summary", h.SummaryNode)
@@ -96,6 +100,41 @@ func (h *NodeHandler) CreateNode(c echo.Context) error {
 	})
 }
 
+// UpsertNodes
+//
+//	@Summary		Upsert Nodes
+//	@Description	Create or update documents by external id, missing parent folders are created from the path
+//	@Tags			node
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			body	body		domain.UpsertNodesReq	true	"Nodes"
+//	@Success		200		{object}	domain.PWResponse{data=domain.UpsertNodesResp}
+//	@Router			/api/v1/node/upsert [post]
+func (h *NodeHandler) UpsertNodes(c echo.Context) error {
+	ctx := c.Request().Context()
+	authInfo := domain.GetAuthInfoFromCtx(ctx)
+	if authInfo == nil {
+		return h.NewResponseWithError(c, "authInfo not found in context", nil)
+	}
+
+	req := &domain.UpsertNodesReq{}
+	if err := c.Bind(req); err != nil {
+		return h.NewResponseWithError(c, "request body is invalid", err)
+	}
+	if err := c.Validate(req); err != nil {
+		return h.NewResponseWithError(c, "validate request body failed", err)
+	}
+
+	req.MaxNode = domain.GetBaseEditionLimitation(ctx).MaxNode
+
+	resp, err := h.usecase.Upsert(ctx, req, authInfo.UserId)
+	if err != nil {
+		return h.NewResponseWithError(c, "upsert nodes failed", err)
+	}
+	return h.NewResponseWithData(c, resp)
+}
+
 // GetNodeList
 //
 //	@Summary		Get Node List
@@ -155,13 +194,13 @@ func (h *NodeHandler) GetNodeDetail(c echo.Context) error {
 // NodeAction
 //
 //	@Summary		Node Action
-//	@Description	Node Action
+//	@Description	Node Action, a delete answers the links of other documents it breaks
 //	@Tags			node
 //	@Accept			json
 //	@Produce		json
 //	@Security		bearerAuth
 //	@Param			action	body		domain.NodeActionReq	true	"Action"
-//	@Success		200		{object}	domain.PWResponse{data=map[string]string}
+//	@Success		200		{object}	domain.PWResponse{data=domain.NodeActionResp}
 //	@Router			/api/v1/node/action [post]
 func (h *NodeHandler) NodeAction(c echo.Context) error {
 	req := &domain.NodeActionReq{}
@@ -172,10 +211,19 @@ func (h *NodeHandler) NodeAction(c echo.Context) error {
 		return h.NewResponseWithError(c, "validate request body failed", err)
 	}
 	ctx := c.Request().Context()
+	resp := &domain.NodeActionResp{BrokenLinks: make([]*domain.NodeBacklink, 0)}
+	if req.Action == "delete" {
+		backlinks, err := h.linkUsecase.CheckDelete(ctx, req.KBID, req.IDs)
+		if err != nil {
+			h.logger.Warn("check backlinks of deleted nodes failed", log.Error(err))
+		} else {
+			resp.BrokenLinks = backlinks
+		}
+	}
 	if err := h.usecase.NodeAction(ctx, req); err != nil {
 		return h.NewResponseWithError(c, "node action failed", err)
 	}
-	return h.NewResponseWithData(c, nil)
+	return h.NewResponseWithData(c, resp)
 }
 
 // UpdateNodeDetail
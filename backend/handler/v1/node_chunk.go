@@ -0,0 +1,139 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/chaitin/panda-wiki/consts"
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/handler"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/middleware"
+	"github.com/chaitin/panda-wiki/usecase"
+)
+
+type NodeChunkHandler struct {
+	*handler.BaseHandler
+	logger  *log.Logger
+	auth    middleware.AuthMiddleware
+	usecase *usecase.NodeChunkUsecase
+}
+
+func NewNodeChunkHandler(e *echo.Echo, baseHandler *handler.BaseHandler, logger *log.Logger, auth middleware.AuthMiddleware,
+	usecase *usecase.NodeChunkUsecase) *NodeChunkHandler {
+	h := &NodeChunkHandler{
+		BaseHandler: baseHandler,
+		logger:      logger.WithModule("handler.v1.node_chunk"),
+		auth:        auth,
+		usecase:     usecase,
+	}
+
+	group := e.Group("/api/v1/node/chunk", h.auth.Authorize, h.auth.ValidateKBUserPerm(consts.UserKBPermissionDocManage))
+	group.GET("/list", h.GetNodeChunkList)
+	group.PUT("", h.UpdateNodeChunk)
+	group.POST("", h.AddNodeChunk)
+	group.DELETE("/edit", h.DeleteNodeChunkEdit)
+
+	return h
+}
+
+// GetNodeChunkList
+//
+//	@Summary		GetNodeChunkList
+//	@Description	List the rag chunks of the latest indexed release of a node
+//	@Tags			node_chunk
+//	@Accept			json
+//	@Produce		json
+//	@Param			req	query		domain.NodeChunkListReq								true	"NodeChunkListReq"
+//	@Success		200	{object}	domain.PWResponse{data=domain.NodeChunkListResp}	"node chunk list"
+//	@Router			/api/v1/node/chunk/list [get]
+func (h *NodeChunkHandler) GetNodeChunkList(c echo.Context) error {
+	var req domain.NodeChunkListReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	resp, err := h.usecase.GetList(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to get node chunk list", err)
+	}
+	return h.NewResponseWithData(c, resp)
+}
+
+// UpdateNodeChunk
+//
+//	@Summary		UpdateNodeChunk
+//	@Description	Edit or disable a chunk, the edit survives re-publishing unless the section changed
+//	@Tags			node_chunk
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		domain.UpdateNodeChunkReq	true	"UpdateNodeChunkReq"
+//	@Success		200		{object}	domain.Response
+//	@Router			/api/v1/node/chunk [put]
+func (h *NodeChunkHandler) UpdateNodeChunk(c echo.Context) error {
+	var req domain.UpdateNodeChunkReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	if err := h.usecase.Update(c.Request().Context(), &req); err != nil {
+		return h.NewResponseWithError(c, "failed to update node chunk", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
+
+// AddNodeChunk
+//
+//	@Summary		AddNodeChunk
+//	@Description	Add a manual chunk such as synonyms or Q&A hints to a node
+//	@Tags			node_chunk
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		domain.AddNodeChunkReq							true	"AddNodeChunkReq"
+//	@Success		200		{object}	domain.PWResponse{data=domain.NodeChunkEdit}	"node chunk edit"
+//	@Router			/api/v1/node/chunk [post]
+func (h *NodeChunkHandler) AddNodeChunk(c echo.Context) error {
+	var req domain.AddNodeChunkReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	edit, err := h.usecase.Add(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to add node chunk", err)
+	}
+	return h.NewResponseWithData(c, edit)
+}
+
+// DeleteNodeChunkEdit
+//
+//	@Summary		DeleteNodeChunkEdit
+//	@Description	Drop a chunk edit, manual chunks are removed and edited chunks are restored on the next publish
+//	@Tags			node_chunk
+//	@Accept			json
+//	@Produce		json
+//	@Param			req	query		domain.DeleteNodeChunkEditReq	true	"DeleteNodeChunkEditReq"
+//	@Success		200	{object}	domain.Response
+//	@Router			/api/v1/node/chunk/edit [delete]
+func (h *NodeChunkHandler) DeleteNodeChunkEdit(c echo.Context) error {
+	var req domain.DeleteNodeChunkEditReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	if err := h.usecase.DeleteEdit(c.Request().Context(), req.KBID, req.ID); err != nil {
+		return h.NewResponseWithError(c, "failed to delete node chunk edit", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
@@ -0,0 +1,137 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/chaitin/panda-wiki/consts"
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/handler"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/middleware"
+	"github.com/chaitin/panda-wiki/pkg/docimport"
+	"github.com/chaitin/panda-wiki/usecase"
+)
+
+type NodeImportHandler struct {
+	*handler.BaseHandler
+	logger  *log.Logger
+	auth    middleware.AuthMiddleware
+	usecase *usecase.NodeImportUsecase
+}
+
+func NewNodeImportHandler(e *echo.Echo, baseHandler *handler.BaseHandler, logger *log.Logger, auth middleware.AuthMiddleware,
+	usecase *usecase.NodeImportUsecase) *NodeImportHandler {
+	h := &NodeImportHandler{
+		BaseHandler: baseHandler,
+		logger:      logger.WithModule("handler.v1.node_import"),
+		auth:        auth,
+		usecase:     usecase,
+	}
+
+	group := e.Group("/api/v1/node/import", h.auth.Authorize, h.auth.ValidateKBUserPerm(consts.UserKBPermissionDocManage))
+	group.POST("", h.ImportNodes)
+	group.GET("/list", h.GetNodeImportJobList)
+	group.GET("/detail", h.GetNodeImportJobDetail)
+
+	return h
+}
+
+// ImportNodes
+//
+//	@Summary		ImportNodes
+//	@Description	Import an uploaded file into nodes in the background, the folder tree is rebuilt and links between the imported documents point to the new nodes
+//	@Tags			node
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			body	body		domain.NodeImportReq							true	"NodeImportReq"
+//	@Success		200		{object}	domain.PWResponse{data=domain.NodeImportJob}	"node import job"
+//	@Failure		413		{object}	domain.PWResponse								"the file is too large"
+//	@Router			/api/v1/node/import [post]
+func (h *NodeImportHandler) ImportNodes(c echo.Context) error {
+	ctx := c.Request().Context()
+	authInfo := domain.GetAuthInfoFromCtx(ctx)
+	if authInfo == nil {
+		return h.NewResponseWithError(c, "authInfo not found in context", nil)
+	}
+
+	var req domain.NodeImportReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "request body is invalid", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "validate request body failed", err)
+	}
+	req.MaxNode = domain.GetBaseEditionLimitation(ctx).MaxNode
+
+	job, err := h.usecase.Create(ctx, &req, authInfo.UserId)
+	if err != nil {
+		if errors.Is(err, docimport.ErrLimitExceeded) {
+			return c.JSON(http.StatusRequestEntityTooLarge, domain.PWResponse{
+				Success: false,
+				Message: err.Error(),
+			})
+		}
+		return h.NewResponseWithError(c, "import nodes failed", err)
+	}
+	return h.NewResponseWithData(c, job)
+}
+
+type NodeImportJobList = domain.PaginatedResult[[]*domain.NodeImportJob]
+
+// GetNodeImportJobList
+//
+//	@Summary		GetNodeImportJobList
+//	@Description	List the file imports of the kb with their progress, without the result of each document
+//	@Tags			node
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			req	query		domain.NodeImportJobListReq					true	"NodeImportJobListReq"
+//	@Success		200	{object}	domain.PWResponse{data=NodeImportJobList}	"node import job list"
+//	@Router			/api/v1/node/import/list [get]
+func (h *NodeImportHandler) GetNodeImportJobList(c echo.Context) error {
+	var req domain.NodeImportJobListReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	jobs, err := h.usecase.GetList(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to get node import job list", err)
+	}
+	return h.NewResponseWithData(c, jobs)
+}
+
+// GetNodeImportJobDetail
+//
+//	@Summary		GetNodeImportJobDetail
+//	@Description	Get a file import with the result of each document once it is completed
+//	@Tags			node
+//	@Accept			json
+//	@Produce		json
+//	@Security		bearerAuth
+//	@Param			req	query		domain.NodeImportJobDetailReq					true	"NodeImportJobDetailReq"
+//	@Success		200	{object}	domain.PWResponse{data=domain.NodeImportJob}	"node import job"
+//	@Router			/api/v1/node/import/detail [get]
+func (h *NodeImportHandler) GetNodeImportJobDetail(c echo.Context) error {
+	var req domain.NodeImportJobDetailReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	job, err := h.usecase.GetDetail(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to get node import job detail", err)
+	}
+	return h.NewResponseWithData(c, job)
+}
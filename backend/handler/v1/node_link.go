@@ -0,0 +1,114 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/chaitin/panda-wiki/consts"
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/handler"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/middleware"
+	"github.com/chaitin/panda-wiki/usecase"
+)
+
+type NodeLinkHandler struct {
+	*handler.BaseHandler
+	logger  *log.Logger
+	auth    middleware.AuthMiddleware
+	usecase *usecase.NodeLinkUsecase
+}
+
+func NewNodeLinkHandler(e *echo.Echo, baseHandler *handler.BaseHandler, logger *log.Logger, auth middleware.AuthMiddleware,
+	usecase *usecase.NodeLinkUsecase) *NodeLinkHandler {
+	h := &NodeLinkHandler{
+		BaseHandler: baseHandler,
+		logger:      logger.WithModule("handler.v1.node_link"),
+		auth:        auth,
+		usecase:     usecase,
+	}
+
+	group := e.Group("/api/v1/node/links", h.auth.Authorize, h.auth.ValidateKBUserPerm(consts.UserKBPermissionDocManage))
+	group.GET("", h.GetNodeLinks)
+	group.GET("/graph", h.GetNodeLinkGraph)
+	group.POST("/delete_check", h.CheckNodeDelete)
+
+	return h
+}
+
+// GetNodeLinks
+//
+//	@Summary		GetNodeLinks
+//	@Description	Get the documents linking to a document and the links of the document, broken links included
+//	@Tags			node_link
+//	@Accept			json
+//	@Produce		json
+//	@Param			req	query		domain.NodeLinksReq								true	"NodeLinksReq"
+//	@Success		200	{object}	domain.PWResponse{data=domain.NodeLinksResp}	"node links"
+//	@Router			/api/v1/node/links [get]
+func (h *NodeLinkHandler) GetNodeLinks(c echo.Context) error {
+	var req domain.NodeLinksReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	links, err := h.usecase.GetLinks(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to get node links", err)
+	}
+	return h.NewResponseWithData(c, links)
+}
+
+// GetNodeLinkGraph
+//
+//	@Summary		GetNodeLinkGraph
+//	@Description	Get the documents of the kb with the links between them
+//	@Tags			node_link
+//	@Accept			json
+//	@Produce		json
+//	@Param			req	query		domain.NodeLinkGraphReq							true	"NodeLinkGraphReq"
+//	@Success		200	{object}	domain.PWResponse{data=domain.NodeLinkGraph}	"node link graph"
+//	@Router			/api/v1/node/links/graph [get]
+func (h *NodeLinkHandler) GetNodeLinkGraph(c echo.Context) error {
+	var req domain.NodeLinkGraphReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	graph, err := h.usecase.GetGraph(c.Request().Context(), req.KBID)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to get node link graph", err)
+	}
+	return h.NewResponseWithData(c, graph)
+}
+
+// CheckNodeDelete
+//
+//	@Summary		CheckNodeDelete
+//	@Description	List the documents whose links would break by deleting the nodes and their children
+//	@Tags			node_link
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		domain.NodeDeleteCheckReq							true	"NodeDeleteCheckReq"
+//	@Success		200		{object}	domain.PWResponse{data=domain.NodeDeleteCheckResp}	"documents linking to the nodes"
+//	@Router			/api/v1/node/links/delete_check [post]
+func (h *NodeLinkHandler) CheckNodeDelete(c echo.Context) error {
+	var req domain.NodeDeleteCheckReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	backlinks, err := h.usecase.CheckDelete(c.Request().Context(), req.KBID, req.IDs)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to check node delete", err)
+	}
+	return h.NewResponseWithData(c, &domain.NodeDeleteCheckResp{Backlinks: backlinks})
+}
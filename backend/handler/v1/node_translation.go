@@ -0,0 +1,93 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/chaitin/panda-wiki/consts"
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/handler"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/middleware"
+	"github.com/chaitin/panda-wiki/usecase"
+)
+
+type NodeTranslationHandler struct {
+	*handler.BaseHandler
+	logger  *log.Logger
+	auth    middleware.AuthMiddleware
+	usecase *usecase.NodeTranslationUsecase
+}
+
+func NewNodeTranslationHandler(e *echo.Echo, baseHandler *handler.BaseHandler, logger *log.Logger, auth middleware.AuthMiddleware,
+	usecase *usecase.NodeTranslationUsecase) *NodeTranslationHandler {
+	h := &NodeTranslationHandler{
+		BaseHandler: baseHandler,
+		logger:      logger.WithModule("handler.v1.node_translation"),
+		auth:        auth,
+		usecase:     usecase,
+	}
+
+	group := e.Group("/api/v1/node/translation", h.auth.Authorize, h.auth.ValidateKBUserPerm(consts.UserKBPermissionDocManage))
+	group.POST("", h.TranslateNode)
+	group.GET("/list", h.GetNodeTranslationList)
+
+	return h
+}
+
+// TranslateNode
+//
+//	@Summary		TranslateNode
+//	@Description	Translate a node, or the subtree under it, into a language in the background
+//	@Tags			node_translation
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		domain.TranslateNodeReq								true	"TranslateNodeReq"
+//	@Success		200		{object}	domain.PWResponse{data=[]domain.NodeTranslation}	"queued translations"
+//	@Router			/api/v1/node/translation [post]
+func (h *NodeTranslationHandler) TranslateNode(c echo.Context) error {
+	ctx := c.Request().Context()
+	authInfo := domain.GetAuthInfoFromCtx(ctx)
+	if authInfo == nil {
+		return h.NewResponseWithError(c, "authInfo not found in context", nil)
+	}
+
+	var req domain.TranslateNodeReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	translations, err := h.usecase.Translate(ctx, &req, authInfo.UserId, domain.GetBaseEditionLimitation(ctx).MaxNode)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to translate node", err)
+	}
+	return h.NewResponseWithData(c, translations)
+}
+
+// GetNodeTranslationList
+//
+//	@Summary		GetNodeTranslationList
+//	@Description	List the translations of a node, stale translations were made from an older version of the source
+//	@Tags			node_translation
+//	@Accept			json
+//	@Produce		json
+//	@Param			req	query		domain.NodeTranslationListReq							true	"NodeTranslationListReq"
+//	@Success		200	{object}	domain.PWResponse{data=domain.NodeTranslationListResp}	"node translation list"
+//	@Router			/api/v1/node/translation/list [get]
+func (h *NodeTranslationHandler) GetNodeTranslationList(c echo.Context) error {
+	var req domain.NodeTranslationListReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	resp, err := h.usecase.GetList(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to get node translation list", err)
+	}
+	return h.NewResponseWithData(c, resp)
+}
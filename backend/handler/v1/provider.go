@@ -9,20 +9,31 @@ import (
 )
 
 type APIHandlers struct {
-	UserHandler          *UserHandler
-	KnowledgeBaseHandler *KnowledgeBaseHandler
-	NodeHandler          *NodeHandler
-	AppHandler           *AppHandler
-	FileHandler          *FileHandler
-	ModelHandler         *ModelHandler
-	ConversationHandler  *ConversationHandler
-	CrawlerHandler       *CrawlerHandler
-	CreationHandler      *CreationHandler
-	StatHandler          *StatHandler
-	SystemHandler        *SystemHandler
-	CommentHandler       *CommentHandler
-	AuthV1Handler        *AuthV1Handler
-	LicenseHandler       *LicenseHandler
+	UserHandler                *UserHandler
+	KnowledgeBaseHandler       *KnowledgeBaseHandler
+	NodeHandler                *NodeHandler
+	AppHandler                 *AppHandler
+	FileHandler                *FileHandler
+	ModelHandler               *ModelHandler
+	ConversationHandler        *ConversationHandler
+	CrawlerHandler             *CrawlerHandler
+	CreationHandler            *CreationHandler
+	StatHandler                *StatHandler
+	SystemHandler              *SystemHandler
+	CommentHandler             *CommentHandler
+	AuthV1Handler              *AuthV1Handler
+	LicenseHandler             *LicenseHandler
+	CuratedAnswerHandler       *CuratedAnswerHandler
+	EvalHandler                *EvalHandler
+	NodeChunkHandler           *NodeChunkHandler
+	NodeTranslationHandler     *NodeTranslationHandler
+	SummaryJobHandler          *SummaryJobHandler
+	GitSourceHandler           *GitSourceHandler
+	NodeImportHandler          *NodeImportHandler
+	CrawlerSubscriptionHandler *CrawlerSubscriptionHandler
+	ImportJobHandler           *ImportJobHandler
+	LinkCheckHandler           *LinkCheckHandler
+	NodeLinkHandler            *NodeLinkHandler
 	// Pro handlers 已迁移到 handler/pro 包
 	// PromptHandler, BlockWordHandler, APITokenHandler, ContributeHandler 等
 	// 现在在 handler/pro 中注册和管理
@@ -47,6 +58,17 @@ var ProviderSet = wire.NewSet(
 	NewCommentHandler,
 	NewAuthV1Handler,
 	NewLicenseHandler,
+	NewCuratedAnswerHandler,
+	NewEvalHandler,
+	NewNodeChunkHandler,
+	NewNodeTranslationHandler,
+	NewSummaryJobHandler,
+	NewGitSourceHandler,
+	NewNodeImportHandler,
+	NewCrawlerSubscriptionHandler,
+	NewImportJobHandler,
+	NewLinkCheckHandler,
+	NewNodeLinkHandler,
 
 	wire.Struct(new(APIHandlers), "*"),
 )
@@ -0,0 +1,168 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/chaitin/panda-wiki/consts"
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/handler"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/middleware"
+	"github.com/chaitin/panda-wiki/usecase"
+)
+
+type SummaryJobHandler struct {
+	*handler.BaseHandler
+	logger  *log.Logger
+	auth    middleware.AuthMiddleware
+	usecase *usecase.SummaryJobUsecase
+}
+
+func NewSummaryJobHandler(e *echo.Echo, baseHandler *handler.BaseHandler, logger *log.Logger, auth middleware.AuthMiddleware,
+	usecase *usecase.SummaryJobUsecase) *SummaryJobHandler {
+	h := &SummaryJobHandler{
+		BaseHandler: baseHandler,
+		logger:      logger.WithModule("handler.v1.summary_job"),
+		auth:        auth,
+		usecase:     usecase,
+	}
+
+	group := e.Group("/api/v1/node/summary/job", h.auth.Authorize, h.auth.ValidateKBUserPerm(consts.UserKBPermissionDocManage))
+	group.POST("", h.CreateSummaryJob)
+	group.GET("/list", h.GetSummaryJobList)
+	group.GET("/detail", h.GetSummaryJobDetail)
+	group.POST("/retry", h.RetrySummaryJob)
+	group.POST("/cancel", h.CancelSummaryJob)
+
+	return h
+}
+
+// CreateSummaryJob
+//
+//	@Summary		CreateSummaryJob
+//	@Description	Summarize all documents of the kb without summary in the background
+//	@Tags			summary_job
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		domain.CreateSummaryJobReq					true	"CreateSummaryJobReq"
+//	@Success		200		{object}	domain.PWResponse{data=domain.SummaryJob}	"summary job"
+//	@Router			/api/v1/node/summary/job [post]
+func (h *SummaryJobHandler) CreateSummaryJob(c echo.Context) error {
+	var req domain.CreateSummaryJobReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	job, err := h.usecase.Create(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to create summary job", err)
+	}
+	return h.NewResponseWithData(c, job)
+}
+
+type SummaryJobList = domain.PaginatedResult[[]*domain.SummaryJob]
+
+// GetSummaryJobList
+//
+//	@Summary		GetSummaryJobList
+//	@Description	List the summary jobs of the kb with their progress
+//	@Tags			summary_job
+//	@Accept			json
+//	@Produce		json
+//	@Param			req	query		domain.SummaryJobListReq				true	"SummaryJobListReq"
+//	@Success		200	{object}	domain.PWResponse{data=SummaryJobList}	"summary job list"
+//	@Router			/api/v1/node/summary/job/list [get]
+func (h *SummaryJobHandler) GetSummaryJobList(c echo.Context) error {
+	var req domain.SummaryJobListReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	jobs, err := h.usecase.GetList(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to get summary job list", err)
+	}
+	return h.NewResponseWithData(c, jobs)
+}
+
+// GetSummaryJobDetail
+//
+//	@Summary		GetSummaryJobDetail
+//	@Description	Get a summary job with the status of every node
+//	@Tags			summary_job
+//	@Accept			json
+//	@Produce		json
+//	@Param			req	query		domain.SummaryJobDetailReq							true	"SummaryJobDetailReq"
+//	@Success		200	{object}	domain.PWResponse{data=domain.SummaryJobDetailResp}	"summary job detail"
+//	@Router			/api/v1/node/summary/job/detail [get]
+func (h *SummaryJobHandler) GetSummaryJobDetail(c echo.Context) error {
+	var req domain.SummaryJobDetailReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	detail, err := h.usecase.GetDetail(c.Request().Context(), &req)
+	if err != nil {
+		return h.NewResponseWithError(c, "failed to get summary job detail", err)
+	}
+	return h.NewResponseWithData(c, detail)
+}
+
+// RetrySummaryJob
+//
+//	@Summary		RetrySummaryJob
+//	@Description	Run the failed nodes of a finished summary job again
+//	@Tags			summary_job
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		domain.RetrySummaryJobReq	true	"RetrySummaryJobReq"
+//	@Success		200		{object}	domain.Response
+//	@Router			/api/v1/node/summary/job/retry [post]
+func (h *SummaryJobHandler) RetrySummaryJob(c echo.Context) error {
+	var req domain.RetrySummaryJobReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	if err := h.usecase.Retry(c.Request().Context(), &req); err != nil {
+		return h.NewResponseWithError(c, "failed to retry summary job", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
+
+// CancelSummaryJob
+//
+//	@Summary		CancelSummaryJob
+//	@Description	Stop a pending or running summary job, the nodes not summarized yet are marked failed and can be retried
+//	@Tags			summary_job
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		domain.CancelSummaryJobReq	true	"CancelSummaryJobReq"
+//	@Success		200		{object}	domain.Response
+//	@Router			/api/v1/node/summary/job/cancel [post]
+func (h *SummaryJobHandler) CancelSummaryJob(c echo.Context) error {
+	var req domain.CancelSummaryJobReq
+	if err := c.Bind(&req); err != nil {
+		return h.NewResponseWithError(c, "bind request", err)
+	}
+	if err := c.Validate(&req); err != nil {
+		return h.NewResponseWithError(c, "invalid request", err)
+	}
+
+	if err := h.usecase.Cancel(c.Request().Context(), &req); err != nil {
+		return h.NewResponseWithError(c, "failed to cancel summary job", err)
+	}
+	return h.NewResponseWithData(c, nil)
+}
@@ -0,0 +1,50 @@
+package fns
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+)
+
+type MigrationBuildNodeLinks struct {
+	Name   string
+	logger *log.Logger
+}
+
+func NewMigrationBuildNodeLinks(logger *log.Logger) *MigrationBuildNodeLinks {
+	return &MigrationBuildNodeLinks{
+		Name:   "0003_build_node_links",
+		logger: logger,
+	}
+}
+
+// Execute indexes the links of the documents saved before the link index existed
+func (m *MigrationBuildNodeLinks) Execute(tx *gorm.DB) error {
+	var nodes []*domain.Node
+	count := 0
+	result := tx.Model(&domain.Node{}).
+		Select("id", "kb_id", "content").
+		Where("type = ?", domain.NodeTypeDocument).
+		FindInBatches(&nodes, 100, func(batch *gorm.DB, _ int) error {
+			links := make([]*domain.NodeLink, 0)
+			for _, node := range nodes {
+				links = append(links, domain.NewNodeLinks(node.KBID, node.ID, node.Content)...)
+			}
+			if len(links) == 0 {
+				return nil
+			}
+			count += len(links)
+			return tx.Session(&gorm.Session{NewDB: true}).
+				Clauses(clause.OnConflict{DoNothing: true}).
+				CreateInBatches(links, 100).Error
+		})
+	if result.Error != nil {
+		return fmt.Errorf("build node links failed: %w", result.Error)
+	}
+	m.logger.Info("build node links success", log.Int("links", count))
+	return nil
+}
@@ -34,6 +34,10 @@ func (p *MQProducer) EnsureStreams() error {
 			name:     "rag",
 			subjects: []string{"rag.doc.update"},
 		},
+		{
+			name:     "job",
+			subjects: []string{"apps.panda-wiki.job.>"},
+		},
 	}
 
 	for _, stream := range streams {
@@ -6,4 +6,5 @@ import (
 	"github.com/chaitin/panda-wiki/domain"
 )
 
-type GetQAFun func(ctx context.Context, msg string, info domain.ConversationInfo, ConversationID string) (chan string, error)
+// GetQAFun answers the question, images are image urls or data urls attached to it
+type GetQAFun func(ctx context.Context, msg string, images []string, info domain.ConversationInfo, ConversationID string) (chan string, error)
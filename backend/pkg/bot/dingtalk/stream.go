@@ -194,6 +194,19 @@ func (c *DingTalkClient) CreateAndDeliverCard(ctx context.Context, trackID strin
 func (c *DingTalkClient) OnChatBotMessageReceived(ctx context.Context, data *chatbot.BotCallbackDataModel) ([]byte, error) {
 	question := data.Text.Content
 	question = strings.TrimSpace(question)
+	var images []string
+	if data.Msgtype == "picture" {
+		pictureURL, err := c.GetPictureURL(data)
+		if err != nil {
+			c.logger.Error("dingtalk client failed to get picture", log.Error(err))
+			return nil, nil
+		}
+		images = append(images, pictureURL)
+	}
+	title := question
+	if title == "" && len(images) > 0 {
+		title = "[图片]"
+	}
 	trackID := uuid.New().String()
 	// conversation_type == 1 表示机器人单聊，==2 表示群聊中@机器人
 	c.logger.Info("dingtalk client received message", log.String("question", question), log.String("track_id", trackID), log.String("conversation_type", data.ConversationType))
@@ -203,7 +216,7 @@ func (c *DingTalkClient) OnChatBotMessageReceived(ctx context.Context, data *cha
 		return nil, err
 	}
 
-	initialContent := fmt.Sprintf("**%s**\n\n%s", question, "稍等，让我想一想……")
+	initialContent := fmt.Sprintf("**%s**\n\n%s", title, "稍等，让我想一想……")
 
 	if err := c.UpdateAIStreamCard(trackID, initialContent, false); err != nil {
 		c.logger.Error("UpdateInteractiveCard", log.Error(err))
@@ -232,7 +245,7 @@ func (c *DingTalkClient) OnChatBotMessageReceived(ctx context.Context, data *cha
 		convInfo.UserInfo.From = domain.MessageFromPrivate
 	}
 
-	contentCh, err := c.getQA(ctx, question, *convInfo, "")
+	contentCh, err := c.getQA(ctx, question, images, *convInfo, "")
 	if err != nil {
 		c.logger.Error("dingtalk client failed to get answer", log.Error(err))
 		if err := c.UpdateAIStreamCard(trackID, "出错了，请稍后再试", true); err != nil {
@@ -244,8 +257,8 @@ func (c *DingTalkClient) OnChatBotMessageReceived(ctx context.Context, data *cha
 	updateTicker := time.NewTicker(1500 * time.Millisecond)
 	defer updateTicker.Stop()
 
-	ans := fmt.Sprintf("**%s**\n\n", question)
-	fullContent := fmt.Sprintf("**%s**\n\n", question)
+	ans := fmt.Sprintf("**%s**\n\n", title)
+	fullContent := fmt.Sprintf("**%s**\n\n", title)
 	for {
 		select {
 		case content, ok := <-contentCh:
@@ -361,3 +374,39 @@ func (c *DingTalkClient) GetUserInfo(userID string) (*UserDetailResponse, error)
 
 	return &result, nil
 }
+
+// GetPictureURL 获取图片消息的临时下载地址
+func (c *DingTalkClient) GetPictureURL(data *chatbot.BotCallbackDataModel) (string, error) {
+	content, ok := data.Content.(map[string]any)
+	if !ok {
+		return "", fmt.Errorf("invalid picture message content")
+	}
+	downloadCode, _ := content["downloadCode"].(string)
+	if downloadCode == "" {
+		return "", fmt.Errorf("picture download code is empty")
+	}
+	accessToken, err := c.GetAccessToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to get access token while downloading picture: %w", err)
+	}
+	payload, _ := json.Marshal(map[string]string{"downloadCode": downloadCode, "robotCode": c.clientID})
+	req, _ := http.NewRequest("POST", "https://api.dingtalk.com/v1.0/robot/messageFiles/download", bytes.NewBuffer(payload))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-acs-dingtalk-access-token", accessToken)
+
+	resp, err := (&http.Client{Timeout: 30 * time.Second}).Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	var result struct {
+		DownloadURL string `json:"downloadUrl"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", err
+	}
+	if result.DownloadURL == "" {
+		return "", fmt.Errorf("get picture download url failed: status %d", resp.StatusCode)
+	}
+	return result.DownloadURL, nil
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/chaitin/panda-wiki/domain"
 	"github.com/chaitin/panda-wiki/log"
 	"github.com/chaitin/panda-wiki/pkg/bot"
+	botutils "github.com/chaitin/panda-wiki/pkg/bot/utils"
 )
 
 type FeishuBotLogger struct {
@@ -86,9 +87,13 @@ func NewFeishuClient(ctx context.Context, cancel context.CancelFunc, clientID, c
 
 var cardDataTemplate = `{"schema":"2.0","header":{"title":{"content":"%s","tag":"plain_text"}},"config":{"streaming_mode":true,"summary":{"content":""}},"body":{"elements":[{"tag":"markdown","content":"%s","element_id":"markdown_1"}]}}`
 
-func (c *FeishuClient) sendQACard(ctx context.Context, receiveIdType string, receiveId string, question string, additionalInfo string) {
+func (c *FeishuClient) sendQACard(ctx context.Context, receiveIdType string, receiveId string, question string, images []string, additionalInfo string) {
+	title := question
+	if title == "" && len(images) > 0 {
+		title = "[图片]"
+	}
 	// create card
-	cardData := fmt.Sprintf(cardDataTemplate, question, "稍等，让我想一想...")
+	cardData := fmt.Sprintf(cardDataTemplate, title, "稍等，让我想一想...")
 	req := larkcardkit.NewCreateCardReqBuilder().
 		Body(larkcardkit.NewCreateCardReqBodyBuilder().
 			Type(`card_json`).
@@ -178,7 +183,7 @@ func (c *FeishuClient) sendQACard(ctx context.Context, receiveIdType string, rec
 		convInfo.UserInfo.From = domain.MessageFromGroup // 群聊
 	}
 
-	answerCh, err := c.getQA(ctx, question, convInfo, "")
+	answerCh, err := c.getQA(ctx, question, images, convInfo, "")
 	if err != nil {
 		c.logger.Error("get QA failed", log.Error(err))
 		return
@@ -213,7 +218,24 @@ func (c *FeishuClient) sendQACard(ctx context.Context, receiveIdType string, rec
 }
 
 type Message struct {
-	Text string `json:"text"`
+	Text     string `json:"text"`
+	ImageKey string `json:"image_key"`
+}
+
+// downloadImage returns the image of the message as a data url
+func (c *FeishuClient) downloadImage(ctx context.Context, messageID, imageKey string) (string, error) {
+	resp, err := c.client.Im.MessageResource.Get(ctx, larkim.NewGetMessageResourceReqBuilder().
+		MessageId(messageID).
+		FileKey(imageKey).
+		Type("image").
+		Build())
+	if err != nil {
+		return "", err
+	}
+	if !resp.Success() {
+		return "", fmt.Errorf("get message resource failed: %d %s", resp.Code, resp.Msg)
+	}
+	return botutils.ImageDataURL(resp.File)
 }
 
 func (c *FeishuClient) Start() error {
@@ -229,25 +251,30 @@ func (c *FeishuClient) Start() error {
 			}
 			c.msgMap.Store(messageId, time.Now().Unix())
 			c.logger.Info("received message from feishu bot", log.String("message_id", messageId))
-			// only handle text type
-			if *event.Event.Message.MessageType != "text" {
+			// only handle text and image type
+			messageType := *event.Event.Message.MessageType
+			if messageType != "text" && messageType != "image" {
 				return nil
 			}
-			switch *event.Event.Message.ChatType {
-			case "group":
-				var message Message
-				if err := json.Unmarshal([]byte(*event.Event.Message.Content), &message); err != nil {
-					c.logger.Error("failed to unmarshal message", log.Error(err))
+			var message Message
+			if err := json.Unmarshal([]byte(*event.Event.Message.Content), &message); err != nil {
+				c.logger.Error("failed to unmarshal message", log.Error(err))
+				return nil
+			}
+			var images []string
+			if messageType == "image" {
+				image, err := c.downloadImage(ctx, messageId, message.ImageKey)
+				if err != nil {
+					c.logger.Error("failed to download image", log.Error(err))
 					return nil
 				}
-				c.sendQACard(ctx, "chat_id", *event.Event.Message.ChatId, message.Text, *event.Event.Sender.SenderId.OpenId)
+				images = append(images, image)
+			}
+			switch *event.Event.Message.ChatType {
+			case "group":
+				c.sendQACard(ctx, "chat_id", *event.Event.Message.ChatId, message.Text, images, *event.Event.Sender.SenderId.OpenId)
 			case "p2p":
-				var message Message
-				if err := json.Unmarshal([]byte(*event.Event.Message.Content), &message); err != nil {
-					c.logger.Error("failed to unmarshal message", log.Error(err))
-					return nil
-				}
-				c.sendQACard(ctx, "open_id", *event.Event.Sender.SenderId.OpenId, message.Text, *event.Event.Message.ChatId)
+				c.sendQACard(ctx, "open_id", *event.Event.Sender.SenderId.OpenId, message.Text, images, *event.Event.Message.ChatId)
 			default:
 				c.logger.Warn("unsupported chat type", log.String("chat_type", *event.Event.Message.ChatType))
 			}
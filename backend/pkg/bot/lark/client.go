@@ -240,7 +240,7 @@ func (c *LarkClient) sendQACard(ctx context.Context, receiveIdType string, recei
 		convInfo.UserInfo.From = domain.MessageFromGroup
 	}
 
-	answerCh, err := c.getQA(ctx, question, convInfo, "")
+	answerCh, err := c.getQA(ctx, question, nil, convInfo, "")
 	if err != nil {
 		c.logger.Error("lark client failed to get answer", log.Error(err))
 		return
@@ -0,0 +1,27 @@
+package utils
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/chaitin/panda-wiki/domain"
+)
+
+// ImageDataURL reads an image downloaded from a bot platform into a data url
+func ImageDataURL(r io.Reader) (string, error) {
+	data, err := io.ReadAll(io.LimitReader(r, domain.ChatImageMaxBytes+1))
+	if err != nil {
+		return "", fmt.Errorf("read image failed: %w", err)
+	}
+	if len(data) > domain.ChatImageMaxBytes {
+		return "", fmt.Errorf("image is larger than %d bytes", domain.ChatImageMaxBytes)
+	}
+	mimeType := http.DetectContentType(data)
+	if !strings.HasPrefix(mimeType, "image/") {
+		return "", fmt.Errorf("unsupported image type %s", mimeType)
+	}
+	return fmt.Sprintf("data:%s;base64,%s", mimeType, base64.StdEncoding.EncodeToString(data)), nil
+}
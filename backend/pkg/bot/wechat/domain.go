@@ -31,9 +31,26 @@ type ReceivedMessage struct {
 	CreateTime   int64  `xml:"CreateTime"`
 	MsgType      string `xml:"MsgType"`
 	Content      string `xml:"Content"`
+	PicURL       string `xml:"PicUrl"`
 	MsgID        string `xml:"MsgId"`
 }
 
+// Images returns the picture of an image message
+func (m ReceivedMessage) Images() []string {
+	if m.PicURL == "" {
+		return nil
+	}
+	return []string{m.PicURL}
+}
+
+// Title returns the question shown to the user
+func (m ReceivedMessage) Title() string {
+	if m.Content == "" && m.PicURL != "" {
+		return "[图片]"
+	}
+	return m.Content
+}
+
 type ResponseMessage struct {
 	XMLName      xml.Name `xml:"xml"`
 	ToUserName   CDATA    `xml:"ToUserName"`
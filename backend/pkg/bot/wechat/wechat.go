@@ -84,7 +84,7 @@ func (cfg *WechatConfig) ProcessUrlMessage(msg ReceivedMessage, GetQA bot.GetQAF
 	}
 	conversationID := id.String()
 
-	contentChan, err := GetQA(cfg.Ctx, msg.Content, domain.ConversationInfo{
+	contentChan, err := GetQA(cfg.Ctx, msg.Content, msg.Images(), domain.ConversationInfo{
 		UserInfo: domain.UserInfo{
 			UserID:   userinfo.UserID,
 			NickName: userinfo.Name,
@@ -98,7 +98,7 @@ func (cfg *WechatConfig) ProcessUrlMessage(msg ReceivedMessage, GetQA bot.GetQAF
 	//2. go send to ai and store in map--> get conversation-id
 	if _, ok := domain.ConversationManager.Load(conversationID); !ok {
 		state := &domain.ConversationState{
-			Question:         msg.Content,
+			Question:         msg.Title(),
 			NotificationChan: make(chan string), // notification channel
 			IsVisited:        false,
 		}
@@ -113,7 +113,7 @@ func (cfg *WechatConfig) ProcessUrlMessage(msg ReceivedMessage, GetQA bot.GetQAF
 	}
 
 	//3.send url to user
-	Errcode, Errmsg, err := cfg.SendURLToUser(msg.FromUserName, msg.Content, token, conversationID, baseUrl)
+	Errcode, Errmsg, err := cfg.SendURLToUser(msg.FromUserName, msg.Title(), token, conversationID, baseUrl)
 	if err != nil {
 		return err
 	}
@@ -132,7 +132,7 @@ func (cfg *WechatConfig) ProcessTextMessage(msg ReceivedMessage, GetQA bot.GetQA
 	}
 	conversationID := id.String()
 
-	contentChan, err := GetQA(cfg.Ctx, msg.Content, domain.ConversationInfo{
+	contentChan, err := GetQA(cfg.Ctx, msg.Content, msg.Images(), domain.ConversationInfo{
 		UserInfo: domain.UserInfo{
 			UserID:   userinfo.UserID,
 			NickName: userinfo.Name,
@@ -12,7 +12,7 @@ import (
 
 func Wechat(ctx context.Context, GetQA bot.GetQAFun, userinfo *user.Info, content string) (string, error) {
 
-	wccontent, err := GetQA(ctx, content, domain.ConversationInfo{UserInfo: domain.UserInfo{
+	wccontent, err := GetQA(ctx, content, nil, domain.ConversationInfo{UserInfo: domain.UserInfo{
 		UserID:   userinfo.OpenID,     // 用户对话的id
 		NickName: userinfo.Nickname,   //用户微信的昵称
 		Avatar:   userinfo.Headimgurl, // 用户微信的头像
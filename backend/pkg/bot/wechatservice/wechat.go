@@ -154,7 +154,7 @@ func (cfg *WechatServiceConfig) Processmessage(msgRet *MsgRet, Kfmsg *WeixinUser
 		id = uuid.New()
 	}
 	conversationID := id.String()
-	wccontent, err := GetQA(cfg.Ctx, content, domain.ConversationInfo{UserInfo: domain.UserInfo{
+	wccontent, err := GetQA(cfg.Ctx, content, nil, domain.ConversationInfo{UserInfo: domain.UserInfo{
 		UserID:   customer.ExternalUserID, // 用户对话的id
 		NickName: customer.Nickname,       //用户微信的昵称
 		Avatar:   customer.Avatar,         // 用户微信的头像
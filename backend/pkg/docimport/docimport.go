@@ -0,0 +1,190 @@
+// Package docimport converts exported documents into markdown documents ready to be written as nodes.
+//
+// links between the documents of an import and references to their assets are written as
+// pwdoc:<key> and pwasset:<key> urls, they are replaced with node and file urls by ResolveRefs
+// once the nodes are created and the assets are uploaded
+package docimport
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	docRefScheme   = "pwdoc:"
+	assetRefScheme = "pwasset:"
+)
+
+// limits of a single import shared by every parser, so that a small upload can not expand into unbounded
+// memory or an unbounded number of nodes. an import exceeding one of them fails with ErrLimitExceeded
+const (
+	// MaxTotalSize bounds the bytes read from an archive or decoded from a dump
+	MaxTotalSize = 512 << 20
+	MaxDocs      = 5000
+	MaxAssets    = 5000
+)
+
+// ErrLimitExceeded is returned when an import is larger than its limits
+var ErrLimitExceeded = errors.New("import limit exceeded")
+
+// budget counts what a parser read and produced against the limits of an import
+type budget struct {
+	maxSize   int64
+	maxDocs   int
+	maxAssets int
+
+	size   int64
+	docs   int
+	assets int
+}
+
+func newBudget() *budget {
+	return &budget{maxSize: MaxTotalSize, maxDocs: MaxDocs, maxAssets: MaxAssets}
+}
+
+// read reads r, which must not be larger than limit, and counts the bytes against the total size
+func (b *budget) read(r io.Reader, limit int64) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, min(limit, b.maxSize-b.size)+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("%w: file is larger than %d bytes", ErrLimitExceeded, limit)
+	}
+	return data, b.use(int64(len(data)))
+}
+
+// use counts n bytes read or decoded against the total size
+func (b *budget) use(n int64) error {
+	b.size += n
+	if b.size > b.maxSize {
+		return fmt.Errorf("%w: more than %d bytes", ErrLimitExceeded, b.maxSize)
+	}
+	return nil
+}
+
+// addDocs counts n documents of the result
+func (b *budget) addDocs(n int) error {
+	b.docs += n
+	if b.docs > b.maxDocs {
+		return fmt.Errorf("%w: more than %d documents", ErrLimitExceeded, b.maxDocs)
+	}
+	return nil
+}
+
+// addAssets counts n assets of the result
+func (b *budget) addAssets(n int) error {
+	b.assets += n
+	if b.assets > b.maxAssets {
+		return fmt.Errorf("%w: more than %d assets", ErrLimitExceeded, b.maxAssets)
+	}
+	return nil
+}
+
+// Doc is a document of an import
+type Doc struct {
+	// unique key of the document within the import, the path of the source file when there is one
+	Key string
+	// folders the document is placed in, outermost first
+	Folders []string
+	Name    string
+	// markdown content, see DocRef and AssetRef
+	Content string
+	// optional key of the document in the source, re-importing updates the nodes with the same external id
+	ExternalID string
+}
+
+// Result is the output of a converter
+type Result struct {
+	Docs []*Doc
+	// content of the assets referenced by the documents, keyed by asset key
+	Assets map[string][]byte
+}
+
+func NewResult() *Result {
+	return &Result{Docs: make([]*Doc, 0), Assets: make(map[string][]byte)}
+}
+
+// SortDocs orders the documents by folder and name so that the created tree follows the source
+func (r *Result) SortDocs() {
+	sort.SliceStable(r.Docs, func(i, j int) bool {
+		return r.Docs[i].Key < r.Docs[j].Key
+	})
+}
+
+// DocRef is the url of a link to another document of the import, anchor may be empty
+func DocRef(key, anchor string) string {
+	ref := docRefScheme + url.PathEscape(key)
+	if anchor != "" {
+		ref += "#" + url.PathEscape(anchor)
+	}
+	return ref
+}
+
+// AssetRef is the url of an asset of the import
+func AssetRef(key string) string {
+	return assetRefScheme + url.PathEscape(key)
+}
+
+var refRegex = regexp.MustCompile(`(pwdoc|pwasset):([^\s()<>"'#]+)`)
+
+// ResolveRefs replaces the document and asset urls of content, references that can not be resolved point to "#"
+func ResolveRefs(content string, docURL, assetURL func(key string) (string, bool)) string {
+	return refRegex.ReplaceAllStringFunc(content, func(match string) string {
+		parts := refRegex.FindStringSubmatch(match)
+		key, err := url.PathUnescape(parts[2])
+		if err != nil {
+			return "#"
+		}
+		resolve := docURL
+		if parts[1]+":" == assetRefScheme {
+			resolve = assetURL
+		}
+		if resolved, ok := resolve(key); ok {
+			return resolved
+		}
+		return "#"
+	})
+}
+
+// RefKeys returns the keys of the documents and assets referenced by content
+func RefKeys(content string) (docs, assets []string) {
+	for _, parts := range refRegex.FindAllStringSubmatch(content, -1) {
+		key, err := url.PathUnescape(parts[2])
+		if err != nil {
+			continue
+		}
+		if parts[1]+":" == assetRefScheme {
+			assets = append(assets, key)
+		} else {
+			docs = append(docs, key)
+		}
+	}
+	return docs, assets
+}
+
+// CleanPath normalizes a path of an archive against its root, false for the root itself.
+// paths are only used as keys and never touch the file system
+func CleanPath(p string) (string, bool) {
+	cleaned := strings.TrimPrefix(path.Clean("/"+strings.ReplaceAll(p, "\\", "/")), "/")
+	return cleaned, cleaned != ""
+}
+
+// SplitFolders returns the folders and the file name of a path
+func SplitFolders(p string) ([]string, string) {
+	dir, file := path.Split(p)
+	dir = strings.Trim(dir, "/")
+	if dir == "" {
+		return nil, file
+	}
+	return strings.Split(dir, "/"), file
+}
+
+// ErrEmpty is returned when an archive contains nothing to import
+var ErrEmpty = errors.New("no document found")
@@ -0,0 +1,693 @@
+package docimport
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// xmlNode is a generic element of an office document
+type xmlNode struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr `xml:",any,attr"`
+	Content  string     `xml:",chardata"`
+	Children []xmlNode  `xml:",any"`
+}
+
+func (n *xmlNode) attr(local string) string {
+	for _, a := range n.Attrs {
+		if a.Name.Local == local {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+func (n *xmlNode) child(local string) *xmlNode {
+	for i := range n.Children {
+		if n.Children[i].XMLName.Local == local {
+			return &n.Children[i]
+		}
+	}
+	return nil
+}
+
+// find returns the first descendant with the local name
+func (n *xmlNode) find(local string) *xmlNode {
+	for i := range n.Children {
+		if n.Children[i].XMLName.Local == local {
+			return &n.Children[i]
+		}
+		if found := n.Children[i].find(local); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// on reports whether a toggle property such as <w:b/> is set, <w:b w:val="0"/> turns it off
+func (n *xmlNode) on(local string) bool {
+	prop := n.child(local)
+	if prop == nil {
+		return false
+	}
+	switch prop.attr("val") {
+	case "0", "false", "none":
+		return false
+	}
+	return true
+}
+
+func readXML(b *budget, files map[string]*zip.File, name string) (*xmlNode, error) {
+	f, ok := files[name]
+	if !ok {
+		return nil, nil
+	}
+	data, err := b.readZipFile(f, maxDocSize)
+	if err != nil {
+		return nil, err
+	}
+	var node xmlNode
+	if err := xml.Unmarshal(data, &node); err != nil {
+		return nil, fmt.Errorf("parse %s failed: %w", name, err)
+	}
+	return &node, nil
+}
+
+// ParseDocx converts a word document into markdown. headings, lists, tables, links and images are kept.
+// with splitLevel 1 the document is split into a folder named name with a document per Heading 1,
+// with splitLevel 2 every Heading 1 containing Heading 2 becomes a folder with a document per Heading 2
+func ParseDocx(r *zip.Reader, name string, splitLevel int) (*Result, error) {
+	files := make(map[string]*zip.File, len(r.File))
+	for _, f := range r.File {
+		if p, ok := CleanPath(f.Name); ok {
+			files[p] = f
+		}
+	}
+	b := newBudget()
+	document, err := readXML(b, files, "word/document.xml")
+	if err != nil {
+		return nil, err
+	}
+	if document == nil {
+		return nil, fmt.Errorf("word/document.xml not found, not a docx file")
+	}
+	c := &docxConverter{
+		files:     files,
+		budget:    b,
+		rels:      make(map[string]docxRel),
+		styles:    make(map[string]docxStyle),
+		numFmts:   make(map[string]map[string]string),
+		bookmarks: make(map[string]int),
+	}
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+	body := document.child("body")
+	if body == nil {
+		return nil, ErrEmpty
+	}
+	c.convertBlocks(body.Children)
+
+	result := NewResult()
+	for _, doc := range c.split(strings.TrimSpace(name), splitLevel) {
+		if err := b.addDocs(1); err != nil {
+			return nil, err
+		}
+		result.Docs = append(result.Docs, doc)
+		_, assets := RefKeys(doc.Content)
+		for _, asset := range assets {
+			if _, ok := result.Assets[asset]; ok {
+				continue
+			}
+			if err := b.addAssets(1); err != nil {
+				return nil, err
+			}
+			data, err := b.readZipFile(files[asset], maxAssetSize)
+			if err != nil {
+				return nil, fmt.Errorf("read %s failed: %w", asset, err)
+			}
+			result.Assets[asset] = data
+		}
+	}
+	if len(result.Docs) == 0 {
+		return nil, ErrEmpty
+	}
+	return result, nil
+}
+
+type docxRel struct {
+	target   string
+	external bool
+}
+
+type docxStyle struct {
+	name    string
+	heading int
+}
+
+// docxBlock is a paragraph or a table of the document
+type docxBlock struct {
+	heading int
+	text    string
+	list    bool
+}
+
+type docxConverter struct {
+	files  map[string]*zip.File
+	budget *budget
+	rels   map[string]docxRel
+	styles map[string]docxStyle
+	// numId -> ilvl -> numFmt
+	numFmts map[string]map[string]string
+	blocks  []docxBlock
+	// bookmark name -> index of the block it is in
+	bookmarks map[string]int
+}
+
+var headingStyleRegex = regexp.MustCompile(`(?i)^heading\s*(\d)$`)
+
+func (c *docxConverter) load() error {
+	rels, err := readXML(c.budget, c.files, "word/_rels/document.xml.rels")
+	if err != nil {
+		return err
+	}
+	if rels != nil {
+		for _, rel := range rels.Children {
+			target := rel.attr("Target")
+			external := rel.attr("TargetMode") == "External"
+			if !external {
+				if strings.HasPrefix(target, "/") {
+					target = strings.TrimPrefix(target, "/")
+				} else {
+					target = path.Join("word", target)
+				}
+			}
+			c.rels[rel.attr("Id")] = docxRel{target: target, external: external}
+		}
+	}
+
+	styles, err := readXML(c.budget, c.files, "word/styles.xml")
+	if err != nil {
+		return err
+	}
+	if styles != nil {
+		for _, style := range styles.Children {
+			if style.XMLName.Local != "style" || style.attr("type") != "paragraph" {
+				continue
+			}
+			id := style.attr("styleId")
+			s := docxStyle{}
+			if n := style.child("name"); n != nil {
+				s.name = n.attr("val")
+			}
+			for _, candidate := range []string{s.name, id} {
+				if m := headingStyleRegex.FindStringSubmatch(candidate); m != nil {
+					s.heading, _ = strconv.Atoi(m[1])
+					break
+				}
+			}
+			if s.heading == 0 {
+				if pPr := style.child("pPr"); pPr != nil {
+					if lvl := pPr.child("outlineLvl"); lvl != nil {
+						if n, err := strconv.Atoi(lvl.attr("val")); err == nil && n < 9 {
+							s.heading = n + 1
+						}
+					}
+				}
+			}
+			c.styles[id] = s
+		}
+	}
+
+	numbering, err := readXML(c.budget, c.files, "word/numbering.xml")
+	if err != nil {
+		return err
+	}
+	if numbering != nil {
+		abstract := make(map[string]map[string]string)
+		for _, n := range numbering.Children {
+			if n.XMLName.Local != "abstractNum" {
+				continue
+			}
+			levels := make(map[string]string)
+			for _, lvl := range n.Children {
+				if lvl.XMLName.Local == "lvl" {
+					if numFmt := lvl.child("numFmt"); numFmt != nil {
+						levels[lvl.attr("ilvl")] = numFmt.attr("val")
+					}
+				}
+			}
+			abstract[n.attr("abstractNumId")] = levels
+		}
+		for _, n := range numbering.Children {
+			if n.XMLName.Local != "num" {
+				continue
+			}
+			if id := n.child("abstractNumId"); id != nil {
+				c.numFmts[n.attr("numId")] = abstract[id.attr("val")]
+			}
+		}
+	}
+	return nil
+}
+
+func (c *docxConverter) convertBlocks(nodes []xmlNode) {
+	for i := range nodes {
+		node := &nodes[i]
+		switch node.XMLName.Local {
+		case "p":
+			c.convertParagraph(node)
+		case "tbl":
+			if text := c.convertTable(node); text != "" {
+				c.blocks = append(c.blocks, docxBlock{text: text})
+			}
+		case "sdt":
+			if content := node.child("sdtContent"); content != nil {
+				c.convertBlocks(content.Children)
+			}
+		case "customXml", "ins":
+			c.convertBlocks(node.Children)
+		case "bookmarkStart":
+			c.bookmarks[node.attr("name")] = len(c.blocks)
+		}
+	}
+}
+
+func (c *docxConverter) convertParagraph(p *xmlNode) {
+	block := docxBlock{}
+	prefix := ""
+	if pPr := p.child("pPr"); pPr != nil {
+		if style := pPr.child("pStyle"); style != nil {
+			s := c.styles[style.attr("val")]
+			block.heading = s.heading
+			if strings.EqualFold(s.name, "title") || strings.EqualFold(style.attr("val"), "title") {
+				prefix = "# "
+			}
+		}
+		if lvl := pPr.child("outlineLvl"); lvl != nil && block.heading == 0 {
+			if n, err := strconv.Atoi(lvl.attr("val")); err == nil && n < 9 {
+				block.heading = n + 1
+			}
+		}
+		if numPr := pPr.child("numPr"); numPr != nil && block.heading == 0 {
+			numID, ilvl := "", "0"
+			if n := numPr.child("numId"); n != nil {
+				numID = n.attr("val")
+			}
+			if n := numPr.child("ilvl"); n != nil {
+				ilvl = n.attr("val")
+			}
+			if numID != "" && numID != "0" {
+				block.list = true
+				depth, _ := strconv.Atoi(ilvl)
+				marker := "- "
+				if numFmt := c.numFmts[numID][ilvl]; numFmt != "" && numFmt != "bullet" && numFmt != "none" {
+					marker = "1. "
+				}
+				prefix = strings.Repeat("    ", depth) + marker
+			}
+		}
+	}
+
+	segments := c.convertInline(p.Children, "", nil)
+	text := strings.TrimSpace(renderSegments(segments))
+	for _, name := range collectBookmarks(p) {
+		c.bookmarks[name] = len(c.blocks)
+	}
+	if text == "" {
+		return
+	}
+	if block.heading > 0 {
+		prefix = strings.Repeat("#", min(block.heading, 6)) + " "
+	} else if prefix == "" {
+		text = escapeLineStart(text)
+	}
+	block.text = prefix + text
+	c.blocks = append(c.blocks, block)
+}
+
+func collectBookmarks(n *xmlNode) []string {
+	names := make([]string, 0)
+	for i := range n.Children {
+		if n.Children[i].XMLName.Local == "bookmarkStart" {
+			names = append(names, n.Children[i].attr("name"))
+		}
+	}
+	return names
+}
+
+type docxSegment struct {
+	text                 string
+	bold, italic, strike bool
+	link                 string
+	raw                  bool
+}
+
+// convertInline collects the text runs of a paragraph, link is the target of the enclosing hyperlink
+func (c *docxConverter) convertInline(nodes []xmlNode, link string, segments []docxSegment) []docxSegment {
+	for i := range nodes {
+		node := &nodes[i]
+		switch node.XMLName.Local {
+		case "r":
+			segments = c.convertRun(node, link, segments)
+		case "hyperlink":
+			target := ""
+			if id := node.attr("id"); id != "" {
+				if rel, ok := c.rels[id]; ok && rel.external {
+					target = rel.target
+				}
+			} else if anchor := node.attr("anchor"); anchor != "" {
+				target = DocRef(bookmarkKey(anchor), "")
+			}
+			segments = c.convertInline(node.Children, target, segments)
+		case "ins", "smartTag", "customXml", "fldSimple":
+			segments = c.convertInline(node.Children, link, segments)
+		case "sdt":
+			if content := node.child("sdtContent"); content != nil {
+				segments = c.convertInline(content.Children, link, segments)
+			}
+		}
+	}
+	return segments
+}
+
+func (c *docxConverter) convertRun(r *xmlNode, link string, segments []docxSegment) []docxSegment {
+	seg := docxSegment{link: link}
+	if rPr := r.child("rPr"); rPr != nil {
+		seg.bold = rPr.on("b")
+		seg.italic = rPr.on("i")
+		seg.strike = rPr.on("strike") || rPr.on("dstrike")
+	}
+	for i := range r.Children {
+		node := &r.Children[i]
+		switch node.XMLName.Local {
+		case "t":
+			text := seg
+			text.text = node.Content
+			segments = append(segments, text)
+		case "tab":
+			text := seg
+			text.text = " "
+			segments = append(segments, text)
+		case "br", "cr":
+			if node.attr("type") != "page" {
+				segments = append(segments, docxSegment{text: "  \n", raw: true})
+			}
+		case "drawing", "pict", "object":
+			if image := c.convertImage(node); image != "" {
+				segments = append(segments, docxSegment{text: image, raw: true})
+			}
+		}
+	}
+	return segments
+}
+
+func (c *docxConverter) convertImage(node *xmlNode) string {
+	id := ""
+	if blip := node.find("blip"); blip != nil {
+		id = blip.attr("embed")
+	} else if data := node.find("imagedata"); data != nil {
+		id = data.attr("id")
+	}
+	rel, ok := c.rels[id]
+	if !ok || rel.external {
+		return ""
+	}
+	if _, ok := c.files[rel.target]; !ok {
+		return ""
+	}
+	alt := ""
+	if docPr := node.find("docPr"); docPr != nil {
+		alt = docPr.attr("descr")
+	}
+	return "![" + escapeMarkdown(alt) + "](" + AssetRef(rel.target) + ")"
+}
+
+type segmentStyle struct {
+	bold, italic, strike bool
+	link                 string
+}
+
+// renderSegments merges runs with the same formatting so that a word split over several runs is not broken by markers
+func renderSegments(segments []docxSegment) string {
+	var b strings.Builder
+	for i := 0; i < len(segments); {
+		if segments[i].raw {
+			b.WriteString(segments[i].text)
+			i++
+			continue
+		}
+		style := segmentStyle{segments[i].bold, segments[i].italic, segments[i].strike, segments[i].link}
+		var text strings.Builder
+		j := i
+		for ; j < len(segments) && !segments[j].raw &&
+			(segmentStyle{segments[j].bold, segments[j].italic, segments[j].strike, segments[j].link}) == style; j++ {
+			text.WriteString(segments[j].text)
+		}
+		b.WriteString(renderStyled(text.String(), style))
+		i = j
+	}
+	return b.String()
+}
+
+func renderStyled(text string, style segmentStyle) string {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return text
+	}
+	leading := text[:strings.Index(text, trimmed)]
+	trailing := text[len(leading)+len(trimmed):]
+	out := escapeMarkdown(trimmed)
+	if style.strike {
+		out = "~~" + out + "~~"
+	}
+	if style.italic {
+		out = "*" + out + "*"
+	}
+	if style.bold {
+		out = "**" + out + "**"
+	}
+	if style.link != "" {
+		out = "[" + out + "](" + style.link + ")"
+	}
+	return leading + out + trailing
+}
+
+var markdownEscaper = strings.NewReplacer(`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "<", `\<`)
+
+func escapeMarkdown(text string) string {
+	return markdownEscaper.Replace(text)
+}
+
+var orderedListStartRegex = regexp.MustCompile(`^(\d+)([.)])(\s)`)
+
+// escapeLineStart keeps paragraphs starting with markdown block syntax plain text
+func escapeLineStart(text string) string {
+	if text == "" {
+		return text
+	}
+	switch text[0] {
+	case '#', '>', '-', '+', '=', '|':
+		return `\` + text
+	}
+	return orderedListStartRegex.ReplaceAllString(text, `$1\$2$3`)
+}
+
+func (c *docxConverter) convertTable(tbl *xmlNode) string {
+	rows := make([][]string, 0)
+	columns := 0
+	for i := range tbl.Children {
+		tr := &tbl.Children[i]
+		if tr.XMLName.Local != "tr" {
+			continue
+		}
+		row := make([]string, 0)
+		for j := range tr.Children {
+			tc := &tr.Children[j]
+			if tc.XMLName.Local != "tc" {
+				continue
+			}
+			row = append(row, c.convertCell(tc))
+			if tcPr := tc.child("tcPr"); tcPr != nil {
+				if span := tcPr.child("gridSpan"); span != nil {
+					n, _ := strconv.Atoi(span.attr("val"))
+					for k := 1; k < n; k++ {
+						row = append(row, "")
+					}
+				}
+			}
+		}
+		columns = max(columns, len(row))
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 || columns == 0 {
+		return ""
+	}
+	var b strings.Builder
+	for i, row := range rows {
+		for len(row) < columns {
+			row = append(row, "")
+		}
+		b.WriteString("| " + strings.Join(row, " | ") + " |\n")
+		if i == 0 {
+			b.WriteString("|" + strings.Repeat(" --- |", columns) + "\n")
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// convertCell renders the paragraphs of a cell on one line, nested tables are flattened into text
+func (c *docxConverter) convertCell(tc *xmlNode) string {
+	lines := make([]string, 0)
+	var walk func(nodes []xmlNode)
+	walk = func(nodes []xmlNode) {
+		for i := range nodes {
+			node := &nodes[i]
+			switch node.XMLName.Local {
+			case "p":
+				if text := strings.TrimSpace(renderSegments(c.convertInline(node.Children, "", nil))); text != "" {
+					lines = append(lines, text)
+				}
+			case "tbl", "tr", "tc", "sdt", "sdtContent", "customXml", "ins":
+				walk(node.Children)
+			}
+		}
+	}
+	walk(tc.Children)
+	cell := strings.Join(lines, "<br>")
+	cell = strings.ReplaceAll(cell, "  \n", "<br>")
+	cell = strings.ReplaceAll(cell, "\n", " ")
+	return strings.ReplaceAll(cell, "|", `\|`)
+}
+
+func bookmarkKey(name string) string {
+	return "#bookmark/" + name
+}
+
+type docxSection struct {
+	folders []string
+	name    string
+	start   int
+	blocks  []docxBlock
+}
+
+// split groups the blocks into documents, links to bookmarks are pointed at the document containing them
+func (c *docxConverter) split(name string, splitLevel int) []*Doc {
+	if name == "" {
+		name = "Untitled"
+	}
+	sections := make([]*docxSection, 0)
+	current := &docxSection{name: name}
+	if splitLevel > 0 {
+		current.folders = []string{name}
+	}
+	h1 := ""
+	for i, block := range c.blocks {
+		level := block.heading
+		if splitLevel > 0 && level > 0 && level <= splitLevel {
+			title := stripHeading(block.text)
+			if title == "" {
+				title = name
+			}
+			folders := []string{name}
+			if level == 1 {
+				h1 = title
+			} else if h1 != "" {
+				folders = append(folders, h1)
+			}
+			sections = append(sections, current)
+			current = &docxSection{folders: folders, name: title, start: i}
+			continue
+		}
+		current.blocks = append(current.blocks, block)
+	}
+	sections = append(sections, current)
+
+	if splitLevel == 2 {
+		// a Heading 1 only becomes a folder when it has Heading 2 sections
+		hasChildren := make(map[string]bool)
+		for _, s := range sections {
+			if len(s.folders) == 2 {
+				hasChildren[s.folders[1]] = true
+			}
+		}
+		for _, s := range sections {
+			if len(s.folders) == 1 && s.name != name && hasChildren[s.name] {
+				s.folders = []string{name, s.name}
+			}
+		}
+	}
+
+	docs := make([]*Doc, 0, len(sections))
+	keys := make([]string, len(sections))
+	for i := range sections {
+		keys[i] = fmt.Sprintf("%04d", i)
+	}
+	sectionOf := func(block int) string {
+		key := keys[0]
+		for i, s := range sections {
+			if s.start <= block {
+				key = keys[i]
+			}
+		}
+		return key
+	}
+	// each ref is looked up exactly, bookmark names are often prefixes of each other like _Ref1 and _Ref12
+	resolveBookmarks := func(content string) string {
+		return refRegex.ReplaceAllStringFunc(content, func(match string) string {
+			parts := refRegex.FindStringSubmatch(match)
+			if parts[1]+":" != docRefScheme {
+				return match
+			}
+			key, err := url.PathUnescape(parts[2])
+			if err != nil {
+				return match
+			}
+			bookmark, ok := strings.CutPrefix(key, bookmarkKey(""))
+			if !ok {
+				return match
+			}
+			if block, ok := c.bookmarks[bookmark]; ok {
+				return DocRef(sectionOf(block), "")
+			}
+			return match
+		})
+	}
+
+	for i, s := range sections {
+		var b strings.Builder
+		for j, block := range s.blocks {
+			if j > 0 {
+				if s.blocks[j-1].list && block.list {
+					b.WriteString("\n")
+				} else {
+					b.WriteString("\n\n")
+				}
+			}
+			b.WriteString(block.text)
+		}
+		content := strings.TrimSpace(b.String())
+		if content == "" && (i == 0 && len(sections) > 1 || splitLevel == 2 && len(s.folders) == 2 && s.folders[1] == s.name) {
+			continue
+		}
+		docs = append(docs, &Doc{
+			Key:     keys[i],
+			Folders: s.folders,
+			Name:    s.name,
+			Content: resolveBookmarks(content) + "\n",
+		})
+	}
+	return docs
+}
+
+func stripHeading(text string) string {
+	text = strings.TrimLeft(text, "#")
+	text = strings.NewReplacer(`\`, "", "**", "", "~~", "").Replace(strings.TrimSpace(text))
+	return strings.TrimSpace(strings.Trim(text, "*"))
+}
@@ -0,0 +1,112 @@
+package docimport
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDocumentXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" xmlns:wp="http://schemas.openxmlformats.org/drawingml/2006/wordprocessingDrawing">
+<w:body>
+<w:p><w:r><w:t>Intro with </w:t></w:r><w:r><w:rPr><w:b/></w:rPr><w:t>bold</w:t></w:r><w:r><w:rPr><w:b/></w:rPr><w:t xml:space="preserve"> text </w:t></w:r><w:r><w:t>and </w:t></w:r><w:hyperlink r:id="rId2"><w:r><w:t>a link</w:t></w:r></w:hyperlink></w:p>
+<w:p><w:pPr><w:pStyle w:val="1"/></w:pPr><w:bookmarkStart w:id="0" w:name="_Toc1"/><w:r><w:t>Install</w:t></w:r></w:p>
+<w:p><w:pPr><w:numPr><w:ilvl w:val="0"/><w:numId w:val="1"/></w:numPr></w:pPr><w:r><w:t>first</w:t></w:r></w:p>
+<w:p><w:pPr><w:numPr><w:ilvl w:val="1"/><w:numId w:val="1"/></w:numPr></w:pPr><w:r><w:t>nested</w:t></w:r></w:p>
+<w:p><w:pPr><w:numPr><w:ilvl w:val="0"/><w:numId w:val="2"/></w:numPr></w:pPr><w:r><w:t>step</w:t></w:r></w:p>
+<w:tbl><w:tr><w:tc><w:p><w:r><w:t>Key</w:t></w:r></w:p></w:tc><w:tc><w:p><w:r><w:t>Value</w:t></w:r></w:p></w:tc></w:tr>
+<w:tr><w:tc><w:p><w:r><w:t>a|b</w:t></w:r></w:p></w:tc><w:tc><w:p><w:r><w:t>1</w:t></w:r></w:p><w:p><w:r><w:t>2</w:t></w:r></w:p></w:tc></w:tr></w:tbl>
+<w:p><w:r><w:drawing><wp:inline><wp:docPr id="1" name="Picture 1" descr="diagram"/><a:graphic><a:graphicData><a:blip r:embed="rId3"/></a:graphicData></a:graphic></wp:inline></w:drawing></w:r></w:p>
+<w:p><w:pPr><w:pStyle w:val="2"/></w:pPr><w:r><w:t>Linux</w:t></w:r></w:p>
+<w:p><w:r><w:t># not a heading, see </w:t></w:r><w:hyperlink w:anchor="_Toc1"><w:r><w:t>install</w:t></w:r></w:hyperlink></w:p>
+</w:body>
+</w:document>`
+
+const testStylesXML = `<?xml version="1.0" encoding="UTF-8"?>
+<w:styles xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:style w:type="paragraph" w:styleId="1"><w:name w:val="heading 1"/></w:style>
+<w:style w:type="paragraph" w:styleId="2"><w:name w:val="heading 2"/></w:style>
+</w:styles>`
+
+const testNumberingXML = `<?xml version="1.0" encoding="UTF-8"?>
+<w:numbering xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:abstractNum w:abstractNumId="0"><w:lvl w:ilvl="0"><w:numFmt w:val="bullet"/></w:lvl><w:lvl w:ilvl="1"><w:numFmt w:val="bullet"/></w:lvl></w:abstractNum>
+<w:abstractNum w:abstractNumId="1"><w:lvl w:ilvl="0"><w:numFmt w:val="decimal"/></w:lvl></w:abstractNum>
+<w:num w:numId="1"><w:abstractNumId w:val="0"/></w:num>
+<w:num w:numId="2"><w:abstractNumId w:val="1"/></w:num>
+</w:numbering>`
+
+const testRelsXML = `<?xml version="1.0" encoding="UTF-8"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink" Target="https://example.com" TargetMode="External"/>
+<Relationship Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/image" Target="media/image1.png"/>
+</Relationships>`
+
+func newDocx(t *testing.T) map[string]string {
+	t.Helper()
+	return map[string]string{
+		"word/document.xml":            testDocumentXML,
+		"word/styles.xml":              testStylesXML,
+		"word/numbering.xml":           testNumberingXML,
+		"word/_rels/document.xml.rels": testRelsXML,
+		"word/media/image1.png":        "png",
+	}
+}
+
+func TestParseDocx(t *testing.T) {
+	result, err := ParseDocx(newZip(t, newDocx(t)), "Manual", 0)
+	require.NoError(t, err)
+	require.Len(t, result.Docs, 1)
+	doc := result.Docs[0]
+	assert.Equal(t, "Manual", doc.Name)
+	assert.Empty(t, doc.Folders)
+	assert.Equal(t, "Intro with **bold text** and [a link](https://example.com)\n\n"+
+		"# Install\n\n"+
+		"- first\n    - nested\n1. step\n\n"+
+		"| Key | Value |\n| --- | --- |\n| a\\|b | 1<br>2 |\n\n"+
+		"![diagram](pwasset:word%2Fmedia%2Fimage1.png)\n\n"+
+		"## Linux\n\n"+
+		"\\# not a heading, see [install](pwdoc:0000)\n", doc.Content)
+	assert.Equal(t, []byte("png"), result.Assets["word/media/image1.png"])
+}
+
+func TestParseDocxSplit(t *testing.T) {
+	result, err := ParseDocx(newZip(t, newDocx(t)), "Manual", 2)
+	require.NoError(t, err)
+	require.Len(t, result.Docs, 3)
+
+	assert.Equal(t, "Manual", result.Docs[0].Name)
+	assert.Equal(t, []string{"Manual"}, result.Docs[0].Folders)
+
+	assert.Equal(t, "Install", result.Docs[1].Name)
+	assert.Equal(t, []string{"Manual", "Install"}, result.Docs[1].Folders)
+	assert.Contains(t, result.Docs[1].Content, "- first")
+
+	assert.Equal(t, "Linux", result.Docs[2].Name)
+	assert.Equal(t, []string{"Manual", "Install"}, result.Docs[2].Folders)
+	assert.Equal(t, "\\# not a heading, see [install](pwdoc:0001)\n", result.Docs[2].Content)
+}
+
+func TestParseDocxBookmarkPrefixes(t *testing.T) {
+	files := newDocx(t)
+	files["word/document.xml"] = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:body>
+<w:p><w:r><w:t>Contents</w:t></w:r></w:p>
+<w:p><w:hyperlink w:anchor="_Ref12"><w:r><w:t>second</w:t></w:r></w:hyperlink><w:r><w:t xml:space="preserve"> </w:t></w:r><w:hyperlink w:anchor="_Ref1"><w:r><w:t>first</w:t></w:r></w:hyperlink><w:r><w:t xml:space="preserve"> </w:t></w:r><w:hyperlink w:anchor="_Ref123"><w:r><w:t>missing</w:t></w:r></w:hyperlink></w:p>
+<w:p><w:pPr><w:pStyle w:val="1"/></w:pPr><w:bookmarkStart w:id="0" w:name="_Ref1"/><w:r><w:t>First</w:t></w:r></w:p>
+<w:p><w:r><w:t>one</w:t></w:r></w:p>
+<w:p><w:pPr><w:pStyle w:val="1"/></w:pPr><w:bookmarkStart w:id="1" w:name="_Ref12"/><w:r><w:t>Second</w:t></w:r></w:p>
+<w:p><w:r><w:t>two</w:t></w:r></w:p>
+</w:body>
+</w:document>`
+	// the bookmarks are kept in a map, repeat to cover its iteration orders
+	for range 50 {
+		result, err := ParseDocx(newZip(t, files), "Manual", 1)
+		require.NoError(t, err)
+		require.Len(t, result.Docs, 3)
+		assert.Equal(t, "Contents\n\n[second](pwdoc:0002) [first](pwdoc:0001) [missing](pwdoc:%23bookmark%2F_Ref123)\n",
+			result.Docs[0].Content)
+	}
+}
@@ -0,0 +1,366 @@
+package docimport
+
+import (
+	"archive/zip"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+)
+
+const (
+	maxDocSize   = 20 << 20
+	maxAssetSize = 50 << 20
+)
+
+var imageExts = map[string]bool{
+	".png": true, ".jpg": true, ".jpeg": true, ".gif": true, ".svg": true, ".webp": true, ".bmp": true, ".avif": true,
+}
+
+func isMarkdown(p string) bool {
+	ext := strings.ToLower(path.Ext(p))
+	return ext == ".md" || ext == ".markdown"
+}
+
+func isImage(p string) bool {
+	return imageExts[strings.ToLower(path.Ext(p))]
+}
+
+// ParseMarkdownZip converts a zip of markdown files, such as an Obsidian vault, into documents.
+// folders of the archive become folders, relative links and [[wikilinks]] to other files of the archive
+// are rewritten and the referenced images and attachments are added as assets
+func ParseMarkdownZip(r *zip.Reader) (*Result, error) {
+	files := make(map[string]*zip.File)
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		p, ok := CleanPath(f.Name)
+		if !ok || isHiddenPath(p) {
+			continue
+		}
+		files[p] = f
+	}
+	files = stripCommonRoot(files)
+
+	v := newVault(files)
+	if len(v.notes) == 0 {
+		return nil, ErrEmpty
+	}
+	b := newBudget()
+	result := NewResult()
+	for _, key := range v.notes {
+		if err := b.addDocs(1); err != nil {
+			return nil, err
+		}
+		content, err := b.readZipFile(files[key], maxDocSize)
+		if err != nil {
+			return nil, fmt.Errorf("read %s failed: %w", key, err)
+		}
+		folders, file := SplitFolders(key)
+		doc := &Doc{
+			Key:     key,
+			Folders: folders,
+			Name:    strings.TrimSuffix(file, path.Ext(file)),
+			Content: v.rewrite(key, stripFrontMatter(string(content))),
+		}
+		result.Docs = append(result.Docs, doc)
+
+		_, assets := RefKeys(doc.Content)
+		for _, asset := range assets {
+			if _, ok := result.Assets[asset]; ok {
+				continue
+			}
+			if err := b.addAssets(1); err != nil {
+				return nil, err
+			}
+			data, err := b.readZipFile(files[asset], maxAssetSize)
+			if err != nil {
+				return nil, fmt.Errorf("read %s failed: %w", asset, err)
+			}
+			result.Assets[asset] = data
+		}
+	}
+	result.SortDocs()
+	return result, nil
+}
+
+// isHiddenPath skips the metadata of Obsidian, macOS archives and other dot files
+func isHiddenPath(p string) bool {
+	for _, part := range strings.Split(p, "/") {
+		if strings.HasPrefix(part, ".") || part == "__MACOSX" {
+			return true
+		}
+	}
+	return false
+}
+
+// stripCommonRoot removes the folder wrapping all files, archives of a folder usually contain the folder itself
+func stripCommonRoot(files map[string]*zip.File) map[string]*zip.File {
+	root := ""
+	for p := range files {
+		i := strings.Index(p, "/")
+		if i < 0 || root != "" && p[:i] != root {
+			return files
+		}
+		root = p[:i]
+	}
+	if root == "" {
+		return files
+	}
+	stripped := make(map[string]*zip.File, len(files))
+	for p, f := range files {
+		stripped[strings.TrimPrefix(p, root+"/")] = f
+	}
+	return stripped
+}
+
+// readZipFile reads a file of an archive, the size recorded in the archive is checked first but not trusted
+func (b *budget) readZipFile(f *zip.File, limit int64) ([]byte, error) {
+	if f == nil {
+		return nil, fmt.Errorf("file not found")
+	}
+	if f.UncompressedSize64 > uint64(limit) {
+		return nil, fmt.Errorf("%w: file is larger than %d bytes", ErrLimitExceeded, limit)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return b.read(rc, limit)
+}
+
+func stripFrontMatter(content string) string {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	if !strings.HasPrefix(content, "---\n") {
+		return content
+	}
+	end := strings.Index(content[4:], "\n---")
+	if end < 0 {
+		return content
+	}
+	rest := content[4+end+4:]
+	if rest != "" && rest[0] != '\n' {
+		return content
+	}
+	return strings.TrimLeft(rest, "\n")
+}
+
+// vault resolves the links of the markdown files of an archive
+type vault struct {
+	files map[string]*zip.File
+	notes []string
+	// lower case file name -> paths, note names are indexed with and without extension
+	byName map[string][]string
+}
+
+func newVault(files map[string]*zip.File) *vault {
+	v := &vault{files: files, notes: make([]string, 0), byName: make(map[string][]string)}
+	for p := range files {
+		base := strings.ToLower(path.Base(p))
+		v.byName[base] = append(v.byName[base], p)
+		if isMarkdown(p) {
+			v.notes = append(v.notes, p)
+			name := strings.TrimSuffix(base, path.Ext(base))
+			v.byName[name] = append(v.byName[name], p)
+		}
+	}
+	return v
+}
+
+var (
+	fenceRegex      = regexp.MustCompile("^\\s*(```|~~~)")
+	inlineCodeRegex = regexp.MustCompile("`[^`\n]+`")
+	wikiLinkRegex   = regexp.MustCompile(`(!?)\[\[([^\[\]|]*?)(?:\|([^\[\]]*))?\]\]`)
+	mdLinkRegex     = regexp.MustCompile(`(!?)\[([^\]]*)\]\(\s*(<[^>]*>|[^()\s]+)(\s+"[^"]*")?\s*\)`)
+	htmlImgRegex    = regexp.MustCompile(`(<img\b[^>]*?\bsrc=["'])([^"']+)(["'])`)
+	imageSizeRegex  = regexp.MustCompile(`^\d+(x\d+)?$`)
+)
+
+// rewrite rewrites the links of the note at key, code blocks and inline code are left as they are
+func (v *vault) rewrite(key, content string) string {
+	var b strings.Builder
+	lines := strings.SplitAfter(content, "\n")
+	fence := ""
+	text := make([]string, 0)
+	flush := func() {
+		b.WriteString(rewriteOutsideInlineCode(strings.Join(text, ""), func(s string) string {
+			return v.rewriteLinks(key, s)
+		}))
+		text = text[:0]
+	}
+	for _, line := range lines {
+		if m := fenceRegex.FindStringSubmatch(line); m != nil {
+			switch fence {
+			case "":
+				flush()
+				fence = m[1]
+				b.WriteString(line)
+				continue
+			case m[1]:
+				fence = ""
+				b.WriteString(line)
+				continue
+			}
+		}
+		if fence != "" {
+			b.WriteString(line)
+			continue
+		}
+		text = append(text, line)
+	}
+	flush()
+	return b.String()
+}
+
+func rewriteOutsideInlineCode(s string, fn func(string) string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range inlineCodeRegex.FindAllStringIndex(s, -1) {
+		b.WriteString(fn(s[last:loc[0]]))
+		b.WriteString(s[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	b.WriteString(fn(s[last:]))
+	return b.String()
+}
+
+func (v *vault) rewriteLinks(key, s string) string {
+	s = wikiLinkRegex.ReplaceAllStringFunc(s, func(match string) string {
+		m := wikiLinkRegex.FindStringSubmatch(match)
+		return v.rewriteWikiLink(key, m[1] == "!", strings.TrimSpace(m[2]), strings.TrimSpace(m[3]))
+	})
+	s = mdLinkRegex.ReplaceAllStringFunc(s, func(match string) string {
+		m := mdLinkRegex.FindStringSubmatch(match)
+		dest := strings.TrimSuffix(strings.TrimPrefix(m[3], "<"), ">")
+		ref, ok := v.resolveRelative(key, dest)
+		if !ok {
+			return match
+		}
+		return m[1] + "[" + m[2] + "](" + ref + m[4] + ")"
+	})
+	return htmlImgRegex.ReplaceAllStringFunc(s, func(match string) string {
+		m := htmlImgRegex.FindStringSubmatch(match)
+		ref, ok := v.resolveRelative(key, m[2])
+		if !ok {
+			return match
+		}
+		return m[1] + ref + m[3]
+	})
+}
+
+// rewriteWikiLink converts [[target#anchor|alias]] and ![[embed|alias]] into markdown links,
+// unresolved links are replaced with their text
+func (v *vault) rewriteWikiLink(key string, embed bool, target, alias string) string {
+	target, anchor, _ := strings.Cut(target, "#")
+	text := alias
+	if text == "" || embed && imageSizeRegex.MatchString(text) {
+		text = path.Base(target)
+		if isMarkdown(text) {
+			text = strings.TrimSuffix(text, path.Ext(text))
+		}
+		switch {
+		case anchor != "" && target == "":
+			text = anchor
+		case anchor != "":
+			text += " > " + anchor
+		}
+	}
+
+	resolved := key
+	if target != "" {
+		var ok bool
+		if resolved, ok = v.resolveWiki(key, target); !ok {
+			return text
+		}
+	}
+	switch {
+	case isMarkdown(resolved):
+		return "[" + text + "](" + DocRef(resolved, strings.TrimPrefix(anchor, "^")) + ")"
+	case embed && isImage(resolved):
+		return "![" + strings.TrimSuffix(text, path.Ext(text)) + "](" + AssetRef(resolved) + ")"
+	default:
+		return "[" + text + "](" + AssetRef(resolved) + ")"
+	}
+}
+
+// resolveWiki finds the file a wikilink points to the way Obsidian does: a path from the root or the note,
+// otherwise the file with that name closest to the note
+func (v *vault) resolveWiki(key, target string) (string, bool) {
+	candidates := []string{target, target + ".md"}
+	for _, candidate := range candidates {
+		for _, p := range []string{path.Join(path.Dir(key), candidate), path.Clean(candidate)} {
+			if _, ok := v.files[p]; ok {
+				return p, true
+			}
+		}
+	}
+	matches := v.byName[strings.ToLower(path.Base(target))]
+	if strings.Contains(target, "/") {
+		suffix := strings.ToLower(target)
+		matches = filterSuffix(matches, suffix)
+	}
+	if len(matches) == 0 {
+		return "", false
+	}
+	best := matches[0]
+	for _, p := range matches[1:] {
+		if closer(key, p, best) {
+			best = p
+		}
+	}
+	return best, true
+}
+
+func filterSuffix(paths []string, suffix string) []string {
+	filtered := make([]string, 0, len(paths))
+	for _, p := range paths {
+		lower := strings.ToLower(p)
+		if strings.HasSuffix(lower, suffix) || strings.HasSuffix(lower, suffix+".md") {
+			filtered = append(filtered, p)
+		}
+	}
+	return filtered
+}
+
+// closer reports whether a is a better match than b for a link from key: same folder first, then the shorter path
+func closer(key, a, b string) bool {
+	dir := path.Dir(key)
+	if (path.Dir(a) == dir) != (path.Dir(b) == dir) {
+		return path.Dir(a) == dir
+	}
+	if strings.Count(a, "/") != strings.Count(b, "/") {
+		return strings.Count(a, "/") < strings.Count(b, "/")
+	}
+	return a < b
+}
+
+// resolveRelative resolves the destination of a markdown link or image against the note,
+// urls, absolute paths and files missing from the archive are left as they are
+func (v *vault) resolveRelative(key, dest string) (string, bool) {
+	if dest == "" || strings.HasPrefix(dest, "/") || strings.HasPrefix(dest, "#") || strings.Contains(dest, ":") {
+		return "", false
+	}
+	ref, anchor, _ := strings.Cut(dest, "#")
+	ref, _, _ = strings.Cut(ref, "?")
+	if unescaped, err := url.PathUnescape(ref); err == nil {
+		ref = unescaped
+	}
+	for _, candidate := range []string{path.Join(path.Dir(key), ref), path.Clean(ref)} {
+		if strings.HasPrefix(candidate, "..") {
+			continue
+		}
+		for _, p := range []string{candidate, candidate + ".md"} {
+			if _, ok := v.files[p]; !ok {
+				continue
+			}
+			if isMarkdown(p) {
+				return DocRef(p, anchor), true
+			}
+			return AssetRef(p), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,96 @@
+package docimport
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newZip(t *testing.T, files map[string]string) *zip.Reader {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	r, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	return r
+}
+
+func TestParseMarkdownZip(t *testing.T) {
+	r := newZip(t, map[string]string{
+		"vault/.obsidian/app.json":       "{}",
+		"vault/Home.md":                  "---\ntags: [a]\n---\nSee [[Install|setup]], [[Guide/Install#Linux]] and [[Missing]].\n![[logo.png|200]]\n[guide](Guide/Install.md#linux) [site](https://example.com)\n```\n[[Install]]\n```\n`[[Install]]`\n",
+		"vault/Guide/Install.md":         "![diagram](../assets/a%20b.png)\n<img src=\"../assets/logo.png\">\n[[#Linux]]\n",
+		"vault/assets/logo.png":          "png",
+		"vault/assets/a b.png":           "png2",
+		"vault/assets/unreferenced.json": "{}",
+	})
+	result, err := ParseMarkdownZip(r)
+	require.NoError(t, err)
+	require.Len(t, result.Docs, 2)
+
+	install, home := result.Docs[0], result.Docs[1]
+	assert.Equal(t, "Guide/Install.md", install.Key)
+	assert.Equal(t, []string{"Guide"}, install.Folders)
+	assert.Equal(t, "Install", install.Name)
+	assert.Equal(t, "![diagram](pwasset:assets%2Fa%20b.png)\n<img src=\"pwasset:assets%2Flogo.png\">\n[Linux](pwdoc:Guide%2FInstall.md#Linux)\n", install.Content)
+
+	assert.Equal(t, "Home.md", home.Key)
+	assert.Empty(t, home.Folders)
+	assert.Equal(t, "See [setup](pwdoc:Guide%2FInstall.md), [Install > Linux](pwdoc:Guide%2FInstall.md#Linux) and Missing.\n"+
+		"![logo](pwasset:assets%2Flogo.png)\n"+
+		"[guide](pwdoc:Guide%2FInstall.md#linux) [site](https://example.com)\n```\n[[Install]]\n```\n`[[Install]]`\n", home.Content)
+
+	assert.Len(t, result.Assets, 2)
+	assert.Equal(t, []byte("png2"), result.Assets["assets/a b.png"])
+
+	resolved := ResolveRefs(home.Content, func(key string) (string, bool) {
+		return "/node/1", key == "Guide/Install.md"
+	}, func(key string) (string, bool) {
+		return "/static-file/kb/logo.png", true
+	})
+	assert.Contains(t, resolved, "[setup](/node/1)")
+	assert.Contains(t, resolved, "[Install > Linux](/node/1#Linux)")
+	assert.Contains(t, resolved, "![logo](/static-file/kb/logo.png)")
+}
+
+func TestParseMarkdownZipEmpty(t *testing.T) {
+	_, err := ParseMarkdownZip(newZip(t, map[string]string{"a.png": "png"}))
+	assert.ErrorIs(t, err, ErrEmpty)
+}
+
+func TestBudget(t *testing.T) {
+	b := &budget{maxSize: 10, maxDocs: 2, maxAssets: 1}
+	data, err := b.read(strings.NewReader("12345"), 8)
+	require.NoError(t, err)
+	assert.Equal(t, "12345", string(data))
+
+	_, err = b.read(strings.NewReader("123456789"), 8)
+	assert.ErrorIs(t, err, ErrLimitExceeded)
+	_, err = b.read(strings.NewReader("123456"), 8)
+	assert.ErrorIs(t, err, ErrLimitExceeded)
+
+	assert.NoError(t, b.addDocs(2))
+	assert.ErrorIs(t, b.addDocs(1), ErrLimitExceeded)
+	assert.NoError(t, b.addAssets(1))
+	assert.ErrorIs(t, b.addAssets(1), ErrLimitExceeded)
+}
+
+func TestParseMarkdownZipTooManyDocs(t *testing.T) {
+	files := make(map[string]string, MaxDocs+1)
+	for i := 0; i <= MaxDocs; i++ {
+		files[fmt.Sprintf("note%d.md", i)] = "text"
+	}
+	_, err := ParseMarkdownZip(newZip(t, files))
+	assert.ErrorIs(t, err, ErrLimitExceeded)
+}
@@ -0,0 +1,753 @@
+package docimport
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxDumpSize bounds the uncompressed size of a dump, the older revisions of a full history dump are read
+// but not kept, the kept pages count against MaxTotalSize
+const maxDumpSize = 1 << 30
+
+const (
+	mwNamespaceMain     = 0
+	mwNamespaceCategory = 14
+)
+
+// mwSkippedNamespaces are not imported: user pages, files, interface messages, templates and modules.
+// talk pages, which have odd namespaces, and the virtual namespaces are skipped as well
+var mwSkippedNamespaces = map[int]bool{2: true, 6: true, 8: true, 10: true, 828: true}
+
+var mwDefaultNamespaces = map[int]string{
+	1: "Talk", 2: "User", 4: "Project", 6: "File", 8: "MediaWiki", 10: "Template", 12: "Help", 14: "Category",
+}
+
+type mwSiteInfo struct {
+	SiteName   string `xml:"sitename"`
+	Namespaces []struct {
+		Key  int    `xml:"key,attr"`
+		Name string `xml:",chardata"`
+	} `xml:"namespaces>namespace"`
+}
+
+type mwPage struct {
+	Title string
+	NS    int
+	ID    string
+	// the page redirects to RedirectTitle
+	Redirect      bool
+	RedirectTitle string
+	// latest revision, nil for a page without revisions
+	Revision *mwRevision
+}
+
+type mwRevision struct {
+	Timestamp string
+	Text      string
+}
+
+// ParseMediaWikiDump converts a MediaWiki XML export, plain or compressed with gzip or bzip2, into documents.
+// the latest revision of each page is converted to markdown, pages are placed in a folder per namespace
+// and per first category, and [[links]] between the pages, redirects included, are rewritten.
+// uploaded files are not part of a dump, file links keep their caption only
+func ParseMediaWikiDump(r io.Reader, name string) (*Result, error) {
+	r, err := decompress(r)
+	if err != nil {
+		return nil, err
+	}
+	b := newBudget()
+	site := &mwSiteInfo{}
+	pages := make([]*mwPage, 0)
+	decoder := xml.NewDecoder(io.LimitReader(r, maxDumpSize))
+	decoder.Strict = false
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid dump: %w", err)
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch start.Name.Local {
+		case "siteinfo":
+			if err := decoder.DecodeElement(site, &start); err != nil {
+				return nil, fmt.Errorf("invalid siteinfo: %w", err)
+			}
+		case "page":
+			page, err := readMWPage(decoder)
+			if err != nil {
+				return nil, fmt.Errorf("invalid page: %w", err)
+			}
+			size := len(page.Title) + len(page.RedirectTitle)
+			if page.Revision != nil {
+				size += len(page.Revision.Text)
+			}
+			if err := b.use(int64(size)); err != nil {
+				return nil, err
+			}
+			pages = append(pages, page)
+		}
+	}
+
+	if name = strings.TrimSpace(name); name == "" {
+		name = strings.TrimSpace(site.SiteName)
+	}
+	if name == "" {
+		name = "MediaWiki"
+	}
+	w := newMediaWiki(site)
+	for _, page := range pages {
+		if w.imported(page) {
+			w.pages[w.normalize(page.Title)] = page
+		}
+	}
+	for _, page := range pages {
+		if page.Redirect && page.RedirectTitle != "" {
+			w.redirects[w.normalize(page.Title)] = w.normalize(page.RedirectTitle)
+		}
+	}
+
+	if err := b.addDocs(len(w.pages)); err != nil {
+		return nil, err
+	}
+	result := NewResult()
+	for key, page := range w.pages {
+		content, categories := w.convert(page.Revision.Text)
+		title := w.displayTitle(page)
+		folders := []string{name}
+		switch page.NS {
+		case mwNamespaceCategory:
+			// a category page describes the folder of the category
+			folders = append(folders, title)
+		case mwNamespaceMain:
+		default:
+			folders = append(folders, w.namespaces[page.NS])
+		}
+		if len(categories) > 0 && page.NS != mwNamespaceCategory {
+			folders = append(folders, categories[0])
+		}
+		externalID := page.ID
+		if externalID == "" {
+			externalID = key
+		}
+		result.Docs = append(result.Docs, &Doc{
+			Key:        key,
+			Folders:    folders,
+			Name:       title,
+			Content:    content,
+			ExternalID: "mediawiki:" + name + ":" + externalID,
+		})
+	}
+	if len(result.Docs) == 0 {
+		return nil, ErrEmpty
+	}
+	result.SortDocs()
+	return result, nil
+}
+
+// readMWPage reads the page whose start element was just read. only the text of the latest revision is decoded,
+// the text of a revision older than the one already read is skipped
+func readMWPage(decoder *xml.Decoder) (*mwPage, error) {
+	page := &mwPage{}
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.EndElement:
+			return page, nil
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "title":
+				err = decoder.DecodeElement(&page.Title, &t)
+			case "ns":
+				err = decoder.DecodeElement(&page.NS, &t)
+			case "id":
+				err = decoder.DecodeElement(&page.ID, &t)
+			case "redirect":
+				page.Redirect = true
+				for _, attr := range t.Attr {
+					if attr.Name.Local == "title" {
+						page.RedirectTitle = attr.Value
+					}
+				}
+				err = decoder.Skip()
+			case "revision":
+				err = readMWRevision(decoder, page)
+			default:
+				err = decoder.Skip()
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// readMWRevision reads a revision into page.Revision if it is not older than the revision already read,
+// dumps with the full history list the revisions oldest first
+func readMWRevision(decoder *xml.Decoder, page *mwPage) error {
+	timestamp := ""
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		switch t := token.(type) {
+		case xml.EndElement:
+			return nil
+		case xml.StartElement:
+			switch {
+			case t.Name.Local == "timestamp":
+				err = decoder.DecodeElement(&timestamp, &t)
+			case t.Name.Local == "text" && (page.Revision == nil || timestamp >= page.Revision.Timestamp):
+				revision := &mwRevision{Timestamp: timestamp}
+				if err = decoder.DecodeElement(&revision.Text, &t); err == nil {
+					page.Revision = revision
+				}
+			default:
+				err = decoder.Skip()
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func decompress(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	magic, _ := br.Peek(3)
+	switch {
+	case bytes.HasPrefix(magic, []byte{0x1f, 0x8b}):
+		return gzip.NewReader(br)
+	case bytes.Equal(magic, []byte("BZh")):
+		return bzip2.NewReader(br), nil
+	}
+	return br, nil
+}
+
+// mediaWiki resolves the titles of a dump
+type mediaWiki struct {
+	// namespace key -> canonical name, and lower case name or alias -> key
+	namespaces map[int]string
+	nsByName   map[string]int
+	// normalized title -> imported page
+	pages map[string]*mwPage
+	// normalized title of a redirect -> normalized title of its target
+	redirects map[string]string
+}
+
+func newMediaWiki(site *mwSiteInfo) *mediaWiki {
+	w := &mediaWiki{
+		namespaces: make(map[int]string),
+		nsByName:   make(map[string]int),
+		pages:      make(map[string]*mwPage),
+		redirects:  make(map[string]string),
+	}
+	for key, ns := range mwDefaultNamespaces {
+		w.namespaces[key] = ns
+		w.nsByName[strings.ToLower(ns)] = key
+	}
+	for _, ns := range site.Namespaces {
+		if ns.Key == mwNamespaceMain || strings.TrimSpace(ns.Name) == "" {
+			continue
+		}
+		w.namespaces[ns.Key] = strings.TrimSpace(ns.Name)
+		w.nsByName[strings.ToLower(strings.TrimSpace(ns.Name))] = ns.Key
+	}
+	w.nsByName["image"] = 6
+	w.nsByName["media"] = 6
+	return w
+}
+
+func (w *mediaWiki) imported(page *mwPage) bool {
+	return !page.Redirect && page.Revision != nil &&
+		page.NS >= 0 && page.NS%2 == 0 && !mwSkippedNamespaces[page.NS]
+}
+
+// normalize returns the canonical form of a title: underscores are spaces, the namespace is
+// spelled as declared and the first letter of the page name is upper case
+func (w *mediaWiki) normalize(title string) string {
+	title = strings.Join(strings.Fields(strings.ReplaceAll(title, "_", " ")), " ")
+	title = strings.TrimPrefix(title, ":")
+	if prefix, rest, ok := strings.Cut(title, ":"); ok {
+		if ns, ok := w.nsByName[strings.ToLower(strings.TrimSpace(prefix))]; ok {
+			return w.namespaces[ns] + ":" + upperFirst(strings.TrimSpace(rest))
+		}
+	}
+	return upperFirst(title)
+}
+
+func (w *mediaWiki) namespaceOf(title string) (int, string) {
+	if prefix, rest, ok := strings.Cut(title, ":"); ok {
+		if ns, ok := w.nsByName[strings.ToLower(prefix)]; ok {
+			return ns, rest
+		}
+	}
+	return mwNamespaceMain, title
+}
+
+// displayTitle is the title of a page without its namespace
+func (w *mediaWiki) displayTitle(page *mwPage) string {
+	title := strings.ReplaceAll(page.Title, "_", " ")
+	if page.NS != mwNamespaceMain {
+		if _, rest, ok := strings.Cut(title, ":"); ok {
+			return strings.TrimSpace(rest)
+		}
+	}
+	return title
+}
+
+// resolve returns the key of the page a link points to, following redirects
+func (w *mediaWiki) resolve(title string) (string, bool) {
+	key := w.normalize(title)
+	for i := 0; i < 5; i++ {
+		if _, ok := w.pages[key]; ok {
+			return key, true
+		}
+		target, ok := w.redirects[key]
+		if !ok {
+			break
+		}
+		key = target
+	}
+	return "", false
+}
+
+func upperFirst(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+var (
+	mwCommentRegex   = regexp.MustCompile(`(?s)<!--.*?-->`)
+	mwProtectRegex   = regexp.MustCompile(`(?is)<(nowiki|pre|code|syntaxhighlight|source)(\s[^>]*)?>(.*?)</(?:nowiki|pre|code|syntaxhighlight|source)>`)
+	mwLangRegex      = regexp.MustCompile(`(?i)\blang\s*=\s*["']?([\w+#-]+)`)
+	mwRefRegex       = regexp.MustCompile(`(?is)<ref(\s[^>]*?)?(?:/>|>(.*?)</ref>)`)
+	mwRefNameRegex   = regexp.MustCompile(`(?i)\bname\s*=\s*["']?([^"'/>]+?)["']?\s*(?:/|$)`)
+	mwReferenceRegex = regexp.MustCompile(`(?i)<references\s*(/>|>.*?</references>)`)
+	mwMagicRegex     = regexp.MustCompile(`__[A-Z]+__`)
+	mwHeadingRegex   = regexp.MustCompile(`^(={1,6})\s*(.*?)\s*(={1,6})\s*$`)
+	mwListRegex      = regexp.MustCompile(`^([*#:;]+)\s*(.*)$`)
+	mwExtLinkRegex   = regexp.MustCompile(`\[((?:https?:|ftp:|mailto:|//)[^\s\]]+)(?:\s+([^\]]*))?\]`)
+	mwBoldItalic     = regexp.MustCompile(`'''''(.+?)'''''`)
+	mwBold           = regexp.MustCompile(`'''(.+?)'''`)
+	mwItalic         = regexp.MustCompile(`''(.+?)''`)
+	mwLinkTrailRegex = regexp.MustCompile(`^[a-z]+`)
+	mwPlaceholder    = regexp.MustCompile("\x00(\\d+)\x00")
+)
+
+// mwConverter converts the wikitext of a page
+type mwConverter struct {
+	wiki       *mediaWiki
+	protected  []string
+	categories []string
+	footnotes  []string
+	refNames   map[string]int
+}
+
+// convert returns the markdown of a page and the categories it belongs to
+func (w *mediaWiki) convert(text string) (string, []string) {
+	c := &mwConverter{wiki: w, refNames: make(map[string]int)}
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = mwCommentRegex.ReplaceAllString(text, "")
+	text = mwProtectRegex.ReplaceAllStringFunc(text, c.protect)
+	text = stripTemplates(text)
+	text = mwMagicRegex.ReplaceAllString(text, "")
+	text = mwReferenceRegex.ReplaceAllString(text, "")
+	text = mwRefRegex.ReplaceAllStringFunc(text, c.footnote)
+
+	md := c.convertBlocks(strings.Split(text, "\n"))
+	if len(c.footnotes) > 0 {
+		md += "\n\n" + strings.Join(c.footnotes, "\n")
+	}
+	md = mwPlaceholder.ReplaceAllStringFunc(md, func(match string) string {
+		i, _ := strconv.Atoi(strings.Trim(match, "\x00"))
+		return c.protected[i]
+	})
+	return strings.TrimSpace(md) + "\n", c.categories
+}
+
+// protect replaces the content that is not wikitext with a placeholder holding its markdown
+func (c *mwConverter) protect(match string) string {
+	m := mwProtectRegex.FindStringSubmatch(match)
+	tag, attrs, content := strings.ToLower(m[1]), m[2], m[3]
+	var md string
+	switch tag {
+	case "nowiki":
+		md = escapeMarkdown(content)
+	case "code":
+		fence := "`"
+		if strings.Contains(content, "`") {
+			fence = "`` "
+		}
+		md = fence + content + reverse(fence)
+	default:
+		lang := ""
+		if l := mwLangRegex.FindStringSubmatch(attrs); l != nil {
+			lang = strings.ToLower(l[1])
+		}
+		md = "```" + lang + "\n" + strings.Trim(content, "\n") + "\n```"
+	}
+	c.protected = append(c.protected, md)
+	placeholder := "\x00" + strconv.Itoa(len(c.protected)-1) + "\x00"
+	if tag == "nowiki" || tag == "code" {
+		return placeholder
+	}
+	// code blocks are kept on their own line
+	return "\n" + placeholder + "\n"
+}
+
+func reverse(s string) string {
+	runes := []rune(s)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
+
+// footnote turns <ref> into a markdown footnote, named references share their footnote
+func (c *mwConverter) footnote(match string) string {
+	m := mwRefRegex.FindStringSubmatch(match)
+	name := ""
+	if n := mwRefNameRegex.FindStringSubmatch(strings.TrimSpace(m[1])); n != nil {
+		name = strings.TrimSpace(n[1])
+	}
+	if i, ok := c.refNames[name]; ok && name != "" {
+		return fmt.Sprintf("[^%d]", i)
+	}
+	if strings.TrimSpace(m[2]) == "" {
+		return ""
+	}
+	i := len(c.footnotes) + 1
+	if name != "" {
+		c.refNames[name] = i
+	}
+	c.footnotes = append(c.footnotes, fmt.Sprintf("[^%d]: %s", i, c.inline(strings.Join(strings.Fields(m[2]), " "))))
+	return fmt.Sprintf("[^%d]", i)
+}
+
+// stripTemplates removes templates, parser functions and template parameters, which can be nested
+func stripTemplates(text string) string {
+	var b strings.Builder
+	depth := 0
+	for i := 0; i < len(text); i++ {
+		switch {
+		case strings.HasPrefix(text[i:], "{{"):
+			depth++
+			i++
+		case depth > 0 && strings.HasPrefix(text[i:], "}}"):
+			depth--
+			i++
+		case depth == 0:
+			b.WriteByte(text[i])
+		}
+	}
+	return b.String()
+}
+
+func (c *mwConverter) convertBlocks(lines []string) string {
+	blocks := make([]string, 0)
+	paragraph := make([]string, 0)
+	flush := func() {
+		if len(paragraph) > 0 {
+			blocks = append(blocks, strings.Join(paragraph, "\n"))
+			paragraph = paragraph[:0]
+		}
+	}
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			flush()
+		case strings.HasPrefix(trimmed, "{|"):
+			flush()
+			// end is the line closing the table, the end of the page for a table left open
+			end := i + 1
+			for depth := 1; end < len(lines); end++ {
+				t := strings.TrimSpace(lines[end])
+				if strings.HasPrefix(t, "{|") {
+					depth++
+				} else if strings.HasPrefix(t, "|}") {
+					if depth--; depth == 0 {
+						break
+					}
+				}
+			}
+			if table := c.convertTable(lines[i+1 : end]); table != "" {
+				blocks = append(blocks, table)
+			}
+			i = end
+		case mwHeadingRegex.MatchString(trimmed) && len(trimmed) > 2:
+			flush()
+			m := mwHeadingRegex.FindStringSubmatch(trimmed)
+			level := min(len(m[1]), len(m[3]))
+			blocks = append(blocks, strings.Repeat("#", level)+" "+c.inline(m[2]))
+		case strings.HasPrefix(trimmed, "----"):
+			flush()
+			blocks = append(blocks, "---")
+		case mwListRegex.MatchString(line):
+			flush()
+			// a list ends where its marker changes, markdown would otherwise continue it
+			items := make([]string, 0)
+			for ; i < len(lines) && mwListRegex.MatchString(lines[i]) && lines[i][0] == line[0]; i++ {
+				items = append(items, c.convertListItem(lines[i]))
+			}
+			i--
+			blocks = append(blocks, strings.Join(items, "\n"))
+		case strings.HasPrefix(line, " ") && !strings.HasPrefix(trimmed, "\x00"):
+			// lines indented with a space are preformatted
+			flush()
+			code := make([]string, 0)
+			for ; i < len(lines) && strings.HasPrefix(lines[i], " "); i++ {
+				code = append(code, lines[i][1:])
+			}
+			i--
+			blocks = append(blocks, "```\n"+strings.Join(code, "\n")+"\n```")
+		default:
+			if text := c.inline(trimmed); strings.TrimSpace(text) != "" {
+				paragraph = append(paragraph, escapeLineStart(text))
+			}
+		}
+	}
+	flush()
+	return strings.Join(blocks, "\n\n")
+}
+
+// convertListItem converts a line of a list: * bullets, # numbers, ; terms and : indented text
+func (c *mwConverter) convertListItem(line string) string {
+	m := mwListRegex.FindStringSubmatch(line)
+	prefix, text := m[1], c.inline(m[2])
+	indent := ""
+	for _, ch := range prefix[:len(prefix)-1] {
+		if ch == '#' {
+			indent += "   "
+		} else {
+			indent += "  "
+		}
+	}
+	switch prefix[len(prefix)-1] {
+	case '*':
+		return indent + "- " + text
+	case '#':
+		return indent + "1. " + text
+	case ';':
+		term, definition, ok := strings.Cut(text, " : ")
+		if ok {
+			return indent + "**" + strings.TrimSpace(term) + "**: " + strings.TrimSpace(definition)
+		}
+		return indent + "**" + text + "**"
+	default:
+		if len(prefix) == 1 || strings.Trim(prefix, ":") == "" {
+			return indent + "> " + text
+		}
+		return indent + "  " + text
+	}
+}
+
+// convertTable converts the rows of a table between {| and |}, cells spanning lines are joined with <br>
+func (c *mwConverter) convertTable(lines []string) string {
+	caption := ""
+	rows := make([][]string, 0)
+	var row []string
+	appendCells := func(text, separator string) {
+		for _, cell := range strings.Split(text, separator) {
+			// attributes of a cell come before a single |
+			if attrs, content, ok := strings.Cut(cell, "|"); ok && !strings.Contains(attrs, "[[") && !strings.HasPrefix(content, "|") {
+				cell = content
+			}
+			row = append(row, strings.TrimSpace(cell))
+		}
+	}
+	depth := 0
+	for _, line := range lines {
+		t := strings.TrimSpace(line)
+		switch {
+		case depth > 0 || strings.HasPrefix(t, "{|"):
+			// nested tables are flattened into the text of the cell
+			if strings.HasPrefix(t, "{|") {
+				depth++
+			} else if strings.HasPrefix(t, "|}") {
+				depth--
+			} else if len(row) > 0 && !strings.HasPrefix(t, "|-") {
+				row[len(row)-1] += "<br>" + strings.TrimLeft(t, "|! ")
+			}
+		case strings.HasPrefix(t, "|+"):
+			caption = strings.TrimSpace(t[2:])
+		case strings.HasPrefix(t, "|-"):
+			if len(row) > 0 {
+				rows = append(rows, row)
+			}
+			row = nil
+		case strings.HasPrefix(t, "!"):
+			appendCells(strings.ReplaceAll(t[1:], "||", "!!"), "!!")
+		case strings.HasPrefix(t, "|"):
+			appendCells(t[1:], "||")
+		case t != "" && len(row) > 0:
+			row[len(row)-1] += "<br>" + t
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+	columns := 0
+	for _, r := range rows {
+		columns = max(columns, len(r))
+	}
+	if columns == 0 {
+		return ""
+	}
+	var b strings.Builder
+	if caption != "" {
+		b.WriteString("**" + c.inline(caption) + "**\n\n")
+	}
+	for i, r := range rows {
+		cells := make([]string, columns)
+		for j := range r {
+			cells[j] = strings.ReplaceAll(c.inline(r[j]), "|", `\|`)
+		}
+		b.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+		if i == 0 {
+			b.WriteString("|" + strings.Repeat(" --- |", columns) + "\n")
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// inline converts the links and the formatting of a line
+func (c *mwConverter) inline(text string) string {
+	text = c.convertLinks(text)
+	text = mwExtLinkRegex.ReplaceAllStringFunc(text, func(match string) string {
+		m := mwExtLinkRegex.FindStringSubmatch(match)
+		link := m[1]
+		if strings.HasPrefix(link, "//") {
+			link = "https:" + link
+		}
+		if label := strings.TrimSpace(m[2]); label != "" {
+			return "[" + label + "](" + link + ")"
+		}
+		return "<" + link + ">"
+	})
+	text = mwBoldItalic.ReplaceAllString(text, "***$1***")
+	text = mwBold.ReplaceAllString(text, "**$1**")
+	return mwItalic.ReplaceAllString(text, "*$1*")
+}
+
+// convertLinks rewrites the [[internal links]] of a line, captions of files can contain links themselves
+func (c *mwConverter) convertLinks(text string) string {
+	var b strings.Builder
+	for {
+		start := strings.Index(text, "[[")
+		if start < 0 {
+			b.WriteString(text)
+			return b.String()
+		}
+		end, depth := -1, 0
+		for i := start; i < len(text)-1; i++ {
+			if strings.HasPrefix(text[i:], "[[") {
+				depth++
+				i++
+			} else if strings.HasPrefix(text[i:], "]]") {
+				if depth--; depth == 0 {
+					end = i
+					break
+				}
+				i++
+			}
+		}
+		if end < 0 {
+			b.WriteString(text)
+			return b.String()
+		}
+		b.WriteString(text[:start])
+		trail := mwLinkTrailRegex.FindString(text[end+2:])
+		b.WriteString(c.convertLink(text[start+2:end], trail))
+		text = text[end+2+len(trail):]
+	}
+}
+
+func (c *mwConverter) convertLink(inner, trail string) string {
+	parts := splitTopLevel(inner)
+	target := strings.TrimSpace(parts[0])
+	explicit := strings.HasPrefix(target, ":")
+	ns, _ := c.wiki.namespaceOf(c.wiki.normalize(target))
+
+	if !explicit && ns == mwNamespaceCategory {
+		_, category, _ := strings.Cut(c.wiki.normalize(target), ":")
+		c.categories = append(c.categories, category)
+		return ""
+	}
+	if !explicit && ns == 6 {
+		// file links and embeds keep their caption, the last parameter that is not an option
+		for i := len(parts) - 1; i > 0; i-- {
+			if caption := strings.TrimSpace(parts[i]); caption != "" && !isFileOption(caption) {
+				return c.convertLinks(caption)
+			}
+		}
+		return ""
+	}
+
+	title, anchor, _ := strings.Cut(target, "#")
+	label := strings.TrimPrefix(target, ":")
+	if len(parts) > 1 {
+		label = strings.TrimSpace(strings.Join(parts[1:], "|"))
+		if label == "" {
+			// the pipe trick hides the namespace
+			_, label = c.wiki.namespaceOf(strings.TrimPrefix(title, ":"))
+		}
+	}
+	label = c.convertLinks(label) + trail
+	if strings.TrimSpace(title) == "" {
+		return "[" + label + "](#" + strings.ReplaceAll(strings.TrimSpace(anchor), " ", "-") + ")"
+	}
+	key, ok := c.wiki.resolve(title)
+	if !ok {
+		return label
+	}
+	return "[" + label + "](" + DocRef(key, strings.TrimSpace(anchor)) + ")"
+}
+
+func splitTopLevel(inner string) []string {
+	parts := make([]string, 0)
+	depth, last := 0, 0
+	for i := 0; i < len(inner); i++ {
+		switch {
+		case strings.HasPrefix(inner[i:], "[["):
+			depth++
+			i++
+		case strings.HasPrefix(inner[i:], "]]"):
+			depth--
+			i++
+		case inner[i] == '|' && depth == 0:
+			parts = append(parts, inner[last:i])
+			last = i + 1
+		}
+	}
+	return append(parts, inner[last:])
+}
+
+var mwFileOptionRegex = regexp.MustCompile(`^(thumb|thumbnail|frame|frameless|border|left|right|center|centre|none|upright|baseline|middle|sub|super|top|text-top|bottom|text-bottom|\d*x?\d+px|(upright|alt|link|page|class|lang)\s*=.*)$`)
+
+func isFileOption(s string) bool {
+	return mwFileOptionRegex.MatchString(strings.ToLower(s))
+}
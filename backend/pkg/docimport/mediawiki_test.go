@@ -0,0 +1,134 @@
+package docimport
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const mediaWikiDump = `<mediawiki xmlns="http://www.mediawiki.org/xml/export-0.11/" version="0.11">
+  <siteinfo>
+    <sitename>Old Wiki</sitename>
+    <namespaces>
+      <namespace key="0" case="first-letter" />
+      <namespace key="12" case="first-letter">Help</namespace>
+      <namespace key="14" case="first-letter">Category</namespace>
+    </namespaces>
+  </siteinfo>
+  <page>
+    <title>Main Page</title>
+    <ns>0</ns>
+    <id>1</id>
+    <revision><timestamp>2020-01-01T00:00:00Z</timestamp><text xml:space="preserve">old text</text></revision>
+    <revision><timestamp>2021-01-01T00:00:00Z</timestamp><text xml:space="preserve">{{Infobox|a={{b}}}}
+== Intro ==
+'''Welcome''' to the [[install guide|guide]]s, see [[Help:Editing#Tables]] and [[Missing page]].
+* one
+** two
+# first
+&lt;pre&gt;[[not a link]]&lt;/pre&gt;
+See [https://example.com Example].&lt;ref&gt;A note&lt;/ref&gt;
+{| class="wikitable"
+! Name !! Value
+|-
+| style="color:red" | a || [[Setup]]
+|}
+[[Category:Guides]]</text></revision>
+  </page>
+  <page>
+    <title>Install guide</title>
+    <ns>0</ns>
+    <id>2</id>
+    <revision><timestamp>2021-01-01T00:00:00Z</timestamp><text xml:space="preserve">Install it. [[File:Shot.png|thumb|200px|A screenshot]]</text></revision>
+  </page>
+  <page>
+    <title>Setup</title>
+    <ns>0</ns>
+    <id>3</id>
+    <redirect title="Install guide" />
+    <revision><timestamp>2021-01-01T00:00:00Z</timestamp><text xml:space="preserve">#REDIRECT [[Install guide]]</text></revision>
+  </page>
+  <page>
+    <title>Help:Editing</title>
+    <ns>12</ns>
+    <id>4</id>
+    <revision><timestamp>2021-01-01T00:00:00Z</timestamp><text xml:space="preserve">=== Tables ===</text></revision>
+  </page>
+  <page>
+    <title>Template:Infobox</title>
+    <ns>10</ns>
+    <id>5</id>
+    <revision><timestamp>2021-01-01T00:00:00Z</timestamp><text xml:space="preserve">template</text></revision>
+  </page>
+</mediawiki>`
+
+func TestParseMediaWikiDump(t *testing.T) {
+	var compressed bytes.Buffer
+	zw := gzip.NewWriter(&compressed)
+	_, _ = zw.Write([]byte(mediaWikiDump))
+	_ = zw.Close()
+
+	result, err := ParseMediaWikiDump(&compressed, "")
+	require.NoError(t, err)
+	docs := make(map[string]*Doc)
+	for _, doc := range result.Docs {
+		docs[doc.Key] = doc
+	}
+	require.Len(t, docs, 3)
+	main := docs["Main Page"]
+	require.NotNil(t, main)
+	assert.Equal(t, []string{"Old Wiki", "Guides"}, main.Folders)
+	assert.Equal(t, "mediawiki:Old Wiki:1", main.ExternalID)
+	for _, want := range []string{
+		"## Intro",
+		"**Welcome** to the [guides](" + DocRef("Install guide", "") + ")",
+		"[Help:Editing#Tables](" + DocRef("Help:Editing", "Tables") + ")",
+		"and Missing page.",
+		"- one\n  - two\n\n1. first",
+		"```\n[[not a link]]\n```",
+		"[Example](https://example.com).[^1]",
+		"| Name | Value |\n| --- | --- |\n| a | [Setup](" + DocRef("Install guide", "") + ") |",
+		"[^1]: A note",
+	} {
+		assert.Contains(t, main.Content, want)
+	}
+	for _, unwanted := range []string{"old text", "Infobox", "Category"} {
+		assert.NotContains(t, main.Content, unwanted)
+	}
+	if doc := docs["Install guide"]; assert.NotNil(t, doc) {
+		assert.Equal(t, "Install it. A screenshot\n", doc.Content)
+	}
+	if doc := docs["Help:Editing"]; assert.NotNil(t, doc) {
+		assert.Equal(t, "Editing", doc.Name)
+		assert.Equal(t, []string{"Old Wiki", "Help"}, doc.Folders)
+	}
+}
+
+func TestParseMediaWikiDumpLatestRevision(t *testing.T) {
+	dump := `<mediawiki><page><title>Page</title><ns>0</ns><id>1</id>
+    <revision><id>3</id><timestamp>2022-01-01T00:00:00Z</timestamp><text>newest</text></revision>
+    <revision><id>1</id><timestamp>2020-01-01T00:00:00Z</timestamp><text>oldest</text></revision>
+    <revision><id>2</id><timestamp>2021-01-01T00:00:00Z</timestamp><contributor><id>7</id></contributor><text>older</text></revision>
+  </page></mediawiki>`
+	result, err := ParseMediaWikiDump(strings.NewReader(dump), "wiki")
+	require.NoError(t, err)
+	require.Len(t, result.Docs, 1)
+	assert.Equal(t, "newest\n", result.Docs[0].Content)
+	assert.Equal(t, "mediawiki:wiki:1", result.Docs[0].ExternalID)
+}
+
+func TestParseMediaWikiDumpTooManyPages(t *testing.T) {
+	var dump strings.Builder
+	dump.WriteString("<mediawiki>")
+	for i := 0; i <= MaxDocs; i++ {
+		fmt.Fprintf(&dump, "<page><title>Page %d</title><ns>0</ns><revision><text>text</text></revision></page>", i)
+	}
+	dump.WriteString("</mediawiki>")
+	_, err := ParseMediaWikiDump(strings.NewReader(dump.String()), "wiki")
+	assert.ErrorIs(t, err, ErrLimitExceeded)
+}
@@ -0,0 +1,488 @@
+package docimport
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/invopop/yaml"
+)
+
+// maxSchemaDepth bounds the nesting rendered for a schema, recursive schemas stop there as well
+const maxSchemaDepth = 5
+
+var openAPIMethods = []string{
+	http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete,
+	http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodConnect,
+}
+
+// ParseOpenAPI converts an OpenAPI 3 or Swagger 2 spec in JSON or YAML into a folder named after the api
+// with a folder per tag and a document per operation. the documents carry an external id derived from
+// name and the operation, so that importing the spec again updates the operations in place
+func ParseOpenAPI(data []byte, name string) (*Result, error) {
+	doc, err := loadOpenAPI(data)
+	if err != nil {
+		return nil, err
+	}
+	if name = strings.TrimSpace(name); name == "" && doc.Info != nil {
+		name = strings.TrimSpace(doc.Info.Title)
+	}
+	if name == "" {
+		name = "API"
+	}
+	externalPrefix := "openapi:" + name + ":"
+
+	result := NewResult()
+	if overview := renderOverview(doc); overview != "" {
+		result.Docs = append(result.Docs, &Doc{
+			Folders:    []string{name},
+			Name:       name,
+			Content:    overview,
+			ExternalID: externalPrefix,
+		})
+	}
+
+	// tags declared by the spec keep their order, the others follow in order of appearance
+	tagOrder := make([]string, 0)
+	tagSeen := make(map[string]bool)
+	addTag := func(tag string) {
+		if !tagSeen[tag] {
+			tagSeen[tag] = true
+			tagOrder = append(tagOrder, tag)
+		}
+	}
+	for _, tag := range doc.Tags {
+		addTag(tag.Name)
+	}
+	byTag := make(map[string][]*Doc)
+	paths := make([]string, 0, len(doc.Paths))
+	for p := range doc.Paths {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		item := doc.Paths[p]
+		operations := item.Operations()
+		for _, method := range openAPIMethods {
+			op, ok := operations[method]
+			if !ok {
+				continue
+			}
+			tag := "default"
+			if len(op.Tags) > 0 && strings.TrimSpace(op.Tags[0]) != "" {
+				tag = strings.TrimSpace(op.Tags[0])
+			}
+			addTag(tag)
+			opName := strings.TrimSpace(op.Summary)
+			if opName == "" {
+				opName = method + " " + p
+			}
+			opKey := op.OperationID
+			if opKey == "" {
+				opKey = method + " " + p
+			}
+			byTag[tag] = append(byTag[tag], &Doc{
+				Folders:    []string{name, tag},
+				Name:       opName,
+				Content:    renderOperation(method, p, item, op),
+				ExternalID: externalPrefix + opKey,
+			})
+		}
+	}
+	for _, tag := range tagOrder {
+		result.Docs = append(result.Docs, byTag[tag]...)
+	}
+	if len(result.Docs) == 0 {
+		return nil, ErrEmpty
+	}
+	if err := newBudget().addDocs(len(result.Docs)); err != nil {
+		return nil, err
+	}
+	for i, d := range result.Docs {
+		d.Key = fmt.Sprintf("%05d", i)
+	}
+	return result, nil
+}
+
+func loadOpenAPI(data []byte) (*openapi3.T, error) {
+	var version struct {
+		Swagger string `json:"swagger"`
+		OpenAPI string `json:"openapi"`
+	}
+	if err := yaml.Unmarshal(data, &version); err != nil {
+		return nil, fmt.Errorf("invalid spec: %w", err)
+	}
+	loader := openapi3.NewLoader()
+	switch {
+	case strings.HasPrefix(version.Swagger, "2"):
+		var doc2 openapi2.T
+		if err := yaml.Unmarshal(data, &doc2); err != nil {
+			return nil, fmt.Errorf("invalid swagger spec: %w", err)
+		}
+		doc, err := openapi2conv.ToV3(&doc2)
+		if err != nil {
+			return nil, fmt.Errorf("convert swagger spec failed: %w", err)
+		}
+		if err := loader.ResolveRefsIn(doc, nil); err != nil {
+			return nil, fmt.Errorf("resolve spec refs failed: %w", err)
+		}
+		return doc, nil
+	case strings.HasPrefix(version.OpenAPI, "3"):
+		doc, err := loader.LoadFromData(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid openapi spec: %w", err)
+		}
+		return doc, nil
+	default:
+		return nil, fmt.Errorf("not an OpenAPI 3 or Swagger 2 spec")
+	}
+}
+
+func renderOverview(doc *openapi3.T) string {
+	var b strings.Builder
+	if doc.Info != nil {
+		if doc.Info.Version != "" {
+			b.WriteString("Version: `" + doc.Info.Version + "`\n\n")
+		}
+		if doc.Info.Description != "" {
+			b.WriteString(strings.TrimSpace(doc.Info.Description) + "\n\n")
+		}
+	}
+	if len(doc.Servers) > 0 {
+		b.WriteString("## Servers\n\n")
+		for _, server := range doc.Servers {
+			line := "- `" + server.URL + "`"
+			if server.Description != "" {
+				line += " " + server.Description
+			}
+			b.WriteString(line + "\n")
+		}
+		b.WriteString("\n")
+	}
+	tags := make([]string, 0)
+	for _, tag := range doc.Tags {
+		if tag.Description != "" {
+			tags = append(tags, "- **"+escapeMarkdown(tag.Name)+"** "+oneLine(tag.Description))
+		}
+	}
+	if len(tags) > 0 {
+		b.WriteString("## Tags\n\n" + strings.Join(tags, "\n") + "\n")
+	}
+	if b.Len() == 0 {
+		return ""
+	}
+	return strings.TrimSpace(b.String()) + "\n"
+}
+
+func renderOperation(method, p string, item *openapi3.PathItem, op *openapi3.Operation) string {
+	var b strings.Builder
+	b.WriteString("`" + method + " " + p + "`\n\n")
+	if op.Deprecated {
+		b.WriteString("> **Deprecated**\n\n")
+	}
+	if op.Summary != "" && op.Description != "" {
+		b.WriteString(strings.TrimSpace(op.Description) + "\n\n")
+	} else if op.Description != "" || item.Description != "" {
+		b.WriteString(strings.TrimSpace(op.Description+"\n\n"+item.Description) + "\n\n")
+	}
+
+	// operation parameters override the path item parameters with the same name and location
+	params := make([]*openapi3.Parameter, 0)
+	seen := make(map[string]bool)
+	for _, refs := range []openapi3.Parameters{op.Parameters, item.Parameters} {
+		for _, ref := range refs {
+			if ref == nil || ref.Value == nil || seen[ref.Value.In+":"+ref.Value.Name] {
+				continue
+			}
+			seen[ref.Value.In+":"+ref.Value.Name] = true
+			params = append(params, ref.Value)
+		}
+	}
+	if len(params) > 0 {
+		b.WriteString("## Parameters\n\n| Name | In | Type | Required | Description |\n| --- | --- | --- | --- | --- |\n")
+		for _, param := range params {
+			schema := param.Schema
+			if schema == nil {
+				for _, mediaType := range sortedKeys(param.Content) {
+					schema = param.Content[mediaType].Schema
+					break
+				}
+			}
+			b.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s |\n",
+				tableCell(param.Name), param.In, tableCell(schemaType(schema)), yesNo(param.Required), tableCell(param.Description)))
+		}
+		b.WriteString("\n")
+	}
+
+	if op.RequestBody != nil && op.RequestBody.Value != nil {
+		body := op.RequestBody.Value
+		b.WriteString("## Request Body\n\n")
+		if body.Description != "" {
+			b.WriteString(strings.TrimSpace(body.Description) + "\n\n")
+		}
+		renderContent(&b, body.Content, "###")
+	}
+
+	if len(op.Responses) > 0 {
+		b.WriteString("## Responses\n\n")
+		for _, status := range sortedKeys(op.Responses) {
+			ref := op.Responses[status]
+			if ref == nil || ref.Value == nil {
+				continue
+			}
+			title := "### " + status
+			if ref.Value.Description != nil && *ref.Value.Description != "" {
+				title += " " + oneLine(*ref.Value.Description)
+			}
+			b.WriteString(title + "\n\n")
+			renderContent(&b, ref.Value.Content, "####")
+		}
+	}
+	return strings.TrimSpace(b.String()) + "\n"
+}
+
+func renderContent(b *strings.Builder, content openapi3.Content, heading string) {
+	for _, mediaType := range sortedKeys(content) {
+		media := content[mediaType]
+		if media == nil {
+			continue
+		}
+		b.WriteString(heading + " `" + mediaType + "`\n\n")
+		if media.Schema != nil && media.Schema.Value != nil {
+			rows := make([]string, 0)
+			schemaRows(media.Schema, "", nil, 0, map[string]bool{}, &rows)
+			if len(rows) > 0 {
+				b.WriteString("| Field | Type | Required | Description |\n| --- | --- | --- | --- |\n")
+				b.WriteString(strings.Join(rows, "\n") + "\n\n")
+			} else {
+				b.WriteString("Type: " + schemaType(media.Schema) + "\n\n")
+			}
+		}
+		if example, ok := mediaExample(media); ok {
+			if data, err := json.MarshalIndent(example, "", "  "); err == nil {
+				b.WriteString("```json\n" + string(data) + "\n```\n\n")
+			}
+		}
+	}
+}
+
+// schemaRows flattens the properties of an object schema into table rows, nested fields are named parent.child
+// and array items parent[]
+func schemaRows(ref *openapi3.SchemaRef, prefix string, required map[string]bool, depth int, visiting map[string]bool, rows *[]string) {
+	if ref == nil || ref.Value == nil || depth > maxSchemaDepth {
+		return
+	}
+	if ref.Ref != "" {
+		if visiting[ref.Ref] {
+			return
+		}
+		visiting[ref.Ref] = true
+		defer delete(visiting, ref.Ref)
+	}
+	schema := ref.Value
+	properties, requiredFields := mergedProperties(schema)
+	if len(properties) == 0 {
+		if schema.Items != nil && prefix == "" {
+			schemaRows(schema.Items, "[]", nil, depth+1, visiting, rows)
+		}
+		return
+	}
+	for _, field := range sortedKeys(properties) {
+		prop := properties[field]
+		if prop == nil || prop.Value == nil {
+			continue
+		}
+		fieldName := field
+		if prefix != "" {
+			fieldName = prefix + "." + field
+		}
+		*rows = append(*rows, fmt.Sprintf("| %s | %s | %s | %s |",
+			tableCell(fieldName), tableCell(schemaType(prop)), yesNo(requiredFields[field]), tableCell(schemaDescription(prop.Value))))
+		if prop.Value.Items != nil {
+			schemaRows(prop.Value.Items, fieldName+"[]", nil, depth+1, visiting, rows)
+		} else {
+			schemaRows(prop, fieldName, nil, depth+1, visiting, rows)
+		}
+	}
+}
+
+// mergedProperties returns the properties of the schema and of the schemas of its allOf
+func mergedProperties(schema *openapi3.Schema) (openapi3.Schemas, map[string]bool) {
+	properties := make(openapi3.Schemas)
+	required := make(map[string]bool)
+	for name, prop := range schema.Properties {
+		properties[name] = prop
+	}
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+	for _, part := range schema.AllOf {
+		if part == nil || part.Value == nil {
+			continue
+		}
+		partProperties, partRequired := mergedProperties(part.Value)
+		for name, prop := range partProperties {
+			properties[name] = prop
+		}
+		for name := range partRequired {
+			required[name] = true
+		}
+	}
+	return properties, required
+}
+
+func schemaType(ref *openapi3.SchemaRef) string {
+	if ref == nil || ref.Value == nil {
+		return ""
+	}
+	schema := ref.Value
+	name := ""
+	if ref.Ref != "" {
+		name = ref.Ref[strings.LastIndex(ref.Ref, "/")+1:]
+	}
+	t := schema.Type
+	switch {
+	case t == "array" && schema.Items != nil:
+		t = schemaType(schema.Items) + "[]"
+	case len(schema.OneOf) > 0 || len(schema.AnyOf) > 0:
+		variants := make([]string, 0)
+		for _, variant := range append(append(openapi3.SchemaRefs{}, schema.OneOf...), schema.AnyOf...) {
+			variants = append(variants, schemaType(variant))
+		}
+		t = strings.Join(variants, " \\| ")
+	case t == "" && (len(schema.Properties) > 0 || len(schema.AllOf) > 0):
+		t = "object"
+	}
+	if name != "" && (t == "object" || t == "") {
+		t = name
+	}
+	if schema.Format != "" {
+		t += " (" + schema.Format + ")"
+	}
+	if schema.Nullable {
+		t += ", nullable"
+	}
+	return t
+}
+
+func schemaDescription(schema *openapi3.Schema) string {
+	description := oneLine(schema.Description)
+	if len(schema.Enum) > 0 {
+		values := make([]string, 0, len(schema.Enum))
+		for _, value := range schema.Enum {
+			values = append(values, fmt.Sprintf("`%v`", value))
+		}
+		description = strings.TrimSpace(description + " Enum: " + strings.Join(values, ", "))
+	}
+	if schema.Default != nil {
+		description = strings.TrimSpace(description + fmt.Sprintf(" Default: `%v`", schema.Default))
+	}
+	return description
+}
+
+// mediaExample returns the example of the media type, the first named example or an example generated from the schema
+func mediaExample(media *openapi3.MediaType) (any, bool) {
+	if media.Example != nil {
+		return media.Example, true
+	}
+	for _, name := range sortedKeys(media.Examples) {
+		if example := media.Examples[name]; example != nil && example.Value != nil && example.Value.Value != nil {
+			return example.Value.Value, true
+		}
+	}
+	if media.Schema == nil {
+		return nil, false
+	}
+	example := schemaExample(media.Schema, 0, map[string]bool{})
+	return example, example != nil
+}
+
+func schemaExample(ref *openapi3.SchemaRef, depth int, visiting map[string]bool) any {
+	if ref == nil || ref.Value == nil || depth > maxSchemaDepth {
+		return nil
+	}
+	if ref.Ref != "" {
+		if visiting[ref.Ref] {
+			return nil
+		}
+		visiting[ref.Ref] = true
+		defer delete(visiting, ref.Ref)
+	}
+	schema := ref.Value
+	switch {
+	case schema.Example != nil:
+		return schema.Example
+	case schema.Default != nil:
+		return schema.Default
+	case len(schema.Enum) > 0:
+		return schema.Enum[0]
+	case len(schema.OneOf) > 0:
+		return schemaExample(schema.OneOf[0], depth+1, visiting)
+	case len(schema.AnyOf) > 0:
+		return schemaExample(schema.AnyOf[0], depth+1, visiting)
+	}
+	switch schema.Type {
+	case "array":
+		if item := schemaExample(schema.Items, depth+1, visiting); item != nil {
+			return []any{item}
+		}
+		return []any{}
+	case "string":
+		switch schema.Format {
+		case "date-time":
+			return "2024-01-01T00:00:00Z"
+		case "date":
+			return "2024-01-01"
+		case "uuid":
+			return "00000000-0000-0000-0000-000000000000"
+		}
+		return "string"
+	case "integer", "number":
+		return 0
+	case "boolean":
+		return true
+	}
+	properties, _ := mergedProperties(schema)
+	if len(properties) == 0 {
+		if schema.Type == "object" {
+			return map[string]any{}
+		}
+		return nil
+	}
+	object := make(map[string]any, len(properties))
+	for name, prop := range properties {
+		if value := schemaExample(prop, depth+1, visiting); value != nil {
+			object[name] = value
+		}
+	}
+	return object
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func oneLine(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+func tableCell(text string) string {
+	return strings.ReplaceAll(oneLine(text), "|", `\|`)
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "Yes"
+	}
+	return "No"
+}
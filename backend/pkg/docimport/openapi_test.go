@@ -0,0 +1,111 @@
+package docimport
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const petstoreV3 = `openapi: 3.0.0
+info:
+  title: Petstore
+  version: 1.0.0
+tags:
+  - name: pets
+    description: Pet operations
+paths:
+  /pets/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: integer
+          format: int64
+    get:
+      tags: [pets]
+      summary: Get a pet
+      operationId: getPet
+      responses:
+        "200":
+          description: the pet
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+  /health:
+    get:
+      responses:
+        "204":
+          description: healthy
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+          example: rex
+        parent:
+          $ref: '#/components/schemas/Pet'
+`
+
+func TestParseOpenAPIV3(t *testing.T) {
+	result, err := ParseOpenAPI([]byte(petstoreV3), "")
+	require.NoError(t, err)
+	require.Len(t, result.Docs, 3, "expected overview and 2 operations")
+	overview, pet, health := result.Docs[0], result.Docs[1], result.Docs[2]
+	assert.Equal(t, "openapi:Petstore:", overview.ExternalID)
+	assert.Contains(t, overview.Content, "Pet operations")
+
+	assert.Equal(t, "Get a pet", pet.Name)
+	assert.Equal(t, "openapi:Petstore:getPet", pet.ExternalID)
+	assert.Equal(t, []string{"Petstore", "pets"}, pet.Folders)
+	for _, want := range []string{"`GET /pets/{id}`", "| id | path | integer (int64) | Yes |", "| name | string | Yes |", `"name": "rex"`} {
+		assert.Contains(t, pet.Content, want)
+	}
+
+	assert.Equal(t, "GET /health", health.Name)
+	assert.Equal(t, "openapi:Petstore:GET /health", health.ExternalID)
+	require.Len(t, health.Folders, 2)
+	assert.Equal(t, "default", health.Folders[1])
+
+	again, err := ParseOpenAPI([]byte(petstoreV3), "")
+	require.NoError(t, err)
+	require.Len(t, again.Docs, len(result.Docs))
+	for i := range again.Docs {
+		assert.Equal(t, result.Docs[i].Content, again.Docs[i].Content, "content of %s is not deterministic", again.Docs[i].Name)
+	}
+}
+
+func TestParseOpenAPISwagger2(t *testing.T) {
+	spec := `{
+  "swagger": "2.0",
+  "info": {"title": "Users", "version": "1"},
+  "paths": {
+    "/users": {
+      "post": {
+        "operationId": "createUser",
+        "consumes": ["application/json"],
+        "parameters": [{"name": "body", "in": "body", "required": true, "schema": {"$ref": "#/definitions/User"}}],
+        "responses": {"201": {"description": "created"}}
+      }
+    }
+  },
+  "definitions": {"User": {"type": "object", "properties": {"email": {"type": "string"}}}}
+}`
+	result, err := ParseOpenAPI([]byte(spec), "User API")
+	require.NoError(t, err)
+	require.Len(t, result.Docs, 2, "expected overview and 1 operation")
+	doc := result.Docs[1]
+	assert.Equal(t, "openapi:User API:createUser", doc.ExternalID)
+	assert.Contains(t, doc.Content, "## Request Body")
+	assert.Contains(t, doc.Content, "| email | string | No |")
+}
+
+func TestParseOpenAPIInvalid(t *testing.T) {
+	_, err := ParseOpenAPI([]byte("name: not a spec"), "")
+	assert.Error(t, err)
+}
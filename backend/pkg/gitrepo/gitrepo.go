@@ -0,0 +1,155 @@
+// Package gitrepo reads git repositories through the git command line
+package gitrepo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// protocols allowed when fetching, ext:: and file:// are left out on purpose
+const allowedProtocols = "http:https:ssh:git"
+
+type ChangeType string
+
+const (
+	ChangeAdded    ChangeType = "A"
+	ChangeModified ChangeType = "M"
+	ChangeDeleted  ChangeType = "D"
+)
+
+// Change is a file changed between two commits, renames are reported as a deletion and an addition
+type Change struct {
+	Type ChangeType
+	Path string
+}
+
+// Repo is a local repository, either a work tree or a bare repository
+type Repo struct {
+	dir string
+}
+
+// Open returns the repository at dir
+func Open(ctx context.Context, dir string) (*Repo, error) {
+	r := &Repo{dir: dir}
+	if _, err := r.run(ctx, "rev-parse", "--git-dir"); err != nil {
+		return nil, fmt.Errorf("%s is not a git repository: %w", dir, err)
+	}
+	return r, nil
+}
+
+// Mirror fetches the branch of a remote repository into a bare repository at dir, creating it if needed
+func Mirror(ctx context.Context, url, branch, dir string) (*Repo, error) {
+	if err := ValidateRef(branch); err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(url, "-") {
+		return nil, fmt.Errorf("invalid repository url %s", url)
+	}
+	r := &Repo{dir: dir}
+	if _, err := os.Stat(filepath.Join(dir, "HEAD")); errors.Is(err, os.ErrNotExist) {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, err
+		}
+		if _, err := r.run(ctx, "init", "--bare", "--quiet"); err != nil {
+			return nil, err
+		}
+	}
+	refspec := fmt.Sprintf("+refs/heads/%s:refs/heads/%s", branch, branch)
+	if _, err := r.run(ctx, "fetch", "--quiet", "--no-tags", "--prune", "--", url, refspec); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// ValidateRef rejects refs that could be taken as options or escape refs/heads
+func ValidateRef(ref string) error {
+	if ref == "" || strings.HasPrefix(ref, "-") || strings.Contains(ref, "..") || strings.ContainsAny(ref, " ~^:?*[\\") {
+		return fmt.Errorf("invalid branch %q", ref)
+	}
+	return nil
+}
+
+// ResolveCommit returns the commit the branch points to
+func (r *Repo) ResolveCommit(ctx context.Context, branch string) (string, error) {
+	if err := ValidateRef(branch); err != nil {
+		return "", err
+	}
+	out, err := r.run(ctx, "rev-parse", "--verify", "--quiet", "refs/heads/"+branch+"^{commit}")
+	if err != nil {
+		return "", fmt.Errorf("branch %s not found: %w", branch, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// HasCommit reports whether the commit exists in the repository, history may have been rewritten since
+func (r *Repo) HasCommit(ctx context.Context, commit string) bool {
+	_, err := r.run(ctx, "cat-file", "-e", commit+"^{commit}")
+	return err == nil
+}
+
+// ListFiles returns the paths of all files in the commit
+func (r *Repo) ListFiles(ctx context.Context, commit string) ([]string, error) {
+	out, err := r.run(ctx, "ls-tree", "-r", "-z", "--name-only", commit)
+	if err != nil {
+		return nil, err
+	}
+	return splitNUL(out), nil
+}
+
+// Diff returns the files changed between two commits
+func (r *Repo) Diff(ctx context.Context, from, to string) ([]Change, error) {
+	out, err := r.run(ctx, "diff", "--name-status", "--no-renames", "-z", from, to)
+	if err != nil {
+		return nil, err
+	}
+	fields := splitNUL(out)
+	changes := make([]Change, 0, len(fields)/2)
+	for i := 0; i+1 < len(fields); i += 2 {
+		changeType := ChangeModified
+		switch fields[i][0] {
+		case 'A':
+			changeType = ChangeAdded
+		case 'D':
+			changeType = ChangeDeleted
+		}
+		changes = append(changes, Change{Type: changeType, Path: fields[i+1]})
+	}
+	return changes, nil
+}
+
+// ReadFile returns the content of the file in the commit
+func (r *Repo) ReadFile(ctx context.Context, commit, path string) ([]byte, error) {
+	return r.run(ctx, "cat-file", "blob", commit+":"+path)
+}
+
+func (r *Repo) run(ctx context.Context, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", r.dir}, args...)...)
+	cmd.Env = append(os.Environ(),
+		"GIT_TERMINAL_PROMPT=0",
+		"GIT_ALLOW_PROTOCOL="+allowedProtocols,
+	)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return nil, fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return stdout.Bytes(), nil
+}
+
+func splitNUL(out []byte) []string {
+	fields := strings.Split(string(out), "\x00")
+	if len(fields) > 0 && fields[len(fields)-1] == "" {
+		fields = fields[:len(fields)-1]
+	}
+	return fields
+}
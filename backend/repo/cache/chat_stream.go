@@ -0,0 +1,117 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/store/cache"
+)
+
+const (
+	chatStreamKeyPrefix = "chat_stream_"
+	chatStreamTTL       = 10 * time.Minute
+	chatStopChannel     = "chat_stop"
+)
+
+// ChatStreamRepo buffers chat SSE events in redis streams so that clients can resume
+// an answer after reconnecting, and broadcasts stop signals to every api instance.
+type ChatStreamRepo struct {
+	cache *cache.Cache
+}
+
+func NewChatStreamRepo(cache *cache.Cache) *ChatStreamRepo {
+	return &ChatStreamRepo{cache: cache}
+}
+
+func (r *ChatStreamRepo) key(streamID string) string {
+	return chatStreamKeyPrefix + streamID
+}
+
+// Append adds the event to the stream log and returns its event id
+func (r *ChatStreamRepo) Append(ctx context.Context, streamID string, event domain.SSEEvent) (string, error) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return "", err
+	}
+	key := r.key(streamID)
+	pipe := r.cache.TxPipeline()
+	idCmd := pipe.XAdd(ctx, &redis.XAddArgs{
+		Stream: key,
+		Values: map[string]any{"event": data},
+	})
+	pipe.Expire(ctx, key, chatStreamTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return "", err
+	}
+	return idCmd.Val(), nil
+}
+
+// Read returns the events after lastEventID, waiting up to block for new ones
+func (r *ChatStreamRepo) Read(ctx context.Context, streamID, lastEventID string, block time.Duration) ([]domain.SSEEvent, error) {
+	if lastEventID == "" {
+		lastEventID = "0"
+	}
+	streams, err := r.cache.XRead(ctx, &redis.XReadArgs{
+		Streams: []string{r.key(streamID), lastEventID},
+		Count:   100,
+		Block:   block,
+	}).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	events := make([]domain.SSEEvent, 0)
+	for _, stream := range streams {
+		for _, message := range stream.Messages {
+			data, ok := message.Values["event"].(string)
+			if !ok {
+				continue
+			}
+			var event domain.SSEEvent
+			if err := json.Unmarshal([]byte(data), &event); err != nil {
+				return nil, err
+			}
+			event.ID = message.ID
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func (r *ChatStreamRepo) Exists(ctx context.Context, streamID string) (bool, error) {
+	count, err := r.cache.Exists(ctx, r.key(streamID)).Result()
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// PublishStop asks the instance generating streamID to stop
+func (r *ChatStreamRepo) PublishStop(ctx context.Context, streamID string) error {
+	return r.cache.Publish(ctx, chatStopChannel, streamID).Err()
+}
+
+// SubscribeStop calls onStop for every stop signal until ctx is done
+func (r *ChatStreamRepo) SubscribeStop(ctx context.Context, onStop func(streamID string)) {
+	sub := r.cache.Subscribe(ctx, chatStopChannel)
+	defer sub.Close()
+	ch := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			onStop(msg.Payload)
+		}
+	}
+}
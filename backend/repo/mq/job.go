@@ -0,0 +1,82 @@
+package mq
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/mq"
+)
+
+// JobRepository publishes long running jobs executed by the consumer
+type JobRepository struct {
+	producer mq.MQProducer
+}
+
+func NewJobRepository(producer mq.MQProducer) *JobRepository {
+	return &JobRepository{producer: producer}
+}
+
+func (r *JobRepository) AsyncRunEval(ctx context.Context, request *domain.EvalTaskRequest) error {
+	requestBytes, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	return r.producer.Produce(ctx, domain.EvalTaskTopic, "", requestBytes)
+}
+
+func (r *JobRepository) AsyncTranslateNodes(ctx context.Context, request *domain.NodeTranslateTaskRequest) error {
+	requestBytes, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	return r.producer.Produce(ctx, domain.TranslateTaskTopic, "", requestBytes)
+}
+
+func (r *JobRepository) AsyncRunSummaryJob(ctx context.Context, request *domain.SummaryTaskRequest) error {
+	requestBytes, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	return r.producer.Produce(ctx, domain.SummaryTaskTopic, "", requestBytes)
+}
+
+func (r *JobRepository) AsyncSyncGitSource(ctx context.Context, request *domain.GitSyncTaskRequest) error {
+	requestBytes, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	return r.producer.Produce(ctx, domain.GitSyncTaskTopic, "", requestBytes)
+}
+
+func (r *JobRepository) AsyncSyncCrawlerSubscription(ctx context.Context, request *domain.CrawlerSyncTaskRequest) error {
+	requestBytes, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	return r.producer.Produce(ctx, domain.CrawlerSyncTaskTopic, "", requestBytes)
+}
+
+func (r *JobRepository) AsyncRunImportJob(ctx context.Context, request *domain.ImportTaskRequest) error {
+	requestBytes, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	return r.producer.Produce(ctx, domain.ImportTaskTopic, "", requestBytes)
+}
+
+func (r *JobRepository) AsyncRunLinkCheckJob(ctx context.Context, request *domain.LinkCheckTaskRequest) error {
+	requestBytes, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	return r.producer.Produce(ctx, domain.LinkCheckTaskTopic, "", requestBytes)
+}
+
+func (r *JobRepository) AsyncRunNodeImportJob(ctx context.Context, request *domain.NodeImportTaskRequest) error {
+	requestBytes, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	return r.producer.Produce(ctx, domain.NodeImportTaskTopic, "", requestBytes)
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/cloudwego/eino/schema"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/chaitin/panda-wiki/domain"
 	"github.com/chaitin/panda-wiki/log"
@@ -37,6 +38,15 @@ func (r *ConversationRepository) CreateConversation(ctx context.Context, convers
 	return r.db.WithContext(ctx).Create(conversation).Error
 }
 
+// CreateConversationIfNotExists creates the conversation unless its id exists, reports whether it was created
+func (r *ConversationRepository) CreateConversationIfNotExists(ctx context.Context, conversation *domain.Conversation) (bool, error) {
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(conversation)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
 func (r *ConversationRepository) GetConversationList(ctx context.Context, request *domain.ConversationListReq) ([]*domain.ConversationListItem, uint64, error) {
 	conversations := []*domain.ConversationListItem{}
 	query := r.db.WithContext(ctx).
@@ -106,11 +116,57 @@ func (r *ConversationRepository) GetConversationMessagesByID(ctx context.Context
 	return messages, nil
 }
 
-func (r *ConversationRepository) ValidateConversationNonce(ctx context.Context, conversationID, nonce string) error {
+func (r *ConversationRepository) GetConversationByID(ctx context.Context, conversationID string) (*domain.Conversation, error) {
+	conversation := &domain.Conversation{}
+	if err := r.db.WithContext(ctx).
+		Model(&domain.Conversation{}).
+		Where("id = ?", conversationID).
+		First(conversation).Error; err != nil {
+		return nil, err
+	}
+	return conversation, nil
+}
+
+func (r *ConversationRepository) UpdateConversationSummary(ctx context.Context, conversationID, summary, summaryMessageID string) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.Conversation{}).
+		Where("id = ?", conversationID).
+		Updates(map[string]any{
+			"summary":            summary,
+			"summary_message_id": summaryMessageID,
+		}).Error
+}
+
+func (r *ConversationRepository) GetConversationMessage(ctx context.Context, conversationID, messageID string) (*domain.ConversationMessage, error) {
+	message := &domain.ConversationMessage{}
+	if err := r.db.WithContext(ctx).
+		Model(&domain.ConversationMessage{}).
+		Where("conversation_id = ?", conversationID).
+		Where("id = ?", messageID).
+		First(message).Error; err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+func (r *ConversationRepository) GetLatestConversationMessage(ctx context.Context, conversationID string) (*domain.ConversationMessage, error) {
+	message := &domain.ConversationMessage{}
+	if err := r.db.WithContext(ctx).
+		Model(&domain.ConversationMessage{}).
+		Where("conversation_id = ?", conversationID).
+		Order("created_at desc").
+		First(message).Error; err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+func (r *ConversationRepository) ValidateConversationNonce(ctx context.Context, kbID, conversationID, nonce string) error {
 	conversation := &domain.Conversation{}
 	if err := r.db.WithContext(ctx).
 		Model(&domain.Conversation{}).
 		Where("id = ?", conversationID).
+		Where("kb_id = ?", kbID).
 		Where("nonce = ?", nonce).
 		First(&conversation).Error; err != nil {
 		return err
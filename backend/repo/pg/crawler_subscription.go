@@ -0,0 +1,214 @@
+package pg
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/store/pg"
+)
+
+type CrawlerSubscriptionRepository struct {
+	db     *pg.DB
+	logger *log.Logger
+}
+
+func NewCrawlerSubscriptionRepository(db *pg.DB, logger *log.Logger) *CrawlerSubscriptionRepository {
+	return &CrawlerSubscriptionRepository{db: db, logger: logger.WithModule("repo.pg.crawler_subscription")}
+}
+
+func (r *CrawlerSubscriptionRepository) Create(ctx context.Context, subscription *domain.CrawlerSubscription) error {
+	return r.db.WithContext(ctx).Create(subscription).Error
+}
+
+func (r *CrawlerSubscriptionRepository) GetByID(ctx context.Context, kbID, id string) (*domain.CrawlerSubscription, error) {
+	var subscription domain.CrawlerSubscription
+	query := r.db.WithContext(ctx).Model(&domain.CrawlerSubscription{}).Where("id = ?", id)
+	if kbID != "" {
+		query = query.Where("kb_id = ?", kbID)
+	}
+	if err := query.First(&subscription).Error; err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (r *CrawlerSubscriptionRepository) GetList(ctx context.Context, kbID string) ([]*domain.CrawlerSubscription, error) {
+	subscriptions := make([]*domain.CrawlerSubscription, 0)
+	if err := r.db.WithContext(ctx).
+		Model(&domain.CrawlerSubscription{}).
+		Where("kb_id = ?", kbID).
+		Order("created_at ASC").
+		Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+func (r *CrawlerSubscriptionRepository) Update(ctx context.Context, subscription *domain.CrawlerSubscription) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.CrawlerSubscription{}).
+		Where("kb_id = ? AND id = ?", subscription.KBID, subscription.ID).
+		Updates(map[string]any{
+			"policy":         subscription.Policy,
+			"delete_removed": subscription.DeleteRemoved,
+			"interval_hours": subscription.IntervalHours,
+			"enabled":        subscription.Enabled,
+			"next_sync_at":   subscription.NextSyncAt,
+			"updated_at":     subscription.UpdatedAt,
+		}).Error
+}
+
+// Delete deletes the subscription, the sources of its nodes are deleted with it
+func (r *CrawlerSubscriptionRepository) Delete(ctx context.Context, kbID, id string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("kb_id = ? AND id = ?", kbID, id).Delete(&domain.CrawlerSubscription{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Where("subscription_id = ?", id).Delete(&domain.NodeSource{}).Error
+	})
+}
+
+// queueableStatus matches subscriptions that are not syncing, or whose sync was lost
+func queueableStatus(query *gorm.DB, now time.Time) *gorm.DB {
+	return query.Where("(status NOT IN ? OR started_at < ?)",
+		[]domain.CrawlerSyncStatus{domain.CrawlerSyncStatusPending, domain.CrawlerSyncStatusRunning},
+		now.Add(-domain.CrawlerSyncStaleAfter))
+}
+
+// Queue marks the subscription pending unless a sync is already queued or running, false if it is
+func (r *CrawlerSubscriptionRepository) Queue(ctx context.Context, kbID, id string) (bool, error) {
+	now := time.Now()
+	query := r.db.WithContext(ctx).
+		Model(&domain.CrawlerSubscription{}).
+		Where("kb_id = ? AND id = ?", kbID, id)
+	result := queueableStatus(query, now).
+		Updates(map[string]any{
+			"status":     domain.CrawlerSyncStatusPending,
+			"started_at": now,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// QueueDue marks the enabled subscriptions whose next sync is due as pending and returns their ids
+func (r *CrawlerSubscriptionRepository) QueueDue(ctx context.Context, now time.Time) ([]string, error) {
+	subscriptions := make([]*domain.CrawlerSubscription, 0)
+	query := r.db.WithContext(ctx).
+		Model(&subscriptions).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}}}).
+		Where("enabled AND next_sync_at <= ?", now)
+	if err := queueableStatus(query, now).
+		Updates(map[string]any{
+			"status":     domain.CrawlerSyncStatusPending,
+			"started_at": now,
+		}).Error; err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		ids = append(ids, subscription.ID)
+	}
+	return ids, nil
+}
+
+// Start marks a pending subscription as running, false when the sync was already picked up
+func (r *CrawlerSubscriptionRepository) Start(ctx context.Context, id string) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&domain.CrawlerSubscription{}).
+		Where("id = ? AND status = ?", id, domain.CrawlerSyncStatusPending).
+		Updates(map[string]any{
+			"status":     domain.CrawlerSyncStatusRunning,
+			"error":      "",
+			"started_at": time.Now(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Finish records the outcome of a sync and schedules the next one, the validators of the feed
+// are only moved forward by successful syncs
+func (r *CrawlerSubscriptionRepository) Finish(ctx context.Context, subscription *domain.CrawlerSubscription) error {
+	updates := map[string]any{
+		"status":       subscription.Status,
+		"error":        subscription.Error,
+		"result":       subscription.Result,
+		"synced_at":    subscription.SyncedAt,
+		"next_sync_at": subscription.NextSyncAt,
+	}
+	if subscription.Status == domain.CrawlerSyncStatusCompleted {
+		updates["etag"] = subscription.ETag
+		updates["last_modified"] = subscription.LastModified
+		updates["content_hash"] = subscription.ContentHash
+	}
+	return r.db.WithContext(ctx).
+		Model(&domain.CrawlerSubscription{}).
+		Where("id = ?", subscription.ID).
+		Updates(updates).Error
+}
+
+// GetNodeSources returns the sources of the nodes of the subscription by item id
+func (r *CrawlerSubscriptionRepository) GetNodeSources(ctx context.Context, subscriptionID string) (map[string]*domain.NodeSource, error) {
+	sources, err := r.GetNodeSourceList(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	sourceMap := make(map[string]*domain.NodeSource, len(sources))
+	for _, source := range sources {
+		sourceMap[source.DocID] = source
+	}
+	return sourceMap, nil
+}
+
+func (r *CrawlerSubscriptionRepository) GetNodeSourceList(ctx context.Context, subscriptionID string) ([]*domain.NodeSource, error) {
+	sources := make([]*domain.NodeSource, 0)
+	if err := r.db.WithContext(ctx).
+		Model(&domain.NodeSource{}).
+		Where("subscription_id = ?", subscriptionID).
+		Order("synced_at DESC").
+		Find(&sources).Error; err != nil {
+		return nil, err
+	}
+	return sources, nil
+}
+
+func (r *CrawlerSubscriptionRepository) SaveNodeSource(ctx context.Context, source *domain.NodeSource) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "node_id"}},
+			UpdateAll: true,
+		}).
+		Create(source).Error
+}
+
+func (r *CrawlerSubscriptionRepository) DeleteNodeSources(ctx context.Context, nodeIDs []string) error {
+	if len(nodeIDs) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Where("node_id IN ?", nodeIDs).
+		Delete(&domain.NodeSource{}).Error
+}
+
+// MarkNodeSourcesRemoved flags the sources of items the source does not list any more
+func (r *CrawlerSubscriptionRepository) MarkNodeSourcesRemoved(ctx context.Context, nodeIDs []string) error {
+	if len(nodeIDs) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Model(&domain.NodeSource{}).
+		Where("node_id IN ?", nodeIDs).
+		Updates(map[string]any{"removed": true, "synced_at": time.Now()}).Error
+}
@@ -0,0 +1,122 @@
+package pg
+
+import (
+	"context"
+	"time"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/store/pg"
+)
+
+type CuratedAnswerRepository struct {
+	db     *pg.DB
+	logger *log.Logger
+}
+
+func NewCuratedAnswerRepository(db *pg.DB, logger *log.Logger) *CuratedAnswerRepository {
+	return &CuratedAnswerRepository{db: db, logger: logger.WithModule("repo.pg.curated_answer")}
+}
+
+func (r *CuratedAnswerRepository) Create(ctx context.Context, answer *domain.CuratedAnswer) error {
+	return r.db.WithContext(ctx).Create(answer).Error
+}
+
+func (r *CuratedAnswerRepository) GetByID(ctx context.Context, kbID, id string) (*domain.CuratedAnswer, error) {
+	var answer domain.CuratedAnswer
+	if err := r.db.WithContext(ctx).
+		Model(&domain.CuratedAnswer{}).
+		Where("kb_id = ? AND id = ?", kbID, id).
+		First(&answer).Error; err != nil {
+		return nil, err
+	}
+	return &answer, nil
+}
+
+func (r *CuratedAnswerRepository) List(ctx context.Context, req *domain.CuratedAnswerListReq) ([]*domain.CuratedAnswer, int64, error) {
+	answers := make([]*domain.CuratedAnswer, 0)
+	query := r.db.WithContext(ctx).Model(&domain.CuratedAnswer{}).Where("kb_id = ?", req.KBID)
+	if req.Keyword != "" {
+		keyword := "%" + req.Keyword + "%"
+		query = query.Where("question LIKE ? OR answer LIKE ?", keyword, keyword)
+	}
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := query.Order("updated_at DESC").
+		Offset(req.Offset()).
+		Limit(req.Limit()).
+		Find(&answers).Error; err != nil {
+		return nil, 0, err
+	}
+	return answers, count, nil
+}
+
+// GetEmbedded returns the curated answers of the kb whose question was embedded by model
+func (r *CuratedAnswerRepository) GetEmbedded(ctx context.Context, kbID, model string) ([]*domain.CuratedAnswer, error) {
+	answers := make([]*domain.CuratedAnswer, 0)
+	if err := r.db.WithContext(ctx).
+		Model(&domain.CuratedAnswer{}).
+		Where("kb_id = ? AND embedding_model = ? AND cardinality(embedding) > 0", kbID, model).
+		Find(&answers).Error; err != nil {
+		return nil, err
+	}
+	return answers, nil
+}
+
+// GetStale returns up to limit curated answers of any kb that were not embedded by model, least recently updated first
+func (r *CuratedAnswerRepository) GetStale(ctx context.Context, model string, limit int) ([]*domain.CuratedAnswer, error) {
+	answers := make([]*domain.CuratedAnswer, 0)
+	if err := r.db.WithContext(ctx).
+		Model(&domain.CuratedAnswer{}).
+		Where("embedding_model <> ? OR embedding IS NULL OR cardinality(embedding) = 0", model).
+		Order("updated_at ASC").
+		Limit(limit).
+		Find(&answers).Error; err != nil {
+		return nil, err
+	}
+	return answers, nil
+}
+
+func (r *CuratedAnswerRepository) Update(ctx context.Context, answer *domain.CuratedAnswer) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.CuratedAnswer{}).
+		Where("kb_id = ? AND id = ?", answer.KBID, answer.ID).
+		Updates(map[string]any{
+			"question":        answer.Question,
+			"answer":          answer.Answer,
+			"embedding":       answer.Embedding,
+			"embedding_model": answer.EmbeddingModel,
+			"updated_at":      time.Now(),
+		}).Error
+}
+
+func (r *CuratedAnswerRepository) UpdateEmbedding(ctx context.Context, id string, embedding []float32, embeddingModel string) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.CuratedAnswer{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"embedding":       pq.Float32Array(embedding),
+			"embedding_model": embeddingModel,
+		}).Error
+}
+
+func (r *CuratedAnswerRepository) Delete(ctx context.Context, kbID, id string) error {
+	return r.db.WithContext(ctx).
+		Where("kb_id = ? AND id = ?", kbID, id).
+		Delete(&domain.CuratedAnswer{}).Error
+}
+
+func (r *CuratedAnswerRepository) RecordHit(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.CuratedAnswer{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"hit_count":   gorm.Expr("hit_count + 1"),
+			"last_hit_at": time.Now(),
+		}).Error
+}
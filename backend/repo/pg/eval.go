@@ -0,0 +1,200 @@
+package pg
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/store/pg"
+)
+
+type EvalRepository struct {
+	db     *pg.DB
+	logger *log.Logger
+}
+
+func NewEvalRepository(db *pg.DB, logger *log.Logger) *EvalRepository {
+	return &EvalRepository{db: db, logger: logger.WithModule("repo.pg.eval")}
+}
+
+func (r *EvalRepository) CreateSet(ctx context.Context, set *domain.EvalSet) error {
+	return r.db.WithContext(ctx).Create(set).Error
+}
+
+func (r *EvalRepository) GetSet(ctx context.Context, kbID, id string) (*domain.EvalSet, error) {
+	var set domain.EvalSet
+	if err := r.db.WithContext(ctx).
+		Model(&domain.EvalSet{}).
+		Where("kb_id = ? AND id = ?", kbID, id).
+		First(&set).Error; err != nil {
+		return nil, err
+	}
+	return &set, nil
+}
+
+func (r *EvalRepository) GetSetList(ctx context.Context, kbID string) ([]*domain.EvalSet, error) {
+	sets := make([]*domain.EvalSet, 0)
+	if err := r.db.WithContext(ctx).
+		Model(&domain.EvalSet{}).
+		Where("kb_id = ?", kbID).
+		Order("created_at DESC").
+		Find(&sets).Error; err != nil {
+		return nil, err
+	}
+	return sets, nil
+}
+
+func (r *EvalRepository) UpdateSet(ctx context.Context, set *domain.EvalSet) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.EvalSet{}).
+		Where("kb_id = ? AND id = ?", set.KBID, set.ID).
+		Updates(map[string]any{
+			"name":        set.Name,
+			"description": set.Description,
+			"updated_at":  set.UpdatedAt,
+		}).Error
+}
+
+// DeleteSet deletes the set with its questions, runs and results
+func (r *EvalRepository) DeleteSet(ctx context.Context, kbID, id string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("kb_id = ? AND id = ?", kbID, id).Delete(&domain.EvalSet{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if err := tx.Where("set_id = ?", id).Delete(&domain.EvalQuestion{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("run_id IN (?)", tx.Model(&domain.EvalRun{}).Select("id").Where("set_id = ?", id)).
+			Delete(&domain.EvalResult{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("set_id = ?", id).Delete(&domain.EvalRun{}).Error
+	})
+}
+
+func (r *EvalRepository) CreateQuestion(ctx context.Context, question *domain.EvalQuestion) error {
+	return r.db.WithContext(ctx).Create(question).Error
+}
+
+func (r *EvalRepository) GetQuestion(ctx context.Context, kbID, id string) (*domain.EvalQuestion, error) {
+	var question domain.EvalQuestion
+	if err := r.db.WithContext(ctx).
+		Model(&domain.EvalQuestion{}).
+		Where("kb_id = ? AND id = ?", kbID, id).
+		First(&question).Error; err != nil {
+		return nil, err
+	}
+	return &question, nil
+}
+
+func (r *EvalRepository) GetQuestionsBySetID(ctx context.Context, setID string) ([]*domain.EvalQuestion, error) {
+	questions := make([]*domain.EvalQuestion, 0)
+	if err := r.db.WithContext(ctx).
+		Model(&domain.EvalQuestion{}).
+		Where("set_id = ?", setID).
+		Order("created_at ASC").
+		Find(&questions).Error; err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
+
+func (r *EvalRepository) UpdateQuestion(ctx context.Context, question *domain.EvalQuestion) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.EvalQuestion{}).
+		Where("kb_id = ? AND id = ?", question.KBID, question.ID).
+		Updates(map[string]any{
+			"question":          question.Question,
+			"expected_node_ids": question.ExpectedNodeIDs,
+			"reference_answer":  question.ReferenceAnswer,
+			"updated_at":        question.UpdatedAt,
+		}).Error
+}
+
+func (r *EvalRepository) DeleteQuestion(ctx context.Context, kbID, id string) error {
+	return r.db.WithContext(ctx).
+		Where("kb_id = ? AND id = ?", kbID, id).
+		Delete(&domain.EvalQuestion{}).Error
+}
+
+func (r *EvalRepository) CreateRun(ctx context.Context, run *domain.EvalRun) error {
+	return r.db.WithContext(ctx).Create(run).Error
+}
+
+func (r *EvalRepository) GetRun(ctx context.Context, kbID, id string) (*domain.EvalRun, error) {
+	var run domain.EvalRun
+	query := r.db.WithContext(ctx).Model(&domain.EvalRun{}).Where("id = ?", id)
+	if kbID != "" {
+		query = query.Where("kb_id = ?", kbID)
+	}
+	if err := query.First(&run).Error; err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+func (r *EvalRepository) GetRunList(ctx context.Context, req *domain.EvalRunListReq) ([]*domain.EvalRun, int64, error) {
+	runs := make([]*domain.EvalRun, 0)
+	query := r.db.WithContext(ctx).Model(&domain.EvalRun{}).Where("kb_id = ? AND set_id = ?", req.KBID, req.SetID)
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := query.Order("created_at DESC").
+		Offset(req.Offset()).
+		Limit(req.Limit()).
+		Find(&runs).Error; err != nil {
+		return nil, 0, err
+	}
+	return runs, count, nil
+}
+
+// StartRun marks a pending run as running, false when the run was already picked up
+func (r *EvalRepository) StartRun(ctx context.Context, run *domain.EvalRun) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&domain.EvalRun{}).
+		Where("id = ? AND status = ?", run.ID, domain.EvalRunStatusPending).
+		Updates(map[string]any{
+			"status":     domain.EvalRunStatusRunning,
+			"config":     run.Config,
+			"started_at": run.StartedAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *EvalRepository) FinishRun(ctx context.Context, run *domain.EvalRun) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.EvalRun{}).
+		Where("id = ?", run.ID).
+		Updates(map[string]any{
+			"status":      run.Status,
+			"error":       run.Error,
+			"metrics":     run.Metrics,
+			"finished_at": run.FinishedAt,
+		}).Error
+}
+
+func (r *EvalRepository) CreateResult(ctx context.Context, result *domain.EvalResult) error {
+	return r.db.WithContext(ctx).Create(result).Error
+}
+
+func (r *EvalRepository) GetResultsByRunID(ctx context.Context, runID string) ([]*domain.EvalResult, error) {
+	results := make([]*domain.EvalResult, 0)
+	if err := r.db.WithContext(ctx).
+		Model(&domain.EvalResult{}).
+		Where("run_id = ?", runID).
+		Order("created_at ASC").
+		Find(&results).Error; err != nil {
+		return nil, err
+	}
+	return results, nil
+}
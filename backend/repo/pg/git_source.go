@@ -0,0 +1,203 @@
+package pg
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/store/pg"
+)
+
+type GitSourceRepository struct {
+	db     *pg.DB
+	logger *log.Logger
+}
+
+func NewGitSourceRepository(db *pg.DB, logger *log.Logger) *GitSourceRepository {
+	return &GitSourceRepository{db: db, logger: logger.WithModule("repo.pg.git_source")}
+}
+
+func (r *GitSourceRepository) Create(ctx context.Context, source *domain.GitSource) error {
+	return r.db.WithContext(ctx).Create(source).Error
+}
+
+func (r *GitSourceRepository) GetByID(ctx context.Context, kbID, id string) (*domain.GitSource, error) {
+	var source domain.GitSource
+	query := r.db.WithContext(ctx).Model(&domain.GitSource{}).Where("id = ?", id)
+	if kbID != "" {
+		query = query.Where("kb_id = ?", kbID)
+	}
+	if err := query.First(&source).Error; err != nil {
+		return nil, err
+	}
+	return &source, nil
+}
+
+func (r *GitSourceRepository) GetList(ctx context.Context, kbID string) ([]*domain.GitSource, error) {
+	sources := make([]*domain.GitSource, 0)
+	if err := r.db.WithContext(ctx).
+		Model(&domain.GitSource{}).
+		Where("kb_id = ?", kbID).
+		Order("created_at ASC").
+		Find(&sources).Error; err != nil {
+		return nil, err
+	}
+	return sources, nil
+}
+
+func (r *GitSourceRepository) Update(ctx context.Context, source *domain.GitSource) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.GitSource{}).
+		Where("kb_id = ? AND id = ?", source.KBID, source.ID).
+		Updates(map[string]any{
+			"branch":       source.Branch,
+			"dir":          source.Dir,
+			"auto_release": source.AutoRelease,
+			"last_commit":  source.LastCommit,
+			"updated_at":   source.UpdatedAt,
+		}).Error
+}
+
+// Delete deletes the source with its path mapping and uploaded images
+func (r *GitSourceRepository) Delete(ctx context.Context, kbID, id string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("kb_id = ? AND id = ?", kbID, id).Delete(&domain.GitSource{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if err := tx.Where("source_id = ?", id).Delete(&domain.GitSourceNode{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("source_id = ?", id).Delete(&domain.GitSourceImage{}).Error
+	})
+}
+
+// Queue marks the source pending unless a sync is already queued or running, false if it is.
+// a sync queued or started longer than GitSyncStaleAfter ago was lost and does not block a new one
+func (r *GitSourceRepository) Queue(ctx context.Context, kbID, id string) (bool, error) {
+	now := time.Now()
+	result := r.db.WithContext(ctx).
+		Model(&domain.GitSource{}).
+		Where("kb_id = ? AND id = ?", kbID, id).
+		Where("(status NOT IN ? OR started_at IS NULL OR started_at < ?)",
+			[]domain.GitSyncStatus{domain.GitSyncStatusPending, domain.GitSyncStatusRunning},
+			now.Add(-domain.GitSyncStaleAfter)).
+		Updates(map[string]any{
+			"status":     domain.GitSyncStatusPending,
+			"started_at": now,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Start marks a pending source as running, false when the sync was already picked up
+func (r *GitSourceRepository) Start(ctx context.Context, id string) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&domain.GitSource{}).
+		Where("id = ? AND status = ?", id, domain.GitSyncStatusPending).
+		Updates(map[string]any{
+			"status":     domain.GitSyncStatusRunning,
+			"error":      "",
+			"started_at": time.Now(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Fail marks a queued sync whose task could not be published as failed
+func (r *GitSourceRepository) Fail(ctx context.Context, id, reason string) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.GitSource{}).
+		Where("id = ? AND status = ?", id, domain.GitSyncStatusPending).
+		Updates(map[string]any{
+			"status": domain.GitSyncStatusFailed,
+			"error":  reason,
+		}).Error
+}
+
+// Finish records the outcome of a sync, the last commit is only moved forward by successful syncs
+func (r *GitSourceRepository) Finish(ctx context.Context, source *domain.GitSource) error {
+	updates := map[string]any{
+		"status":    source.Status,
+		"error":     source.Error,
+		"result":    source.Result,
+		"synced_at": source.SyncedAt,
+	}
+	if source.Status == domain.GitSyncStatusCompleted {
+		updates["last_commit"] = source.LastCommit
+	}
+	return r.db.WithContext(ctx).
+		Model(&domain.GitSource{}).
+		Where("id = ?", source.ID).
+		Updates(updates).Error
+}
+
+// GetNodes returns the synced nodes of the source by path
+func (r *GitSourceRepository) GetNodes(ctx context.Context, sourceID string) (map[string]*domain.GitSourceNode, error) {
+	nodes := make([]*domain.GitSourceNode, 0)
+	if err := r.db.WithContext(ctx).
+		Model(&domain.GitSourceNode{}).
+		Where("source_id = ?", sourceID).
+		Find(&nodes).Error; err != nil {
+		return nil, err
+	}
+	nodeMap := make(map[string]*domain.GitSourceNode, len(nodes))
+	for _, node := range nodes {
+		nodeMap[node.Path] = node
+	}
+	return nodeMap, nil
+}
+
+func (r *GitSourceRepository) SaveNode(ctx context.Context, node *domain.GitSourceNode) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "source_id"}, {Name: "path"}},
+			DoUpdates: clause.AssignmentColumns([]string{"node_id", "type"}),
+		}).
+		Create(node).Error
+}
+
+func (r *GitSourceRepository) DeleteNodes(ctx context.Context, sourceID string, paths []string) error {
+	if len(paths) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Where("source_id = ? AND path IN ?", sourceID, paths).
+		Delete(&domain.GitSourceNode{}).Error
+}
+
+// GetImage returns the uploaded image of the path, nil if it was never uploaded
+func (r *GitSourceRepository) GetImage(ctx context.Context, sourceID, path string) (*domain.GitSourceImage, error) {
+	images := make([]*domain.GitSourceImage, 0, 1)
+	if err := r.db.WithContext(ctx).
+		Model(&domain.GitSourceImage{}).
+		Where("source_id = ? AND path = ?", sourceID, path).
+		Limit(1).
+		Find(&images).Error; err != nil {
+		return nil, err
+	}
+	if len(images) == 0 {
+		return nil, nil
+	}
+	return images[0], nil
+}
+
+func (r *GitSourceRepository) SaveImage(ctx context.Context, image *domain.GitSourceImage) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "source_id"}, {Name: "path"}},
+			DoUpdates: clause.AssignmentColumns([]string{"hash", "url"}),
+		}).
+		Create(image).Error
+}
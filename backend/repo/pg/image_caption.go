@@ -0,0 +1,42 @@
+package pg
+
+import (
+	"context"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/store/pg"
+)
+
+type ImageCaptionRepository struct {
+	db     *pg.DB
+	logger *log.Logger
+}
+
+func NewImageCaptionRepository(db *pg.DB, logger *log.Logger) *ImageCaptionRepository {
+	return &ImageCaptionRepository{db: db, logger: logger.WithModule("repo.pg.image_caption")}
+}
+
+// GetByHashes returns the cached captions keyed by image hash
+func (r *ImageCaptionRepository) GetByHashes(ctx context.Context, hashes []string) (map[string]*domain.ImageCaption, error) {
+	captions := make([]*domain.ImageCaption, 0)
+	if len(hashes) > 0 {
+		if err := r.db.WithContext(ctx).
+			Model(&domain.ImageCaption{}).
+			Where("hash IN ?", hashes).
+			Find(&captions).Error; err != nil {
+			return nil, err
+		}
+	}
+	result := make(map[string]*domain.ImageCaption, len(captions))
+	for _, caption := range captions {
+		result[caption.Hash] = caption
+	}
+	return result, nil
+}
+
+func (r *ImageCaptionRepository) Create(ctx context.Context, caption *domain.ImageCaption) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(caption).Error
+}
@@ -0,0 +1,250 @@
+package pg
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/store/pg"
+)
+
+type ImportJobRepository struct {
+	db     *pg.DB
+	logger *log.Logger
+}
+
+func NewImportJobRepository(db *pg.DB, logger *log.Logger) *ImportJobRepository {
+	return &ImportJobRepository{db: db, logger: logger.WithModule("repo.pg.import_job")}
+}
+
+// Create creates the job with its items
+func (r *ImportJobRepository) Create(ctx context.Context, job *domain.ImportJob, items []*domain.ImportJobItem) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(job).Error; err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(items, 100).Error
+	})
+}
+
+func (r *ImportJobRepository) GetByID(ctx context.Context, kbID, id string) (*domain.ImportJob, error) {
+	var job domain.ImportJob
+	query := r.db.WithContext(ctx).Model(&domain.ImportJob{}).Where("id = ?", id)
+	if kbID != "" {
+		query = query.Where("kb_id = ?", kbID)
+	}
+	if err := query.First(&job).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *ImportJobRepository) GetList(ctx context.Context, req *domain.ImportJobListReq) ([]*domain.ImportJob, int64, error) {
+	jobs := make([]*domain.ImportJob, 0)
+	query := r.db.WithContext(ctx).Model(&domain.ImportJob{}).Where("kb_id = ?", req.KBID)
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := query.Order("created_at DESC").
+		Offset(req.Offset()).
+		Limit(req.Limit()).
+		Find(&jobs).Error; err != nil {
+		return nil, 0, err
+	}
+	return jobs, count, nil
+}
+
+// GetProgress counts the items of the jobs by status
+func (r *ImportJobRepository) GetProgress(ctx context.Context, jobIDs []string) (map[string]domain.ImportJobProgress, error) {
+	var rows []struct {
+		JobID  string
+		Status domain.ImportJobItemStatus
+		Count  int
+	}
+	if err := r.db.WithContext(ctx).
+		Model(&domain.ImportJobItem{}).
+		Select("job_id, status, COUNT(*) AS count").
+		Where("job_id IN ?", jobIDs).
+		Group("job_id, status").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	counts := make(map[string]map[domain.ImportJobItemStatus]int, len(jobIDs))
+	for _, row := range rows {
+		if counts[row.JobID] == nil {
+			counts[row.JobID] = make(map[domain.ImportJobItemStatus]int)
+		}
+		counts[row.JobID][row.Status] = row.Count
+	}
+	progress := make(map[string]domain.ImportJobProgress, len(jobIDs))
+	for _, jobID := range jobIDs {
+		progress[jobID] = domain.NewImportJobProgress(counts[jobID])
+	}
+	return progress, nil
+}
+
+// GetItems returns the items of the job in the order they were listed
+func (r *ImportJobRepository) GetItems(ctx context.Context, jobID string, statuses ...domain.ImportJobItemStatus) ([]*domain.ImportJobItem, error) {
+	items := make([]*domain.ImportJobItem, 0)
+	query := r.db.WithContext(ctx).Model(&domain.ImportJobItem{}).Where("job_id = ?", jobID)
+	if len(statuses) > 0 {
+		query = query.Where("status IN ?", statuses)
+	}
+	if err := query.Order("position ASC").Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *ImportJobRepository) GetItemList(ctx context.Context, req *domain.ImportJobItemListReq) ([]*domain.ImportJobItem, int64, error) {
+	items := make([]*domain.ImportJobItem, 0)
+	query := r.db.WithContext(ctx).Model(&domain.ImportJobItem{}).Where("job_id = ?", req.ID)
+	if req.Status != "" {
+		query = query.Where("status = ?", req.Status)
+	}
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := query.Order("position ASC").
+		Offset(req.Offset()).
+		Limit(req.Limit()).
+		Find(&items).Error; err != nil {
+		return nil, 0, err
+	}
+	return items, count, nil
+}
+
+// Start marks a pending job as running, false when the job was already picked up
+func (r *ImportJobRepository) Start(ctx context.Context, id string, startedAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&domain.ImportJob{}).
+		Where("id = ? AND status = ?", id, domain.ImportJobStatusPending).
+		Updates(map[string]any{
+			"status":       domain.ImportJobStatusRunning,
+			"error":        "",
+			"started_at":   startedAt,
+			"heartbeat_at": startedAt,
+			"finished_at":  nil,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *ImportJobRepository) Finish(ctx context.Context, job *domain.ImportJob) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.ImportJob{}).
+		Where("id = ?", job.ID).
+		Updates(map[string]any{
+			"status":      job.Status,
+			"error":       job.Error,
+			"finished_at": job.FinishedAt,
+		}).Error
+}
+
+// UpdateItem saves the state of the item and moves the heartbeat of its job forward
+func (r *ImportJobRepository) UpdateItem(ctx context.Context, item *domain.ImportJobItem) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.ImportJobItem{}).
+			Where("id = ?", item.ID).
+			Updates(map[string]any{
+				"status":     item.Status,
+				"task_id":    item.TaskID,
+				"node_id":    item.NodeID,
+				"error":      item.Error,
+				"attempts":   item.Attempts,
+				"updated_at": item.UpdatedAt,
+			}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&domain.ImportJob{}).
+			Where("id = ?", item.JobID).
+			Update("heartbeat_at", item.UpdatedAt).Error
+	})
+}
+
+// Retry resets the failed items, all of them when itemIDs is empty, and the job to pending.
+// false when the job is still active
+func (r *ImportJobRepository) Retry(ctx context.Context, kbID, id string, itemIDs []string) (bool, error) {
+	retried := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&domain.ImportJob{}).
+			Where("kb_id = ? AND id = ?", kbID, id).
+			Where("status IN ?", []domain.ImportJobStatus{domain.ImportJobStatusCompleted, domain.ImportJobStatusFailed}).
+			Updates(map[string]any{
+				"status":       domain.ImportJobStatusPending,
+				"error":        "",
+				"heartbeat_at": now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		retried = true
+		query := tx.Model(&domain.ImportJobItem{}).
+			Where("job_id = ? AND status = ?", id, domain.ImportJobItemStatusFailed)
+		if len(itemIDs) > 0 {
+			query = query.Where("id IN ?", itemIDs)
+		}
+		// the document is exported again, the task of the failed export is not reused
+		items := query.Updates(map[string]any{
+			"status":     domain.ImportJobItemStatusListed,
+			"task_id":    "",
+			"error":      "",
+			"updated_at": now,
+		})
+		if items.Error != nil {
+			return items.Error
+		}
+		if items.RowsAffected > 0 {
+			return nil
+		}
+		// a job that failed as a whole may still have items that were never finished
+		var unfinished int64
+		if err := tx.Model(&domain.ImportJobItem{}).
+			Where("job_id = ? AND status IN ?", id, []domain.ImportJobItemStatus{domain.ImportJobItemStatusListed, domain.ImportJobItemStatusExporting}).
+			Count(&unfinished).Error; err != nil {
+			return err
+		}
+		if unfinished == 0 {
+			return domain.ErrNoFailedImportItems
+		}
+		return nil
+	})
+	return retried, err
+}
+
+// ResumeStale marks the jobs that were never picked up or stopped making progress as pending and returns their ids.
+// their heartbeat is moved forward so that they are not resumed again before they had the time to run
+func (r *ImportJobRepository) ResumeStale(ctx context.Context, now time.Time) ([]string, error) {
+	jobs := make([]*domain.ImportJob, 0)
+	if err := r.db.WithContext(ctx).
+		Model(&jobs).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}}}).
+		Where("status IN ?", []domain.ImportJobStatus{domain.ImportJobStatusPending, domain.ImportJobStatusRunning}).
+		Where("COALESCE(heartbeat_at, created_at) < ?", now.Add(-domain.ImportJobStaleAfter)).
+		Updates(map[string]any{
+			"status":       domain.ImportJobStatusPending,
+			"heartbeat_at": now,
+		}).Error; err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		ids = append(ids, job.ID)
+	}
+	return ids, nil
+}
@@ -0,0 +1,201 @@
+package pg
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/store/pg"
+)
+
+// linkCheckKeepResults is the number of finished jobs of a kb whose results are kept
+const linkCheckKeepResults = 5
+
+var linkCheckFinishedStatus = []domain.LinkCheckJobStatus{domain.LinkCheckJobStatusCompleted, domain.LinkCheckJobStatusFailed}
+
+type LinkCheckRepository struct {
+	db     *pg.DB
+	logger *log.Logger
+}
+
+func NewLinkCheckRepository(db *pg.DB, logger *log.Logger) *LinkCheckRepository {
+	return &LinkCheckRepository{db: db, logger: logger.WithModule("repo.pg.link_check")}
+}
+
+func (r *LinkCheckRepository) Create(ctx context.Context, job *domain.LinkCheckJob) error {
+	return r.db.WithContext(ctx).Create(job).Error
+}
+
+func (r *LinkCheckRepository) GetByID(ctx context.Context, kbID, id string) (*domain.LinkCheckJob, error) {
+	var job domain.LinkCheckJob
+	query := r.db.WithContext(ctx).Model(&domain.LinkCheckJob{}).Where("id = ?", id)
+	if kbID != "" {
+		query = query.Where("kb_id = ?", kbID)
+	}
+	if err := query.First(&job).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// GetActive returns the pending or running job of the kb, nil if there is none
+func (r *LinkCheckRepository) GetActive(ctx context.Context, kbID string) (*domain.LinkCheckJob, error) {
+	jobs := make([]*domain.LinkCheckJob, 0, 1)
+	if err := r.db.WithContext(ctx).
+		Model(&domain.LinkCheckJob{}).
+		Where("kb_id = ? AND status IN ?", kbID, []domain.LinkCheckJobStatus{domain.LinkCheckJobStatusPending, domain.LinkCheckJobStatusRunning}).
+		Limit(1).
+		Find(&jobs).Error; err != nil {
+		return nil, err
+	}
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+	return jobs[0], nil
+}
+
+// GetLatestFinished returns the last finished job of the kb, nil if there is none
+func (r *LinkCheckRepository) GetLatestFinished(ctx context.Context, kbID string) (*domain.LinkCheckJob, error) {
+	jobs := make([]*domain.LinkCheckJob, 0, 1)
+	if err := r.db.WithContext(ctx).
+		Model(&domain.LinkCheckJob{}).
+		Where("kb_id = ? AND status IN ?", kbID, linkCheckFinishedStatus).
+		Order("created_at DESC").
+		Limit(1).
+		Find(&jobs).Error; err != nil {
+		return nil, err
+	}
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+	return jobs[0], nil
+}
+
+func (r *LinkCheckRepository) GetList(ctx context.Context, req *domain.LinkCheckJobListReq) ([]*domain.LinkCheckJob, int64, error) {
+	jobs := make([]*domain.LinkCheckJob, 0)
+	query := r.db.WithContext(ctx).Model(&domain.LinkCheckJob{}).Where("kb_id = ?", req.KBID)
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := query.Order("created_at DESC").
+		Offset(req.Offset()).
+		Limit(req.Limit()).
+		Find(&jobs).Error; err != nil {
+		return nil, 0, err
+	}
+	return jobs, count, nil
+}
+
+// Start marks a pending job as running, false when the job was already picked up
+func (r *LinkCheckRepository) Start(ctx context.Context, id string, startedAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&domain.LinkCheckJob{}).
+		Where("id = ? AND status = ?", id, domain.LinkCheckJobStatusPending).
+		Updates(map[string]any{
+			"status":     domain.LinkCheckJobStatusRunning,
+			"started_at": startedAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Finish saves the results and the outcome of the job, the results of older jobs of the kb are removed
+// except for the last few
+func (r *LinkCheckRepository) Finish(ctx context.Context, job *domain.LinkCheckJob, results []*domain.LinkCheckResult) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(results) > 0 {
+			if err := tx.CreateInBatches(results, 500).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Model(&domain.LinkCheckJob{}).
+			Where("id = ?", job.ID).
+			Updates(map[string]any{
+				"status":      job.Status,
+				"error":       job.Error,
+				"release_id":  job.ReleaseID,
+				"total":       job.Total,
+				"broken":      job.Broken,
+				"finished_at": job.FinishedAt,
+			}).Error; err != nil {
+			return err
+		}
+		old := tx.Model(&domain.LinkCheckJob{}).
+			Select("id").
+			Where("kb_id = ? AND status IN ?", job.KBID, linkCheckFinishedStatus).
+			Order("created_at DESC").
+			Offset(linkCheckKeepResults)
+		return tx.Where("job_id IN (?)", old).Delete(&domain.LinkCheckResult{}).Error
+	})
+}
+
+// FailStale marks the jobs that were not picked up or did not finish in time as failed, their task or consumer
+// is gone. running jobs are timed from their start so that a job queued behind others is not cut short
+func (r *LinkCheckRepository) FailStale(ctx context.Context, now time.Time) error {
+	staleBefore := now.Add(-domain.LinkCheckStaleAfter)
+	return r.db.WithContext(ctx).
+		Model(&domain.LinkCheckJob{}).
+		Where("(status = ? AND created_at < ?) OR (status = ? AND COALESCE(started_at, created_at) < ?)",
+			domain.LinkCheckJobStatusPending, staleBefore, domain.LinkCheckJobStatusRunning, staleBefore).
+		Updates(map[string]any{
+			"status":      domain.LinkCheckJobStatusFailed,
+			"error":       "link check job was interrupted",
+			"finished_at": now,
+		}).Error
+}
+
+// GetBrokenResults returns the broken links found by the job, only those of nodeID when it is set
+func (r *LinkCheckRepository) GetBrokenResults(ctx context.Context, jobID, nodeID string) ([]*domain.LinkCheckResult, error) {
+	results := make([]*domain.LinkCheckResult, 0)
+	query := r.db.WithContext(ctx).Model(&domain.LinkCheckResult{}).Where("job_id = ? AND broken", jobID)
+	if nodeID != "" {
+		query = query.Where("node_id = ?", nodeID)
+	}
+	if err := query.Order("node_name ASC, node_id ASC, kind ASC, url ASC").Find(&results).Error; err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// GetSchedule returns the link check schedule of the kb, nil if it was never set up
+func (r *LinkCheckRepository) GetSchedule(ctx context.Context, kbID string) (*domain.LinkCheckSchedule, error) {
+	schedules := make([]*domain.LinkCheckSchedule, 0, 1)
+	if err := r.db.WithContext(ctx).
+		Model(&domain.LinkCheckSchedule{}).
+		Where("kb_id = ?", kbID).
+		Limit(1).
+		Find(&schedules).Error; err != nil {
+		return nil, err
+	}
+	if len(schedules) == 0 {
+		return nil, nil
+	}
+	return schedules[0], nil
+}
+
+func (r *LinkCheckRepository) SaveSchedule(ctx context.Context, schedule *domain.LinkCheckSchedule) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{UpdateAll: true}).
+		Create(schedule).Error
+}
+
+// QueueDue moves the next run of the enabled schedules that are due forward and returns them
+func (r *LinkCheckRepository) QueueDue(ctx context.Context, now time.Time) ([]*domain.LinkCheckSchedule, error) {
+	schedules := make([]*domain.LinkCheckSchedule, 0)
+	if err := r.db.WithContext(ctx).
+		Model(&schedules).
+		Clauses(clause.Returning{}).
+		Where("enabled AND next_run_at <= ?", now).
+		Update("next_run_at", gorm.Expr("? + make_interval(hours => CASE WHEN interval_hours > 0 THEN interval_hours ELSE ? END)",
+			now, domain.DefaultLinkCheckIntervalHours)).Error; err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
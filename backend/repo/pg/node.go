@@ -91,20 +91,21 @@ func (r *NodeRepository) Create(ctx context.Context, req *domain.CreateNodeReq,
 		}
 
 		node := &domain.Node{
-			ID:        nodeIDStr,
-			KBID:      req.KBID,
-			Name:      req.Name,
-			Content:   req.Content,
-			Meta:      meta,
-			Type:      req.Type,
-			ParentID:  req.ParentID,
-			Position:  newPos,
-			Status:    domain.NodeStatusDraft,
-			CreatorId: userId,
-			EditorId:  userId,
-			CreatedAt: now,
-			UpdatedAt: now,
-			EditTime:  now,
+			ID:         nodeIDStr,
+			KBID:       req.KBID,
+			Name:       req.Name,
+			Content:    req.Content,
+			Meta:       meta,
+			Type:       req.Type,
+			ParentID:   req.ParentID,
+			Position:   newPos,
+			Status:     domain.NodeStatusDraft,
+			CreatorId:  userId,
+			EditorId:   userId,
+			ExternalID: req.ExternalID,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+			EditTime:   now,
 			RagInfo: domain.RagInfo{
 				Status:  consts.NodeRagStatusBasicPending,
 				Message: "",
@@ -116,7 +117,13 @@ func (r *NodeRepository) Create(ctx context.Context, req *domain.CreateNodeReq,
 			},
 		}
 
-		return tx.Create(node).Error
+		if err := tx.Create(node).Error; err != nil {
+			return err
+		}
+		if node.Type == domain.NodeTypeDocument && node.Content != "" {
+			return replaceNodeLinks(tx, node.KBID, node.ID, node.Content)
+		}
+		return nil
 	})
 	if err != nil {
 		return "", err
@@ -132,7 +139,7 @@ func (r *NodeRepository) GetList(ctx context.Context, req *domain.GetNodeListReq
 		Joins("LEFT JOIN users cu ON nodes.creator_id = cu.id").
 		Joins("LEFT JOIN users eu ON nodes.editor_id = eu.id").
 		Where("nodes.kb_id = ?", req.KBID).
-		Select("cu.account AS creator, eu.account AS editor, nodes.editor_id, nodes.rag_info, nodes.creator_id, nodes.id, nodes.permissions, nodes.type, nodes.status, nodes.name, nodes.parent_id, nodes.position, nodes.created_at, nodes.edit_time as updated_at, nodes.meta->>'summary' as summary, nodes.meta->>'emoji' as emoji, nodes.meta->>'content_type' as content_type")
+		Select("cu.account AS creator, eu.account AS editor, nodes.editor_id, nodes.rag_info, nodes.creator_id, nodes.id, nodes.permissions, nodes.type, nodes.status, nodes.name, nodes.parent_id, nodes.position, nodes.created_at, nodes.edit_time as updated_at, nodes.meta->>'summary' as summary, nodes.meta->>'emoji' as emoji, nodes.meta->>'content_type' as content_type, nodes.external_id")
 	if req.Search != "" {
 		searchPattern := "%" + req.Search + "%"
 		query = query.Where("name LIKE ? OR content LIKE ?", searchPattern, searchPattern)
@@ -269,10 +276,15 @@ func (r *NodeRepository) UpdateNodeContent(ctx context.Context, req *domain.Upda
 		// Perform update if there are changes
 		if len(updateMap) > 0 {
 			// Use the transaction's DB instance for the update
-			return tx.Model(&domain.Node{}).
+			if err := tx.Model(&domain.Node{}).
 				Where("id = ?", req.ID).
 				Where("kb_id = ?", req.KBID).
-				Updates(updateMap).Error
+				Updates(updateMap).Error; err != nil {
+				return err
+			}
+		}
+		if _, ok := updateMap["content"]; ok && currentNode.Type == domain.NodeTypeDocument {
+			return replaceNodeLinks(tx, req.KBID, req.ID, *req.Content)
 		}
 		return nil
 	})
@@ -318,6 +330,10 @@ func (r *NodeRepository) Delete(ctx context.Context, kbID string, ids []string)
 			Delete(&nodeReleases).Error; err != nil {
 			return err
 		}
+		// links to the deleted nodes are kept, they show up as broken links of their documents
+		if err := tx.Where("source_id IN ?", allIDs).Delete(&domain.NodeLink{}).Error; err != nil {
+			return err
+		}
 		for _, node := range nodes {
 			if node.DocID != "" {
 				docIDs = append(docIDs, node.DocID)
@@ -335,6 +351,67 @@ func (r *NodeRepository) Delete(ctx context.Context, kbID string, ids []string)
 	return lo.Uniq(docIDs), nil
 }
 
+func (r *NodeRepository) HasChildren(ctx context.Context, kbID, id string) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&domain.Node{}).
+		Where("kb_id = ? AND parent_id = ?", kbID, id).
+		Limit(1).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// GetNodesByExternalIDs returns the nodes of the kb with the external ids, keyed by external id
+func (r *NodeRepository) GetNodesByExternalIDs(ctx context.Context, kbID string, externalIDs []string) (map[string]*domain.Node, error) {
+	nodes := make(map[string]*domain.Node, len(externalIDs))
+	for _, chunk := range lo.Chunk(externalIDs, 1000) {
+		var chunkNodes []*domain.Node
+		if err := r.db.WithContext(ctx).
+			Model(&domain.Node{}).
+			Where("kb_id = ?", kbID).
+			Where("external_id IN ?", chunk).
+			Find(&chunkNodes).Error; err != nil {
+			return nil, err
+		}
+		for _, node := range chunkNodes {
+			nodes[node.ExternalID] = node
+		}
+	}
+	return nodes, nil
+}
+
+// GetExternalDocuments returns the id and external id of the documents whose external id starts with prefix
+func (r *NodeRepository) GetExternalDocuments(ctx context.Context, kbID, prefix string) ([]*domain.Node, error) {
+	var nodes []*domain.Node
+	query := r.db.WithContext(ctx).
+		Model(&domain.Node{}).
+		Where("kb_id = ?", kbID).
+		Where("type = ?", domain.NodeTypeDocument).
+		Where("external_id <> ''")
+	if prefix != "" {
+		query = query.Where("starts_with(external_id, ?)", prefix)
+	}
+	if err := query.Select("id, external_id").Find(&nodes).Error; err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+// GetFolderByName returns the first folder with the name directly under parentID
+func (r *NodeRepository) GetFolderByName(ctx context.Context, kbID, parentID, name string) (*domain.Node, error) {
+	var node *domain.Node
+	if err := whereParentID(r.db.WithContext(ctx).Model(&domain.Node{}).Where("kb_id = ?", kbID), parentID).
+		Where("type = ?", domain.NodeTypeFolder).
+		Where("name = ?", name).
+		Order("position").
+		First(&node).Error; err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
 // collectAllChildNodeIDs recursively collects all child node IDs for the given parent IDs
 func (r *NodeRepository) collectAllChildNodeIDs(tx *gorm.DB, kbID string, parentIDs []string) []string {
 	allIDs := make([]string, 0)
@@ -362,6 +439,11 @@ func (r *NodeRepository) collectAllChildNodeIDs(tx *gorm.DB, kbID string, parent
 	return lo.Uniq(allIDs)
 }
 
+// GetSubtreeNodeIDs returns the node and all its descendants, parents come before their children
+func (r *NodeRepository) GetSubtreeNodeIDs(ctx context.Context, kbID, id string) []string {
+	return r.collectAllChildNodeIDs(r.db.WithContext(ctx), kbID, []string{id})
+}
+
 func (r *NodeRepository) GetNodeByID(ctx context.Context, id string) (*domain.Node, error) {
 	var node *domain.Node
 	if err := r.db.WithContext(ctx).
@@ -455,6 +537,19 @@ func (r *NodeRepository) GetLatestNodeReleaseByNodeID(ctx context.Context, nodeI
 	return nodeRelease, nil
 }
 
+// GetLatestIndexedNodeRelease returns the latest release of the node that has a rag document
+func (r *NodeRepository) GetLatestIndexedNodeRelease(ctx context.Context, kbID, nodeID string) (*domain.NodeRelease, error) {
+	var nodeRelease *domain.NodeRelease
+	if err := r.db.WithContext(ctx).
+		Model(&domain.NodeRelease{}).
+		Where("kb_id = ? AND node_id = ? AND doc_id != ''", kbID, nodeID).
+		Order("updated_at DESC").
+		First(&nodeRelease).Error; err != nil {
+		return nil, err
+	}
+	return nodeRelease, nil
+}
+
 func (r *NodeRepository) GetLatestNodeReleaseWithPublishAccount(ctx context.Context, nodeID string) (*domain.NodeReleaseWithPublisher, error) {
 	var nodeRelease *domain.NodeReleaseWithPublisher
 	if err := r.db.WithContext(ctx).
@@ -743,6 +838,63 @@ func (r *NodeRepository) GetNodeReleaseDetailByKBIDAndID(ctx context.Context, kb
 	return node, nil
 }
 
+// GetReleasedNodeNames returns the names of the nodes that are visitable in the latest kb release
+func (r *NodeRepository) GetReleasedNodeNames(ctx context.Context, kbID string, ids []string) (map[string]string, error) {
+	var kbRelease *domain.KBRelease
+	if err := r.db.WithContext(ctx).
+		Model(&domain.KBRelease{}).
+		Where("kb_id = ?", kbID).
+		Order("created_at DESC").
+		First(&kbRelease).Error; err != nil {
+		return nil, err
+	}
+	var nodes []*domain.NodeRelease
+	if err := r.db.WithContext(ctx).
+		Model(&domain.KBReleaseNodeRelease{}).
+		Select("node_releases.node_id, node_releases.name").
+		Joins("LEFT JOIN node_releases ON node_releases.id = kb_release_node_releases.node_release_id").
+		Joins("LEFT JOIN nodes ON nodes.id = kb_release_node_releases.node_id").
+		Where("kb_release_node_releases.release_id = ?", kbRelease.ID).
+		Where("node_releases.node_id IN ?", ids).
+		Where("nodes.permissions->>'visitable' != ?", consts.NodeAccessPermClosed).
+		Find(&nodes).Error; err != nil {
+		return nil, err
+	}
+	names := make(map[string]string, len(nodes))
+	for _, node := range nodes {
+		names[node.NodeID] = node.Name
+	}
+	return names, nil
+}
+
+// GetLatestKBReleaseNodes returns the id of the latest release of the kb with the releases of its nodes,
+// an empty id when the kb was never released
+func (r *NodeRepository) GetLatestKBReleaseNodes(ctx context.Context, kbID string) (string, []*domain.NodeRelease, error) {
+	kbReleases := make([]*domain.KBRelease, 0, 1)
+	if err := r.db.WithContext(ctx).
+		Model(&domain.KBRelease{}).
+		Where("kb_id = ?", kbID).
+		Order("created_at DESC").
+		Limit(1).
+		Find(&kbReleases).Error; err != nil {
+		return "", nil, err
+	}
+	if len(kbReleases) == 0 {
+		return "", nil, nil
+	}
+	var nodes []*domain.NodeRelease
+	if err := r.db.WithContext(ctx).
+		Model(&domain.KBReleaseNodeRelease{}).
+		Select("node_releases.node_id, node_releases.name, node_releases.type, node_releases.content").
+		Joins("JOIN node_releases ON node_releases.id = kb_release_node_releases.node_release_id").
+		Where("kb_release_node_releases.release_id = ?", kbReleases[0].ID).
+		Order("node_releases.name ASC").
+		Find(&nodes).Error; err != nil {
+		return "", nil, err
+	}
+	return kbReleases[0].ID, nodes, nil
+}
+
 func (r *NodeRepository) MoveNodeBetween(ctx context.Context, id, parentID, prevID, nextID, kbId string) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
 		var prevPos, maxPos float64 = 0, domain.MaxPosition
@@ -792,6 +944,47 @@ func (r *NodeRepository) MoveNodeBetween(ctx context.Context, id, parentID, prev
 	})
 }
 
+// MoveNodeToParent moves the node after the last child of parentID
+func (r *NodeRepository) MoveNodeToParent(ctx context.Context, kbID, id, parentID string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		maxPosition := func() (float64, error) {
+			var maxPos float64
+			err := whereParentID(tx.Model(&domain.Node{}).Where("kb_id = ?", kbID), parentID).
+				Select("COALESCE(MAX(position::float), 0)").
+				Scan(&maxPos).Error
+			return maxPos, err
+		}
+		maxPos, err := maxPosition()
+		if err != nil {
+			return err
+		}
+		if domain.MaxPosition-maxPos < 2*domain.MinPositionGap {
+			if err := r.reorderPositionsByParentID(tx, kbID, parentID); err != nil {
+				return err
+			}
+			if maxPos, err = maxPosition(); err != nil {
+				return err
+			}
+		}
+		return tx.Model(&domain.Node{}).
+			Where("id = ?", id).
+			Where("kb_id = ?", kbID).
+			Updates(map[string]any{
+				"parent_id": parentID,
+				"position":  maxPos + (domain.MaxPosition-maxPos)/2.0,
+				"status":    domain.NodeStatusDraft,
+			}).Error
+	})
+}
+
+// whereParentID matches the children of parentID, root nodes may have a null parent_id
+func whereParentID(query *gorm.DB, parentID string) *gorm.DB {
+	if parentID == "" {
+		return query.Where("parent_id IS NULL OR parent_id = ''")
+	}
+	return query.Where("parent_id = ?", parentID)
+}
+
 // UpdateNodeDocID update node doc id
 func (r *NodeRepository) UpdateNodeDocID(ctx context.Context, id, docID string) error {
 	return r.db.WithContext(ctx).
@@ -824,6 +1017,22 @@ func (r *NodeRepository) UpdateNodeSummary(ctx context.Context, kbID, nodeID, su
 		}).Error
 }
 
+// GetNodesWithoutSummary returns the id and name of the documents of the kb with content but no summary
+func (r *NodeRepository) GetNodesWithoutSummary(ctx context.Context, kbID string) ([]*domain.Node, error) {
+	nodes := make([]*domain.Node, 0)
+	if err := r.db.WithContext(ctx).
+		Model(&domain.Node{}).
+		Select("id, name").
+		Where("kb_id = ? AND type = ?", kbID, domain.NodeTypeDocument).
+		Where("COALESCE(meta->>'summary', '') = ''").
+		Where("content != ''").
+		Order("created_at ASC").
+		Find(&nodes).Error; err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
 // traverse all nodes by pg cursor
 func (r *NodeRepository) TraverseNodesByCursor(ctx context.Context, callback func(*domain.NodeRelease) error) error {
 	rows, err := r.db.WithContext(ctx).
@@ -871,6 +1080,12 @@ func (r *NodeRepository) CreateNodeReleases(ctx context.Context, kbID, userId st
 		}
 		nodeReleases := make([]*domain.NodeRelease, len(updatedNodes))
 		for i, updatedNode := range updatedNodes {
+			// the links of the published content are indexed again, so that the index also covers nodes written before it existed
+			if updatedNode.Type == domain.NodeTypeDocument {
+				if err := replaceNodeLinks(tx, kbID, updatedNode.ID, updatedNode.Content); err != nil {
+					return err
+				}
+			}
 			// create node release
 			nodeRelease := &domain.NodeRelease{
 				ID:          uuid.New().String(),
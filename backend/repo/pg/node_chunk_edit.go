@@ -0,0 +1,69 @@
+package pg
+
+import (
+	"context"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/store/pg"
+)
+
+type NodeChunkEditRepository struct {
+	db     *pg.DB
+	logger *log.Logger
+}
+
+func NewNodeChunkEditRepository(db *pg.DB, logger *log.Logger) *NodeChunkEditRepository {
+	return &NodeChunkEditRepository{db: db, logger: logger.WithModule("repo.pg.node_chunk_edit")}
+}
+
+func (r *NodeChunkEditRepository) Create(ctx context.Context, edit *domain.NodeChunkEdit) error {
+	return r.db.WithContext(ctx).Create(edit).Error
+}
+
+func (r *NodeChunkEditRepository) GetByID(ctx context.Context, kbID, id string) (*domain.NodeChunkEdit, error) {
+	var edit domain.NodeChunkEdit
+	if err := r.db.WithContext(ctx).
+		Model(&domain.NodeChunkEdit{}).
+		Where("kb_id = ? AND id = ?", kbID, id).
+		First(&edit).Error; err != nil {
+		return nil, err
+	}
+	return &edit, nil
+}
+
+func (r *NodeChunkEditRepository) GetByNodeID(ctx context.Context, nodeID string) ([]*domain.NodeChunkEdit, error) {
+	edits := make([]*domain.NodeChunkEdit, 0)
+	if err := r.db.WithContext(ctx).
+		Model(&domain.NodeChunkEdit{}).
+		Where("node_id = ?", nodeID).
+		Order("created_at ASC").
+		Find(&edits).Error; err != nil {
+		return nil, err
+	}
+	return edits, nil
+}
+
+func (r *NodeChunkEditRepository) Update(ctx context.Context, edit *domain.NodeChunkEdit) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.NodeChunkEdit{}).
+		Where("id = ?", edit.ID).
+		Updates(map[string]any{
+			"source_hash":    edit.SourceHash,
+			"source_content": edit.SourceContent,
+			"content":        edit.Content,
+			"keywords":       edit.Keywords,
+			"questions":      edit.Questions,
+			"available":      edit.Available,
+			"status":         edit.Status,
+			"doc_id":         edit.DocID,
+			"chunk_id":       edit.ChunkID,
+			"updated_at":     edit.UpdatedAt,
+		}).Error
+}
+
+func (r *NodeChunkEditRepository) Delete(ctx context.Context, kbID, id string) error {
+	return r.db.WithContext(ctx).
+		Where("kb_id = ? AND id = ?", kbID, id).
+		Delete(&domain.NodeChunkEdit{}).Error
+}
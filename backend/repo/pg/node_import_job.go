@@ -0,0 +1,121 @@
+package pg
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/store/pg"
+)
+
+type NodeImportJobRepository struct {
+	db     *pg.DB
+	logger *log.Logger
+}
+
+func NewNodeImportJobRepository(db *pg.DB, logger *log.Logger) *NodeImportJobRepository {
+	return &NodeImportJobRepository{db: db, logger: logger.WithModule("repo.pg.node_import_job")}
+}
+
+func (r *NodeImportJobRepository) Create(ctx context.Context, job *domain.NodeImportJob) error {
+	return r.db.WithContext(ctx).Create(job).Error
+}
+
+func (r *NodeImportJobRepository) GetByID(ctx context.Context, kbID, id string) (*domain.NodeImportJob, error) {
+	var job domain.NodeImportJob
+	query := r.db.WithContext(ctx).Model(&domain.NodeImportJob{}).Where("id = ?", id)
+	if kbID != "" {
+		query = query.Where("kb_id = ?", kbID)
+	}
+	if err := query.First(&job).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// GetList returns the jobs of the kb without the result of each document
+func (r *NodeImportJobRepository) GetList(ctx context.Context, req *domain.NodeImportJobListReq) ([]*domain.NodeImportJob, int64, error) {
+	jobs := make([]*domain.NodeImportJob, 0)
+	query := r.db.WithContext(ctx).Model(&domain.NodeImportJob{}).Where("kb_id = ?", req.KBID)
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := query.Omit("result").
+		Order("created_at DESC").
+		Offset(req.Offset()).
+		Limit(req.Limit()).
+		Find(&jobs).Error; err != nil {
+		return nil, 0, err
+	}
+	return jobs, count, nil
+}
+
+// Start marks a pending job as running, false when the job was already picked up
+func (r *NodeImportJobRepository) Start(ctx context.Context, id string, startedAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&domain.NodeImportJob{}).
+		Where("id = ? AND status = ?", id, domain.NodeImportJobStatusPending).
+		Updates(map[string]any{
+			"status":       domain.NodeImportJobStatusRunning,
+			"error":        "",
+			"done":         0,
+			"started_at":   startedAt,
+			"heartbeat_at": startedAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// UpdateProgress saves the number of documents written and moves the heartbeat of the job forward
+func (r *NodeImportJobRepository) UpdateProgress(ctx context.Context, id string, total, done int, now time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.NodeImportJob{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"total":        total,
+			"done":         done,
+			"heartbeat_at": now,
+		}).Error
+}
+
+func (r *NodeImportJobRepository) Finish(ctx context.Context, job *domain.NodeImportJob) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.NodeImportJob{}).
+		Where("id = ?", job.ID).
+		Updates(map[string]any{
+			"status":      job.Status,
+			"error":       job.Error,
+			"total":       job.Total,
+			"done":        job.Done,
+			"result":      job.Result,
+			"finished_at": job.FinishedAt,
+		}).Error
+}
+
+// ResumeStale marks the jobs that were never picked up or stopped making progress as pending and returns their ids.
+// their heartbeat is moved forward so that they are not resumed again before they had the time to run
+func (r *NodeImportJobRepository) ResumeStale(ctx context.Context, now time.Time) ([]string, error) {
+	jobs := make([]*domain.NodeImportJob, 0)
+	if err := r.db.WithContext(ctx).
+		Model(&jobs).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}}}).
+		Where("status IN ?", []domain.NodeImportJobStatus{domain.NodeImportJobStatusPending, domain.NodeImportJobStatusRunning}).
+		Where("COALESCE(heartbeat_at, created_at) < ?", now.Add(-domain.NodeImportJobStaleAfter)).
+		Updates(map[string]any{
+			"status":       domain.NodeImportJobStatusPending,
+			"heartbeat_at": now,
+		}).Error; err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		ids = append(ids, job.ID)
+	}
+	return ids, nil
+}
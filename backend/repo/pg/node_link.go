@@ -0,0 +1,96 @@
+package pg
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/store/pg"
+)
+
+// nodeLinkTargetJoin matches a link to the documents it refers to, url links by id and wiki-links by name
+const nodeLinkTargetJoin = "nodes t ON t.kb_id = l.kb_id AND t.type = ? AND " +
+	"((l.kind = 'url' AND t.id = l.target) OR (l.kind = 'wiki' AND LOWER(BTRIM(t.name)) = LOWER(l.target)))"
+
+type NodeLinkRepository struct {
+	db     *pg.DB
+	logger *log.Logger
+}
+
+func NewNodeLinkRepository(db *pg.DB, logger *log.Logger) *NodeLinkRepository {
+	return &NodeLinkRepository{db: db, logger: logger.WithModule("repo.pg.node_link")}
+}
+
+// replaceNodeLinks indexes the links of the content of a document in place of its previous links
+func replaceNodeLinks(tx *gorm.DB, kbID, nodeID, content string) error {
+	if err := tx.Where("source_id = ?", nodeID).Delete(&domain.NodeLink{}).Error; err != nil {
+		return err
+	}
+	links := domain.NewNodeLinks(kbID, nodeID, content)
+	if len(links) == 0 {
+		return nil
+	}
+	return tx.CreateInBatches(links, 100).Error
+}
+
+// GetBacklinks returns the documents linking to any of targetIDs, links from the documents of excludeIDs are left out
+func (r *NodeLinkRepository) GetBacklinks(ctx context.Context, kbID string, targetIDs, excludeIDs []string) ([]*domain.NodeBacklink, error) {
+	backlinks := make([]*domain.NodeBacklink, 0)
+	query := r.db.WithContext(ctx).
+		Table("node_links l").
+		Select("DISTINCT s.id, s.name, s.meta->>'emoji' AS emoji, l.kind, t.id AS target_id").
+		Joins("JOIN "+nodeLinkTargetJoin, domain.NodeTypeDocument).
+		Joins("JOIN nodes s ON s.id = l.source_id").
+		Where("l.kb_id = ? AND t.id IN ?", kbID, targetIDs)
+	if len(excludeIDs) > 0 {
+		query = query.Where("l.source_id NOT IN ?", excludeIDs)
+	}
+	if err := query.Order("s.name ASC").Scan(&backlinks).Error; err != nil {
+		return nil, err
+	}
+	return backlinks, nil
+}
+
+// GetOutgoing returns the links of a document with the documents they refer to, a wiki-link matching
+// several documents refers to the oldest one
+func (r *NodeLinkRepository) GetOutgoing(ctx context.Context, kbID, nodeID string) ([]*domain.NodeOutgoingLink, error) {
+	links := make([]*domain.NodeOutgoingLink, 0)
+	if err := r.db.WithContext(ctx).
+		Table("node_links l").
+		Select("DISTINCT ON (l.kind, l.target) l.kind, l.target, COALESCE(t.id, '') AS id, COALESCE(t.name, '') AS name, "+
+			"COALESCE(t.meta->>'emoji', '') AS emoji, t.id IS NULL AS broken").
+		Joins("LEFT JOIN "+nodeLinkTargetJoin, domain.NodeTypeDocument).
+		Where("l.kb_id = ? AND l.source_id = ?", kbID, nodeID).
+		Order("l.kind, l.target, t.created_at ASC").
+		Scan(&links).Error; err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+// GetGraph returns the documents of the kb and the resolved links between them
+func (r *NodeLinkRepository) GetGraph(ctx context.Context, kbID string) (*domain.NodeLinkGraph, error) {
+	graph := &domain.NodeLinkGraph{
+		Nodes: make([]*domain.NodeLinkGraphNode, 0),
+		Edges: make([]*domain.NodeLinkGraphEdge, 0),
+	}
+	if err := r.db.WithContext(ctx).
+		Model(&domain.Node{}).
+		Select("id, name, parent_id, meta->>'emoji' AS emoji").
+		Where("kb_id = ? AND type = ?", kbID, domain.NodeTypeDocument).
+		Order("created_at ASC").
+		Scan(&graph.Nodes).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.WithContext(ctx).
+		Table("node_links l").
+		Select("DISTINCT l.source_id AS source, t.id AS target, l.kind").
+		Joins("JOIN "+nodeLinkTargetJoin, domain.NodeTypeDocument).
+		Where("l.kb_id = ? AND t.id <> l.source_id", kbID).
+		Scan(&graph.Edges).Error; err != nil {
+		return nil, err
+	}
+	return graph, nil
+}
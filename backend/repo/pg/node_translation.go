@@ -0,0 +1,120 @@
+package pg
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/store/pg"
+)
+
+type NodeTranslationRepository struct {
+	db     *pg.DB
+	logger *log.Logger
+}
+
+func NewNodeTranslationRepository(db *pg.DB, logger *log.Logger) *NodeTranslationRepository {
+	return &NodeTranslationRepository{db: db, logger: logger.WithModule("repo.pg.node_translation")}
+}
+
+func (r *NodeTranslationRepository) Create(ctx context.Context, translation *domain.NodeTranslation) error {
+	return r.db.WithContext(ctx).Create(translation).Error
+}
+
+func (r *NodeTranslationRepository) GetByID(ctx context.Context, id string) (*domain.NodeTranslation, error) {
+	var translation domain.NodeTranslation
+	if err := r.db.WithContext(ctx).
+		Model(&domain.NodeTranslation{}).
+		Where("id = ?", id).
+		First(&translation).Error; err != nil {
+		return nil, err
+	}
+	return &translation, nil
+}
+
+// GetBySource returns the translation of the source node in the language, nil if not translated yet
+func (r *NodeTranslationRepository) GetBySource(ctx context.Context, sourceNodeID, language string) (*domain.NodeTranslation, error) {
+	var translation domain.NodeTranslation
+	if err := r.db.WithContext(ctx).
+		Model(&domain.NodeTranslation{}).
+		Where("source_node_id = ? AND language = ?", sourceNodeID, language).
+		First(&translation).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &translation, nil
+}
+
+// GetByNodeID returns the translation a variant node was created by, nil if the node is not a variant
+func (r *NodeTranslationRepository) GetByNodeID(ctx context.Context, kbID, nodeID string) (*domain.NodeTranslation, error) {
+	var translation domain.NodeTranslation
+	if err := r.db.WithContext(ctx).
+		Model(&domain.NodeTranslation{}).
+		Where("kb_id = ? AND node_id = ?", kbID, nodeID).
+		First(&translation).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &translation, nil
+}
+
+// GetListBySourceNodeID returns the translations of a source node whose variant, if any, still exists
+func (r *NodeTranslationRepository) GetListBySourceNodeID(ctx context.Context, kbID, sourceNodeID string) ([]*domain.NodeTranslation, error) {
+	translations := make([]*domain.NodeTranslation, 0)
+	if err := r.db.WithContext(ctx).
+		Model(&domain.NodeTranslation{}).
+		Where("kb_id = ? AND source_node_id = ?", kbID, sourceNodeID).
+		Where("node_id = '' OR EXISTS (SELECT 1 FROM nodes WHERE nodes.id = node_translations.node_id)").
+		Order("language").
+		Find(&translations).Error; err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
+
+// Reset queues the translation again
+func (r *NodeTranslationRepository) Reset(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.NodeTranslation{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"status":     domain.NodeTranslationStatusPending,
+			"error":      "",
+			"updated_at": gorm.Expr("NOW()"),
+		}).Error
+}
+
+// Start marks a pending translation as running, false when it was already picked up
+func (r *NodeTranslationRepository) Start(ctx context.Context, id string) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&domain.NodeTranslation{}).
+		Where("id = ? AND status = ?", id, domain.NodeTranslationStatusPending).
+		Updates(map[string]any{
+			"status":     domain.NodeTranslationStatusRunning,
+			"updated_at": gorm.Expr("NOW()"),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *NodeTranslationRepository) Finish(ctx context.Context, translation *domain.NodeTranslation) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.NodeTranslation{}).
+		Where("id = ?", translation.ID).
+		Updates(map[string]any{
+			"node_id":     translation.NodeID,
+			"source_hash": translation.SourceHash,
+			"status":      translation.Status,
+			"error":       translation.Error,
+			"updated_at":  translation.UpdatedAt,
+		}).Error
+}
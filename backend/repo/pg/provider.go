@@ -26,4 +26,17 @@ var ProviderSet = wire.NewSet(
 	NewSystemSettingRepo,
 	NewMCPRepository,
 	NewContributeRepo,
+	NewCuratedAnswerRepository,
+	NewImageCaptionRepository,
+	NewEvalRepository,
+	NewNodeChunkEditRepository,
+	NewSettingRepository,
+	NewNodeTranslationRepository,
+	NewSummaryJobRepository,
+	NewGitSourceRepository,
+	NewCrawlerSubscriptionRepository,
+	NewImportJobRepository,
+	NewLinkCheckRepository,
+	NewNodeLinkRepository,
+	NewNodeImportJobRepository,
 )
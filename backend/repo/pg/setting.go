@@ -2,6 +2,7 @@ package pg
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 
 	"github.com/chaitin/panda-wiki/domain"
@@ -62,3 +63,49 @@ func (r *SettingRepository) UpdateSetting(ctx context.Context, kbID, key string,
 	}
 	return r.CreateSetting(ctx, newSetting)
 }
+
+// getJSONSetting unmarshals the setting into v, v is left untouched if the setting does not exist
+func (r *SettingRepository) getJSONSetting(ctx context.Context, kbID, key string, v any) error {
+	setting, err := r.GetSetting(ctx, kbID, key)
+	if err != nil {
+		return err
+	}
+	if setting == nil {
+		return nil
+	}
+	return json.Unmarshal(setting.Value, v)
+}
+
+func (r *SettingRepository) updateJSONSetting(ctx context.Context, kbID, key string, v any) error {
+	value, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return r.UpdateSetting(ctx, kbID, key, value)
+}
+
+// GetChunkSetting returns the chunk setting of the kb, the default setting is returned if not set
+func (r *SettingRepository) GetChunkSetting(ctx context.Context, kbID string) (*domain.ChunkSetting, error) {
+	chunkSetting := domain.DefaultChunkSetting()
+	if err := r.getJSONSetting(ctx, kbID, domain.SettingKeyChunk, chunkSetting); err != nil {
+		return nil, err
+	}
+	return chunkSetting, nil
+}
+
+func (r *SettingRepository) UpdateChunkSetting(ctx context.Context, kbID string, chunkSetting *domain.ChunkSetting) error {
+	return r.updateJSONSetting(ctx, kbID, domain.SettingKeyChunk, chunkSetting)
+}
+
+// GetLanguageSetting returns the language setting of the kb, the default setting is returned if not set
+func (r *SettingRepository) GetLanguageSetting(ctx context.Context, kbID string) (*domain.LanguageSetting, error) {
+	languageSetting := domain.DefaultLanguageSetting()
+	if err := r.getJSONSetting(ctx, kbID, domain.SettingKeyLanguage, languageSetting); err != nil {
+		return nil, err
+	}
+	return languageSetting, nil
+}
+
+func (r *SettingRepository) UpdateLanguageSetting(ctx context.Context, kbID string, languageSetting *domain.LanguageSetting) error {
+	return r.updateJSONSetting(ctx, kbID, domain.SettingKeyLanguage, languageSetting)
+}
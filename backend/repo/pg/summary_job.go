@@ -0,0 +1,296 @@
+package pg
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/store/pg"
+)
+
+type SummaryJobRepository struct {
+	db     *pg.DB
+	logger *log.Logger
+}
+
+func NewSummaryJobRepository(db *pg.DB, logger *log.Logger) *SummaryJobRepository {
+	return &SummaryJobRepository{db: db, logger: logger.WithModule("repo.pg.summary_job")}
+}
+
+// Create creates the job with its items
+func (r *SummaryJobRepository) Create(ctx context.Context, job *domain.SummaryJob, items []*domain.SummaryJobItem) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(job).Error; err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(items, 100).Error
+	})
+}
+
+func (r *SummaryJobRepository) GetByID(ctx context.Context, kbID, id string) (*domain.SummaryJob, error) {
+	var job domain.SummaryJob
+	query := r.db.WithContext(ctx).Model(&domain.SummaryJob{}).Where("id = ?", id)
+	if kbID != "" {
+		query = query.Where("kb_id = ?", kbID)
+	}
+	if err := query.First(&job).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// GetActive returns the pending or running job of the kb, nil if there is none
+func (r *SummaryJobRepository) GetActive(ctx context.Context, kbID string) (*domain.SummaryJob, error) {
+	jobs := make([]*domain.SummaryJob, 0, 1)
+	if err := r.db.WithContext(ctx).
+		Model(&domain.SummaryJob{}).
+		Where("kb_id = ? AND status IN ?", kbID, []domain.SummaryJobStatus{domain.SummaryJobStatusPending, domain.SummaryJobStatusRunning}).
+		Limit(1).
+		Find(&jobs).Error; err != nil {
+		return nil, err
+	}
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+	return jobs[0], nil
+}
+
+func (r *SummaryJobRepository) GetList(ctx context.Context, req *domain.SummaryJobListReq) ([]*domain.SummaryJob, int64, error) {
+	jobs := make([]*domain.SummaryJob, 0)
+	query := r.db.WithContext(ctx).Model(&domain.SummaryJob{}).Where("kb_id = ?", req.KBID)
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := query.Order("created_at DESC").
+		Offset(req.Offset()).
+		Limit(req.Limit()).
+		Find(&jobs).Error; err != nil {
+		return nil, 0, err
+	}
+	return jobs, count, nil
+}
+
+// GetProgress counts the items of the jobs by status
+func (r *SummaryJobRepository) GetProgress(ctx context.Context, jobIDs []string) (map[string]domain.SummaryJobProgress, error) {
+	var rows []struct {
+		JobID  string
+		Status domain.SummaryJobStatus
+		Count  int
+	}
+	if err := r.db.WithContext(ctx).
+		Model(&domain.SummaryJobItem{}).
+		Select("job_id, status, COUNT(*) AS count").
+		Where("job_id IN ?", jobIDs).
+		Group("job_id, status").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	counts := make(map[string]map[domain.SummaryJobStatus]int, len(jobIDs))
+	for _, row := range rows {
+		if counts[row.JobID] == nil {
+			counts[row.JobID] = make(map[domain.SummaryJobStatus]int)
+		}
+		counts[row.JobID][row.Status] = row.Count
+	}
+	progress := make(map[string]domain.SummaryJobProgress, len(jobIDs))
+	for _, jobID := range jobIDs {
+		progress[jobID] = domain.NewSummaryJobProgress(counts[jobID])
+	}
+	return progress, nil
+}
+
+func (r *SummaryJobRepository) GetItems(ctx context.Context, jobID string, statuses ...domain.SummaryJobStatus) ([]*domain.SummaryJobItem, error) {
+	items := make([]*domain.SummaryJobItem, 0)
+	query := r.db.WithContext(ctx).Model(&domain.SummaryJobItem{}).Where("job_id = ?", jobID)
+	if len(statuses) > 0 {
+		query = query.Where("status IN ?", statuses)
+	}
+	if err := query.Order("node_name ASC").Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// Start marks a pending job as running, false when the job was already picked up
+func (r *SummaryJobRepository) Start(ctx context.Context, id string, startedAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&domain.SummaryJob{}).
+		Where("id = ? AND status = ?", id, domain.SummaryJobStatusPending).
+		Updates(map[string]any{
+			"status":       domain.SummaryJobStatusRunning,
+			"error":        "",
+			"started_at":   startedAt,
+			"heartbeat_at": startedAt,
+			"finished_at":  nil,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Finish saves the outcome of a running job, a job canceled in the meantime stays canceled
+func (r *SummaryJobRepository) Finish(ctx context.Context, job *domain.SummaryJob) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.SummaryJob{}).
+		Where("id = ? AND status = ?", job.ID, domain.SummaryJobStatusRunning).
+		Updates(map[string]any{
+			"status":      job.Status,
+			"error":       job.Error,
+			"finished_at": job.FinishedAt,
+		}).Error
+}
+
+// Retry resets the failed items and the job to pending, false when the job is still active
+func (r *SummaryJobRepository) Retry(ctx context.Context, kbID, id string) (bool, error) {
+	retried := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.SummaryJob{}).
+			Where("kb_id = ? AND id = ?", kbID, id).
+			Where("status IN ?", []domain.SummaryJobStatus{domain.SummaryJobStatusCompleted, domain.SummaryJobStatusFailed}).
+			Updates(map[string]any{
+				"status":       domain.SummaryJobStatusPending,
+				"error":        "",
+				"heartbeat_at": time.Now(),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		retried = true
+		// items left running by a crashed consumer are retried as well
+		return tx.Model(&domain.SummaryJobItem{}).
+			Where("job_id = ? AND status IN ?", id, []domain.SummaryJobStatus{domain.SummaryJobStatusFailed, domain.SummaryJobStatusRunning}).
+			Updates(map[string]any{
+				"status":     domain.SummaryJobStatusPending,
+				"error":      "",
+				"updated_at": time.Now(),
+			}).Error
+	})
+	return retried, err
+}
+
+// Fail marks a pending or running job and its unfinished items as failed, false when the job already finished.
+// items that are being summarized finish normally, the others are skipped by the consumer
+func (r *SummaryJobRepository) Fail(ctx context.Context, kbID, id, reason string) (bool, error) {
+	failed := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		query := tx.Model(&domain.SummaryJob{}).Where("id = ?", id)
+		if kbID != "" {
+			query = query.Where("kb_id = ?", kbID)
+		}
+		result := query.
+			Where("status IN ?", []domain.SummaryJobStatus{domain.SummaryJobStatusPending, domain.SummaryJobStatusRunning}).
+			Updates(map[string]any{
+				"status":      domain.SummaryJobStatusFailed,
+				"error":       reason,
+				"finished_at": now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		failed = true
+		return tx.Model(&domain.SummaryJobItem{}).
+			Where("job_id = ? AND status = ?", id, domain.SummaryJobStatusPending).
+			Updates(map[string]any{
+				"status":     domain.SummaryJobStatusFailed,
+				"error":      reason,
+				"updated_at": now,
+			}).Error
+	})
+	return failed, err
+}
+
+// StartItem marks a pending item as running and moves the heartbeat of its job forward,
+// false when the item is no longer pending, e.g. because the job was canceled
+func (r *SummaryJobRepository) StartItem(ctx context.Context, item *domain.SummaryJobItem) (bool, error) {
+	started := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.SummaryJobItem{}).
+			Where("id = ? AND status = ?", item.ID, domain.SummaryJobStatusPending).
+			Updates(map[string]any{
+				"status":     domain.SummaryJobStatusRunning,
+				"error":      "",
+				"updated_at": item.UpdatedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		started = true
+		return tx.Model(&domain.SummaryJob{}).
+			Where("id = ?", item.JobID).
+			Update("heartbeat_at", item.UpdatedAt).Error
+	})
+	return started, err
+}
+
+// UpdateItem saves the state of the item and moves the heartbeat of its job forward
+func (r *SummaryJobRepository) UpdateItem(ctx context.Context, item *domain.SummaryJobItem) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.SummaryJobItem{}).
+			Where("id = ?", item.ID).
+			Updates(map[string]any{
+				"status":     item.Status,
+				"error":      item.Error,
+				"updated_at": item.UpdatedAt,
+			}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&domain.SummaryJob{}).
+			Where("id = ?", item.JobID).
+			Update("heartbeat_at", item.UpdatedAt).Error
+	})
+}
+
+// ResumeStale marks the jobs that were never picked up or stopped making progress as pending and returns their ids.
+// items left running by the lost consumer are summarized again, the heartbeat is moved forward so that the jobs
+// are not resumed again before they had the time to run
+func (r *SummaryJobRepository) ResumeStale(ctx context.Context, now time.Time) ([]string, error) {
+	ids := make([]string, 0)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		jobs := make([]*domain.SummaryJob, 0)
+		if err := tx.Model(&jobs).
+			Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}}}).
+			Where("status IN ?", []domain.SummaryJobStatus{domain.SummaryJobStatusPending, domain.SummaryJobStatusRunning}).
+			Where("COALESCE(heartbeat_at, created_at) < ?", now.Add(-domain.SummaryJobStaleAfter)).
+			Updates(map[string]any{
+				"status":       domain.SummaryJobStatusPending,
+				"heartbeat_at": now,
+			}).Error; err != nil {
+			return err
+		}
+		for _, job := range jobs {
+			ids = append(ids, job.ID)
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		return tx.Model(&domain.SummaryJobItem{}).
+			Where("job_id IN ? AND status = ?", ids, domain.SummaryJobStatusRunning).
+			Updates(map[string]any{
+				"status":     domain.SummaryJobStatusPending,
+				"updated_at": now,
+			}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
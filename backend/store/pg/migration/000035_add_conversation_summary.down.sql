@@ -0,0 +1,2 @@
+ALTER TABLE conversations DROP COLUMN IF EXISTS summary;
+ALTER TABLE conversations DROP COLUMN IF EXISTS summary_message_id;
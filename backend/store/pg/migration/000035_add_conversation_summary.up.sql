@@ -0,0 +1,2 @@
+ALTER TABLE conversations ADD COLUMN IF NOT EXISTS summary text default '';
+ALTER TABLE conversations ADD COLUMN IF NOT EXISTS summary_message_id text default '';
@@ -0,0 +1,13 @@
+-- link legacy questions to the message they followed so that every conversation forms a tree
+UPDATE conversation_messages AS m
+SET parent_id = prev.prev_id
+FROM (
+    SELECT id, LAG(id) OVER (PARTITION BY conversation_id ORDER BY created_at) AS prev_id
+    FROM conversation_messages
+) AS prev
+WHERE m.id = prev.id
+  AND m.role = 'user'
+  AND (m.parent_id IS NULL OR m.parent_id = '')
+  AND prev.prev_id IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_conversation_messages_parent_id ON conversation_messages(parent_id);
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS curated_answers (
+    id TEXT PRIMARY KEY,
+    kb_id TEXT NOT NULL,
+    question TEXT NOT NULL,
+    answer TEXT NOT NULL,
+    embedding REAL[] NOT NULL DEFAULT '{}',
+    embedding_model TEXT NOT NULL DEFAULT '',
+    source_message_id TEXT NOT NULL DEFAULT '',
+    hit_count BIGINT NOT NULL DEFAULT 0,
+    last_hit_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_curated_answers_kb_id ON curated_answers(kb_id);
@@ -0,0 +1,2 @@
+ALTER TABLE conversation_messages DROP COLUMN IF EXISTS images;
+ALTER TABLE conversation_messages DROP COLUMN IF EXISTS image_description;
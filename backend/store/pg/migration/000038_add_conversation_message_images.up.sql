@@ -0,0 +1,2 @@
+ALTER TABLE conversation_messages ADD COLUMN IF NOT EXISTS images text[] NOT NULL DEFAULT '{}';
+ALTER TABLE conversation_messages ADD COLUMN IF NOT EXISTS image_description text NOT NULL DEFAULT '';
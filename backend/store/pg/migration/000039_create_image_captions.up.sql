@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS image_captions (
+    hash TEXT PRIMARY KEY,
+    model TEXT NOT NULL DEFAULT '',
+    content TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,56 @@
+CREATE TABLE IF NOT EXISTS eval_sets (
+    id TEXT PRIMARY KEY,
+    kb_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_eval_sets_kb_id ON eval_sets(kb_id);
+
+CREATE TABLE IF NOT EXISTS eval_questions (
+    id TEXT PRIMARY KEY,
+    set_id TEXT NOT NULL,
+    kb_id TEXT NOT NULL,
+    question TEXT NOT NULL,
+    expected_node_ids TEXT[] NOT NULL DEFAULT '{}',
+    reference_answer TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_eval_questions_set_id ON eval_questions(set_id);
+
+CREATE TABLE IF NOT EXISTS eval_runs (
+    id TEXT PRIMARY KEY,
+    set_id TEXT NOT NULL,
+    kb_id TEXT NOT NULL,
+    status TEXT NOT NULL,
+    error TEXT NOT NULL DEFAULT '',
+    config JSONB NOT NULL DEFAULT '{}',
+    metrics JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_eval_runs_set_id ON eval_runs(set_id);
+
+CREATE TABLE IF NOT EXISTS eval_results (
+    id TEXT PRIMARY KEY,
+    run_id TEXT NOT NULL,
+    question_id TEXT NOT NULL,
+    question TEXT NOT NULL,
+    answer TEXT NOT NULL DEFAULT '',
+    retrieved_node_ids TEXT[] NOT NULL DEFAULT '{}',
+    hit BOOLEAN NOT NULL DEFAULT FALSE,
+    reciprocal_rank REAL NOT NULL DEFAULT 0,
+    faithfulness REAL,
+    correctness REAL,
+    judge_reason TEXT NOT NULL DEFAULT '',
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_eval_results_run_id ON eval_results(run_id);
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS node_chunk_edits (
+    id TEXT PRIMARY KEY,
+    kb_id TEXT NOT NULL,
+    node_id TEXT NOT NULL,
+    type TEXT NOT NULL,
+    source_hash TEXT NOT NULL DEFAULT '',
+    content TEXT NOT NULL DEFAULT '',
+    keywords TEXT[] NOT NULL DEFAULT '{}',
+    questions TEXT[] NOT NULL DEFAULT '{}',
+    available BOOLEAN NOT NULL DEFAULT TRUE,
+    status TEXT NOT NULL DEFAULT 'applied',
+    doc_id TEXT NOT NULL DEFAULT '',
+    chunk_id TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_node_chunk_edits_node_id ON node_chunk_edits(node_id);
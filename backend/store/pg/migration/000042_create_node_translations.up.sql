@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS node_translations (
+    id TEXT PRIMARY KEY,
+    kb_id TEXT NOT NULL,
+    source_node_id TEXT NOT NULL,
+    node_id TEXT NOT NULL DEFAULT '',
+    language TEXT NOT NULL,
+    source_hash TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'pending',
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_node_translations_source_language ON node_translations(source_node_id, language);
+CREATE INDEX IF NOT EXISTS idx_node_translations_node_id ON node_translations(node_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS summary_job_items;
+DROP TABLE IF EXISTS summary_jobs;
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS summary_jobs (
+    id TEXT PRIMARY KEY,
+    kb_id TEXT NOT NULL,
+    status TEXT NOT NULL,
+    concurrency INT NOT NULL DEFAULT 1,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_summary_jobs_kb_id ON summary_jobs(kb_id);
+
+CREATE TABLE IF NOT EXISTS summary_job_items (
+    id TEXT PRIMARY KEY,
+    job_id TEXT NOT NULL,
+    node_id TEXT NOT NULL,
+    node_name TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL,
+    error TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_summary_job_items_job_id_node_id ON summary_job_items(job_id, node_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS git_source_images;
+DROP TABLE IF EXISTS git_source_nodes;
+DROP TABLE IF EXISTS git_sources;
@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS git_sources (
+    id TEXT PRIMARY KEY,
+    kb_id TEXT NOT NULL,
+    url TEXT NOT NULL DEFAULT '',
+    local_path TEXT NOT NULL DEFAULT '',
+    branch TEXT NOT NULL,
+    dir TEXT NOT NULL DEFAULT '',
+    parent_id TEXT NOT NULL DEFAULT '',
+    auto_release BOOLEAN NOT NULL DEFAULT FALSE,
+    last_commit TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'idle',
+    error TEXT NOT NULL DEFAULT '',
+    result JSONB NOT NULL DEFAULT '{}',
+    synced_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_git_sources_kb_id ON git_sources(kb_id);
+
+CREATE TABLE IF NOT EXISTS git_source_nodes (
+    source_id TEXT NOT NULL,
+    path TEXT NOT NULL,
+    node_id TEXT NOT NULL,
+    type SMALLINT NOT NULL,
+    PRIMARY KEY (source_id, path)
+);
+
+CREATE INDEX IF NOT EXISTS idx_git_source_nodes_node_id ON git_source_nodes(node_id);
+
+CREATE TABLE IF NOT EXISTS git_source_images (
+    source_id TEXT NOT NULL,
+    path TEXT NOT NULL,
+    hash TEXT NOT NULL,
+    url TEXT NOT NULL,
+    PRIMARY KEY (source_id, path)
+);
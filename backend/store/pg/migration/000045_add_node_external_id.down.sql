@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_nodes_kb_id_external_id;
+ALTER TABLE nodes DROP COLUMN IF EXISTS external_id;
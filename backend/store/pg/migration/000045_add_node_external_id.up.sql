@@ -0,0 +1,3 @@
+ALTER TABLE nodes ADD COLUMN IF NOT EXISTS external_id text NOT NULL DEFAULT '';
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_nodes_kb_id_external_id ON nodes(kb_id, external_id) WHERE external_id <> '';
@@ -0,0 +1,44 @@
+CREATE TABLE IF NOT EXISTS crawler_subscriptions (
+    id TEXT PRIMARY KEY,
+    kb_id TEXT NOT NULL,
+    source TEXT NOT NULL,
+    url TEXT NOT NULL,
+    parent_id TEXT NOT NULL DEFAULT '',
+    policy TEXT NOT NULL DEFAULT 'draft',
+    delete_removed BOOLEAN NOT NULL DEFAULT FALSE,
+    interval_hours INT NOT NULL DEFAULT 24,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    creator_id TEXT NOT NULL DEFAULT '',
+    max_node INT NOT NULL DEFAULT 0,
+    etag TEXT NOT NULL DEFAULT '',
+    last_modified TEXT NOT NULL DEFAULT '',
+    content_hash TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'idle',
+    error TEXT NOT NULL DEFAULT '',
+    result JSONB NOT NULL DEFAULT '{}',
+    started_at TIMESTAMPTZ,
+    synced_at TIMESTAMPTZ,
+    next_sync_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_crawler_subscriptions_kb_id ON crawler_subscriptions(kb_id);
+CREATE INDEX IF NOT EXISTS idx_crawler_subscriptions_next_sync_at ON crawler_subscriptions(next_sync_at) WHERE enabled;
+
+CREATE TABLE IF NOT EXISTS node_sources (
+    node_id TEXT PRIMARY KEY,
+    kb_id TEXT NOT NULL,
+    subscription_id TEXT NOT NULL DEFAULT '',
+    source TEXT NOT NULL,
+    doc_id TEXT NOT NULL,
+    url TEXT NOT NULL DEFAULT '',
+    etag TEXT NOT NULL DEFAULT '',
+    last_modified TEXT NOT NULL DEFAULT '',
+    content_hash TEXT NOT NULL DEFAULT '',
+    removed BOOLEAN NOT NULL DEFAULT FALSE,
+    synced_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_node_sources_kb_id ON node_sources(kb_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_node_sources_subscription_doc ON node_sources(subscription_id, doc_id) WHERE subscription_id <> '';
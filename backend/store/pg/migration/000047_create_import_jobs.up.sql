@@ -0,0 +1,39 @@
+CREATE TABLE IF NOT EXISTS import_jobs (
+    id TEXT PRIMARY KEY,
+    kb_id TEXT NOT NULL,
+    source TEXT NOT NULL,
+    parse_id TEXT NOT NULL,
+    parent_id TEXT NOT NULL DEFAULT '',
+    concurrency INT NOT NULL DEFAULT 1,
+    status TEXT NOT NULL,
+    error TEXT NOT NULL DEFAULT '',
+    creator_id TEXT NOT NULL DEFAULT '',
+    max_node INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMPTZ,
+    heartbeat_at TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_import_jobs_kb_id ON import_jobs(kb_id);
+CREATE INDEX IF NOT EXISTS idx_import_jobs_status ON import_jobs(status);
+
+CREATE TABLE IF NOT EXISTS import_job_items (
+    id TEXT PRIMARY KEY,
+    job_id TEXT NOT NULL,
+    doc_id TEXT NOT NULL,
+    title TEXT NOT NULL DEFAULT '',
+    file_type TEXT NOT NULL DEFAULT '',
+    space_id TEXT NOT NULL DEFAULT '',
+    folders TEXT[] NOT NULL DEFAULT '{}',
+    position INT NOT NULL DEFAULT 0,
+    status TEXT NOT NULL,
+    task_id TEXT NOT NULL DEFAULT '',
+    node_id TEXT NOT NULL DEFAULT '',
+    error TEXT NOT NULL DEFAULT '',
+    attempts INT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_import_job_items_job_id_doc_id ON import_job_items(job_id, doc_id);
+CREATE INDEX IF NOT EXISTS idx_import_job_items_job_id_status ON import_job_items(job_id, status);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS node_links (
+    kb_id TEXT NOT NULL,
+    source_id TEXT NOT NULL,
+    kind TEXT NOT NULL,
+    target TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (source_id, kind, target)
+);
+
+CREATE INDEX IF NOT EXISTS idx_node_links_kb_id_target ON node_links(kb_id, target);
+CREATE INDEX IF NOT EXISTS idx_node_links_kb_id_lower_target ON node_links(kb_id, LOWER(target)) WHERE kind = 'wiki';
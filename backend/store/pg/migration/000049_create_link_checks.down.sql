@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS link_check_schedules;
+DROP TABLE IF EXISTS link_check_results;
+DROP TABLE IF EXISTS link_check_jobs;
@@ -0,0 +1,43 @@
+CREATE TABLE IF NOT EXISTS link_check_jobs (
+    id TEXT PRIMARY KEY,
+    kb_id TEXT NOT NULL,
+    release_id TEXT NOT NULL DEFAULT '',
+    concurrency INT NOT NULL DEFAULT 1,
+    timeout_seconds INT NOT NULL DEFAULT 10,
+    scheduled BOOLEAN NOT NULL DEFAULT FALSE,
+    status TEXT NOT NULL,
+    error TEXT NOT NULL DEFAULT '',
+    total INT NOT NULL DEFAULT 0,
+    broken INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_link_check_jobs_kb_id ON link_check_jobs(kb_id);
+CREATE INDEX IF NOT EXISTS idx_link_check_jobs_status ON link_check_jobs(status);
+
+CREATE TABLE IF NOT EXISTS link_check_results (
+    id TEXT PRIMARY KEY,
+    job_id TEXT NOT NULL,
+    node_id TEXT NOT NULL,
+    node_name TEXT NOT NULL DEFAULT '',
+    kind TEXT NOT NULL,
+    url TEXT NOT NULL,
+    broken BOOLEAN NOT NULL DEFAULT FALSE,
+    status_code INT NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    checked_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_link_check_results_job_id_broken ON link_check_results(job_id, broken);
+
+CREATE TABLE IF NOT EXISTS link_check_schedules (
+    kb_id TEXT PRIMARY KEY,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    interval_hours INT NOT NULL DEFAULT 0,
+    concurrency INT NOT NULL DEFAULT 8,
+    timeout_seconds INT NOT NULL DEFAULT 10,
+    next_run_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
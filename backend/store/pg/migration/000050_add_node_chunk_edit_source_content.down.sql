@@ -0,0 +1 @@
+ALTER TABLE node_chunk_edits DROP COLUMN IF EXISTS source_content;
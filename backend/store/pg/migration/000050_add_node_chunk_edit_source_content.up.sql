@@ -0,0 +1 @@
+ALTER TABLE node_chunk_edits ADD COLUMN IF NOT EXISTS source_content TEXT NOT NULL DEFAULT '';
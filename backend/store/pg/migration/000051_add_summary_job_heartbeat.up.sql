@@ -0,0 +1,3 @@
+ALTER TABLE summary_jobs ADD COLUMN IF NOT EXISTS heartbeat_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_summary_jobs_status ON summary_jobs(status);
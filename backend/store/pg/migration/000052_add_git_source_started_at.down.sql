@@ -0,0 +1 @@
+ALTER TABLE git_sources DROP COLUMN IF EXISTS started_at;
@@ -0,0 +1 @@
+ALTER TABLE git_sources ADD COLUMN IF NOT EXISTS started_at TIMESTAMPTZ;
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS node_import_jobs (
+    id TEXT PRIMARY KEY,
+    kb_id TEXT NOT NULL,
+    parent_id TEXT NOT NULL DEFAULT '',
+    source TEXT NOT NULL,
+    key TEXT NOT NULL,
+    name TEXT NOT NULL DEFAULT '',
+    split_level INT NOT NULL DEFAULT 0,
+    status TEXT NOT NULL,
+    error TEXT NOT NULL DEFAULT '',
+    total INT NOT NULL DEFAULT 0,
+    done INT NOT NULL DEFAULT 0,
+    result JSONB,
+    creator_id TEXT NOT NULL DEFAULT '',
+    max_node INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMPTZ,
+    heartbeat_at TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_node_import_jobs_kb_id ON node_import_jobs(kb_id);
+CREATE INDEX IF NOT EXISTS idx_node_import_jobs_status ON node_import_jobs(status);
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
 	"github.com/cloudwego/eino/schema"
@@ -94,7 +96,9 @@ func (s *CTRAG) QueryRecords(ctx context.Context, datasetIDs []string, query str
 	return nodeChunks, nil
 }
 
-func (s *CTRAG) UpsertRecords(ctx context.Context, datasetID string, nodeRelease *domain.NodeReleaseWithDirPath, groupIds []int) (string, error) {
+// UpsertRecords uploads the node as a new document, with the heading strategy the document is not parsed
+// by the rag service and the chunks split by PandaWiki are added instead
+func (s *CTRAG) UpsertRecords(ctx context.Context, datasetID string, nodeRelease *domain.NodeReleaseWithDirPath, groupIds []int, chunkSetting *domain.ChunkSetting) (string, error) {
 	// create new doc and return new_doc.doc_id
 	tempFile, err := os.CreateTemp("", fmt.Sprintf("%s-*.md", nodeRelease.ID))
 	if err != nil {
@@ -115,19 +119,41 @@ func (s *CTRAG) UpsertRecords(ctx context.Context, datasetID string, nodeRelease
 		return "", fmt.Errorf("close temp file failed: %w", err)
 	}
 	defer os.Remove(tempFile.Name())
-	docs, err := s.client.UploadDocumentsAndParse(ctx, datasetID, []string{tempFile.Name()}, groupIds, &rag.DocumentMetadata{
+	metadata := &rag.DocumentMetadata{
 		DocumentName: nodeRelease.Name,
 		CreatedAt:    nodeRelease.CreatedAt.String(),
 		UpdatedAt:    nodeRelease.UpdatedAt.String(),
 		FolderName:   nodeRelease.Path,
-	})
+	}
+	if chunkSetting == nil || chunkSetting.Strategy != domain.ChunkStrategyHeading {
+		docs, err := s.client.UploadDocumentsAndParse(ctx, datasetID, []string{tempFile.Name()}, groupIds, metadata)
+		if err != nil {
+			return "", fmt.Errorf("upload document text failed: %w", err)
+		}
+		if len(docs) == 0 {
+			return "", fmt.Errorf("no docs found")
+		}
+		return docs[0].ID, nil
+	}
+
+	docs, err := s.client.UploadDocuments(ctx, datasetID, []string{tempFile.Name()}, groupIds, metadata)
 	if err != nil {
 		return "", fmt.Errorf("upload document text failed: %w", err)
 	}
 	if len(docs) == 0 {
 		return "", fmt.Errorf("no docs found")
 	}
-	return docs[0].ID, nil
+	docID := docs[0].ID
+	path := append(strings.FieldsFunc(nodeRelease.Path, func(r rune) bool { return r == '/' }), nodeRelease.Name)
+	for _, chunk := range domain.SplitMarkdownByHeading(markdown, path, chunkSetting) {
+		if _, err := s.client.AddChunk(ctx, datasetID, docID, rag.AddChunkRequest{Content: chunk}); err != nil {
+			if err := s.client.DeleteDocuments(ctx, datasetID, []string{docID}); err != nil {
+				s.logger.Warn("delete partially chunked document failed", log.String("doc_id", docID), log.Error(err))
+			}
+			return "", fmt.Errorf("add chunk failed: %w", err)
+		}
+	}
+	return docID, nil
 }
 
 func (s *CTRAG) DeleteRecords(ctx context.Context, datasetID string, docIDs []string) error {
@@ -236,3 +262,64 @@ func (s *CTRAG) ListDocuments(ctx context.Context, datasetID string, params map[
 	}
 	return docs, nil
 }
+
+const listChunksPageSize = 100
+
+func (s *CTRAG) ListChunks(ctx context.Context, datasetID, docID string) ([]*domain.RAGChunk, error) {
+	chunks := make([]*domain.RAGChunk, 0)
+	for page := 1; ; page++ {
+		items, total, err := s.client.ListChunks(ctx, datasetID, docID, map[string]string{
+			"page":      strconv.Itoa(page),
+			"page_size": strconv.Itoa(listChunksPageSize),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("list chunks failed: %w", err)
+		}
+		for _, item := range items {
+			chunks = append(chunks, toRAGChunk(item))
+		}
+		if len(items) < listChunksPageSize || len(chunks) >= total {
+			return chunks, nil
+		}
+	}
+}
+
+func (s *CTRAG) AddChunk(ctx context.Context, datasetID, docID string, chunk *domain.RAGChunk) (*domain.RAGChunk, error) {
+	added, err := s.client.AddChunk(ctx, datasetID, docID, rag.AddChunkRequest{
+		Content:           chunk.Content,
+		ImportantKeywords: chunk.Keywords,
+		Questions:         chunk.Questions,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("add chunk failed: %w", err)
+	}
+	return toRAGChunk(*added), nil
+}
+
+func (s *CTRAG) UpdateChunk(ctx context.Context, datasetID, docID, chunkID string, req *domain.UpdateRAGChunkReq) error {
+	if err := s.client.UpdateChunk(ctx, datasetID, docID, chunkID, rag.UpdateChunkRequest{
+		Content:           req.Content,
+		ImportantKeywords: req.Keywords,
+		Available:         req.Available,
+	}); err != nil {
+		return fmt.Errorf("update chunk failed: %w", err)
+	}
+	return nil
+}
+
+func (s *CTRAG) DeleteChunks(ctx context.Context, datasetID, docID string, chunkIDs []string) error {
+	if err := s.client.DeleteChunks(ctx, datasetID, docID, chunkIDs); err != nil {
+		return fmt.Errorf("delete chunks failed: %w", err)
+	}
+	return nil
+}
+
+func toRAGChunk(chunk rag.Chunk) *domain.RAGChunk {
+	return &domain.RAGChunk{
+		ID:        chunk.ID,
+		Content:   chunk.Content,
+		Keywords:  chunk.ImportantKeywords,
+		Questions: chunk.Questions,
+		Available: chunk.Available,
+	}
+}
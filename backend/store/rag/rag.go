@@ -16,13 +16,18 @@ import (
 
 type RAGService interface {
 	CreateKnowledgeBase(ctx context.Context) (string, error)
-	UpsertRecords(ctx context.Context, datasetID string, nodeRelease *domain.NodeReleaseWithDirPath, authGroupId []int) (string, error)
+	UpsertRecords(ctx context.Context, datasetID string, nodeRelease *domain.NodeReleaseWithDirPath, authGroupId []int, chunkSetting *domain.ChunkSetting) (string, error)
 	QueryRecords(ctx context.Context, datasetIDs []string, query string, groupIDs []int, similarityThreshold float64, historyMsgs []*schema.Message) ([]*domain.NodeContentChunk, error)
 	DeleteRecords(ctx context.Context, datasetID string, docIDs []string) error
 	DeleteKnowledgeBase(ctx context.Context, datasetID string) error
 	UpdateDocumentGroupIDs(ctx context.Context, datasetID string, docID string, groupIds []int) error
 	ListDocuments(ctx context.Context, datasetID string, params map[string]string) ([]rag.Document, error)
 
+	ListChunks(ctx context.Context, datasetID, docID string) ([]*domain.RAGChunk, error)
+	AddChunk(ctx context.Context, datasetID, docID string, chunk *domain.RAGChunk) (*domain.RAGChunk, error)
+	UpdateChunk(ctx context.Context, datasetID, docID, chunkID string, req *domain.UpdateRAGChunkReq) error
+	DeleteChunks(ctx context.Context, datasetID, docID string, chunkIDs []string) error
+
 	GetModelList(ctx context.Context) ([]*domain.Model, error)
 	AddModel(ctx context.Context, model *domain.Model) (string, error)
 	UpdateModel(ctx context.Context, model *domain.Model) error
@@ -184,7 +184,7 @@ func (u *AppUsecase) UpdateApp(ctx context.Context, id string, appRequest *domai
 }
 
 func (u *AppUsecase) getQAFunc(kbID string, appType domain.AppType) bot.GetQAFun {
-	return func(ctx context.Context, msg string, info domain.ConversationInfo, ConversationID string) (chan string, error) {
+	return func(ctx context.Context, msg string, images []string, info domain.ConversationInfo, ConversationID string) (chan string, error) {
 		auth, err := u.authRepo.GetAuthByKBIDAndSourceType(ctx, kbID, appType.ToSourceType())
 		if err != nil {
 			u.logger.Error("get auth failed", log.Error(err))
@@ -194,6 +194,7 @@ func (u *AppUsecase) getQAFunc(kbID string, appType domain.AppType) bot.GetQAFun
 
 		eventCh, err := u.chatUsecase.Chat(ctx, &domain.ChatRequest{
 			Message:        msg,
+			Images:         images,
 			KBID:           kbID,
 			AppType:        appType,
 			RemoteIP:       "",
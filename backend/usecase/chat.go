@@ -2,17 +2,21 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	modelkit "github.com/chaitin/ModelKit/v2/usecase"
 	"github.com/cloudwego/eino/schema"
 	"github.com/google/uuid"
+	"github.com/samber/lo"
 	"gorm.io/gorm"
 
 	"github.com/chaitin/panda-wiki/domain"
 	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/repo/cache"
 	"github.com/chaitin/panda-wiki/repo/pg"
 	"github.com/chaitin/panda-wiki/utils"
 )
@@ -21,35 +25,145 @@ type ChatUsecase struct {
 	llmUsecase          *LLMUsecase
 	conversationUsecase *ConversationUsecase
 	modelUsecase        *ModelUsecase
+	curatedAnswer       *CuratedAnswerUsecase
+	imageUsecase        *ImageUsecase
 	appRepo             *pg.AppRepository
 	blockWordRepo       *pg.BlockWordRepo
 	kbRepo              *pg.KnowledgeBaseRepository
 	AuthRepo            *pg.AuthRepo
+	chatStreamRepo      *cache.ChatStreamRepo
 	logger              *log.Logger
 	modelkit            *modelkit.ModelKit
+
+	// cancel funcs of the answers generated by this instance, keyed by stream id
+	generations   map[string]context.CancelFunc
+	generationsMu sync.Mutex
 }
 
+const (
+	chatStreamEOF         = "eof" // internal event closing the stream log, never sent to clients
+	chatResumeBlockTime   = 5 * time.Second
+	chatResumeIdleTimeout = 2 * time.Minute
+)
+
 func NewChatUsecase(llmUsecase *LLMUsecase, kbRepo *pg.KnowledgeBaseRepository, conversationUsecase *ConversationUsecase, modelUsecase *ModelUsecase, appRepo *pg.AppRepository,
-	blockWordRepo *pg.BlockWordRepo, authRepo *pg.AuthRepo, logger *log.Logger) (*ChatUsecase, error) {
+	blockWordRepo *pg.BlockWordRepo, authRepo *pg.AuthRepo, chatStreamRepo *cache.ChatStreamRepo, curatedAnswer *CuratedAnswerUsecase, imageUsecase *ImageUsecase, logger *log.Logger) (*ChatUsecase, error) {
 	modelkit := modelkit.NewModelKit(logger.Logger)
 	u := &ChatUsecase{
 		llmUsecase:          llmUsecase,
 		conversationUsecase: conversationUsecase,
 		modelUsecase:        modelUsecase,
+		curatedAnswer:       curatedAnswer,
+		imageUsecase:        imageUsecase,
 		appRepo:             appRepo,
 		blockWordRepo:       blockWordRepo,
 		kbRepo:              kbRepo,
 		AuthRepo:            authRepo,
+		chatStreamRepo:      chatStreamRepo,
 		logger:              logger.WithModule("usecase.chat"),
 		modelkit:            modelkit,
+		generations:         make(map[string]context.CancelFunc),
 	}
 	if err := u.initDFA(); err != nil {
 		u.logger.Error("failed to init dfa", log.Error(err))
 		return nil, err
 	}
+	go u.chatStreamRepo.SubscribeStop(context.Background(), u.cancelGeneration)
 	return u, nil
 }
 
+func chatStreamID(conversationID, messageID string) string {
+	return conversationID + "_" + messageID
+}
+
+func (u *ChatUsecase) registerGeneration(streamID string, cancel context.CancelFunc) {
+	u.generationsMu.Lock()
+	defer u.generationsMu.Unlock()
+	u.generations[streamID] = cancel
+}
+
+func (u *ChatUsecase) unregisterGeneration(streamID string) {
+	u.generationsMu.Lock()
+	defer u.generationsMu.Unlock()
+	if cancel, ok := u.generations[streamID]; ok {
+		cancel()
+		delete(u.generations, streamID)
+	}
+}
+
+func (u *ChatUsecase) cancelGeneration(streamID string) {
+	u.generationsMu.Lock()
+	defer u.generationsMu.Unlock()
+	if cancel, ok := u.generations[streamID]; ok {
+		u.logger.Info("stop chat generation", log.String("stream_id", streamID))
+		cancel()
+	}
+}
+
+// Stop cancels an in-flight answer on whichever instance is generating it, the partial answer is saved.
+// the conversation must belong to the kb of the request
+func (u *ChatUsecase) Stop(ctx context.Context, req *domain.ChatStopRequest) error {
+	if err := u.conversationUsecase.ValidateConversationNonce(ctx, req.KBID, req.ConversationID, req.Nonce); err != nil {
+		return fmt.Errorf("validate conversation nonce failed: %w", err)
+	}
+	return u.chatStreamRepo.PublishStop(ctx, chatStreamID(req.ConversationID, req.MessageID))
+}
+
+// Resume replays the buffered events of an answer of a conversation of the kb after lastEventID and follows it
+// until the stream ends
+func (u *ChatUsecase) Resume(ctx context.Context, req *domain.ChatResumeRequest) (<-chan domain.SSEEvent, error) {
+	if err := u.conversationUsecase.ValidateConversationNonce(ctx, req.KBID, req.ConversationID, req.Nonce); err != nil {
+		return nil, fmt.Errorf("validate conversation nonce failed: %w", err)
+	}
+	streamID := chatStreamID(req.ConversationID, req.MessageID)
+	exists, err := u.chatStreamRepo.Exists(ctx, streamID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("chat stream not found or expired")
+	}
+	eventCh := make(chan domain.SSEEvent, 100)
+	go func() {
+		defer close(eventCh)
+		lastEventID := req.LastEventID
+		lastActive := time.Now()
+		for ctx.Err() == nil {
+			events, err := u.chatStreamRepo.Read(ctx, streamID, lastEventID, chatResumeBlockTime)
+			if err != nil {
+				if ctx.Err() == nil {
+					u.logger.Error("failed to read chat stream", log.Error(err))
+					eventCh <- domain.SSEEvent{Type: "error", Content: "failed to read chat stream"}
+				}
+				return
+			}
+			if len(events) == 0 {
+				if time.Since(lastActive) > chatResumeIdleTimeout {
+					eventCh <- domain.SSEEvent{Type: "error", Content: "chat stream timeout"}
+					return
+				}
+				continue
+			}
+			lastActive = time.Now()
+			for _, event := range events {
+				lastEventID = event.ID
+				if event.Type == chatStreamEOF {
+					return
+				}
+				select {
+				case eventCh <- event:
+				case <-ctx.Done():
+					return
+				}
+				if event.Type == "error" {
+					return
+				}
+			}
+		}
+	}()
+	return eventCh, nil
+}
+
 func (u *ChatUsecase) initDFA() error {
 	ctx := context.Background()
 	kbList, err := u.kbRepo.GetKnowledgeBaseList(context.Background())
@@ -73,12 +187,55 @@ func (u *ChatUsecase) initDFA() error {
 
 func (u *ChatUsecase) Chat(ctx context.Context, req *domain.ChatRequest) (<-chan domain.SSEEvent, error) {
 	eventCh := make(chan domain.SSEEvent, 100)
+	isWechatApp := req.AppType == domain.AppTypeWechatServiceBot || req.AppType == domain.AppTypeWechatBot || req.AppType == domain.AppTypeWecomAIBot
+	if req.ConversationUser != "" {
+		req.ConversationID = domain.OpenAIUserConversationID(req.KBID, req.ConversationUser)
+	}
+	if strings.TrimSpace(req.Message) == "" && len(req.Images) > 0 {
+		req.Message = domain.ChatImageDefaultQuestion
+	}
+	newConversation := req.ConversationID == "" && !isWechatApp
+	if newConversation {
+		id, err := uuid.NewV7()
+		if err != nil {
+			u.logger.Error("failed to generate conversation uuid", log.Error(err))
+			id = uuid.New()
+		}
+		req.ConversationID = id.String()
+	}
+	messageId := uuid.New().String()
+	streamID := chatStreamID(req.ConversationID, messageId)
+
+	// generation is detached from the request, it only stops when finished or via Stop
+	requestCtx := ctx
+	ctx, cancel := context.WithCancel(context.WithoutCancel(requestCtx))
+	u.registerGeneration(streamID, cancel)
+	logCtx := context.WithoutCancel(ctx)
+	// emit appends the event to the resumable log and hands it to the live consumer if still connected
+	emit := func(event domain.SSEEvent) {
+		if id, err := u.chatStreamRepo.Append(logCtx, streamID, event); err != nil {
+			u.logger.Warn("failed to append chat stream event", log.Error(err))
+		} else {
+			event.ID = id
+		}
+		select {
+		case eventCh <- event:
+		case <-requestCtx.Done():
+		}
+	}
 	go func() {
 		defer close(eventCh)
+		defer u.unregisterGeneration(streamID)
+		defer func() {
+			// marks the end of the stream log for resuming clients
+			if _, err := u.chatStreamRepo.Append(logCtx, streamID, domain.SSEEvent{Type: chatStreamEOF}); err != nil {
+				u.logger.Warn("failed to close chat stream", log.Error(err))
+			}
+		}()
 		// 1. get app detail and validate app
 		app, err := u.appRepo.GetOrCreateAppByKBIDAndType(ctx, req.KBID, req.AppType)
 		if err != nil {
-			eventCh <- domain.SSEEvent{Type: "error", Content: "app not found"}
+			emit(domain.SSEEvent{Type: "error", Content: "app not found"})
 			return
 		}
 		req.KBID = app.KBID
@@ -88,18 +245,18 @@ func (u *ChatUsecase) Chat(ctx context.Context, req *domain.ChatRequest) (<-chan
 		model, err := u.modelUsecase.GetChatModel(ctx)
 		if err != nil {
 			if err == gorm.ErrRecordNotFound {
-				eventCh <- domain.SSEEvent{Type: "error", Content: "请前往管理后台，点击右上角的“系统设置”配置推理大模型。"}
+				emit(domain.SSEEvent{Type: "error", Content: "请前往管理后台，点击右上角的“系统设置”配置推理大模型。"})
 			} else {
-				eventCh <- domain.SSEEvent{Type: "error", Content: "模型获取失败"}
+				emit(domain.SSEEvent{Type: "error", Content: "模型获取失败"})
 			}
 			return
 		}
 		req.ModelInfo = model
 		// 3. conversation management
-		if req.AppType == domain.AppTypeWechatServiceBot || req.AppType == domain.AppTypeWechatBot || req.AppType == domain.AppTypeWecomAIBot { // wechat service has its own id
+		if isWechatApp { // wechat service has its own id
 			nonce := uuid.New().String()
-			eventCh <- domain.SSEEvent{Type: "conversation_id", Content: req.ConversationID}
-			eventCh <- domain.SSEEvent{Type: "nonce", Content: nonce}
+			emit(domain.SSEEvent{Type: "conversation_id", Content: req.ConversationID})
+			emit(domain.SSEEvent{Type: "nonce", Content: nonce})
 			err = u.conversationUsecase.CreateConversation(ctx, &domain.Conversation{
 				ID:        req.ConversationID,
 				Nonce:     nonce,
@@ -112,20 +269,31 @@ func (u *ChatUsecase) Chat(ctx context.Context, req *domain.ChatRequest) (<-chan
 			})
 			if err != nil {
 				u.logger.Error("failed to create chat conversation", log.Error(err))
-				eventCh <- domain.SSEEvent{Type: "error", Content: "failed to create chat conversation"}
+				emit(domain.SSEEvent{Type: "error", Content: "failed to create chat conversation"})
 				return
 			}
-		} else if req.ConversationID == "" {
-			id, err := uuid.NewV7()
+		} else if req.ConversationUser != "" { // OpenAI API users are identified by the user field instead of a nonce
+			emit(domain.SSEEvent{Type: "conversation_id", Content: req.ConversationID})
+			err = u.conversationUsecase.GetOrCreateConversation(ctx, &domain.Conversation{
+				ID:        req.ConversationID,
+				Nonce:     uuid.New().String(),
+				AppID:     req.AppID,
+				KBID:      req.KBID,
+				Subject:   req.Message,
+				RemoteIP:  req.RemoteIP,
+				Info:      req.Info,
+				CreatedAt: time.Now(),
+			})
 			if err != nil {
-				u.logger.Error("failed to generate conversation uuid", log.Error(err))
-				id = uuid.New()
+				u.logger.Error("failed to get or create chat conversation", log.Error(err))
+				emit(domain.SSEEvent{Type: "error", Content: "failed to create chat conversation"})
+				return
 			}
-			conversationID := id.String()
-			req.ConversationID = conversationID
+		} else if newConversation {
+			conversationID := req.ConversationID
 			nonce := uuid.New().String()
-			eventCh <- domain.SSEEvent{Type: "conversation_id", Content: conversationID}
-			eventCh <- domain.SSEEvent{Type: "nonce", Content: nonce}
+			emit(domain.SSEEvent{Type: "conversation_id", Content: conversationID})
+			emit(domain.SSEEvent{Type: "nonce", Content: nonce})
 			err = u.conversationUsecase.CreateConversation(ctx, &domain.Conversation{
 				ID:        conversationID,
 				Nonce:     nonce,
@@ -138,51 +306,63 @@ func (u *ChatUsecase) Chat(ctx context.Context, req *domain.ChatRequest) (<-chan
 			})
 			if err != nil {
 				u.logger.Error("failed to create chat conversation", log.Error(err))
-				eventCh <- domain.SSEEvent{Type: "error", Content: "failed to create chat conversation"}
+				emit(domain.SSEEvent{Type: "error", Content: "failed to create chat conversation"})
 				return
 			}
 		} else {
 			if req.Nonce == "" {
-				eventCh <- domain.SSEEvent{Type: "error", Content: "nonce is required"}
+				emit(domain.SSEEvent{Type: "error", Content: "nonce is required"})
 				return
 			}
-			err := u.conversationUsecase.ValidateConversationNonce(ctx, req.ConversationID, req.Nonce)
+			err := u.conversationUsecase.ValidateConversationNonce(ctx, req.KBID, req.ConversationID, req.Nonce)
 			if err != nil {
 				u.logger.Error("failed to validate chat conversation nonce", log.Error(err))
-				eventCh <- domain.SSEEvent{Type: "error", Content: "validate chat conversation nonce failed"}
+				emit(domain.SSEEvent{Type: "error", Content: "validate chat conversation nonce failed"})
 				return
 			}
 		}
 
-		messageId := uuid.New().String()
-		eventCh <- domain.SSEEvent{Type: "message_id", Content: messageId}
-		userMessageId := uuid.New().String()
-		// save user question to conversation message
-		if err := u.conversationUsecase.CreateChatConversationMessage(ctx, req.KBID, &domain.ConversationMessage{
-			ID:             userMessageId,
-			ConversationID: req.ConversationID,
-			KBID:           req.KBID,
-			AppID:          req.AppID,
-			Role:           schema.User,
-			Content:        req.Message,
-			RemoteIP:       req.RemoteIP,
-		}); err != nil {
-			u.logger.Error("failed to save user question to conversation message", log.Error(err))
-			eventCh <- domain.SSEEvent{Type: "error", Content: "failed to save user question to conversation message"}
+		userMessage, exists, err := u.resolveUserMessage(ctx, req)
+		if err != nil {
+			u.logger.Error("failed to resolve user question", log.Error(err))
+			emit(domain.SSEEvent{Type: "error", Content: "failed to resolve user question"})
+			return
+		}
+		emit(domain.SSEEvent{Type: "message_id", Content: messageId})
+		userMessageId := userMessage.ID
+		req.UserMessageID = userMessageId
+		if exists {
+			req.Message = userMessage.Content
+			req.Images = userMessage.Images
+			req.ImageDescription = userMessage.ImageDescription
+		}
+		if err := u.prepareImages(ctx, req); err != nil {
+			u.logger.Error("failed to prepare question images", log.Error(err))
+			emit(domain.SSEEvent{Type: "error", Content: "图片解析失败，请检查图片或前往管理后台配置图像分析模型"})
 			return
 		}
+		if !exists {
+			userMessage.Images = req.Images
+			userMessage.ImageDescription = req.ImageDescription
+			// save user question to conversation message
+			if err := u.conversationUsecase.CreateChatConversationMessage(ctx, req.KBID, userMessage); err != nil {
+				u.logger.Error("failed to save user question to conversation message", log.Error(err))
+				emit(domain.SSEEvent{Type: "error", Content: "failed to save user question to conversation message"})
+				return
+			}
+		}
 		// extra1. if user set question block words then check it
 		blockWords, err := u.blockWordRepo.GetBlockWords(ctx, req.KBID)
 		if err != nil {
 			u.logger.Error("failed to get question block words", log.Error(err))
-			eventCh <- domain.SSEEvent{Type: "error", Content: "failed to get question block words"}
+			emit(domain.SSEEvent{Type: "error", Content: "failed to get question block words"})
 			return
 		}
 		if len(blockWords) > 0 { // check --> filter
 			questionFilter := utils.GetDFA(req.KBID)
 			if err := questionFilter.DFA.Check(req.Message); err != nil { // exist then return err
 				answer := "**您的问题包含敏感词, AI 无法回答您的问题。**"
-				eventCh <- domain.SSEEvent{Type: "error", Content: answer}
+				emit(domain.SSEEvent{Type: "error", Content: answer})
 				// save ai answer and set it err
 				if err := u.conversationUsecase.CreateChatConversationMessage(context.Background(), req.KBID, &domain.ConversationMessage{
 					ID:             messageId,
@@ -197,7 +377,7 @@ func (u *ChatUsecase) Chat(ctx context.Context, req *domain.ChatRequest) (<-chan
 					ParentID:       userMessageId,
 				}); err != nil {
 					u.logger.Error("failed to save assistant answer to conversation message", log.Error(err))
-					eventCh <- domain.SSEEvent{Type: "error", Content: "failed to save assistant answer to conversation message"}
+					emit(domain.SSEEvent{Type: "error", Content: "failed to save assistant answer to conversation message"})
 					return
 				}
 				return
@@ -214,19 +394,60 @@ func (u *ChatUsecase) Chat(ctx context.Context, req *domain.ChatRequest) (<-chan
 		groupIds, err := u.AuthRepo.GetAuthGroupIdsWithParentsByAuthId(ctx, req.Info.UserInfo.AuthUserID)
 		if err != nil {
 			u.logger.Error("failed to get auth groupIds", log.Error(err))
-			eventCh <- domain.SSEEvent{Type: "error", Content: "failed to get auth groupIds"}
+			emit(domain.SSEEvent{Type: "error", Content: "failed to get auth groupIds"})
 			return
 		}
 
-		// 4. retrieve documents and format prompt
-		messages, rankedNodes, err := u.llmUsecase.FormatConversationMessages(ctx, req.ConversationID, req.KBID, groupIds, req.Prompt)
+		// 4. curated answers written by admins take precedence over generated ones
+		curated, err := u.curatedAnswer.Match(ctx, req.KBID, req.Message)
+		if err != nil {
+			u.logger.Warn("failed to match curated answer", log.Error(err))
+		}
+		if curated != nil {
+			if err := u.curatedAnswer.RecordHit(logCtx, curated.Answer.ID); err != nil {
+				u.logger.Warn("failed to record curated answer hit", log.Error(err))
+			}
+			if curated.IsDirect() {
+				u.logger.Info("answer with curated answer", log.String("curated_answer_id", curated.Answer.ID), log.Any("score", curated.Score))
+				emit(domain.SSEEvent{Type: "data", Content: curated.Answer.Answer})
+				usage := u.llmUsecase.EstimateUsage([]*schema.Message{schema.UserMessage(req.Message)}, curated.Answer.Answer)
+				if err := u.conversationUsecase.CreateChatConversationMessage(logCtx, req.KBID, &domain.ConversationMessage{
+					ID:             messageId,
+					ConversationID: req.ConversationID,
+					KBID:           req.KBID,
+					AppID:          req.AppID,
+					Role:           schema.Assistant,
+					Content:        curated.Answer.Answer,
+					Provider:       req.ModelInfo.Provider,
+					Model:          string(req.ModelInfo.Model),
+					RemoteIP:       req.RemoteIP,
+					ParentID:       userMessageId,
+				}); err != nil {
+					u.logger.Error("failed to save assistant answer to conversation message", log.Error(err))
+					emit(domain.SSEEvent{Type: "error", Content: "failed to save assistant answer to conversation message"})
+					return
+				}
+				emit(domain.SSEEvent{Type: "done", Usage: toOpenAIUsage(usage)})
+				return
+			}
+			req.CuratedAnswer = curated.Answer
+		}
+
+		// 5. retrieve documents and format prompt
+		messages, rankedNodes, err := u.llmUsecase.FormatConversationMessages(ctx, req, groupIds)
 		if err != nil {
 			u.logger.Error("failed to format chat messages", log.Error(err))
-			eventCh <- domain.SSEEvent{Type: "error", Content: "failed to format chat messages"}
+			emit(domain.SSEEvent{Type: "error", Content: "failed to format chat messages"})
 			return
 		}
 
 		u.logger.Debug("message:", log.Any("schema", messages))
+		baseURL := ""
+		if kb, err := u.kbRepo.GetKnowledgeBaseByID(ctx, req.KBID); err != nil {
+			u.logger.Warn("failed to get kb base url", log.Error(err))
+		} else {
+			baseURL = kb.AccessSettings.BaseURL
+		}
 		for _, node := range rankedNodes {
 			chunkResult := domain.NodeContentChunkSSE{
 				NodeID:        node.NodeID,
@@ -234,27 +455,30 @@ func (u *ChatUsecase) Chat(ctx context.Context, req *domain.ChatRequest) (<-chan
 				Summary:       node.NodeSummary,
 				NodePathNames: node.NodePathNames,
 			}
-			eventCh <- domain.SSEEvent{Type: "chunk_result", ChunkResult: &chunkResult}
+			if baseURL != "" {
+				chunkResult.URL = node.GetURL(baseURL)
+			}
+			emit(domain.SSEEvent{Type: "chunk_result", ChunkResult: &chunkResult})
 		}
-		// 5. LLM inference (streaming callback), message storage, token statistics
+		// 6. LLM inference (streaming callback), message storage, token statistics
 		answer := ""
 		usage := schema.TokenUsage{}
 
 		modelkitModel, err := req.ModelInfo.ToModelkitModel()
 		if err != nil {
 			u.logger.Error("failed to convert model to modelkit model", log.Error(err))
-			eventCh <- domain.SSEEvent{Type: "error", Content: "failed to convert model to modelkit model"}
+			emit(domain.SSEEvent{Type: "error", Content: "failed to convert model to modelkit model"})
 			return
 		}
 		chatModel, err := u.modelkit.GetChatModel(ctx, modelkitModel)
 
 		if err != nil {
 			u.logger.Error("failed to get chat model", log.Error(err))
-			eventCh <- domain.SSEEvent{Type: "error", Content: "failed to get chat model"}
+			emit(domain.SSEEvent{Type: "error", Content: "failed to get chat model"})
 			return
 		}
 		// get words
-		onChunkAC, flushBuffer := u.CreateAcOnChunk(ctx, req.KBID, &answer, eventCh, blockWords)
+		onChunkAC, flushBuffer := u.CreateAcOnChunk(ctx, req.KBID, &answer, emit, blockWords)
 
 		chatErr := u.llmUsecase.ChatWithAgent(ctx, chatModel, messages, &usage, onChunkAC)
 
@@ -263,8 +487,14 @@ func (u *ChatUsecase) Chat(ctx context.Context, req *domain.ChatRequest) (<-chan
 			flushBuffer(ctx, "data")
 		}
 
-		// save assistant answer to conversation message
+		// stopped by user, keep the partial answer
+		stopped := ctx.Err() != nil
+		ctx = logCtx
+		if usage.TotalTokens == 0 {
+			usage = u.llmUsecase.EstimateUsage(messages, answer)
+		}
 
+		// save assistant answer to conversation message
 		if err := u.conversationUsecase.CreateChatConversationMessage(ctx, req.KBID, &domain.ConversationMessage{
 			ID:               messageId,
 			ConversationID:   req.ConversationID,
@@ -281,26 +511,163 @@ func (u *ChatUsecase) Chat(ctx context.Context, req *domain.ChatRequest) (<-chan
 			ParentID:         userMessageId,
 		}); err != nil {
 			u.logger.Error("failed to save assistant answer to conversation message", log.Error(err))
-			eventCh <- domain.SSEEvent{Type: "error", Content: "failed to save assistant answer to conversation message"}
+			emit(domain.SSEEvent{Type: "error", Content: "failed to save assistant answer to conversation message"})
 			return
 		}
 		// update model usage
 		if err := u.modelUsecase.UpdateUsage(ctx, req.ModelInfo.ID, &usage); err != nil {
 			u.logger.Error("failed to update model usage", log.Error(err))
-			eventCh <- domain.SSEEvent{Type: "error", Content: "failed to update model usage"}
+			emit(domain.SSEEvent{Type: "error", Content: "failed to update model usage"})
 			return
 		}
 
+		if stopped {
+			emit(domain.SSEEvent{Type: "done", Content: "stopped", Usage: toOpenAIUsage(usage)})
+			return
+		}
 		if chatErr != nil {
 			u.logger.Error("对话失败", log.Error(chatErr))
-			eventCh <- domain.SSEEvent{Type: "error", Content: "对话失败，请稍后再试"}
+			emit(domain.SSEEvent{Type: "error", Content: "对话失败，请稍后再试"})
 			return
 		}
-		eventCh <- domain.SSEEvent{Type: "done"}
+		emit(domain.SSEEvent{Type: "done", Usage: toOpenAIUsage(usage)})
+
+		// 7. optional follow-up suggestions after the answer
+		if u.followUpSuggestionsEnabled(app) {
+			suggestions, err := u.llmUsecase.GenerateFollowUpSuggestions(ctx, req.ModelInfo, req.Message, answer, rankedNodes)
+			if err != nil {
+				u.logger.Error("failed to generate follow-up suggestions", log.Error(err))
+			} else if len(suggestions) > 0 {
+				emit(domain.SSEEvent{Type: "suggestions", Suggestions: suggestions})
+			}
+		}
 	}()
 	return eventCh, nil
 }
 
+func toOpenAIUsage(usage schema.TokenUsage) *domain.OpenAIUsage {
+	return &domain.OpenAIUsage{
+		PromptTokens:     usage.PromptTokens,
+		CompletionTokens: usage.CompletionTokens,
+		TotalTokens:      usage.TotalTokens,
+	}
+}
+
+// Embed embeds the inputs with the embedding model in use, for the OpenAI compatible embeddings API
+func (u *ChatUsecase) Embed(ctx context.Context, inputs []string) (string, [][]float32, *domain.OpenAIUsage, error) {
+	model, err := u.modelUsecase.GetActiveModelByType(ctx, domain.ModelTypeEmbedding)
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("get embedding model failed: %w", err)
+	}
+	embeddings, usage, err := u.llmUsecase.Embed(ctx, model, inputs)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if usage == nil || usage.TotalTokens == 0 {
+		tokens := u.llmUsecase.CountTokens(inputs...)
+		usage = &domain.OpenAIUsage{PromptTokens: tokens, TotalTokens: tokens}
+	}
+	return model.Model, embeddings, usage, nil
+}
+
+// prepareImages loads the images of the question and describes them with the vision model.
+// The description is required unless the chat model accepts images itself.
+func (u *ChatUsecase) prepareImages(ctx context.Context, req *domain.ChatRequest) error {
+	if len(req.Images) == 0 {
+		return nil
+	}
+	if len(req.Images) > domain.ChatMaxImages {
+		return fmt.Errorf("at most %d images are allowed", domain.ChatMaxImages)
+	}
+	if req.ImageDescription != "" && !req.ModelInfo.Parameters.SupportImages {
+		// described before, the images themselves are not needed
+		return nil
+	}
+	images, err := u.imageUsecase.LoadImages(ctx, req.KBID, req.Images)
+	if err != nil {
+		return err
+	}
+	req.ImageData = images
+	req.Images = lo.Map(images, func(image *domain.ChatImage, _ int) string { return image.Ref })
+	if req.ImageDescription != "" {
+		return nil
+	}
+	description, err := u.imageUsecase.Describe(ctx, images, req.Message)
+	if err != nil {
+		if !req.ModelInfo.Parameters.SupportImages {
+			return fmt.Errorf("describe images failed: %w", err)
+		}
+		u.logger.Warn("failed to describe images, send them to the chat model only", log.Error(err))
+	}
+	req.ImageDescription = description
+	return nil
+}
+
+// followUpSuggestionsEnabled reports whether the app turned on follow-up suggestions
+func (u *ChatUsecase) followUpSuggestionsEnabled(app *domain.App) bool {
+	switch app.Type {
+	case domain.AppTypeWeb:
+		return app.Settings.ConversationSetting.FollowUpSuggestionsEnabled
+	case domain.AppTypeWidget:
+		return app.Settings.WidgetBotSettings.FollowUpSuggestionsEnabled
+	case domain.AppTypeOpenAIAPI:
+		return app.Settings.OpenAIAPIBotSettings.FollowUpSuggestionsEnabled
+	default:
+		return false
+	}
+}
+
+// resolveUserMessage returns the question to answer and whether it already exists.
+// New questions are attached to the selected branch: the edited question's parent,
+// the requested parent message or the latest message of the conversation.
+func (u *ChatUsecase) resolveUserMessage(ctx context.Context, req *domain.ChatRequest) (*domain.ConversationMessage, bool, error) {
+	if req.RegenerateMessageID != "" {
+		message, err := u.conversationUsecase.GetConversationMessage(ctx, req.ConversationID, req.RegenerateMessageID)
+		if err != nil {
+			return nil, false, fmt.Errorf("get regenerate message failed: %w", err)
+		}
+		if message.Role != schema.User {
+			return nil, false, fmt.Errorf("message %s is not a user question", message.ID)
+		}
+		return message, true, nil
+	}
+
+	parentID := req.ParentMessageID
+	switch {
+	case req.EditMessageID != "":
+		message, err := u.conversationUsecase.GetConversationMessage(ctx, req.ConversationID, req.EditMessageID)
+		if err != nil {
+			return nil, false, fmt.Errorf("get edit message failed: %w", err)
+		}
+		if message.Role != schema.User {
+			return nil, false, fmt.Errorf("message %s is not a user question", message.ID)
+		}
+		parentID = message.ParentID
+	case parentID != "":
+		if _, err := u.conversationUsecase.GetConversationMessage(ctx, req.ConversationID, parentID); err != nil {
+			return nil, false, fmt.Errorf("get parent message failed: %w", err)
+		}
+	default:
+		latest, err := u.conversationUsecase.GetLatestConversationMessage(ctx, req.ConversationID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, false, fmt.Errorf("get latest message failed: %w", err)
+		}
+		if latest != nil {
+			parentID = latest.ID
+		}
+	}
+	return &domain.ConversationMessage{
+		ID:             uuid.New().String(),
+		ConversationID: req.ConversationID,
+		KBID:           req.KBID,
+		AppID:          req.AppID,
+		Role:           schema.User,
+		Content:        req.Message,
+		RemoteIP:       req.RemoteIP,
+		ParentID:       parentID,
+	}, false, nil
+}
+
 func (u *ChatUsecase) ChatRagOnly(ctx context.Context, req *domain.ChatRagOnlyRequest) (<-chan domain.SSEEvent, error) {
 	eventCh := make(chan domain.SSEEvent, 100)
 	go func() {
@@ -359,14 +726,14 @@ func (u *ChatUsecase) ChatRagOnly(ctx context.Context, req *domain.ChatRagOnlyRe
 	return eventCh, nil
 }
 
-func (u *ChatUsecase) CreateAcOnChunk(ctx context.Context, kbID string, answer *string, eventCh chan<- domain.SSEEvent, blockWords []string) (func(ctx context.Context, dataType, chunk string) error,
+func (u *ChatUsecase) CreateAcOnChunk(ctx context.Context, kbID string, answer *string, emit func(domain.SSEEvent), blockWords []string) (func(ctx context.Context, dataType, chunk string) error,
 	func(ctx context.Context, dataType string)) {
 	var buffer strings.Builder
 	// 如果用户没有设置敏感词，不需要处理
 	if len(blockWords) == 0 {
 		onChunk := func(ctx context.Context, dataType, chunk string) error {
 			*answer += chunk
-			eventCh <- domain.SSEEvent{Type: dataType, Content: chunk}
+			emit(domain.SSEEvent{Type: dataType, Content: chunk})
 			return nil
 		}
 		return onChunk, nil
@@ -392,7 +759,7 @@ func (u *ChatUsecase) CreateAcOnChunk(ctx context.Context, kbID string, answer *
 			// 输出前面的部分，保留后面bufferSize - 1个rune
 			outputPart := string(processedRunes[:len(processedRunes)-filter.BuffSize+1])
 			*answer += outputPart
-			eventCh <- domain.SSEEvent{Type: dataType, Content: outputPart}
+			emit(domain.SSEEvent{Type: dataType, Content: outputPart})
 
 			// 清空缓冲区
 			newBufferContent := string(processedRunes[len(processedRunes)-filter.BuffSize+1:])
@@ -408,7 +775,7 @@ func (u *ChatUsecase) CreateAcOnChunk(ctx context.Context, kbID string, answer *
 			fullContent := buffer.String()
 			processedContent := u.replaceWithSimpleString(fullContent, filter.DFA)
 			*answer += processedContent
-			eventCh <- domain.SSEEvent{Type: dataType, Content: processedContent}
+			emit(domain.SSEEvent{Type: dataType, Content: processedContent})
 		}
 	}
 
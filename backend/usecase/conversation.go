@@ -122,6 +122,7 @@ func (u *ConversationUsecase) GetConversationDetail(ctx context.Context, kbID, c
 		return nil, err
 	}
 	conversation.Messages = messages
+	conversation.MessageTree = domain.BuildConversationMessageTree(messages)
 	// get references
 	references, err := u.repo.GetConversationReferences(ctx, conversationID)
 	if err != nil {
@@ -165,14 +166,40 @@ func extractReferencesBlock(conversationID, appID, text string) []*domain.Conver
 	return refs
 }
 
-func (u *ConversationUsecase) ValidateConversationNonce(ctx context.Context, conversationID, nonce string) error {
-	return u.repo.ValidateConversationNonce(ctx, conversationID, nonce)
+func (u *ConversationUsecase) GetConversationMessage(ctx context.Context, conversationID, messageID string) (*domain.ConversationMessage, error) {
+	return u.repo.GetConversationMessage(ctx, conversationID, messageID)
+}
+
+func (u *ConversationUsecase) GetLatestConversationMessage(ctx context.Context, conversationID string) (*domain.ConversationMessage, error) {
+	return u.repo.GetLatestConversationMessage(ctx, conversationID)
+}
+
+// ValidateConversationNonce checks the nonce of a conversation of the kb
+func (u *ConversationUsecase) ValidateConversationNonce(ctx context.Context, kbID, conversationID, nonce string) error {
+	return u.repo.ValidateConversationNonce(ctx, kbID, conversationID, nonce)
 }
 
 func (u *ConversationUsecase) CreateConversation(ctx context.Context, conversation *domain.Conversation) error {
 	if err := u.repo.CreateConversation(ctx, conversation); err != nil {
 		return err
 	}
+	u.setConversationGeo(ctx, conversation)
+	return nil
+}
+
+// GetOrCreateConversation creates the conversation on first use, later calls keep the existing one
+func (u *ConversationUsecase) GetOrCreateConversation(ctx context.Context, conversation *domain.Conversation) error {
+	created, err := u.repo.CreateConversationIfNotExists(ctx, conversation)
+	if err != nil {
+		return err
+	}
+	if created {
+		u.setConversationGeo(ctx, conversation)
+	}
+	return nil
+}
+
+func (u *ConversationUsecase) setConversationGeo(ctx context.Context, conversation *domain.Conversation) {
 	remoteIP := conversation.RemoteIP
 	ipAddress, err := u.ipRepo.GetIPAddress(ctx, remoteIP)
 	if err != nil {
@@ -183,7 +210,6 @@ func (u *ConversationUsecase) CreateConversation(ctx context.Context, conversati
 			u.logger.Warn("set geo cache failed", log.Error(err), log.String("conversation_id", conversation.ID), log.String("ip", remoteIP))
 		}
 	}
-	return nil
 }
 
 func (u *ConversationUsecase) FeedBack(ctx context.Context, feedback *domain.FeedbackRequest) error {
@@ -272,8 +298,11 @@ func (u *ConversationUsecase) GetShareConversationDetail(ctx context.Context, kb
 	var shareMessages []*domain.ShareConversationMessage
 	for _, message := range messages {
 		shareMessages = append(shareMessages, &domain.ShareConversationMessage{
+			ID:        message.ID,
+			ParentID:  message.ParentID,
 			Role:      message.Role,
 			Content:   message.Content,
+			Images:    message.Images,
 			CreatedAt: message.CreatedAt,
 		})
 	}
@@ -3,9 +3,11 @@ package usecase
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"net/http"
 	"slices"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -137,19 +139,9 @@ func (u *CrawlerUsecase) ParseUrl(ctx context.Context, req *v1.CrawlerParseReq)
 }
 
 func (u *CrawlerUsecase) ExportDoc(ctx context.Context, req *v1.CrawlerExportReq) (*v1.CrawlerExportResp, error) {
-	var taskId string
-	if req.SpaceId != "" {
-		urlExportRes, err := u.anydocClient.FeishuExportDoc(ctx, req.ID, req.DocID, req.FileType, req.SpaceId, req.KbID)
-		if err != nil {
-			return nil, err
-		}
-		taskId = urlExportRes.Data
-	} else {
-		urlExportRes, err := u.anydocClient.UrlExport(ctx, req.ID, req.DocID, req.KbID)
-		if err != nil {
-			return nil, err
-		}
-		taskId = urlExportRes.Data
+	taskId, err := u.StartExport(ctx, req.KbID, req.ID, req.DocID, req.SpaceId, req.FileType)
+	if err != nil {
+		return nil, err
 	}
 
 	return &v1.CrawlerExportResp{
@@ -157,6 +149,23 @@ func (u *CrawlerUsecase) ExportDoc(ctx context.Context, req *v1.CrawlerExportReq
 	}, nil
 }
 
+// StartExport asks the crawler to export a listed document and returns the id of the export task,
+// documents of feishu spaces are exported with their space
+func (u *CrawlerUsecase) StartExport(ctx context.Context, kbID, id, docID, spaceID, fileType string) (string, error) {
+	if spaceID != "" {
+		urlExportRes, err := u.anydocClient.FeishuExportDoc(ctx, id, docID, fileType, spaceID, kbID)
+		if err != nil {
+			return "", err
+		}
+		return urlExportRes.Data, nil
+	}
+	urlExportRes, err := u.anydocClient.UrlExport(ctx, id, docID, kbID)
+	if err != nil {
+		return "", err
+	}
+	return urlExportRes.Data, nil
+}
+
 func (u *CrawlerUsecase) ScrapeGetResult(ctx context.Context, taskId string) (*v1.CrawlerResultResp, error) {
 	taskRes, err := u.anydocClient.TaskList(ctx, []string{taskId})
 	if err != nil {
@@ -217,3 +226,89 @@ func (u *CrawlerUsecase) ScrapeGetResults(ctx context.Context, taskIds []string)
 		List:   list,
 	}, nil
 }
+
+// ListSourceDocs lists the documents of a url, rss feed or sitemap, the same id is needed to export them
+func (u *CrawlerUsecase) ListSourceDocs(ctx context.Context, source consts.CrawlerSource, targetURL, id string) ([]anydoc.Value, error) {
+	var (
+		docs *anydoc.ListDocResponse
+		err  error
+	)
+	switch source {
+	case consts.CrawlerSourceUrl:
+		docs, err = u.anydocClient.GetUrlList(ctx, targetURL, id)
+	case consts.CrawlerSourceRSS:
+		docs, err = u.anydocClient.RssListDocs(ctx, targetURL, id)
+	case consts.CrawlerSourceSitemap:
+		docs, err = u.anydocClient.SitemapListDocs(ctx, targetURL, id)
+	default:
+		return nil, fmt.Errorf("sync of %s sources is not supported", source)
+	}
+	if err != nil {
+		return nil, err
+	}
+	values := make([]anydoc.Value, 0)
+	var walk func(child anydoc.Child)
+	walk = func(child anydoc.Child) {
+		if len(child.Children) == 0 && child.Value.ID != "" {
+			values = append(values, child.Value)
+		}
+		for _, c := range child.Children {
+			walk(c)
+		}
+	}
+	walk(docs.Data.Docs)
+	return values, nil
+}
+
+// crawlerExportTimeout bounds the wait for the crawler to export a document
+const crawlerExportTimeout = 10 * time.Minute
+
+// errExportTaskNotFound is returned by WaitExport when the crawler no longer knows the task
+var errExportTaskNotFound = errors.New("export task not found")
+
+// ExportMarkdown exports a listed document and waits for its markdown
+func (u *CrawlerUsecase) ExportMarkdown(ctx context.Context, id, docID, kbID string) (string, error) {
+	taskID, err := u.StartExport(ctx, kbID, id, docID, "", "")
+	if err != nil {
+		return "", err
+	}
+	return u.WaitExport(ctx, taskID)
+}
+
+// WaitExport polls the export task until the crawler finished it and downloads its markdown.
+// the task is kept by the crawler, so that the wait can be resumed with the task id after a restart
+func (u *CrawlerUsecase) WaitExport(ctx context.Context, taskID string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, crawlerExportTimeout)
+	defer cancel()
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return "", fmt.Errorf("wait for export task %s: %w", taskID, ctx.Err())
+		case <-ticker.C:
+		}
+		taskRes, err := u.anydocClient.TaskList(ctx, []string{taskID})
+		if err != nil {
+			return "", err
+		}
+		if len(taskRes.Data) == 0 {
+			return "", fmt.Errorf("%w: %s", errExportTaskNotFound, taskID)
+		}
+		task := taskRes.Data[0]
+		switch task.Status {
+		case anydoc.StatusPending, anydoc.StatusInProgress:
+			continue
+		case anydoc.StatusFailed:
+			return "", fmt.Errorf("export failed: %s", task.Err)
+		case anydoc.StatusCompleted:
+			markdown, err := u.anydocClient.DownloadDoc(ctx, task.Markdown)
+			if err != nil {
+				return "", err
+			}
+			return string(markdown), nil
+		default:
+			return "", fmt.Errorf("unsupported task status : %s", task.Status)
+		}
+	}
+}
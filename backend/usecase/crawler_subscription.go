@@ -0,0 +1,437 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/pkg/anydoc"
+	"github.com/chaitin/panda-wiki/repo/mq"
+	"github.com/chaitin/panda-wiki/repo/pg"
+	"github.com/chaitin/panda-wiki/utils"
+)
+
+// maxCrawlerCheckSize bounds the body read to hash a feed or a page
+const maxCrawlerCheckSize = 20 << 20
+
+// CrawlerSubscriptionUsecase re-syncs url, rss and sitemap sources into kbs on a schedule
+type CrawlerSubscriptionUsecase struct {
+	repo           *pg.CrawlerSubscriptionRepository
+	nodeRepo       *pg.NodeRepository
+	nodeUsecase    *NodeUsecase
+	kbUsecase      *KnowledgeBaseUsecase
+	crawlerUsecase *CrawlerUsecase
+	jobRepo        *mq.JobRepository
+	httpClient     *http.Client
+	logger         *log.Logger
+}
+
+func NewCrawlerSubscriptionUsecase(repo *pg.CrawlerSubscriptionRepository, nodeRepo *pg.NodeRepository, nodeUsecase *NodeUsecase,
+	kbUsecase *KnowledgeBaseUsecase, crawlerUsecase *CrawlerUsecase, jobRepo *mq.JobRepository, logger *log.Logger) *CrawlerSubscriptionUsecase {
+	return &CrawlerSubscriptionUsecase{
+		repo:           repo,
+		nodeRepo:       nodeRepo,
+		nodeUsecase:    nodeUsecase,
+		kbUsecase:      kbUsecase,
+		crawlerUsecase: crawlerUsecase,
+		jobRepo:        jobRepo,
+		httpClient:     utils.NewPublicHTTPClient(30 * time.Second),
+		logger:         logger.WithModule("usecase.crawler_subscription"),
+	}
+}
+
+func (u *CrawlerSubscriptionUsecase) Create(ctx context.Context, req *domain.CreateCrawlerSubscriptionReq, userID string, maxNode int) (*domain.CrawlerSubscription, error) {
+	if err := u.nodeUsecase.validateParentFolder(ctx, req.KBID, req.ParentID); err != nil {
+		return nil, err
+	}
+	interval := req.IntervalHours
+	if interval == 0 {
+		interval = domain.DefaultCrawlerSyncIntervalHours
+	}
+	now := time.Now()
+	subscription := &domain.CrawlerSubscription{
+		ID:            uuid.New().String(),
+		KBID:          req.KBID,
+		Source:        req.Source,
+		URL:           req.URL,
+		ParentID:      req.ParentID,
+		Policy:        req.Policy,
+		DeleteRemoved: req.DeleteRemoved,
+		IntervalHours: interval,
+		Enabled:       true,
+		CreatorID:     userID,
+		MaxNode:       maxNode,
+		Status:        domain.CrawlerSyncStatusIdle,
+		// the first sync runs with the next round of the cron
+		NextSyncAt: now,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := u.repo.Create(ctx, subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+func (u *CrawlerSubscriptionUsecase) GetList(ctx context.Context, kbID string) ([]*domain.CrawlerSubscription, error) {
+	return u.repo.GetList(ctx, kbID)
+}
+
+func (u *CrawlerSubscriptionUsecase) Update(ctx context.Context, req *domain.UpdateCrawlerSubscriptionReq) error {
+	subscription, err := u.repo.GetByID(ctx, req.KBID, req.ID)
+	if err != nil {
+		return err
+	}
+	if req.Policy != nil {
+		subscription.Policy = *req.Policy
+	}
+	if req.DeleteRemoved != nil {
+		subscription.DeleteRemoved = *req.DeleteRemoved
+	}
+	if req.Enabled != nil {
+		subscription.Enabled = *req.Enabled
+	}
+	if req.IntervalHours != nil {
+		subscription.IntervalHours = *req.IntervalHours
+		if subscription.IntervalHours == 0 {
+			subscription.IntervalHours = domain.DefaultCrawlerSyncIntervalHours
+		}
+		last := time.Now()
+		if subscription.SyncedAt != nil {
+			last = *subscription.SyncedAt
+		}
+		subscription.ScheduleNext(last)
+	}
+	subscription.UpdatedAt = time.Now()
+	return u.repo.Update(ctx, subscription)
+}
+
+// Delete deletes the subscription, the synced nodes are kept unless asked otherwise
+func (u *CrawlerSubscriptionUsecase) Delete(ctx context.Context, req *domain.DeleteCrawlerSubscriptionReq) error {
+	subscription, err := u.repo.GetByID(ctx, req.KBID, req.ID)
+	if err != nil {
+		return err
+	}
+	if req.DeleteNodes {
+		sources, err := u.repo.GetNodeSourceList(ctx, subscription.ID)
+		if err != nil {
+			return err
+		}
+		nodeIDs := make([]string, 0, len(sources))
+		for _, source := range sources {
+			nodeIDs = append(nodeIDs, source.NodeID)
+		}
+		if len(nodeIDs) > 0 {
+			if err := u.nodeUsecase.NodeAction(ctx, &domain.NodeActionReq{KBID: subscription.KBID, IDs: nodeIDs, Action: "delete"}); err != nil {
+				return err
+			}
+		}
+	}
+	return u.repo.Delete(ctx, subscription.KBID, subscription.ID)
+}
+
+func (u *CrawlerSubscriptionUsecase) GetNodeSources(ctx context.Context, kbID, id string) ([]*domain.NodeSource, error) {
+	if _, err := u.repo.GetByID(ctx, kbID, id); err != nil {
+		return nil, err
+	}
+	return u.repo.GetNodeSourceList(ctx, id)
+}
+
+// Sync queues a sync of the subscription now, a subscription is synced by one consumer at a time
+func (u *CrawlerSubscriptionUsecase) Sync(ctx context.Context, req *domain.SyncCrawlerSubscriptionReq) error {
+	queued, err := u.repo.Queue(ctx, req.KBID, req.ID)
+	if err != nil {
+		return err
+	}
+	if !queued {
+		if _, err := u.repo.GetByID(ctx, req.KBID, req.ID); err != nil {
+			return err
+		}
+		return fmt.Errorf("crawler subscription is already syncing")
+	}
+	if err := u.jobRepo.AsyncSyncCrawlerSubscription(ctx, &domain.CrawlerSyncTaskRequest{SubscriptionID: req.ID}); err != nil {
+		return fmt.Errorf("publish crawler sync task failed: %w", err)
+	}
+	return nil
+}
+
+// QueueDue queues the syncs of the subscriptions that are due, it is run by the cron of the consumer
+func (u *CrawlerSubscriptionUsecase) QueueDue(ctx context.Context) error {
+	ids, err := u.repo.QueueDue(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := u.jobRepo.AsyncSyncCrawlerSubscription(ctx, &domain.CrawlerSyncTaskRequest{SubscriptionID: id}); err != nil {
+			return fmt.Errorf("publish crawler sync task failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// Run syncs a pending subscription, subscriptions already picked up are skipped so that redelivered tasks are harmless
+func (u *CrawlerSubscriptionUsecase) Run(ctx context.Context, task *domain.CrawlerSyncTaskRequest) error {
+	subscription, err := u.repo.GetByID(ctx, "", task.SubscriptionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	started, err := u.repo.Start(ctx, subscription.ID)
+	if err != nil || !started {
+		return err
+	}
+
+	result, err := u.execute(ctx, subscription)
+	subscription.Status = domain.CrawlerSyncStatusCompleted
+	subscription.Error = ""
+	if err != nil {
+		subscription.Status = domain.CrawlerSyncStatusFailed
+		subscription.Error = err.Error()
+	}
+	subscription.Result = *result
+	now := time.Now()
+	subscription.SyncedAt = &now
+	subscription.ScheduleNext(now)
+	return u.repo.Finish(ctx, subscription)
+}
+
+func (u *CrawlerSubscriptionUsecase) execute(ctx context.Context, subscription *domain.CrawlerSubscription) (*domain.CrawlerSyncResult, error) {
+	result := &domain.CrawlerSyncResult{}
+	// an unchanged feed has nothing new, unless the last sync or some of its items failed
+	feed, err := u.check(ctx, subscription.URL, subscription.ETag, subscription.LastModified)
+	if errors.Is(err, utils.ErrNonPublicAddress) {
+		return result, err
+	}
+	if err != nil {
+		u.logger.Warn("check crawler source failed", log.String("url", subscription.URL), log.Error(err))
+	} else {
+		unchanged := feed.notModified || feed.hash != "" && feed.hash == subscription.ContentHash
+		if unchanged && subscription.Result.Failed == 0 && subscription.Error == "" {
+			result.NotModified = true
+			return result, nil
+		}
+		if !feed.notModified {
+			subscription.ETag, subscription.LastModified, subscription.ContentHash = feed.etag, feed.lastModified, feed.hash
+		}
+	}
+
+	sources, err := u.repo.GetNodeSources(ctx, subscription.ID)
+	if err != nil {
+		return result, err
+	}
+	listID := uuid.New().String()
+	docs, err := u.crawlerUsecase.ListSourceDocs(ctx, subscription.Source, subscription.URL, listID)
+	if err != nil {
+		return result, fmt.Errorf("list documents failed: %w", err)
+	}
+	// an empty list is more likely an outage of the site than every item being removed
+	if len(docs) == 0 && len(sources) > 0 {
+		return result, fmt.Errorf("the source listed no documents")
+	}
+
+	listed := make(map[string]bool, len(docs))
+	changedNodeIDs := make([]string, 0)
+	for _, doc := range docs {
+		if listed[doc.ID] {
+			continue
+		}
+		listed[doc.ID] = true
+		nodeID, action, err := u.syncDoc(ctx, subscription, listID, doc, sources[doc.ID])
+		switch {
+		case errors.Is(err, utils.ErrNonPublicAddress):
+			result.Skipped++
+		case err != nil:
+			result.AddError(doc.ID, err)
+		case action == domain.UpsertNodeActionCreated:
+			result.Created++
+			changedNodeIDs = append(changedNodeIDs, nodeID)
+		case action == domain.UpsertNodeActionUpdated:
+			result.Updated++
+			changedNodeIDs = append(changedNodeIDs, nodeID)
+		default:
+			result.Unchanged++
+		}
+	}
+
+	removedNodeIDs := make([]string, 0)
+	for docID, source := range sources {
+		if !listed[docID] && !source.Removed {
+			removedNodeIDs = append(removedNodeIDs, source.NodeID)
+		}
+	}
+	if err := u.removeNodes(ctx, subscription, removedNodeIDs); err != nil {
+		return result, err
+	}
+	result.Removed = len(removedNodeIDs)
+
+	deleted := subscription.DeleteRemoved && result.Removed > 0
+	if subscription.Policy == domain.CrawlerSyncPolicyPublish && (len(changedNodeIDs) > 0 || deleted) {
+		now := time.Now()
+		releaseID, err := u.kbUsecase.CreateKBRelease(ctx, &domain.CreateKBReleaseReq{
+			KBID:    subscription.KBID,
+			Message: fmt.Sprintf("定时同步 %s", subscription.URL),
+			Tag:     "sync-" + now.Format("20060102150405"),
+			NodeIDs: changedNodeIDs,
+		}, subscription.CreatorID)
+		if err != nil {
+			return result, fmt.Errorf("create release failed: %w", err)
+		}
+		result.ReleaseID = releaseID
+	}
+	return result, nil
+}
+
+// syncDoc exports the item and creates or updates its node. items whose page answers not modified are not exported,
+// and documents deleted in the wiki are not created again. items pointing to private addresses are skipped
+func (u *CrawlerSubscriptionUsecase) syncDoc(ctx context.Context, subscription *domain.CrawlerSubscription, listID string,
+	doc anydoc.Value, source *domain.NodeSource) (string, domain.UpsertNodeAction, error) {
+	pageURL, isURL := domain.CrawlerDocURL(doc.ID)
+	if !isURL {
+		pageURL = subscription.URL
+	}
+	if source == nil {
+		source = &domain.NodeSource{
+			KBID:           subscription.KBID,
+			SubscriptionID: subscription.ID,
+			Source:         subscription.Source,
+			DocID:          doc.ID,
+		}
+	}
+	source.URL = pageURL
+	if isURL {
+		page, err := u.check(ctx, pageURL, source.ETag, source.LastModified)
+		switch {
+		case errors.Is(err, utils.ErrNonPublicAddress):
+			return source.NodeID, domain.UpsertNodeActionUnchanged, err
+		case err != nil:
+			u.logger.Warn("check crawler page failed", log.String("url", pageURL), log.Error(err))
+		case page.notModified && source.NodeID != "" && !source.Removed:
+			return source.NodeID, domain.UpsertNodeActionUnchanged, nil
+		case !page.notModified:
+			source.ETag, source.LastModified = page.etag, page.lastModified
+		}
+	}
+
+	markdown, err := u.crawlerUsecase.ExportMarkdown(ctx, listID, doc.ID, subscription.KBID)
+	if err != nil {
+		return "", domain.UpsertNodeActionFailed, err
+	}
+	sum := sha256.Sum256([]byte(markdown))
+	hash := hex.EncodeToString(sum[:])
+	name := strings.TrimSpace(doc.Title)
+	if name == "" {
+		name = pageURL
+	}
+
+	action := domain.UpsertNodeActionUnchanged
+	if source.NodeID == "" {
+		contentType := domain.ContentTypeMD
+		nodeID, err := u.nodeRepo.Create(ctx, &domain.CreateNodeReq{
+			KBID:        subscription.KBID,
+			ParentID:    subscription.ParentID,
+			Type:        domain.NodeTypeDocument,
+			Name:        name,
+			Content:     markdown,
+			ContentType: &contentType,
+			MaxNode:     subscription.MaxNode,
+		}, subscription.CreatorID)
+		if err != nil {
+			return "", domain.UpsertNodeActionFailed, err
+		}
+		source.NodeID = nodeID
+		action = domain.UpsertNodeActionCreated
+	} else if source.ContentHash != hash {
+		if _, err := u.nodeRepo.GetNodeByID(ctx, source.NodeID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return source.NodeID, domain.UpsertNodeActionUnchanged, nil
+			}
+			return "", domain.UpsertNodeActionFailed, err
+		}
+		if err := u.nodeRepo.UpdateNodeContent(ctx, &domain.UpdateNodeReq{
+			ID:      source.NodeID,
+			KBID:    subscription.KBID,
+			Name:    &name,
+			Content: &markdown,
+		}, subscription.CreatorID); err != nil {
+			return "", domain.UpsertNodeActionFailed, err
+		}
+		action = domain.UpsertNodeActionUpdated
+	}
+
+	source.ContentHash = hash
+	source.Removed = false
+	source.SyncedAt = time.Now()
+	if err := u.repo.SaveNodeSource(ctx, source); err != nil {
+		return "", domain.UpsertNodeActionFailed, err
+	}
+	return source.NodeID, action, nil
+}
+
+// removeNodes deletes the nodes of the items removed from the source, or only marks their sources removed
+func (u *CrawlerSubscriptionUsecase) removeNodes(ctx context.Context, subscription *domain.CrawlerSubscription, nodeIDs []string) error {
+	if len(nodeIDs) == 0 {
+		return nil
+	}
+	if !subscription.DeleteRemoved {
+		return u.repo.MarkNodeSourcesRemoved(ctx, nodeIDs)
+	}
+	if err := u.nodeUsecase.NodeAction(ctx, &domain.NodeActionReq{KBID: subscription.KBID, IDs: nodeIDs, Action: "delete"}); err != nil {
+		return err
+	}
+	return u.repo.DeleteNodeSources(ctx, nodeIDs)
+}
+
+type crawlerCheck struct {
+	notModified  bool
+	etag         string
+	lastModified string
+	hash         string
+}
+
+// check requests the url with the validators of the last sync, the hash of the body catches servers without validators.
+// the url comes from the source, so private addresses are refused with utils.ErrNonPublicAddress
+func (u *CrawlerSubscriptionUsecase) check(ctx context.Context, targetURL, etag, lastModified string) (*crawlerCheck, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, targetURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if lastModified != "" {
+		req.Header.Set("If-Modified-Since", lastModified)
+	}
+	resp, err := u.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotModified {
+		return &crawlerCheck{notModified: true}, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, io.LimitReader(resp.Body, maxCrawlerCheckSize)); err != nil {
+		return nil, err
+	}
+	return &crawlerCheck{
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+		hash:         hex.EncodeToString(h.Sum(nil)),
+	}, nil
+}
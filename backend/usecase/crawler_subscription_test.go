@@ -0,0 +1,25 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/pkg/anydoc"
+	"github.com/chaitin/panda-wiki/utils"
+)
+
+func TestSyncDocSkipsPrivateAddresses(t *testing.T) {
+	u := &CrawlerSubscriptionUsecase{httpClient: utils.NewPublicHTTPClient(5 * time.Second)}
+	subscription := &domain.CrawlerSubscription{ID: "s1", KBID: "kb1", URL: "https://example.com/feed.xml"}
+	for _, docID := range []string{"http://169.254.169.254/latest/meta-data/", "http://127.0.0.1:8000/admin"} {
+		source := &domain.NodeSource{NodeID: "n1", DocID: docID}
+		nodeID, action, err := u.syncDoc(context.Background(), subscription, "list1", anydoc.Value{ID: docID}, source)
+		assert.ErrorIs(t, err, utils.ErrNonPublicAddress, docID)
+		assert.Equal(t, "n1", nodeID, docID)
+		assert.Equal(t, domain.UpsertNodeActionUnchanged, action, docID)
+	}
+}
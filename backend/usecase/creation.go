@@ -52,7 +52,8 @@ func (u *CreationUsecase) TextCreation(ctx context.Context, req *domain.TextReq,
 				"规则：\n" +
 				"1. 保持输入文本的原始语言\n" +
 				"2. 禁止将文本翻译成其他语言\n" +
-				"3. 保持原文的语言风格和表达方式\n\n" +
+				"3. 保持原文的语言风格和表达方式\n" +
+				fmt.Sprintf("4. 无法判断原文语言时，使用%s\n\n", domain.LanguageName(u.llm.GetLanguageSetting(ctx, req.KBID).Language)) +
 				"优化方向：\n" +
 				"1. 内容优化：\n" +
 				"   - 提高文本的清晰度和可读性\n" +
@@ -105,8 +106,9 @@ func (u *CreationUsecase) TabComplete(ctx context.Context, req *domain.CompleteR
 		)
 
 		messages, err := template.Format(ctx, map[string]any{
-			"Prefix": req.Prefix,
-			"Suffix": req.Suffix,
+			"Prefix":          req.Prefix,
+			"Suffix":          req.Suffix,
+			"DefaultLanguage": domain.LanguageName(u.llm.GetLanguageSetting(ctx, req.KBID).Language),
 		})
 		if err != nil {
 			return "", fmt.Errorf("failed to format message: %w", err)
@@ -0,0 +1,225 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/repo/pg"
+)
+
+type CuratedAnswerUsecase struct {
+	repo             *pg.CuratedAnswerRepository
+	conversationRepo *pg.ConversationRepository
+	llmUsecase       *LLMUsecase
+	modelUsecase     *ModelUsecase
+	logger           *log.Logger
+}
+
+// curatedAnswerReembedBatch bounds the answers re-embedded by one round of the cron
+const curatedAnswerReembedBatch = 100
+
+func NewCuratedAnswerUsecase(repo *pg.CuratedAnswerRepository, conversationRepo *pg.ConversationRepository, llmUsecase *LLMUsecase,
+	modelUsecase *ModelUsecase, logger *log.Logger) *CuratedAnswerUsecase {
+	return &CuratedAnswerUsecase{
+		repo:             repo,
+		conversationRepo: conversationRepo,
+		llmUsecase:       llmUsecase,
+		modelUsecase:     modelUsecase,
+		logger:           logger.WithModule("usecase.curated_answer"),
+	}
+}
+
+func (u *CuratedAnswerUsecase) Create(ctx context.Context, req *domain.CreateCuratedAnswerReq) (*domain.CuratedAnswer, error) {
+	question := strings.TrimSpace(req.Question)
+	if req.MessageID != "" && question == "" {
+		q, err := u.getMessageQuestion(ctx, req.KBID, req.MessageID)
+		if err != nil {
+			return nil, err
+		}
+		question = q
+	}
+	if question == "" {
+		return nil, fmt.Errorf("question is required")
+	}
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+	answer := &domain.CuratedAnswer{
+		ID:              id.String(),
+		KBID:            req.KBID,
+		Question:        question,
+		Answer:          req.Answer,
+		SourceMessageID: req.MessageID,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+	if err := u.embedAnswer(ctx, answer); err != nil {
+		return nil, err
+	}
+	if err := u.repo.Create(ctx, answer); err != nil {
+		return nil, err
+	}
+	return answer, nil
+}
+
+func (u *CuratedAnswerUsecase) Update(ctx context.Context, req *domain.UpdateCuratedAnswerReq) error {
+	answer, err := u.repo.GetByID(ctx, req.KBID, req.ID)
+	if err != nil {
+		return err
+	}
+	if req.Answer != nil {
+		answer.Answer = *req.Answer
+	}
+	if req.Question != nil && strings.TrimSpace(*req.Question) != answer.Question {
+		answer.Question = strings.TrimSpace(*req.Question)
+		if answer.Question == "" {
+			return fmt.Errorf("question is required")
+		}
+		if err := u.embedAnswer(ctx, answer); err != nil {
+			return err
+		}
+	}
+	return u.repo.Update(ctx, answer)
+}
+
+func (u *CuratedAnswerUsecase) Delete(ctx context.Context, kbID, id string) error {
+	return u.repo.Delete(ctx, kbID, id)
+}
+
+func (u *CuratedAnswerUsecase) GetList(ctx context.Context, req *domain.CuratedAnswerListReq) (*domain.PaginatedResult[[]*domain.CuratedAnswer], error) {
+	answers, total, err := u.repo.List(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewPaginatedResult(answers, uint64(total)), nil
+}
+
+// Match returns the curated answer most similar to the question, nil when none is similar enough.
+// only the question is embedded on the chat path, answers embedded by another model are skipped
+// until ReembedStale embeds them again
+func (u *CuratedAnswerUsecase) Match(ctx context.Context, kbID, question string) (*domain.CuratedAnswerMatch, error) {
+	model, err := u.modelUsecase.GetActiveModelByType(ctx, domain.ModelTypeEmbedding)
+	if err != nil {
+		return nil, fmt.Errorf("get embedding model failed: %w", err)
+	}
+	answers, err := u.repo.GetEmbedded(ctx, kbID, model.Model)
+	if err != nil {
+		return nil, err
+	}
+	if len(answers) == 0 {
+		return nil, nil
+	}
+	embeddings, _, err := u.llmUsecase.Embed(ctx, model, []string{question})
+	if err != nil {
+		return nil, err
+	}
+	return selectCuratedAnswer(embeddings[0], answers), nil
+}
+
+// selectCuratedAnswer returns the answer most similar to the question embedding, nil below the prompt score
+func selectCuratedAnswer(question []float32, answers []*domain.CuratedAnswer) *domain.CuratedAnswerMatch {
+	var best *domain.CuratedAnswerMatch
+	for _, answer := range answers {
+		score := cosineSimilarity(question, answer.Embedding)
+		if best == nil || score > best.Score {
+			best = &domain.CuratedAnswerMatch{Answer: answer, Score: score}
+		}
+	}
+	if best == nil || best.Score < domain.CuratedAnswerPromptScore {
+		return nil
+	}
+	return best
+}
+
+// ReembedStale embeds the answers that were embedded by another model, e.g. after the embedding model was changed.
+// it is run by the cron of the consumer, answers whose embedding failed are tried again in the next round
+func (u *CuratedAnswerUsecase) ReembedStale(ctx context.Context) error {
+	model, err := u.modelUsecase.GetActiveModelByType(ctx, domain.ModelTypeEmbedding)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("get embedding model failed: %w", err)
+	}
+	stale, err := u.repo.GetStale(ctx, model.Model, curatedAnswerReembedBatch)
+	if err != nil || len(stale) == 0 {
+		return err
+	}
+	embeddings, _, err := u.llmUsecase.Embed(ctx, model, lo.Map(stale, func(answer *domain.CuratedAnswer, _ int) string {
+		return answer.Question
+	}))
+	if err != nil {
+		return fmt.Errorf("re-embed curated answers failed: %w", err)
+	}
+	for i, answer := range stale {
+		if err := u.repo.UpdateEmbedding(ctx, answer.ID, embeddings[i], model.Model); err != nil {
+			u.logger.Warn("failed to update curated answer embedding", log.String("id", answer.ID), log.Error(err))
+		}
+	}
+	return nil
+}
+
+func (u *CuratedAnswerUsecase) RecordHit(ctx context.Context, id string) error {
+	return u.repo.RecordHit(ctx, id)
+}
+
+func (u *CuratedAnswerUsecase) embedAnswer(ctx context.Context, answer *domain.CuratedAnswer) error {
+	model, err := u.modelUsecase.GetActiveModelByType(ctx, domain.ModelTypeEmbedding)
+	if err != nil {
+		return fmt.Errorf("get embedding model failed: %w", err)
+	}
+	embeddings, _, err := u.llmUsecase.Embed(ctx, model, []string{answer.Question})
+	if err != nil {
+		return err
+	}
+	answer.Embedding = embeddings[0]
+	answer.EmbeddingModel = model.Model
+	return nil
+}
+
+// getMessageQuestion returns the user question answered by the message
+func (u *CuratedAnswerUsecase) getMessageQuestion(ctx context.Context, kbID, messageID string) (string, error) {
+	message, err := u.conversationRepo.GetConversationMessagesDetailByKbID(ctx, kbID, messageID)
+	if err != nil {
+		return "", fmt.Errorf("get message failed: %w", err)
+	}
+	if message.Role == schema.User {
+		return message.Content, nil
+	}
+	if message.ParentID == "" {
+		return "", fmt.Errorf("question of message %s not found", messageID)
+	}
+	parent, err := u.conversationRepo.GetConversationMessage(ctx, message.ConversationID, message.ParentID)
+	if err != nil {
+		return "", fmt.Errorf("get question message failed: %w", err)
+	}
+	return parent.Content, nil
+}
+
+func cosineSimilarity(a, b []float32) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/chaitin/panda-wiki/domain"
+)
+
+func TestCosineSimilarity(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []float32
+		want float64
+	}{
+		{"same direction", []float32{1, 2, 3}, []float32{2, 4, 6}, 1},
+		{"orthogonal", []float32{1, 0}, []float32{0, 1}, 0},
+		{"opposite", []float32{1, 1}, []float32{-1, -1}, -1},
+		{"different length", []float32{1, 2}, []float32{1, 2, 3}, 0},
+		{"empty", nil, nil, 0},
+		{"zero vector", []float32{0, 0}, []float32{1, 1}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, cosineSimilarity(tt.a, tt.b), 1e-6)
+		})
+	}
+}
+
+func TestSelectCuratedAnswer(t *testing.T) {
+	// unit vector at the given cosine similarity to the question {1, 0}
+	at := func(id string, score float64) *domain.CuratedAnswer {
+		return &domain.CuratedAnswer{ID: id, Embedding: []float32{float32(score), float32(math.Sqrt(1 - score*score))}}
+	}
+	question := []float32{1, 0}
+
+	match := selectCuratedAnswer(question, []*domain.CuratedAnswer{at("low", 0.5), at("best", 0.95), at("close", 0.85)})
+	assert.Equal(t, "best", match.Answer.ID)
+	assert.InDelta(t, 0.95, match.Score, 1e-6)
+	assert.True(t, match.IsDirect())
+
+	match = selectCuratedAnswer(question, []*domain.CuratedAnswer{at("low", 0.5), at("prompt", 0.85)})
+	assert.Equal(t, "prompt", match.Answer.ID)
+	assert.False(t, match.IsDirect())
+
+	assert.Nil(t, selectCuratedAnswer(question, []*domain.CuratedAnswer{at("low", 0.79)}))
+	assert.Nil(t, selectCuratedAnswer(question, nil))
+}
@@ -0,0 +1,364 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	modelkit "github.com/chaitin/ModelKit/v2/usecase"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/repo/mq"
+	"github.com/chaitin/panda-wiki/repo/pg"
+)
+
+// EvalUsecase manages golden question sets and runs them through the retrieval and chat pipeline
+type EvalUsecase struct {
+	repo         *pg.EvalRepository
+	jobRepo      *mq.JobRepository
+	promptRepo   *pg.PromptRepo
+	llmUsecase   *LLMUsecase
+	modelUsecase *ModelUsecase
+	logger       *log.Logger
+	modelkit     *modelkit.ModelKit
+}
+
+func NewEvalUsecase(repo *pg.EvalRepository, jobRepo *mq.JobRepository, promptRepo *pg.PromptRepo, llmUsecase *LLMUsecase,
+	modelUsecase *ModelUsecase, logger *log.Logger) *EvalUsecase {
+	return &EvalUsecase{
+		repo:         repo,
+		jobRepo:      jobRepo,
+		promptRepo:   promptRepo,
+		llmUsecase:   llmUsecase,
+		modelUsecase: modelUsecase,
+		logger:       logger.WithModule("usecase.eval"),
+		modelkit:     modelkit.NewModelKit(logger.Logger),
+	}
+}
+
+func (u *EvalUsecase) CreateSet(ctx context.Context, req *domain.CreateEvalSetReq) (*domain.EvalSet, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+	set := &domain.EvalSet{
+		ID:          id.String(),
+		KBID:        req.KBID,
+		Name:        req.Name,
+		Description: req.Description,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+	if err := u.repo.CreateSet(ctx, set); err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+func (u *EvalUsecase) UpdateSet(ctx context.Context, req *domain.UpdateEvalSetReq) error {
+	set, err := u.repo.GetSet(ctx, req.KBID, req.ID)
+	if err != nil {
+		return err
+	}
+	if req.Name != nil {
+		set.Name = *req.Name
+	}
+	if req.Description != nil {
+		set.Description = *req.Description
+	}
+	set.UpdatedAt = time.Now()
+	return u.repo.UpdateSet(ctx, set)
+}
+
+func (u *EvalUsecase) DeleteSet(ctx context.Context, kbID, id string) error {
+	return u.repo.DeleteSet(ctx, kbID, id)
+}
+
+func (u *EvalUsecase) GetSetList(ctx context.Context, kbID string) ([]*domain.EvalSet, error) {
+	return u.repo.GetSetList(ctx, kbID)
+}
+
+func (u *EvalUsecase) GetQuestionList(ctx context.Context, req *domain.EvalQuestionListReq) ([]*domain.EvalQuestion, error) {
+	if _, err := u.repo.GetSet(ctx, req.KBID, req.SetID); err != nil {
+		return nil, err
+	}
+	return u.repo.GetQuestionsBySetID(ctx, req.SetID)
+}
+
+func (u *EvalUsecase) CreateQuestion(ctx context.Context, req *domain.CreateEvalQuestionReq) (*domain.EvalQuestion, error) {
+	if _, err := u.repo.GetSet(ctx, req.KBID, req.SetID); err != nil {
+		return nil, err
+	}
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+	question := &domain.EvalQuestion{
+		ID:              id.String(),
+		SetID:           req.SetID,
+		KBID:            req.KBID,
+		Question:        strings.TrimSpace(req.Question),
+		ExpectedNodeIDs: lo.Uniq(req.ExpectedNodeIDs),
+		ReferenceAnswer: req.ReferenceAnswer,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+	if err := u.repo.CreateQuestion(ctx, question); err != nil {
+		return nil, err
+	}
+	return question, nil
+}
+
+func (u *EvalUsecase) UpdateQuestion(ctx context.Context, req *domain.UpdateEvalQuestionReq) error {
+	question, err := u.repo.GetQuestion(ctx, req.KBID, req.ID)
+	if err != nil {
+		return err
+	}
+	if req.Question != nil {
+		question.Question = strings.TrimSpace(*req.Question)
+		if question.Question == "" {
+			return fmt.Errorf("question is required")
+		}
+	}
+	if req.ExpectedNodeIDs != nil {
+		question.ExpectedNodeIDs = lo.Uniq(*req.ExpectedNodeIDs)
+	}
+	if req.ReferenceAnswer != nil {
+		question.ReferenceAnswer = *req.ReferenceAnswer
+	}
+	question.UpdatedAt = time.Now()
+	return u.repo.UpdateQuestion(ctx, question)
+}
+
+func (u *EvalUsecase) DeleteQuestion(ctx context.Context, kbID, id string) error {
+	return u.repo.DeleteQuestion(ctx, kbID, id)
+}
+
+// CreateRun creates a pending run and hands it to the consumer
+func (u *EvalUsecase) CreateRun(ctx context.Context, req *domain.CreateEvalRunReq) (*domain.EvalRun, error) {
+	if _, err := u.repo.GetSet(ctx, req.KBID, req.SetID); err != nil {
+		return nil, err
+	}
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+	run := &domain.EvalRun{
+		ID:        id.String(),
+		SetID:     req.SetID,
+		KBID:      req.KBID,
+		Status:    domain.EvalRunStatusPending,
+		CreatedAt: time.Now(),
+	}
+	if err := u.repo.CreateRun(ctx, run); err != nil {
+		return nil, err
+	}
+	if err := u.jobRepo.AsyncRunEval(ctx, &domain.EvalTaskRequest{RunID: run.ID}); err != nil {
+		return nil, fmt.Errorf("publish eval task failed: %w", err)
+	}
+	return run, nil
+}
+
+func (u *EvalUsecase) GetRunList(ctx context.Context, req *domain.EvalRunListReq) (*domain.PaginatedResult[[]*domain.EvalRun], error) {
+	runs, total, err := u.repo.GetRunList(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewPaginatedResult(runs, uint64(total)), nil
+}
+
+func (u *EvalUsecase) GetRunDetail(ctx context.Context, kbID, id string) (*domain.EvalRunDetailResp, error) {
+	run, err := u.repo.GetRun(ctx, kbID, id)
+	if err != nil {
+		return nil, err
+	}
+	results, err := u.repo.GetResultsByRunID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.EvalRunDetailResp{EvalRun: run, Results: results}, nil
+}
+
+// CompareRuns pairs the results of two runs by question
+func (u *EvalUsecase) CompareRuns(ctx context.Context, req *domain.EvalRunCompareReq) (*domain.EvalRunCompareResp, error) {
+	base, err := u.GetRunDetail(ctx, req.KBID, req.BaseID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := u.GetRunDetail(ctx, req.KBID, req.TargetID)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]*domain.EvalRunCompareItem, 0, len(base.Results))
+	itemMap := make(map[string]*domain.EvalRunCompareItem)
+	for _, result := range base.Results {
+		item := &domain.EvalRunCompareItem{QuestionID: result.QuestionID, Question: result.Question, Base: result}
+		items = append(items, item)
+		itemMap[result.QuestionID] = item
+	}
+	for _, result := range target.Results {
+		if item, ok := itemMap[result.QuestionID]; ok {
+			item.Target = result
+			continue
+		}
+		items = append(items, &domain.EvalRunCompareItem{QuestionID: result.QuestionID, Question: result.Question, Target: result})
+	}
+	return &domain.EvalRunCompareResp{Base: base.EvalRun, Target: target.EvalRun, Items: items}, nil
+}
+
+// Run executes a pending run, runs already picked up are skipped so that redelivered tasks are harmless
+func (u *EvalUsecase) Run(ctx context.Context, runID string) error {
+	run, err := u.repo.GetRun(ctx, "", runID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if run.Status != domain.EvalRunStatusPending {
+		u.logger.Info("eval run already picked up", log.String("run_id", runID), log.String("status", string(run.Status)))
+		return nil
+	}
+	now := time.Now()
+	run.StartedAt = &now
+	run.Config = u.runConfig(ctx, run.KBID)
+	started, err := u.repo.StartRun(ctx, run)
+	if err != nil || !started {
+		return err
+	}
+
+	metrics, err := u.execute(ctx, run)
+	finishedAt := time.Now()
+	run.FinishedAt = &finishedAt
+	run.Status = domain.EvalRunStatusCompleted
+	if err != nil {
+		run.Status = domain.EvalRunStatusFailed
+		run.Error = err.Error()
+	}
+	run.Metrics = metrics
+	return u.repo.FinishRun(ctx, run)
+}
+
+func (u *EvalUsecase) execute(ctx context.Context, run *domain.EvalRun) (domain.EvalMetrics, error) {
+	questions, err := u.repo.GetQuestionsBySetID(ctx, run.SetID)
+	if err != nil {
+		return domain.EvalMetrics{}, err
+	}
+	chatModelInfo, err := u.modelUsecase.GetChatModel(ctx)
+	if err != nil {
+		return domain.EvalMetrics{}, fmt.Errorf("get chat model failed: %w", err)
+	}
+	modelkitModel, err := chatModelInfo.ToModelkitModel()
+	if err != nil {
+		return domain.EvalMetrics{}, err
+	}
+	chatModel, err := u.modelkit.GetChatModel(ctx, modelkitModel)
+	if err != nil {
+		return domain.EvalMetrics{}, err
+	}
+
+	results := make([]*domain.EvalResult, 0, len(questions))
+	for _, question := range questions {
+		result := u.evaluate(ctx, run, question, chatModelInfo, chatModel)
+		if err := u.repo.CreateResult(ctx, result); err != nil {
+			return domain.EvalMetrics{}, fmt.Errorf("save eval result failed: %w", err)
+		}
+		results = append(results, result)
+	}
+	return domain.NewEvalMetrics(questions, results), nil
+}
+
+// evaluate answers the question as an anonymous user without a stored conversation and judges the answer
+func (u *EvalUsecase) evaluate(ctx context.Context, run *domain.EvalRun, question *domain.EvalQuestion, modelInfo *domain.Model, chatModel model.BaseChatModel) *domain.EvalResult {
+	result := &domain.EvalResult{
+		ID:               uuid.New().String(),
+		RunID:            run.ID,
+		QuestionID:       question.ID,
+		Question:         question.Question,
+		RetrievedNodeIDs: []string{},
+		CreatedAt:        time.Now(),
+	}
+	messages, rankedNodes, err := u.llmUsecase.FormatConversationMessages(ctx, &domain.ChatRequest{
+		KBID:      run.KBID,
+		Message:   question.Question,
+		History:   []*schema.Message{},
+		ModelInfo: modelInfo,
+	}, []int{})
+	if err != nil {
+		result.Error = fmt.Sprintf("retrieve failed: %s", err)
+		return result
+	}
+	result.RetrievedNodeIDs = lo.Map(rankedNodes, func(node *domain.RankedNodeChunks, _ int) string {
+		return node.NodeID
+	})
+	result.ScoreRetrieval(question.ExpectedNodeIDs)
+
+	answer, err := u.llmUsecase.Generate(ctx, chatModel, messages)
+	if err != nil {
+		result.Error = fmt.Sprintf("answer failed: %s", err)
+		return result
+	}
+	result.Answer = u.llmUsecase.trimThinking(answer)
+
+	judgement, err := u.judge(ctx, chatModel, question, result.Answer, rankedNodes)
+	if err != nil {
+		u.logger.Warn("failed to judge eval answer", log.String("question_id", question.ID), log.Error(err))
+		result.JudgeReason = fmt.Sprintf("judge failed: %s", err)
+		return result
+	}
+	result.Faithfulness = judgement.Faithfulness
+	if question.ReferenceAnswer != "" {
+		result.Correctness = judgement.Correctness
+	}
+	result.JudgeReason = judgement.Reason
+	return result
+}
+
+func (u *EvalUsecase) judge(ctx context.Context, chatModel model.BaseChatModel, question *domain.EvalQuestion, answer string, rankedNodes []*domain.RankedNodeChunks) (*domain.EvalJudgement, error) {
+	reference := question.ReferenceAnswer
+	if reference == "" {
+		reference = "无"
+	}
+	content := fmt.Sprintf(domain.EvalJudgeFormatter, domain.FormatNodeChunks(rankedNodes, ""), question.Question, answer, reference)
+	result, err := u.llmUsecase.Generate(ctx, chatModel, []*schema.Message{
+		schema.SystemMessage(domain.EvalJudgePrompt),
+		schema.UserMessage(content),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return domain.ParseEvalJudgement(u.llmUsecase.trimThinking(result))
+}
+
+// runConfig snapshots the models and prompt in use
+func (u *EvalUsecase) runConfig(ctx context.Context, kbID string) domain.EvalRunConfig {
+	config := domain.EvalRunConfig{}
+	if setting, err := u.modelUsecase.GetModelModeSetting(ctx); err == nil {
+		config.Mode = string(setting.Mode)
+	}
+	modelName := func(modelType domain.ModelType) string {
+		m, err := u.modelUsecase.GetActiveModelByType(ctx, modelType)
+		if err != nil {
+			return ""
+		}
+		return m.Model
+	}
+	config.ChatModel = modelName(domain.ModelTypeChat)
+	config.EmbeddingModel = modelName(domain.ModelTypeEmbedding)
+	config.RerankModel = modelName(domain.ModelTypeRerank)
+	if prompt, err := u.promptRepo.GetPrompt(ctx, kbID); err == nil {
+		config.Prompt = prompt
+	}
+	if config.Prompt == "" {
+		config.Prompt = domain.SystemDefaultPrompt
+	}
+	return config
+}
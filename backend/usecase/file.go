@@ -16,6 +16,7 @@ import (
 	"github.com/chaitin/panda-wiki/config"
 	"github.com/chaitin/panda-wiki/domain"
 	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/pkg/docimport"
 	"github.com/chaitin/panda-wiki/store/s3"
 )
 
@@ -186,3 +187,42 @@ func (u *FileUsecase) AnyDocUploadFile(ctx context.Context, file *multipart.File
 
 	return resp.Key, nil
 }
+
+// DownloadFile reads a file uploaded to the kb, files larger than maxSize are rejected with docimport.ErrLimitExceeded
+func (u *FileUsecase) DownloadFile(ctx context.Context, kbID, key string, maxSize int64) ([]byte, error) {
+	object, err := u.openFile(ctx, kbID, key, maxSize)
+	if err != nil {
+		return nil, err
+	}
+	defer object.Close()
+	return io.ReadAll(io.LimitReader(object, maxSize))
+}
+
+// CheckFile fails like DownloadFile would, without reading the file
+func (u *FileUsecase) CheckFile(ctx context.Context, kbID, key string, maxSize int64) error {
+	object, err := u.openFile(ctx, kbID, key, maxSize)
+	if err != nil {
+		return err
+	}
+	return object.Close()
+}
+
+func (u *FileUsecase) openFile(ctx context.Context, kbID, key string, maxSize int64) (*minio.Object, error) {
+	if !strings.HasPrefix(key, kbID+"/") || strings.Contains(key, "..") {
+		return nil, fmt.Errorf("file %s does not belong to the knowledge base", key)
+	}
+	object, err := u.s3Client.GetObject(ctx, domain.Bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("get file failed: %w", err)
+	}
+	info, err := object.Stat()
+	if err != nil {
+		object.Close()
+		return nil, fmt.Errorf("get file failed: %w", err)
+	}
+	if info.Size > maxSize {
+		object.Close()
+		return nil, fmt.Errorf("%w: file is larger than %d MB", docimport.ErrLimitExceeded, maxSize>>20)
+	}
+	return object, nil
+}
@@ -0,0 +1,563 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/chaitin/panda-wiki/config"
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/pkg/gitrepo"
+	"github.com/chaitin/panda-wiki/repo/mq"
+	"github.com/chaitin/panda-wiki/repo/pg"
+	"github.com/chaitin/panda-wiki/utils"
+)
+
+// GitSourceUsecase syncs markdown files from git repositories into kbs
+type GitSourceUsecase struct {
+	repo        *pg.GitSourceRepository
+	nodeRepo    *pg.NodeRepository
+	nodeUsecase *NodeUsecase
+	kbUsecase   *KnowledgeBaseUsecase
+	fileUsecase *FileUsecase
+	jobRepo     *mq.JobRepository
+	config      *config.Config
+	logger      *log.Logger
+}
+
+func NewGitSourceUsecase(repo *pg.GitSourceRepository, nodeRepo *pg.NodeRepository, nodeUsecase *NodeUsecase, kbUsecase *KnowledgeBaseUsecase,
+	fileUsecase *FileUsecase, jobRepo *mq.JobRepository, config *config.Config, logger *log.Logger) *GitSourceUsecase {
+	return &GitSourceUsecase{
+		repo:        repo,
+		nodeRepo:    nodeRepo,
+		nodeUsecase: nodeUsecase,
+		kbUsecase:   kbUsecase,
+		fileUsecase: fileUsecase,
+		jobRepo:     jobRepo,
+		config:      config,
+		logger:      logger.WithModule("usecase.git_source"),
+	}
+}
+
+func (u *GitSourceUsecase) Create(ctx context.Context, req *domain.CreateGitSourceReq) (*domain.GitSource, error) {
+	switch {
+	case (req.URL == "") == (req.LocalPath == ""):
+		return nil, fmt.Errorf("either url or local path is required")
+	case req.URL != "":
+		if err := domain.ValidateGitURL(req.URL); err != nil {
+			return nil, err
+		}
+		if err := checkPublicGitHost(ctx, req.URL); err != nil {
+			return nil, err
+		}
+	default:
+		if _, err := u.localRepoPath(req.LocalPath); err != nil {
+			return nil, err
+		}
+	}
+	if err := gitrepo.ValidateRef(req.Branch); err != nil {
+		return nil, err
+	}
+	dir, err := domain.CleanGitDir(req.Dir)
+	if err != nil {
+		return nil, err
+	}
+	if req.ParentID != "" {
+		parent, err := u.nodeRepo.GetNodeByID(ctx, req.ParentID)
+		if err != nil {
+			return nil, fmt.Errorf("get parent node failed: %w", err)
+		}
+		if parent.KBID != req.KBID || parent.Type != domain.NodeTypeFolder {
+			return nil, fmt.Errorf("parent node must be a folder of the kb")
+		}
+	}
+	source := &domain.GitSource{
+		ID:          uuid.New().String(),
+		KBID:        req.KBID,
+		URL:         req.URL,
+		LocalPath:   req.LocalPath,
+		Branch:      req.Branch,
+		Dir:         dir,
+		ParentID:    req.ParentID,
+		AutoRelease: req.AutoRelease,
+		Status:      domain.GitSyncStatusIdle,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+	if err := u.repo.Create(ctx, source); err != nil {
+		return nil, err
+	}
+	return source, nil
+}
+
+func (u *GitSourceUsecase) GetList(ctx context.Context, kbID string) ([]*domain.GitSource, error) {
+	return u.repo.GetList(ctx, kbID)
+}
+
+func (u *GitSourceUsecase) Update(ctx context.Context, req *domain.UpdateGitSourceReq) error {
+	source, err := u.repo.GetByID(ctx, req.KBID, req.ID)
+	if err != nil {
+		return err
+	}
+	if req.Branch != nil {
+		if err := gitrepo.ValidateRef(*req.Branch); err != nil {
+			return err
+		}
+		source.Branch = *req.Branch
+	}
+	if req.Dir != nil {
+		dir, err := domain.CleanGitDir(*req.Dir)
+		if err != nil {
+			return err
+		}
+		// paths are mapped relative to the directory, the next sync compares the whole tree
+		if dir != source.Dir {
+			source.LastCommit = ""
+		}
+		source.Dir = dir
+	}
+	if req.AutoRelease != nil {
+		source.AutoRelease = *req.AutoRelease
+	}
+	source.UpdatedAt = time.Now()
+	return u.repo.Update(ctx, source)
+}
+
+// Delete deletes the source, the synced nodes are kept unless asked otherwise
+func (u *GitSourceUsecase) Delete(ctx context.Context, req *domain.DeleteGitSourceReq) error {
+	source, err := u.repo.GetByID(ctx, req.KBID, req.ID)
+	if err != nil {
+		return err
+	}
+	if req.DeleteNodes {
+		nodes, err := u.repo.GetNodes(ctx, source.ID)
+		if err != nil {
+			return err
+		}
+		nodeIDs := make([]string, 0, len(nodes))
+		for _, node := range nodes {
+			nodeIDs = append(nodeIDs, node.NodeID)
+		}
+		if len(nodeIDs) > 0 {
+			if err := u.nodeUsecase.NodeAction(ctx, &domain.NodeActionReq{KBID: source.KBID, IDs: nodeIDs, Action: "delete"}); err != nil {
+				return err
+			}
+		}
+	}
+	if err := u.repo.Delete(ctx, source.KBID, source.ID); err != nil {
+		return err
+	}
+	if source.URL != "" {
+		if err := os.RemoveAll(u.mirrorDir(source.ID)); err != nil {
+			u.logger.Warn("remove git mirror failed", log.String("source_id", source.ID), log.Error(err))
+		}
+	}
+	return nil
+}
+
+// Sync queues a sync of the source, a source is synced by one consumer at a time
+func (u *GitSourceUsecase) Sync(ctx context.Context, req *domain.SyncGitSourceReq, userID string, maxNode int) error {
+	queued, err := u.repo.Queue(ctx, req.KBID, req.ID)
+	if err != nil {
+		return err
+	}
+	if !queued {
+		if _, err := u.repo.GetByID(ctx, req.KBID, req.ID); err != nil {
+			return err
+		}
+		return fmt.Errorf("git source is already syncing")
+	}
+	if err := u.jobRepo.AsyncSyncGitSource(ctx, &domain.GitSyncTaskRequest{
+		SourceID: req.ID,
+		UserID:   userID,
+		MaxNode:  maxNode,
+		Full:     req.Full,
+	}); err != nil {
+		err = fmt.Errorf("publish git sync task failed: %w", err)
+		// the source would stay pending and block the next sync
+		if failErr := u.repo.Fail(context.WithoutCancel(ctx), req.ID, err.Error()); failErr != nil {
+			u.logger.Error("fail git sync failed", log.String("source_id", req.ID), log.Error(failErr))
+		}
+		return err
+	}
+	return nil
+}
+
+// Run syncs a pending source, sources already picked up are skipped so that redelivered tasks are harmless
+func (u *GitSourceUsecase) Run(ctx context.Context, task *domain.GitSyncTaskRequest) error {
+	source, err := u.repo.GetByID(ctx, "", task.SourceID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	started, err := u.repo.Start(ctx, source.ID)
+	if err != nil || !started {
+		return err
+	}
+
+	result, err := u.execute(ctx, source, task)
+	source.Status = domain.GitSyncStatusCompleted
+	source.Error = ""
+	if err != nil {
+		source.Status = domain.GitSyncStatusFailed
+		source.Error = err.Error()
+	} else {
+		source.LastCommit = result.Commit
+	}
+	source.Result = *result
+	syncedAt := time.Now()
+	source.SyncedAt = &syncedAt
+	return u.repo.Finish(ctx, source)
+}
+
+func (u *GitSourceUsecase) execute(ctx context.Context, source *domain.GitSource, task *domain.GitSyncTaskRequest) (*domain.GitSyncResult, error) {
+	result := &domain.GitSyncResult{}
+	repo, err := u.openRepo(ctx, source)
+	if err != nil {
+		return result, err
+	}
+	commit, err := repo.ResolveCommit(ctx, source.Branch)
+	if err != nil {
+		return result, err
+	}
+	result.Commit = commit
+	nodes, err := u.repo.GetNodes(ctx, source.ID)
+	if err != nil {
+		return result, err
+	}
+
+	// paths of the documents relative to source.Dir
+	upserts, deletes := make([]string, 0), make([]string, 0)
+	if task.Full || source.LastCommit == "" || !repo.HasCommit(ctx, source.LastCommit) {
+		files, err := repo.ListFiles(ctx, commit)
+		if err != nil {
+			return result, err
+		}
+		present := make(map[string]bool)
+		for _, file := range files {
+			if rel, ok := domain.GitDocPath(source.Dir, file); ok {
+				upserts = append(upserts, rel)
+				present[rel] = true
+			}
+		}
+		for rel, node := range nodes {
+			if node.Type == domain.NodeTypeDocument && !present[rel] {
+				deletes = append(deletes, rel)
+			}
+		}
+	} else if commit != source.LastCommit {
+		// only changed documents are synced, an image changed on its own is picked up with the next change
+		// of a document using it or by a full sync
+		changes, err := repo.Diff(ctx, source.LastCommit, commit)
+		if err != nil {
+			return result, err
+		}
+		for _, change := range changes {
+			rel, ok := domain.GitDocPath(source.Dir, change.Path)
+			if !ok {
+				continue
+			}
+			if change.Type == gitrepo.ChangeDeleted {
+				if _, ok := nodes[rel]; ok {
+					deletes = append(deletes, rel)
+				}
+				continue
+			}
+			upserts = append(upserts, rel)
+		}
+	}
+	sort.Strings(upserts)
+
+	changedNodeIDs := make([]string, 0, len(upserts))
+	images := make(map[string]string)
+	for _, rel := range upserts {
+		nodeIDs, err := u.syncDoc(ctx, repo, commit, source, task, rel, nodes, images, result)
+		if err != nil {
+			return result, fmt.Errorf("sync %s failed: %w", rel, err)
+		}
+		changedNodeIDs = append(changedNodeIDs, nodeIDs...)
+	}
+	if err := u.deleteDocs(ctx, source, deletes, nodes, result); err != nil {
+		return result, err
+	}
+
+	if source.AutoRelease && (len(changedNodeIDs) > 0 || result.Deleted > 0) {
+		releaseID, err := u.kbUsecase.CreateKBRelease(ctx, &domain.CreateKBReleaseReq{
+			KBID:    source.KBID,
+			Message: fmt.Sprintf("Git 同步 %s@%s", source.Branch, commit[:min(len(commit), 12)]),
+			Tag:     commit,
+			NodeIDs: changedNodeIDs,
+		}, task.UserID)
+		if err != nil {
+			return result, fmt.Errorf("create release failed: %w", err)
+		}
+		result.ReleaseID = releaseID
+	}
+	return result, nil
+}
+
+// syncDoc creates or updates the document at rel and the folders above it, the ids of the changed nodes are returned
+func (u *GitSourceUsecase) syncDoc(ctx context.Context, repo *gitrepo.Repo, commit string, source *domain.GitSource, task *domain.GitSyncTaskRequest,
+	rel string, nodes map[string]*domain.GitSourceNode, images map[string]string, result *domain.GitSyncResult) ([]string, error) {
+	changedNodeIDs := make([]string, 0)
+	parentID := source.ParentID
+	for _, dir := range domain.GitParentDirs(rel) {
+		nodeID, state, err := u.syncNode(ctx, source, task, dir, parentID, domain.NodeTypeFolder, path.Base(dir), "", nodes)
+		if err != nil {
+			return nil, err
+		}
+		if state == gitNodeCreated {
+			result.Created++
+			changedNodeIDs = append(changedNodeIDs, nodeID)
+		}
+		parentID = nodeID
+	}
+
+	file := path.Join(source.Dir, rel)
+	content, err := repo.ReadFile(ctx, commit, file)
+	if err != nil {
+		return nil, err
+	}
+	markdown := u.uploadImages(ctx, repo, commit, source, file, string(content), images)
+	nodeID, state, err := u.syncNode(ctx, source, task, rel, parentID, domain.NodeTypeDocument, domain.GitDocName(rel), markdown, nodes)
+	if err != nil {
+		return nil, err
+	}
+	switch state {
+	case gitNodeCreated:
+		result.Created++
+	case gitNodeUpdated:
+		result.Updated++
+	default:
+		return changedNodeIDs, nil
+	}
+	return append(changedNodeIDs, nodeID), nil
+}
+
+type gitNodeState int
+
+const (
+	gitNodeUnchanged gitNodeState = iota
+	gitNodeCreated
+	gitNodeUpdated
+)
+
+// syncNode updates the node mapped to the path, or creates it if it does not exist any more.
+// folders that already exist are left untouched
+func (u *GitSourceUsecase) syncNode(ctx context.Context, source *domain.GitSource, task *domain.GitSyncTaskRequest, rel, parentID string,
+	nodeType domain.NodeType, name, content string, nodes map[string]*domain.GitSourceNode) (string, gitNodeState, error) {
+	if mapped, ok := nodes[rel]; ok {
+		node, err := u.nodeRepo.GetNodeByID(ctx, mapped.NodeID)
+		switch {
+		case err == nil && (nodeType == domain.NodeTypeFolder || node.Name == name && node.Content == content):
+			return mapped.NodeID, gitNodeUnchanged, nil
+		case err == nil:
+			if err := u.nodeRepo.UpdateNodeContent(ctx, &domain.UpdateNodeReq{
+				ID:      mapped.NodeID,
+				KBID:    source.KBID,
+				Name:    &name,
+				Content: &content,
+			}, task.UserID); err != nil {
+				return "", gitNodeUnchanged, err
+			}
+			return mapped.NodeID, gitNodeUpdated, nil
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return "", gitNodeUnchanged, err
+		}
+	}
+
+	contentType := domain.ContentTypeMD
+	nodeID, err := u.nodeRepo.Create(ctx, &domain.CreateNodeReq{
+		KBID:        source.KBID,
+		ParentID:    parentID,
+		Type:        nodeType,
+		Name:        name,
+		Content:     content,
+		ContentType: &contentType,
+		MaxNode:     task.MaxNode,
+	}, task.UserID)
+	if err != nil {
+		return "", gitNodeUnchanged, err
+	}
+	mapped := &domain.GitSourceNode{SourceID: source.ID, Path: rel, NodeID: nodeID, Type: nodeType}
+	if err := u.repo.SaveNode(ctx, mapped); err != nil {
+		return "", gitNodeUnchanged, err
+	}
+	nodes[rel] = mapped
+	return nodeID, gitNodeCreated, nil
+}
+
+// deleteDocs deletes the documents removed from the repository and the folders left empty by them
+func (u *GitSourceUsecase) deleteDocs(ctx context.Context, source *domain.GitSource, deletes []string, nodes map[string]*domain.GitSourceNode, result *domain.GitSyncResult) error {
+	if len(deletes) == 0 {
+		return nil
+	}
+	nodeIDs := make([]string, 0, len(deletes))
+	for _, rel := range deletes {
+		nodeIDs = append(nodeIDs, nodes[rel].NodeID)
+		delete(nodes, rel)
+	}
+	if err := u.nodeUsecase.NodeAction(ctx, &domain.NodeActionReq{KBID: source.KBID, IDs: nodeIDs, Action: "delete"}); err != nil {
+		return err
+	}
+	if err := u.repo.DeleteNodes(ctx, source.ID, deletes); err != nil {
+		return err
+	}
+	result.Deleted += len(deletes)
+
+	// deepest folders first so that their parents can become empty as well
+	folders := make([]string, 0)
+	for rel, node := range nodes {
+		if node.Type == domain.NodeTypeFolder {
+			folders = append(folders, rel)
+		}
+	}
+	sort.Slice(folders, func(i, j int) bool {
+		return strings.Count(folders[i], "/") > strings.Count(folders[j], "/")
+	})
+	for _, folder := range folders {
+		hasChildren, err := u.nodeRepo.HasChildren(ctx, source.KBID, nodes[folder].NodeID)
+		if err != nil {
+			return err
+		}
+		if hasChildren {
+			continue
+		}
+		if err := u.nodeUsecase.NodeAction(ctx, &domain.NodeActionReq{KBID: source.KBID, IDs: []string{nodes[folder].NodeID}, Action: "delete"}); err != nil {
+			return err
+		}
+		if err := u.repo.DeleteNodes(ctx, source.ID, []string{folder}); err != nil {
+			return err
+		}
+		delete(nodes, folder)
+		result.Deleted++
+	}
+	return nil
+}
+
+// uploadImages uploads the images referenced by relative paths and points the document at the uploaded files,
+// images that can not be read are left as they are
+func (u *GitSourceUsecase) uploadImages(ctx context.Context, repo *gitrepo.Repo, commit string, source *domain.GitSource, file, content string, images map[string]string) string {
+	urls := make(map[string]string)
+	for _, ref := range domain.ExtractImageURLs(content) {
+		resolved, ok := domain.ResolveRelativeRef(file, ref)
+		if !ok {
+			continue
+		}
+		if uploaded, ok := images[resolved]; ok {
+			urls[ref] = uploaded
+			continue
+		}
+		uploaded, err := u.uploadImage(ctx, repo, commit, source, resolved)
+		if err != nil {
+			u.logger.Warn("upload image failed", log.String("path", resolved), log.Error(err))
+			continue
+		}
+		images[resolved] = uploaded
+		urls[ref] = uploaded
+	}
+	return domain.ReplaceImageURLs(content, urls)
+}
+
+// uploadImage uploads the image unless the same content was uploaded by an earlier sync,
+// so that unchanged documents keep their content
+func (u *GitSourceUsecase) uploadImage(ctx context.Context, repo *gitrepo.Repo, commit string, source *domain.GitSource, file string) (string, error) {
+	data, err := repo.ReadFile(ctx, commit, file)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	image, err := u.repo.GetImage(ctx, source.ID, file)
+	if err != nil {
+		return "", err
+	}
+	if image != nil && image.Hash == hash {
+		return image.URL, nil
+	}
+	key, err := u.fileUsecase.UploadFileFromBytes(ctx, source.KBID, path.Base(file), data)
+	if err != nil {
+		return "", err
+	}
+	image = &domain.GitSourceImage{SourceID: source.ID, Path: file, Hash: hash, URL: "/" + domain.Bucket + "/" + key}
+	if err := u.repo.SaveImage(ctx, image); err != nil {
+		return "", err
+	}
+	return image.URL, nil
+}
+
+func (u *GitSourceUsecase) openRepo(ctx context.Context, source *domain.GitSource) (*gitrepo.Repo, error) {
+	if source.URL != "" {
+		// checked again on every sync since the host may resolve elsewhere by now
+		if err := checkPublicGitHost(ctx, source.URL); err != nil {
+			return nil, err
+		}
+		return gitrepo.Mirror(ctx, source.URL, source.Branch, u.mirrorDir(source.ID))
+	}
+	dir, err := u.localRepoPath(source.LocalPath)
+	if err != nil {
+		return nil, err
+	}
+	return gitrepo.Open(ctx, dir)
+}
+
+// checkPublicGitHost rejects repository urls whose host resolves to a private, loopback or link-local address.
+// git resolves the host again when fetching, so this narrows the window rather than pinning the address
+func checkPublicGitHost(ctx context.Context, gitURL string) error {
+	host, err := domain.GitURLHost(gitURL)
+	if err != nil {
+		return err
+	}
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return fmt.Errorf("resolve repository host %s failed: %w", host, err)
+	}
+	for _, addr := range addrs {
+		if !utils.IsPublicIP(addr.IP.String()) {
+			return fmt.Errorf("%w: %s resolves to %s", utils.ErrNonPublicAddress, host, addr.IP)
+		}
+	}
+	return nil
+}
+
+func (u *GitSourceUsecase) mirrorDir(sourceID string) string {
+	return filepath.Join(u.config.Git.CacheDir, sourceID)
+}
+
+// localRepoPath resolves a local repository path, only paths below the configured root are allowed
+func (u *GitSourceUsecase) localRepoPath(localPath string) (string, error) {
+	if u.config.Git.LocalRoot == "" {
+		return "", fmt.Errorf("local repositories are disabled")
+	}
+	if !filepath.IsAbs(localPath) {
+		return "", fmt.Errorf("local path must be absolute")
+	}
+	root, err := filepath.EvalSymlinks(u.config.Git.LocalRoot)
+	if err != nil {
+		return "", fmt.Errorf("invalid local root: %w", err)
+	}
+	dir, err := filepath.EvalSymlinks(localPath)
+	if err != nil {
+		return "", fmt.Errorf("invalid local path: %w", err)
+	}
+	rel, err := filepath.Rel(root, dir)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("local path must be below %s", u.config.Git.LocalRoot)
+	}
+	return dir, nil
+}
@@ -0,0 +1,19 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/chaitin/panda-wiki/utils"
+)
+
+func TestCheckPublicGitHost(t *testing.T) {
+	ctx := context.Background()
+	assert.ErrorIs(t, checkPublicGitHost(ctx, "http://127.0.0.1/docs.git"), utils.ErrNonPublicAddress)
+	assert.ErrorIs(t, checkPublicGitHost(ctx, "https://169.254.169.254:443/docs.git"), utils.ErrNonPublicAddress)
+	assert.ErrorIs(t, checkPublicGitHost(ctx, "git@10.0.0.1:team/docs.git"), utils.ErrNonPublicAddress)
+	assert.ErrorIs(t, checkPublicGitHost(ctx, "ssh://git@[::1]/docs.git"), utils.ErrNonPublicAddress)
+	assert.NoError(t, checkPublicGitHost(ctx, "https://8.8.8.8/docs.git"))
+}
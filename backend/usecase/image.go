@@ -0,0 +1,270 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	modelkit "github.com/chaitin/ModelKit/v2/usecase"
+	"github.com/cloudwego/eino/schema"
+	"github.com/samber/lo"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/repo/pg"
+	"github.com/chaitin/panda-wiki/utils"
+)
+
+const imageDownloadTimeout = 30 * time.Second
+
+// ImageUsecase loads images and extracts their content with the analysis-vl model
+type ImageUsecase struct {
+	fileUsecase  *FileUsecase
+	modelUsecase *ModelUsecase
+	llmUsecase   *LLMUsecase
+	captionRepo  *pg.ImageCaptionRepository
+	logger       *log.Logger
+	modelkit     *modelkit.ModelKit
+	httpClient   *http.Client
+}
+
+func NewImageUsecase(fileUsecase *FileUsecase, modelUsecase *ModelUsecase, llmUsecase *LLMUsecase,
+	captionRepo *pg.ImageCaptionRepository, logger *log.Logger) *ImageUsecase {
+	return &ImageUsecase{
+		fileUsecase:  fileUsecase,
+		modelUsecase: modelUsecase,
+		llmUsecase:   llmUsecase,
+		captionRepo:  captionRepo,
+		logger:       logger.WithModule("usecase.image"),
+		modelkit:     modelkit.NewModelKit(logger.Logger),
+		httpClient:   utils.NewPublicHTTPClient(imageDownloadTimeout),
+	}
+}
+
+// LoadImages loads the images of a question, data urls are uploaded so that only references are persisted
+func (u *ImageUsecase) LoadImages(ctx context.Context, kbID string, refs []string) ([]*domain.ChatImage, error) {
+	images := make([]*domain.ChatImage, 0, len(refs))
+	for _, ref := range refs {
+		image, err := u.loadImage(ctx, kbID, strings.TrimSpace(ref))
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, image)
+	}
+	return images, nil
+}
+
+func (u *ImageUsecase) loadImage(ctx context.Context, kbID, ref string) (*domain.ChatImage, error) {
+	image, err := u.fetchImage(ctx, kbID, ref)
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(ref, "data:") {
+		ext := ".png"
+		if exts, _ := mime.ExtensionsByType(image.MIMEType); len(exts) > 0 {
+			ext = exts[0]
+		}
+		key, err := u.fileUsecase.UploadFileFromBytes(ctx, kbID, "image"+ext, image.Data)
+		if err != nil {
+			return nil, fmt.Errorf("upload image failed: %w", err)
+		}
+		image.Ref = "/" + domain.Bucket + "/" + key
+	}
+	return image, nil
+}
+
+// fetchImage reads a data url, a file uploaded to the kb or a remote url. remote urls are downloaded
+// from public addresses only and uploaded files of other kbs are rejected
+func (u *ImageUsecase) fetchImage(ctx context.Context, kbID, ref string) (*domain.ChatImage, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if key, ok := uploadedImageKey(ref); ok {
+		data, err = u.fileUsecase.DownloadFile(ctx, kbID, key, domain.ChatImageMaxBytes)
+		ref = "/" + domain.Bucket + "/" + key
+	} else if strings.HasPrefix(ref, "data:") {
+		data, err = decodeDataURL(ref)
+	} else {
+		data, err = u.download(ctx, ref)
+	}
+	if err != nil {
+		return nil, err
+	}
+	mimeType := http.DetectContentType(data)
+	if !strings.HasPrefix(mimeType, "image/") {
+		return nil, fmt.Errorf("unsupported image type %s", mimeType)
+	}
+	return &domain.ChatImage{
+		Ref:      ref,
+		MIMEType: mimeType,
+		Data:     data,
+	}, nil
+}
+
+// uploadedImageKey returns the key of an uploaded file, referenced as /static-file/<key>, <key> or through
+// the internal address of minio
+func uploadedImageKey(ref string) (string, bool) {
+	if strings.HasPrefix(ref, "data:") {
+		return "", false
+	}
+	u, err := url.Parse(ref)
+	if err != nil {
+		return "", false
+	}
+	if u.Scheme == "http" || u.Scheme == "https" {
+		if u.Hostname() != "panda-wiki-minio" {
+			return "", false
+		}
+	} else if u.Scheme != "" {
+		return "", false
+	}
+	return strings.TrimPrefix(strings.TrimPrefix(u.Path, "/"), domain.Bucket+"/"), true
+}
+
+func (u *ImageUsecase) download(ctx context.Context, url string) ([]byte, error) {
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return nil, fmt.Errorf("unsupported image url")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := u.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("download image failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download image failed: status %d", resp.StatusCode)
+	}
+	return readImage(resp.Body)
+}
+
+// Describe extracts the text and describes the content of the images with the analysis-vl model
+func (u *ImageUsecase) Describe(ctx context.Context, images []*domain.ChatImage, question string) (string, error) {
+	model, err := u.modelUsecase.GetActiveModelByType(ctx, domain.ModelTypeAnalysisVL)
+	if err != nil {
+		return "", fmt.Errorf("get analysis-vl model failed: %w", err)
+	}
+	return u.analyze(ctx, model, domain.ImageDescriptionPrompt, fmt.Sprintf("用户问题：%s", question), images)
+}
+
+// CaptionDocument extracts the text of the images referenced by a document and injects it after each image,
+// captions are cached by image hash so that republishing a document does not analyze the same image again
+func (u *ImageUsecase) CaptionDocument(ctx context.Context, kbID, content string) (string, error) {
+	refs := domain.ExtractImageURLs(content)
+	if len(refs) == 0 {
+		return content, nil
+	}
+	model, err := u.modelUsecase.GetActiveModelByType(ctx, domain.ModelTypeAnalysisVL)
+	if err != nil {
+		return content, fmt.Errorf("get analysis-vl model failed: %w", err)
+	}
+
+	images := make(map[string]*domain.ChatImage, len(refs))
+	hashes := make(map[string]string, len(refs))
+	for _, ref := range refs {
+		image, err := u.fetchImage(ctx, kbID, ref)
+		if err != nil {
+			u.logger.Warn("failed to load document image", log.String("image", ref), log.Error(err))
+			continue
+		}
+		sum := sha256.Sum256(image.Data)
+		images[ref] = image
+		hashes[ref] = hex.EncodeToString(sum[:])
+	}
+	cached, err := u.captionRepo.GetByHashes(ctx, lo.Uniq(lo.Values(hashes)))
+	if err != nil {
+		return content, fmt.Errorf("get image captions failed: %w", err)
+	}
+
+	captions := make(map[string]string, len(images))
+	for ref, image := range images {
+		hash := hashes[ref]
+		if caption, ok := cached[hash]; ok {
+			captions[ref] = caption.Content
+			continue
+		}
+		text, err := u.analyze(ctx, model, domain.ImageCaptionPrompt, "请提取这张图片的内容", []*domain.ChatImage{image})
+		if err != nil {
+			u.logger.Warn("failed to caption document image", log.String("image", ref), log.Error(err))
+			continue
+		}
+		caption := &domain.ImageCaption{
+			Hash:      hash,
+			Model:     model.Model,
+			Content:   text,
+			CreatedAt: time.Now(),
+		}
+		if err := u.captionRepo.Create(ctx, caption); err != nil {
+			u.logger.Warn("failed to cache image caption", log.String("hash", hash), log.Error(err))
+		}
+		cached[hash] = caption
+		captions[ref] = text
+	}
+	return domain.InjectImageCaptions(content, captions), nil
+}
+
+func (u *ImageUsecase) analyze(ctx context.Context, model *domain.Model, prompt, text string, images []*domain.ChatImage) (string, error) {
+	modelkitModel, err := model.ToModelkitModel()
+	if err != nil {
+		return "", err
+	}
+	chatModel, err := u.modelkit.GetChatModel(ctx, modelkitModel)
+	if err != nil {
+		return "", err
+	}
+	parts := []schema.ChatMessagePart{{
+		Type: schema.ChatMessagePartTypeText,
+		Text: text,
+	}}
+	for _, image := range images {
+		parts = append(parts, schema.ChatMessagePart{
+			Type:     schema.ChatMessagePartTypeImageURL,
+			ImageURL: &schema.ChatMessageImageURL{URL: image.DataURL()},
+		})
+	}
+	result, err := u.llmUsecase.Generate(ctx, chatModel, []*schema.Message{
+		schema.SystemMessage(prompt),
+		{Role: schema.User, MultiContent: parts},
+	})
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(u.llmUsecase.trimThinking(result)), nil
+}
+
+func decodeDataURL(dataURL string) ([]byte, error) {
+	meta, payload, found := strings.Cut(strings.TrimPrefix(dataURL, "data:"), ",")
+	if !found || !strings.HasSuffix(meta, ";base64") {
+		return nil, fmt.Errorf("invalid image data url")
+	}
+	if base64.StdEncoding.DecodedLen(len(payload)) > domain.ChatImageMaxBytes {
+		return nil, fmt.Errorf("image is larger than %d bytes", domain.ChatImageMaxBytes)
+	}
+	data, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, fmt.Errorf("invalid image data url: %w", err)
+	}
+	return data, nil
+}
+
+func readImage(r io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, domain.ChatImageMaxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("read image failed: %w", err)
+	}
+	if len(data) > domain.ChatImageMaxBytes {
+		return nil, fmt.Errorf("image is larger than %d bytes", domain.ChatImageMaxBytes)
+	}
+	return data, nil
+}
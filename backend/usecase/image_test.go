@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/chaitin/panda-wiki/utils"
+)
+
+func TestUploadedImageKey(t *testing.T) {
+	for ref, want := range map[string]string{
+		"/static-file/kb/a.png": "kb/a.png",
+		"kb/a.png":              "kb/a.png",
+		"http://panda-wiki-minio:9000/static-file/kb/a.png": "kb/a.png",
+	} {
+		key, ok := uploadedImageKey(ref)
+		assert.True(t, ok, ref)
+		assert.Equal(t, want, key, ref)
+	}
+	for _, ref := range []string{"https://example.com/static-file/kb/a.png", "data:image/png;base64,AA", "file:///etc/passwd"} {
+		_, ok := uploadedImageKey(ref)
+		assert.False(t, ok, ref)
+	}
+}
+
+func TestFetchImageRejectsPrivateAndForeignImages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("\x89PNG\r\n\x1a\n"))
+	}))
+	defer server.Close()
+	u := &ImageUsecase{httpClient: utils.NewPublicHTTPClient(imageDownloadTimeout)}
+
+	_, err := u.fetchImage(context.Background(), "kb", server.URL+"/a.png")
+	assert.ErrorIs(t, err, utils.ErrNonPublicAddress)
+
+	for _, ref := range []string{"/static-file/other/a.png", "/static-file/kb/../other/a.png"} {
+		_, err = u.fetchImage(context.Background(), "kb", ref)
+		assert.ErrorContains(t, err, "does not belong to the knowledge base", ref)
+	}
+}
@@ -0,0 +1,321 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"golang.org/x/sync/errgroup"
+	"gorm.io/gorm"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/repo/mq"
+	"github.com/chaitin/panda-wiki/repo/pg"
+)
+
+// ImportJobUsecase imports the documents listed by the crawler in the background. the state of every document
+// is kept in the database, so that a job interrupted by a restart is resumed where it stopped
+type ImportJobUsecase struct {
+	repo           *pg.ImportJobRepository
+	nodeRepo       *pg.NodeRepository
+	nodeUsecase    *NodeUsecase
+	crawlerUsecase *CrawlerUsecase
+	jobRepo        *mq.JobRepository
+	logger         *log.Logger
+}
+
+func NewImportJobUsecase(repo *pg.ImportJobRepository, nodeRepo *pg.NodeRepository, nodeUsecase *NodeUsecase,
+	crawlerUsecase *CrawlerUsecase, jobRepo *mq.JobRepository, logger *log.Logger) *ImportJobUsecase {
+	return &ImportJobUsecase{
+		repo:           repo,
+		nodeRepo:       nodeRepo,
+		nodeUsecase:    nodeUsecase,
+		crawlerUsecase: crawlerUsecase,
+		jobRepo:        jobRepo,
+		logger:         logger.WithModule("usecase.import_job"),
+	}
+}
+
+// Create records the picked documents as listed items of a new job and queues the job
+func (u *ImportJobUsecase) Create(ctx context.Context, req *domain.CreateImportJobReq, userID string, maxNode int) (*domain.ImportJob, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if err := u.nodeUsecase.validateParentFolder(ctx, req.KBID, req.ParentID); err != nil {
+		return nil, err
+	}
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	job := &domain.ImportJob{
+		ID:          id.String(),
+		KBID:        req.KBID,
+		Source:      req.Source,
+		ParseID:     req.ParseID,
+		ParentID:    req.ParentID,
+		Concurrency: req.Concurrency,
+		Status:      domain.ImportJobStatusPending,
+		CreatorID:   userID,
+		MaxNode:     maxNode,
+		CreatedAt:   now,
+	}
+	items := lo.Map(req.Docs, func(doc *domain.ImportJobDoc, i int) *domain.ImportJobItem {
+		return &domain.ImportJobItem{
+			ID:       uuid.New().String(),
+			JobID:    job.ID,
+			DocID:    doc.DocID,
+			Title:    strings.TrimSpace(doc.Title),
+			FileType: doc.FileType,
+			SpaceID:  doc.SpaceID,
+			Folders: lo.FilterMap(doc.Folders, func(name string, _ int) (string, bool) {
+				name = strings.TrimSpace(name)
+				return name, name != ""
+			}),
+			Position:  i,
+			Status:    domain.ImportJobItemStatusListed,
+			UpdatedAt: now,
+		}
+	})
+	if err := u.repo.Create(ctx, job, items); err != nil {
+		return nil, err
+	}
+	// the job is in the database already, a lost task is published again by ResumeStale
+	if err := u.jobRepo.AsyncRunImportJob(ctx, &domain.ImportTaskRequest{JobID: job.ID}); err != nil {
+		u.logger.Error("publish import task failed", log.String("job_id", job.ID), log.Error(err))
+	}
+	job.Progress = domain.NewImportJobProgress(map[domain.ImportJobItemStatus]int{domain.ImportJobItemStatusListed: len(items)})
+	return job, nil
+}
+
+func (u *ImportJobUsecase) GetList(ctx context.Context, req *domain.ImportJobListReq) (*domain.PaginatedResult[[]*domain.ImportJob], error) {
+	jobs, total, err := u.repo.GetList(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if len(jobs) > 0 {
+		progress, err := u.repo.GetProgress(ctx, lo.Map(jobs, func(job *domain.ImportJob, _ int) string { return job.ID }))
+		if err != nil {
+			return nil, err
+		}
+		for _, job := range jobs {
+			job.Progress = progress[job.ID]
+		}
+	}
+	return domain.NewPaginatedResult(jobs, uint64(total)), nil
+}
+
+// GetDetail returns the job with the number of its items in each state
+func (u *ImportJobUsecase) GetDetail(ctx context.Context, req *domain.ImportJobDetailReq) (*domain.ImportJob, error) {
+	job, err := u.repo.GetByID(ctx, req.KBID, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	progress, err := u.repo.GetProgress(ctx, []string{job.ID})
+	if err != nil {
+		return nil, err
+	}
+	job.Progress = progress[job.ID]
+	return job, nil
+}
+
+func (u *ImportJobUsecase) GetItemList(ctx context.Context, req *domain.ImportJobItemListReq) (*domain.PaginatedResult[[]*domain.ImportJobItem], error) {
+	if _, err := u.repo.GetByID(ctx, req.KBID, req.ID); err != nil {
+		return nil, err
+	}
+	items, total, err := u.repo.GetItemList(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewPaginatedResult(items, uint64(total)), nil
+}
+
+// Retry exports the failed items of a finished job again, only the given items when req.ItemIDs is set
+func (u *ImportJobUsecase) Retry(ctx context.Context, req *domain.RetryImportJobReq) error {
+	if _, err := u.repo.GetByID(ctx, req.KBID, req.ID); err != nil {
+		return err
+	}
+	retried, err := u.repo.Retry(ctx, req.KBID, req.ID, req.ItemIDs)
+	if err != nil {
+		return err
+	}
+	if !retried {
+		return fmt.Errorf("import job is not finished")
+	}
+	if err := u.jobRepo.AsyncRunImportJob(ctx, &domain.ImportTaskRequest{JobID: req.ID}); err != nil {
+		return fmt.Errorf("publish import task failed: %w", err)
+	}
+	return nil
+}
+
+// ResumeStale queues the jobs that were interrupted, e.g. by a restart of the consumer, or whose task was lost
+func (u *ImportJobUsecase) ResumeStale(ctx context.Context) error {
+	ids, err := u.repo.ResumeStale(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		u.logger.Info("resume import job", log.String("job_id", id))
+		if err := u.jobRepo.AsyncRunImportJob(ctx, &domain.ImportTaskRequest{JobID: id}); err != nil {
+			u.logger.Error("publish import task failed", log.String("job_id", id), log.Error(err))
+		}
+	}
+	return nil
+}
+
+// Run executes a pending job, jobs already picked up are skipped so that redelivered tasks are harmless.
+// a job stopped by the shutdown of the consumer is left running and is resumed by ResumeStale
+func (u *ImportJobUsecase) Run(ctx context.Context, jobID string) error {
+	job, err := u.repo.GetByID(ctx, "", jobID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if job.Status != domain.ImportJobStatusPending {
+		u.logger.Info("import job already picked up", log.String("job_id", jobID), log.String("status", string(job.Status)))
+		return nil
+	}
+	started, err := u.repo.Start(ctx, job.ID, time.Now())
+	if err != nil || !started {
+		return err
+	}
+
+	job.Status = domain.ImportJobStatusCompleted
+	if err := u.execute(ctx, job); err != nil {
+		if ctx.Err() != nil {
+			return err
+		}
+		job.Status = domain.ImportJobStatusFailed
+		job.Error = err.Error()
+	}
+	finishedAt := time.Now()
+	job.FinishedAt = &finishedAt
+	return u.repo.Finish(ctx, job)
+}
+
+// execute imports the unfinished items with at most job.Concurrency exports at a time. items that were exporting
+// when the job was interrupted wait for their export task again instead of being exported twice.
+// failed items are recorded on the item and do not fail the job
+func (u *ImportJobUsecase) execute(ctx context.Context, job *domain.ImportJob) error {
+	items, err := u.repo.GetItems(ctx, job.ID, domain.ImportJobItemStatusListed, domain.ImportJobItemStatusExporting)
+	if err != nil {
+		return err
+	}
+
+	// folders are created one by one, so that documents of the same folder do not create it twice
+	folders := make(map[string]string)
+	parentIDs := make(map[string]string, len(items))
+	pending := make([]*domain.ImportJobItem, 0, len(items))
+	for _, item := range items {
+		parentID, err := u.nodeUsecase.ensureFolders(ctx, job.KBID, job.ParentID, item.Folders, job.MaxNode, folders, job.CreatorID)
+		if err != nil {
+			if err := u.failItem(ctx, job, item, err); err != nil {
+				return err
+			}
+			continue
+		}
+		parentIDs[item.ID] = parentID
+		pending = append(pending, item)
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(max(job.Concurrency, 1))
+	for _, item := range pending {
+		g.Go(func() error {
+			nodeID, err := u.importItem(gctx, job, item, parentIDs[item.ID])
+			if err != nil {
+				if gctx.Err() != nil {
+					return gctx.Err()
+				}
+				return u.failItem(gctx, job, item, err)
+			}
+			item.Status = domain.ImportJobItemStatusImported
+			item.NodeID = nodeID
+			item.Error = ""
+			item.UpdatedAt = time.Now()
+			return u.repo.UpdateItem(gctx, item)
+		})
+	}
+	return g.Wait()
+}
+
+func (u *ImportJobUsecase) failItem(ctx context.Context, job *domain.ImportJob, item *domain.ImportJobItem, err error) error {
+	u.logger.Warn("import document failed", log.String("job_id", job.ID), log.String("doc_id", item.DocID), log.Error(err))
+	item.Status = domain.ImportJobItemStatusFailed
+	item.Error = err.Error()
+	item.UpdatedAt = time.Now()
+	return u.repo.UpdateItem(ctx, item)
+}
+
+// importItem exports the document unless its export was started before, waits for the markdown and writes the node.
+// a resumed export whose task the crawler has forgotten is started again
+func (u *ImportJobUsecase) importItem(ctx context.Context, job *domain.ImportJob, item *domain.ImportJobItem, parentID string) (string, error) {
+	resumed := item.Status == domain.ImportJobItemStatusExporting && item.TaskID != ""
+	if !resumed {
+		taskID, err := u.crawlerUsecase.StartExport(ctx, job.KBID, job.ParseID, item.DocID, item.SpaceID, item.FileType)
+		if err != nil {
+			return "", fmt.Errorf("export failed: %w", err)
+		}
+		item.Status = domain.ImportJobItemStatusExporting
+		item.TaskID = taskID
+		item.Attempts++
+		item.UpdatedAt = time.Now()
+		if err := u.repo.UpdateItem(ctx, item); err != nil {
+			return "", err
+		}
+	}
+	markdown, err := u.crawlerUsecase.WaitExport(ctx, item.TaskID)
+	if resumed && errors.Is(err, errExportTaskNotFound) {
+		u.logger.Warn("export task is gone, exporting again", log.String("job_id", job.ID), log.String("task_id", item.TaskID))
+		item.Status = domain.ImportJobItemStatusListed
+		item.TaskID = ""
+		return u.importItem(ctx, job, item, parentID)
+	}
+	if err != nil {
+		return "", err
+	}
+	return u.writeNode(ctx, job, item, parentID, markdown)
+}
+
+// writeNode creates the document of the item, or updates it when the item was imported before the job was interrupted
+func (u *ImportJobUsecase) writeNode(ctx context.Context, job *domain.ImportJob, item *domain.ImportJobItem, parentID, markdown string) (string, error) {
+	name := item.Title
+	if name == "" {
+		name = item.DocID
+	}
+	contentType := domain.ContentTypeMD
+	externalID := item.ExternalID()
+	existing, err := u.nodeRepo.GetNodesByExternalIDs(ctx, job.KBID, []string{externalID})
+	if err != nil {
+		return "", err
+	}
+	if node, ok := existing[externalID]; ok {
+		if _, err := u.nodeUsecase.updateExternalNode(ctx, node, parentID, &domain.UpsertNodeItem{
+			ExternalID:  externalID,
+			Name:        name,
+			Content:     markdown,
+			ContentType: &contentType,
+		}, job.CreatorID); err != nil {
+			return "", err
+		}
+		return node.ID, nil
+	}
+	return u.nodeRepo.Create(ctx, &domain.CreateNodeReq{
+		KBID:        job.KBID,
+		ParentID:    parentID,
+		Type:        domain.NodeTypeDocument,
+		Name:        name,
+		Content:     markdown,
+		ContentType: &contentType,
+		MaxNode:     job.MaxNode,
+		ExternalID:  externalID,
+	}, job.CreatorID)
+}
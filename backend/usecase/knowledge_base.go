@@ -19,26 +19,28 @@ import (
 )
 
 type KnowledgeBaseUsecase struct {
-	repo     *pg.KnowledgeBaseRepository
-	nodeRepo *pg.NodeRepository
-	ragRepo  *mq.RAGRepository
-	userRepo *pg.UserRepository
-	rag      rag.RAGService
-	kbCache  *cache.KBRepo
-	logger   *log.Logger
-	config   *config.Config
+	repo        *pg.KnowledgeBaseRepository
+	nodeRepo    *pg.NodeRepository
+	ragRepo     *mq.RAGRepository
+	userRepo    *pg.UserRepository
+	settingRepo *pg.SettingRepository
+	rag         rag.RAGService
+	kbCache     *cache.KBRepo
+	logger      *log.Logger
+	config      *config.Config
 }
 
-func NewKnowledgeBaseUsecase(repo *pg.KnowledgeBaseRepository, nodeRepo *pg.NodeRepository, ragRepo *mq.RAGRepository, userRepo *pg.UserRepository, rag rag.RAGService, kbCache *cache.KBRepo, logger *log.Logger, config *config.Config) (*KnowledgeBaseUsecase, error) {
+func NewKnowledgeBaseUsecase(repo *pg.KnowledgeBaseRepository, nodeRepo *pg.NodeRepository, ragRepo *mq.RAGRepository, userRepo *pg.UserRepository, settingRepo *pg.SettingRepository, rag rag.RAGService, kbCache *cache.KBRepo, logger *log.Logger, config *config.Config) (*KnowledgeBaseUsecase, error) {
 	u := &KnowledgeBaseUsecase{
-		repo:     repo,
-		nodeRepo: nodeRepo,
-		ragRepo:  ragRepo,
-		userRepo: userRepo,
-		rag:      rag,
-		logger:   logger.WithModule("usecase.knowledge_base"),
-		config:   config,
-		kbCache:  kbCache,
+		repo:        repo,
+		nodeRepo:    nodeRepo,
+		ragRepo:     ragRepo,
+		userRepo:    userRepo,
+		settingRepo: settingRepo,
+		rag:         rag,
+		logger:      logger.WithModule("usecase.knowledge_base"),
+		config:      config,
+		kbCache:     kbCache,
 	}
 	return u, nil
 }
@@ -283,3 +285,26 @@ func (u *KnowledgeBaseUsecase) KBUserDelete(ctx context.Context, req v1.KBUserDe
 
 	return nil
 }
+
+func (u *KnowledgeBaseUsecase) GetChunkSetting(ctx context.Context, kbID string) (*domain.ChunkSetting, error) {
+	return u.settingRepo.GetChunkSetting(ctx, kbID)
+}
+
+// UpdateChunkSetting updates the chunk strategy of the kb, it applies to documents indexed afterwards
+func (u *KnowledgeBaseUsecase) UpdateChunkSetting(ctx context.Context, req *domain.UpdateChunkSettingReq) error {
+	if err := req.ChunkSetting.Validate(); err != nil {
+		return err
+	}
+	return u.settingRepo.UpdateChunkSetting(ctx, req.KBID, &req.ChunkSetting)
+}
+
+func (u *KnowledgeBaseUsecase) GetLanguageSetting(ctx context.Context, kbID string) (*domain.LanguageSetting, error) {
+	return u.settingRepo.GetLanguageSetting(ctx, kbID)
+}
+
+func (u *KnowledgeBaseUsecase) UpdateLanguageSetting(ctx context.Context, req *domain.UpdateLanguageSettingReq) error {
+	if err := req.LanguageSetting.Validate(); err != nil {
+		return err
+	}
+	return u.settingRepo.UpdateLanguageSetting(ctx, req.KBID, &req.LanguageSetting)
+}
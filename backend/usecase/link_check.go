@@ -0,0 +1,327 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/minio/minio-go/v7"
+	"github.com/samber/lo"
+	"golang.org/x/sync/errgroup"
+	"gorm.io/gorm"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/repo/mq"
+	"github.com/chaitin/panda-wiki/repo/pg"
+	"github.com/chaitin/panda-wiki/store/s3"
+	"github.com/chaitin/panda-wiki/utils"
+)
+
+const linkCheckUserAgent = "PandaWiki-LinkChecker/1.0"
+
+// LinkCheckUsecase checks the node links, uploaded files and external urls of the released documents of a kb
+type LinkCheckUsecase struct {
+	repo     *pg.LinkCheckRepository
+	nodeRepo *pg.NodeRepository
+	s3Client *s3.MinioClient
+	jobRepo  *mq.JobRepository
+	logger   *log.Logger
+}
+
+func NewLinkCheckUsecase(repo *pg.LinkCheckRepository, nodeRepo *pg.NodeRepository, s3Client *s3.MinioClient,
+	jobRepo *mq.JobRepository, logger *log.Logger) *LinkCheckUsecase {
+	return &LinkCheckUsecase{
+		repo:     repo,
+		nodeRepo: nodeRepo,
+		s3Client: s3Client,
+		jobRepo:  jobRepo,
+		logger:   logger.WithModule("usecase.link_check"),
+	}
+}
+
+// Create queues a check of the latest release of the kb, only one job of a kb runs at a time
+func (u *LinkCheckUsecase) Create(ctx context.Context, req *domain.CreateLinkCheckJobReq) (*domain.LinkCheckJob, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return u.create(ctx, req.KBID, req.Concurrency, req.TimeoutSeconds, false)
+}
+
+func (u *LinkCheckUsecase) create(ctx context.Context, kbID string, concurrency, timeoutSeconds int, scheduled bool) (*domain.LinkCheckJob, error) {
+	active, err := u.repo.GetActive(ctx, kbID)
+	if err != nil {
+		return nil, err
+	}
+	if active != nil {
+		return nil, fmt.Errorf("link check job %s of the kb is still %s", active.ID, active.Status)
+	}
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+	job := &domain.LinkCheckJob{
+		ID:             id.String(),
+		KBID:           kbID,
+		Concurrency:    concurrency,
+		TimeoutSeconds: timeoutSeconds,
+		Scheduled:      scheduled,
+		Status:         domain.LinkCheckJobStatusPending,
+		CreatedAt:      time.Now(),
+	}
+	if err := u.repo.Create(ctx, job); err != nil {
+		return nil, err
+	}
+	if err := u.jobRepo.AsyncRunLinkCheckJob(ctx, &domain.LinkCheckTaskRequest{JobID: job.ID}); err != nil {
+		return nil, fmt.Errorf("publish link check task failed: %w", err)
+	}
+	return job, nil
+}
+
+func (u *LinkCheckUsecase) GetList(ctx context.Context, req *domain.LinkCheckJobListReq) (*domain.PaginatedResult[[]*domain.LinkCheckJob], error) {
+	jobs, total, err := u.repo.GetList(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewPaginatedResult(jobs, uint64(total)), nil
+}
+
+func (u *LinkCheckUsecase) GetDetail(ctx context.Context, req *domain.LinkCheckJobDetailReq) (*domain.LinkCheckJob, error) {
+	return u.repo.GetByID(ctx, req.KBID, req.ID)
+}
+
+// GetBroken returns the broken links of a job grouped by document, the latest finished job by default
+func (u *LinkCheckUsecase) GetBroken(ctx context.Context, req *domain.LinkCheckBrokenReq) (*domain.LinkCheckBrokenResp, error) {
+	var (
+		job *domain.LinkCheckJob
+		err error
+	)
+	if req.JobID != "" {
+		job, err = u.repo.GetByID(ctx, req.KBID, req.JobID)
+	} else {
+		job, err = u.repo.GetLatestFinished(ctx, req.KBID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	resp := &domain.LinkCheckBrokenResp{Job: job, Nodes: make([]*domain.LinkCheckNodeReport, 0)}
+	if job == nil {
+		return resp, nil
+	}
+	results, err := u.repo.GetBrokenResults(ctx, job.ID, req.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	resp.Nodes = domain.NewLinkCheckNodeReports(results)
+	return resp, nil
+}
+
+// GetSchedule returns the schedule of the kb, a disabled default schedule when it was never set up
+func (u *LinkCheckUsecase) GetSchedule(ctx context.Context, kbID string) (*domain.LinkCheckSchedule, error) {
+	schedule, err := u.repo.GetSchedule(ctx, kbID)
+	if err != nil {
+		return nil, err
+	}
+	if schedule == nil {
+		schedule = &domain.LinkCheckSchedule{
+			KBID:           kbID,
+			IntervalHours:  domain.DefaultLinkCheckIntervalHours,
+			Concurrency:    domain.DefaultLinkCheckConcurrency,
+			TimeoutSeconds: domain.DefaultLinkCheckTimeoutSeconds,
+		}
+	}
+	return schedule, nil
+}
+
+// UpdateSchedule saves the schedule of the kb, the first scheduled check runs one interval after it is enabled
+func (u *LinkCheckUsecase) UpdateSchedule(ctx context.Context, req *domain.UpdateLinkCheckScheduleReq) (*domain.LinkCheckSchedule, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	schedule, err := u.GetSchedule(ctx, req.KBID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if req.Enabled && (!schedule.Enabled || req.IntervalHours != schedule.IntervalHours) {
+		schedule.IntervalHours = req.IntervalHours
+		schedule.ScheduleNext(now)
+	}
+	schedule.Enabled = req.Enabled
+	schedule.IntervalHours = req.IntervalHours
+	schedule.Concurrency = req.Concurrency
+	schedule.TimeoutSeconds = req.TimeoutSeconds
+	schedule.UpdatedAt = now
+	if err := u.repo.SaveSchedule(ctx, schedule); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+// QueueDue fails the jobs that were lost and queues the checks of the schedules that are due,
+// it is run by the cron of the consumer
+func (u *LinkCheckUsecase) QueueDue(ctx context.Context) error {
+	now := time.Now()
+	if err := u.repo.FailStale(ctx, now); err != nil {
+		return err
+	}
+	schedules, err := u.repo.QueueDue(ctx, now)
+	if err != nil {
+		return err
+	}
+	for _, schedule := range schedules {
+		if _, err := u.create(ctx, schedule.KBID, schedule.Concurrency, schedule.TimeoutSeconds, true); err != nil {
+			u.logger.Warn("queue scheduled link check failed", log.String("kb_id", schedule.KBID), log.Error(err))
+		}
+	}
+	return nil
+}
+
+// Run executes a pending job, jobs already picked up are skipped so that redelivered tasks are harmless
+func (u *LinkCheckUsecase) Run(ctx context.Context, jobID string) error {
+	job, err := u.repo.GetByID(ctx, "", jobID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if job.Status != domain.LinkCheckJobStatusPending {
+		u.logger.Info("link check job already picked up", log.String("job_id", jobID), log.String("status", string(job.Status)))
+		return nil
+	}
+	started, err := u.repo.Start(ctx, job.ID, time.Now())
+	if err != nil || !started {
+		return err
+	}
+
+	job.Status = domain.LinkCheckJobStatusCompleted
+	results, err := u.execute(ctx, job)
+	if err != nil {
+		job.Status = domain.LinkCheckJobStatusFailed
+		job.Error = err.Error()
+		results = nil
+	}
+	job.Total = len(results)
+	job.Broken = lo.CountBy(results, func(result *domain.LinkCheckResult) bool { return result.Broken })
+	finishedAt := time.Now()
+	job.FinishedAt = &finishedAt
+	return u.repo.Finish(ctx, job, results)
+}
+
+// execute checks every distinct link once with at most job.Concurrency checks at a time
+// and returns a result for each link of each released document
+func (u *LinkCheckUsecase) execute(ctx context.Context, job *domain.LinkCheckJob) ([]*domain.LinkCheckResult, error) {
+	releaseID, nodes, err := u.nodeRepo.GetLatestKBReleaseNodes(ctx, job.KBID)
+	if err != nil {
+		return nil, err
+	}
+	if releaseID == "" {
+		return nil, fmt.Errorf("the kb has not been released yet")
+	}
+	job.ReleaseID = releaseID
+
+	released := make(map[string]bool, len(nodes))
+	for _, node := range nodes {
+		released[node.NodeID] = true
+	}
+	refs := make(map[string][]domain.LinkCheckRef)
+	checks := make(map[domain.LinkCheckRef]*domain.LinkCheckResult)
+	for _, node := range nodes {
+		if node.Type != domain.NodeTypeDocument {
+			continue
+		}
+		refs[node.NodeID] = domain.ExtractLinkCheckRefs(node.Content)
+		for _, ref := range refs[node.NodeID] {
+			if _, ok := checks[ref]; !ok {
+				checks[ref] = &domain.LinkCheckResult{Kind: ref.Kind, URL: ref.URL}
+			}
+		}
+	}
+
+	// links are taken from the documents, they must not reach the internal network of the server
+	client := utils.NewPublicHTTPClient(time.Duration(job.TimeoutSeconds) * time.Second)
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(max(job.Concurrency, 1))
+	for ref, check := range checks {
+		g.Go(func() error {
+			switch ref.Kind {
+			case domain.LinkCheckKindNode:
+				check.Broken = !released[ref.URL]
+				if check.Broken {
+					check.Error = "document is not released"
+				}
+			case domain.LinkCheckKindAsset:
+				u.checkAsset(gctx, ref.URL, check)
+			case domain.LinkCheckKindExternal:
+				u.checkURL(gctx, client, ref.URL, check)
+			}
+			check.CheckedAt = time.Now()
+			return gctx.Err()
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	results := make([]*domain.LinkCheckResult, 0, len(checks))
+	for _, node := range nodes {
+		for _, ref := range refs[node.NodeID] {
+			result := *checks[ref]
+			result.ID = uuid.New().String()
+			result.JobID = job.ID
+			result.NodeID = node.NodeID
+			result.NodeName = node.Name
+			results = append(results, &result)
+		}
+	}
+	return results, nil
+}
+
+func (u *LinkCheckUsecase) checkAsset(ctx context.Context, key string, check *domain.LinkCheckResult) {
+	if _, err := u.s3Client.StatObject(ctx, domain.Bucket, key, minio.StatObjectOptions{}); err != nil {
+		response := minio.ToErrorResponse(err)
+		check.Broken = response.Code == "NoSuchKey" || response.StatusCode == http.StatusNotFound
+		check.StatusCode = response.StatusCode
+		check.Error = err.Error()
+	}
+}
+
+// checkURL requests the url with HEAD, falling back to GET for servers that do not answer HEAD properly.
+// urls of private addresses are not checked and not reported as broken, the client refuses to connect to them
+func (u *LinkCheckUsecase) checkURL(ctx context.Context, client *http.Client, url string, check *domain.LinkCheckResult) {
+	code, err := u.request(ctx, client, http.MethodHead, url)
+	if err == nil && code >= http.StatusBadRequest {
+		code, err = u.request(ctx, client, http.MethodGet, url)
+	}
+	check.StatusCode = code
+	if err != nil {
+		check.Broken = !errors.Is(err, utils.ErrNonPublicAddress)
+		check.Error = err.Error()
+		return
+	}
+	check.Broken = domain.LinkCheckBrokenStatus(code)
+	if check.Broken {
+		check.Error = http.StatusText(code)
+	}
+}
+
+func (u *LinkCheckUsecase) request(ctx context.Context, client *http.Client, method, url string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("User-Agent", linkCheckUserAgent)
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// only the status is needed, a small part of the body is drained so that the connection can be reused
+	_, _ = io.CopyN(io.Discard, resp.Body, 4096)
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/utils"
+)
+
+func TestCheckURLSkipsPrivateAddresses(t *testing.T) {
+	requested := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = true
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	u := &LinkCheckUsecase{}
+	client := utils.NewPublicHTTPClient(5 * time.Second)
+	for _, url := range []string{server.URL + "/missing", "http://169.254.169.254/latest/meta-data/"} {
+		check := &domain.LinkCheckResult{URL: url}
+		u.checkURL(context.Background(), client, url, check)
+		assert.False(t, check.Broken, url)
+		assert.Contains(t, check.Error, utils.ErrNonPublicAddress.Error(), url)
+	}
+	assert.False(t, requested)
+}
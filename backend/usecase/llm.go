@@ -1,9 +1,13 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
+	"regexp"
 	"slices"
 	"strings"
 	"time"
@@ -31,17 +35,30 @@ type LLMUsecase struct {
 	nodeRepo         *pg.NodeRepository
 	modelRepo        *pg.ModelRepository
 	promptRepo       *pg.PromptRepo
+	settingRepo      *pg.SettingRepository
 	config           *config.Config
 	logger           *log.Logger
 	modelkit         *modelkit.ModelKit
+	httpClient       *http.Client
 }
 
 const (
 	summaryChunkTokenLimit = 30720 // 30KB tokens per chunk
 	summaryMaxChunks       = 4     // max chunks to process for summary
+
+	defaultContextWindow        = 32768 // used when the model has no context_window configured
+	defaultReservedOutputTokens = 4096  // used when the model has no max_tokens configured
+	historyTokenRatio           = 0.4   // max share of the prompt budget used by history
+	messageTokenOverhead        = 4     // role and separator tokens per message
+	// the summary is generated before the answer starts, a slow model falls back to dropping the turns
+	conversationSummaryTimeout = 20 * time.Second
+
+	followUpSuggestionMaxCount = 5
+
+	embeddingRequestTimeout = 60 * time.Second
 )
 
-func NewLLMUsecase(config *config.Config, rag rag.RAGService, conversationRepo *pg.ConversationRepository, kbRepo *pg.KnowledgeBaseRepository, nodeRepo *pg.NodeRepository, modelRepo *pg.ModelRepository, promptRepo *pg.PromptRepo, logger *log.Logger) *LLMUsecase {
+func NewLLMUsecase(config *config.Config, rag rag.RAGService, conversationRepo *pg.ConversationRepository, kbRepo *pg.KnowledgeBaseRepository, nodeRepo *pg.NodeRepository, modelRepo *pg.ModelRepository, promptRepo *pg.PromptRepo, settingRepo *pg.SettingRepository, logger *log.Logger) *LLMUsecase {
 	tiktoken.SetBpeLoader(&utils.Localloader{})
 	modelkit := modelkit.NewModelKit(logger.Logger)
 	return &LLMUsecase{
@@ -52,81 +69,314 @@ func NewLLMUsecase(config *config.Config, rag rag.RAGService, conversationRepo *
 		nodeRepo:         nodeRepo,
 		modelRepo:        modelRepo,
 		promptRepo:       promptRepo,
+		settingRepo:      settingRepo,
 		logger:           logger.WithModule("usecase.llm"),
 		modelkit:         modelkit,
+		httpClient:       &http.Client{Timeout: embeddingRequestTimeout},
 	}
 }
 
+type historyMessage struct {
+	id      string
+	message *schema.Message
+}
+
 func (u *LLMUsecase) FormatConversationMessages(
 	ctx context.Context,
-	conversationID string,
-	kbID string,
+	req *domain.ChatRequest,
 	groupIDs []int,
-	systemPrompt string,
 ) ([]*schema.Message, []*domain.RankedNodeChunks, error) {
 	messages := make([]*schema.Message, 0)
 	rankedNodes := make([]*domain.RankedNodeChunks, 0)
 
-	msgs, err := u.conversationRepo.GetConversationMessagesByID(ctx, conversationID)
-	if err != nil {
-		return nil, nil, fmt.Errorf("get conversation messages failed: %w", err)
-	}
-	if len(msgs) > 0 {
-		historyMessages := make([]*schema.Message, 0)
+	// client history (OpenAI API) replaces the stored one and is never summarized
+	clientHistory := req.History != nil
+	conversation := &domain.Conversation{}
+	history := make([]*historyMessage, 0)
+	if clientHistory {
+		for _, msg := range req.History {
+			history = append(history, &historyMessage{message: msg})
+		}
+		question := req.Message
+		if req.ImageDescription != "" {
+			question += fmt.Sprintf(domain.ImageDescriptionFormatter, req.ImageDescription)
+		}
+		history = append(history, &historyMessage{id: req.UserMessageID, message: schema.UserMessage(question)})
+	} else {
+		var err error
+		conversation, err = u.conversationRepo.GetConversationByID(ctx, req.ConversationID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("get conversation failed: %w", err)
+		}
+		msgs, err := u.conversationRepo.GetConversationMessagesByID(ctx, req.ConversationID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("get conversation messages failed: %w", err)
+		}
+		if req.UserMessageID != "" {
+			// only the selected branch is part of the history
+			msgs = domain.ConversationBranch(msgs, req.UserMessageID)
+		}
 		for _, msg := range msgs {
 			switch msg.Role {
 			case schema.Assistant:
-				historyMessages = append(historyMessages, schema.AssistantMessage(msg.Content, nil))
+				history = append(history, &historyMessage{id: msg.ID, message: schema.AssistantMessage(msg.Content, nil)})
 			case schema.User:
-				historyMessages = append(historyMessages, schema.UserMessage(msg.Content))
+				content := msg.Content
+				if msg.ImageDescription != "" {
+					// image content joins the question, for retrieval and for models without image input
+					content += fmt.Sprintf(domain.ImageDescriptionFormatter, msg.ImageDescription)
+				}
+				history = append(history, &historyMessage{id: msg.ID, message: schema.UserMessage(content)})
 			default:
 				continue
 			}
 		}
-		if len(historyMessages) > 0 {
-			question := historyMessages[len(historyMessages)-1].Content
-
-			if systemPrompt == "" {
-				if settingPrompt, err := u.promptRepo.GetPrompt(ctx, kbID); err != nil {
-					u.logger.Error("get prompt from settings failed", log.Error(err))
-				} else {
-					if settingPrompt != "" {
-						systemPrompt = settingPrompt
-					} else {
-						systemPrompt = domain.SystemDefaultPrompt
-					}
-				}
-			}
+	}
+	if len(history) == 0 {
+		return messages, rankedNodes, nil
+	}
+	question := history[len(history)-1].message.Content
+	history = history[:len(history)-1]
 
-			template := prompt.FromMessages(schema.GoTemplate,
-				schema.SystemMessage(systemPrompt),
-				schema.UserMessage(domain.UserQuestionFormatter),
-			)
-			kb, err := u.kbRepo.GetKnowledgeBaseByID(ctx, kbID)
-			if err != nil {
-				return nil, nil, fmt.Errorf("get kb failed: %w", err)
+	systemPrompt := req.Prompt
+	if systemPrompt == "" {
+		if settingPrompt, err := u.promptRepo.GetPrompt(ctx, req.KBID); err != nil {
+			u.logger.Error("get prompt from settings failed", log.Error(err))
+		} else {
+			if settingPrompt != "" {
+				systemPrompt = settingPrompt
+			} else {
+				systemPrompt = domain.SystemDefaultPrompt
 			}
-			rankedNodes, err = u.GetRankNodes(ctx, []string{kb.DatasetID}, question, groupIDs, 0, historyMessages[:len(historyMessages)-1])
-			if err != nil {
-				return nil, nil, fmt.Errorf("get rank nodes failed: %w", err)
+		}
+	}
+
+	encoding, err := tiktoken.GetEncoding("cl100k_base")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get encoding: %w", err)
+	}
+	countTokens := func(text string) int {
+		return len(encoding.Encode(text, nil, nil)) + messageTokenOverhead
+	}
+	// tokens left for history and documents after system prompt, question and reserved output
+	available := contextTokenBudget(req.ModelInfo) - countTokens(systemPrompt) - countTokens(domain.UserQuestionFormatter) - countTokens(question)
+	if req.ClientSystemPrompt != "" {
+		available -= countTokens(req.ClientSystemPrompt)
+	}
+
+	// 1. history: keep the most recent turns, fold older ones into the rolling summary
+	summary := conversation.Summary
+	if conversation.SummaryMessageID != "" {
+		if idx := slices.IndexFunc(history, func(m *historyMessage) bool { return m.id == conversation.SummaryMessageID }); idx >= 0 {
+			history = history[idx+1:]
+		} else {
+			summary = ""
+		}
+	}
+	historyBudget := int(float64(available) * historyTokenRatio)
+	if summary != "" {
+		historyBudget -= countTokens(summary)
+	}
+	kept := len(history)
+	historyTokens := 0
+	for i := len(history) - 1; i >= 0; i-- {
+		tokens := countTokens(history[i].message.Content)
+		if historyTokens+tokens > historyBudget {
+			break
+		}
+		historyTokens += tokens
+		kept = i
+	}
+	// never start the kept history with an orphan answer
+	if kept < len(history) && history[kept].message.Role == schema.Assistant {
+		historyTokens -= countTokens(history[kept].message.Content)
+		kept++
+	}
+	if dropped := history[:kept]; len(dropped) > 0 && clientHistory {
+		history = history[kept:]
+	} else if len(dropped) > 0 {
+		u.logger.Info("trim conversation history", log.String("conversation_id", req.ConversationID), log.Int("dropped", len(dropped)), log.Int("kept", len(history)-kept))
+		summaryCtx, cancel := context.WithTimeout(ctx, conversationSummaryTimeout)
+		newSummary, err := u.summarizeConversation(summaryCtx, req.ModelInfo, summary, dropped)
+		cancel()
+		if err != nil {
+			// the turns are dropped without being summarized, the previous summary is kept
+			u.logger.Error("summarize conversation history failed", log.Error(err))
+		} else {
+			summary = newSummary
+			if err := u.conversationRepo.UpdateConversationSummary(ctx, req.ConversationID, summary, dropped[len(dropped)-1].id); err != nil {
+				u.logger.Error("update conversation summary failed", log.Error(err))
 			}
-			documents := domain.FormatNodeChunks(rankedNodes, kb.AccessSettings.BaseURL)
-			u.logger.Debug("documents", log.String("documents", documents))
+		}
+		history = history[kept:]
+	}
+	historyMessages := lo.Map(history, func(m *historyMessage, _ int) *schema.Message {
+		return m.message
+	})
+	if summary != "" {
+		historyTokens += countTokens(summary)
+	}
+
+	// 2. documents: keep the highest ranked chunks that still fit
+	kb, err := u.kbRepo.GetKnowledgeBaseByID(ctx, req.KBID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get kb failed: %w", err)
+	}
+	// questions in other languages are translated to the kb language for retrieval
+	languageSetting := u.GetLanguageSetting(ctx, req.KBID)
+	questionLanguage := domain.DetectLanguage(question)
+	query := question
+	if languageSetting.TranslateQuery && questionLanguage != "" && questionLanguage != languageSetting.Language && req.ModelInfo != nil {
+		translated, err := u.translateQuery(ctx, req.ModelInfo, question, languageSetting.Language)
+		if err != nil {
+			u.logger.Warn("translate query failed, retrieve with the original question", log.Error(err))
+		} else if translated != "" {
+			u.logger.Debug("translate query", log.String("question", question), log.String("query", translated))
+			query = translated
+		}
+	}
+	rankedNodes, err = u.GetRankNodes(ctx, []string{kb.DatasetID}, query, groupIDs, 0, historyMessages)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get rank nodes failed: %w", err)
+	}
+	documentBudget := available - historyTokens
+	curatedDocument := ""
+	if req.CuratedAnswer != nil {
+		// the curated answer of a similar question goes first and is never trimmed
+		curatedDocument = domain.FormatCuratedAnswer(req.CuratedAnswer)
+		documentBudget -= countTokens(curatedDocument)
+	}
+	rankedNodes = trimRankedNodes(rankedNodes, documentBudget, kb.AccessSettings.BaseURL, countTokens)
+	documents := domain.FormatNodeChunks(rankedNodes, kb.AccessSettings.BaseURL)
+	if curatedDocument != "" {
+		documents = strings.TrimSpace(curatedDocument + "\n" + documents)
+	}
+	u.logger.Debug("documents", log.String("documents", documents))
 
-			formattedMessages, err := template.Format(ctx, map[string]any{
-				"CurrentDate": time.Now().Format("2006-01-02"),
-				"Question":    question,
-				"Documents":   documents,
+	template := prompt.FromMessages(schema.GoTemplate,
+		schema.SystemMessage(systemPrompt),
+		schema.UserMessage(domain.UserQuestionFormatter),
+	)
+	formattedMessages, err := template.Format(ctx, map[string]any{
+		"CurrentDate": time.Now().Format("2006-01-02"),
+		"Question":    question,
+		"Documents":   documents,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("format messages failed: %w", err)
+	}
+	// summary is appended after formatting so that it is never parsed as a template
+	if summary != "" {
+		formattedMessages[0].Content += fmt.Sprintf(domain.ConversationSummaryFormatter, summary)
+	}
+	if languageSetting.MatchAnswerLanguage && questionLanguage != "" {
+		formattedMessages[0].Content += fmt.Sprintf(domain.AnswerLanguageFormatter, domain.LanguageName(questionLanguage))
+	}
+	if req.ClientSystemPrompt != "" {
+		formattedMessages[0].Content += fmt.Sprintf(domain.ClientSystemPromptFormatter, req.ClientSystemPrompt)
+	}
+	// models with image input also get the images of the question
+	if len(req.ImageData) > 0 && req.ModelInfo != nil && req.ModelInfo.Parameters.SupportImages {
+		questionMessage := formattedMessages[len(formattedMessages)-1]
+		questionMessage.MultiContent = []schema.ChatMessagePart{{Type: schema.ChatMessagePartTypeText, Text: questionMessage.Content}}
+		for _, image := range req.ImageData {
+			questionMessage.MultiContent = append(questionMessage.MultiContent, schema.ChatMessagePart{
+				Type:     schema.ChatMessagePartTypeImageURL,
+				ImageURL: &schema.ChatMessageImageURL{URL: image.DataURL()},
 			})
-			if err != nil {
-				return nil, nil, fmt.Errorf("format messages failed: %w", err)
-			}
-			messages = slices.Insert(formattedMessages, 1, historyMessages[:len(historyMessages)-1]...)
 		}
 	}
+	messages = slices.Insert(formattedMessages, 1, historyMessages...)
 	return messages, rankedNodes, nil
 }
 
+// contextTokenBudget returns the prompt tokens available for the model, leaving room for the answer
+func contextTokenBudget(model *domain.Model) int {
+	contextWindow := defaultContextWindow
+	reserved := defaultReservedOutputTokens
+	if model != nil {
+		if model.Parameters.ContextWindow > 0 {
+			contextWindow = model.Parameters.ContextWindow
+		}
+		if model.Parameters.MaxTokens > 0 {
+			reserved = model.Parameters.MaxTokens
+		}
+	}
+	if reserved >= contextWindow {
+		reserved = contextWindow / 4
+	}
+	return contextWindow - reserved
+}
+
+// trimRankedNodes drops the lowest ranked chunks until the documents fit into budget tokens
+func trimRankedNodes(nodes []*domain.RankedNodeChunks, budget int, baseURL string, countTokens func(string) int) []*domain.RankedNodeChunks {
+	result := make([]*domain.RankedNodeChunks, 0, len(nodes))
+	used := 0
+	for _, node := range nodes {
+		trimmed := &domain.RankedNodeChunks{
+			NodeID:        node.NodeID,
+			NodeName:      node.NodeName,
+			NodeSummary:   node.NodeSummary,
+			NodeEmoji:     node.NodeEmoji,
+			NodePathNames: node.NodePathNames,
+		}
+		nodeTokens := countTokens(domain.FormatNodeChunks([]*domain.RankedNodeChunks{trimmed}, baseURL))
+		for _, chunk := range node.Chunks {
+			tokens := countTokens(chunk.Content)
+			if used+nodeTokens+tokens > budget {
+				continue
+			}
+			nodeTokens += tokens
+			trimmed.Chunks = append(trimmed.Chunks, chunk)
+		}
+		if len(trimmed.Chunks) == 0 {
+			continue
+		}
+		used += nodeTokens
+		result = append(result, trimmed)
+	}
+	return result
+}
+
+// summarizeConversation merges dropped turns into the existing rolling summary
+func (u *LLMUsecase) summarizeConversation(ctx context.Context, model *domain.Model, summary string, dropped []*historyMessage) (string, error) {
+	if model == nil {
+		return "", fmt.Errorf("chat model is required")
+	}
+	modelkitModel, err := model.ToModelkitModel()
+	if err != nil {
+		return "", err
+	}
+	chatModel, err := u.modelkit.GetChatModel(ctx, modelkitModel)
+	if err != nil {
+		return "", err
+	}
+	transcript := strings.Builder{}
+	for _, m := range dropped {
+		role := "用户"
+		if m.message.Role == schema.Assistant {
+			role = "助手"
+		}
+		transcript.WriteString(fmt.Sprintf("%s：%s\n", role, u.trimThinking(m.message.Content)))
+	}
+	chunkLimit := min(summaryChunkTokenLimit, contextTokenBudget(model)/2)
+	chunks, err := u.SplitByTokenLimit(transcript.String(), chunkLimit)
+	if err != nil {
+		return "", err
+	}
+	for _, chunk := range chunks {
+		result, err := u.Generate(ctx, chatModel, []*schema.Message{
+			schema.SystemMessage(domain.ConversationSummaryPrompt),
+			schema.UserMessage(fmt.Sprintf("已有摘要：\n%s\n\n新增对话：\n%s", summary, chunk)),
+		})
+		if err != nil {
+			return "", err
+		}
+		summary = strings.TrimSpace(u.trimThinking(result))
+	}
+	return summary, nil
+}
+
 func (u *LLMUsecase) ChatWithAgent(
 	ctx context.Context,
 	chatModel model.BaseChatModel,
@@ -193,7 +443,17 @@ func (u *LLMUsecase) Generate(
 	return resp.Content, nil
 }
 
-func (u *LLMUsecase) SummaryNode(ctx context.Context, model *domain.Model, name, content string) (string, error) {
+// GetLanguageSetting returns the language setting of the kb, falling back to the default one
+func (u *LLMUsecase) GetLanguageSetting(ctx context.Context, kbID string) *domain.LanguageSetting {
+	languageSetting, err := u.settingRepo.GetLanguageSetting(ctx, kbID)
+	if err != nil {
+		u.logger.Error("get language setting failed", log.String("kb_id", kbID), log.Error(err))
+		return domain.DefaultLanguageSetting()
+	}
+	return languageSetting
+}
+
+func (u *LLMUsecase) translateQuery(ctx context.Context, model *domain.Model, question, language string) (string, error) {
 	modelkitModel, err := model.ToModelkitModel()
 	if err != nil {
 		return "", err
@@ -202,7 +462,27 @@ func (u *LLMUsecase) SummaryNode(ctx context.Context, model *domain.Model, name,
 	if err != nil {
 		return "", err
 	}
+	result, err := u.Generate(ctx, chatModel, []*schema.Message{
+		schema.SystemMessage(fmt.Sprintf(domain.QueryTranslationPrompt, domain.LanguageName(language))),
+		schema.UserMessage(question),
+	})
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(u.trimThinking(result)), nil
+}
 
+func (u *LLMUsecase) SummaryNode(ctx context.Context, model *domain.Model, kbID, name, content string) (string, error) {
+	modelkitModel, err := model.ToModelkitModel()
+	if err != nil {
+		return "", err
+	}
+	chatModel, err := u.modelkit.GetChatModel(ctx, modelkitModel)
+	if err != nil {
+		return "", err
+	}
+
+	language := u.GetLanguageSetting(ctx, kbID).Language
 	chunks, err := u.SplitByTokenLimit(content, summaryChunkTokenLimit)
 	if err != nil {
 		return "", err
@@ -214,7 +494,7 @@ func (u *LLMUsecase) SummaryNode(ctx context.Context, model *domain.Model, name,
 
 	summaries := make([]string, 0, len(chunks))
 	for idx, chunk := range chunks {
-		summary, err := u.requestSummary(ctx, chatModel, name, chunk)
+		summary, err := u.requestSummary(ctx, chatModel, language, name, chunk)
 		if err != nil {
 			u.logger.Error("Failed to generate summary for chunk", log.Int("chunk_index", idx), log.Error(err))
 			continue
@@ -232,7 +512,7 @@ func (u *LLMUsecase) SummaryNode(ctx context.Context, model *domain.Model, name,
 
 	// Join all summaries and generate final summary
 	joined := strings.Join(summaries, "\n\n")
-	finalSummary, err := u.requestSummary(ctx, chatModel, name, joined)
+	finalSummary, err := u.requestSummary(ctx, chatModel, language, name, joined)
 	if err != nil {
 		u.logger.Error("Failed to generate final summary, using aggregated summaries", log.Error(err))
 		// Fallback: return the joined summaries directly
@@ -255,11 +535,11 @@ func (u *LLMUsecase) trimThinking(summary string) string {
 	return strings.TrimSpace(summary[endIndex+len("</think>"):])
 }
 
-func (u *LLMUsecase) requestSummary(ctx context.Context, chatModel model.BaseChatModel, name, content string) (string, error) {
+func (u *LLMUsecase) requestSummary(ctx context.Context, chatModel model.BaseChatModel, language, name, content string) (string, error) {
 	summary, err := u.Generate(ctx, chatModel, []*schema.Message{
 		{
 			Role:    "system",
-			Content: "你是文档总结助手，请根据文档内容总结出文档的摘要。摘要是纯文本，应该简洁明了，不要超过160个字。",
+			Content: fmt.Sprintf(domain.NodeSummaryPrompt, domain.LanguageName(language)),
 		},
 		{
 			Role:    "user",
@@ -272,6 +552,145 @@ func (u *LLMUsecase) requestSummary(ctx context.Context, chatModel model.BaseCha
 	return strings.TrimSpace(u.trimThinking(summary)), nil
 }
 
+// GenerateFollowUpSuggestions generates 3-5 follow-up questions from the answer and retrieved documents
+// The RAG service's related questions API is not used: it needs a user login token the backend never holds and only sees the question
+func (u *LLMUsecase) GenerateFollowUpSuggestions(ctx context.Context, model *domain.Model, question, answer string, rankedNodes []*domain.RankedNodeChunks) ([]string, error) {
+	modelkitModel, err := model.ToModelkitModel()
+	if err != nil {
+		return nil, err
+	}
+	chatModel, err := u.modelkit.GetChatModel(ctx, modelkitModel)
+	if err != nil {
+		return nil, err
+	}
+	documents := strings.Builder{}
+	for _, node := range rankedNodes {
+		documents.WriteString(fmt.Sprintf("- %s：%s\n", node.NodeName, node.NodeSummary))
+	}
+	result, err := u.Generate(ctx, chatModel, []*schema.Message{
+		schema.SystemMessage(domain.FollowUpSuggestionPrompt),
+		schema.UserMessage(fmt.Sprintf("用户问题：%s\n\n助手回答：%s\n\n参考文档：\n%s", question, u.trimThinking(answer), documents.String())),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return parseFollowUpSuggestions(u.trimThinking(result), question), nil
+}
+
+// parseFollowUpSuggestions reads a JSON string array from the model output, falling back to one question per line
+func parseFollowUpSuggestions(result, question string) []string {
+	var items []string
+	start, end := strings.Index(result, "["), strings.LastIndex(result, "]")
+	if start < 0 || end <= start || json.Unmarshal([]byte(result[start:end+1]), &items) != nil {
+		// plain text answer, one suggestion per line with optional list markers
+		items = lo.Map(strings.Split(result, "\n"), func(line string, _ int) string {
+			return trimListMarker(line)
+		})
+	}
+	suggestions := make([]string, 0, followUpSuggestionMaxCount)
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+		if item == "" || item == question || slices.Contains(suggestions, item) {
+			continue
+		}
+		suggestions = append(suggestions, item)
+		if len(suggestions) == followUpSuggestionMaxCount {
+			break
+		}
+	}
+	return suggestions
+}
+
+var (
+	listMarkerRegexp    = regexp.MustCompile(`^\s*(?:[-*•]|\d{1,2}[.、)）])\s*`)
+	leadingNumberRegexp = regexp.MustCompile(`^\s*\d+[.、)）]\d`)
+)
+
+// trimListMarker removes a leading bullet or number marker of a line, a number such as "3.0 版本" is kept
+func trimListMarker(line string) string {
+	if leadingNumberRegexp.MatchString(line) {
+		return line
+	}
+	return listMarkerRegexp.ReplaceAllString(line, "")
+}
+
+// Embed calls the OpenAI compatible embeddings API of the model, embeddings are returned in input order
+func (u *LLMUsecase) Embed(ctx context.Context, model *domain.Model, inputs []string) ([][]float32, *domain.OpenAIUsage, error) {
+	if len(inputs) == 0 {
+		return nil, nil, nil
+	}
+	body, err := json.Marshal(domain.OpenAIEmbeddingsRequest{
+		Model:          model.Model,
+		Input:          inputs,
+		EncodingFormat: "float",
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(model.BaseURL, "/")+"/embeddings", bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if model.APIKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+model.APIKey)
+	}
+	resp, err := u.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, nil, fmt.Errorf("request embeddings failed: %w", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("request embeddings failed: status %d, body: %s", resp.StatusCode, string(respBody))
+	}
+	var result domain.OpenAIEmbeddingsResponse
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, nil, fmt.Errorf("decode embeddings response failed: %w", err)
+	}
+	embeddings := make([][]float32, len(inputs))
+	for _, item := range result.Data {
+		if item.Index < 0 || item.Index >= len(inputs) {
+			return nil, nil, fmt.Errorf("invalid embedding index %d", item.Index)
+		}
+		embeddings[item.Index] = item.Embedding
+	}
+	for i, embedding := range embeddings {
+		if len(embedding) == 0 {
+			return nil, nil, fmt.Errorf("missing embedding of input %d", i)
+		}
+	}
+	return embeddings, result.Usage, nil
+}
+
+// EstimateUsage counts the tokens of the prompt and the answer, used when the model reports no usage
+func (u *LLMUsecase) EstimateUsage(messages []*schema.Message, answer string) schema.TokenUsage {
+	usage := schema.TokenUsage{}
+	for _, message := range messages {
+		usage.PromptTokens += u.CountTokens(message.Content) + messageTokenOverhead
+	}
+	usage.CompletionTokens = u.CountTokens(answer)
+	usage.TotalTokens = usage.PromptTokens + usage.CompletionTokens
+	return usage
+}
+
+// CountTokens returns the cl100k_base token count of the texts
+func (u *LLMUsecase) CountTokens(texts ...string) int {
+	encoding, err := tiktoken.GetEncoding("cl100k_base")
+	if err != nil {
+		u.logger.Warn("failed to get encoding", log.Error(err))
+		return 0
+	}
+	tokens := 0
+	for _, text := range texts {
+		tokens += len(encoding.Encode(text, nil, nil))
+	}
+	return tokens
+}
+
 func (u *LLMUsecase) SplitByTokenLimit(text string, maxTokens int) ([]string, error) {
 	if maxTokens <= 0 {
 		return nil, fmt.Errorf("maxTokens must be greater than 0")
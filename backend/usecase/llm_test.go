@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/chaitin/panda-wiki/domain"
+)
+
+func TestContextTokenBudget(t *testing.T) {
+	tests := []struct {
+		name  string
+		model *domain.Model
+		want  int
+	}{
+		{"no model", nil, defaultContextWindow - defaultReservedOutputTokens},
+		{"no parameters", &domain.Model{}, defaultContextWindow - defaultReservedOutputTokens},
+		{"context window and max tokens", &domain.Model{Parameters: domain.ModelParam{ContextWindow: 128000, MaxTokens: 8000}}, 120000},
+		{"max tokens only", &domain.Model{Parameters: domain.ModelParam{MaxTokens: 1000}}, defaultContextWindow - 1000},
+		{"max tokens above the window", &domain.Model{Parameters: domain.ModelParam{ContextWindow: 8000, MaxTokens: 8000}}, 6000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, contextTokenBudget(tt.model))
+		})
+	}
+}
+
+func TestTrimRankedNodes(t *testing.T) {
+	// the document frame of a node costs 10 tokens, a chunk costs its length
+	countTokens := func(text string) int {
+		if strings.HasPrefix(text, "<document>") {
+			return 10
+		}
+		return len(text)
+	}
+	chunk := func(size int) *domain.NodeContentChunk {
+		return &domain.NodeContentChunk{Content: strings.Repeat("x", size)}
+	}
+	nodes := []*domain.RankedNodeChunks{
+		{NodeID: "a", Chunks: []*domain.NodeContentChunk{chunk(30), chunk(50), chunk(10)}},
+		{NodeID: "b", Chunks: []*domain.NodeContentChunk{chunk(20)}},
+		{NodeID: "c", Chunks: []*domain.NodeContentChunk{chunk(5)}},
+	}
+	sizes := func(nodes []*domain.RankedNodeChunks) map[string][]int {
+		result := make(map[string][]int)
+		for _, node := range nodes {
+			for _, chunk := range node.Chunks {
+				result[node.NodeID] = append(result[node.NodeID], len(chunk.Content))
+			}
+		}
+		return result
+	}
+
+	tests := []struct {
+		name   string
+		budget int
+		want   map[string][]int
+	}{
+		{"everything fits", 1000, map[string][]int{"a": {30, 50, 10}, "b": {20}, "c": {5}}},
+		// a: 10+30+50 = 90, the 10 chunk, b and c do not fit any more
+		{"lower ranked chunks are dropped first", 99, map[string][]int{"a": {30, 50}}},
+		// a: 10+30 = 40, 50 does not fit, 10 fits, b: 50+10+20 = 80
+		{"smaller chunks fill the remaining budget", 80, map[string][]int{"a": {30, 10}, "b": {20}}},
+		{"node frames count", 39, map[string][]int{"a": {10}, "c": {5}}},
+		{"nothing fits", 10, map[string][]int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, sizes(trimRankedNodes(nodes, tt.budget, "", countTokens)))
+		})
+	}
+}
+
+func TestParseFollowUpSuggestions(t *testing.T) {
+	tests := []struct {
+		name   string
+		result string
+		want   []string
+	}{
+		{
+			"json items keep leading digits",
+			`推荐问题：["2024 年报怎么看", "3.0 版本有哪些变化", "- 如何部署", "2024 年报怎么看"]`,
+			[]string{"2024 年报怎么看", "3.0 版本有哪些变化", "- 如何部署"},
+		},
+		{
+			"plain text list markers are removed",
+			"1. 如何部署\n2、如何升级\n- 如何备份\n* 3.0 版本有哪些变化\n4) 2024 年报怎么看",
+			[]string{"如何部署", "如何升级", "如何备份", "3.0 版本有哪些变化", "2024 年报怎么看"},
+		},
+		{
+			"plain text numbers are kept",
+			"3.0 版本有哪些变化\n2024 年报怎么看\n当前问题",
+			[]string{"3.0 版本有哪些变化", "2024 年报怎么看"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseFollowUpSuggestions(tt.result, "当前问题"))
+		})
+	}
+}
@@ -154,6 +154,28 @@ func (u *ModelUsecase) GetChatModel(ctx context.Context) (*domain.Model, error)
 	return model, nil
 }
 
+// GetActiveModelByType returns the model of the type in use, including the models of auto mode
+func (u *ModelUsecase) GetActiveModelByType(ctx context.Context, modelType domain.ModelType) (*domain.Model, error) {
+	if modelType == domain.ModelTypeChat {
+		return u.GetChatModel(ctx)
+	}
+	modelModeSetting, err := u.GetModelModeSetting(ctx)
+	if err != nil {
+		u.logger.Error("get model mode setting failed, use manual mode", log.Error(err))
+	}
+	if err == nil && modelModeSetting.Mode == consts.ModelSettingModeAuto && modelModeSetting.AutoModeAPIKey != "" {
+		return &domain.Model{
+			Model:    consts.GetAutoModeDefaultModel(string(modelType)),
+			Type:     modelType,
+			IsActive: true,
+			BaseURL:  consts.AutoModeBaseURL,
+			APIKey:   modelModeSetting.AutoModeAPIKey,
+			Provider: domain.ModelProviderBrandBaiZhiCloud,
+		}, nil
+	}
+	return u.modelRepo.GetModelByType(ctx, modelType)
+}
+
 func (u *ModelUsecase) GetModelByType(ctx context.Context, modelType domain.ModelType) (*domain.Model, error) {
 	return u.modelRepo.GetModelByType(ctx, modelType)
 }
@@ -27,18 +27,19 @@ import (
 )
 
 type NodeUsecase struct {
-	nodeRepo     *pg.NodeRepository
-	appRepo      *pg.AppRepository
-	ragRepo      *mq.RAGRepository
-	kbRepo       *pg.KnowledgeBaseRepository
-	modelRepo    *pg.ModelRepository
-	userRepo     *pg.UserRepository
-	authRepo     *pg.AuthRepo
-	llmUsecase   *LLMUsecase
-	logger       *log.Logger
-	s3Client     *s3.MinioClient
-	rAGService   rag.RAGService
-	modelUsecase *ModelUsecase
+	nodeRepo        *pg.NodeRepository
+	appRepo         *pg.AppRepository
+	ragRepo         *mq.RAGRepository
+	kbRepo          *pg.KnowledgeBaseRepository
+	modelRepo       *pg.ModelRepository
+	userRepo        *pg.UserRepository
+	authRepo        *pg.AuthRepo
+	llmUsecase      *LLMUsecase
+	logger          *log.Logger
+	s3Client        *s3.MinioClient
+	rAGService      rag.RAGService
+	modelUsecase    *ModelUsecase
+	translationRepo *pg.NodeTranslationRepository
 }
 
 func NewNodeUsecase(
@@ -54,20 +55,22 @@ func NewNodeUsecase(
 	modelRepo *pg.ModelRepository,
 	authRepo *pg.AuthRepo,
 	modelUsecase *ModelUsecase,
+	translationRepo *pg.NodeTranslationRepository,
 ) *NodeUsecase {
 	return &NodeUsecase{
-		nodeRepo:     nodeRepo,
-		rAGService:   ragService,
-		appRepo:      appRepo,
-		ragRepo:      ragRepo,
-		kbRepo:       kbRepo,
-		authRepo:     authRepo,
-		userRepo:     userRepo,
-		llmUsecase:   llmUsecase,
-		modelRepo:    modelRepo,
-		logger:       logger.WithModule("usecase.node"),
-		s3Client:     s3Client,
-		modelUsecase: modelUsecase,
+		nodeRepo:        nodeRepo,
+		rAGService:      ragService,
+		appRepo:         appRepo,
+		ragRepo:         ragRepo,
+		kbRepo:          kbRepo,
+		authRepo:        authRepo,
+		userRepo:        userRepo,
+		llmUsecase:      llmUsecase,
+		modelRepo:       modelRepo,
+		logger:          logger.WithModule("usecase.node"),
+		s3Client:        s3Client,
+		modelUsecase:    modelUsecase,
+		translationRepo: translationRepo,
 	}
 }
 
@@ -242,6 +245,12 @@ func (u *NodeUsecase) GetNodeReleaseDetailByKBIDAndID(ctx context.Context, kbID,
 		}
 	}
 
+	translations, err := u.getTranslationLinks(ctx, kbID, nodeId)
+	if err != nil {
+		return nil, err
+	}
+	node.Translations = translations
+
 	if node.Meta.ContentType == domain.ContentTypeMD {
 		return node, nil
 	}
@@ -254,6 +263,57 @@ func (u *NodeUsecase) GetNodeReleaseDetailByKBIDAndID(ctx context.Context, kbID,
 	return node, nil
 }
 
+// getTranslationLinks returns the published language variants of the node including its source,
+// nil is returned if the node has no published variant
+func (u *NodeUsecase) getTranslationLinks(ctx context.Context, kbID, nodeID string) ([]*domain.NodeTranslationLink, error) {
+	sourceNodeID := nodeID
+	variant, err := u.translationRepo.GetByNodeID(ctx, kbID, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	if variant != nil {
+		sourceNodeID = variant.SourceNodeID
+	}
+	translations, err := u.translationRepo.GetListBySourceNodeID(ctx, kbID, sourceNodeID)
+	if err != nil {
+		return nil, err
+	}
+	translations = lo.Filter(translations, func(t *domain.NodeTranslation, _ int) bool {
+		return t.NodeID != ""
+	})
+	if len(translations) == 0 {
+		return nil, nil
+	}
+	names, err := u.nodeRepo.GetReleasedNodeNames(ctx, kbID, append(lo.Map(translations, func(t *domain.NodeTranslation, _ int) string {
+		return t.NodeID
+	}), sourceNodeID))
+	if err != nil {
+		return nil, err
+	}
+	links := make([]*domain.NodeTranslationLink, 0, len(translations)+1)
+	if name, ok := names[sourceNodeID]; ok {
+		links = append(links, &domain.NodeTranslationLink{
+			Language: u.llmUsecase.GetLanguageSetting(ctx, kbID).Language,
+			NodeID:   sourceNodeID,
+			Name:     name,
+			Source:   true,
+		})
+	}
+	for _, t := range translations {
+		if name, ok := names[t.NodeID]; ok {
+			links = append(links, &domain.NodeTranslationLink{
+				Language: t.Language,
+				NodeID:   t.NodeID,
+				Name:     name,
+			})
+		}
+	}
+	if len(links) < 2 {
+		return nil, nil
+	}
+	return links, nil
+}
+
 func (u *NodeUsecase) MoveNode(ctx context.Context, req *domain.MoveNodeReq) error {
 	return u.nodeRepo.MoveNodeBetween(ctx, req.ID, req.ParentID, req.PrevID, req.NextID, req.KbID)
 }
@@ -271,7 +331,7 @@ func (u *NodeUsecase) SummaryNode(ctx context.Context, req *domain.NodeSummaryRe
 		if err != nil {
 			return "", fmt.Errorf("get latest node release failed: %w", err)
 		}
-		summary, err := u.llmUsecase.SummaryNode(ctx, model, node.Name, node.Content)
+		summary, err := u.llmUsecase.SummaryNode(ctx, model, req.KBID, node.Name, node.Content)
 		if err != nil {
 			return "", fmt.Errorf("summary node failed: %w", err)
 		}
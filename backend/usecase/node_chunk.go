@@ -0,0 +1,274 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/repo/pg"
+	"github.com/chaitin/panda-wiki/store/rag"
+)
+
+// NodeChunkUsecase inspects and edits the rag chunks of published nodes
+type NodeChunkUsecase struct {
+	nodeRepo *pg.NodeRepository
+	kbRepo   *pg.KnowledgeBaseRepository
+	editRepo *pg.NodeChunkEditRepository
+	rag      rag.RAGService
+	logger   *log.Logger
+}
+
+func NewNodeChunkUsecase(nodeRepo *pg.NodeRepository, kbRepo *pg.KnowledgeBaseRepository, editRepo *pg.NodeChunkEditRepository,
+	rag rag.RAGService, logger *log.Logger) *NodeChunkUsecase {
+	return &NodeChunkUsecase{
+		nodeRepo: nodeRepo,
+		kbRepo:   kbRepo,
+		editRepo: editRepo,
+		rag:      rag,
+		logger:   logger.WithModule("usecase.node_chunk"),
+	}
+}
+
+// getIndexedDoc returns the dataset and rag document of the latest indexed release of the node
+func (u *NodeChunkUsecase) getIndexedDoc(ctx context.Context, kbID, nodeID string) (string, *domain.NodeRelease, error) {
+	kb, err := u.kbRepo.GetKnowledgeBaseByID(ctx, kbID)
+	if err != nil {
+		return "", nil, err
+	}
+	nodeRelease, err := u.nodeRepo.GetLatestIndexedNodeRelease(ctx, kbID, nodeID)
+	if err != nil {
+		return "", nil, fmt.Errorf("node is not indexed: %w", err)
+	}
+	return kb.DatasetID, nodeRelease, nil
+}
+
+func (u *NodeChunkUsecase) GetList(ctx context.Context, req *domain.NodeChunkListReq) (*domain.NodeChunkListResp, error) {
+	datasetID, nodeRelease, err := u.getIndexedDoc(ctx, req.KBID, req.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	chunks, err := u.rag.ListChunks(ctx, datasetID, nodeRelease.DocID)
+	if err != nil {
+		return nil, err
+	}
+	edits, err := u.editRepo.GetByNodeID(ctx, req.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	chunkEdits := make(map[string]*domain.NodeChunkEdit)
+	staleEdits := make([]*domain.NodeChunkEdit, 0)
+	for _, edit := range edits {
+		if edit.Status == domain.NodeChunkEditStatusStale {
+			staleEdits = append(staleEdits, edit)
+			continue
+		}
+		if edit.DocID == nodeRelease.DocID {
+			chunkEdits[edit.ChunkID] = edit
+		}
+	}
+	return &domain.NodeChunkListResp{
+		NodeReleaseID: nodeRelease.ID,
+		DocID:         nodeRelease.DocID,
+		Chunks: lo.Map(chunks, func(chunk *domain.RAGChunk, _ int) *domain.NodeChunkItem {
+			return &domain.NodeChunkItem{RAGChunk: chunk, Edit: chunkEdits[chunk.ID]}
+		}),
+		StaleEdits: staleEdits,
+	}, nil
+}
+
+// Update edits a chunk of the node, the edit is kept for the section the chunk was generated from
+func (u *NodeChunkUsecase) Update(ctx context.Context, req *domain.UpdateNodeChunkReq) error {
+	datasetID, nodeRelease, err := u.getIndexedDoc(ctx, req.KBID, req.NodeID)
+	if err != nil {
+		return err
+	}
+	chunks, err := u.rag.ListChunks(ctx, datasetID, nodeRelease.DocID)
+	if err != nil {
+		return err
+	}
+	chunk, ok := lo.Find(chunks, func(c *domain.RAGChunk) bool { return c.ID == req.ChunkID })
+	if !ok {
+		return fmt.Errorf("chunk %s not found", req.ChunkID)
+	}
+	edits, err := u.editRepo.GetByNodeID(ctx, req.NodeID)
+	if err != nil {
+		return err
+	}
+	edit, exists := lo.Find(edits, func(e *domain.NodeChunkEdit) bool {
+		return e.DocID == nodeRelease.DocID && e.ChunkID == req.ChunkID && e.Status == domain.NodeChunkEditStatusApplied
+	})
+	if !exists {
+		edit = &domain.NodeChunkEdit{
+			ID:            uuid.New().String(),
+			KBID:          req.KBID,
+			NodeID:        req.NodeID,
+			Type:          domain.NodeChunkEditTypeUpdate,
+			SourceHash:    domain.NodeChunkHash(chunk.Content),
+			SourceContent: chunk.Content,
+			Content:       chunk.Content,
+			Keywords:      chunk.Keywords,
+			Questions:     chunk.Questions,
+			Available:     chunk.Available,
+			Status:        domain.NodeChunkEditStatusApplied,
+			DocID:         nodeRelease.DocID,
+			ChunkID:       chunk.ID,
+			CreatedAt:     time.Now(),
+		}
+	}
+	if req.Content != nil {
+		if strings.TrimSpace(*req.Content) == "" {
+			return fmt.Errorf("chunk content is required")
+		}
+		edit.Content = *req.Content
+	}
+	if req.Keywords != nil {
+		edit.Keywords = *req.Keywords
+	}
+	if req.Available != nil {
+		edit.Available = *req.Available
+	}
+	edit.UpdatedAt = time.Now()
+
+	if err := u.rag.UpdateChunk(ctx, datasetID, nodeRelease.DocID, chunk.ID, &domain.UpdateRAGChunkReq{
+		Content:   edit.Content,
+		Keywords:  edit.Keywords,
+		Available: &edit.Available,
+	}); err != nil {
+		return err
+	}
+	if exists {
+		return u.editRepo.Update(ctx, edit)
+	}
+	return u.editRepo.Create(ctx, edit)
+}
+
+// Add adds a manual chunk to the node, it is added again after every publish
+func (u *NodeChunkUsecase) Add(ctx context.Context, req *domain.AddNodeChunkReq) (*domain.NodeChunkEdit, error) {
+	datasetID, nodeRelease, err := u.getIndexedDoc(ctx, req.KBID, req.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	chunk, err := u.rag.AddChunk(ctx, datasetID, nodeRelease.DocID, &domain.RAGChunk{
+		Content:   req.Content,
+		Keywords:  req.Keywords,
+		Questions: req.Questions,
+	})
+	if err != nil {
+		return nil, err
+	}
+	edit := &domain.NodeChunkEdit{
+		ID:        uuid.New().String(),
+		KBID:      req.KBID,
+		NodeID:    req.NodeID,
+		Type:      domain.NodeChunkEditTypeAdd,
+		Content:   req.Content,
+		Keywords:  req.Keywords,
+		Questions: req.Questions,
+		Available: true,
+		Status:    domain.NodeChunkEditStatusApplied,
+		DocID:     nodeRelease.DocID,
+		ChunkID:   chunk.ID,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if err := u.editRepo.Create(ctx, edit); err != nil {
+		return nil, err
+	}
+	return edit, nil
+}
+
+// DeleteEdit drops an edit, manual chunks are removed right away, updated chunks are restored on the next publish
+func (u *NodeChunkUsecase) DeleteEdit(ctx context.Context, kbID, id string) error {
+	edit, err := u.editRepo.GetByID(ctx, kbID, id)
+	if err != nil {
+		return err
+	}
+	if edit.Type == domain.NodeChunkEditTypeAdd && edit.ChunkID != "" {
+		kb, err := u.kbRepo.GetKnowledgeBaseByID(ctx, kbID)
+		if err != nil {
+			return err
+		}
+		if err := u.rag.DeleteChunks(ctx, kb.DatasetID, edit.DocID, []string{edit.ChunkID}); err != nil {
+			u.logger.Warn("failed to delete manual chunk", log.String("chunk_id", edit.ChunkID), log.Error(err))
+		}
+	}
+	return u.editRepo.Delete(ctx, kbID, id)
+}
+
+// ApplyEdits re-applies the chunk edits of a node to a newly parsed rag document. updates follow their section
+// to the most similar new chunk and are only marked stale when no chunk is similar enough
+func (u *NodeChunkUsecase) ApplyEdits(ctx context.Context, docID string) error {
+	releases, err := u.nodeRepo.GetNodeReleasesByDocIDs(ctx, []string{docID})
+	if err != nil {
+		return err
+	}
+	nodeRelease, ok := releases[docID]
+	if !ok {
+		return fmt.Errorf("node release of doc %s not found", docID)
+	}
+	edits, err := u.editRepo.GetByNodeID(ctx, nodeRelease.NodeID)
+	if err != nil {
+		return err
+	}
+	edits = lo.Filter(edits, func(edit *domain.NodeChunkEdit, _ int) bool { return edit.DocID != docID })
+	if len(edits) == 0 {
+		return nil
+	}
+	kb, err := u.kbRepo.GetKnowledgeBaseByID(ctx, nodeRelease.KBID)
+	if err != nil {
+		return err
+	}
+	chunks, err := u.rag.ListChunks(ctx, kb.DatasetID, docID)
+	if err != nil {
+		return err
+	}
+	matched := domain.MatchNodeChunkEdits(edits, chunks)
+
+	for _, edit := range edits {
+		edit.DocID = docID
+		edit.ChunkID = ""
+		edit.Status = domain.NodeChunkEditStatusApplied
+		switch edit.Type {
+		case domain.NodeChunkEditTypeAdd:
+			chunk, err := u.rag.AddChunk(ctx, kb.DatasetID, docID, &domain.RAGChunk{
+				Content:   edit.Content,
+				Keywords:  edit.Keywords,
+				Questions: edit.Questions,
+			})
+			if err != nil {
+				u.logger.Error("failed to add manual chunk", log.String("edit_id", edit.ID), log.Error(err))
+				continue
+			}
+			edit.ChunkID = chunk.ID
+		case domain.NodeChunkEditTypeUpdate:
+			chunk, ok := matched[edit.ID]
+			if !ok {
+				edit.Status = domain.NodeChunkEditStatusStale
+				break
+			}
+			if err := u.rag.UpdateChunk(ctx, kb.DatasetID, docID, chunk.ID, &domain.UpdateRAGChunkReq{
+				Content:   edit.Content,
+				Keywords:  edit.Keywords,
+				Available: &edit.Available,
+			}); err != nil {
+				u.logger.Error("failed to update chunk", log.String("edit_id", edit.ID), log.Error(err))
+				continue
+			}
+			// the edit follows the section, the next publish is matched against the new chunk
+			edit.SourceHash = domain.NodeChunkHash(chunk.Content)
+			edit.SourceContent = chunk.Content
+			edit.ChunkID = chunk.ID
+		}
+		edit.UpdatedAt = time.Now()
+		if err := u.editRepo.Update(ctx, edit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,297 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/pkg/docimport"
+	"github.com/chaitin/panda-wiki/repo/mq"
+	"github.com/chaitin/panda-wiki/repo/pg"
+)
+
+// NodeImportUsecase imports uploaded files into nodes in the consumer, the links between the imported documents
+// are rewritten to the created nodes
+type NodeImportUsecase struct {
+	repo        *pg.NodeImportJobRepository
+	nodeRepo    *pg.NodeRepository
+	nodeUsecase *NodeUsecase
+	fileUsecase *FileUsecase
+	jobRepo     *mq.JobRepository
+	logger      *log.Logger
+}
+
+func NewNodeImportUsecase(repo *pg.NodeImportJobRepository, nodeRepo *pg.NodeRepository, nodeUsecase *NodeUsecase, fileUsecase *FileUsecase,
+	jobRepo *mq.JobRepository, logger *log.Logger) *NodeImportUsecase {
+	return &NodeImportUsecase{
+		repo:        repo,
+		nodeRepo:    nodeRepo,
+		nodeUsecase: nodeUsecase,
+		fileUsecase: fileUsecase,
+		jobRepo:     jobRepo,
+		logger:      logger.WithModule("usecase.node_import"),
+	}
+}
+
+// Create queues the import of an uploaded file, a file that is too large is rejected right away with
+// docimport.ErrLimitExceeded
+func (u *NodeImportUsecase) Create(ctx context.Context, req *domain.NodeImportReq, userID string) (*domain.NodeImportJob, error) {
+	if err := u.nodeUsecase.validateParentFolder(ctx, req.KBID, req.ParentID); err != nil {
+		return nil, err
+	}
+	if err := u.fileUsecase.CheckFile(ctx, req.KBID, req.Key, domain.MaxNodeImportFileSize); err != nil {
+		return nil, err
+	}
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+	job := &domain.NodeImportJob{
+		ID:         id.String(),
+		KBID:       req.KBID,
+		ParentID:   req.ParentID,
+		Source:     req.Source,
+		Key:        req.Key,
+		Name:       req.Name,
+		SplitLevel: req.SplitLevel,
+		Status:     domain.NodeImportJobStatusPending,
+		CreatorID:  userID,
+		MaxNode:    req.MaxNode,
+		CreatedAt:  time.Now(),
+	}
+	if err := u.repo.Create(ctx, job); err != nil {
+		return nil, err
+	}
+	// the job is in the database already, a lost task is published again by ResumeStale
+	if err := u.jobRepo.AsyncRunNodeImportJob(ctx, &domain.NodeImportTaskRequest{JobID: job.ID}); err != nil {
+		u.logger.Error("publish node import task failed", log.String("job_id", job.ID), log.Error(err))
+	}
+	return job, nil
+}
+
+func (u *NodeImportUsecase) GetList(ctx context.Context, req *domain.NodeImportJobListReq) (*domain.PaginatedResult[[]*domain.NodeImportJob], error) {
+	jobs, total, err := u.repo.GetList(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewPaginatedResult(jobs, uint64(total)), nil
+}
+
+func (u *NodeImportUsecase) GetDetail(ctx context.Context, req *domain.NodeImportJobDetailReq) (*domain.NodeImportJob, error) {
+	return u.repo.GetByID(ctx, req.KBID, req.ID)
+}
+
+// ResumeStale queues the jobs that were interrupted, e.g. by a restart of the consumer, or whose task was lost
+func (u *NodeImportUsecase) ResumeStale(ctx context.Context) error {
+	ids, err := u.repo.ResumeStale(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		u.logger.Info("resume node import job", log.String("job_id", id))
+		if err := u.jobRepo.AsyncRunNodeImportJob(ctx, &domain.NodeImportTaskRequest{JobID: id}); err != nil {
+			u.logger.Error("publish node import task failed", log.String("job_id", id), log.Error(err))
+		}
+	}
+	return nil
+}
+
+// Run executes a pending job, jobs already picked up are skipped so that redelivered tasks are harmless.
+// a job stopped by the shutdown of the consumer is left running and is resumed by ResumeStale, it imports
+// the whole file again and updates the documents written before
+func (u *NodeImportUsecase) Run(ctx context.Context, jobID string) error {
+	job, err := u.repo.GetByID(ctx, "", jobID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if job.Status != domain.NodeImportJobStatusPending {
+		u.logger.Info("node import job already picked up", log.String("job_id", jobID), log.String("status", string(job.Status)))
+		return nil
+	}
+	started, err := u.repo.Start(ctx, job.ID, time.Now())
+	if err != nil || !started {
+		return err
+	}
+
+	job.Status = domain.NodeImportJobStatusCompleted
+	if err := u.execute(ctx, job); err != nil {
+		if ctx.Err() != nil {
+			return err
+		}
+		job.Status = domain.NodeImportJobStatusFailed
+		job.Error = err.Error()
+	}
+	finishedAt := time.Now()
+	job.FinishedAt = &finishedAt
+	return u.repo.Finish(ctx, job)
+}
+
+func (u *NodeImportUsecase) execute(ctx context.Context, job *domain.NodeImportJob) error {
+	data, err := u.fileUsecase.DownloadFile(ctx, job.KBID, job.Key, domain.MaxNodeImportFileSize)
+	if err != nil {
+		return err
+	}
+	result, err := u.convert(job, data)
+	if err != nil {
+		return fmt.Errorf("parse %s file failed: %w", job.Source, err)
+	}
+	for _, doc := range result.Docs {
+		if doc.ExternalID == "" {
+			doc.ExternalID = job.DocExternalID(doc.Key)
+		}
+	}
+	job.Total = len(result.Docs)
+	resp, err := u.write(ctx, job, result)
+	if err != nil {
+		return err
+	}
+	job.Result = resp
+	return nil
+}
+
+func (u *NodeImportUsecase) convert(job *domain.NodeImportJob, data []byte) (*docimport.Result, error) {
+	switch job.Source {
+	case domain.NodeImportSourceMarkdown:
+		r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return nil, err
+		}
+		return docimport.ParseMarkdownZip(r)
+	case domain.NodeImportSourceDocx:
+		r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return nil, err
+		}
+		return docimport.ParseDocx(r, job.Name, job.SplitLevel)
+	case domain.NodeImportSourceOpenAPI:
+		return docimport.ParseOpenAPI(data, job.Name)
+	case domain.NodeImportSourceMediaWiki:
+		return docimport.ParseMediaWikiDump(bytes.NewReader(data), job.Name)
+	default:
+		return nil, fmt.Errorf("unsupported import source %s", job.Source)
+	}
+}
+
+// write creates the nodes of the documents first so that every document has an id,
+// then fills in their content with the links resolved to the nodes and the assets uploaded.
+// documents with an external id update the existing node instead. the progress is saved after each document
+func (u *NodeImportUsecase) write(ctx context.Context, job *domain.NodeImportJob, result *docimport.Result) (*domain.UpsertNodesResp, error) {
+	externalIDs := lo.FilterMap(result.Docs, func(doc *docimport.Doc, _ int) (string, bool) {
+		return doc.ExternalID, doc.ExternalID != ""
+	})
+	existing, err := u.nodeRepo.GetNodesByExternalIDs(ctx, job.KBID, externalIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	contentType := domain.ContentTypeMD
+	folders := make(map[string]string)
+	parentIDs := make(map[string]string, len(result.Docs))
+	nodeIDs := make(map[string]string, len(result.Docs))
+	results := make(map[string]*domain.UpsertNodeResult, len(result.Docs))
+	if err := u.repo.UpdateProgress(ctx, job.ID, job.Total, 0, time.Now()); err != nil {
+		return nil, err
+	}
+	for _, doc := range result.Docs {
+		res := &domain.UpsertNodeResult{ExternalID: doc.ExternalID, Path: doc.Key}
+		results[doc.Key] = res
+		parentID, err := u.nodeUsecase.ensureFolders(ctx, job.KBID, job.ParentID, doc.Folders, job.MaxNode, folders, job.CreatorID)
+		if err != nil {
+			setUpsertFailed(res, err)
+			continue
+		}
+		parentIDs[doc.Key] = parentID
+		if node, ok := existing[doc.ExternalID]; ok && doc.ExternalID != "" {
+			if node.Type != domain.NodeTypeDocument {
+				setUpsertFailed(res, fmt.Errorf("external_id %s belongs to a folder", doc.ExternalID))
+				continue
+			}
+			nodeIDs[doc.Key] = node.ID
+			continue
+		}
+		nodeID, err := u.nodeRepo.Create(ctx, &domain.CreateNodeReq{
+			KBID:        job.KBID,
+			ParentID:    parentID,
+			Type:        domain.NodeTypeDocument,
+			Name:        doc.Name,
+			ContentType: &contentType,
+			MaxNode:     job.MaxNode,
+			ExternalID:  doc.ExternalID,
+		}, job.CreatorID)
+		if err != nil {
+			setUpsertFailed(res, err)
+			continue
+		}
+		nodeIDs[doc.Key] = nodeID
+		res.Action = domain.UpsertNodeActionCreated
+	}
+
+	assetURLs := make(map[string]string)
+	docURL := func(key string) (string, bool) {
+		nodeID, ok := nodeIDs[key]
+		return "/node/" + nodeID, ok
+	}
+	assetURL := func(key string) (string, bool) {
+		if url, ok := assetURLs[key]; ok {
+			return url, true
+		}
+		data, ok := result.Assets[key]
+		if !ok {
+			return "", false
+		}
+		uploaded, err := u.fileUsecase.UploadFileFromBytes(ctx, job.KBID, path.Base(key), data)
+		if err != nil {
+			u.logger.Warn("upload imported asset failed", log.String("key", key), log.Error(err))
+			return "", false
+		}
+		assetURLs[key] = "/" + domain.Bucket + "/" + uploaded
+		return assetURLs[key], true
+	}
+
+	resp := &domain.UpsertNodesResp{Results: make([]*domain.UpsertNodeResult, 0, len(result.Docs))}
+	for _, doc := range result.Docs {
+		if err := u.repo.UpdateProgress(ctx, job.ID, job.Total, job.Done, time.Now()); err != nil {
+			return nil, err
+		}
+		job.Done++
+		res := results[doc.Key]
+		nodeID, ok := nodeIDs[doc.Key]
+		if !ok {
+			resp.Add(res)
+			continue
+		}
+		res.ID = nodeID
+		content := docimport.ResolveRefs(doc.Content, docURL, assetURL)
+		if res.Action == domain.UpsertNodeActionCreated {
+			if err := u.nodeRepo.UpdateNodeContent(ctx, &domain.UpdateNodeReq{ID: nodeID, KBID: job.KBID, Content: &content}, job.CreatorID); err != nil {
+				setUpsertFailed(res, err)
+			}
+		} else {
+			action, err := u.nodeUsecase.updateExternalNode(ctx, existing[doc.ExternalID], parentIDs[doc.Key], &domain.UpsertNodeItem{
+				ExternalID:  doc.ExternalID,
+				Name:        doc.Name,
+				Content:     content,
+				ContentType: &contentType,
+			}, job.CreatorID)
+			res.Action = action
+			if err != nil {
+				setUpsertFailed(res, err)
+			}
+		}
+		resp.Add(res)
+	}
+	return resp, nil
+}
@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samber/lo"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/repo/pg"
+)
+
+// NodeLinkUsecase answers which documents link to which, the index is kept up to date by the node repository
+type NodeLinkUsecase struct {
+	repo     *pg.NodeLinkRepository
+	nodeRepo *pg.NodeRepository
+	logger   *log.Logger
+}
+
+func NewNodeLinkUsecase(repo *pg.NodeLinkRepository, nodeRepo *pg.NodeRepository, logger *log.Logger) *NodeLinkUsecase {
+	return &NodeLinkUsecase{
+		repo:     repo,
+		nodeRepo: nodeRepo,
+		logger:   logger.WithModule("usecase.node_link"),
+	}
+}
+
+// GetLinks returns the documents linking to the node and the links of the node
+func (u *NodeLinkUsecase) GetLinks(ctx context.Context, req *domain.NodeLinksReq) (*domain.NodeLinksResp, error) {
+	node, err := u.nodeRepo.GetNodeByID(ctx, req.ID)
+	if err != nil || node.KBID != req.KBID {
+		return nil, fmt.Errorf("node %s not found", req.ID)
+	}
+	backlinks, err := u.repo.GetBacklinks(ctx, req.KBID, []string{node.ID}, []string{node.ID})
+	if err != nil {
+		return nil, err
+	}
+	outgoing, err := u.repo.GetOutgoing(ctx, req.KBID, node.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.NodeLinksResp{Backlinks: backlinks, Outgoing: outgoing}, nil
+}
+
+// GetGraph returns the documents of the kb with the links between them and the number of their backlinks
+func (u *NodeLinkUsecase) GetGraph(ctx context.Context, kbID string) (*domain.NodeLinkGraph, error) {
+	graph, err := u.repo.GetGraph(ctx, kbID)
+	if err != nil {
+		return nil, err
+	}
+	sources := make(map[string]map[string]bool)
+	for _, edge := range graph.Edges {
+		if sources[edge.Target] == nil {
+			sources[edge.Target] = make(map[string]bool)
+		}
+		sources[edge.Target][edge.Source] = true
+	}
+	for _, node := range graph.Nodes {
+		node.Backlinks = len(sources[node.ID])
+	}
+	return graph, nil
+}
+
+// CheckDelete returns the documents outside of the deleted nodes and their children that link to them
+func (u *NodeLinkUsecase) CheckDelete(ctx context.Context, kbID string, ids []string) ([]*domain.NodeBacklink, error) {
+	deleted := lo.Uniq(lo.FlatMap(ids, func(id string, _ int) []string {
+		return u.nodeRepo.GetSubtreeNodeIDs(ctx, kbID, id)
+	}))
+	return u.repo.GetBacklinks(ctx, kbID, deleted, deleted)
+}
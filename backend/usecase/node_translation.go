@@ -0,0 +1,278 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	modelkit "github.com/chaitin/ModelKit/v2/usecase"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/repo/mq"
+	"github.com/chaitin/panda-wiki/repo/pg"
+)
+
+// translate about this many characters per request so that the answer fits the output limit of the model
+const translationPartMaxRunes = 3000
+
+// NodeTranslationUsecase creates machine translated variants of nodes with the chat model
+type NodeTranslationUsecase struct {
+	repo         *pg.NodeTranslationRepository
+	nodeRepo     *pg.NodeRepository
+	jobRepo      *mq.JobRepository
+	llmUsecase   *LLMUsecase
+	modelUsecase *ModelUsecase
+	logger       *log.Logger
+	modelkit     *modelkit.ModelKit
+}
+
+func NewNodeTranslationUsecase(repo *pg.NodeTranslationRepository, nodeRepo *pg.NodeRepository, jobRepo *mq.JobRepository,
+	llmUsecase *LLMUsecase, modelUsecase *ModelUsecase, logger *log.Logger) *NodeTranslationUsecase {
+	return &NodeTranslationUsecase{
+		repo:         repo,
+		nodeRepo:     nodeRepo,
+		jobRepo:      jobRepo,
+		llmUsecase:   llmUsecase,
+		modelUsecase: modelUsecase,
+		logger:       logger.WithModule("usecase.node_translation"),
+		modelkit:     modelkit.NewModelKit(logger.Logger),
+	}
+}
+
+// Translate queues the translation of the node, and of its descendants if recursive, into the language
+func (u *NodeTranslationUsecase) Translate(ctx context.Context, req *domain.TranslateNodeReq, userID string, maxNode int) ([]*domain.NodeTranslation, error) {
+	if err := (&domain.LanguageSetting{Language: req.Language}).Validate(); err != nil {
+		return nil, err
+	}
+	if req.Language == u.llmUsecase.GetLanguageSetting(ctx, req.KBID).Language {
+		return nil, fmt.Errorf("node is already in %s", domain.LanguageName(req.Language))
+	}
+	variant, err := u.repo.GetByNodeID(ctx, req.KBID, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if variant != nil {
+		return nil, fmt.Errorf("node is a translation, translate its source instead")
+	}
+
+	nodeIDs := []string{req.ID}
+	if req.Recursive {
+		nodeIDs = u.nodeRepo.GetSubtreeNodeIDs(ctx, req.KBID, req.ID)
+	}
+	translations := make([]*domain.NodeTranslation, 0, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		// variants inside the subtree are not translated again
+		if variant, err := u.repo.GetByNodeID(ctx, req.KBID, nodeID); err != nil {
+			return nil, err
+		} else if variant != nil {
+			continue
+		}
+		translation, err := u.repo.GetBySource(ctx, nodeID, req.Language)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case translation == nil:
+			translation = &domain.NodeTranslation{
+				ID:           uuid.New().String(),
+				KBID:         req.KBID,
+				SourceNodeID: nodeID,
+				Language:     req.Language,
+				Status:       domain.NodeTranslationStatusPending,
+				CreatedAt:    time.Now(),
+				UpdatedAt:    time.Now(),
+			}
+			if err := u.repo.Create(ctx, translation); err != nil {
+				return nil, err
+			}
+		case translation.Retryable(time.Now()):
+			if err := u.repo.Reset(ctx, translation.ID); err != nil {
+				return nil, err
+			}
+			translation.Status = domain.NodeTranslationStatusPending
+			translation.Error = ""
+		}
+		translations = append(translations, translation)
+	}
+	if len(translations) == 0 {
+		return translations, nil
+	}
+
+	if err := u.jobRepo.AsyncTranslateNodes(ctx, &domain.NodeTranslateTaskRequest{
+		KBID: req.KBID,
+		TranslationIDs: func() []string {
+			ids := make([]string, len(translations))
+			for i, translation := range translations {
+				ids[i] = translation.ID
+			}
+			return ids
+		}(),
+		UserID:  userID,
+		MaxNode: maxNode,
+	}); err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
+
+// GetList returns the translations of the node, or of its source if the node is a variant
+func (u *NodeTranslationUsecase) GetList(ctx context.Context, req *domain.NodeTranslationListReq) (*domain.NodeTranslationListResp, error) {
+	sourceNodeID := req.NodeID
+	variant, err := u.repo.GetByNodeID(ctx, req.KBID, req.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	if variant != nil {
+		sourceNodeID = variant.SourceNodeID
+	}
+	source, err := u.nodeRepo.GetNodeByID(ctx, sourceNodeID)
+	if err != nil {
+		return nil, err
+	}
+	translations, err := u.repo.GetListBySourceNodeID(ctx, req.KBID, sourceNodeID)
+	if err != nil {
+		return nil, err
+	}
+	for _, translation := range translations {
+		translation.CheckStale(source)
+	}
+	return &domain.NodeTranslationListResp{
+		SourceNodeID: sourceNodeID,
+		Translations: translations,
+	}, nil
+}
+
+// Run translates the queued nodes in order, translations already picked up are skipped
+func (u *NodeTranslationUsecase) Run(ctx context.Context, task *domain.NodeTranslateTaskRequest) error {
+	chatModelInfo, err := u.modelUsecase.GetChatModel(ctx)
+	if err != nil {
+		return fmt.Errorf("get chat model failed: %w", err)
+	}
+	modelkitModel, err := chatModelInfo.ToModelkitModel()
+	if err != nil {
+		return err
+	}
+	chatModel, err := u.modelkit.GetChatModel(ctx, modelkitModel)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range task.TranslationIDs {
+		started, err := u.repo.Start(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !started {
+			u.logger.Info("node translation already picked up", log.String("translation_id", id))
+			continue
+		}
+		translation, err := u.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		translation.Status = domain.NodeTranslationStatusCompleted
+		if err := u.translate(ctx, chatModel, task, translation); err != nil {
+			u.logger.Error("translate node failed", log.String("node_id", translation.SourceNodeID), log.Error(err))
+			translation.Status = domain.NodeTranslationStatusFailed
+			translation.Error = err.Error()
+		}
+		translation.UpdatedAt = time.Now()
+		if err := u.repo.Finish(ctx, translation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (u *NodeTranslationUsecase) translate(ctx context.Context, chatModel model.BaseChatModel, task *domain.NodeTranslateTaskRequest, translation *domain.NodeTranslation) error {
+	source, err := u.nodeRepo.GetNodeByID(ctx, translation.SourceNodeID)
+	if err != nil {
+		return fmt.Errorf("get source node failed: %w", err)
+	}
+	name, err := u.translateText(ctx, chatModel, translation.Language, source.Name)
+	if err != nil {
+		return err
+	}
+	content := ""
+	if source.Type == domain.NodeTypeDocument && strings.TrimSpace(source.Content) != "" {
+		parts := domain.SplitForTranslation(source.Content, translationPartMaxRunes)
+		translated := make([]string, 0, len(parts))
+		for _, part := range parts {
+			if strings.TrimSpace(part) == "" {
+				translated = append(translated, part)
+				continue
+			}
+			text, err := u.translateText(ctx, chatModel, translation.Language, part)
+			if err != nil {
+				return err
+			}
+			translated = append(translated, text)
+		}
+		content = strings.Join(translated, "\n")
+	}
+
+	// update the existing variant, it may have been deleted in the meantime
+	if translation.NodeID != "" {
+		if _, err := u.nodeRepo.GetNodeByID(ctx, translation.NodeID); err == nil {
+			if err := u.nodeRepo.UpdateNodeContent(ctx, &domain.UpdateNodeReq{
+				ID:      translation.NodeID,
+				KBID:    translation.KBID,
+				Name:    &name,
+				Content: &content,
+			}, task.UserID); err != nil {
+				return fmt.Errorf("update translated node failed: %w", err)
+			}
+			translation.SourceHash = domain.NodeTranslationHash(source.Name, source.Content)
+			return nil
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+	}
+
+	// variants of children are placed under the variant of their parent
+	parentID := source.ParentID
+	if parentID != "" {
+		parent, err := u.repo.GetBySource(ctx, parentID, translation.Language)
+		if err != nil {
+			return err
+		}
+		if parent != nil && parent.NodeID != "" {
+			parentID = parent.NodeID
+		}
+	}
+	contentType := source.Meta.ContentType
+	nodeID, err := u.nodeRepo.Create(ctx, &domain.CreateNodeReq{
+		KBID:        translation.KBID,
+		ParentID:    parentID,
+		Type:        source.Type,
+		Name:        name,
+		Content:     content,
+		Emoji:       source.Meta.Emoji,
+		ContentType: &contentType,
+		MaxNode:     task.MaxNode,
+	}, task.UserID)
+	if err != nil {
+		return fmt.Errorf("create translated node failed: %w", err)
+	}
+	translation.NodeID = nodeID
+	translation.SourceHash = domain.NodeTranslationHash(source.Name, source.Content)
+	return nil
+}
+
+func (u *NodeTranslationUsecase) translateText(ctx context.Context, chatModel model.BaseChatModel, language, text string) (string, error) {
+	result, err := u.llmUsecase.Generate(ctx, chatModel, []*schema.Message{
+		schema.SystemMessage(fmt.Sprintf(domain.NodeTranslationPrompt, domain.LanguageName(language))),
+		schema.UserMessage(text),
+	})
+	if err != nil {
+		return "", fmt.Errorf("translate failed: %w", err)
+	}
+	return strings.TrimSpace(u.llmUsecase.trimThinking(result)), nil
+}
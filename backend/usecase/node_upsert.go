@@ -0,0 +1,197 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+)
+
+// Upsert creates or updates the documents of the batch by external id, building the folders of their paths.
+// items are applied one by one and a failed item does not stop the batch
+func (u *NodeUsecase) Upsert(ctx context.Context, req *domain.UpsertNodesReq, userID string) (*domain.UpsertNodesResp, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if err := u.validateParentFolder(ctx, req.KBID, req.ParentID); err != nil {
+		return nil, err
+	}
+	existing, err := u.nodeRepo.GetNodesByExternalIDs(ctx, req.KBID, lo.Map(req.Items, func(item *domain.UpsertNodeItem, _ int) string {
+		return item.ExternalID
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &domain.UpsertNodesResp{Results: make([]*domain.UpsertNodeResult, 0, len(req.Items))}
+	folders := make(map[string]string)
+	for _, item := range req.Items {
+		result := &domain.UpsertNodeResult{ExternalID: item.ExternalID}
+		nodeID, action, err := u.upsertNode(ctx, req, item, existing[item.ExternalID], folders, userID)
+		result.ID = nodeID
+		result.Action = action
+		if err != nil {
+			u.logger.Warn("upsert node failed", log.String("kb_id", req.KBID), log.String("external_id", item.ExternalID), log.Error(err))
+			setUpsertFailed(result, err)
+		}
+		resp.Add(result)
+	}
+
+	if req.DeleteMissing {
+		if err := u.deleteMissingNodes(ctx, req, resp); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+func (u *NodeUsecase) upsertNode(ctx context.Context, req *domain.UpsertNodesReq, item *domain.UpsertNodeItem, node *domain.Node,
+	folders map[string]string, userID string) (string, domain.UpsertNodeAction, error) {
+	parentID, err := u.ensureFolders(ctx, req.KBID, req.ParentID, domain.SplitNodePath(item.Path), req.MaxNode, folders, userID)
+	if err != nil {
+		return "", domain.UpsertNodeActionFailed, err
+	}
+
+	if node == nil {
+		nodeID, err := u.nodeRepo.Create(ctx, &domain.CreateNodeReq{
+			KBID:        req.KBID,
+			ParentID:    parentID,
+			Type:        domain.NodeTypeDocument,
+			Name:        item.Name,
+			Content:     item.Content,
+			Emoji:       lo.FromPtr(item.Emoji),
+			Summary:     item.Summary,
+			ContentType: item.ContentType,
+			MaxNode:     req.MaxNode,
+			ExternalID:  item.ExternalID,
+		}, userID)
+		if err != nil {
+			return "", domain.UpsertNodeActionFailed, err
+		}
+		return nodeID, domain.UpsertNodeActionCreated, nil
+	}
+	if node.Type != domain.NodeTypeDocument {
+		return node.ID, domain.UpsertNodeActionFailed, fmt.Errorf("external_id %s belongs to a folder", item.ExternalID)
+	}
+	action, err := u.updateExternalNode(ctx, node, parentID, item, userID)
+	return node.ID, action, err
+}
+
+// updateExternalNode moves the node to parentID and updates it with the item, only the fields that changed are written
+func (u *NodeUsecase) updateExternalNode(ctx context.Context, node *domain.Node, parentID string, item *domain.UpsertNodeItem, userID string) (domain.UpsertNodeAction, error) {
+	changed := false
+	if node.ParentID != parentID {
+		if err := u.nodeRepo.MoveNodeToParent(ctx, node.KBID, node.ID, parentID); err != nil {
+			return domain.UpsertNodeActionFailed, err
+		}
+		changed = true
+	}
+	if node.Name != item.Name || node.Content != item.Content ||
+		item.Emoji != nil && *item.Emoji != node.Meta.Emoji ||
+		item.Summary != nil && *item.Summary != node.Meta.Summary ||
+		item.ContentType != nil && *item.ContentType != "" && node.Meta.ContentType == "" {
+		if err := u.nodeRepo.UpdateNodeContent(ctx, &domain.UpdateNodeReq{
+			ID:          node.ID,
+			KBID:        node.KBID,
+			Name:        &item.Name,
+			Content:     &item.Content,
+			Emoji:       item.Emoji,
+			Summary:     item.Summary,
+			ContentType: item.ContentType,
+		}, userID); err != nil {
+			return domain.UpsertNodeActionFailed, err
+		}
+		changed = true
+	}
+	if !changed {
+		return domain.UpsertNodeActionUnchanged, nil
+	}
+	return domain.UpsertNodeActionUpdated, nil
+}
+
+func (u *NodeUsecase) validateParentFolder(ctx context.Context, kbID, parentID string) error {
+	if parentID == "" {
+		return nil
+	}
+	parent, err := u.nodeRepo.GetNodeByID(ctx, parentID)
+	if err != nil || parent.KBID != kbID || parent.Type != domain.NodeTypeFolder {
+		return fmt.Errorf("parent folder %s not found", parentID)
+	}
+	return nil
+}
+
+// ensureFolders returns the folder at the path of names under rootID, reusing folders with the same name and creating the missing ones.
+// folders caches the folders found by path
+func (u *NodeUsecase) ensureFolders(ctx context.Context, kbID, rootID string, names []string, maxNode int, folders map[string]string, userID string) (string, error) {
+	parentID := rootID
+	for i, name := range names {
+		key := strings.Join(names[:i+1], "/")
+		if folderID, ok := folders[key]; ok {
+			parentID = folderID
+			continue
+		}
+		folder, err := u.nodeRepo.GetFolderByName(ctx, kbID, parentID, name)
+		switch {
+		case err == nil:
+			parentID = folder.ID
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			if parentID, err = u.nodeRepo.Create(ctx, &domain.CreateNodeReq{
+				KBID:     kbID,
+				ParentID: parentID,
+				Type:     domain.NodeTypeFolder,
+				Name:     name,
+				MaxNode:  maxNode,
+			}, userID); err != nil {
+				return "", fmt.Errorf("create folder %s failed: %w", key, err)
+			}
+		default:
+			return "", err
+		}
+		folders[key] = parentID
+	}
+	return parentID, nil
+}
+
+// deleteMissingNodes deletes the documents with an external id under req.DeletePrefix that are not in the batch
+func (u *NodeUsecase) deleteMissingNodes(ctx context.Context, req *domain.UpsertNodesReq, resp *domain.UpsertNodesResp) error {
+	nodes, err := u.nodeRepo.GetExternalDocuments(ctx, req.KBID, req.DeletePrefix)
+	if err != nil {
+		return err
+	}
+	inBatch := lo.SliceToMap(req.Items, func(item *domain.UpsertNodeItem) (string, struct{}) {
+		return item.ExternalID, struct{}{}
+	})
+	missing := lo.Filter(nodes, func(node *domain.Node, _ int) bool {
+		_, ok := inBatch[node.ExternalID]
+		return !ok
+	})
+	if len(missing) == 0 {
+		return nil
+	}
+	if err := u.NodeAction(ctx, &domain.NodeActionReq{
+		KBID:   req.KBID,
+		IDs:    lo.Map(missing, func(node *domain.Node, _ int) string { return node.ID }),
+		Action: "delete",
+	}); err != nil {
+		return fmt.Errorf("delete missing nodes failed: %w", err)
+	}
+	for _, node := range missing {
+		resp.Add(&domain.UpsertNodeResult{ExternalID: node.ExternalID, ID: node.ID, Action: domain.UpsertNodeActionDeleted})
+	}
+	return nil
+}
+
+func setUpsertFailed(result *domain.UpsertNodeResult, err error) {
+	result.Action = domain.UpsertNodeActionFailed
+	if errors.Is(err, domain.ErrMaxNodeLimitReached) {
+		result.Error = "已达到最大文档数量限制，请升级到更高版本"
+	} else {
+		result.Error = err.Error()
+	}
+}
@@ -36,4 +36,16 @@ var ProviderSet = wire.NewSet(
 	NewWecomUsecase,
 	NewWechatAppUsecase,
 	NewAuthUsecase,
+	NewCuratedAnswerUsecase,
+	NewImageUsecase,
+	NewEvalUsecase,
+	NewNodeChunkUsecase,
+	NewNodeTranslationUsecase,
+	NewSummaryJobUsecase,
+	NewGitSourceUsecase,
+	NewCrawlerSubscriptionUsecase,
+	NewImportJobUsecase,
+	NewLinkCheckUsecase,
+	NewNodeLinkUsecase,
+	NewNodeImportUsecase,
 )
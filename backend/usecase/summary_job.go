@@ -0,0 +1,274 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"golang.org/x/sync/errgroup"
+	"gorm.io/gorm"
+
+	"github.com/chaitin/panda-wiki/domain"
+	"github.com/chaitin/panda-wiki/log"
+	"github.com/chaitin/panda-wiki/repo/mq"
+	"github.com/chaitin/panda-wiki/repo/pg"
+)
+
+// SummaryJobUsecase generates the missing summaries of a kb in the background
+type SummaryJobUsecase struct {
+	repo         *pg.SummaryJobRepository
+	nodeRepo     *pg.NodeRepository
+	jobRepo      *mq.JobRepository
+	llmUsecase   *LLMUsecase
+	modelUsecase *ModelUsecase
+	logger       *log.Logger
+}
+
+func NewSummaryJobUsecase(repo *pg.SummaryJobRepository, nodeRepo *pg.NodeRepository, jobRepo *mq.JobRepository,
+	llmUsecase *LLMUsecase, modelUsecase *ModelUsecase, logger *log.Logger) *SummaryJobUsecase {
+	return &SummaryJobUsecase{
+		repo:         repo,
+		nodeRepo:     nodeRepo,
+		jobRepo:      jobRepo,
+		llmUsecase:   llmUsecase,
+		modelUsecase: modelUsecase,
+		logger:       logger.WithModule("usecase.summary_job"),
+	}
+}
+
+// Create queues a job for all documents of the kb without summary, only one job of a kb runs at a time
+func (u *SummaryJobUsecase) Create(ctx context.Context, req *domain.CreateSummaryJobReq) (*domain.SummaryJob, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	active, err := u.repo.GetActive(ctx, req.KBID)
+	if err != nil {
+		return nil, err
+	}
+	if active != nil {
+		return nil, fmt.Errorf("summary job %s of the kb is still %s", active.ID, active.Status)
+	}
+	nodes, err := u.nodeRepo.GetNodesWithoutSummary(ctx, req.KBID)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("all documents already have a summary")
+	}
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+	job := &domain.SummaryJob{
+		ID:          id.String(),
+		KBID:        req.KBID,
+		Status:      domain.SummaryJobStatusPending,
+		Concurrency: req.Concurrency,
+		CreatedAt:   time.Now(),
+	}
+	items := lo.Map(nodes, func(node *domain.Node, _ int) *domain.SummaryJobItem {
+		return &domain.SummaryJobItem{
+			ID:        uuid.New().String(),
+			JobID:     job.ID,
+			NodeID:    node.ID,
+			NodeName:  node.Name,
+			Status:    domain.SummaryJobStatusPending,
+			UpdatedAt: time.Now(),
+		}
+	})
+	if err := u.repo.Create(ctx, job, items); err != nil {
+		return nil, err
+	}
+	if err := u.publish(ctx, job.ID); err != nil {
+		return nil, err
+	}
+	job.Progress = domain.NewSummaryJobProgress(map[domain.SummaryJobStatus]int{domain.SummaryJobStatusPending: len(items)})
+	return job, nil
+}
+
+func (u *SummaryJobUsecase) GetList(ctx context.Context, req *domain.SummaryJobListReq) (*domain.PaginatedResult[[]*domain.SummaryJob], error) {
+	jobs, total, err := u.repo.GetList(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if len(jobs) > 0 {
+		progress, err := u.repo.GetProgress(ctx, lo.Map(jobs, func(job *domain.SummaryJob, _ int) string { return job.ID }))
+		if err != nil {
+			return nil, err
+		}
+		for _, job := range jobs {
+			job.Progress = progress[job.ID]
+		}
+	}
+	return domain.NewPaginatedResult(jobs, uint64(total)), nil
+}
+
+func (u *SummaryJobUsecase) GetDetail(ctx context.Context, req *domain.SummaryJobDetailReq) (*domain.SummaryJobDetailResp, error) {
+	job, err := u.repo.GetByID(ctx, req.KBID, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := u.repo.GetItems(ctx, job.ID)
+	if err != nil {
+		return nil, err
+	}
+	job.Progress = domain.NewSummaryJobProgress(lo.CountValuesBy(items, func(item *domain.SummaryJobItem) domain.SummaryJobStatus {
+		return item.Status
+	}))
+	return &domain.SummaryJobDetailResp{SummaryJob: job, Items: items}, nil
+}
+
+// Retry runs the failed items of a finished job again
+func (u *SummaryJobUsecase) Retry(ctx context.Context, req *domain.RetrySummaryJobReq) error {
+	if _, err := u.repo.GetByID(ctx, req.KBID, req.ID); err != nil {
+		return err
+	}
+	active, err := u.repo.GetActive(ctx, req.KBID)
+	if err != nil {
+		return err
+	}
+	if active != nil {
+		return fmt.Errorf("summary job %s of the kb is still %s", active.ID, active.Status)
+	}
+	retried, err := u.repo.Retry(ctx, req.KBID, req.ID)
+	if err != nil {
+		return err
+	}
+	if !retried {
+		return fmt.Errorf("summary job is not finished")
+	}
+	return u.publish(ctx, req.ID)
+}
+
+// publish queues the job in the consumer, the job is failed when the task cannot be published
+// so that it does not block the kb and can be retried
+func (u *SummaryJobUsecase) publish(ctx context.Context, jobID string) error {
+	err := u.jobRepo.AsyncRunSummaryJob(ctx, &domain.SummaryTaskRequest{JobID: jobID})
+	if err == nil {
+		return nil
+	}
+	err = fmt.Errorf("publish summary task failed: %w", err)
+	if _, failErr := u.repo.Fail(context.WithoutCancel(ctx), "", jobID, err.Error()); failErr != nil {
+		u.logger.Error("fail summary job failed", log.String("job_id", jobID), log.Error(failErr))
+	}
+	return err
+}
+
+// Cancel stops a pending or running job, the nodes being summarized finish and the others are marked failed
+// so that they can be retried later
+func (u *SummaryJobUsecase) Cancel(ctx context.Context, req *domain.CancelSummaryJobReq) error {
+	if _, err := u.repo.GetByID(ctx, req.KBID, req.ID); err != nil {
+		return err
+	}
+	canceled, err := u.repo.Fail(ctx, req.KBID, req.ID, "summary job was canceled")
+	if err != nil {
+		return err
+	}
+	if !canceled {
+		return fmt.Errorf("summary job is already finished")
+	}
+	return nil
+}
+
+// ResumeStale queues the jobs that were interrupted, e.g. by a restart of the consumer, or whose task was lost
+func (u *SummaryJobUsecase) ResumeStale(ctx context.Context) error {
+	ids, err := u.repo.ResumeStale(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		u.logger.Info("resume summary job", log.String("job_id", id))
+		if err := u.jobRepo.AsyncRunSummaryJob(ctx, &domain.SummaryTaskRequest{JobID: id}); err != nil {
+			u.logger.Error("publish summary task failed", log.String("job_id", id), log.Error(err))
+		}
+	}
+	return nil
+}
+
+// Run executes a pending job, jobs already picked up are skipped so that redelivered tasks are harmless.
+// a job stopped by the shutdown of the consumer is left running and is resumed by ResumeStale
+func (u *SummaryJobUsecase) Run(ctx context.Context, jobID string) error {
+	job, err := u.repo.GetByID(ctx, "", jobID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if job.Status != domain.SummaryJobStatusPending {
+		u.logger.Info("summary job already picked up", log.String("job_id", jobID), log.String("status", string(job.Status)))
+		return nil
+	}
+	started, err := u.repo.Start(ctx, job.ID, time.Now())
+	if err != nil || !started {
+		return err
+	}
+
+	job.Status = domain.SummaryJobStatusCompleted
+	if err := u.execute(ctx, job); err != nil {
+		if ctx.Err() != nil {
+			return err
+		}
+		job.Status = domain.SummaryJobStatusFailed
+		job.Error = err.Error()
+	}
+	finishedAt := time.Now()
+	job.FinishedAt = &finishedAt
+	return u.repo.Finish(ctx, job)
+}
+
+// execute summarizes the pending items with at most job.Concurrency requests at a time,
+// failed items are recorded on the item and do not fail the job, items failed by a cancel are skipped
+func (u *SummaryJobUsecase) execute(ctx context.Context, job *domain.SummaryJob) error {
+	model, err := u.modelUsecase.GetChatModel(ctx)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.ErrModelNotConfigured
+		}
+		return fmt.Errorf("get chat model failed: %w", err)
+	}
+	items, err := u.repo.GetItems(ctx, job.ID, domain.SummaryJobStatusPending)
+	if err != nil {
+		return err
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(max(job.Concurrency, 1))
+	for _, item := range items {
+		g.Go(func() error {
+			item.UpdatedAt = time.Now()
+			started, err := u.repo.StartItem(gctx, item)
+			if err != nil || !started {
+				return err
+			}
+			item.Status = domain.SummaryJobStatusCompleted
+			if err := u.summarize(gctx, job.KBID, item.NodeID, model); err != nil {
+				u.logger.Error("summary node failed", log.String("job_id", job.ID), log.String("node_id", item.NodeID), log.Error(err))
+				item.Status = domain.SummaryJobStatusFailed
+				item.Error = err.Error()
+			}
+			item.UpdatedAt = time.Now()
+			return u.repo.UpdateItem(gctx, item)
+		})
+	}
+	return g.Wait()
+}
+
+func (u *SummaryJobUsecase) summarize(ctx context.Context, kbID, nodeID string, model *domain.Model) error {
+	node, err := u.nodeRepo.GetNodeByID(ctx, nodeID)
+	if err != nil {
+		return fmt.Errorf("get node failed: %w", err)
+	}
+	// the summary may have been written by hand since the job was created
+	if node.Meta.Summary != "" {
+		return nil
+	}
+	summary, err := u.llmUsecase.SummaryNode(ctx, model, kbID, node.Name, node.Content)
+	if err != nil {
+		return err
+	}
+	return u.nodeRepo.UpdateNodeSummary(ctx, kbID, nodeID, summary)
+}
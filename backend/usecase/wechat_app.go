@@ -77,7 +77,7 @@ func (u *WechatAppUsecase) NewWechatConfig(ctx context.Context, appInfo *domain.
 }
 
 func (u *WechatAppUsecase) getQAFunc(kbID string, appType domain.AppType) bot.GetQAFun {
-	return func(ctx context.Context, msg string, info domain.ConversationInfo, ConversationID string) (chan string, error) {
+	return func(ctx context.Context, msg string, images []string, info domain.ConversationInfo, ConversationID string) (chan string, error) {
 		auth, err := u.authRepo.GetAuthBySourceType(ctx, domain.AppTypeWechatBot.ToSourceType())
 		if err != nil {
 			u.logger.Error("get auth failed", log.Error(err))
@@ -93,6 +93,7 @@ func (u *WechatAppUsecase) getQAFunc(kbID string, appType domain.AppType) bot.Ge
 
 		eventCh, err := u.chatUsecase.Chat(ctx, &domain.ChatRequest{
 			Message:        msg,
+			Images:         images,
 			KBID:           kbID,
 			AppType:        appType,
 			RemoteIP:       "",
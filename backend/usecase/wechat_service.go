@@ -65,7 +65,7 @@ func (u *WechatUsecase) NewWechatServiceConfig(ctx context.Context, appInfo *dom
 }
 
 func (u *WechatUsecase) getQAFunc(kbID string, appType domain.AppType) bot.GetQAFun {
-	return func(ctx context.Context, msg string, info domain.ConversationInfo, ConversationID string) (chan string, error) {
+	return func(ctx context.Context, msg string, images []string, info domain.ConversationInfo, ConversationID string) (chan string, error) {
 		auth, err := u.authRepo.GetAuthBySourceType(ctx, domain.AppTypeWechatServiceBot.ToSourceType())
 		if err != nil {
 			u.logger.Error("get auth failed", log.Error(err))
@@ -75,6 +75,7 @@ func (u *WechatUsecase) getQAFunc(kbID string, appType domain.AppType) bot.GetQA
 
 		eventCh, err := u.chatUsecase.Chat(ctx, &domain.ChatRequest{
 			Message:        msg,
+			Images:         images,
 			KBID:           kbID,
 			AppType:        appType,
 			RemoteIP:       "",
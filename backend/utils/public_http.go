@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// MaxPublicRedirects bounds the redirects followed by a public http client
+const MaxPublicRedirects = 5
+
+var ErrNonPublicAddress = errors.New("address is not public")
+
+// NewPublicHTTPClient returns a http client for urls taken from user input. it only connects to public
+// addresses, the check runs on the resolved ip of every connection so that dns names and redirects
+// pointing to private, loopback, link-local or metadata addresses are rejected as well
+func NewPublicHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if !IsPublicIP(host) {
+				return fmt.Errorf("%w: %s", ErrNonPublicAddress, host)
+			}
+			return nil
+		},
+	}
+	transport := &http.Transport{
+		// a proxy would be dialed instead of the target and bypass the check
+		Proxy:                 nil,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	}
+	return &http.Client{
+		Timeout:       timeout,
+		Transport:     transport,
+		CheckRedirect: CheckPublicRedirect,
+	}
+}
+
+// CheckPublicRedirect only follows http redirects up to MaxPublicRedirects hops, the target of each hop
+// is checked again by the dialer of the client
+func CheckPublicRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= MaxPublicRedirects {
+		return fmt.Errorf("stopped after %d redirects", MaxPublicRedirects)
+	}
+	if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+		return fmt.Errorf("redirect to unsupported scheme %s", req.URL.Scheme)
+	}
+	return nil
+}
+
+// IsPublicIP tells whether the ip can be reached by a public http client
+func IsPublicIP(ipStr string) bool {
+	ip := net.ParseIP(ipStr)
+	if ip == nil || ip.IsUnspecified() || ip.IsMulticast() || ip.IsInterfaceLocalMulticast() {
+		return false
+	}
+	// ipv4-mapped ipv6 addresses are checked as ipv4
+	if ip4 := ip.To4(); ip4 != nil {
+		ipStr = ip4.String()
+	}
+	return !IsPrivateOrReservedIP(ipStr)
+}
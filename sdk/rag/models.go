@@ -332,18 +332,6 @@ type RetrievalResponse struct {
 	} `json:"data"`
 }
 
-// RelatedQuestionsRequest 相关问题请求
-type RelatedQuestionsRequest struct {
-	Question string `json:"question"`
-}
-
-// RelatedQuestionsResponse 相关问题响应
-type RelatedQuestionsResponse struct {
-	Code    int      `json:"code"`
-	Data    []string `json:"data"`
-	Message string   `json:"message"`
-}
-
 // ModelConfig 模型配置
 type ModelConfig struct {
 	ID          string          `json:"id"`
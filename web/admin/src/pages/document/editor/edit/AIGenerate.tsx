@@ -6,6 +6,7 @@ import { useCallback, useEffect, useRef, useState } from 'react';
 
 interface AIGenerateProps {
   open: boolean;
+  kbId: string;
   selectText: string;
   onClose: () => void;
   editorRef: UseTiptapReturn;
@@ -13,6 +14,7 @@ interface AIGenerateProps {
 
 const AIGenerate = ({
   open,
+  kbId,
   selectText,
   onClose,
   editorRef,
@@ -35,6 +37,7 @@ const AIGenerate = ({
       setLoading(true);
       sseClientRef.current.subscribe(
         JSON.stringify({
+          kb_id: kbId,
           text: selectText,
           action: 'rephrase',
           stream: true,
@@ -48,7 +51,7 @@ const AIGenerate = ({
         },
       );
     }
-  }, [selectText, sseClientRef.current, readEditor]);
+  }, [kbId, selectText, sseClientRef.current, readEditor]);
 
   const onCancel = () => {
     sseClientRef.current?.unsubscribe();
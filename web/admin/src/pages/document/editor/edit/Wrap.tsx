@@ -172,6 +172,7 @@ const Wrap = ({ detail: defaultDetail }: WrapProps) => {
 
     const suggestion = await postApiV1CreationTabComplete(
       {
+        kb_id: defaultDetail.kb_id!,
         prefix: prefix.length > 300 ? prefix.slice(-300) : prefix,
         suffix: suffix.slice(0, 300),
       },
@@ -752,6 +753,7 @@ const Wrap = ({ detail: defaultDetail }: WrapProps) => {
       />
       <AIGenerate
         open={aiGenerateOpen}
+        kbId={defaultDetail.kb_id!}
         selectText={selectionText}
         onClose={() => setAiGenerateOpen(false)}
         editorRef={editorRef}
@@ -625,6 +625,7 @@ export interface DomainCommentReq {
 }
 
 export interface DomainCompleteReq {
+  kb_id: string;
   /** For FIM (Fill in Middle) style completion */
   prefix?: string;
   suffix?: string;
@@ -1294,6 +1295,7 @@ export interface DomainTextImgConfig {
 export interface DomainTextReq {
   /** action: improve, summary, extend, shorten, etc. */
   action?: string;
+  kb_id: string;
   text: string;
 }
 
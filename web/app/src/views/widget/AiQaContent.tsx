@@ -84,6 +84,7 @@ export interface ConversationItem {
   chunk_result: ChunkResultItem[];
   thinking_content: string;
   id: string;
+  suggestions?: string[];
 }
 
 dayjs.extend(relativeTime);
@@ -128,6 +129,7 @@ const AiQaContent: React.FC<{
     type: string;
     content: string;
     chunk_result: ChunkResultItem;
+    suggestions?: string[];
   }> | null>(null);
   const { palette } = useTheme();
   const messageIdRef = useRef('');
@@ -401,7 +403,7 @@ const AiQaContent: React.FC<{
     if (sseClientRef.current) {
       sseClientRef.current.subscribe(
         JSON.stringify(reqData),
-        ({ type, content, chunk_result }) => {
+        ({ type, content, chunk_result, suggestions }) => {
           if (type === 'conversation_id') {
             setConversationId(prev => prev + content);
           } else if (type === 'message_id') {
@@ -472,6 +474,16 @@ const AiQaContent: React.FC<{
 
               return newFullAnswer;
             });
+          } else if (type === 'suggestions') {
+            setConversation(preConversation => {
+              const newConversation = [...preConversation];
+              const lastConversation =
+                newConversation[newConversation.length - 1];
+              if (lastConversation) {
+                lastConversation.suggestions = suggestions || [];
+              }
+              return newConversation;
+            });
           } else if (type === 'chunk_result') {
             setConversation(preConversation => {
               const newConversation = [...preConversation];
@@ -938,6 +950,22 @@ const AiQaContent: React.FC<{
                     </Box>
                   </StyledActionStack>
                 )}
+
+                {/* 追问建议 */}
+                {index === conversation.length - 1 &&
+                  !loading &&
+                  !!item.suggestions?.length && (
+                    <Stack gap={0.5} sx={{ mt: 1 }}>
+                      {item.suggestions.map((suggestion, idx) => (
+                        <StyledHotSearchColumnItem
+                          key={idx}
+                          onClick={() => onSuggestionClick(suggestion)}
+                        >
+                          • {suggestion}
+                        </StyledHotSearchColumnItem>
+                      ))}
+                    </Stack>
+                  )}
               </StyledAiBubble>
             </StyledConversationItem>
           ))}